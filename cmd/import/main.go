@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"dixitme/internal/config"
+	"dixitme/internal/database"
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/deckimport"
+	"dixitme/internal/storage"
+)
+
+func main() {
+	// Define flags
+	var (
+		ttsFile         = flag.String("tts", "", "Tabletop Simulator save or saved object JSON to import")
+		folder          = flag.String("folder", "", "Folder of card images to import")
+		name            = flag.String("name", "", "Deck name (defaults to the TTS deck nickname or the folder name)")
+		active          = flag.Bool("active", false, "Make imported cards playable right away (needs -artist and -license)")
+		artist          = flag.String("artist", "", "Artist credited on every card of the deck")
+		sourceURL       = flag.String("source-url", "", "Where the deck's art was published")
+		license         = flag.String("license", "", "License the art is used under, e.g. CC BY 4.0")
+		allowDuplicates = flag.Bool("allow-duplicates", false, "Import cards whose image duplicates an existing card")
+		dryRun          = flag.Bool("dry-run", false, "Read and check every card without writing anything")
+		workers         = flag.Int("workers", 4, "Cards read and uploaded concurrently")
+		help            = flag.Bool("help", false, "Show help")
+	)
+	flag.Parse()
+
+	if *help || (*ttsFile == "") == (*folder == "") {
+		fmt.Println("DixitMe Deck Importer")
+		fmt.Println()
+		fmt.Println("Usage:")
+		fmt.Println("  go run cmd/import/main.go (-tts <file> | -folder <dir>) [options]")
+		fmt.Println()
+		fmt.Println("Options:")
+		fmt.Println("  -tts               Tabletop Simulator save or saved object JSON")
+		fmt.Println("  -folder            Folder of card images, with optional <image>.txt descriptions")
+		fmt.Println("  -name              Deck name for the placeholder tag")
+		fmt.Println("  -active            Make imported cards playable right away (needs -artist and -license)")
+		fmt.Println("  -artist            Artist credited on every card")
+		fmt.Println("  -source-url        Where the deck's art was published")
+		fmt.Println("  -license           License the art is used under")
+		fmt.Println("  -allow-duplicates  Import cards whose image duplicates an existing card")
+		fmt.Println("  -dry-run           Read and check every card without writing anything")
+		fmt.Println("  -workers           Cards read and uploaded concurrently (default 4)")
+		fmt.Println("  -help              Show this help message")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  go run cmd/import/main.go -tts MyDeck.json -dry-run")
+		fmt.Println("  go run cmd/import/main.go -folder ./decks/dreams -name \"Dreams\"")
+		fmt.Println("  go run cmd/import/main.go -folder ./decks/dreams -active -artist \"Jane Doe\" -license \"CC BY 4.0\"")
+		if !*help {
+			os.Exit(2)
+		}
+		return
+	}
+
+	// Load configuration
+	cfg := config.Load()
+
+	// Initialize logger
+	logger.InitLogger(cfg.Logger)
+	log := logger.GetLogger()
+
+	var (
+		deck *deckimport.Deck
+		err  error
+	)
+	if *ttsFile != "" {
+		deck, err = deckimport.ReadTTSDeck(*ttsFile)
+	} else {
+		deck, err = deckimport.ReadFolder(*folder)
+	}
+	if err != nil {
+		log.Error("Failed to read deck", "error", err)
+		os.Exit(1)
+	}
+	if *name != "" {
+		deck.Name = *name
+	}
+	log.Info("Deck read", "name", deck.Name, "cards", len(deck.Cards))
+
+	// Initialize database
+	database.Initialize(cfg.DatabaseURL)
+
+	// Card images always go to MinIO; only a dry run can do without it
+	var store deckimport.Store
+	if err := storage.Initialize(cfg.MinIO); err != nil {
+		if !*dryRun {
+			log.Error("MinIO is required to import cards", "error", err)
+			os.Exit(1)
+		}
+		log.Warn("MinIO not available", "error", err)
+	} else {
+		store = storage.GetClient()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	importer := deckimport.NewImporter(database.GetDB(), store, deckimport.Options{
+		Active:          *active,
+		AllowDuplicates: *allowDuplicates,
+		DryRun:          *dryRun,
+		Workers:         *workers,
+		Attribution: models.CardAttribution{
+			Artist:    *artist,
+			SourceURL: *sourceURL,
+			License:   *license,
+		},
+	})
+	result, err := importer.Import(ctx, deck)
+	if err != nil {
+		log.Error("Import failed", "error", err)
+		if result == nil {
+			os.Exit(1)
+		}
+	}
+
+	log.Info("Import finished",
+		"deck", deck.Name,
+		"tag", result.Tag,
+		"imported", result.Imported,
+		"duplicates", result.Duplicates,
+		"failed", result.Failed,
+		"dry_run", *dryRun)
+
+	fmt.Println()
+	if *dryRun {
+		fmt.Printf("✅ Dry run of %q finished, nothing was written\n", deck.Name)
+		fmt.Printf("   - Cards that would be imported: %d\n", result.Imported)
+	} else {
+		fmt.Printf("✅ Deck %q imported!\n", deck.Name)
+		fmt.Printf("   - Cards imported: %d\n", result.Imported)
+		fmt.Printf("   - Placeholder tag: %s\n", result.Tag)
+	}
+	fmt.Printf("   - Duplicates skipped: %d\n", result.Duplicates)
+	fmt.Printf("   - Failed: %d\n", result.Failed)
+	fmt.Println()
+
+	if err != nil || result.Failed > 0 {
+		os.Exit(1)
+	}
+}
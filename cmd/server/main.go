@@ -9,6 +9,15 @@
 // @host localhost:8080
 // @BasePath /api/v1
 // @schemes http https
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT as "Bearer <token>". Tokens issued from /auth/tokens carry only the scopes they were granted.
+// @securitydefinitions.oauth2.application Scopes
+// @tokenUrl /api/v1/auth/tokens
+// @scope.play Join games, play and chat
+// @scope.manage_cards Create and edit cards and tags
+// @scope.admin Admin endpoints; implies every other scope
 package main
 
 import (
@@ -2,17 +2,36 @@
 package app
 
 import (
+	"context"
+	"time"
+
+	"dixitme/internal/cache"
 	"dixitme/internal/config"
 	"dixitme/internal/database"
 	"dixitme/internal/logger"
 	"dixitme/internal/redis"
 	"dixitme/internal/seeder"
+	"dixitme/internal/services/activity"
 	"dixitme/internal/services/auth"
 	"dixitme/internal/services/bot"
+	"dixitme/internal/services/branding"
+	"dixitme/internal/services/cardart"
+	"dixitme/internal/services/cardimages"
+	"dixitme/internal/services/experiments"
 	"dixitme/internal/services/game"
+	"dixitme/internal/services/ladder"
+	"dixitme/internal/services/mail"
+	"dixitme/internal/services/namepolicy"
+	"dixitme/internal/services/notify"
+	"dixitme/internal/services/readmodel"
+	"dixitme/internal/services/statsexport"
+	"dixitme/internal/services/taxonomy"
+	"dixitme/internal/startup"
 	"dixitme/internal/storage"
 	"dixitme/internal/transport/handlers"
+	"dixitme/internal/transport/longpoll"
 	"dixitme/internal/transport/router"
+	"dixitme/internal/transport/versioning"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,10 +40,13 @@ import (
 type App struct {
 	Router  *gin.Engine
 	Config  *config.Config
-	Cleanup func() // Cleanup function for graceful shutdown
+	Startup *startup.Orchestrator // Component status and shutdown hooks
+	Cleanup func()                // Cleanup function for graceful shutdown
 }
 
-// NewApp creates and initializes a new application instance
+// NewApp creates and initializes a new application instance. The database
+// and Redis are required: if either never comes up NewApp fails. Without
+// MinIO or the stored settings the server runs degraded.
 func NewApp() (*App, error) {
 	// Load configuration
 	cfg := config.Load()
@@ -38,73 +60,252 @@ func NewApp() (*App, error) {
 	// Set Gin mode
 	gin.SetMode(cfg.GinMode)
 
+	ctx := context.Background()
+	orchestrator := startup.New(cfg.Startup)
+	fail := func(err error) (*App, error) {
+		orchestrator.Shutdown()
+		return nil, err
+	}
+
 	// Initialize database
-	database.Initialize(cfg.DatabaseURL)
+	if err := orchestrator.Start(ctx, startup.Component{
+		Name:     "database",
+		Required: true,
+		Start:    func(context.Context) error { return database.Connect(cfg.DatabaseURL) },
+		Stop:     database.Close,
+		Check:    database.Ping,
+	}); err != nil {
+		return fail(err)
+	}
+	database.InitializeReplica(cfg.Replica)
+	orchestrator.OnShutdown("database replica", database.CloseReplica)
 
 	// Initialize Redis
-	redis.Initialize(cfg.RedisURL)
-
-	// Initialize MinIO storage
-	if err := storage.Initialize(cfg.MinIO); err != nil {
-		log.Error("Failed to initialize MinIO", "error", err)
-		// Continue without MinIO - fallback to local storage
+	if err := orchestrator.Start(ctx, startup.Component{
+		Name:     "redis",
+		Required: true,
+		Start:    func(context.Context) error { return redis.Connect(cfg.RedisURL) },
+		Stop:     redis.Close,
+		Check:    redis.Ping,
+	}); err != nil {
+		return fail(err)
 	}
+	cache.Configure(cfg.Cache)
+
+	// Feature flags for experimental mechanics; rows in feature_flags override the configured set
+	experiments.Configure(cfg.Experiments)
+	orchestrator.Start(ctx, startup.Component{
+		Name:     "feature flags",
+		Attempts: 1,
+		Start:    func(ctx context.Context) error { return experiments.LoadOverrides(ctx, database.GetDB()) },
+	})
+
+	// Branding from the configuration, with the admins' runtime changes on top
+	branding.Configure(cfg.Branding)
+	orchestrator.Start(ctx, startup.Component{
+		Name:     "branding",
+		Attempts: 1,
+		Start:    func(ctx context.Context) error { return branding.LoadOverride(ctx, database.GetDB()) },
+	})
+
+	// Drop in-memory caches when another instance or direct SQL changes their data
+	cache.Subscribe(cache.ScopeTags, taxonomy.Invalidate)
+	cache.Subscribe(cache.ScopeFlags, func() {
+		if err := experiments.LoadOverrides(context.Background(), database.GetDB()); err != nil {
+			log.Error("Failed to reload feature flags", "error", err)
+		}
+	})
+	cache.Subscribe(cache.ScopeBranding, func() {
+		if err := branding.LoadOverride(context.Background(), database.GetDB()); err != nil {
+			log.Error("Failed to reload branding", "error", err)
+		}
+	})
+	cache.Subscribe(cache.ScopeBotPolicy, func() {
+		if err := bot.LoadPolicy(context.Background(), database.GetDB()); err != nil {
+			log.Error("Failed to reload bot difficulty policy", "error", err)
+		}
+	})
+	cacheListener := cache.NewListener(cfg.DatabaseURL)
+	cacheListener.Start()
+	orchestrator.OnShutdown("cache listener", cacheListener.Stop)
+
+	// Deprecation policy for old API and realtime protocol versions
+	versioning.Configure(cfg.Versioning)
+
+	// Initialize MinIO storage; without it card images fall back to local storage
+	orchestrator.Start(ctx, startup.Component{
+		Name:  "minio",
+		Start: func(context.Context) error { return storage.Initialize(cfg.MinIO) },
+		Check: func(ctx context.Context) error { return storage.GetClient().Ping(ctx) },
+	})
 
 	// Initialize bot system
 	bot.Initialize()
+	bot.AddReservedNames(cfg.Bots.ReservedNames)
+	if cfg.Bots.NamesFile != "" {
+		pools, err := bot.LoadNamePools(cfg.Bots.NamesFile)
+		if err != nil {
+			log.Error("Failed to load bot names, using built-in names", "error", err)
+		} else {
+			bot.SetNamePools(pools)
+		}
+	}
+	orchestrator.Start(ctx, startup.Component{
+		Name:     "bot policy",
+		Attempts: 1,
+		Start:    func(ctx context.Context) error { return bot.LoadPolicy(ctx, database.GetDB()) },
+	})
 
 	// Seed database with default data
-	if err := seeder.SeedDatabase(); err != nil {
-		log.Error("Failed to seed database", "error", err)
-		// Continue without seeding - not critical for startup
-	}
+	orchestrator.Start(ctx, startup.Component{
+		Name:     "seed data",
+		Attempts: 1,
+		Start:    func(context.Context) error { return seeder.SeedDatabase() },
+	})
 
 	// Initialize authentication services
 	jwtService := auth.NewJWTService(cfg.Auth.JWTSecret)
 	authService := auth.NewAuthService(jwtService)
 	authHandlers := auth.NewAuthHandlers(authService, jwtService, cfg.Auth.EnableSSO)
 
+	// Card art URLs in v2 game state payloads are signed with a key derived from the JWT secret
+	cardart.Configure(cfg.Auth.JWTSecret, "/api/v2")
+
 	// Initialize game services with dependency injection
 	db := database.GetDB()
 	redisConn := redis.GetClient()
 	gameManager := game.NewManager(db, redisConn)
+	orchestrator.OnShutdown("game manager", func() {
+		gameManager.StopCleanupService()
+		gameManager.StopChatRetentionService()
+	})
+	gameManager.SetChatRetentionPolicy(game.ChatRetentionPolicy{
+		LobbyRetention: time.Duration(cfg.Chat.LobbyRetentionDays) * 24 * time.Hour,
+		GameRetention:  time.Duration(cfg.Chat.GameRetentionDays) * 24 * time.Hour,
+		PurgeInterval:  cfg.Chat.PurgeInterval,
+		JoinHistory:    cfg.Chat.JoinHistory,
+	})
+	gameManager.SetResumeTokenIssuer(jwtService)
+	gameManager.SetUpgradeTokenIssuer(jwtService)
+	gameManager.SetBotLimits(game.BotLimits{
+		MaxBots:   cfg.Bots.MaxPerRoom,
+		MinHumans: cfg.Bots.MinHumans,
+	})
+	gameManager.SetBotDecisionLog(cfg.Bots.LogDecisions)
+	gameManager.SetDeployment(cfg.Deployment.Region, cfg.Deployment.PublicURL)
+	gameManager.SetCapacityLimits(game.CapacityLimits{
+		MaxGames:       cfg.Capacity.MaxGames,
+		MaxConnections: cfg.Capacity.MaxConnections,
+		RetryAfter:     cfg.Capacity.RetryAfter,
+	})
+	gameManager.SetMaxClueLength(cfg.Clues.MaxLength)
+	namepolicy.AddBlockedWords(cfg.Clues.BlockedWords)
+	if err := gameManager.SetAFKThresholds(game.AFKThresholds{
+		LobbySeconds:       int(cfg.AFK.Lobby.Seconds()),
+		StorytellerSeconds: int(cfg.AFK.Storyteller.Seconds()),
+		SubmittingSeconds:  int(cfg.AFK.Submitting.Seconds()),
+		VotingSeconds:      int(cfg.AFK.Voting.Seconds()),
+	}); err != nil {
+		log.Warn("Invalid AFK thresholds, keeping the defaults", "error", err)
+	}
+	if err := gameManager.LoadShadowBans(context.Background()); err != nil {
+		log.Error("Failed to load shadow bans", "error", err)
+	}
+	// Project game events into the listing and history read tables
+	projector := readmodel.NewProjector(db)
+	projector.Start()
+	orchestrator.OnShutdown("read model projector", projector.Stop)
+	gameManager.RegisterLifecycleHook(projector)
+	projector.Subscribe(gameManager.Events())
+
+	// Record finished games and rating changes in players' activity feeds
+	activityFeed := activity.NewFeed(db)
+	activityFeed.Start()
+	orchestrator.OnShutdown("activity feed", activityFeed.Stop)
+	gameManager.RegisterLifecycleHook(activityFeed)
+	activityFeed.Subscribe(gameManager.Events())
+
+	// Push tournament results to their external ladders
+	ladderDispatcher := ladder.NewDispatcher(db, ladder.DefaultAdapters())
+	ladderDispatcher.Start()
+	orchestrator.OnShutdown("ladder dispatcher", ladderDispatcher.Stop)
+	gameManager.RegisterLifecycleHook(ladderDispatcher)
+
+	// Email notifications, held during each user's quiet hours
+	mailer := mail.New(cfg.Mail)
+	notifications := notify.NewDispatcher(db, mailer)
+	notifications.Start()
+	orchestrator.OnShutdown("notifications", notifications.Stop)
+
+	// Relay broadcasts from other instances to players connected here
+	gameManager.StartRoomRelay()
+	orchestrator.OnShutdown("room relay", gameManager.StopRoomRelay)
+
+	// WebSocket handlers still resolve the manager globally; point them at this instance
+	game.SetManager(gameManager)
 
 	// Initialize handlers with dependency injection
 	handlerDeps := handlers.NewHandlerDependencies(authService, gameManager, jwtService)
 
+	// Long-poll sessions are swept once their clients stop polling
+	pollHandlers := longpoll.NewHandlers()
+	orchestrator.OnShutdown("long-poll sweeper", pollHandlers.Stop)
+
 	// Setup router with dependencies
 	routerDeps := &router.RouterDependencies{
-		AuthHandlers:   authHandlers,
-		JWTService:     jwtService,
-		GameHandlers:   handlers.NewGameHandlers(handlerDeps),
-		PlayerHandlers: handlers.NewPlayerHandlers(handlerDeps),
-		CardHandlers:   handlers.NewCardHandlers(handlerDeps),
-		TagHandlers:    handlers.NewTagHandlers(handlerDeps),
-		AdminHandlers:  handlers.NewAdminHandlers(handlerDeps),
-		ChatHandlers:   handlers.NewChatHandlers(handlerDeps),
+		AuthHandlers:      authHandlers,
+		JWTService:        jwtService,
+		GameHandlers:      handlers.NewGameHandlers(handlerDeps),
+		PlayerHandlers:    handlers.NewPlayerHandlers(handlerDeps),
+		CardHandlers:      handlers.NewCardHandlers(handlerDeps),
+		TagHandlers:       handlers.NewTagHandlers(handlerDeps),
+		AdminHandlers:     handlers.NewAdminHandlers(handlerDeps),
+		UserAdminHandlers: handlers.NewUserAdminHandlers(handlerDeps, mailer, cfg.Auth.PasswordResetURL),
+		ChatHandlers:      handlers.NewChatHandlers(handlerDeps),
+		ClueHandlers:      handlers.NewClueHandlers(handlerDeps),
+		PollHandlers:      pollHandlers,
+
+		TournamentHandlers: handlers.NewTournamentHandlers(ladderDispatcher),
+		ActivityHandlers:   handlers.NewActivityHandlers(activityFeed),
+
+		Startup: orchestrator,
 	}
 	r := router.SetupRouter(routerDeps)
 
-	// Create cleanup function
-	cleanup := func() {
-		log.Info("Shutting down application...")
+	// Periodically verify that active cards still have usable art
+	imageChecker := cardimages.NewChecker(db, storage.GetClient())
+	if cfg.CardImages.CheckInterval > 0 {
+		imageChecker.Start(cfg.CardImages.CheckInterval, cfg.CardImages.AutoDeactivate)
+		orchestrator.OnShutdown("card image checker", imageChecker.Stop)
+	}
 
-		// Close database connection
-		if db := database.GetDB(); db != nil {
-			if sqlDB, err := db.DB(); err == nil {
-				sqlDB.Close()
+	// Periodically export anonymized game datasets for offline bot training
+	var statsExporter *statsexport.Exporter
+	if cfg.StatsExport.Interval > 0 {
+		if client := storage.GetClient(); client != nil {
+			exportKey := cfg.StatsExport.Key
+			if exportKey == "" {
+				exportKey = cfg.Auth.JWTSecret
 			}
+			statsExporter = statsexport.NewExporter(db, client, cfg.StatsExport.Bucket, exportKey)
+			statsExporter.Start(cfg.StatsExport.Interval)
+			orchestrator.OnShutdown("stats exporter", statsExporter.Stop)
+		} else {
+			log.Warn("Stats export is enabled but MinIO is unavailable; not exporting")
 		}
+	}
 
-		// Close Redis connection
-		redis.Close()
-
+	// Shutdown hooks run in reverse: background jobs first, connections last
+	cleanup := func() {
+		log.Info("Shutting down application...")
+		orchestrator.Shutdown()
 		log.Info("Application shutdown complete")
 	}
 
 	return &App{
 		Router:  r,
 		Config:  cfg,
+		Startup: orchestrator,
 		Cleanup: cleanup,
 	}, nil
 }
@@ -0,0 +1,200 @@
+// Package cache provides a Redis-backed HTTP response cache for public read endpoints.
+//
+// Cached responses are grouped into scopes (cards, tags, ...). Each scope has a
+// version counter in Redis that is part of every entry key, so Invalidate drops a
+// whole scope with a single INCR; stale entries simply expire.
+//
+// In-memory caches Subscribe to a scope instead. Database triggers NOTIFY every
+// instance's Listener when cached data changes, whoever changed it.
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"dixitme/internal/logger"
+	redisClient "dixitme/internal/redis"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cache scopes
+const (
+	ScopeCards    = "cards"
+	ScopeTags     = "tags"
+	ScopeBots     = "bots"
+	ScopeGames    = "games" // Lobby listing from the game summary read model
+	ScopeChat     = "chat"
+	ScopeFlags    = "flags"    // Feature flags; in-memory only
+	ScopeBranding = "branding" // Runtime branding; in-memory only
+
+	ScopeBotPolicy = "bot_policy" // Runtime bot difficulty policy; in-memory only
+)
+
+// Config holds HTTP cache configuration
+type Config struct {
+	Enabled bool
+	TTL     time.Duration // Lifetime of cached responses
+}
+
+var config = Config{Enabled: true, TTL: 5 * time.Minute}
+
+// Configure sets the cache configuration
+func Configure(cfg Config) {
+	config = cfg
+}
+
+// entry is a cached response as stored in Redis
+type entry struct {
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag"`
+	Body        []byte `json:"body"`
+}
+
+// bodyRecorder buffers the response body so an ETag can be set before it is sent
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// Middleware serves GET requests from the cache and stores successful responses.
+// ttl overrides the configured TTL when non-zero. Responses carry an ETag and
+// requests with a matching If-None-Match get 304 Not Modified.
+func Middleware(scope string, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client := redisClient.GetClient()
+		if !config.Enabled || client == nil || c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+		lifetime := ttl
+		if lifetime == 0 {
+			lifetime = config.TTL
+		}
+
+		ctx := c.Request.Context()
+		key := entryKey(ctx, scope, c.Request.URL.RequestURI())
+
+		if data, err := client.Get(ctx, key).Bytes(); err == nil {
+			var cached entry
+			if err := json.Unmarshal(data, &cached); err == nil {
+				c.Header("X-Cache", "HIT")
+				writeCached(c, cached)
+				c.Abort()
+				return
+			}
+		}
+
+		writer := c.Writer
+		recorder := &bodyRecorder{ResponseWriter: writer}
+		c.Writer = recorder
+		c.Next()
+		c.Writer = writer
+
+		if recorder.Status() != http.StatusOK {
+			writer.Write(recorder.body.Bytes())
+			return
+		}
+
+		cached := entry{
+			ContentType: writer.Header().Get("Content-Type"),
+			ETag:        computeETag(recorder.body.Bytes()),
+			Body:        recorder.body.Bytes(),
+		}
+		c.Header("X-Cache", "MISS")
+		writeCached(c, cached)
+
+		data, err := json.Marshal(cached)
+		if err != nil {
+			return
+		}
+		if err := client.Set(context.Background(), key, data, lifetime).Err(); err != nil {
+			logger.Warn("Failed to store cached response", "error", err, "scope", scope)
+		}
+	}
+}
+
+// Unless runs middleware only for requests skip rejects, e.g. to keep
+// responses that depend on the caller's role out of a shared cache
+func Unless(skip func(*gin.Context) bool, middleware gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if skip(c) {
+			c.Next()
+			return
+		}
+		middleware(c)
+	}
+}
+
+// Invalidate drops all cached responses for the given scopes
+func Invalidate(ctx context.Context, scopes ...string) {
+	client := redisClient.GetClient()
+	if client == nil {
+		return
+	}
+
+	for _, scope := range scopes {
+		if err := client.Incr(ctx, versionKey(scope)).Err(); err != nil {
+			logger.Warn("Failed to invalidate response cache", "error", err, "scope", scope)
+		}
+	}
+}
+
+// writeCached writes a cached entry, or 304 if the client already has it
+func writeCached(c *gin.Context, cached entry) {
+	c.Header("ETag", cached.ETag)
+	if matchesETag(c.GetHeader("If-None-Match"), cached.ETag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, cached.ContentType, cached.Body)
+}
+
+// entryKey builds the Redis key for a request under the scope's current version
+func entryKey(ctx context.Context, scope, requestURI string) string {
+	version, err := redisClient.GetClient().Get(ctx, versionKey(scope)).Int64()
+	if err != nil {
+		version = 0
+	}
+	sum := sha256.Sum256([]byte(requestURI))
+	return fmt.Sprintf("httpcache:%s:%d:%s", scope, version, hex.EncodeToString(sum[:16]))
+}
+
+func versionKey(scope string) string {
+	return fmt.Sprintf("httpcache:%s:version", scope)
+}
+
+// computeETag returns a strong ETag for a response body
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// matchesETag reports whether an If-None-Match header matches etag
+func matchesETag(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
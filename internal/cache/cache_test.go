@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeETag(t *testing.T) {
+	etag := computeETag([]byte(`{"cards":[]}`))
+
+	assert.Equal(t, etag, computeETag([]byte(`{"cards":[]}`)))
+	assert.NotEqual(t, etag, computeETag([]byte(`{"cards":[1]}`)))
+	assert.True(t, etag[0] == '"' && etag[len(etag)-1] == '"')
+}
+
+func TestMatchesETag(t *testing.T) {
+	etag := `"abc123"`
+
+	assert.False(t, matchesETag("", etag))
+	assert.True(t, matchesETag(`"abc123"`, etag))
+	assert.True(t, matchesETag(`W/"abc123"`, etag))
+	assert.True(t, matchesETag(`"other", "abc123"`, etag))
+	assert.True(t, matchesETag("*", etag))
+	assert.False(t, matchesETag(`"other"`, etag))
+}
+
+func TestInvalidateLocalRunsScopeSubscribers(t *testing.T) {
+	var tags, flags int
+	Subscribe(ScopeTags, func() { tags++ })
+	Subscribe(ScopeFlags, func() { flags++ })
+
+	invalidateLocal(ScopeTags)
+	assert.Equal(t, 1, tags)
+	assert.Equal(t, 0, flags)
+
+	invalidateAllLocal()
+	assert.Equal(t, 2, tags)
+	assert.Equal(t, 1, flags)
+
+	assert.True(t, isScope(ScopeGames))
+	assert.True(t, isScope(ScopeCards))
+	assert.False(t, isScope("players"))
+}
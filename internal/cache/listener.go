@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// NotifyChannel is the Postgres channel database triggers announce changes on.
+// The payload is the scope of the changed data.
+const NotifyChannel = "cache_invalidation"
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+var (
+	subscribersMu sync.RWMutex
+	subscribers   = make(map[string][]func())
+)
+
+// Subscribe registers fn to drop an in-memory cache whenever data in scope
+// changes, on this instance, another instance or through direct SQL
+func Subscribe(scope string, fn func()) {
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+	subscribers[scope] = append(subscribers[scope], fn)
+}
+
+// isScope reports whether scope is one of the cache scopes
+func isScope(scope string) bool {
+	switch scope {
+	case ScopeCards, ScopeTags, ScopeBots, ScopeGames, ScopeChat, ScopeFlags, ScopeBranding:
+		return true
+	}
+	return false
+}
+
+// invalidateLocal runs the subscribers of scope
+func invalidateLocal(scope string) {
+	subscribersMu.RLock()
+	fns := subscribers[scope]
+	subscribersMu.RUnlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// invalidateAllLocal runs every subscriber
+func invalidateAllLocal() {
+	subscribersMu.RLock()
+	scopes := make([]string, 0, len(subscribers))
+	for scope := range subscribers {
+		scopes = append(scopes, scope)
+	}
+	subscribersMu.RUnlock()
+
+	for _, scope := range scopes {
+		invalidateLocal(scope)
+	}
+}
+
+// Listener keeps a dedicated connection LISTENing on NotifyChannel and
+// invalidates the announced scope, in memory and in the response cache.
+// Notifications sent while it is disconnected are lost, so every subscriber
+// runs again after a reconnect.
+type Listener struct {
+	databaseURL string
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewListener creates a listener connecting to databaseURL
+func NewListener(databaseURL string) *Listener {
+	return &Listener{databaseURL: databaseURL}
+}
+
+// Start listens in the background until Stop is called
+func (l *Listener) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+	l.done = make(chan struct{})
+
+	go func() {
+		defer close(l.done)
+
+		delay := minReconnectDelay
+		everConnected := false
+		for {
+			connected, err := l.listen(ctx, everConnected)
+			if ctx.Err() != nil {
+				return
+			}
+			if connected {
+				everConnected = true
+				delay = minReconnectDelay
+			}
+			logger.Warn("Cache invalidation listener disconnected, reconnecting", "error", err, "delay", delay)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxReconnectDelay)
+		}
+	}()
+}
+
+// Stop closes the listener's connection and waits for it to finish
+func (l *Listener) Stop() {
+	if l.cancel == nil {
+		return
+	}
+	l.cancel()
+	<-l.done
+}
+
+// listen handles notifications until the connection fails or ctx is cancelled.
+// It reports whether it got as far as listening.
+func (l *Listener) listen(ctx context.Context, reconnected bool) (bool, error) {
+	conn, err := pgx.Connect(ctx, l.databaseURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+NotifyChannel); err != nil {
+		return false, fmt.Errorf("failed to listen: %w", err)
+	}
+	if reconnected {
+		invalidateAllLocal()
+	}
+	logger.Info("Listening for cache invalidations", "channel", NotifyChannel)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		scope := notification.Payload
+		if !isScope(scope) {
+			logger.Warn("Ignoring cache invalidation for unknown scope", "scope", scope)
+			continue
+		}
+		metrics.GetCounter(metrics.Name("cache_invalidations_received_total", "scope", scope)).Inc()
+		logger.Debug("Cache invalidation received", "scope", scope, "sender_pid", notification.PID)
+
+		invalidateLocal(scope)
+		Invalidate(ctx, scope)
+	}
+}
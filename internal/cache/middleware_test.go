@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	redisClient "dixitme/internal/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cachedRouter serves a counting handler behind the middleware, as the games
+// listing is: responses asking for private rooms skip the cache
+func cachedRouter(t *testing.T) (*gin.Engine, *miniredis.Miniredis, *int) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	server := miniredis.RunT(t)
+	previous := redisClient.Client
+	require.NoError(t, redisClient.Connect("redis://"+server.Addr()))
+	t.Cleanup(func() {
+		redisClient.Client.Close()
+		redisClient.Client = previous
+	})
+
+	calls := 0
+	private := func(c *gin.Context) bool { return c.Query("private") == "true" }
+	router := gin.New()
+	router.GET("/games", Unless(private, Middleware(ScopeGames, time.Minute)), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, gin.H{"calls": calls})
+	})
+	return router, server, &calls
+}
+
+func get(router *gin.Engine, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for name := range header {
+		req.Header.Set(name, header.Get(name))
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestMiddlewareServesRepeatRequestsFromCache(t *testing.T) {
+	router, _, calls := cachedRouter(t)
+
+	first := get(router, "/games", nil)
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "MISS", first.Header().Get("X-Cache"))
+
+	second := get(router, "/games", nil)
+	require.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "HIT", second.Header().Get("X-Cache"))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, first.Header().Get("Content-Type"), second.Header().Get("Content-Type"))
+	assert.Equal(t, 1, *calls)
+
+	etag := second.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	notModified := get(router, "/games", http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	assert.Empty(t, notModified.Body.String())
+
+	// Another query string is another entry
+	assert.Equal(t, "MISS", get(router, "/games?page=2", nil).Header().Get("X-Cache"))
+	assert.Equal(t, 2, *calls)
+}
+
+func TestInvalidateBumpsScopeVersion(t *testing.T) {
+	router, server, calls := cachedRouter(t)
+
+	get(router, "/games", nil)
+	assert.Equal(t, "HIT", get(router, "/games", nil).Header().Get("X-Cache"))
+
+	Invalidate(context.Background(), ScopeGames)
+	version, err := server.Get(versionKey(ScopeGames))
+	require.NoError(t, err)
+	assert.Equal(t, "1", version)
+
+	fresh := get(router, "/games", nil)
+	assert.Equal(t, "MISS", fresh.Header().Get("X-Cache"))
+	assert.JSONEq(t, `{"calls":2}`, fresh.Body.String())
+	assert.Equal(t, "HIT", get(router, "/games", nil).Header().Get("X-Cache"))
+
+	// Other scopes are left alone
+	Invalidate(context.Background(), ScopeCards)
+	assert.Equal(t, "HIT", get(router, "/games", nil).Header().Get("X-Cache"))
+	assert.Equal(t, 2, *calls)
+}
+
+func TestUnlessBypassesCache(t *testing.T) {
+	router, server, calls := cachedRouter(t)
+
+	for i := 1; i <= 2; i++ {
+		recorder := get(router, "/games?private=true", nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("X-Cache"))
+		assert.Equal(t, i, *calls)
+	}
+	assert.Empty(t, server.Keys(), "skipped responses are not stored")
+}
@@ -4,21 +4,51 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
+	"dixitme/internal/cache"
+	"dixitme/internal/database"
 	"dixitme/internal/logger"
+	"dixitme/internal/services/branding"
+	"dixitme/internal/services/mail"
+	"dixitme/internal/startup"
 	"dixitme/internal/storage"
+	"dixitme/internal/transport/versioning"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	DatabaseURL string
+	Replica     database.ReplicaConfig
 	RedisURL    string
 	Port        string
 	GinMode     string
 	Logger      logger.Config
 	MinIO       storage.MinIOConfig
 	Auth        AuthConfig
+	Chat        ChatConfig
+	Bots        BotConfig
+	AFK         AFKConfig
+	Clues       ClueConfig
+	Capacity    CapacityConfig
+	Cache       cache.Config
+	CardImages  CardImagesConfig
+	StatsExport StatsExportConfig
+	Branding    branding.Branding
+	Experiments []string // Experiment keys flagged on for this deployment
+	Versioning  versioning.Config
+	Mail        mail.Config
+	Deployment  DeploymentConfig
+	Startup     startup.Config
+}
+
+// DeploymentConfig says where this instance runs, for routing players to the
+// instance hosting their room
+type DeploymentConfig struct {
+	Region    string // Region label shown on rooms created here (empty = unlabeled)
+	PublicURL string // Base URL clients can reach this instance at directly
 }
 
 // AuthConfig holds authentication configuration
@@ -27,6 +57,59 @@ type AuthConfig struct {
 	GoogleClientID     string
 	GoogleClientSecret string
 	EnableSSO          bool
+	PasswordResetURL   string // Frontend page that takes ?token= from password reset emails
+}
+
+// ChatConfig holds chat retention configuration
+type ChatConfig struct {
+	LobbyRetentionDays int           // Lobby chat older than this is purged
+	GameRetentionDays  int           // In-game chat older than this is purged (0 = keep with the game)
+	PurgeInterval      time.Duration // How often the retention job runs
+	JoinHistory        int           // Latest messages delivered when a player joins a room (0 = none)
+}
+
+// BotConfig holds per-room bot limits and bot naming
+type BotConfig struct {
+	MaxPerRoom    int      // Most bots a room may hold
+	MinHumans     int      // Humans required to start a game
+	NamesFile     string   // JSON file of bot names per locale (empty = built-in names)
+	ReservedNames []string // Names bots may never use, on top of the built-in ones
+	LogDecisions  bool     // Store each bot decision's top candidates for post-game review
+}
+
+// AFKConfig holds the inactivity thresholds per phase, for the standard pace.
+// Faster and slower paces scale them; rooms may override them.
+type AFKConfig struct {
+	Lobby       time.Duration // Disconnected in the lobby, before losing the seat
+	Storyteller time.Duration // Storyteller while the clue is due
+	Submitting  time.Duration // Players while their card is due
+	Voting      time.Duration // Voters while their vote is due
+}
+
+// ClueConfig holds the deployment's clue rules; hosts may add a word limit per room
+type ClueConfig struct {
+	MaxLength    int      // Longest clue, in characters
+	BlockedWords []string // Refused in clues and player names, on top of the built-in list
+}
+
+// CapacityConfig holds the server's room and connection limits (0 = unlimited)
+type CapacityConfig struct {
+	MaxGames       int           // Rooms held in memory
+	MaxConnections int           // Open client connections
+	RetryAfter     time.Duration // Retry hint sent to refused clients
+}
+
+// CardImagesConfig holds card image integrity check configuration
+type CardImagesConfig struct {
+	CheckInterval  time.Duration // How often the integrity job runs (0 disables it)
+	AutoDeactivate bool          // Deactivate cards with broken images during scheduled runs
+}
+
+// StatsExportConfig holds the data science export configuration
+type StatsExportConfig struct {
+	Interval time.Duration // How often completed games are exported (0 disables the export)
+	Bucket   string        // MinIO bucket the datasets are written to
+	Key      string        // Anonymizes IDs; keep it stable so exports stay joinable (empty = derived from the JWT secret)
 }
 
 func Load() *Config {
@@ -38,9 +121,15 @@ func Load() *Config {
 
 	return &Config{
 		DatabaseURL: getEnv("DATABASE_URL", "postgres://localhost/dixitme?sslmode=disable"),
-		RedisURL:    getEnv("REDIS_URL", "redis://localhost:6379"),
-		Port:        getEnv("PORT", "8080"),
-		GinMode:     getEnv("GIN_MODE", "debug"),
+		Replica: database.ReplicaConfig{
+			URL:           getEnv("DATABASE_REPLICA_URL", ""),
+			MaxLag:        getDurationEnv("DATABASE_REPLICA_MAX_LAG", 5*time.Second),
+			CheckInterval: getDurationEnv("DATABASE_REPLICA_CHECK_INTERVAL", 10*time.Second),
+			StickyWindow:  getDurationEnv("DATABASE_REPLICA_STICKY_WINDOW", 10*time.Second),
+		},
+		RedisURL: getEnv("REDIS_URL", "redis://localhost:6379"),
+		Port:     getEnv("PORT", "8080"),
+		GinMode:  getEnv("GIN_MODE", "debug"),
 		Logger: logger.Config{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "text"),
@@ -58,6 +147,84 @@ func Load() *Config {
 			GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 			GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 			EnableSSO:          getBoolEnv("ENABLE_SSO", true),
+			PasswordResetURL:   getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+		},
+		Chat: ChatConfig{
+			LobbyRetentionDays: getIntEnv("CHAT_LOBBY_RETENTION_DAYS", 30),
+			GameRetentionDays:  getIntEnv("CHAT_GAME_RETENTION_DAYS", 0),
+			PurgeInterval:      getDurationEnv("CHAT_PURGE_INTERVAL", 24*time.Hour),
+			JoinHistory:        getIntEnv("CHAT_JOIN_HISTORY", 30),
+		},
+		Bots: BotConfig{
+			MaxPerRoom:    getIntEnv("BOT_MAX_PER_ROOM", 5),
+			MinHumans:     getIntEnv("BOT_MIN_HUMANS", 1),
+			NamesFile:     getEnv("BOT_NAMES_FILE", ""),
+			ReservedNames: getListEnv("BOT_RESERVED_NAMES"),
+			LogDecisions:  getBoolEnv("BOT_LOG_DECISIONS", false),
+		},
+		AFK: AFKConfig{
+			Lobby:       getDurationEnv("AFK_LOBBY_TIMEOUT", 10*time.Minute),
+			Storyteller: getDurationEnv("AFK_STORYTELLER_TIMEOUT", 4*time.Minute),
+			Submitting:  getDurationEnv("AFK_SUBMITTING_TIMEOUT", 3*time.Minute),
+			Voting:      getDurationEnv("AFK_VOTING_TIMEOUT", 2*time.Minute),
+		},
+		Clues: ClueConfig{
+			MaxLength:    getIntEnv("CLUE_MAX_LENGTH", 100),
+			BlockedWords: getListEnv("BLOCKED_WORDS"),
+		},
+		Capacity: CapacityConfig{
+			MaxGames:       getIntEnv("CAPACITY_MAX_GAMES", 0),
+			MaxConnections: getIntEnv("CAPACITY_MAX_CONNECTIONS", 0),
+			RetryAfter:     getDurationEnv("CAPACITY_RETRY_AFTER", 30*time.Second),
+		},
+		Cache: cache.Config{
+			Enabled: getBoolEnv("HTTP_CACHE_ENABLED", true),
+			TTL:     getDurationEnv("HTTP_CACHE_TTL", 5*time.Minute),
+		},
+		Experiments: getListEnv("EXPERIMENTS"),
+		CardImages: CardImagesConfig{
+			CheckInterval:  getDurationEnv("CARD_IMAGE_CHECK_INTERVAL", 24*time.Hour),
+			AutoDeactivate: getBoolEnv("CARD_IMAGE_AUTO_DEACTIVATE", false),
+		},
+		Branding: branding.Branding{
+			Name:    getEnv("BRANDING_NAME", "DixitMe"),
+			LogoURL: getEnv("BRANDING_LOGO_URL", ""),
+			Colors: branding.Palette{
+				Primary:    getEnv("BRANDING_COLOR_PRIMARY", "#3B82F6"),
+				Secondary:  getEnv("BRANDING_COLOR_SECONDARY", ""),
+				Accent:     getEnv("BRANDING_COLOR_ACCENT", ""),
+				Background: getEnv("BRANDING_COLOR_BACKGROUND", ""),
+				Text:       getEnv("BRANDING_COLOR_TEXT", ""),
+			},
+			DefaultDeck: getEnv("BRANDING_DEFAULT_DECK", ""),
+			FooterLinks: getLinksEnv("BRANDING_FOOTER_LINKS"),
+		},
+		Mail: mail.Config{
+			SMTPAddr: getEnv("MAIL_SMTP_ADDR", ""),
+			Username: getEnv("MAIL_SMTP_USERNAME", ""),
+			Password: getEnv("MAIL_SMTP_PASSWORD", ""),
+			From:     getEnv("MAIL_FROM", "DixitMe <no-reply@dixitme.local>"),
+		},
+		StatsExport: StatsExportConfig{
+			Interval: getDurationEnv("STATS_EXPORT_INTERVAL", 0),
+			Bucket:   getEnv("STATS_EXPORT_BUCKET", "dixitme-exports"),
+			Key:      getEnv("STATS_EXPORT_KEY", ""),
+		},
+		Deployment: DeploymentConfig{
+			Region:    getEnv("REGION", ""),
+			PublicURL: getEnv("PUBLIC_URL", ""),
+		},
+		Startup: startup.Config{
+			Attempts:       getIntEnv("STARTUP_ATTEMPTS", 5),
+			InitialBackoff: getDurationEnv("STARTUP_BACKOFF", time.Second),
+			MaxBackoff:     getDurationEnv("STARTUP_MAX_BACKOFF", 15*time.Second),
+		},
+		Versioning: versioning.Config{
+			V1: versioning.Deprecation{
+				Deprecated: getBoolEnv("API_V1_DEPRECATED", false),
+				Sunset:     getDateEnv("API_V1_SUNSET"),
+				Link:       getEnv("API_DEPRECATION_LINK", ""),
+			},
 		},
 	}
 }
@@ -71,6 +238,58 @@ func getBoolEnv(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getDateEnv parses a YYYY-MM-DD date, returning the zero time when unset or invalid
+func getDateEnv(key string) time.Time {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.Parse(time.DateOnly, value); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
+func getListEnv(key string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getLinksEnv parses comma-separated Label|URL pairs
+func getLinksEnv(key string) []branding.Link {
+	links := make([]branding.Link, 0)
+	for _, value := range getListEnv(key) {
+		label, link, found := strings.Cut(value, "|")
+		if !found {
+			log.Printf("Ignoring %s entry without a URL: %q", key, value)
+			continue
+		}
+		links = append(links, branding.Link{Label: strings.TrimSpace(label), URL: strings.TrimSpace(link)})
+	}
+	return links
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
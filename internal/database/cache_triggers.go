@@ -0,0 +1,56 @@
+package database
+
+import (
+	"fmt"
+
+	"dixitme/internal/cache"
+)
+
+// cacheTriggerTables maps every table holding cached data to the cache scope
+// its changes invalidate
+var cacheTriggerTables = []struct {
+	table string
+	scope string
+}{
+	{"cards", cache.ScopeCards},
+	{"card_tags", cache.ScopeCards},
+	{"card_tag_relations", cache.ScopeCards},
+	{"card_packs", cache.ScopeCards},
+	{"card_pack_cards", cache.ScopeCards},
+	{"tags", cache.ScopeTags},
+	{"feature_flags", cache.ScopeFlags},
+	{"branding_overrides", cache.ScopeBranding},
+	{"bot_policy_overrides", cache.ScopeBotPolicy},
+}
+
+// migrateCacheTriggers installs statement-level triggers that NOTIFY cache
+// listeners when cached data changes, however it was changed. Postgres
+// delivers the notifications on commit and folds duplicates within a
+// transaction, so bulk edits notify once. Safe to run repeatedly.
+func migrateCacheTriggers() error {
+	if err := DB.Exec(fmt.Sprintf(`
+		CREATE OR REPLACE FUNCTION notify_cache_invalidation() RETURNS trigger AS $$
+		BEGIN
+			PERFORM pg_notify('%s', TG_ARGV[0]);
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql`, cache.NotifyChannel)).Error; err != nil {
+		return fmt.Errorf("failed to create cache invalidation function: %w", err)
+	}
+
+	for _, t := range cacheTriggerTables {
+		if !DB.Migrator().HasTable(t.table) {
+			continue
+		}
+		if err := DB.Exec(fmt.Sprintf(`DROP TRIGGER IF EXISTS cache_invalidation ON %s`, t.table)).Error; err != nil {
+			return fmt.Errorf("failed to drop cache trigger on %s: %w", t.table, err)
+		}
+		if err := DB.Exec(fmt.Sprintf(`
+			CREATE TRIGGER cache_invalidation
+			AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON %s
+			FOR EACH STATEMENT EXECUTE FUNCTION notify_cache_invalidation('%s')`, t.table, t.scope)).Error; err != nil {
+			return fmt.Errorf("failed to create cache trigger on %s: %w", t.table, err)
+		}
+	}
+	return nil
+}
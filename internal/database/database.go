@@ -1,6 +1,9 @@
 package database
 
 import (
+	"context"
+	"fmt"
+
 	"dixitme/internal/logger"
 	"dixitme/internal/models"
 
@@ -11,26 +14,58 @@ import (
 
 var DB *gorm.DB
 
+// Initialize connects to the database and runs the migrations, panicking on
+// failure. The server starts through Connect, which it can retry.
 func Initialize(databaseURL string) {
-	var err error
+	if err := Connect(databaseURL); err != nil {
+		panic(err)
+	}
+}
+
+// Connect connects to the database and runs the migrations
+func Connect(databaseURL string) error {
 	log := logger.GetLogger()
 
-	DB, err = gorm.Open(postgres.Open(databaseURL), &gorm.Config{
+	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
 		Logger: gormLogger.Default.LogMode(gormLogger.Info),
 	})
-
 	if err != nil {
 		log.Error("Failed to connect to database", "error", err)
-		panic(err)
+		return fmt.Errorf("failed to connect to database: %w", err)
 	}
+	DB = db
 
 	// Run migrations
 	if err := migrate(); err != nil {
 		log.Error("Failed to run migrations", "error", err)
-		panic(err)
+		Close()
+		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
 	log.Info("Database connection established and migrations completed")
+	return nil
+}
+
+// Ping checks that the database answers
+func Ping(ctx context.Context) error {
+	if DB == nil {
+		return fmt.Errorf("database not connected")
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Close closes the database connections
+func Close() {
+	if DB == nil {
+		return
+	}
+	if sqlDB, err := DB.DB(); err == nil {
+		sqlDB.Close()
+	}
 }
 
 func migrate() error {
@@ -43,30 +78,65 @@ func migrate() error {
 		return err
 	}
 
+	// Migrate card history (depends on Card)
+	log.Info("Migrating card history models...")
+	if err := DB.AutoMigrate(&models.CardVersion{}); err != nil {
+		log.Error("Failed to migrate card history models", "error", err)
+		return err
+	}
+
+	// Migrate card packs (depends on Card)
+	log.Info("Migrating card packs...")
+	if err := DB.AutoMigrate(&models.CardPack{}); err != nil {
+		log.Error("Failed to migrate card packs", "error", err)
+		return err
+	}
+
+	// Migrate custom decks (depends on Card)
+	log.Info("Migrating custom decks...")
+	if err := DB.AutoMigrate(&models.CustomDeck{}); err != nil {
+		log.Error("Failed to migrate custom decks", "error", err)
+		return err
+	}
+
 	// Migrate user and authentication models
 	log.Info("Migrating user and authentication models...")
-	if err := DB.AutoMigrate(&models.User{}, &models.Session{}); err != nil {
+	if err := DB.AutoMigrate(&models.User{}, &models.Session{}, &models.PasswordResetToken{}); err != nil {
 		log.Error("Failed to migrate user models", "error", err)
 		return err
 	}
 
+	// Migrate the notification outbox
+	log.Info("Migrating notifications...")
+	if err := DB.AutoMigrate(&models.Notification{}); err != nil {
+		log.Error("Failed to migrate notifications", "error", err)
+		return err
+	}
+
 	// Migrate player model (depends on User)
 	log.Info("Migrating Player model...")
-	if err := DB.AutoMigrate(&models.Player{}); err != nil {
+	if err := DB.AutoMigrate(&models.Player{}, &models.PlayerRating{}); err != nil {
 		log.Error("Failed to migrate Player model", "error", err)
 		return err
 	}
 
 	// Migrate game models (depends on Player)
 	log.Info("Migrating game models...")
-	if err := DB.AutoMigrate(&models.Game{}, &models.GamePlayer{}, &models.GameHistory{}); err != nil {
+	if err := DB.AutoMigrate(&models.Game{}, &models.GamePlayer{}, &models.GameHistory{}, &models.GameReport{}, &models.GameExperiment{}, &models.GameForfeit{}, &models.BotDecision{}, &models.TableCardHistory{}, &models.ReplayEvent{}); err != nil {
 		log.Error("Failed to migrate game models", "error", err)
 		return err
 	}
 
+	// Migrate the game listing and history read models
+	log.Info("Migrating read models...")
+	if err := DB.AutoMigrate(&models.GameSummary{}, &models.PlayerGameStat{}); err != nil {
+		log.Error("Failed to migrate read models", "error", err)
+		return err
+	}
+
 	// Migrate round models (depends on Game and Player)
 	log.Info("Migrating round models...")
-	if err := DB.AutoMigrate(&models.GameRound{}, &models.CardSubmission{}, &models.Vote{}); err != nil {
+	if err := DB.AutoMigrate(&models.GameRound{}, &models.CardSubmission{}, &models.Vote{}, &models.RoundScore{}); err != nil {
 		log.Error("Failed to migrate round models", "error", err)
 		return err
 	}
@@ -78,6 +148,97 @@ func migrate() error {
 		return err
 	}
 
+	// Migrate the player activity feed
+	log.Info("Migrating activity feed...")
+	if err := DB.AutoMigrate(&models.ActivityEvent{}); err != nil {
+		log.Error("Failed to migrate activity feed", "error", err)
+		return err
+	}
+
+	// Migrate tournament ladder models
+	log.Info("Migrating tournament models...")
+	if err := DB.AutoMigrate(&models.Tournament{}, &models.TournamentParticipant{}, &models.LadderDelivery{}); err != nil {
+		log.Error("Failed to migrate tournament models", "error", err)
+		return err
+	}
+
+	// Migrate moderation models
+	log.Info("Migrating moderation models...")
+	if err := DB.AutoMigrate(&models.ShadowBan{}, &models.PlayerReport{}, &models.AdminAuditEntry{}); err != nil {
+		log.Error("Failed to migrate moderation models", "error", err)
+		return err
+	}
+
+	// Migrate runtime feature flags
+	log.Info("Migrating feature flags...")
+	if err := DB.AutoMigrate(&models.FeatureFlag{}); err != nil {
+		log.Error("Failed to migrate feature flags", "error", err)
+		return err
+	}
+
+	// Migrate runtime branding
+	log.Info("Migrating branding...")
+	if err := DB.AutoMigrate(&models.BrandingOverride{}); err != nil {
+		log.Error("Failed to migrate branding", "error", err)
+		return err
+	}
+
+	// Migrate the runtime bot difficulty policy
+	log.Info("Migrating bot difficulty policy...")
+	if err := DB.AutoMigrate(&models.BotPolicyOverride{}); err != nil {
+		log.Error("Failed to migrate bot difficulty policy", "error", err)
+		return err
+	}
+
+	// Migrate hosts' saved room templates
+	log.Info("Migrating room templates...")
+	if err := DB.AutoMigrate(&models.RoomTemplate{}); err != nil {
+		log.Error("Failed to migrate room templates", "error", err)
+		return err
+	}
+
+	// Migrate the data science export log
+	log.Info("Migrating stats exports...")
+	if err := DB.AutoMigrate(&models.StatsExport{}); err != nil {
+		log.Error("Failed to migrate stats exports", "error", err)
+		return err
+	}
+
+	// Migrate the deck pack marketplace
+	log.Info("Migrating deck packs...")
+	if err := DB.AutoMigrate(&models.DeckPack{}, &models.DeckPackInstall{}, &models.DeckPackRating{}); err != nil {
+		log.Error("Failed to migrate deck packs", "error", err)
+		return err
+	}
+
+	// Announce changes to cached data to every instance
+	log.Info("Migrating cache invalidation triggers...")
+	if err := migrateCacheTriggers(); err != nil {
+		log.Error("Failed to migrate cache invalidation triggers", "error", err)
+		return err
+	}
+
+	// System chat messages are authored by a reserved player row
+	log.Info("Migrating system player...")
+	if err := migrateSystemPlayer(); err != nil {
+		log.Error("Failed to migrate system player", "error", err)
+		return err
+	}
+
+	// Registered users' players are keyed by user ID rather than session ID
+	log.Info("Migrating player identities...")
+	if err := migratePlayerIdentities(); err != nil {
+		log.Error("Failed to migrate player identities", "error", err)
+		return err
+	}
+
+	// Accounts from before roles existed are players
+	log.Info("Migrating user roles...")
+	if err := migrateUserRoles(); err != nil {
+		log.Error("Failed to migrate user roles", "error", err)
+		return err
+	}
+
 	log.Info("All database migrations completed successfully!")
 	return nil
 }
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"fmt"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MergePlayerIdentity moves everything recorded for player fromID onto player toID
+// and deletes fromID. It is used when a registered user's games were recorded
+// under a session ID. If both identities sat in the same game, the seat with the
+// higher score is kept. Run it inside a transaction.
+func MergePlayerIdentity(tx *gorm.DB, fromID, toID uuid.UUID, userID *uuid.UUID) error {
+	if fromID == toID {
+		return nil
+	}
+
+	var from models.Player
+	if err := tx.Unscoped().First(&from, "id = ?", fromID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to load player %s: %w", fromID, err)
+	}
+
+	// Make sure the canonical player exists
+	to := models.Player{
+		ID:        toID,
+		UserID:    userID,
+		Name:      from.Name,
+		Type:      from.Type,
+		AuthType:  from.AuthType,
+		CreatedAt: from.CreatedAt,
+	}
+	if err := tx.Where("id = ?", toID).FirstOrCreate(&to).Error; err != nil {
+		return fmt.Errorf("failed to create canonical player: %w", err)
+	}
+	if userID != nil {
+		if err := tx.Model(&models.Player{}).Where("id = ?", toID).Update("user_id", userID).Error; err != nil {
+			return fmt.Errorf("failed to link player to user: %w", err)
+		}
+	}
+
+	// Resolve games both identities joined, e.g. the same user on two devices
+	var seats []models.GamePlayer
+	if err := tx.Where("player_id IN ?", []uuid.UUID{fromID, toID}).Find(&seats).Error; err != nil {
+		return fmt.Errorf("failed to load game seats: %w", err)
+	}
+	best := make(map[uuid.UUID]models.GamePlayer)
+	var duplicates []uuid.UUID
+	for _, seat := range seats {
+		kept, exists := best[seat.GameID]
+		switch {
+		case !exists:
+			best[seat.GameID] = seat
+		case seat.Score > kept.Score:
+			duplicates = append(duplicates, kept.ID)
+			best[seat.GameID] = seat
+		default:
+			duplicates = append(duplicates, seat.ID)
+		}
+	}
+	if len(duplicates) > 0 {
+		if err := tx.Where("id IN ?", duplicates).Delete(&models.GamePlayer{}).Error; err != nil {
+			return fmt.Errorf("failed to remove duplicate seats: %w", err)
+		}
+	}
+
+	// Repoint every reference to the old identity
+	updates := []struct {
+		model  interface{}
+		column string
+	}{
+		{&models.GamePlayer{}, "player_id"},
+		{&models.GameRound{}, "storyteller_id"},
+		{&models.CardSubmission{}, "player_id"},
+		{&models.Vote{}, "player_id"},
+		{&models.ChatMessage{}, "player_id"},
+		{&models.GameHistory{}, "winner_id"},
+		{&models.GameReport{}, "host_id"},
+		{&models.GameForfeit{}, "player_id"},
+		{&models.RoundScore{}, "player_id"},
+		{&models.PlayerReport{}, "reporter_id"},
+		{&models.PlayerReport{}, "reported_id"},
+		{&models.TournamentParticipant{}, "player_id"},
+		{&models.ActivityEvent{}, "player_id"},
+	}
+	for _, u := range updates {
+		if err := tx.Model(u.model).Where(u.column+" = ?", fromID).Update(u.column, toID).Error; err != nil {
+			return fmt.Errorf("failed to update %s: %w", u.column, err)
+		}
+	}
+
+	// Keep the canonical rating when both have one; otherwise carry the old one over
+	var canonicalRatings int64
+	if err := tx.Model(&models.PlayerRating{}).Where("player_id = ?", toID).Count(&canonicalRatings).Error; err != nil {
+		return fmt.Errorf("failed to load rating: %w", err)
+	}
+	if canonicalRatings == 0 {
+		if err := tx.Model(&models.PlayerRating{}).Where("player_id = ?", fromID).Update("player_id", toID).Error; err != nil {
+			return fmt.Errorf("failed to move rating: %w", err)
+		}
+	} else if err := tx.Delete(&models.PlayerRating{}, "player_id = ?", fromID).Error; err != nil {
+		return fmt.Errorf("failed to remove merged rating: %w", err)
+	}
+
+	if err := tx.Unscoped().Delete(&models.Player{}, "id = ?", fromID).Error; err != nil {
+		return fmt.Errorf("failed to delete merged player: %w", err)
+	}
+
+	return nil
+}
+
+// migratePlayerIdentities moves registered users' players from session IDs to
+// user IDs. Player IDs used to be session IDs, so one user on two devices
+// became two players. Safe to run repeatedly.
+func migratePlayerIdentities() error {
+	log := logger.GetLogger()
+
+	var pairs []struct {
+		PlayerID uuid.UUID
+		UserID   uuid.UUID
+	}
+	if err := DB.Table("players").
+		Select("players.id AS player_id, sessions.user_id AS user_id").
+		Joins("JOIN sessions ON sessions.id = players.id").
+		Where("sessions.user_id IS NOT NULL").
+		Scan(&pairs).Error; err != nil {
+		return fmt.Errorf("failed to find session-keyed players: %w", err)
+	}
+
+	for _, pair := range pairs {
+		userID := pair.UserID
+		if err := DB.Transaction(func(tx *gorm.DB) error {
+			return MergePlayerIdentity(tx, pair.PlayerID, userID, &userID)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(pairs) > 0 {
+		log.Info("Migrated session-keyed players to user identities", "players", len(pairs))
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"dixitme/internal/logger"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// ReplicaConfig configures an optional read replica. Heavy reads (lists,
+// stats, history) go to the replica while it keeps up; everything else,
+// including all game engine writes, uses the primary.
+type ReplicaConfig struct {
+	URL           string        // Empty disables the replica
+	MaxLag        time.Duration // Reads fall back to the primary while the replica is further behind
+	CheckInterval time.Duration // How often the replica's lag is measured
+	StickyWindow  time.Duration // After a client writes, its reads stay on the primary this long
+}
+
+// replicaLagQuery measures how far a replica is behind. A replica that has
+// replayed everything it received is caught up, however old its last
+// transaction is.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`
+
+// Replica is the read replica connection, nil when none is configured
+var Replica *gorm.DB
+
+var (
+	replicaConfig  ReplicaConfig
+	replicaHealthy atomic.Bool
+	replicaLag     atomic.Int64 // Last measured lag, in nanoseconds
+	stopReplica    chan struct{}
+)
+
+// InitializeReplica connects to the read replica and starts watching its lag.
+// A replica that can't be reached is logged and skipped: reads then use the
+// primary.
+func InitializeReplica(cfg ReplicaConfig) {
+	if cfg.URL == "" {
+		return
+	}
+	log := logger.GetLogger()
+
+	replica, err := gorm.Open(postgres.Open(cfg.URL), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Warn),
+	})
+	if err != nil {
+		log.Error("Failed to connect to read replica, reading from the primary", "error", err)
+		return
+	}
+
+	Replica = replica
+	replicaConfig = cfg
+	checkReplicaLag()
+
+	stopReplica = make(chan struct{})
+	go watchReplicaLag(stopReplica, cfg.CheckInterval)
+
+	log.Info("Read replica connection established", "max_lag", cfg.MaxLag, "healthy", replicaHealthy.Load())
+}
+
+// CloseReplica stops watching the replica and closes its connection
+func CloseReplica() {
+	if Replica == nil {
+		return
+	}
+	close(stopReplica)
+	if sqlDB, err := Replica.DB(); err == nil {
+		sqlDB.Close()
+	}
+	replicaHealthy.Store(false)
+	Replica = nil
+}
+
+func watchReplicaLag(stop chan struct{}, interval time.Duration) {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			checkReplicaLag()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// checkReplicaLag measures the replica's lag and marks it usable or not
+func checkReplicaLag() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var seconds float64
+	if err := Replica.WithContext(ctx).Raw(replicaLagQuery).Scan(&seconds).Error; err != nil {
+		if replicaHealthy.Swap(false) {
+			logger.Warn("Read replica unreachable, reading from the primary", "error", err)
+		}
+		return
+	}
+
+	lag := time.Duration(seconds * float64(time.Second))
+	replicaLag.Store(int64(lag))
+	healthy := replicaConfig.MaxLag <= 0 || lag <= replicaConfig.MaxLag
+	if replicaHealthy.Swap(healthy) != healthy {
+		if healthy {
+			logger.Info("Read replica caught up, reading from it again", "lag", lag)
+		} else {
+			logger.Warn("Read replica lagging, reading from the primary", "lag", lag, "max_lag", replicaConfig.MaxLag)
+		}
+	}
+}
+
+// ReplicaStatus reports whether a replica is configured, whether reads use
+// it and its last measured lag
+func ReplicaStatus() (configured, healthy bool, lag time.Duration) {
+	if Replica == nil {
+		return false, false, 0
+	}
+	return true, replicaHealthy.Load(), time.Duration(replicaLag.Load())
+}
+
+// StickyWindow is how long a client's reads stay on the primary after it
+// writes, or zero without a replica
+func StickyWindow() time.Duration {
+	if Replica == nil {
+		return 0
+	}
+	if replicaConfig.StickyWindow > 0 {
+		return replicaConfig.StickyWindow
+	}
+	return replicaConfig.MaxLag
+}
+
+type primaryKey struct{}
+
+// WithPrimary marks a request's reads as needing the primary, e.g. because
+// the client just wrote what it is about to read
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func usesReplica(ctx context.Context) bool {
+	if Replica == nil || !replicaHealthy.Load() {
+		return false
+	}
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return !primary
+}
+
+// Reader returns the connection heavy reads should use: the replica while it
+// keeps up and the request doesn't need the primary, else the primary
+func Reader(ctx context.Context) *gorm.DB {
+	if usesReplica(ctx) {
+		return Replica.WithContext(ctx)
+	}
+	return DB.WithContext(ctx)
+}
+
+// ReadWithFallback runs a read on the Reader and, when the replica didn't
+// have the record yet, again on the primary. It suits lookups of things a
+// client may have just created elsewhere, like a room joined right after it
+// was made.
+func ReadWithFallback(ctx context.Context, read func(db *gorm.DB) error) error {
+	if !usesReplica(ctx) {
+		return read(DB.WithContext(ctx))
+	}
+	err := read(Replica.WithContext(ctx))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return read(DB.WithContext(ctx))
+	}
+	return err
+}
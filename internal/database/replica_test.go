@@ -0,0 +1,30 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestReadsUseReplicaOnlyWhileHealthy(t *testing.T) {
+	defer func() {
+		Replica, replicaConfig = nil, ReplicaConfig{}
+		replicaHealthy.Store(false)
+	}()
+
+	ctx := context.Background()
+	assert.False(t, usesReplica(ctx), "no replica configured")
+	assert.Zero(t, StickyWindow())
+
+	Replica = &gorm.DB{}
+	replicaConfig = ReplicaConfig{MaxLag: 5 * time.Second}
+	assert.False(t, usesReplica(ctx), "replica not yet measured")
+
+	replicaHealthy.Store(true)
+	assert.True(t, usesReplica(ctx))
+	assert.False(t, usesReplica(WithPrimary(ctx)), "reads after a write stay on the primary")
+	assert.Equal(t, 5*time.Second, StickyWindow(), "the sticky window defaults to the max lag")
+}
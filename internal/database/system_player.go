@@ -0,0 +1,38 @@
+package database
+
+import (
+	"fmt"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// migrateSystemPlayer creates the reserved system player and points system
+// chat messages written before it existed at it. Safe to run repeatedly.
+func migrateSystemPlayer() error {
+	system := models.Player{
+		ID:       models.SystemPlayerID,
+		Name:     models.SystemPlayerName,
+		Type:     models.PlayerTypeSystem,
+		AuthType: models.AuthTypeGuest,
+	}
+	if err := DB.Unscoped().
+		Where(models.Player{ID: models.SystemPlayerID}).
+		Assign(map[string]interface{}{"name": system.Name, "type": system.Type, "deleted_at": nil}).
+		FirstOrCreate(&system).Error; err != nil {
+		return fmt.Errorf("failed to create system player: %w", err)
+	}
+
+	result := DB.Model(&models.ChatMessage{}).
+		Where("player_id IS NULL OR player_id = ?", uuid.Nil).
+		Update("player_id", models.SystemPlayerID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to migrate system chat messages: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		logger.GetLogger().Info("Attributed system chat messages to the system player", "messages", result.RowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+	"dixitme/internal/testutils/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// systemPlayerDB swaps in a database with a game to chat in
+func systemPlayerDB(t *testing.T) uuid.UUID {
+	t.Helper()
+	previous := DB
+	DB = testdb.Open(t, &models.Game{}, &models.Player{}, &models.ChatMessage{})
+	t.Cleanup(func() { DB = previous })
+
+	gameID := uuid.New()
+	require.NoError(t, DB.Create(&models.Game{ID: gameID, RoomCode: "SYS"}).Error)
+	return gameID
+}
+
+func systemMessage(gameID uuid.UUID, playerID *uuid.UUID) *models.ChatMessage {
+	return &models.ChatMessage{ID: uuid.New(), GameID: gameID, PlayerID: playerID, Message: "Alice joined the game", MessageType: "system", Phase: "lobby", IsVisible: true}
+}
+
+func TestMigrateSystemPlayerIsIdempotent(t *testing.T) {
+	systemPlayerDB(t)
+
+	require.NoError(t, migrateSystemPlayer())
+	require.NoError(t, migrateSystemPlayer())
+
+	var players []models.Player
+	require.NoError(t, DB.Unscoped().Find(&players).Error)
+	require.Len(t, players, 1)
+	assert.Equal(t, models.SystemPlayerID, players[0].ID)
+	assert.Equal(t, models.SystemPlayerName, players[0].Name)
+	assert.Equal(t, models.PlayerTypeSystem, players[0].Type)
+}
+
+func TestMigrateSystemPlayerRestoresEditedRow(t *testing.T) {
+	systemPlayerDB(t)
+	require.NoError(t, migrateSystemPlayer())
+
+	require.NoError(t, DB.Model(&models.Player{}).Where("id = ?", models.SystemPlayerID).Update("name", "Mallory").Error)
+	require.NoError(t, DB.Delete(&models.Player{ID: models.SystemPlayerID}).Error)
+
+	require.NoError(t, migrateSystemPlayer())
+	var system models.Player
+	require.NoError(t, DB.First(&system, "id = ?", models.SystemPlayerID).Error, "a deleted system player is brought back")
+	assert.Equal(t, models.SystemPlayerName, system.Name)
+}
+
+func TestSystemChatMessagesReferenceSystemPlayer(t *testing.T) {
+	gameID := systemPlayerDB(t)
+	systemID := models.SystemPlayerID
+
+	// Messages from before the system player existed had no author
+	legacy := systemMessage(gameID, nil)
+	require.NoError(t, DB.Create(legacy).Error)
+	assert.Error(t, DB.Create(systemMessage(gameID, &systemID)).Error, "the author must exist")
+
+	require.NoError(t, migrateSystemPlayer())
+
+	var migrated models.ChatMessage
+	require.NoError(t, DB.Preload("Player").First(&migrated, "id = ?", legacy.ID).Error)
+	require.NotNil(t, migrated.PlayerID)
+	assert.Equal(t, models.SystemPlayerID, *migrated.PlayerID)
+	assert.Equal(t, models.SystemPlayerName, migrated.Player.Name)
+
+	// New system messages satisfy the foreign key
+	assert.NoError(t, DB.Create(systemMessage(gameID, &systemID)).Error)
+}
@@ -0,0 +1,23 @@
+package database
+
+import (
+	"fmt"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+)
+
+// migrateUserRoles gives accounts created before roles existed the player
+// role, which new accounts start with. Safe to run repeatedly.
+func migrateUserRoles() error {
+	result := DB.Model(&models.User{}).
+		Where("role IS NULL OR role = ?", "").
+		Update("role", models.UserRolePlayer)
+	if result.Error != nil {
+		return fmt.Errorf("failed to backfill user roles: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		logger.GetLogger().Info("Gave role-less accounts the player role", "users", result.RowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+	"dixitme/internal/testutils/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateUserRolesMakesRoleLessAccountsPlayers(t *testing.T) {
+	previous := DB
+	DB = testdb.Open(t, &models.User{})
+	t.Cleanup(func() { DB = previous })
+
+	legacy := models.User{ID: uuid.New(), Email: "old@example.com", Username: "old", AuthType: models.AuthTypePassword}
+	curator := models.User{ID: uuid.New(), Email: "cur@example.com", Username: "cur", AuthType: models.AuthTypePassword, Role: "curator"}
+	require.NoError(t, DB.Create(&legacy).Error)
+	require.NoError(t, DB.Create(&curator).Error)
+
+	require.NoError(t, migrateUserRoles())
+	require.NoError(t, migrateUserRoles())
+
+	roles := make(map[uuid.UUID]string)
+	var users []models.User
+	require.NoError(t, DB.Find(&users).Error)
+	for _, user := range users {
+		roles[user.ID] = user.Role
+	}
+	assert.Equal(t, map[uuid.UUID]string{legacy.ID: models.UserRolePlayer, curator.ID: "curator"}, roles)
+}
@@ -0,0 +1,172 @@
+// Package events is an in-process publish/subscribe bus with typed topics.
+// Publishers emit domain events without knowing who consumes them, and new
+// consumers (metrics, feeds, webhooks, notifications) subscribe without
+// changes to the publisher.
+package events
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+)
+
+// Topic names a stream of events of type T. Declaring topics as package
+// variables lets publishers and subscribers agree on the event type at
+// compile time.
+type Topic[T any] struct {
+	name string
+}
+
+// NewTopic declares a topic
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name}
+}
+
+// Name is the topic's name, as used in stats and metrics
+func (t Topic[T]) Name() string {
+	return t.name
+}
+
+// subscription is a handler of one topic, with its events' type erased
+type subscription struct {
+	id      uint64
+	handler func(any)
+}
+
+// topicState holds a topic's subscribers and counters
+type topicState struct {
+	subscriptions []subscription
+	published     atomic.Int64
+	failures      atomic.Int64
+	lastPublished atomic.Int64 // Unix nanoseconds, 0 if never
+}
+
+// TopicStats describes a topic's traffic, for the admin dashboard
+type TopicStats struct {
+	Topic           string     `json:"topic"`
+	Subscribers     int        `json:"subscribers"`
+	Published       int64      `json:"published"`
+	HandlerFailures int64      `json:"handler_failures"`
+	LastPublishedAt *time.Time `json:"last_published_at,omitempty"`
+}
+
+// Bus delivers published events to the topic's subscribers. Delivery is
+// synchronous and in subscription order, so a handler sees the publisher's
+// state as it was when the event was published; handlers with slow work
+// should hand it to a goroutine of their own.
+type Bus struct {
+	mu     sync.RWMutex
+	topics map[string]*topicState
+	nextID uint64
+}
+
+// NewBus creates an empty bus
+func NewBus() *Bus {
+	return &Bus{topics: make(map[string]*topicState)}
+}
+
+// topic returns a topic's state, creating it on first use. Callers hold the write lock.
+func (b *Bus) topic(name string) *topicState {
+	state, exists := b.topics[name]
+	if !exists {
+		state = &topicState{}
+		b.topics[name] = state
+	}
+	return state
+}
+
+// Subscribe calls handler with every event published on topic from now on.
+// The returned function unsubscribes it.
+func Subscribe[T any](b *Bus, topic Topic[T], handler func(T)) func() {
+	b.mu.Lock()
+	b.nextID++
+	id := b.nextID
+	state := b.topic(topic.name)
+	state.subscriptions = append(state.subscriptions, subscription{
+		id:      id,
+		handler: func(event any) { handler(event.(T)) },
+	})
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { b.unsubscribe(topic.name, id) })
+	}
+}
+
+func (b *Bus) unsubscribe(name string, id uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := b.topics[name]
+	for i, sub := range state.subscriptions {
+		if sub.id == id {
+			// Copy, so publishers iterating the old slice are unaffected
+			subscriptions := make([]subscription, 0, len(state.subscriptions)-1)
+			subscriptions = append(subscriptions, state.subscriptions[:i]...)
+			state.subscriptions = append(subscriptions, state.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish delivers event to topic's subscribers. A panicking handler is
+// logged and skipped; it doesn't stop the others or reach the publisher.
+// Publishing on a nil bus does nothing.
+func Publish[T any](b *Bus, topic Topic[T], event T) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	state := b.topic(topic.name)
+	subscriptions := state.subscriptions
+	b.mu.Unlock()
+
+	state.published.Add(1)
+	state.lastPublished.Store(time.Now().UnixNano())
+	metrics.GetCounter(metrics.Name("events_published_total", "topic", topic.name)).Inc()
+
+	for _, sub := range subscriptions {
+		deliver(topic.name, state, sub, event)
+	}
+}
+
+// deliver calls one handler, keeping a panicking one from taking the publisher down with it
+func deliver(name string, state *topicState, sub subscription, event any) {
+	defer func() {
+		if r := recover(); r != nil {
+			state.failures.Add(1)
+			metrics.GetCounter(metrics.Name("event_handler_panics_total", "topic", name)).Inc()
+			logger.Error("Event handler panicked", "topic", name, "panic", fmt.Sprint(r))
+		}
+	}()
+	sub.handler(event)
+}
+
+// Stats describes every topic that has been subscribed to or published on, by name
+func (b *Bus) Stats() []TopicStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	stats := make([]TopicStats, 0, len(b.topics))
+	for name, state := range b.topics {
+		topicStats := TopicStats{
+			Topic:           name,
+			Subscribers:     len(state.subscriptions),
+			Published:       state.published.Load(),
+			HandlerFailures: state.failures.Load(),
+		}
+		if last := state.lastPublished.Load(); last != 0 {
+			at := time.Unix(0, last)
+			topicStats.LastPublishedAt = &at
+		}
+		stats = append(stats, topicStats)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Topic < stats[j].Topic })
+	return stats
+}
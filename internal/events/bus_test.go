@@ -0,0 +1,48 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type joined struct {
+	Name string
+}
+
+var topicJoined = NewTopic[joined]("test.joined")
+
+func TestPublishReachesSubscribersInOrder(t *testing.T) {
+	bus := NewBus()
+	var seen []string
+	Subscribe(bus, topicJoined, func(e joined) { seen = append(seen, "stats:"+e.Name) })
+	Subscribe(bus, topicJoined, func(joined) { panic("boom") })
+	unsubscribe := Subscribe(bus, topicJoined, func(e joined) { seen = append(seen, "feed:"+e.Name) })
+
+	Publish(bus, topicJoined, joined{Name: "Ana"})
+	assert.Equal(t, []string{"stats:Ana", "feed:Ana"}, seen, "a panicking handler doesn't stop the others")
+
+	unsubscribe()
+	unsubscribe()
+	Publish(bus, topicJoined, joined{Name: "Bo"})
+	assert.Equal(t, []string{"stats:Ana", "feed:Ana", "stats:Bo"}, seen)
+
+	stats := bus.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, "test.joined", stats[0].Topic)
+	assert.Equal(t, 2, stats[0].Subscribers)
+	assert.Equal(t, int64(2), stats[0].Published)
+	assert.Equal(t, int64(2), stats[0].HandlerFailures)
+	assert.NotNil(t, stats[0].LastPublishedAt)
+}
+
+func TestPublishWithoutSubscribers(t *testing.T) {
+	bus := NewBus()
+	Publish(bus, topicJoined, joined{Name: "Ana"})
+
+	stats := bus.Stats()
+	require.Len(t, stats, 1)
+	assert.Zero(t, stats[0].Subscribers)
+	assert.Equal(t, int64(1), stats[0].Published)
+}
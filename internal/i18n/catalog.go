@@ -0,0 +1,276 @@
+package i18n
+
+// catalogs holds the translations of each supported locale, keyed by the
+// English source text. Placeholders may be reordered but must all be kept.
+var catalogs = map[string]map[string]string{
+	"fr": {
+		// System chat
+		"{1} joined the game":                              "{1} a rejoint la partie",
+		"Bot {1} ({2} difficulty) joined the game":         "Le bot {1} (difficulté {2}) a rejoint la partie",
+		"{1} left the game":                                "{1} a quitté la partie",
+		"Player {1} left the game":                         "Le joueur {1} a quitté la partie",
+		"Bot {1} left the game":                            "Le bot {1} a quitté la partie",
+		"Player {1} went AFK and may be replaced by a bot": "Le joueur {1} est inactif et pourrait être remplacé par un bot",
+		"Bot {1} went AFK and may be replaced by a bot":    "Le bot {1} est inactif et pourrait être remplacé par un bot",
+		"Game has been deleted by the lobby manager":       "La partie a été supprimée par l'hôte",
+		"Game started! Let the storytelling begin!":        "La partie commence ! Place aux histoires !",
+		"{1} was replaced by {2} ({3})":                    "{1} a été remplacé par {2} ({3})",
+		"Game ended: All players went AFK":                 "Partie terminée : tous les joueurs sont inactifs",
+		"{1} took a mulligan and drew a new hand":          "{1} a changé de main et pioché de nouvelles cartes",
+		"Round {1} twist - {2}: {3}":                       "Variante de la manche {1} - {2} : {3}",
+		"Game ended: {1} reached {2} points!":              "Partie terminée : {1} a atteint {2} points !",
+		"Time ran out: a bot played for {1}":               "Temps écoulé : un bot a joué pour {1}",
+		"{1} is now the host":                              "{1} est maintenant l'hôte",
+		"Deck running low: round {1} is the last!":         "La pioche s'épuise : la manche {1} sera la dernière !",
+		"The request is invalid":                           "La requête est invalide",
+		"The request body is missing":                      "Le corps de la requête est manquant",
+		"The request body is not valid JSON":               "Le corps de la requête n'est pas du JSON valide",
+		"{1} is required":                                  "{1} est obligatoire",
+		"{1} has the wrong type":                           "{1} n'a pas le bon type",
+		"{1} is invalid":                                   "{1} est invalide",
+		"{1} must be an email address":                     "{1} doit être une adresse e-mail",
+		"{1} must be a valid ID":                           "{1} doit être un identifiant valide",
+		"{1} must be one of: {2}":                          "{1} doit valoir l'une de ces valeurs : {2}",
+		"{1} must be at least {2} characters":              "{1} doit contenir au moins {2} caractères",
+		"{1} must be at most {2} characters":               "{1} doit contenir au plus {2} caractères",
+		"{1} needs at least {2} items":                     "{1} doit contenir au moins {2} éléments",
+		"{1} allows at most {2} items":                     "{1} accepte au plus {2} éléments",
+		"{1} must be at least {2}":                         "{1} doit être au moins {2}",
+		"{1} must be at most {2}":                          "{1} doit être au plus {2}",
+		"{1} started a vote to kick {2}":                   "{1} a lancé un vote pour exclure {2}",
+		"The vote to kick {1} failed":                      "Le vote pour exclure {1} a échoué",
+		"{1} asks to take over {2}'s seat":                 "{1} demande à reprendre la place de {2}",
+		"{1} took over {2}'s seat":                         "{1} a repris la place de {2}",
+		"{1} started a rematch":                            "{1} a lancé une revanche",
+		"Game ended: No more cards in deck!":               "Partie terminée : la pioche est vide !",
+
+		// Errors
+		"game not found":                    "partie introuvable",
+		"game is full":                      "la partie est complète",
+		"game already started":              "la partie a déjà commencé",
+		"player not in game":                "vous ne participez pas à cette partie",
+		"no active round":                   "aucune manche en cours",
+		"only storyteller can submit clue":  "seul le conteur peut donner un indice",
+		"storyteller cannot submit cards":   "le conteur ne peut pas proposer de carte",
+		"storyteller cannot vote":           "le conteur ne peut pas voter",
+		"card not in player's hand":         "cette carte n'est pas dans votre main",
+		"card already submitted":            "vous avez déjà proposé une carte",
+		"already voted":                     "vous avez déjà voté",
+		"need at least 3 players to start":  "il faut au moins 3 joueurs pour commencer",
+		"chat not allowed in current phase": "le chat est fermé pendant cette phase",
+		"message cannot be empty":           "le message ne peut pas être vide",
+		"message too long":                  "message trop long",
+
+		// Chat moderation
+		"The host paused the chat":                       "L'hôte a mis le chat en pause",
+		"The host resumed the chat":                      "L'hôte a réactivé le chat",
+		"Slow mode is on: one message every {1} seconds": "Mode lent activé : un message toutes les {1} secondes",
+		"Slow mode is off":                               "Mode lent désactivé",
+		"The host turned off emotes":                     "L'hôte a désactivé les émotes",
+		"The host turned on emotes":                      "L'hôte a activé les émotes",
+		"the host has paused the chat":                   "l'hôte a mis le chat en pause",
+		"the host has turned off emotes":                 "l'hôte a désactivé les émotes",
+		"only the host can moderate the chat":            "seul l'hôte peut modérer le chat",
+	},
+	"es": {
+		"{1} joined the game":                              "{1} se unió a la partida",
+		"Bot {1} ({2} difficulty) joined the game":         "El bot {1} (dificultad {2}) se unió a la partida",
+		"{1} left the game":                                "{1} salió de la partida",
+		"Player {1} left the game":                         "El jugador {1} salió de la partida",
+		"Bot {1} left the game":                            "El bot {1} salió de la partida",
+		"Player {1} went AFK and may be replaced by a bot": "El jugador {1} está ausente y podría ser reemplazado por un bot",
+		"Bot {1} went AFK and may be replaced by a bot":    "El bot {1} está ausente y podría ser reemplazado por un bot",
+		"Game has been deleted by the lobby manager":       "El anfitrión eliminó la partida",
+		"Game started! Let the storytelling begin!":        "¡Empieza la partida! ¡Que comiencen las historias!",
+		"{1} was replaced by {2} ({3})":                    "{1} fue reemplazado por {2} ({3})",
+		"Game ended: All players went AFK":                 "Fin de la partida: todos los jugadores están ausentes",
+		"{1} took a mulligan and drew a new hand":          "{1} cambió su mano y robó cartas nuevas",
+		"Round {1} twist - {2}: {3}":                       "Giro de la ronda {1} - {2}: {3}",
+		"Game ended: {1} reached {2} points!":              "Fin de la partida: ¡{1} llegó a {2} puntos!",
+		"Time ran out: a bot played for {1}":               "Se acabó el tiempo: un bot jugó por {1}",
+		"{1} is now the host":                              "{1} es ahora el anfitrión",
+		"Deck running low: round {1} is the last!":         "El mazo se está agotando: ¡la ronda {1} será la última!",
+		"The request is invalid":                           "La solicitud no es válida",
+		"The request body is missing":                      "Falta el cuerpo de la solicitud",
+		"The request body is not valid JSON":               "El cuerpo de la solicitud no es JSON válido",
+		"{1} is required":                                  "{1} es obligatorio",
+		"{1} has the wrong type":                           "{1} tiene un tipo incorrecto",
+		"{1} is invalid":                                   "{1} no es válido",
+		"{1} must be an email address":                     "{1} debe ser una dirección de correo",
+		"{1} must be a valid ID":                           "{1} debe ser un identificador válido",
+		"{1} must be one of: {2}":                          "{1} debe ser uno de: {2}",
+		"{1} must be at least {2} characters":              "{1} debe tener al menos {2} caracteres",
+		"{1} must be at most {2} characters":               "{1} debe tener como máximo {2} caracteres",
+		"{1} needs at least {2} items":                     "{1} necesita al menos {2} elementos",
+		"{1} allows at most {2} items":                     "{1} admite como máximo {2} elementos",
+		"{1} must be at least {2}":                         "{1} debe ser al menos {2}",
+		"{1} must be at most {2}":                          "{1} debe ser como máximo {2}",
+		"{1} started a vote to kick {2}":                   "{1} inició una votación para expulsar a {2}",
+		"The vote to kick {1} failed":                      "La votación para expulsar a {1} fracasó",
+		"{1} asks to take over {2}'s seat":                 "{1} pide ocupar el puesto de {2}",
+		"{1} took over {2}'s seat":                         "{1} ocupó el puesto de {2}",
+		"{1} started a rematch":                            "{1} inició una revancha",
+		"Game ended: No more cards in deck!":               "Fin de la partida: ¡no quedan cartas en el mazo!",
+
+		"game not found":                    "partida no encontrada",
+		"game is full":                      "la partida está llena",
+		"game already started":              "la partida ya comenzó",
+		"player not in game":                "no estás en esta partida",
+		"no active round":                   "no hay ninguna ronda en curso",
+		"only storyteller can submit clue":  "solo el narrador puede dar la pista",
+		"storyteller cannot submit cards":   "el narrador no puede enviar cartas",
+		"storyteller cannot vote":           "el narrador no puede votar",
+		"card not in player's hand":         "esa carta no está en tu mano",
+		"card already submitted":            "ya enviaste una carta",
+		"already voted":                     "ya votaste",
+		"need at least 3 players to start":  "se necesitan al menos 3 jugadores para empezar",
+		"chat not allowed in current phase": "el chat no está disponible en esta fase",
+		"message cannot be empty":           "el mensaje no puede estar vacío",
+		"message too long":                  "mensaje demasiado largo",
+
+		// Chat moderation
+		"The host paused the chat":                       "El anfitrión pausó el chat",
+		"The host resumed the chat":                      "El anfitrión reanudó el chat",
+		"Slow mode is on: one message every {1} seconds": "Modo lento activado: un mensaje cada {1} segundos",
+		"Slow mode is off":                               "Modo lento desactivado",
+		"The host turned off emotes":                     "El anfitrión desactivó los emotes",
+		"The host turned on emotes":                      "El anfitrión activó los emotes",
+		"the host has paused the chat":                   "el anfitrión pausó el chat",
+		"the host has turned off emotes":                 "el anfitrión desactivó los emotes",
+		"only the host can moderate the chat":            "solo el anfitrión puede moderar el chat",
+	},
+	"de": {
+		"{1} joined the game":                              "{1} ist dem Spiel beigetreten",
+		"Bot {1} ({2} difficulty) joined the game":         "Bot {1} (Schwierigkeit {2}) ist dem Spiel beigetreten",
+		"{1} left the game":                                "{1} hat das Spiel verlassen",
+		"Player {1} left the game":                         "Spieler {1} hat das Spiel verlassen",
+		"Bot {1} left the game":                            "Bot {1} hat das Spiel verlassen",
+		"Player {1} went AFK and may be replaced by a bot": "Spieler {1} ist inaktiv und wird eventuell durch einen Bot ersetzt",
+		"Bot {1} went AFK and may be replaced by a bot":    "Bot {1} ist inaktiv und wird eventuell durch einen Bot ersetzt",
+		"Game has been deleted by the lobby manager":       "Das Spiel wurde vom Gastgeber gelöscht",
+		"Game started! Let the storytelling begin!":        "Das Spiel beginnt! Lasst die Geschichten beginnen!",
+		"{1} was replaced by {2} ({3})":                    "{1} wurde durch {2} ersetzt ({3})",
+		"Game ended: All players went AFK":                 "Spiel beendet: Alle Spieler sind inaktiv",
+		"{1} took a mulligan and drew a new hand":          "{1} hat die Hand getauscht und neue Karten gezogen",
+		"Round {1} twist - {2}: {3}":                       "Besonderheit in Runde {1} - {2}: {3}",
+		"Game ended: {1} reached {2} points!":              "Spiel beendet: {1} hat {2} Punkte erreicht!",
+		"Time ran out: a bot played for {1}":               "Die Zeit ist abgelaufen: Ein Bot hat für {1} gespielt",
+		"{1} is now the host":                              "{1} ist jetzt der Gastgeber",
+		"Deck running low: round {1} is the last!":         "Der Stapel geht zur Neige: Runde {1} wird die letzte sein!",
+		"The request is invalid":                           "Die Anfrage ist ungültig",
+		"The request body is missing":                      "Der Anfragetext fehlt",
+		"The request body is not valid JSON":               "Der Anfragetext ist kein gültiges JSON",
+		"{1} is required":                                  "{1} ist erforderlich",
+		"{1} has the wrong type":                           "{1} hat den falschen Typ",
+		"{1} is invalid":                                   "{1} ist ungültig",
+		"{1} must be an email address":                     "{1} muss eine E-Mail-Adresse sein",
+		"{1} must be a valid ID":                           "{1} muss eine gültige ID sein",
+		"{1} must be one of: {2}":                          "{1} muss einer der folgenden Werte sein: {2}",
+		"{1} must be at least {2} characters":              "{1} muss mindestens {2} Zeichen lang sein",
+		"{1} must be at most {2} characters":               "{1} darf höchstens {2} Zeichen lang sein",
+		"{1} needs at least {2} items":                     "{1} braucht mindestens {2} Einträge",
+		"{1} allows at most {2} items":                     "{1} erlaubt höchstens {2} Einträge",
+		"{1} must be at least {2}":                         "{1} muss mindestens {2} sein",
+		"{1} must be at most {2}":                          "{1} darf höchstens {2} sein",
+		"{1} started a vote to kick {2}":                   "{1} hat eine Abstimmung gestartet, um {2} rauszuwerfen",
+		"The vote to kick {1} failed":                      "Die Abstimmung, {1} rauszuwerfen, ist gescheitert",
+		"{1} asks to take over {2}'s seat":                 "{1} möchte den Platz von {2} übernehmen",
+		"{1} took over {2}'s seat":                         "{1} hat den Platz von {2} übernommen",
+		"{1} started a rematch":                            "{1} hat eine Revanche gestartet",
+		"Game ended: No more cards in deck!":               "Spiel beendet: Der Stapel ist leer!",
+
+		"game not found":                    "Spiel nicht gefunden",
+		"game is full":                      "Das Spiel ist voll",
+		"game already started":              "Das Spiel hat bereits begonnen",
+		"player not in game":                "Du bist nicht in diesem Spiel",
+		"no active round":                   "Keine laufende Runde",
+		"only storyteller can submit clue":  "Nur der Erzähler kann den Hinweis geben",
+		"storyteller cannot submit cards":   "Der Erzähler kann keine Karte einreichen",
+		"storyteller cannot vote":           "Der Erzähler kann nicht abstimmen",
+		"card not in player's hand":         "Diese Karte ist nicht auf deiner Hand",
+		"card already submitted":            "Du hast bereits eine Karte eingereicht",
+		"already voted":                     "Du hast bereits abgestimmt",
+		"need at least 3 players to start":  "Zum Starten werden mindestens 3 Spieler benötigt",
+		"chat not allowed in current phase": "Der Chat ist in dieser Phase gesperrt",
+		"message cannot be empty":           "Die Nachricht darf nicht leer sein",
+		"message too long":                  "Nachricht zu lang",
+
+		// Chat moderation
+		"The host paused the chat":                       "Der Gastgeber hat den Chat pausiert",
+		"The host resumed the chat":                      "Der Gastgeber hat den Chat wieder freigegeben",
+		"Slow mode is on: one message every {1} seconds": "Langsamer Modus aktiv: eine Nachricht alle {1} Sekunden",
+		"Slow mode is off":                               "Langsamer Modus aus",
+		"The host turned off emotes":                     "Der Gastgeber hat Emotes deaktiviert",
+		"The host turned on emotes":                      "Der Gastgeber hat Emotes aktiviert",
+		"the host has paused the chat":                   "Der Gastgeber hat den Chat pausiert",
+		"the host has turned off emotes":                 "Der Gastgeber hat Emotes deaktiviert",
+		"only the host can moderate the chat":            "Nur der Gastgeber kann den Chat moderieren",
+	},
+	"vi": {
+		"{1} joined the game":                              "{1} đã tham gia ván chơi",
+		"Bot {1} ({2} difficulty) joined the game":         "Bot {1} (độ khó {2}) đã tham gia ván chơi",
+		"{1} left the game":                                "{1} đã rời ván chơi",
+		"Player {1} left the game":                         "Người chơi {1} đã rời ván chơi",
+		"Bot {1} left the game":                            "Bot {1} đã rời ván chơi",
+		"Player {1} went AFK and may be replaced by a bot": "Người chơi {1} đang vắng mặt và có thể bị thay bằng bot",
+		"Bot {1} went AFK and may be replaced by a bot":    "Bot {1} đang vắng mặt và có thể bị thay bằng bot",
+		"Game has been deleted by the lobby manager":       "Chủ phòng đã xoá ván chơi",
+		"Game started! Let the storytelling begin!":        "Ván chơi bắt đầu! Hãy cùng kể chuyện nào!",
+		"{1} was replaced by {2} ({3})":                    "{1} đã được thay bằng {2} ({3})",
+		"Game ended: All players went AFK":                 "Ván chơi kết thúc: tất cả người chơi đều vắng mặt",
+		"{1} took a mulligan and drew a new hand":          "{1} đã đổi bài và rút bộ bài mới",
+		"Round {1} twist - {2}: {3}":                       "Biến thể vòng {1} - {2}: {3}",
+		"Game ended: {1} reached {2} points!":              "Ván chơi kết thúc: {1} đã đạt {2} điểm!",
+		"Time ran out: a bot played for {1}":               "Hết giờ: một bot đã chơi thay {1}",
+		"{1} is now the host":                              "{1} giờ là chủ phòng",
+		"Deck running low: round {1} is the last!":         "Bộ bài sắp hết: vòng {1} sẽ là vòng cuối!",
+		"The request is invalid":                           "Yêu cầu không hợp lệ",
+		"The request body is missing":                      "Thiếu nội dung yêu cầu",
+		"The request body is not valid JSON":               "Nội dung yêu cầu không phải JSON hợp lệ",
+		"{1} is required":                                  "{1} là bắt buộc",
+		"{1} has the wrong type":                           "{1} sai kiểu dữ liệu",
+		"{1} is invalid":                                   "{1} không hợp lệ",
+		"{1} must be an email address":                     "{1} phải là địa chỉ email",
+		"{1} must be a valid ID":                           "{1} phải là ID hợp lệ",
+		"{1} must be one of: {2}":                          "{1} phải là một trong: {2}",
+		"{1} must be at least {2} characters":              "{1} phải có ít nhất {2} ký tự",
+		"{1} must be at most {2} characters":               "{1} chỉ được có tối đa {2} ký tự",
+		"{1} needs at least {2} items":                     "{1} cần ít nhất {2} mục",
+		"{1} allows at most {2} items":                     "{1} cho phép tối đa {2} mục",
+		"{1} must be at least {2}":                         "{1} phải ít nhất là {2}",
+		"{1} must be at most {2}":                          "{1} chỉ được tối đa là {2}",
+		"{1} started a vote to kick {2}":                   "{1} đã mở bỏ phiếu để loại {2}",
+		"The vote to kick {1} failed":                      "Cuộc bỏ phiếu loại {1} không thành công",
+		"{1} asks to take over {2}'s seat":                 "{1} xin thế chỗ của {2}",
+		"{1} took over {2}'s seat":                         "{1} đã thế chỗ của {2}",
+		"{1} started a rematch":                            "{1} đã bắt đầu ván đấu lại",
+		"Game ended: No more cards in deck!":               "Ván chơi kết thúc: đã hết bài!",
+
+		"game not found":                    "không tìm thấy ván chơi",
+		"game is full":                      "ván chơi đã đủ người",
+		"game already started":              "ván chơi đã bắt đầu",
+		"player not in game":                "bạn không ở trong ván chơi này",
+		"no active round":                   "không có vòng nào đang diễn ra",
+		"only storyteller can submit clue":  "chỉ người kể chuyện mới được đưa gợi ý",
+		"storyteller cannot submit cards":   "người kể chuyện không thể nộp bài",
+		"storyteller cannot vote":           "người kể chuyện không thể bình chọn",
+		"card not in player's hand":         "lá bài này không có trong tay bạn",
+		"card already submitted":            "bạn đã nộp bài rồi",
+		"already voted":                     "bạn đã bình chọn rồi",
+		"need at least 3 players to start":  "cần ít nhất 3 người chơi để bắt đầu",
+		"chat not allowed in current phase": "không thể trò chuyện trong giai đoạn này",
+		"message cannot be empty":           "tin nhắn không được để trống",
+		"message too long":                  "tin nhắn quá dài",
+
+		// Chat moderation
+		"The host paused the chat":                       "Chủ phòng đã tạm dừng trò chuyện",
+		"The host resumed the chat":                      "Chủ phòng đã mở lại trò chuyện",
+		"Slow mode is on: one message every {1} seconds": "Chế độ chậm đang bật: mỗi {1} giây một tin nhắn",
+		"Slow mode is off":                               "Chế độ chậm đã tắt",
+		"The host turned off emotes":                     "Chủ phòng đã tắt biểu cảm",
+		"The host turned on emotes":                      "Chủ phòng đã bật biểu cảm",
+		"the host has paused the chat":                   "chủ phòng đã tạm dừng trò chuyện",
+		"the host has turned off emotes":                 "chủ phòng đã tắt biểu cảm",
+		"only the host can moderate the chat":            "chỉ chủ phòng mới có thể quản lý trò chuyện",
+	},
+}
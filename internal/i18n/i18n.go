@@ -0,0 +1,114 @@
+// Package i18n localizes text the server generates, such as system chat
+// messages and errors. Catalogs are keyed by the English source text, so
+// untranslated strings fall back to English without any extra bookkeeping.
+package i18n
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is the source language of every server string
+const DefaultLocale = "en"
+
+// Supported returns the locales the server can localize into
+func Supported() []string {
+	locales := []string{DefaultLocale}
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// Normalize maps a language tag such as "fr-CA" or "FR" to a supported
+// locale, returning "" when the language isn't supported
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	tag = strings.ReplaceAll(tag, "_", "-")
+	base, _, _ := strings.Cut(tag, "-")
+	if base == DefaultLocale {
+		return DefaultLocale
+	}
+	if _, ok := catalogs[base]; ok {
+		return base
+	}
+	return ""
+}
+
+// Negotiate picks the best supported locale from an Accept-Language header,
+// falling back to the default locale
+func Negotiate(acceptLanguage string) string {
+	best, bestQ := DefaultLocale, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if locale := Normalize(tag); locale != "" && q > bestQ {
+			best, bestQ = locale, q
+		}
+	}
+	return best
+}
+
+// FromRequest returns the locale a client asked for: an explicit ?locale=
+// query parameter, reported as explicit, or else the Accept-Language header.
+// An unsupported explicit locale is ignored.
+func FromRequest(r *http.Request) (locale string, explicit bool) {
+	if locale := Normalize(r.URL.Query().Get("locale")); locale != "" {
+		return locale, true
+	}
+	if header := r.Header.Get("Accept-Language"); header != "" {
+		return Negotiate(header), false
+	}
+	return "", false
+}
+
+// T translates an English source string into a locale and fills in its
+// positional placeholders {1}, {2}, ... with args
+func T(locale, text string, args ...string) string {
+	if catalog, ok := catalogs[Normalize(locale)]; ok {
+		if translated, ok := catalog[text]; ok {
+			text = translated
+		}
+	}
+	if len(args) == 0 {
+		return text
+	}
+
+	pairs := make([]string, 0, len(args)*2)
+	for i, arg := range args {
+		pairs = append(pairs, "{"+strconv.Itoa(i+1)+"}", arg)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// Message is a source string and its arguments, kept apart so it can be
+// rendered for each recipient
+type Message struct {
+	Text string   `json:"text"`
+	Args []string `json:"args,omitempty"`
+}
+
+// Msg builds a message from an English source string
+func Msg(text string, args ...string) Message {
+	return Message{Text: text, Args: args}
+}
+
+// Localize renders the message in a locale
+func (m Message) Localize(locale string) string {
+	return T(locale, m.Text, m.Args...)
+}
+
+// String renders the message in the default locale
+func (m Message) String() string {
+	return m.Localize(DefaultLocale)
+}
@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "fr", Normalize("fr-CA"))
+	assert.Equal(t, "vi", Normalize(" VI_vn "))
+	assert.Equal(t, "en", Normalize("en-GB"))
+	assert.Equal(t, "", Normalize("ja"))
+	assert.Equal(t, "", Normalize(""))
+}
+
+func TestNegotiate(t *testing.T) {
+	assert.Equal(t, "de", Negotiate("de-DE,de;q=0.9,en;q=0.8"))
+	assert.Equal(t, "es", Negotiate("ja;q=1.0, es;q=0.7, fr;q=0.5"))
+	assert.Equal(t, "fr", Negotiate("en;q=0.4, fr;q=0.6"))
+	assert.Equal(t, DefaultLocale, Negotiate("ja, zh"))
+	assert.Equal(t, DefaultLocale, Negotiate("fr;q=bogus"))
+}
+
+func TestFromRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/ws?locale=es", nil)
+	r.Header.Set("Accept-Language", "fr")
+	locale, explicit := FromRequest(r)
+	assert.Equal(t, "es", locale)
+	assert.True(t, explicit)
+
+	r = httptest.NewRequest("GET", "/ws?locale=klingon", nil)
+	r.Header.Set("Accept-Language", "fr")
+	locale, explicit = FromRequest(r)
+	assert.Equal(t, "fr", locale)
+	assert.False(t, explicit)
+
+	locale, _ = FromRequest(httptest.NewRequest("GET", "/ws", nil))
+	assert.Equal(t, "", locale)
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Alice a rejoint la partie", T("fr", "{1} joined the game", "Alice"))
+	assert.Equal(t, "Alice joined the game", T("en", "{1} joined the game", "Alice"))
+	assert.Equal(t, "Alice joined the game", T("ja", "{1} joined the game", "Alice"))
+	assert.Equal(t, "not translated yet", T("fr", "not translated yet"))
+	// Arguments are substituted once, so placeholders inside them survive
+	assert.Equal(t, "{2} a rejoint la partie", T("fr", "{1} joined the game", "{2}"))
+}
+
+func TestCatalogsKeepPlaceholders(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for source, translated := range catalog {
+			for i := 1; i <= 3; i++ {
+				placeholder := "{" + strconv.Itoa(i) + "}"
+				assert.Equal(t, strings.Contains(source, placeholder), strings.Contains(translated, placeholder),
+					"%s: %q lost or gained %s", locale, source, placeholder)
+			}
+		}
+	}
+}
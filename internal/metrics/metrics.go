@@ -0,0 +1,255 @@
+// Package metrics provides a lightweight in-process metrics registry.
+// Counters, gauges and histograms are registered lazily by name and exposed
+// as a snapshot through the admin API.
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Name formats a metric name with label pairs, e.g.
+// Name("disconnects_total", "phase", "voting") is `disconnects_total{phase="voting"}`
+func Name(base string, labels ...string) string {
+	if len(labels) < 2 {
+		return base
+	}
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, labels[i]+"=\""+labels[i+1]+"\"")
+	}
+	return base + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Counter is a monotonically increasing value
+type Counter struct {
+	value atomic.Int64
+}
+
+// Inc increments the counter by one
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Add increments the counter by n (negative values are ignored)
+func (c *Counter) Add(n int64) {
+	if n > 0 {
+		c.value.Add(n)
+	}
+}
+
+// Value returns the current counter value
+func (c *Counter) Value() int64 {
+	return c.value.Load()
+}
+
+// Gauge is a value that can go up and down
+type Gauge struct {
+	value atomic.Int64
+}
+
+// Set sets the gauge to the given value
+func (g *Gauge) Set(n int64) {
+	g.value.Store(n)
+}
+
+// Add adds n (which may be negative) to the gauge
+func (g *Gauge) Add(n int64) {
+	g.value.Add(n)
+}
+
+// Value returns the current gauge value
+func (g *Gauge) Value() int64 {
+	return g.value.Load()
+}
+
+// Histogram counts observations into cumulative buckets by upper bound
+type Histogram struct {
+	bounds  []int64
+	buckets []atomic.Int64 // One per bound, plus a final +Inf bucket
+	count   atomic.Int64
+	sum     atomic.Int64
+}
+
+func newHistogram(bounds []int64) *Histogram {
+	bounds = append([]int64(nil), bounds...)
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+	return &Histogram{bounds: bounds, buckets: make([]atomic.Int64, len(bounds)+1)}
+}
+
+// Observe records one value
+func (h *Histogram) Observe(v int64) {
+	i := sort.Search(len(h.bounds), func(i int) bool { return v <= h.bounds[i] })
+	h.buckets[i].Add(1)
+	h.count.Add(1)
+	h.sum.Add(v)
+}
+
+// Count returns the number of observations
+func (h *Histogram) Count() int64 {
+	return h.count.Load()
+}
+
+// Sum returns the total of all observed values
+func (h *Histogram) Sum() int64 {
+	return h.sum.Load()
+}
+
+// snapshot adds the histogram to a snapshot as name_bucket{le="..."},
+// name_sum and name_count, with buckets cumulative like Prometheus
+func (h *Histogram) snapshot(name string, into map[string]int64) {
+	base, labels, _ := strings.Cut(name, "{")
+	labels = strings.TrimSuffix(labels, "}")
+	withLabels := func(suffix, extra string) string {
+		all := labels
+		if extra != "" {
+			if all != "" {
+				all += ","
+			}
+			all += extra
+		}
+		if all == "" {
+			return base + suffix
+		}
+		return base + suffix + "{" + all + "}"
+	}
+
+	var cumulative int64
+	for i := range h.buckets {
+		cumulative += h.buckets[i].Load()
+		le := "+Inf"
+		if i < len(h.bounds) {
+			le = strconv.FormatInt(h.bounds[i], 10)
+		}
+		into[withLabels("_bucket", "le=\""+le+"\"")] = cumulative
+	}
+	into[withLabels("_sum", "")] = h.Sum()
+	into[withLabels("_count", "")] = h.Count()
+}
+
+// Registry holds named metrics
+type Registry struct {
+	mu         sync.RWMutex
+	counters   map[string]*Counter
+	gauges     map[string]*Gauge
+	histograms map[string]*Histogram
+}
+
+// NewRegistry creates an empty metrics registry
+func NewRegistry() *Registry {
+	return &Registry{
+		counters:   make(map[string]*Counter),
+		gauges:     make(map[string]*Gauge),
+		histograms: make(map[string]*Histogram),
+	}
+}
+
+// Counter returns the counter with the given name, creating it if needed
+func (r *Registry) Counter(name string) *Counter {
+	r.mu.RLock()
+	c, exists := r.counters[name]
+	r.mu.RUnlock()
+	if exists {
+		return c
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, exists = r.counters[name]; !exists {
+		c = &Counter{}
+		r.counters[name] = c
+	}
+	return c
+}
+
+// Gauge returns the gauge with the given name, creating it if needed
+func (r *Registry) Gauge(name string) *Gauge {
+	r.mu.RLock()
+	g, exists := r.gauges[name]
+	r.mu.RUnlock()
+	if exists {
+		return g
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if g, exists = r.gauges[name]; !exists {
+		g = &Gauge{}
+		r.gauges[name] = g
+	}
+	return g
+}
+
+// Histogram returns the histogram with the given name, creating it with the
+// given bucket bounds if needed. Bounds are ignored for an existing histogram.
+func (r *Registry) Histogram(name string, bounds []int64) *Histogram {
+	r.mu.RLock()
+	h, exists := r.histograms[name]
+	r.mu.RUnlock()
+	if exists {
+		return h
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if h, exists = r.histograms[name]; !exists {
+		h = newHistogram(bounds)
+		r.histograms[name] = h
+	}
+	return h
+}
+
+// Snapshot returns the current value of every registered metric
+func (r *Registry) Snapshot() map[string]int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := make(map[string]int64, len(r.counters)+len(r.gauges))
+	for name, c := range r.counters {
+		snapshot[name] = c.Value()
+	}
+	for name, g := range r.gauges {
+		snapshot[name] = g.Value()
+	}
+	for name, h := range r.histograms {
+		h.snapshot(name, snapshot)
+	}
+	return snapshot
+}
+
+// Names returns the sorted names of all registered metrics
+func (r *Registry) Names() []string {
+	snapshot := r.Snapshot()
+	names := make([]string, 0, len(snapshot))
+	for name := range snapshot {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Global registry used by the application
+var defaultRegistry = NewRegistry()
+
+// GetCounter returns a counter from the global registry
+func GetCounter(name string) *Counter {
+	return defaultRegistry.Counter(name)
+}
+
+// GetGauge returns a gauge from the global registry
+func GetGauge(name string) *Gauge {
+	return defaultRegistry.Gauge(name)
+}
+
+// GetHistogram returns a histogram from the global registry
+func GetHistogram(name string, bounds []int64) *Histogram {
+	return defaultRegistry.Histogram(name, bounds)
+}
+
+// Snapshot returns the values of all metrics in the global registry
+func Snapshot() map[string]int64 {
+	return defaultRegistry.Snapshot()
+}
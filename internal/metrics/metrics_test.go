@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "plain_total", Name("plain_total"))
+	assert.Equal(t, `x_total{phase="voting",room_size="4"}`, Name("x_total", "phase", "voting", "room_size", "4"))
+}
+
+func TestHistogramSnapshot(t *testing.T) {
+	r := NewRegistry()
+	h := r.Histogram(Name("wait_seconds", "phase", "voting"), []int64{60, 10})
+	for _, v := range []int64{5, 10, 30, 120} {
+		h.Observe(v)
+	}
+
+	snapshot := r.Snapshot()
+	assert.Equal(t, int64(2), snapshot[`wait_seconds_bucket{phase="voting",le="10"}`])
+	assert.Equal(t, int64(3), snapshot[`wait_seconds_bucket{phase="voting",le="60"}`])
+	assert.Equal(t, int64(4), snapshot[`wait_seconds_bucket{phase="voting",le="+Inf"}`])
+	assert.Equal(t, int64(165), snapshot[`wait_seconds_sum{phase="voting"}`])
+	assert.Equal(t, int64(4), snapshot[`wait_seconds_count{phase="voting"}`])
+}
+
+func TestHistogramWithoutLabels(t *testing.T) {
+	r := NewRegistry()
+	r.Histogram("latency", []int64{1}).Observe(2)
+
+	snapshot := r.Snapshot()
+	assert.Equal(t, int64(0), snapshot[`latency_bucket{le="1"}`])
+	assert.Equal(t, int64(1), snapshot[`latency_bucket{le="+Inf"}`])
+	assert.Equal(t, int64(1), snapshot["latency_count"])
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Activity event types
+const (
+	ActivityGamePlayed          = "game_played"
+	ActivityRatingChanged       = "rating_changed"
+	ActivityAchievementUnlocked = "achievement_unlocked"
+	ActivityFriendRequest       = "friend_request"
+	ActivityStandInResult       = "stand_in_result" // A game finished by the bot that replaced the player
+)
+
+// ActivityEvent is one entry in a player's activity feed
+type ActivityEvent struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	PlayerID  uuid.UUID  `json:"player_id" gorm:"type:uuid;not null;index:idx_activity_player_created,priority:1"`
+	Type      string     `json:"type" gorm:"size:32;not null"`
+	GameID    *uuid.UUID `json:"game_id,omitempty" gorm:"type:uuid"`
+	Details   string     `json:"-" gorm:"type:text"` // JSON encoded, shape depends on Type
+	CreatedAt time.Time  `json:"created_at" gorm:"index:idx_activity_player_created,priority:2,sort:desc"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BotPolicyOverrideID is the key of the only bot difficulty policy row
+const BotPolicyOverrideID = 1
+
+// BotPolicyOverride holds the bot difficulty policy set by admins at runtime
+type BotPolicyOverride struct {
+	ID        int        `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	Policy    string     `json:"-" gorm:"type:text"` // JSON-encoded bot.DifficultyPolicy
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
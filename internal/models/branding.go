@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BrandingOverrideID is the key of the only branding override row
+const BrandingOverrideID = 1
+
+// BrandingOverride holds branding set by admins at runtime, on top of the
+// deployment's configured branding
+type BrandingOverride struct {
+	ID        int        `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	Branding  string     `json:"-" gorm:"type:text"` // JSON-encoded branding.Branding
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
@@ -1,7 +1,12 @@
 package models
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Card represents a game card with tags for categorization and bot AI
@@ -11,12 +16,87 @@ type Card struct {
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
 	Extension   string    `json:"extension" gorm:"default:'.jpg'"`
+	ImageHash   string    `json:"image_hash,omitempty" gorm:"size:16;index"` // Perceptual hash of the image, for duplicate detection
 	IsActive    bool      `json:"is_active" gorm:"default:true"`
+	Version     int       `json:"version" gorm:"not null;default:1"` // Incremented on every metadata edit
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
 	// Relationships
 	Tags []CardTag `json:"tags" gorm:"many2many:card_tag_relations;"`
+
+	// Downscaled copy served for the thumb art variant; the full image is used when empty
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+
+	CardAttribution
+}
+
+// Attribution field limits
+const (
+	MaxArtistLength    = 200
+	MaxSourceURLLength = 500
+	MaxLicenseLength   = 100
+)
+
+// CardAttribution credits a card's art. Cards from custom decks need an
+// artist and a license before they can be published; the source is optional.
+type CardAttribution struct {
+	Artist    string `json:"artist,omitempty" gorm:"size:200;index"`
+	SourceURL string `json:"source_url,omitempty" gorm:"size:500"`
+	License   string `json:"license,omitempty" gorm:"size:100"`
+}
+
+// Complete reports whether the attribution names both the artist and the license
+func (a CardAttribution) Complete() bool {
+	return strings.TrimSpace(a.Artist) != "" && strings.TrimSpace(a.License) != ""
+}
+
+// Normalize trims the attribution and checks its lengths and source URL
+func (a CardAttribution) Normalize() (CardAttribution, error) {
+	a.Artist = strings.TrimSpace(a.Artist)
+	a.SourceURL = strings.TrimSpace(a.SourceURL)
+	a.License = strings.TrimSpace(a.License)
+
+	if len(a.Artist) > MaxArtistLength {
+		return a, fmt.Errorf("artist must be at most %d characters", MaxArtistLength)
+	}
+	if len(a.License) > MaxLicenseLength {
+		return a, fmt.Errorf("license must be at most %d characters", MaxLicenseLength)
+	}
+	if a.SourceURL != "" {
+		if len(a.SourceURL) > MaxSourceURLLength {
+			return a, fmt.Errorf("source URL must be at most %d characters", MaxSourceURLLength)
+		}
+		parsed, err := url.Parse(a.SourceURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return a, fmt.Errorf("source URL must be an http(s) URL")
+		}
+	}
+	return a, nil
+}
+
+// CardVersion is a snapshot of a card's editable metadata after an edit
+type CardVersion struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	CardID       int        `json:"card_id" gorm:"not null;uniqueIndex:idx_card_version"`
+	Version      int        `json:"version" gorm:"not null;uniqueIndex:idx_card_version"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	Tags         string     `json:"-" gorm:"type:text"`                   // JSON encoded []CardTagSnapshot
+	EditorID     *uuid.UUID `json:"editor_id,omitempty" gorm:"type:uuid"` // NULL for system edits (seeding, migrations)
+	EditorName   string     `json:"editor_name"`
+	ChangeNote   string     `json:"change_note"`
+	RestoredFrom *int       `json:"restored_from,omitempty"` // Version this snapshot restored, if it was a rollback
+	CreatedAt    time.Time  `json:"created_at"`
+
+	// Relationships
+	Card Card `json:"-" gorm:"foreignKey:CardID"`
+}
+
+// CardTagSnapshot records a tag assignment inside a CardVersion
+type CardTagSnapshot struct {
+	TagID  int     `json:"tag_id"`
+	Weight float64 `json:"weight"`
 }
 
 // Tag represents a categorization tag that can be applied to cards
@@ -25,9 +105,10 @@ type Tag struct {
 	Name        string    `json:"name" gorm:"unique;not null;index"`
 	Slug        string    `json:"slug" gorm:"unique;not null;index"`
 	Description string    `json:"description"`
-	Color       string    `json:"color" gorm:"default:'#3B82F6'"` // Hex color for UI
-	Weight      float64   `json:"weight" gorm:"default:1.0"`      // For weighted random selection
-	Category    string    `json:"category"`                       // Group tags by category
+	Color       string    `json:"color" gorm:"default:'#3B82F6'"`   // Hex color for UI
+	Weight      float64   `json:"weight" gorm:"default:1.0"`        // For weighted random selection
+	Category    string    `json:"category"`                         // Group tags by category
+	ParentID    *int      `json:"parent_id,omitempty" gorm:"index"` // Broader tag in the taxonomy, NULL for roots
 	IsActive    bool      `json:"is_active" gorm:"default:true"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CardPack is a set of cards a room can build its deck from. Rooms that pick
+// no packs play with the default ones.
+type CardPack struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Slug        string    `json:"slug" gorm:"size:100;uniqueIndex;not null"`
+	Name        string    `json:"name" gorm:"size:80;not null"`
+	Description string    `json:"description" gorm:"size:500"`
+	IsDefault   bool      `json:"is_default" gorm:"default:false;index"` // Dealt when a room picks no packs
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	Cards []Card `json:"-" gorm:"many2many:card_pack_cards;"`
+}
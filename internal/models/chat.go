@@ -12,6 +12,8 @@ type ChatMessage struct {
 	GameID      uuid.UUID  `json:"game_id" gorm:"type:uuid;not null;index"`
 	PlayerID    *uuid.UUID `json:"player_id" gorm:"type:uuid;index"`
 	Message     string     `json:"message" gorm:"type:text;not null"`
+	MessageKey  string     `json:"-" gorm:"type:text"`                                  // English source of a system message, for localized history
+	MessageArgs string     `json:"-" gorm:"type:text"`                                  // JSON array of the source's arguments
 	MessageType string     `json:"message_type" gorm:"type:varchar(20);default:'chat'"` // chat, system, emote
 	Phase       string     `json:"phase" gorm:"type:varchar(20);not null"`              // lobby, storytelling, submitting, voting, scoring
 	IsVisible   bool       `json:"is_visible" gorm:"default:true"`                      // For moderation
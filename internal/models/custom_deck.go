@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CustomDeck is a named deck a user assembled from existing cards. Rooms can
+// be created with it instead of card packs.
+type CustomDeck struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_custom_deck_user_name"`
+	Name        string    `json:"name" gorm:"size:50;not null;uniqueIndex:idx_custom_deck_user_name"`
+	Description string    `json:"description" gorm:"size:500"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	Cards []Card `json:"-" gorm:"many2many:custom_deck_cards;"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeckPack is an imported deck listed in the pack marketplace. The deck's
+// cards are the active cards carrying the tag Slug, which is also what rooms
+// name in their expansions.
+type DeckPack struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Slug        string     `json:"slug" gorm:"size:100;uniqueIndex;not null"`
+	Name        string     `json:"name" gorm:"size:80;not null"`
+	Author      string     `json:"author" gorm:"size:100;not null"`
+	Description string     `json:"description" gorm:"size:1000"`
+	Published   bool       `json:"published" gorm:"default:true;index"`
+	PublishedBy *uuid.UUID `json:"-" gorm:"type:uuid"`
+	Installs    int        `json:"installs" gorm:"not null;default:0;index"` // Users with the pack in their library
+	RatingCount int        `json:"rating_count" gorm:"not null;default:0"`
+	RatingTotal int        `json:"-" gorm:"not null;default:0"` // Sum of the stars, for the average
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// DeckPackInstall puts a pack in a user's library
+type DeckPackInstall struct {
+	PackID    uuid.UUID `json:"pack_id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DeckPackRating is a user's rating of a pack they played with, one to five stars
+type DeckPackRating struct {
+	PackID    uuid.UUID `json:"pack_id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Stars     int       `json:"stars" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeatureFlag overrides an experiment's deployment-wide flag at runtime. Rows
+// win over the EXPERIMENTS setting and take effect on every instance.
+type FeatureFlag struct {
+	Key       string     `json:"key" gorm:"primaryKey;size:100"`
+	Enabled   bool       `json:"enabled"`
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"` // Admin's player ID
+	UpdatedAt time.Time  `json:"updated_at"`
+}
@@ -19,14 +19,41 @@ const (
 
 // Game represents a game session
 type Game struct {
-	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
-	RoomCode     string         `json:"room_code" gorm:"unique;not null"`
-	Status       GameStatus     `json:"status" gorm:"default:'waiting'"`
-	CurrentRound int            `json:"current_round" gorm:"default:1"`
-	MaxRounds    int            `json:"max_rounds" gorm:"default:6"` // 3 players * 2 rounds each
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	RoomCode          string         `json:"room_code" gorm:"unique;not null"`
+	Status            GameStatus     `json:"status" gorm:"default:'waiting'"`
+	CurrentRound      int            `json:"current_round" gorm:"default:1"`
+	MaxRounds         int            `json:"max_rounds" gorm:"default:6"` // 3 players * 2 rounds each
+	Pace              string         `json:"pace" gorm:"size:16;default:'standard';index"`
+	ChatRetentionDays *int           `json:"chat_retention_days,omitempty"` // Per-room override, NULL = deployment default
+	ShuffleSeed       string         `json:"-" gorm:"size:64"`              // Secret until the game is over
+	ShuffleCommitment string         `json:"shuffle_commitment" gorm:"size:64"`
+	PasswordHash      string         `json:"-" gorm:"size:72"`                               // bcrypt hash of the room password, empty for open rooms
+	AvoidedCards      string         `json:"-" gorm:"type:text"`                             // Comma-separated cards a fresh cards shuffle weighed down
+	ExpansionCards    string         `json:"-" gorm:"type:text"`                             // Comma-separated expansion cards shuffled into the deck
+	Packs             string         `json:"packs" gorm:"type:text"`                         // Comma-separated card pack slugs, empty for the default packs
+	PackCards         string         `json:"-" gorm:"type:text"`                             // Comma-separated cards of the packs, empty for the classic deck
+	DeckID            *uuid.UUID     `json:"deck_id,omitempty" gorm:"type:uuid"`             // Custom deck the room plays with instead of packs
+	TournamentID      *uuid.UUID     `json:"tournament_id,omitempty" gorm:"type:uuid;index"` // Results are pushed to this tournament's ladder
+	Private           bool           `json:"private" gorm:"default:false;index"`             // Hidden from the lobby browser
+	Region            string         `json:"region,omitempty" gorm:"size:32;index"`          // Region of the instance the room was created on
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Table rules chosen by the host
+	MaxPlayers        int    `json:"max_players" gorm:"default:6"`
+	TargetScore       int    `json:"target_score" gorm:"default:30"`
+	HandSize          int    `json:"hand_size" gorm:"default:6"`
+	RoundTimerSeconds int    `json:"round_timer_seconds" gorm:"default:0"` // Per phase, 0 = no timer
+	Expansions        string `json:"expansions" gorm:"type:text"`          // Comma-separated deck tag slugs
+	LargeGame         bool   `json:"large_game" gorm:"default:false"`      // Player-count variant rules
+	TeamSize          int    `json:"team_size" gorm:"default:0"`           // Players per team, 0 = no teams
+
+	// Per-phase overrides of the round timer, 0 = use the round timer
+	StorytellingTimerSeconds int `json:"storytelling_timer_seconds" gorm:"default:0"`
+	SubmittingTimerSeconds   int `json:"submitting_timer_seconds" gorm:"default:0"`
+	VotingTimerSeconds       int `json:"voting_timer_seconds" gorm:"default:0"`
 
 	// Relationships
 	Players []GamePlayer `json:"players" gorm:"foreignKey:GameID"`
@@ -41,22 +68,158 @@ type GamePlayer struct {
 	Score    int       `json:"score" gorm:"default:0"`
 	Position int       `json:"position"` // Turn order
 	IsActive bool      `json:"is_active" gorm:"default:true"`
+	Token    string    `json:"token" gorm:"size:32"`  // Color and avatar the player had in the room
+	Team     int       `json:"team" gorm:"default:0"` // Team in team games, 0 = none
 
 	// Relationships
 	Game   Game   `json:"game" gorm:"foreignKey:GameID"`
 	Player Player `json:"player" gorm:"foreignKey:PlayerID"`
 }
 
-// GameHistory stores completed games for statistics
-type GameHistory struct {
+// GameReport stores the host-facing analytics summary generated when a game ends
+type GameReport struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	GameID    uuid.UUID `json:"game_id" gorm:"type:uuid;not null;uniqueIndex"`
+	RoomCode  string    `json:"room_code" gorm:"not null;index"`
+	HostID    uuid.UUID `json:"host_id" gorm:"type:uuid;not null"`
+	Report    string    `json:"report" gorm:"type:text"` // JSON-encoded game.HostReport
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GameExperiment records that a game was played with an experimental mechanic
+type GameExperiment struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	GameID     uuid.UUID `json:"game_id" gorm:"type:uuid;not null;uniqueIndex:idx_game_experiment"`
+	Experiment string    `json:"experiment" gorm:"not null;uniqueIndex:idx_game_experiment;index"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// TableCardHistory records the cards a group of players saw in one game, so
+// fresh cards shuffles can favor the others in their next games
+type TableCardHistory struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	TableKey  string    `json:"table_key" gorm:"size:64;not null;index"` // Hash of the group's human player IDs
+	GameID    uuid.UUID `json:"game_id" gorm:"type:uuid;not null"`
+	CardIDs   string    `json:"card_ids" gorm:"type:text"` // Comma-separated
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// BotDecision records why a bot played a clue, card or vote, for auditing
+// bot behavior after the game
+type BotDecision struct {
 	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
-	GameID      uuid.UUID `json:"game_id" gorm:"type:uuid;not null"`
-	WinnerID    uuid.UUID `json:"winner_id" gorm:"type:uuid"`
-	TotalRounds int       `json:"total_rounds"`
-	Duration    int       `json:"duration"` // Duration in minutes
+	GameID      uuid.UUID `json:"game_id" gorm:"type:uuid;not null;index"`
+	RoundNumber int       `json:"round_number"`
+	BotID       uuid.UUID `json:"bot_id" gorm:"type:uuid;not null"`
+	Kind        string    `json:"kind" gorm:"size:16;not null"` // storyteller, submit or vote
+	Difficulty  string    `json:"difficulty" gorm:"size:16"`
+	Clue        string    `json:"clue,omitempty"`
+	CardID      int       `json:"card_id"`
+	Rationale   string    `json:"rationale" gorm:"type:text"`
+	Candidates  string    `json:"-" gorm:"type:text"` // JSON-encoded top scored cards, best first
 	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ReplayEvent is one move of a game, numbered in the order it was played, so
+// finished games can be replayed move by move
+type ReplayEvent struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	GameID      uuid.UUID  `json:"game_id" gorm:"type:uuid;not null;index:idx_replay_event_order"`
+	Seq         int        `json:"seq" gorm:"not null;index:idx_replay_event_order"`
+	RoundNumber int        `json:"round_number"`
+	Type        string     `json:"type" gorm:"size:32;not null"`
+	PlayerID    *uuid.UUID `json:"player_id,omitempty" gorm:"type:uuid"`
+	CardID      int        `json:"card_id,omitempty"`
+	Clue        string     `json:"clue,omitempty"`
+	Details     string     `json:"-" gorm:"type:text"` // JSON-encoded table, points and scores, depending on the type
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// GameOutcome records how a finished game ended
+type GameOutcome string
+
+const (
+	GameOutcomeCompleted GameOutcome = "completed" // Played to the last round
+	GameOutcomeForfeit   GameOutcome = "forfeit"   // Ranked game lost to abandonment
+)
+
+// GameHistory stores completed games for statistics
+type GameHistory struct {
+	ID          uuid.UUID   `json:"id" gorm:"type:uuid;primaryKey"`
+	GameID      uuid.UUID   `json:"game_id" gorm:"type:uuid;not null"`
+	WinnerID    uuid.UUID   `json:"winner_id" gorm:"type:uuid"`
+	TotalRounds int         `json:"total_rounds"`
+	Duration    int         `json:"duration"` // Duration in minutes
+	Ranked      bool        `json:"ranked" gorm:"default:false;index"`
+	Outcome     GameOutcome `json:"outcome" gorm:"size:16;default:'completed'"`
+	CreatedAt   time.Time   `json:"created_at"`
 
 	// Relationships
 	Game   Game   `json:"game" gorm:"foreignKey:GameID"`
 	Winner Player `json:"winner" gorm:"foreignKey:WinnerID"`
 }
+
+// Forfeit reasons
+const (
+	ForfeitReasonReplaced = "replaced" // Player was handed over to a bot mid-game
+	ForfeitReasonAllAFK   = "all_afk"  // Every human went AFK and the game was ended
+)
+
+// GameForfeit records a ranked forfeit and the rating penalty it cost
+type GameForfeit struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	GameID        uuid.UUID `json:"game_id" gorm:"type:uuid;not null;uniqueIndex:idx_game_forfeit"`
+	PlayerID      uuid.UUID `json:"player_id" gorm:"type:uuid;not null;uniqueIndex:idx_game_forfeit;index"`
+	Reason        string    `json:"reason" gorm:"size:16;not null"`
+	RatingPenalty int       `json:"rating_penalty"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// A waived forfeit no longer counts against the player's ranked
+	// standing. The rating penalty stays.
+	WaivedAt    *time.Time `json:"waived_at,omitempty"`
+	WaivedBy    *uuid.UUID `json:"waived_by,omitempty" gorm:"type:uuid"`
+	WaiveReason string     `json:"waive_reason,omitempty" gorm:"size:500"`
+}
+
+// GameSummary is the denormalized listing row of a game. It is a read model
+// kept up to date by the game projector; the games table stays the source of truth.
+type GameSummary struct {
+	GameID       uuid.UUID   `json:"game_id" gorm:"type:uuid;primaryKey"`
+	RoomCode     string      `json:"room_code" gorm:"not null;index"`
+	Status       GameStatus  `json:"status" gorm:"size:16;index:idx_game_summary_status_created,priority:1"`
+	Pace         string      `json:"pace" gorm:"size:16;index"`
+	Ranked       bool        `json:"ranked"`
+	Private      bool        `json:"private" gorm:"index"`
+	Region       string      `json:"region,omitempty" gorm:"size:32;index"`
+	HostID       uuid.UUID   `json:"host_id" gorm:"type:uuid"`
+	PlayerCount  int         `json:"player_count"`
+	MaxPlayers   int         `json:"max_players"` // Seats in the room
+	BotCount     int         `json:"bot_count"`
+	RoundsPlayed int         `json:"rounds_played"`
+	WinnerID     *uuid.UUID  `json:"winner_id,omitempty" gorm:"type:uuid"`
+	WinnerName   string      `json:"winner_name,omitempty"`
+	Outcome      GameOutcome `json:"outcome,omitempty" gorm:"size:16"`
+	CreatedAt    time.Time   `json:"created_at" gorm:"index:idx_game_summary_status_created,priority:2,sort:desc"`
+	CompletedAt  *time.Time  `json:"completed_at,omitempty"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+// PlayerGameStat is one player's result in a finished game, the read model
+// behind player history
+type PlayerGameStat struct {
+	GameID          uuid.UUID   `json:"game_id" gorm:"type:uuid;primaryKey"`
+	PlayerID        uuid.UUID   `json:"player_id" gorm:"type:uuid;primaryKey;index:idx_player_game_stat_completed,priority:1"`
+	RoomCode        string      `json:"room_code"`
+	PlayerName      string      `json:"player_name"`
+	Score           int         `json:"score"`
+	Placement       int         `json:"placement"` // 1 for the winner; tied players share a placement
+	Won             bool        `json:"won"`
+	WasReplaced     bool        `json:"was_replaced"`
+	Ranked          bool        `json:"ranked"`
+	RatingChange    int         `json:"rating_change"`
+	Outcome         GameOutcome `json:"outcome" gorm:"size:16"`
+	TotalRounds     int         `json:"total_rounds"`
+	PlayerCount     int         `json:"player_count"`
+	DurationMinutes int         `json:"duration_minutes"`
+	CompletedAt     time.Time   `json:"completed_at" gorm:"index:idx_player_game_stat_completed,priority:2,sort:desc"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShadowBan hides a player's chat from everyone but themselves. The player is
+// not told, and can keep playing and being reported as usual.
+type ShadowBan struct {
+	PlayerID  uuid.UUID  `json:"player_id" gorm:"type:uuid;primaryKey"`
+	Reason    string     `json:"reason" gorm:"size:500"`
+	BannedBy  *uuid.UUID `json:"banned_by,omitempty" gorm:"type:uuid"` // Moderator's player ID
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// AdminAuditEntry records an admin action on an account or player
+type AdminAuditEntry struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ActorID    *uuid.UUID `json:"actor_id,omitempty" gorm:"type:uuid;index"` // Admin's player ID
+	Action     string     `json:"action" gorm:"size:32;not null;index"`
+	TargetType string     `json:"target_type" gorm:"size:16;not null"`
+	TargetID   string     `json:"target_id" gorm:"size:64;not null;index"`
+	Details    string     `json:"details,omitempty" gorm:"type:text"` // JSON
+	CreatedAt  time.Time  `json:"created_at" gorm:"index"`
+}
+
+// PlayerReport is one player's report about another during a game
+type PlayerReport struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	GameID     uuid.UUID `json:"game_id" gorm:"type:uuid;not null;uniqueIndex:idx_player_report"`
+	ReporterID uuid.UUID `json:"reporter_id" gorm:"type:uuid;not null;uniqueIndex:idx_player_report"`
+	ReportedID uuid.UUID `json:"reported_id" gorm:"type:uuid;not null;uniqueIndex:idx_player_report;index"`
+	Reason     string    `json:"reason" gorm:"size:500"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification delivery states
+const (
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+)
+
+// Notification is an email to a user waiting in the notification outbox.
+// Non-urgent ones are held until the user's quiet hours are over.
+type Notification struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	Kind      string     `json:"kind" gorm:"size:32;not null"` // e.g. turn_reminder or digest
+	Subject   string     `json:"subject" gorm:"not null"`
+	Body      string     `json:"body" gorm:"type:text"`
+	Urgent    bool       `json:"urgent"` // Sent even during quiet hours
+	Status    string     `json:"status" gorm:"size:16;not null;index:idx_notification_due"`
+	DeliverAt time.Time  `json:"deliver_at" gorm:"index:idx_notification_due"`
+	Attempts  int        `json:"attempts"`
+	LastError string     `json:"last_error,omitempty" gorm:"type:text"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -11,10 +11,18 @@ import (
 type PlayerType string
 
 const (
-	PlayerTypeHuman PlayerType = "human"
-	PlayerTypeBot   PlayerType = "bot"
+	PlayerTypeHuman  PlayerType = "human"
+	PlayerTypeBot    PlayerType = "bot"
+	PlayerTypeSystem PlayerType = "system" // The reserved author of system chat messages
 )
 
+// SystemPlayerName is the display name of the system player
+const SystemPlayerName = "System"
+
+// SystemPlayerID is the fixed ID of the reserved system player row, so system
+// chat messages reference a real player instead of leaving player_id empty
+var SystemPlayerID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
 // Player represents a player in a game session (can be guest or registered user)
 type Player struct {
 	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
@@ -31,3 +39,16 @@ type Player struct {
 	// Relationships
 	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
+
+// DefaultRating is the rating a player starts ranked play with
+const DefaultRating = 1200
+
+// PlayerRating tracks a player's ranked rating. Rows are created on a player's first ranked result.
+type PlayerRating struct {
+	PlayerID    uuid.UUID `json:"player_id" gorm:"type:uuid;primaryKey"`
+	Rating      int       `json:"rating" gorm:"not null;default:1200"`
+	RankedGames int       `json:"ranked_games" gorm:"default:0"`
+	RankedWins  int       `json:"ranked_wins" gorm:"default:0"`
+	Forfeits    int       `json:"forfeits" gorm:"default:0"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
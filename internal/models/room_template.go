@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoomTemplate is a host's saved combination of room settings, bot fill
+// included, that new rooms can be created from
+type RoomTemplate struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_room_template_user_name"`
+	Name      string    `json:"name" gorm:"size:50;not null;uniqueIndex:idx_room_template_user_name"`
+	Settings  string    `json:"-" gorm:"type:text"` // JSON-encoded game.GameSettings
+	Bots      int       `json:"bots"`
+	BotLevel  string    `json:"bot_level" gorm:"size:16"`
+	Sandbox   bool      `json:"sandbox"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
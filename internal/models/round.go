@@ -24,6 +24,7 @@ type GameRound struct {
 	RoundNumber     int         `json:"round_number"`
 	StorytellerID   uuid.UUID   `json:"storyteller_id" gorm:"type:uuid;not null"`
 	Clue            string      `json:"clue"`
+	ClueLanguage    string      `json:"clue_language" gorm:"size:8;index"` // Detected language of the clue
 	Status          RoundStatus `json:"status" gorm:"default:'storytelling'"`
 	StorytellerCard int         `json:"storyteller_card"` // Card ID chosen by storyteller
 	CreatedAt       time.Time   `json:"created_at"`
@@ -50,12 +51,26 @@ type CardSubmission struct {
 
 // Vote represents a player's vote for which card they think belongs to the storyteller
 type Vote struct {
-	ID       uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
-	RoundID  uuid.UUID `json:"round_id" gorm:"type:uuid;not null"`
-	PlayerID uuid.UUID `json:"player_id" gorm:"type:uuid;not null"`
-	CardID   int       `json:"card_id"` // ID of the card they voted for
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	RoundID    uuid.UUID `json:"round_id" gorm:"type:uuid;not null"`
+	PlayerID   uuid.UUID `json:"player_id" gorm:"type:uuid;not null"`
+	CardID     int       `json:"card_id"`                 // ID of the card they voted for
+	Weight     int       `json:"weight" gorm:"default:1"` // Confidence stake (weighted_votes experiment)
+	DoubleDown bool      `json:"double_down"`             // Double-down bet (double_down experiment)
 
 	// Relationships
 	Round  GameRound `json:"round" gorm:"foreignKey:RoundID"`
 	Player Player    `json:"player" gorm:"foreignKey:PlayerID"`
 }
+
+// RoundScore is a player's cumulative score at the end of a round, for score-over-time charts
+type RoundScore struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	GameID      uuid.UUID `json:"game_id" gorm:"type:uuid;not null;index"`
+	RoundID     uuid.UUID `json:"round_id" gorm:"type:uuid;not null;uniqueIndex:idx_round_score"`
+	RoundNumber int       `json:"round_number"`
+	PlayerID    uuid.UUID `json:"player_id" gorm:"type:uuid;not null;uniqueIndex:idx_round_score"`
+	Score       int       `json:"score"`  // Cumulative score after the round
+	Earned      int       `json:"earned"` // Points earned in the round
+	CreatedAt   time.Time `json:"created_at"`
+}
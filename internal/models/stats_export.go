@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stats export run states
+const (
+	StatsExportRunning   = "running"
+	StatsExportCompleted = "completed"
+)
+
+// StatsExport records one run of the data science export. Runs cover
+// consecutive windows of completed games; the unique window start keeps two
+// instances from exporting the same window.
+type StatsExport struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	WindowStart time.Time `json:"window_start" gorm:"not null;uniqueIndex"`
+	WindowEnd   time.Time `json:"window_end" gorm:"index"`
+	Status      string    `json:"status" gorm:"size:16;not null"`
+	Games       int       `json:"games"`
+	Prefix      string    `json:"prefix"` // Object name prefix of the run's files in the export bucket
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
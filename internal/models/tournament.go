@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ladder platforms game results can be pushed to
+const (
+	LadderPlatformChallonge  = "challonge"
+	LadderPlatformToornament = "toornament"
+)
+
+// Ladder delivery states
+const (
+	LadderDeliveryPending   = "pending"
+	LadderDeliveryDelivered = "delivered"
+	LadderDeliveryFailed    = "failed" // Out of attempts, or rejected by the platform
+)
+
+// Tournament links DixitMe games to a tournament on an external platform
+type Tournament struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Name        string    `json:"name" gorm:"not null"`
+	Platform    string    `json:"platform" gorm:"size:32;not null"`
+	ExternalID  string    `json:"external_id" gorm:"not null"` // Tournament ID or URL slug on the platform
+	APIKey      string    `json:"-"`
+	AccessToken string    `json:"-"` // OAuth token, for platforms that need one besides the API key
+	IsActive    bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TournamentParticipant maps a DixitMe player to their participant ID on the tournament's platform
+type TournamentParticipant struct {
+	TournamentID uuid.UUID `json:"tournament_id" gorm:"type:uuid;primaryKey"`
+	PlayerID     uuid.UUID `json:"player_id" gorm:"type:uuid;primaryKey"`
+	ExternalID   string    `json:"external_id" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LadderDelivery is a completed game's result waiting to be, or already,
+// pushed to a tournament platform
+type LadderDelivery struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	TournamentID  uuid.UUID  `json:"tournament_id" gorm:"type:uuid;not null;uniqueIndex:idx_ladder_delivery_game"`
+	GameID        uuid.UUID  `json:"game_id" gorm:"type:uuid;not null;uniqueIndex:idx_ladder_delivery_game"`
+	Status        string     `json:"status" gorm:"size:16;not null;index:idx_ladder_delivery_due,priority:1"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty" gorm:"type:text"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"index:idx_ladder_delivery_due,priority:2"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+	Result        string     `json:"-" gorm:"type:text"` // JSON encoded result snapshot
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -18,6 +18,9 @@ const (
 	AuthTypeGoogle   AuthType = "google"
 )
 
+// UserRolePlayer is the role accounts start with, until an admin changes it
+const UserRolePlayer = "player"
+
 // Value implements the driver.Valuer interface for database storage
 func (at AuthType) Value() (driver.Value, error) {
 	return string(at), nil
@@ -40,19 +43,30 @@ func (at *AuthType) Scan(value interface{}) error {
 
 // User represents a registered user account
 type User struct {
-	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
-	Email        string         `json:"email" gorm:"unique;index"`
-	Username     string         `json:"username" gorm:"unique;index"`
-	DisplayName  string         `json:"display_name" gorm:"not null"`
-	PasswordHash string         `json:"-" gorm:"type:text"` // For password auth, hidden from JSON
-	AuthType     AuthType       `json:"auth_type" gorm:"not null"`
-	GoogleID     string         `json:"-" gorm:"index"` // For Google SSO, hidden from JSON
-	Avatar       string         `json:"avatar"`         // Profile picture URL
-	IsActive     bool           `json:"is_active" gorm:"default:true"`
-	LastLoginAt  *time.Time     `json:"last_login_at"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Email        string    `json:"email" gorm:"unique;index"`
+	Username     string    `json:"username" gorm:"unique;index"`
+	DisplayName  string    `json:"display_name" gorm:"not null"`
+	PasswordHash string    `json:"-" gorm:"type:text"` // For password auth, hidden from JSON
+	AuthType     AuthType  `json:"auth_type" gorm:"not null"`
+	GoogleID     string    `json:"-" gorm:"index"` // For Google SSO, hidden from JSON
+	Avatar       string    `json:"avatar"`         // Profile picture URL
+	// Player token asked for in rooms, when no one else there holds it
+	PreferredToken string `json:"preferred_token,omitempty" gorm:"size:32"`
+	IsActive       bool   `json:"is_active" gorm:"default:true"`
+	// Notification preferences: an IANA time zone, and quiet hours as HH:MM in
+	// it during which only urgent notifications are sent (empty for none)
+	TimeZone        string `json:"time_zone,omitempty" gorm:"size:64"`
+	QuietHoursStart string `json:"quiet_hours_start,omitempty" gorm:"size:5"`
+	QuietHoursEnd   string `json:"quiet_hours_end,omitempty" gorm:"size:5"`
+	// Presence is online (or empty), do_not_disturb or invisible
+	Presence string `json:"presence,omitempty" gorm:"size:16"`
+	// Role fixes the scopes of new sessions; accounts start as players
+	Role        string         `json:"role,omitempty" gorm:"size:16;index"`
+	LastLoginAt *time.Time     `json:"last_login_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
 	Sessions []Session `json:"-" gorm:"foreignKey:UserID"`
@@ -67,6 +81,18 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// PasswordResetToken lets a user choose a new password. Only a hash of the
+// token is stored; the token itself is sent to the user's email address.
+type PasswordResetToken struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	TokenHash   string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	RequestedBy *uuid.UUID `json:"requested_by,omitempty" gorm:"type:uuid"` // Admin who sent the reset, if any
+	ExpiresAt   time.Time  `json:"expires_at"`
+	UsedAt      *time.Time `json:"used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
 // Session represents a user session (for both registered users and guests)
 type Session struct {
 	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
@@ -80,6 +106,12 @@ type Session struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 
+	Locale string `json:"locale,omitempty" gorm:"size:16"` // Preferred language for server-sent text
+
+	// Scopes is a space-separated list of the scopes tokens for this session
+	// carry. Empty sessions, from before scopes existed, may only play.
+	Scopes string `json:"scopes,omitempty" gorm:"size:255"`
+
 	// Relationships
 	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
@@ -0,0 +1,35 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// Allow counts a hit against a fixed-window rate limit shared by every
+// instance. It reports whether the hit is within limit and, if not, how long
+// until the window resets. Without Redis every hit is allowed.
+func Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	if Client == nil {
+		return true, 0, nil
+	}
+
+	key = "ratelimit:" + key
+	count, err := Client.Incr(ctx, key).Result()
+	if err != nil {
+		return true, 0, err
+	}
+	if count == 1 {
+		if err := Client.Expire(ctx, key, window).Err(); err != nil {
+			return true, 0, err
+		}
+	}
+	if count <= int64(limit) {
+		return true, 0, nil
+	}
+
+	retryAfter, err := Client.PTTL(ctx, key).Result()
+	if err != nil || retryAfter <= 0 {
+		retryAfter = window
+	}
+	return false, retryAfter, nil
+}
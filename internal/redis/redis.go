@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"fmt"
 
 	"dixitme/internal/logger"
 
@@ -10,26 +11,44 @@ import (
 
 var Client *redis.Client
 
+// Initialize connects to Redis, panicking on failure. The server starts
+// through Connect, which it can retry.
 func Initialize(redisURL string) {
+	if err := Connect(redisURL); err != nil {
+		panic(err)
+	}
+}
+
+// Connect connects to Redis and checks that it answers
+func Connect(redisURL string) error {
 	log := logger.GetLogger()
 
 	opt, err := redis.ParseURL(redisURL)
 	if err != nil {
 		log.Error("Failed to parse Redis URL", "error", err, "url", redisURL)
-		panic(err)
+		return fmt.Errorf("failed to parse Redis URL: %w", err)
 	}
 
-	Client = redis.NewClient(opt)
+	client := redis.NewClient(opt)
 
 	// Test connection
-	ctx := context.Background()
-	_, err = Client.Ping(ctx).Result()
-	if err != nil {
+	if _, err := client.Ping(context.Background()).Result(); err != nil {
 		log.Error("Failed to connect to Redis", "error", err)
-		panic(err)
+		client.Close()
+		return fmt.Errorf("failed to connect to Redis: %w", err)
 	}
+	Client = client
 
 	log.Info("Redis connection established")
+	return nil
+}
+
+// Ping checks that Redis answers
+func Ping(ctx context.Context) error {
+	if Client == nil {
+		return fmt.Errorf("redis not connected")
+	}
+	return Client.Ping(ctx).Err()
 }
 
 func GetClient() *redis.Client {
@@ -0,0 +1,73 @@
+package seeder
+
+import (
+	"fmt"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CardPackData describes a seeded card pack: the default cards from FirstCard
+// to LastCard
+type CardPackData struct {
+	Slug        string
+	Name        string
+	Description string
+	FirstCard   int
+	LastCard    int
+	IsDefault   bool
+}
+
+// GetDefaultCardPacks returns the packs the default cards are grouped into.
+// Together they are the classic deck every room plays with unless it picks packs.
+func GetDefaultCardPacks() []CardPackData {
+	return []CardPackData{
+		{Slug: "landscapes", Name: "Landscapes", Description: "Nature, seasons and faraway places", FirstCard: 1, LastCard: 20, IsDefault: true},
+		{Slug: "fantasy", Name: "Fantasy", Description: "Magic, myths and impossible creatures", FirstCard: 21, LastCard: 40, IsDefault: true},
+		{Slug: "people", Name: "People", Description: "Human emotions and everyday moments", FirstCard: 41, LastCard: 60, IsDefault: true},
+		{Slug: "symbols", Name: "Symbols", Description: "Objects, signs and abstract ideas", FirstCard: 61, LastCard: 84, IsDefault: true},
+	}
+}
+
+// SeedCardPacks creates the default card packs that don't exist yet and puts
+// their cards in them. Safe to run repeatedly.
+func SeedCardPacks(db *gorm.DB) error {
+	log := logger.GetLogger()
+
+	created := 0
+	for _, packData := range GetDefaultCardPacks() {
+		var count int64
+		if err := db.Model(&models.CardPack{}).Where("slug = ?", packData.Slug).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check card pack %s: %w", packData.Slug, err)
+		}
+		if count > 0 {
+			continue
+		}
+
+		var cards []models.Card
+		if err := db.Where("id BETWEEN ? AND ?", packData.FirstCard, packData.LastCard).Find(&cards).Error; err != nil {
+			return fmt.Errorf("failed to load cards of pack %s: %w", packData.Slug, err)
+		}
+		pack := models.CardPack{
+			ID:          uuid.New(),
+			Slug:        packData.Slug,
+			Name:        packData.Name,
+			Description: packData.Description,
+			IsDefault:   packData.IsDefault,
+			Cards:       cards,
+		}
+		if err := db.Create(&pack).Error; err != nil {
+			return fmt.Errorf("failed to create card pack %s: %w", packData.Slug, err)
+		}
+		created++
+		log.Debug("Created card pack", "slug", pack.Slug, "cards", len(cards))
+	}
+
+	if created > 0 {
+		log.Info("Card packs seeded", "packs_created", created)
+	}
+	return nil
+}
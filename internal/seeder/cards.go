@@ -211,7 +211,8 @@ func SeedDatabase() error {
 
 	if tagCount > 0 || cardCount > 0 {
 		log.Info("Database already seeded", "tags", tagCount, "cards", cardCount)
-		return nil
+		// Databases seeded before card packs existed still need them
+		return SeedCardPacks(db)
 	}
 
 	log.Info("Starting database seeding...")
@@ -292,7 +293,7 @@ func SeedDatabase() error {
 		"tags_created", len(tags),
 		"cards_created", len(cards))
 
-	return nil
+	return SeedCardPacks(db)
 }
 
 // SeedCardsOnly seeds only the cards (assumes tags already exist)
@@ -0,0 +1,271 @@
+// Package activity keeps each player's feed of recent events: games played,
+// rating changes, achievements and friend requests. Game events come from the
+// game manager's lifecycle hooks and event bus; other subsystems Publish
+// their own. Events
+// are written in the background so the engine never waits on the feed.
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"dixitme/internal/events"
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// queueSize bounds the events waiting to be written. When it fills up events are dropped.
+const queueSize = 512
+
+// GamePlayed details a finished game from one player's seat
+type GamePlayed struct {
+	RoomCode    string             `json:"room_code"`
+	Score       int                `json:"score"`
+	Placement   int                `json:"placement"` // 1 for the winner; ties share a place
+	Won         bool               `json:"won"`
+	Outcome     models.GameOutcome `json:"outcome"`
+	Ranked      bool               `json:"ranked"`
+	PlayerCount int                `json:"player_count"`
+	TotalRounds int                `json:"total_rounds"`
+}
+
+// RatingChanged details a ranked game's effect on a player's rating
+type RatingChanged struct {
+	RoomCode string `json:"room_code"`
+	Change   int    `json:"change"`
+}
+
+// Entry is an activity event as returned to its player
+type Entry struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	GameID    *uuid.UUID      `json:"game_id,omitempty"`
+	Details   json.RawMessage `json:"details,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Feed records activity events and serves them per player. It implements
+// game.GameLifecycleHook.
+type Feed struct {
+	game.NopLifecycleHook
+
+	db     *gorm.DB
+	queue  chan []models.ActivityEvent
+	closed chan struct{}
+
+	mu      sync.RWMutex
+	stopped bool
+}
+
+// NewFeed creates a feed stored in db. Call Start before registering it with
+// the game manager.
+func NewFeed(db *gorm.DB) *Feed {
+	return &Feed{
+		db:     db,
+		queue:  make(chan []models.ActivityEvent, queueSize),
+		closed: make(chan struct{}),
+	}
+}
+
+// Start writes queued events in order until Stop is called
+func (f *Feed) Start() {
+	go func() {
+		defer close(f.closed)
+		for events := range f.queue {
+			if err := f.db.Create(&events).Error; err != nil {
+				metrics.GetCounter(metrics.Name("activity_write_failures_total", "type", events[0].Type)).Inc()
+				logger.Error("Failed to write activity events", "error", err, "type", events[0].Type)
+			}
+		}
+	}()
+}
+
+// Stop writes the events still queued and stops the feed
+func (f *Feed) Stop() {
+	f.mu.Lock()
+	f.stopped = true
+	close(f.queue)
+	f.mu.Unlock()
+	<-f.closed
+}
+
+// OnGameCompleted adds the game, and any rating change, to every human player's feed
+func (f *Feed) OnGameCompleted(gs *game.GameState, result *game.GameResult) {
+	if gs.Sandbox {
+		return
+	}
+	events, err := gameEvents(gs, result, time.Now())
+	if err != nil {
+		logger.Error("Failed to build activity events", "error", err, "room_code", gs.RoomCode)
+		return
+	}
+	f.enqueue(events)
+}
+
+// Subscribe adds the game events the feed keeps for offline players: reports
+// of their bot stand-ins they weren't online to see
+func (f *Feed) Subscribe(bus *events.Bus) {
+	events.Subscribe(bus, game.TopicStandInReported, func(e game.StandInReported) {
+		if e.Delivered {
+			return
+		}
+		gameID := e.Result.GameID
+		if err := f.Publish(e.PlayerID, models.ActivityStandInResult, &gameID, e.Result); err != nil {
+			logger.Error("Failed to publish stand-in result", "error", err, "player_id", e.PlayerID, "room_code", e.Result.RoomCode)
+		}
+	})
+}
+
+// Publish adds an event to a player's feed. details is encoded as JSON.
+func (f *Feed) Publish(playerID uuid.UUID, eventType string, gameID *uuid.UUID, details interface{}) error {
+	event, err := newEvent(playerID, eventType, gameID, details, time.Now())
+	if err != nil {
+		return err
+	}
+	f.enqueue([]models.ActivityEvent{event})
+	return nil
+}
+
+// List returns a page of a player's events, newest first, optionally only of the given types
+func (f *Feed) List(ctx context.Context, playerID uuid.UUID, types []string, page, limit int) ([]Entry, int64, error) {
+	query := f.db.WithContext(ctx).Model(&models.ActivityEvent{}).Where("player_id = ?", playerID)
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count activity: %w", err)
+	}
+
+	var events []models.ActivityEvent
+	if err := query.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&events).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load activity: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(events))
+	for _, event := range events {
+		entry := Entry{ID: event.ID, Type: event.Type, GameID: event.GameID, CreatedAt: event.CreatedAt}
+		if event.Details != "" {
+			entry.Details = json.RawMessage(event.Details)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, total, nil
+}
+
+// IsType reports whether eventType is a known activity event type
+func IsType(eventType string) bool {
+	switch eventType {
+	case models.ActivityGamePlayed, models.ActivityRatingChanged, models.ActivityAchievementUnlocked, models.ActivityFriendRequest,
+		models.ActivityStandInResult:
+		return true
+	}
+	return false
+}
+
+// enqueue hands events to the writer without blocking the caller
+func (f *Feed) enqueue(events []models.ActivityEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.stopped {
+		return
+	}
+
+	select {
+	case f.queue <- events:
+	default:
+		metrics.GetCounter(metrics.Name("activity_dropped_events_total", "type", events[0].Type)).Inc()
+		logger.Warn("Activity queue full, dropping events", "type", events[0].Type, "count", len(events))
+	}
+}
+
+// gameEvents builds the events a finished game adds to its human players'
+// feeds. Callers must hold the game lock.
+func gameEvents(gs *game.GameState, result *game.GameResult, now time.Time) ([]models.ActivityEvent, error) {
+	scores := make([]int, 0, len(gs.Players))
+	for _, player := range gs.Players {
+		scores = append(scores, player.Score)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(scores)))
+
+	gameID := gs.ID
+	events := make([]models.ActivityEvent, 0, len(gs.Players))
+	for playerID, player := range gs.Players {
+		if player.IsBot {
+			continue
+		}
+
+		played, err := newEvent(playerID, models.ActivityGamePlayed, &gameID, GamePlayed{
+			RoomCode:    gs.RoomCode,
+			Score:       player.Score,
+			Placement:   placement(scores, player.Score),
+			Won:         playerID == result.WinnerID,
+			Outcome:     result.Outcome,
+			Ranked:      result.Ranked,
+			PlayerCount: len(gs.Players),
+			TotalRounds: result.TotalRounds,
+		}, now)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, played)
+
+		if change, rated := result.RatingChanges[playerID]; rated {
+			rating, err := newEvent(playerID, models.ActivityRatingChanged, &gameID, RatingChanged{
+				RoomCode: gs.RoomCode,
+				Change:   change,
+			}, now)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, rating)
+		}
+	}
+	return events, nil
+}
+
+func newEvent(playerID uuid.UUID, eventType string, gameID *uuid.UUID, details interface{}, now time.Time) (models.ActivityEvent, error) {
+	if !IsType(eventType) {
+		return models.ActivityEvent{}, fmt.Errorf("unknown activity type %q", eventType)
+	}
+
+	event := models.ActivityEvent{
+		ID:        uuid.New(),
+		PlayerID:  playerID,
+		Type:      eventType,
+		GameID:    gameID,
+		CreatedAt: now,
+	}
+	if details != nil {
+		encoded, err := json.Marshal(details)
+		if err != nil {
+			return models.ActivityEvent{}, fmt.Errorf("failed to encode activity details: %w", err)
+		}
+		event.Details = string(encoded)
+	}
+	return event, nil
+}
+
+// placement ranks a score among scores sorted high to low; ties share a place
+func placement(sortedScores []int, score int) int {
+	for i, s := range sortedScores {
+		if s == score {
+			return i + 1
+		}
+	}
+	return len(sortedScores)
+}
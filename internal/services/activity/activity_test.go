@@ -0,0 +1,56 @@
+package activity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"dixitme/internal/models"
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameEventsSkipBotsAndAddRatingChanges(t *testing.T) {
+	winner, loser, bot := uuid.New(), uuid.New(), uuid.New()
+	gs := &game.GameState{
+		ID:       uuid.New(),
+		RoomCode: "ABC123",
+		Players: map[uuid.UUID]*game.Player{
+			winner: {ID: winner, Score: 30},
+			loser:  {ID: loser, Score: 20},
+			bot:    {ID: bot, Score: 25, IsBot: true},
+		},
+	}
+	result := &game.GameResult{
+		GameID:        gs.ID,
+		WinnerID:      winner,
+		Ranked:        true,
+		RatingChanges: map[uuid.UUID]int{winner: 12},
+	}
+
+	events, err := gameEvents(gs, result, time.Now())
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	byType := make(map[uuid.UUID][]string)
+	for _, event := range events {
+		byType[event.PlayerID] = append(byType[event.PlayerID], event.Type)
+		if event.PlayerID == loser {
+			var played GamePlayed
+			require.NoError(t, json.Unmarshal([]byte(event.Details), &played))
+			assert.Equal(t, 3, played.Placement)
+			assert.False(t, played.Won)
+		}
+	}
+	assert.Equal(t, []string{models.ActivityGamePlayed, models.ActivityRatingChanged}, byType[winner])
+	assert.Equal(t, []string{models.ActivityGamePlayed}, byType[loser])
+	assert.Empty(t, byType[bot])
+}
+
+func TestNewEventRejectsUnknownTypes(t *testing.T) {
+	_, err := newEvent(uuid.New(), "poked", nil, nil, time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// passwordResetTTL is how long a password reset link stays valid
+const passwordResetTTL = time.Hour
+
+var (
+	// ErrUserNotFound is returned when administering an account that doesn't exist
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidResetToken is returned for unknown, used or expired reset tokens
+	ErrInvalidResetToken = errors.New("invalid or expired reset token")
+)
+
+// findUser loads an account whether or not it is active
+func (a *AuthService) findUser(userID uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := a.db.First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	return &user, nil
+}
+
+// revokeUserSessions ends every active session of a user
+func revokeUserSessions(tx *gorm.DB, userID uuid.UUID) error {
+	return tx.Model(&models.Session{}).
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Update("is_active", false).Error
+}
+
+// SetUserActive deactivates or reactivates an account. Deactivating it also
+// ends its sessions, so the user is signed out everywhere.
+func (a *AuthService) SetUserActive(userID uuid.UUID, active bool) (*models.User, error) {
+	user, err := a.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(user).Update("is_active", active).Error; err != nil {
+			return err
+		}
+		if !active {
+			return revokeUserSessions(tx, userID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	user.IsActive = active
+	logger.GetLogger().Info("User active state changed", "user_id", userID, "active", active)
+	return user, nil
+}
+
+// SetUserRole changes an account's role; an empty role makes it a player.
+// Its sessions are ended so the new scopes apply from the next sign-in.
+func (a *AuthService) SetUserRole(userID uuid.UUID, role string) (*models.User, error) {
+	if role == "" {
+		role = RolePlayer
+	}
+	if _, err := RoleScopes(role); err != nil {
+		return nil, err
+	}
+	user, err := a.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(user).Update("role", role).Error; err != nil {
+			return err
+		}
+		return revokeUserSessions(tx, userID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	user.Role = role
+	logger.GetLogger().Info("User role changed", "user_id", userID, "role", role)
+	return user, nil
+}
+
+// CreatePasswordReset issues a single-use password reset token for a
+// password account, replacing any earlier unused one. The token is returned
+// for emailing; only its hash is stored.
+func (a *AuthService) CreatePasswordReset(userID uuid.UUID, requestedBy *uuid.UUID) (*models.User, string, error) {
+	user, err := a.findUser(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if user.AuthType != models.AuthTypePassword || user.Email == "" {
+		return nil, "", fmt.Errorf("only password accounts with an email address can reset their password")
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", fmt.Errorf("failed to generate reset token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	reset := models.PasswordResetToken{
+		ID:          uuid.New(),
+		UserID:      userID,
+		TokenHash:   hashResetToken(token),
+		RequestedBy: requestedBy,
+		ExpiresAt:   time.Now().Add(passwordResetTTL),
+		CreatedAt:   time.Now(),
+	}
+	err = a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND used_at IS NULL", userID).Delete(&models.PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&reset).Error
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to save reset token: %w", err)
+	}
+
+	logger.GetLogger().Info("Password reset issued", "user_id", userID, "requested_by", requestedBy)
+	return user, token, nil
+}
+
+// ResetPassword sets a new password with a reset token and ends the
+// account's sessions
+func (a *AuthService) ResetPassword(token, password string) error {
+	if len(password) < 8 {
+		return fmt.Errorf("password must be at least 8 characters long")
+	}
+
+	var reset models.PasswordResetToken
+	if err := a.db.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", hashResetToken(token), time.Now()).
+		First(&reset).Error; err != nil {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	now := time.Now()
+	err = a.db.Transaction(func(tx *gorm.DB) error {
+		// Claim the token first so concurrent resets can't both use it
+		result := tx.Model(&reset).Where("used_at IS NULL").Update("used_at", &now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidResetToken
+		}
+		if err := tx.Model(&models.User{}).Where("id = ?", reset.UserID).Update("password_hash", string(hashedPassword)).Error; err != nil {
+			return err
+		}
+		return revokeUserSessions(tx, reset.UserID)
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidResetToken) {
+			return err
+		}
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+
+	logger.GetLogger().Info("Password reset", "user_id", reset.UserID)
+	return nil
+}
+
+// hashResetToken is what reset tokens are stored and looked up by
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,9 +1,14 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/services/namepolicy"
+	"dixitme/internal/transport/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -46,10 +51,33 @@ type GuestLoginRequest struct {
 	Name string `json:"name,omitempty"`
 }
 
+type UpgradeGuestRequest struct {
+	UpgradeToken string `json:"upgrade_token" binding:"required"` // From an account_prompt message
+	Email        string `json:"email" binding:"required,email"`
+	Username     string `json:"username" binding:"required,min=3,max=50"`
+	DisplayName  string `json:"display_name" binding:"required,min=1,max=100"`
+	Password     string `json:"password" binding:"required,min=8"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"` // From the password reset email
+	Password string `json:"password" binding:"required,min=8"`
+}
+
 type RefreshTokenRequest struct {
 	Token string `json:"token" binding:"required"`
 }
 
+type IntegrationTokenRequest struct {
+	Scopes []string `json:"scopes" binding:"required,min=1"`
+}
+
+type IntegrationTokenResponse struct {
+	Token     string    `json:"token"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 type AuthResponse struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
@@ -84,21 +112,22 @@ type GuestResponse struct {
 // @Success 201 {object} AuthResponse
 // @Failure 400 {object} map[string]interface{}
 // @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
 // @Router /auth/register [post]
 func (h *AuthHandlers) Register(c *gin.Context) {
 	var req RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 
 	user, err := h.authService.RegisterWithPassword(req.Email, req.Username, req.DisplayName, req.Password)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "user with this email or username already exists" {
-			statusCode = http.StatusConflict
+		if errors.Is(err, ErrAccountExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
 		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+		logger.GetLogger().Error("Failed to register user", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register"})
 		return
 	}
 
@@ -127,11 +156,11 @@ func (h *AuthHandlers) Register(c *gin.Context) {
 // @Param request body LoginRequest true "Login credentials"
 // @Success 200 {object} AuthResponse
 // @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
 // @Router /auth/login [post]
 func (h *AuthHandlers) Login(c *gin.Context) {
 	var req LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 
@@ -142,7 +171,12 @@ func (h *AuthHandlers) Login(c *gin.Context) {
 		c.GetHeader("User-Agent"),
 	)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		if errors.Is(err, ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		logger.GetLogger().Error("Failed to log in", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in"})
 		return
 	}
 
@@ -190,8 +224,7 @@ func (h *AuthHandlers) GoogleLogin(c *gin.Context) {
 	}
 
 	var req GoogleLoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 
@@ -201,7 +234,8 @@ func (h *AuthHandlers) GoogleLogin(c *gin.Context) {
 		c.GetHeader("User-Agent"),
 	)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		logger.GetLogger().Error("Failed to log in with Google", "error", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Google login failed"})
 		return
 	}
 
@@ -235,19 +269,35 @@ func (h *AuthHandlers) GoogleLogin(c *gin.Context) {
 // @Produce json
 // @Param request body GuestLoginRequest true "Guest name (optional)"
 // @Success 200 {object} AuthResponse
+// @Failure 400 {object} map[string]interface{} "Name rejected by the name policy"
 // @Failure 500 {object} map[string]interface{}
 // @Router /auth/guest [post]
 func (h *AuthHandlers) GuestLogin(c *gin.Context) {
 	var req GuestLoginRequest
 	c.ShouldBindJSON(&req) // Optional request body
 
+	if req.Name != "" {
+		name, err := namepolicy.Check(req.Name)
+		if err != nil {
+			var nameErr *namepolicy.PolicyError
+			if errors.As(err, &nameErr) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": nameErr.Message, "code": nameErr.Code, "field": "name"})
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.Name = name
+	}
+
 	session, token, err := h.authService.CreateGuestSession(
 		req.Name,
 		c.ClientIP(),
 		c.GetHeader("User-Agent"),
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		logger.GetLogger().Error("Failed to create guest session", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create guest session"})
 		return
 	}
 
@@ -276,6 +326,74 @@ func (h *AuthHandlers) GuestLogin(c *gin.Context) {
 	logger.GetLogger().Info("Guest session created", "session_id", session.ID)
 }
 
+// @Summary Upgrade a guest to an account
+// @Description Turn the guest session an account prompt was sent to into a registered account, keeping its game history
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body UpgradeGuestRequest true "Upgrade token and account details"
+// @Success 201 {object} AuthResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{} "Invalid or expired upgrade token"
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/upgrade [post]
+func (h *AuthHandlers) UpgradeGuest(c *gin.Context) {
+	var req UpgradeGuestRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	claims, err := h.jwtService.ValidateUpgradeToken(req.UpgradeToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired upgrade token"})
+		return
+	}
+
+	user, err := h.authService.UpgradeGuestToUser(claims.SessionID, req.Email, req.Username, req.DisplayName, req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrAccountExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, ErrGuestSessionNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			logger.GetLogger().Error("Failed to upgrade guest", "error", err, "session_id", claims.SessionID)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upgrade account"})
+		}
+		return
+	}
+
+	// The session now belongs to the account; reissue its token with the user in it
+	session, token, err := h.authService.RefreshSession(claims.SessionID)
+	if err != nil {
+		logger.GetLogger().Error("Failed to refresh upgraded session", "error", err, "session_id", claims.SessionID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh session"})
+		return
+	}
+
+	metrics.GetCounter(metrics.Name("guest_conversions_total", "milestone", claims.Milestone)).Inc()
+
+	c.SetCookie("auth_token", token, 86400, "/", "", false, true) // 24 hours
+
+	c.JSON(http.StatusCreated, AuthResponse{
+		Success: true,
+		Message: "Guest upgraded to an account",
+		User: UserResponse{
+			ID:          user.ID.String(),
+			Email:       user.Email,
+			Username:    user.Username,
+			DisplayName: user.DisplayName,
+			AuthType:    string(user.AuthType),
+			Avatar:      user.Avatar,
+		},
+		Token: token,
+		Type:  "registered",
+	})
+
+	logger.GetLogger().Info("Guest converted from account prompt", "user_id", user.ID, "session_id", session.ID, "milestone", claims.Milestone)
+}
+
 // @Summary Refresh token
 // @Description Refresh authentication token
 // @Tags auth
@@ -287,8 +405,7 @@ func (h *AuthHandlers) GuestLogin(c *gin.Context) {
 // @Router /auth/refresh [post]
 func (h *AuthHandlers) RefreshToken(c *gin.Context) {
 	var req RefreshTokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 
@@ -301,7 +418,8 @@ func (h *AuthHandlers) RefreshToken(c *gin.Context) {
 
 	session, newToken, err := h.authService.RefreshSession(userInfo.SessionID)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		logger.GetLogger().Warn("Failed to refresh session", "error", err, "session_id", userInfo.SessionID)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Session not found or expired"})
 		return
 	}
 
@@ -428,6 +546,59 @@ func (h *AuthHandlers) ValidateToken(c *gin.Context) {
 	})
 }
 
+// @Summary Issue integration token
+// @Description Issue a token limited to the given scopes, backed by its own session. The scopes must be a subset of the caller's.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body IntegrationTokenRequest true "Scopes to grant"
+// @Success 201 {object} IntegrationTokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /auth/tokens [post]
+func (h *AuthHandlers) IssueIntegrationToken(c *gin.Context) {
+	userInfo, exists := GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		return
+	}
+	if userInfo.UserID == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Guests cannot issue integration tokens"})
+		return
+	}
+
+	var req IntegrationTokenRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	scopes, err := NormalizeScopes(req.Scopes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for _, scope := range scopes {
+		if !userInfo.HasScope(scope) {
+			respondInsufficientScope(c, scope)
+			return
+		}
+	}
+
+	session, token, err := h.authService.CreateIntegrationSession(*userInfo.UserID, userInfo.AuthType, scopes, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue token"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, IntegrationTokenResponse{
+		Token:     token,
+		Scopes:    scopes,
+		ExpiresAt: session.ExpiresAt,
+	})
+}
+
 // @Summary Get authentication status
 // @Description Get current authentication configuration and available methods
 // @Tags auth
@@ -445,3 +616,31 @@ func (h *AuthHandlers) GetAuthStatus(c *gin.Context) {
 		"version":     "1.0",
 	})
 }
+
+// @Summary Reset password
+// @Description Choose a new password with the token from a password reset email. Tokens are single-use and expire after an hour; every session of the account is signed out.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /auth/password-reset [post]
+func (h *AuthHandlers) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.authService.ResetPassword(req.Token, req.Password); err != nil {
+		if errors.Is(err, ErrInvalidResetToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		logger.GetLogger().Error("Failed to reset password", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Password changed, please sign in again"})
+}
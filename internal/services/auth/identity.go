@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrRegisteredPlayerID is returned when a caller without a token claims the
+// player ID of a registered account
+var ErrRegisteredPlayerID = errors.New("player ID belongs to a registered account, sign in to use it")
+
+// CheckGuestPlayerID refuses a guest-supplied player ID that belongs to a
+// registered account. Registered users play under their user ID, which other
+// players see in every game state, so only a session JWT may claim it.
+func CheckGuestPlayerID(playerID uuid.UUID) error {
+	db := database.GetDB()
+	if db == nil {
+		return nil // No accounts without a database
+	}
+
+	var count int64
+	if err := db.Model(&models.User{}).Where("id = ?", playerID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check player ID: %w", err)
+	}
+	if count > 0 {
+		return ErrRegisteredPlayerID
+	}
+	return nil
+}
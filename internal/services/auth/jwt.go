@@ -17,6 +17,10 @@ type JWTClaims struct {
 	AuthType  models.AuthType `json:"auth_type"`
 	Name      string          `json:"name"`
 	Email     string          `json:"email,omitempty"`
+
+	// Scopes restrict what the token may do; empty tokens predate scopes and may only play
+	Scopes []string `json:"scopes,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
@@ -48,12 +52,18 @@ func (j *JWTService) GenerateToken(session *models.Session, user *models.User, g
 		name = guestName
 	}
 
+	scopes, err := ParseScopes(session.Scopes)
+	if err != nil {
+		return "", err
+	}
+
 	claims := JWTClaims{
 		UserID:    userID,
 		SessionID: session.ID,
 		AuthType:  session.AuthType,
 		Name:      name,
 		Email:     email,
+		Scopes:    scopes,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -128,6 +138,7 @@ func (j *JWTService) ExtractUserInfo(tokenString string) (*UserInfo, error) {
 		AuthType:  claims.AuthType,
 		Name:      claims.Name,
 		Email:     claims.Email,
+		Scopes:    claims.Scopes,
 	}, nil
 }
 
@@ -138,4 +149,20 @@ type UserInfo struct {
 	AuthType  models.AuthType `json:"auth_type"`
 	Name      string          `json:"name"`
 	Email     string          `json:"email,omitempty"`
+	Scopes    []string        `json:"scopes,omitempty"`
+}
+
+// PlayerID returns the canonical player identity: the user ID for registered
+// accounts, so every device and session maps to the same player, and the
+// session ID for guests
+func (u *UserInfo) PlayerID() uuid.UUID {
+	if u.UserID != nil && *u.UserID != uuid.Nil {
+		return *u.UserID
+	}
+	return u.SessionID
+}
+
+// HasScope reports whether the token may be used for scope
+func (u *UserInfo) HasScope(scope string) bool {
+	return hasScope(u.Scopes, scope)
 }
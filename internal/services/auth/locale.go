@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"fmt"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SessionLocale returns the language a session asked for, or "" if it never did
+func SessionLocale(sessionID uuid.UUID) string {
+	var session models.Session
+	if err := database.GetDB().Select("locale").Where("id = ?", sessionID).First(&session).Error; err != nil {
+		return ""
+	}
+	return session.Locale
+}
+
+// SetSessionLocale remembers a session's language, so later connections
+// made with the same token get it without asking again
+func SetSessionLocale(sessionID uuid.UUID, locale string) error {
+	if err := database.GetDB().Model(&models.Session{}).Where("id = ?", sessionID).Update("locale", locale).Error; err != nil {
+		return fmt.Errorf("failed to save session locale: %w", err)
+	}
+	return nil
+}
@@ -21,62 +21,66 @@ const (
 // AuthMiddleware creates authentication middleware
 func AuthMiddleware(jwtService *JWTService, required bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token := extractToken(c)
-
-		if token == "" {
-			if required {
-				c.JSON(http.StatusUnauthorized, gin.H{
-					"error": "Authentication required",
-					"code":  "AUTH_REQUIRED",
-				})
-				c.Abort()
-				return
-			}
-			// Continue without auth for optional endpoints
+		if authenticate(c, jwtService, required) {
 			c.Next()
-			return
 		}
+	}
+}
 
-		// Validate token
-		userInfo, err := jwtService.ExtractUserInfo(token)
-		if err != nil {
-			if required {
-				c.JSON(http.StatusUnauthorized, gin.H{
-					"error": "Invalid token",
-					"code":  "INVALID_TOKEN",
-				})
-				c.Abort()
-				return
-			}
-			// Log but continue for optional auth
-			logger.GetLogger().Warn("Invalid token in optional auth", "error", err)
-			c.Next()
-			return
-		}
+// authenticate puts the user of the request's token in the context. It
+// reports false after aborting a request that required a valid token.
+func authenticate(c *gin.Context, jwtService *JWTService, required bool) bool {
+	token := extractToken(c)
 
-		// Verify session is still active
-		db := database.GetDB()
-		var session models.Session
-		if err := db.Where("id = ? AND is_active = ? AND expires_at > ?",
-			userInfo.SessionID, true, time.Now()).First(&session).Error; err != nil {
-			if required {
-				c.JSON(http.StatusUnauthorized, gin.H{
-					"error": "Session expired or invalid",
-					"code":  "SESSION_INVALID",
-				})
-				c.Abort()
-				return
-			}
-			c.Next()
-			return
+	if token == "" {
+		if required {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Authentication required",
+				"code":  "AUTH_REQUIRED",
+			})
+			c.Abort()
+			return false
 		}
+		// Continue without auth for optional endpoints
+		return true
+	}
 
-		// Add user info to context
-		c.Set(AuthContextKey, userInfo)
-		c.Set(TokenContextKey, token)
+	// Validate token
+	userInfo, err := jwtService.ExtractUserInfo(token)
+	if err != nil {
+		if required {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid token",
+				"code":  "INVALID_TOKEN",
+			})
+			c.Abort()
+			return false
+		}
+		// Log but continue for optional auth
+		logger.GetLogger().Warn("Invalid token in optional auth", "error", err)
+		return true
+	}
 
-		c.Next()
+	// Verify session is still active
+	db := database.GetDB()
+	var session models.Session
+	if err := db.Where("id = ? AND is_active = ? AND expires_at > ?",
+		userInfo.SessionID, true, time.Now()).First(&session).Error; err != nil {
+		if required {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Session expired or invalid",
+				"code":  "SESSION_INVALID",
+			})
+			c.Abort()
+			return false
+		}
+		return true
 	}
+
+	// Add user info to context
+	c.Set(AuthContextKey, userInfo)
+	c.Set(TokenContextKey, token)
+	return true
 }
 
 // RequireAuth creates middleware that requires authentication
@@ -92,9 +96,8 @@ func OptionalAuth(jwtService *JWTService) gin.HandlerFunc {
 // RequireAdmin creates middleware that requires admin privileges
 func RequireAdmin(jwtService *JWTService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// First require auth
-		RequireAuth(jwtService)(c)
-		if c.IsAborted() {
+		// First require auth, without running the rest of the chain yet
+		if !authenticate(c, jwtService, true) {
 			return
 		}
 
@@ -108,15 +111,73 @@ func RequireAdmin(jwtService *JWTService) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if !userInfo.HasScope(ScopeAdmin) {
+			respondInsufficientScope(c, ScopeAdmin)
+			return
+		}
 
-		// Additional admin check could be added here
-		// For now, any registered user can access admin endpoints
-		// In production, you'd want a proper admin role system
+		// Scopes are fixed when a session starts, so the account's role is
+		// checked too: an admin who was demoted or deactivated loses access
+		// with sessions still open
+		isAdmin, err := hasAdminRole(userInfo.UserID)
+		if err != nil {
+			logger.GetLogger().Error("Failed to check admin role", "error", err, "user_id", userInfo.UserID)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check admin access"})
+			c.Abort()
+			return
+		}
+		if !isAdmin {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Admin access required",
+				"code":  "ADMIN_REQUIRED",
+			})
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
 }
 
+// hasAdminRole reports whether userID is an active account with the admin role
+func hasAdminRole(userID *uuid.UUID) (bool, error) {
+	db := database.GetDB()
+	if userID == nil || db == nil {
+		return false, nil
+	}
+
+	var user models.User
+	err := db.Select("role").Where("id = ? AND is_active = ?", *userID, true).Limit(1).Find(&user).Error
+	if err != nil {
+		return false, err
+	}
+	return user.Role == RoleAdmin, nil
+}
+
+// RequireScope creates middleware that rejects tokens not granted scope. It
+// runs after an auth middleware; requests that carry no token are left to
+// that middleware, so anonymous play keeps working.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userInfo, exists := GetUserFromContext(c)
+		if exists && !userInfo.HasScope(scope) {
+			respondInsufficientScope(c, scope)
+			return
+		}
+		c.Next()
+	}
+}
+
+// respondInsufficientScope aborts with 403 naming the missing scope
+func respondInsufficientScope(c *gin.Context, scope string) {
+	c.JSON(http.StatusForbidden, gin.H{
+		"error": "Token is missing the required scope",
+		"code":  "INSUFFICIENT_SCOPE",
+		"scope": scope,
+	})
+	c.Abort()
+}
+
 // GuestOrAuth allows both guest and authenticated access
 func GuestOrAuth(jwtService *JWTService) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -222,7 +283,7 @@ func GetPlayerID(c *gin.Context) uuid.UUID {
 		}
 
 		if playerIDStr != "" {
-			if playerID, err := uuid.Parse(playerIDStr); err == nil {
+			if playerID, err := uuid.Parse(playerIDStr); err == nil && CheckGuestPlayerID(playerID) == nil {
 				return playerID
 			}
 		}
@@ -231,7 +292,5 @@ func GetPlayerID(c *gin.Context) uuid.UUID {
 		return uuid.New()
 	}
 
-	// For authenticated users, use session ID as player ID
-	// This ensures consistency across sessions
-	return userInfo.SessionID
+	return userInfo.PlayerID()
 }
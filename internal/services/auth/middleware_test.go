@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// signIn stores a password account with role and returns a token for it
+func signIn(t *testing.T, db *gorm.DB, authService *AuthService, username, role string) (uuid.UUID, string) {
+	t.Helper()
+	hashed, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := models.User{
+		ID:           uuid.New(),
+		Email:        username + "@example.com",
+		Username:     username,
+		PasswordHash: string(hashed),
+		AuthType:     models.AuthTypePassword,
+		Role:         role,
+		IsActive:     true,
+	}
+	require.NoError(t, db.Create(&user).Error)
+	_, _, token, err := authService.LoginWithPassword(username, "password123", "127.0.0.1", "test-agent")
+	require.NoError(t, err)
+	return user.ID, token
+}
+
+func TestRequireAdminNeedsTheAdminRole(t *testing.T) {
+	db := authTestDB(t)
+	jwtService := NewJWTService("test-secret")
+	authService := NewAuthService(jwtService)
+
+	gin.SetMode(gin.TestMode)
+	reached := false
+	router := gin.New()
+	router.GET("/admin", RequireAdmin(jwtService), func(c *gin.Context) {
+		reached = true
+		c.Status(http.StatusOK)
+	})
+	get := func(token string) int {
+		reached = false
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	_, guestToken, err := authService.CreateGuestSession("Mallory", "127.0.0.1", "test-agent")
+	require.NoError(t, err)
+	_, playerToken := signIn(t, db, authService, "player", RolePlayer)
+	adminID, adminToken := signIn(t, db, authService, "admin", RoleAdmin)
+
+	assert.Equal(t, http.StatusUnauthorized, get(""))
+	assert.Equal(t, http.StatusForbidden, get(guestToken))
+	assert.Equal(t, http.StatusForbidden, get(playerToken))
+	assert.False(t, reached)
+
+	assert.Equal(t, http.StatusOK, get(adminToken))
+	assert.True(t, reached)
+
+	// A demoted admin's open session carries the admin scope but no longer works
+	require.NoError(t, db.Model(&models.User{}).Where("id = ?", adminID).Update("role", RolePlayer).Error)
+	assert.Equal(t, http.StatusForbidden, get(adminToken))
+	assert.False(t, reached, "the handler never runs before the checks")
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// resumeTokenTTL is how long a resume token stays valid. Tokens are reissued at
+// every round start, so active players always hold a fresh one.
+const resumeTokenTTL = 30 * time.Minute
+
+const resumeAudience = "dixitme-resume"
+
+// ResumeClaims identify a player's seat in one room
+type ResumeClaims struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	RoomCode string    `json:"room_code"`
+	jwt.RegisteredClaims
+}
+
+// resumeKey derives the resume token signing key, so resume tokens and session
+// JWTs can never be used in place of each other
+func (j *JWTService) resumeKey() []byte {
+	return append(append([]byte{}, j.secretKey...), []byte(":resume")...)
+}
+
+// GenerateResumeToken signs a short-lived token that lets a player reclaim their
+// seat in a room, independently of their session JWT
+func (j *JWTService) GenerateResumeToken(playerID uuid.UUID, roomCode string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(resumeTokenTTL)
+
+	claims := ResumeClaims{
+		PlayerID: playerID,
+		RoomCode: roomCode,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "dixitme",
+			Audience:  jwt.ClaimStrings{resumeAudience},
+			Subject:   playerID.String(),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(j.resumeKey())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// ValidateResumeToken validates a resume token and returns its claims
+func (j *JWTService) ValidateResumeToken(tokenString string) (*ResumeClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &ResumeClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		return j.resumeKey(), nil
+	}, jwt.WithAudience(resumeAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*ResumeClaims)
+	if !ok || !token.Valid || claims.RoomCode == "" || claims.PlayerID == uuid.Nil {
+		return nil, errors.New("invalid resume token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTService_ResumeToken(t *testing.T) {
+	jwtService := NewJWTService("test-secret-key")
+	playerID := uuid.New()
+
+	token, expiresAt, err := jwtService.GenerateResumeToken(playerID, "ABCD")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(resumeTokenTTL), expiresAt, time.Second)
+
+	claims, err := jwtService.ValidateResumeToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, playerID, claims.PlayerID)
+	assert.Equal(t, "ABCD", claims.RoomCode)
+}
+
+func TestJWTService_ResumeTokenRejectsOtherTokens(t *testing.T) {
+	jwtService := NewJWTService("test-secret-key")
+
+	// A session JWT is not a resume token
+	sessionToken, err := jwtService.GenerateToken(&models.Session{ID: uuid.New(), AuthType: models.AuthTypeGuest}, nil, "Guest")
+	require.NoError(t, err)
+	_, err = jwtService.ValidateResumeToken(sessionToken)
+	assert.Error(t, err)
+
+	// ...and a resume token is not a session JWT
+	resumeToken, _, err := jwtService.GenerateResumeToken(uuid.New(), "ABCD")
+	require.NoError(t, err)
+	_, err = jwtService.ValidateToken(resumeToken)
+	assert.Error(t, err)
+
+	// Tokens signed with another secret are rejected
+	otherToken, _, err := NewJWTService("other-secret").GenerateResumeToken(uuid.New(), "ABCD")
+	require.NoError(t, err)
+	_, err = jwtService.ValidateResumeToken(otherToken)
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"dixitme/internal/models"
+)
+
+// Scopes limit what a token may be used for
+const (
+	ScopePlay        = "play"         // Join games, play, chat
+	ScopeManageCards = "manage_cards" // Create and edit cards and tags
+	ScopeAdmin       = "admin"        // Admin endpoints; implies every other scope
+)
+
+// AllScopes lists every scope in canonical order
+var AllScopes = []string{ScopePlay, ScopeManageCards, ScopeAdmin}
+
+// Account roles, set by admins. A role fixes the scopes of the user's new
+// sessions; accounts start as players.
+const (
+	RolePlayer  = models.UserRolePlayer
+	RoleCurator = "curator"
+	RoleAdmin   = "admin"
+)
+
+var roleScopes = map[string][]string{
+	RolePlayer:  {ScopePlay},
+	RoleCurator: {ScopePlay, ScopeManageCards},
+	RoleAdmin:   {ScopeAdmin},
+}
+
+// RoleScopes returns the scopes a role grants. Accounts stored before roles
+// existed have none and are players.
+func RoleScopes(role string) ([]string, error) {
+	if role == "" {
+		role = RolePlayer
+	}
+	scopes, ok := roleScopes[role]
+	if !ok {
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+	return scopes, nil
+}
+
+// ParseScopes splits a space-separated scope list, as stored on sessions, and
+// validates every entry. Duplicates are dropped.
+func ParseScopes(raw string) ([]string, error) {
+	return NormalizeScopes(strings.Fields(raw))
+}
+
+// NormalizeScopes validates scopes and returns them deduplicated in canonical order
+func NormalizeScopes(scopes []string) ([]string, error) {
+	requested := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if !isKnownScope(scope) {
+			return nil, fmt.Errorf("unknown scope %q", scope)
+		}
+		requested[scope] = true
+	}
+
+	normalized := make([]string, 0, len(requested))
+	for _, scope := range AllScopes {
+		if requested[scope] {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+// hasScope reports whether granted allows scope. Tokens issued before scopes
+// existed have an empty grant; they may play and nothing more.
+func hasScope(granted []string, scope string) bool {
+	if len(granted) == 0 {
+		return scope == ScopePlay
+	}
+	for _, g := range granted {
+		if g == scope || g == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownScope(scope string) bool {
+	for _, known := range AllScopes {
+		if known == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeScopes(t *testing.T) {
+	scopes, err := NormalizeScopes([]string{ScopeAdmin, ScopePlay, ScopePlay})
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopePlay, ScopeAdmin}, scopes)
+
+	_, err = NormalizeScopes([]string{"root"})
+	assert.Error(t, err)
+}
+
+func TestUserInfo_HasScope(t *testing.T) {
+	// Tokens from before scopes existed may play and nothing more
+	legacy := &UserInfo{}
+	assert.True(t, legacy.HasScope(ScopePlay))
+	assert.False(t, legacy.HasScope(ScopeManageCards))
+	assert.False(t, legacy.HasScope(ScopeAdmin))
+
+	player := &UserInfo{Scopes: []string{ScopePlay}}
+	assert.True(t, player.HasScope(ScopePlay))
+	assert.False(t, player.HasScope(ScopeManageCards))
+	assert.False(t, player.HasScope(ScopeAdmin))
+
+	admin := &UserInfo{Scopes: []string{ScopeAdmin}}
+	assert.True(t, admin.HasScope(ScopeManageCards))
+}
+
+func TestScopedTokenRoundTrip(t *testing.T) {
+	jwtService := NewJWTService("test-secret-key")
+	session := &models.Session{ID: uuid.New(), AuthType: models.AuthTypePassword, Scopes: "manage_cards"}
+	user := &models.User{ID: uuid.New(), DisplayName: "Integration"}
+
+	token, err := jwtService.GenerateToken(session, user, "")
+	require.NoError(t, err)
+
+	userInfo, err := jwtService.ExtractUserInfo(token)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopeManageCards}, userInfo.Scopes)
+	assert.False(t, userInfo.HasScope(ScopePlay))
+
+	refreshed, err := jwtService.RefreshToken(token)
+	require.NoError(t, err)
+	userInfo, err = jwtService.ExtractUserInfo(refreshed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopeManageCards}, userInfo.Scopes)
+}
+
+func TestRoleScopes(t *testing.T) {
+	scopes, err := RoleScopes("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopePlay}, scopes, "accounts without a role are players")
+
+	scopes, err = RoleScopes(RoleCurator)
+	require.NoError(t, err)
+	assert.True(t, hasScope(scopes, ScopeManageCards))
+	assert.False(t, hasScope(scopes, ScopeAdmin))
+
+	scopes, err = RoleScopes(RolePlayer)
+	require.NoError(t, err)
+	assert.False(t, hasScope(scopes, ScopeManageCards))
+
+	_, err = RoleScopes("owner")
+	assert.Error(t, err)
+}
+
+func TestNewSessionsOnlyPlay(t *testing.T) {
+	authTestDB(t)
+	jwtService := NewJWTService("test-secret")
+	authService := NewAuthService(jwtService)
+
+	_, guestToken, err := authService.CreateGuestSession("Guest Player", "127.0.0.1", "test-agent")
+	require.NoError(t, err)
+	guest, err := jwtService.ExtractUserInfo(guestToken)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopePlay}, guest.Scopes)
+
+	user, err := authService.RegisterWithPassword("new@example.com", "newuser", "New User", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, RolePlayer, user.Role)
+	_, _, token, err := authService.LoginWithPassword("new@example.com", "password123", "127.0.0.1", "test-agent")
+	require.NoError(t, err)
+	registered, err := jwtService.ExtractUserInfo(token)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopePlay}, registered.Scopes)
+	assert.False(t, registered.HasScope(ScopeAdmin))
+}
@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"dixitme/internal/database"
@@ -14,6 +16,15 @@ import (
 	"gorm.io/gorm"
 )
 
+var (
+	// ErrAccountExists is returned when registering an email or username that is taken
+	ErrAccountExists = errors.New("user with this email or username already exists")
+	// ErrInvalidCredentials is returned for an unknown account or a wrong password
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrGuestSessionNotFound is returned when upgrading a guest session that ended
+	ErrGuestSessionNotFound = errors.New("guest session not found or inactive")
+)
+
 // AuthenticationService defines the authentication operations interface
 type AuthenticationService interface {
 	// User registration and login
@@ -23,6 +34,7 @@ type AuthenticationService interface {
 
 	// Session management
 	CreateGuestSession(guestName, ipAddress, userAgent string) (*models.Session, string, error)
+	CreateIntegrationSession(userID uuid.UUID, authType models.AuthType, scopes []string, ipAddress, userAgent string) (*models.Session, string, error)
 	RefreshSession(sessionID uuid.UUID) (*models.Session, string, error)
 	LogoutSession(sessionID uuid.UUID) error
 	ValidateSession(sessionID uuid.UUID) bool
@@ -35,6 +47,12 @@ type AuthenticationService interface {
 	GetUserByID(userID uuid.UUID) (*models.User, error)
 	UpdateLastLogin(userID uuid.UUID) error
 	UpgradeGuestToUser(sessionID uuid.UUID, email, username, displayName, password string) (*models.User, error)
+
+	// Account administration
+	SetUserActive(userID uuid.UUID, active bool) (*models.User, error)
+	SetUserRole(userID uuid.UUID, role string) (*models.User, error)
+	CreatePasswordReset(userID uuid.UUID, requestedBy *uuid.UUID) (*models.User, string, error)
+	ResetPassword(token, password string) error
 }
 
 // AuthService handles authentication operations
@@ -65,7 +83,7 @@ func (a *AuthService) RegisterWithPassword(email, username, displayName, passwor
 	// Check if user already exists
 	var existingUser models.User
 	if err := a.db.Where("email = ? OR username = ?", email, username).First(&existingUser).Error; err == nil {
-		return nil, fmt.Errorf("user with this email or username already exists")
+		return nil, ErrAccountExists
 	}
 
 	// Hash password
@@ -82,6 +100,7 @@ func (a *AuthService) RegisterWithPassword(email, username, displayName, passwor
 		DisplayName:  displayName,
 		PasswordHash: string(hashedPassword),
 		AuthType:     models.AuthTypePassword,
+		Role:         RolePlayer,
 		IsActive:     true,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
@@ -101,12 +120,12 @@ func (a *AuthService) LoginWithPassword(emailOrUsername, password string, ipAddr
 	var user models.User
 	if err := a.db.Where("(email = ? OR username = ?) AND auth_type = ? AND is_active = ?",
 		emailOrUsername, emailOrUsername, models.AuthTypePassword, true).First(&user).Error; err != nil {
-		return nil, nil, "", fmt.Errorf("invalid credentials")
+		return nil, nil, "", ErrInvalidCredentials
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return nil, nil, "", fmt.Errorf("invalid credentials")
+		return nil, nil, "", ErrInvalidCredentials
 	}
 
 	// Create session
@@ -150,6 +169,7 @@ func (a *AuthService) LoginWithGoogle(googleAccessToken string, ipAddress, userA
 			AuthType:    models.AuthTypeGoogle,
 			GoogleID:    userInfo.Id,
 			Avatar:      userInfo.Picture,
+			Role:        RolePlayer,
 			IsActive:    true,
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
@@ -190,7 +210,7 @@ func (a *AuthService) CreateGuestSession(guestName, ipAddress, userAgent string)
 		guestName = "Guest " + uuid.New().String()[:8]
 	}
 
-	session, token, err := a.createSession(nil, models.AuthTypeGuest, ipAddress, userAgent)
+	session, token, err := a.createScopedSession(nil, guestName, models.AuthTypeGuest, []string{ScopePlay}, ipAddress, userAgent)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to create guest session: %w", err)
 	}
@@ -199,6 +219,23 @@ func (a *AuthService) CreateGuestSession(guestName, ipAddress, userAgent string)
 	return session, token, nil
 }
 
+// CreateIntegrationSession creates a separate session for a registered user
+// whose tokens carry only the given scopes, for handing to integrations
+func (a *AuthService) CreateIntegrationSession(userID uuid.UUID, authType models.AuthType, scopes []string, ipAddress, userAgent string) (*models.Session, string, error) {
+	user, err := a.GetUserByID(userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	session, token, err := a.createScopedSession(user, "", authType, scopes, ipAddress, userAgent)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create integration session: %w", err)
+	}
+
+	logger.GetLogger().Info("Integration session created", "session_id", session.ID, "user_id", user.ID, "scopes", session.Scopes)
+	return session, token, nil
+}
+
 // RefreshSession refreshes an existing session
 func (a *AuthService) RefreshSession(sessionID uuid.UUID) (*models.Session, string, error) {
 	var session models.Session
@@ -272,9 +309,20 @@ func (a *AuthService) CleanupExpiredSessions() error {
 // Helper functions
 
 func (a *AuthService) createSession(user *models.User, authType models.AuthType, ipAddress, userAgent string) (*models.Session, string, error) {
+	scopes, err := RoleScopes(user.Role)
+	if err != nil {
+		// A role this build doesn't know gets the least privilege
+		logger.GetLogger().Warn("Unknown user role, limiting session to play", "user_id", user.ID, "role", user.Role)
+		scopes = []string{ScopePlay}
+	}
+	return a.createScopedSession(user, "", authType, scopes, ipAddress, userAgent)
+}
+
+func (a *AuthService) createScopedSession(user *models.User, guestName string, authType models.AuthType, scopes []string, ipAddress, userAgent string) (*models.Session, string, error) {
 	session := models.Session{
 		ID:        uuid.New(),
 		AuthType:  authType,
+		Scopes:    strings.Join(scopes, " "),
 		IPAddress: ipAddress,
 		UserAgent: userAgent,
 		ExpiresAt: time.Now().Add(24 * time.Hour), // 24 hours
@@ -292,11 +340,6 @@ func (a *AuthService) createSession(user *models.User, authType models.AuthType,
 	}
 
 	// Generate JWT token
-	var guestName string
-	if user == nil {
-		guestName = "Guest " + session.ID.String()[:8]
-	}
-
 	token, err := a.jwtService.GenerateToken(&session, user, guestName)
 	if err != nil {
 		return nil, "", err
@@ -359,13 +402,13 @@ func (a *AuthService) UpgradeGuestToUser(sessionID uuid.UUID, email, username, d
 	var session models.Session
 	if err := a.db.First(&session, "id = ? AND is_active = ? AND auth_type = ?",
 		sessionID, true, models.AuthTypeGuest).Error; err != nil {
-		return nil, fmt.Errorf("guest session not found or inactive")
+		return nil, ErrGuestSessionNotFound
 	}
 
 	// Check if email or username already exists
 	var existingUser models.User
 	if err := a.db.Where("email = ? OR username = ?", email, username).First(&existingUser).Error; err == nil {
-		return nil, fmt.Errorf("user with this email or username already exists")
+		return nil, ErrAccountExists
 	}
 
 	// Hash password
@@ -382,21 +425,33 @@ func (a *AuthService) UpgradeGuestToUser(sessionID uuid.UUID, email, username, d
 		DisplayName:  displayName,
 		PasswordHash: string(hashedPassword),
 		AuthType:     models.AuthTypePassword,
+		Role:         RolePlayer,
 		IsActive:     true,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
 
-	if err := a.db.Create(&user).Error; err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
+	err = a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
 
-	// Update session to link to the new user
-	if err := a.db.Model(&session).Updates(map[string]interface{}{
-		"user_id":   user.ID,
-		"auth_type": models.AuthTypePassword,
-	}).Error; err != nil {
-		return nil, fmt.Errorf("failed to update session: %w", err)
+		// Update session to link to the new user
+		if err := tx.Model(&session).Updates(map[string]interface{}{
+			"user_id":   user.ID,
+			"auth_type": models.AuthTypePassword,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update session: %w", err)
+		}
+
+		// The guest played under their session ID; carry their history over
+		if err := database.MergePlayerIdentity(tx, session.ID, user.ID, &user.ID); err != nil {
+			return fmt.Errorf("failed to move guest history: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	logger.GetLogger().Info("Guest account upgraded to user", "user_id", user.ID, "email", email)
@@ -1,24 +1,47 @@
 package auth
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"dixitme/internal/database"
 	"dixitme/internal/models"
-	"dixitme/internal/testutils"
+	"dixitme/internal/testutils/testdb"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
-func TestAuthService_RegisterWithPassword(t *testing.T) {
-	db := testutils.SetupTestDB(t)
-	defer testutils.CleanupTestDB(db)
+// authTestDB swaps in a database with users and their sessions
+func authTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := testdb.Open(t, &models.User{}, &models.Session{})
+	previous := database.GetDB()
+	database.SetDB(db)
+	t.Cleanup(func() { database.SetDB(previous) })
+	return db
+}
+
+// createTestSession stores an active guest session
+func createTestSession(t *testing.T, db *gorm.DB) *models.Session {
+	t.Helper()
+	session := &models.Session{
+		ID:        uuid.New(),
+		Token:     uuid.NewString(),
+		AuthType:  models.AuthTypeGuest,
+		IsActive:  true,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, db.Create(session).Error)
+	return session
+}
 
-	// Mock the database
-	cleanup := testutils.MockDatabase(db)
-	defer cleanup()
+func TestAuthService_RegisterWithPassword(t *testing.T) {
+	db := authTestDB(t)
 
 	jwtService := NewJWTService("test-secret")
 	authService := NewAuthService(jwtService)
@@ -118,11 +141,7 @@ func TestAuthService_RegisterWithPassword(t *testing.T) {
 }
 
 func TestAuthService_LoginWithPassword(t *testing.T) {
-	db := testutils.SetupTestDB(t)
-	defer testutils.CleanupTestDB(db)
-
-	cleanup := testutils.MockDatabase(db)
-	defer cleanup()
+	db := authTestDB(t)
 
 	jwtService := NewJWTService("test-secret")
 	authService := NewAuthService(jwtService)
@@ -215,11 +234,7 @@ func TestAuthService_LoginWithPassword(t *testing.T) {
 }
 
 func TestAuthService_CreateGuestSession(t *testing.T) {
-	db := testutils.SetupTestDB(t)
-	defer testutils.CleanupTestDB(db)
-
-	cleanup := testutils.MockDatabase(db)
-	defer cleanup()
+	db := authTestDB(t)
 
 	jwtService := NewJWTService("test-secret")
 	authService := NewAuthService(jwtService)
@@ -262,27 +277,23 @@ func TestAuthService_CreateGuestSession(t *testing.T) {
 			assert.Equal(t, session.ID, userInfo.SessionID)
 			assert.Equal(t, models.AuthTypeGuest, userInfo.AuthType)
 
-			expectedName := tt.guestName
-			if expectedName == "" {
-				expectedName = "Guest"
+			if tt.guestName == "" {
+				assert.True(t, strings.HasPrefix(userInfo.Name, "Guest "), "nameless guests get a generated name")
+			} else {
+				assert.Equal(t, tt.guestName, userInfo.Name)
 			}
-			assert.Equal(t, expectedName, userInfo.Name)
 		})
 	}
 }
 
 func TestAuthService_ValidateSession(t *testing.T) {
-	db := testutils.SetupTestDB(t)
-	defer testutils.CleanupTestDB(db)
-
-	cleanup := testutils.MockDatabase(db)
-	defer cleanup()
+	db := authTestDB(t)
 
 	jwtService := NewJWTService("test-secret")
 	authService := NewAuthService(jwtService)
 
 	// Create test session
-	session := testutils.CreateTestSession(t, db, nil)
+	session := createTestSession(t, db)
 
 	tests := []struct {
 		name        string
@@ -310,17 +321,13 @@ func TestAuthService_ValidateSession(t *testing.T) {
 }
 
 func TestAuthService_Logout(t *testing.T) {
-	db := testutils.SetupTestDB(t)
-	defer testutils.CleanupTestDB(db)
-
-	cleanup := testutils.MockDatabase(db)
-	defer cleanup()
+	db := authTestDB(t)
 
 	jwtService := NewJWTService("test-secret")
 	authService := NewAuthService(jwtService)
 
 	// Create test session
-	session := testutils.CreateTestSession(t, db, nil)
+	session := createTestSession(t, db)
 	require.True(t, session.IsActive)
 
 	// Logout
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// upgradeTokenTTL is how long an account prompt can be acted on. Guest
+// sessions last a day, so a longer token would outlive the session it upgrades.
+const upgradeTokenTTL = 24 * time.Hour
+
+const upgradeAudience = "dixitme-upgrade"
+
+// UpgradeClaims identify the guest session an account prompt was sent to
+type UpgradeClaims struct {
+	SessionID uuid.UUID `json:"session_id"`
+	Milestone string    `json:"milestone"` // What prompted the upgrade, for conversion metrics
+	jwt.RegisteredClaims
+}
+
+// upgradeKey derives the upgrade token signing key, so upgrade tokens cannot
+// stand in for session or resume tokens
+func (j *JWTService) upgradeKey() []byte {
+	return append(append([]byte{}, j.secretKey...), []byte(":upgrade")...)
+}
+
+// GenerateUpgradeToken signs a token that lets a guest session become an
+// account without presenting its session JWT
+func (j *JWTService) GenerateUpgradeToken(sessionID uuid.UUID, milestone string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(upgradeTokenTTL)
+
+	claims := UpgradeClaims{
+		SessionID: sessionID,
+		Milestone: milestone,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "dixitme",
+			Audience:  jwt.ClaimStrings{upgradeAudience},
+			Subject:   sessionID.String(),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(j.upgradeKey())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// ValidateUpgradeToken validates an upgrade token and returns its claims
+func (j *JWTService) ValidateUpgradeToken(tokenString string) (*UpgradeClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &UpgradeClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		return j.upgradeKey(), nil
+	}, jwt.WithAudience(upgradeAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*UpgradeClaims)
+	if !ok || !token.Valid || claims.SessionID == uuid.Nil {
+		return nil, errors.New("invalid upgrade token")
+	}
+
+	return claims, nil
+}
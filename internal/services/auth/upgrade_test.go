@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTService_UpgradeToken(t *testing.T) {
+	jwtService := NewJWTService("test-secret-key")
+	sessionID := uuid.New()
+
+	token, expiresAt, err := jwtService.GenerateUpgradeToken(sessionID, "first_win")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(upgradeTokenTTL), expiresAt, time.Second)
+
+	claims, err := jwtService.ValidateUpgradeToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID, claims.SessionID)
+	assert.Equal(t, "first_win", claims.Milestone)
+}
+
+func TestJWTService_UpgradeTokenRejectsOtherTokens(t *testing.T) {
+	jwtService := NewJWTService("test-secret-key")
+
+	// A resume token is not an upgrade token, and the other way around
+	resumeToken, _, err := jwtService.GenerateResumeToken(uuid.New(), "ABCD")
+	require.NoError(t, err)
+	_, err = jwtService.ValidateUpgradeToken(resumeToken)
+	assert.Error(t, err)
+
+	upgradeToken, _, err := jwtService.GenerateUpgradeToken(uuid.New(), "third_game")
+	require.NoError(t, err)
+	_, err = jwtService.ValidateResumeToken(upgradeToken)
+	assert.Error(t, err)
+	_, err = jwtService.ValidateToken(upgradeToken)
+	assert.Error(t, err)
+}
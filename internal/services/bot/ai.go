@@ -10,6 +10,7 @@ import (
 	"dixitme/internal/database"
 	"dixitme/internal/logger"
 	"dixitme/internal/models"
+	"dixitme/internal/services/taxonomy"
 
 	"github.com/google/uuid"
 )
@@ -30,6 +31,25 @@ type BotPlayer struct {
 	Difficulty BotDifficulty `json:"difficulty"`
 	GameID     uuid.UUID     `json:"game_id"`
 	Hand       []int         `json:"hand"` // Card IDs in bot's hand
+
+	// The scores behind the bot's latest choice, for the room debug stream
+	LastDecision *Decision `json:"-"`
+}
+
+// Decision kinds
+const (
+	DecisionStoryteller = "storyteller"
+	DecisionSubmit      = "submit"
+	DecisionVote        = "vote"
+)
+
+// Decision records how a bot scored the cards it chose between
+type Decision struct {
+	Kind       string        `json:"kind"`
+	Difficulty BotDifficulty `json:"difficulty"`
+	Clue       string        `json:"clue,omitempty"`
+	CardID     int           `json:"card_id"`
+	Scores     []CardScore   `json:"scores"`
 }
 
 // CardScore represents a card with its calculated score for selection
@@ -41,9 +61,10 @@ type CardScore struct {
 
 // Tag represents a simple tag structure for bot logic
 type Tag struct {
-	Name     string  `json:"name"`
-	Weight   float64 `json:"weight"`
-	Category string  `json:"category"`
+	Name      string  `json:"name"`
+	Weight    float64 `json:"weight"`
+	Category  string  `json:"category"`
+	Inherited bool    `json:"inherited,omitempty"` // Related through the tag hierarchy rather than on the card
 }
 
 // BotManager manages all bot players
@@ -78,6 +99,27 @@ func (bm *BotManager) CreateBot(name string, difficulty BotDifficulty) *BotPlaye
 	return bot
 }
 
+// RestoreBot registers a bot under an existing player ID: a newly seated
+// bot's, or one from a game resumed from the cache by another instance or
+// after a restart
+func (bm *BotManager) RestoreBot(botID uuid.UUID, name string, difficulty BotDifficulty, gameID uuid.UUID) *BotPlayer {
+	if bot, exists := bm.bots[botID]; exists {
+		return bot
+	}
+
+	bot := &BotPlayer{
+		ID:         botID,
+		Name:       name,
+		Difficulty: difficulty,
+		GameID:     gameID,
+		Hand:       make([]int, 0),
+	}
+	bm.bots[botID] = bot
+	logger.Info("Bot restored", "bot_id", botID, "name", name, "difficulty", difficulty)
+
+	return bot
+}
+
 // GetBot returns a bot by ID
 func (bm *BotManager) GetBot(botID uuid.UUID) *BotPlayer {
 	return bm.bots[botID]
@@ -104,6 +146,7 @@ func (bp *BotPlayer) SelectCardForClue(clue string) (int, error) {
 
 	// Select card based on difficulty level
 	selectedCardID := bp.selectCardByDifficulty(cardScores)
+	bp.recordDecision(DecisionSubmit, clue, selectedCardID, cardScores)
 
 	logger.Debug("Bot selected card for clue",
 		"bot_id", bp.ID,
@@ -135,6 +178,7 @@ func (bp *BotPlayer) SelectCardAsStoryteller() (int, string, error) {
 
 	selectedCardID := bp.selectCardByDifficulty(cardScores)
 	clue := bp.generateClueForCard(selectedCardID)
+	bp.recordDecision(DecisionStoryteller, clue, selectedCardID, cardScores)
 
 	logger.Debug("Bot selected storyteller card",
 		"bot_id", bp.ID,
@@ -181,6 +225,7 @@ func (bp *BotPlayer) VoteForCard(submittedCardIDs []int, clue string, storytelle
 	}
 
 	selectedCardID := bp.selectCardByDifficulty(cardScores)
+	bp.recordDecision(DecisionVote, clue, selectedCardID, cardScores)
 
 	logger.Debug("Bot voted for card",
 		"bot_id", bp.ID,
@@ -191,12 +236,65 @@ func (bp *BotPlayer) VoteForCard(submittedCardIDs []int, clue string, storytelle
 	return selectedCardID, nil
 }
 
+// Top returns the n best scored candidates, best first
+func (d *Decision) Top(n int) []CardScore {
+	if n > len(d.Scores) {
+		n = len(d.Scores)
+	}
+	return d.Scores[:n]
+}
+
+// Rationale explains in a sentence where the chosen card ranked and why a
+// bot may pass over its best match
+func (d *Decision) Rationale() string {
+	rank := 0
+	for i, candidate := range d.Scores {
+		if candidate.CardID == d.CardID {
+			rank = i + 1
+			break
+		}
+	}
+	if rank == 0 || len(d.Scores) == 0 {
+		return fmt.Sprintf("Chose card %d without scoring it", d.CardID)
+	}
+
+	chosen := d.Scores[rank-1]
+	if rank == 1 {
+		return fmt.Sprintf("Chose the best of %d candidates, scoring %.2f", len(d.Scores), chosen.Score)
+	}
+	return fmt.Sprintf("Chose candidate %d of %d, scoring %.2f against the best %.2f: %s bots don't always play their best match",
+		rank, len(d.Scores), chosen.Score, d.Scores[0].Score, d.Difficulty)
+}
+
+// recordDecision keeps the scores behind a choice as the bot's LastDecision
+func (bp *BotPlayer) recordDecision(kind, clue string, cardID int, scores []CardScore) {
+	bp.LastDecision = &Decision{
+		Kind:       kind,
+		Difficulty: bp.Difficulty,
+		Clue:       clue,
+		CardID:     cardID,
+		Scores:     scores,
+	}
+}
+
 // calculateCardScore calculates how well a card matches a clue using tag analysis
 func (bp *BotPlayer) calculateCardScore(cardID int, clue string) float64 {
-	score := 0.0
+	// Get card tags, including broader and narrower tags from the hierarchy
+	tags := bp.getExpandedCardTags(cardID)
+	card := bp.getCardDetails(cardID)
 
-	// Get card tags
-	tags := bp.getCardTags(cardID)
+	score := bp.scoreCardForClue(tags, card.Description, clue)
+
+	// Add randomness to prevent predictable behavior
+	randomFactor := rand.Float64() * 0.5
+	score += randomFactor
+
+	return score
+}
+
+// scoreCardForClue scores a card's tags and description against a clue
+func (bp *BotPlayer) scoreCardForClue(tags []Tag, description, clue string) float64 {
+	score := 0.0
 
 	// Parse clue into keywords
 	clueWords := strings.Fields(strings.ToLower(clue))
@@ -217,7 +315,10 @@ func (bp *BotPlayer) calculateCardScore(cardID int, clue string) float64 {
 			}
 		}
 
-		// Category-based scoring
+		// Category-based scoring (only for tags on the card itself)
+		if tag.Inherited {
+			continue
+		}
 		switch tag.Category {
 		case "emotion":
 			if containsEmotionalWords(clueWords) {
@@ -235,24 +336,27 @@ func (bp *BotPlayer) calculateCardScore(cardID int, clue string) float64 {
 	}
 
 	// Add semantic scoring based on card description
-	card := bp.getCardDetails(cardID)
-	if card.Description != "" {
-		descScore := bp.calculateSemanticScore(card.Description, clue)
+	if description != "" {
+		descScore := bp.calculateSemanticScore(description, clue)
 		score += descScore
 	}
 
-	// Add randomness to prevent predictable behavior
-	randomFactor := rand.Float64() * 0.5
-	score += randomFactor
-
 	return score
 }
 
 // calculateStorytellerScore calculates how good a card is for being a storyteller card
 func (bp *BotPlayer) calculateStorytellerScore(cardID int) float64 {
-	score := 0.0
+	score := bp.scoreStorytellerTags(bp.getCardTags(cardID))
 
-	tags := bp.getCardTags(cardID)
+	// Add randomness
+	score += rand.Float64() * 1.5
+
+	return score
+}
+
+// scoreStorytellerTags scores how interpretable a card's tags make it, to the bot's taste
+func (bp *BotPlayer) scoreStorytellerTags(tags []Tag) float64 {
+	score := 0.0
 
 	// Prefer cards with multiple diverse tags (more interpretable)
 	score += float64(len(tags)) * 0.5
@@ -294,9 +398,6 @@ func (bp *BotPlayer) calculateStorytellerScore(cardID int) float64 {
 		}
 	}
 
-	// Add randomness
-	score += rand.Float64() * 1.5
-
 	return score
 }
 
@@ -489,6 +590,63 @@ func (bp *BotPlayer) getCardTags(cardID int) []Tag {
 	return tags
 }
 
+// getExpandedCardTags returns the card's tags plus related tags up and down the
+// taxonomy, with weights decayed by distance from the card's own tags
+func (bp *BotPlayer) getExpandedCardTags(cardID int) []Tag {
+	db := database.GetDB()
+
+	var cardTags []models.CardTag
+	if err := db.Preload("Tag").Where("card_id = ?", cardID).Find(&cardTags).Error; err != nil {
+		logger.Error("Failed to get card tags", "error", err, "card_id", cardID)
+		return []Tag{}
+	}
+
+	tags := make([]Tag, 0, len(cardTags))
+	direct := make(map[int]bool, len(cardTags))
+	for _, ct := range cardTags {
+		direct[ct.TagID] = true
+		tags = append(tags, Tag{
+			Name:     ct.Tag.Name,
+			Weight:   ct.Tag.Weight * ct.Weight,
+			Category: ct.Tag.Category,
+		})
+	}
+
+	tree, err := taxonomy.Load(db)
+	if err != nil {
+		logger.Warn("Failed to load tag taxonomy, using direct tags only", "error", err, "card_id", cardID)
+		return tags
+	}
+
+	// Keep the strongest relation when several card tags reach the same related tag
+	related := make(map[int]float64)
+	for _, ct := range cardTags {
+		for tagID, decay := range tree.Expand(ct.TagID, taxonomy.DirectionBoth, taxonomy.DefaultDecay, taxonomy.DefaultMaxDepth) {
+			if direct[tagID] {
+				continue
+			}
+			if weight := decay * ct.Weight; weight > related[tagID] {
+				related[tagID] = weight
+			}
+		}
+	}
+
+	for tagID, weight := range related {
+		tag, exists := tree.Tag(tagID)
+		if !exists {
+			continue
+		}
+		tags = append(tags, Tag{
+			Name:      tag.Name,
+			Weight:    tag.Weight * weight,
+			Category:  tag.Category,
+			Inherited: true,
+		})
+	}
+
+	return tags
+}
+
 func (bp *BotPlayer) getCardDetails(cardID int) models.Card {
 	db := database.GetDB()
 
@@ -568,17 +726,6 @@ func (bp *BotPlayer) SetGameID(gameID uuid.UUID) {
 	bp.GameID = gameID
 }
 
-// GetBotNames returns a list of predefined bot names
-func GetBotNames() []string {
-	return []string{
-		"Alice AI", "Bob Bot", "Charlie CPU", "Diana Digital",
-		"Echo Engine", "Felix Algorithm", "Grace GPU", "Hugo Heuristic",
-		"Iris Intelligence", "Jack Neural", "Kara Quantum", "Leo Logic",
-		"Maya Machine", "Nova Network", "Oscar Optimizer", "Pixel AI",
-		"Quinn Query", "Ruby Runtime", "Sam Synthetic", "Tera Tech",
-	}
-}
-
 // Initialize sets up the bot system
 func Initialize() {
 	// Seed random number generator
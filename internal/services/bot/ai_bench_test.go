@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"strconv"
+	"testing"
+)
+
+// benchCategories and benchWords make up synthetic tags and descriptions for
+// the 84-card deck, so bot scoring can be measured without a database
+var (
+	benchCategories = []string{"emotion", "nature", "action", "object", "fantasy"}
+	benchWords      = []string{
+		"storm", "quiet", "forest", "river", "dance", "lonely", "tower", "dream",
+		"ocean", "fire", "journey", "shadow", "music", "child", "moon", "door",
+	}
+)
+
+// benchCard returns the tags and description of a synthetic card
+func benchCard(cardID int) ([]Tag, string) {
+	tags := make([]Tag, 0, 6)
+	for i := 0; i < 3+cardID%4; i++ {
+		tags = append(tags, Tag{
+			Name:      benchWords[(cardID+i*5)%len(benchWords)],
+			Weight:    0.5 + float64((cardID+i)%5)/10,
+			Category:  benchCategories[(cardID+i)%len(benchCategories)],
+			Inherited: i >= 3, // Cards carry three direct tags, the rest come from the hierarchy
+		})
+	}
+	description := "A " + benchWords[cardID%len(benchWords)] + " near the " +
+		benchWords[(cardID*7)%len(benchWords)] + " at dusk, card " + strconv.Itoa(cardID)
+	return tags, description
+}
+
+// BenchmarkScoreCardForClue scores the whole deck against a clue, the work
+// bots do for every hand and every vote
+func BenchmarkScoreCardForClue(b *testing.B) {
+	bp := &BotPlayer{Difficulty: BotMedium}
+	tags := make([][]Tag, 84)
+	descriptions := make([]string, 84)
+	for i := range tags {
+		tags[i], descriptions[i] = benchCard(i + 1)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for card := range tags {
+			bp.scoreCardForClue(tags[card], descriptions[card], "a quiet storm over the forest")
+		}
+	}
+}
+
+// BenchmarkScoreStorytellerTags scores a six-card hand for each difficulty
+func BenchmarkScoreStorytellerTags(b *testing.B) {
+	hand := make([][]Tag, 6)
+	for i := range hand {
+		hand[i], _ = benchCard(i*13 + 1)
+	}
+
+	for _, difficulty := range []BotDifficulty{BotEasy, BotMedium, BotHard} {
+		b.Run(string(difficulty), func(b *testing.B) {
+			bp := &BotPlayer{Difficulty: difficulty}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, tags := range hand {
+					bp.scoreStorytellerTags(tags)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSelectCardByDifficulty ranks and picks from a six-card hand
+func BenchmarkSelectCardByDifficulty(b *testing.B) {
+	for _, difficulty := range []BotDifficulty{BotEasy, BotMedium, BotHard} {
+		b.Run(string(difficulty), func(b *testing.B) {
+			bp := &BotPlayer{Difficulty: difficulty}
+			scores := make([]CardScore, 6)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for card := range scores {
+					scores[card] = CardScore{CardID: card + 1, Score: float64((card*7 + i) % 11)}
+				}
+				bp.selectCardByDifficulty(scores)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecisionTopAndRationale(t *testing.T) {
+	decision := &Decision{
+		Kind:       DecisionVote,
+		Difficulty: BotEasy,
+		CardID:     7,
+		Scores: []CardScore{
+			{CardID: 3, Score: 4.5},
+			{CardID: 7, Score: 2.25},
+			{CardID: 9, Score: 1},
+			{CardID: 1, Score: 0.5},
+		},
+	}
+
+	assert.Equal(t, []CardScore{{CardID: 3, Score: 4.5}, {CardID: 7, Score: 2.25}, {CardID: 9, Score: 1}}, decision.Top(3))
+	assert.Len(t, decision.Top(10), 4)
+	assert.Equal(t, "Chose candidate 2 of 4, scoring 2.25 against the best 4.50: easy bots don't always play their best match", decision.Rationale())
+
+	decision.CardID = 3
+	assert.Equal(t, "Chose the best of 4 candidates, scoring 4.50", decision.Rationale())
+}
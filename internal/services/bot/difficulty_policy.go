@@ -0,0 +1,175 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LevelAuto asks for a bot whose difficulty the difficulty policy picks
+const LevelAuto = "auto"
+
+// Difficulty policy modes
+const (
+	PolicyHeuristic = "heuristic" // Easy bots in rooms of three or fewer players, medium otherwise
+	PolicyWeighted  = "weighted"  // A random difficulty, weighted by the policy's percentages
+	PolicyMirror    = "mirror"    // The difficulty matching the average rating of the room's humans
+)
+
+// mirrorBand is how far the room's average rating has to be from the default
+// rating for mirror mode to pick an easy or hard bot
+const mirrorBand = 100
+
+// DifficultyPolicy decides the difficulty of bots the server picks itself:
+// AFK replacements and bots added with the auto level
+type DifficultyPolicy struct {
+	Mode    string                `json:"mode"`
+	Weights map[BotDifficulty]int `json:"weights,omitempty"` // Percentages for the weighted mode, summing to 100
+}
+
+var (
+	policyMu sync.RWMutex
+	policy   = DefaultDifficultyPolicy()
+)
+
+// DefaultDifficultyPolicy is the policy used until an admin sets one
+func DefaultDifficultyPolicy() DifficultyPolicy {
+	return DifficultyPolicy{Mode: PolicyHeuristic}
+}
+
+// Validate checks the mode and, for the weighted mode, the percentages
+func (p DifficultyPolicy) Validate() error {
+	switch p.Mode {
+	case PolicyHeuristic, PolicyMirror:
+		if len(p.Weights) > 0 {
+			return fmt.Errorf("weights only apply to the weighted mode")
+		}
+	case PolicyWeighted:
+		total := 0
+		for difficulty, weight := range p.Weights {
+			if difficulty != BotEasy && difficulty != BotMedium && difficulty != BotHard {
+				return fmt.Errorf("unknown bot difficulty %q", difficulty)
+			}
+			if weight < 0 {
+				return fmt.Errorf("weights can't be negative")
+			}
+			total += weight
+		}
+		if total != 100 {
+			return fmt.Errorf("weights must add up to 100, got %d", total)
+		}
+	default:
+		return fmt.Errorf("mode must be heuristic, weighted or mirror")
+	}
+	return nil
+}
+
+// Pick chooses a bot difficulty for a room with the given number of players.
+// averageRating is the mean rating of the room's humans, only used by the
+// mirror mode.
+func (p DifficultyPolicy) Pick(players, averageRating int, rng *rand.Rand) BotDifficulty {
+	switch p.Mode {
+	case PolicyWeighted:
+		roll := rng.Intn(100)
+		for _, difficulty := range []BotDifficulty{BotEasy, BotMedium, BotHard} {
+			if roll < p.Weights[difficulty] {
+				return difficulty
+			}
+			roll -= p.Weights[difficulty]
+		}
+		return BotMedium
+	case PolicyMirror:
+		switch {
+		case averageRating < models.DefaultRating-mirrorBand:
+			return BotEasy
+		case averageRating > models.DefaultRating+mirrorBand:
+			return BotHard
+		default:
+			return BotMedium
+		}
+	default:
+		if players <= 3 {
+			return BotEasy
+		}
+		return BotMedium
+	}
+}
+
+// CurrentPolicy returns the difficulty policy in effect
+func CurrentPolicy() DifficultyPolicy {
+	policyMu.RLock()
+	defer policyMu.RUnlock()
+	return policy
+}
+
+// LoadPolicy reads the admins' difficulty policy from the database
+func LoadPolicy(ctx context.Context, db *gorm.DB) error {
+	var row models.BotPolicyOverride
+	err := db.WithContext(ctx).First(&row, "id = ?", models.BotPolicyOverrideID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		setPolicy(DefaultDifficultyPolicy())
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load bot difficulty policy: %w", err)
+	}
+
+	var p DifficultyPolicy
+	if err := json.Unmarshal([]byte(row.Policy), &p); err != nil {
+		return fmt.Errorf("failed to decode bot difficulty policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return fmt.Errorf("invalid bot difficulty policy: %w", err)
+	}
+	setPolicy(p)
+	return nil
+}
+
+// SavePolicy validates and stores a difficulty policy. Other instances pick it
+// up through the cache invalidation trigger.
+func SavePolicy(ctx context.Context, db *gorm.DB, p DifficultyPolicy, updatedBy *uuid.UUID) (*models.BotPolicyOverride, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode bot difficulty policy: %w", err)
+	}
+	row := models.BotPolicyOverride{
+		ID:        models.BotPolicyOverrideID,
+		Policy:    string(encoded),
+		UpdatedBy: updatedBy,
+		UpdatedAt: time.Now(),
+	}
+	if err := db.WithContext(ctx).Save(&row).Error; err != nil {
+		return nil, fmt.Errorf("failed to save bot difficulty policy: %w", err)
+	}
+
+	setPolicy(p)
+	return &row, nil
+}
+
+// ResetPolicy drops the admins' difficulty policy, going back to the default
+func ResetPolicy(ctx context.Context, db *gorm.DB) error {
+	if err := db.WithContext(ctx).Delete(&models.BotPolicyOverride{}, "id = ?", models.BotPolicyOverrideID).Error; err != nil {
+		return fmt.Errorf("failed to reset bot difficulty policy: %w", err)
+	}
+	setPolicy(DefaultDifficultyPolicy())
+	return nil
+}
+
+func setPolicy(p DifficultyPolicy) {
+	policyMu.Lock()
+	policy = p
+	policyMu.Unlock()
+}
@@ -0,0 +1,47 @@
+package bot
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDifficultyPolicyValidate(t *testing.T) {
+	assert.NoError(t, DefaultDifficultyPolicy().Validate())
+	assert.NoError(t, DifficultyPolicy{Mode: PolicyMirror}.Validate())
+	assert.NoError(t, DifficultyPolicy{Mode: PolicyWeighted, Weights: map[BotDifficulty]int{BotEasy: 20, BotMedium: 60, BotHard: 20}}.Validate())
+
+	assert.Error(t, DifficultyPolicy{Mode: "random"}.Validate())
+	assert.Error(t, DifficultyPolicy{Mode: PolicyWeighted, Weights: map[BotDifficulty]int{BotEasy: 50}}.Validate())
+	assert.Error(t, DifficultyPolicy{Mode: PolicyWeighted, Weights: map[BotDifficulty]int{"expert": 100}}.Validate())
+	assert.Error(t, DifficultyPolicy{Mode: PolicyWeighted, Weights: map[BotDifficulty]int{BotEasy: 120, BotHard: -20}}.Validate())
+	assert.Error(t, DifficultyPolicy{Mode: PolicyHeuristic, Weights: map[BotDifficulty]int{BotEasy: 100}}.Validate())
+}
+
+func TestDifficultyPolicyPick(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	heuristic := DefaultDifficultyPolicy()
+	assert.Equal(t, BotEasy, heuristic.Pick(3, 0, rng))
+	assert.Equal(t, BotMedium, heuristic.Pick(4, 0, rng))
+
+	mirror := DifficultyPolicy{Mode: PolicyMirror}
+	assert.Equal(t, BotEasy, mirror.Pick(4, 1000, rng))
+	assert.Equal(t, BotMedium, mirror.Pick(4, 1250, rng))
+	assert.Equal(t, BotHard, mirror.Pick(4, 1400, rng))
+
+	onlyHard := DifficultyPolicy{Mode: PolicyWeighted, Weights: map[BotDifficulty]int{BotHard: 100}}
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, BotHard, onlyHard.Pick(4, 0, rng))
+	}
+
+	mixed := DifficultyPolicy{Mode: PolicyWeighted, Weights: map[BotDifficulty]int{BotEasy: 20, BotMedium: 60, BotHard: 20}}
+	counts := make(map[BotDifficulty]int)
+	for i := 0; i < 10000; i++ {
+		counts[mixed.Pick(4, 0, rng)]++
+	}
+	assert.InDelta(t, 2000, counts[BotEasy], 300)
+	assert.InDelta(t, 6000, counts[BotMedium], 300)
+	assert.InDelta(t, 2000, counts[BotHard], 300)
+}
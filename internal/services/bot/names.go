@@ -0,0 +1,215 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+
+	"dixitme/internal/logger"
+)
+
+// defaultLocale is the name pool used when a room's locale has none
+const defaultLocale = "en"
+
+// defaultNamePools are the built-in bot names per locale
+var defaultNamePools = map[string][]string{
+	"en": {
+		"Alice AI", "Bob Bot", "Charlie CPU", "Diana Digital",
+		"Echo Engine", "Felix Algorithm", "Grace GPU", "Hugo Heuristic",
+		"Iris Intelligence", "Jack Neural", "Kara Quantum", "Leo Logic",
+		"Maya Machine", "Nova Network", "Oscar Optimizer", "Pixel AI",
+		"Quinn Query", "Ruby Runtime", "Sam Synthetic", "Tera Tech",
+	},
+	"fr": {
+		"Amélie Algo", "Bastien Bot", "Chloé Circuit", "Denis Données",
+		"Élodie Électro", "Fabien Fichier", "Gaëlle GPU", "Hugo Heuristique",
+	},
+	"es": {
+		"Ana Algoritmo", "Beto Bot", "Carla Circuito", "Diego Digital",
+		"Elena Electrón", "Fede Fórmula", "Gloria GPU", "Hugo Heurístico",
+	},
+	"de": {
+		"Anna Algorithmus", "Bernd Bot", "Clara Chip", "Dieter Digital",
+		"Emma Elektron", "Felix Formel", "Greta GPU", "Hans Heuristik",
+	},
+	"vi": {
+		"An Máy", "Bình Bot", "Chi Chip", "Dũng Dữ Liệu",
+		"Hà Thuật Toán", "Khoa Mạch", "Lan Logic", "Minh Mạng",
+	},
+}
+
+// defaultReservedNames can't be used as bot names, so bots never pass for staff or the system
+var defaultReservedNames = []string{
+	"admin", "administrator", "moderator", "mod", "system",
+	"host", "server", "support", "staff", "dixitme",
+}
+
+var (
+	namesMu       sync.RWMutex
+	namePools     = defaultNamePools
+	reservedNames = normalizedSet(defaultReservedNames)
+)
+
+// SetNamePools replaces the bot name pools. Reserved names are dropped; locales
+// left empty fall back to the default pool.
+func SetNamePools(pools map[string][]string) {
+	namesMu.Lock()
+	defer namesMu.Unlock()
+
+	cleaned := make(map[string][]string, len(pools))
+	for locale, names := range pools {
+		var kept []string
+		for _, name := range names {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if reservedNames[normalizeName(name)] {
+				logger.Warn("Dropping reserved bot name from name pool", "locale", locale, "name", name)
+				continue
+			}
+			kept = append(kept, name)
+		}
+		if len(kept) > 0 {
+			cleaned[strings.ToLower(locale)] = kept
+		}
+	}
+	if len(cleaned[defaultLocale]) == 0 {
+		cleaned[defaultLocale] = defaultNamePools[defaultLocale]
+	}
+	namePools = cleaned
+}
+
+// LoadNamePools reads bot name pools from a JSON file mapping locale to names,
+// e.g. {"en": ["Alice AI"], "fr": ["Amélie Algo"]}
+func LoadNamePools(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bot names file: %w", err)
+	}
+	var pools map[string][]string
+	if err := json.Unmarshal(data, &pools); err != nil {
+		return nil, fmt.Errorf("failed to parse bot names file: %w", err)
+	}
+	return pools, nil
+}
+
+// AddReservedNames reserves extra names on top of the built-in ones
+func AddReservedNames(names []string) {
+	namesMu.Lock()
+	defer namesMu.Unlock()
+	for _, name := range names {
+		if key := normalizeName(name); key != "" {
+			reservedNames[key] = true
+		}
+	}
+}
+
+// IsReservedName reports whether a name is reserved, ignoring case, spacing and punctuation
+func IsReservedName(name string) bool {
+	namesMu.RLock()
+	defer namesMu.RUnlock()
+	return reservedNames[normalizeName(name)]
+}
+
+// GetBotNames returns the bot names of the default locale
+func GetBotNames() []string {
+	return NamesFor(defaultLocale)
+}
+
+// NamesFor returns the bot name pool of a locale such as "fr" or "pt-BR",
+// falling back to the base language and then to the default pool
+func NamesFor(locale string) []string {
+	namesMu.RLock()
+	defer namesMu.RUnlock()
+
+	locale = strings.ToLower(locale)
+	if names, ok := namePools[locale]; ok {
+		return names
+	}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		if names, ok := namePools[base]; ok {
+			return names
+		}
+	}
+	return namePools[defaultLocale]
+}
+
+// GenerateName picks a bot name from the locale's pool that isn't reserved and
+// doesn't collide with any name in taken. Once the pool runs out, names get a
+// number suffix.
+func GenerateName(locale string, taken []string) string {
+	return generateName(NamesFor(locale), taken, rand.Intn)
+}
+
+// ReplacementName names the bot taking over a player's seat after that player.
+// Reserved names or names matching someone in the room fall back to the pool.
+func ReplacementName(locale, playerName string, taken []string) string {
+	name := "Bot-" + playerName
+	if !IsReservedName(playerName) && !containsName(taken, name) {
+		return name
+	}
+	return GenerateName(locale, taken)
+}
+
+func generateName(pool, taken []string, intn func(int) int) string {
+	if len(pool) == 0 {
+		pool = defaultNamePools[defaultLocale]
+	}
+	used := normalizedSet(taken)
+
+	var available []string
+	for _, name := range pool {
+		key := normalizeName(name)
+		if !used[key] && !IsReservedName(name) {
+			available = append(available, name)
+		}
+	}
+	if len(available) > 0 {
+		return available[intn(len(available))]
+	}
+
+	// Every pool name is in use, so number one of them
+	base := pool[intn(len(pool))]
+	for i := 2; ; i++ {
+		name := fmt.Sprintf("%s %d", base, i)
+		if !used[normalizeName(name)] {
+			return name
+		}
+	}
+}
+
+func containsName(names []string, name string) bool {
+	key := normalizeName(name)
+	for _, n := range names {
+		if normalizeName(n) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeName folds case and drops spacing and punctuation, so "A.d m-i n" matches "admin"
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func normalizedSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		if key := normalizeName(name); key != "" {
+			set[key] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,43 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pickFirst(n int) int { return 0 }
+
+func TestGenerateNameSkipsTakenAndReserved(t *testing.T) {
+	pool := []string{"Admin", "Alice AI", "Bob Bot"}
+
+	assert.Equal(t, "Bob Bot", generateName(pool, []string{"alice ai"}, pickFirst))
+}
+
+func TestGenerateNameNumbersWhenPoolRunsOut(t *testing.T) {
+	pool := []string{"Alice AI"}
+
+	assert.Equal(t, "Alice AI 2", generateName(pool, []string{"Alice AI"}, pickFirst))
+	assert.Equal(t, "Alice AI 3", generateName(pool, []string{"Alice AI", "Alice AI 2"}, pickFirst))
+}
+
+func TestIsReservedNameIgnoresFormatting(t *testing.T) {
+	assert.True(t, IsReservedName("ADMIN"))
+	assert.True(t, IsReservedName("A.d m-i n"))
+	assert.False(t, IsReservedName("Adminton"))
+}
+
+func TestReplacementName(t *testing.T) {
+	assert.Equal(t, "Bot-Alice", ReplacementName("en", "Alice", []string{"Alice"}))
+
+	name := ReplacementName("en", "Admin", []string{"Admin"})
+	assert.NotEqual(t, "Bot-Admin", name)
+	assert.Contains(t, NamesFor("en"), name)
+}
+
+func TestNamesForFallsBack(t *testing.T) {
+	assert.Equal(t, defaultNamePools["fr"], NamesFor("fr"))
+	assert.Equal(t, defaultNamePools["fr"], NamesFor("FR-ca"))
+	assert.Equal(t, defaultNamePools["en"], NamesFor("xx"))
+	assert.Equal(t, defaultNamePools["en"], NamesFor(""))
+}
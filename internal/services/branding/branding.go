@@ -0,0 +1,218 @@
+// Package branding holds the look of a deployment: name, logo, colors, default
+// deck and footer links. The configured branding can be overridden by admins
+// at runtime, so one server binary can serve differently branded deployments.
+package branding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxNameLength  = 60
+	maxFooterLinks = 10
+	maxLabelLength = 40
+)
+
+// Link is a footer link
+type Link struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// Palette is the deployment's colors, as hex codes
+type Palette struct {
+	Primary    string `json:"primary,omitempty"`
+	Secondary  string `json:"secondary,omitempty"`
+	Accent     string `json:"accent,omitempty"`
+	Background string `json:"background,omitempty"`
+	Text       string `json:"text,omitempty"`
+}
+
+// Branding is what clients need to present a deployment
+type Branding struct {
+	Name        string  `json:"name"`
+	LogoURL     string  `json:"logo_url,omitempty"`
+	Colors      Palette `json:"colors"`
+	DefaultDeck string  `json:"default_deck,omitempty"` // Deck clients preselect
+	FooterLinks []Link  `json:"footer_links"`
+}
+
+var (
+	mu       sync.RWMutex
+	defaults = Branding{Name: "DixitMe", FooterLinks: []Link{}}
+	override *Branding // Set by admins; empty fields fall back to the defaults
+)
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Configure sets the deployment's configured branding
+func Configure(b Branding) {
+	if b.FooterLinks == nil {
+		b.FooterLinks = []Link{}
+	}
+	mu.Lock()
+	defaults = b
+	mu.Unlock()
+}
+
+// Current returns the branding clients should use
+func Current() Branding {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if override == nil {
+		return defaults
+	}
+	return merge(defaults, *override)
+}
+
+// Validate checks colors, URLs and lengths
+func Validate(b Branding) error {
+	if len(b.Name) > maxNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxNameLength)
+	}
+	if err := validateURL("logo URL", b.LogoURL); err != nil {
+		return err
+	}
+	for name, color := range map[string]string{
+		"primary":    b.Colors.Primary,
+		"secondary":  b.Colors.Secondary,
+		"accent":     b.Colors.Accent,
+		"background": b.Colors.Background,
+		"text":       b.Colors.Text,
+	} {
+		if color != "" && !hexColor.MatchString(color) {
+			return fmt.Errorf("%s color must be a hex code like #3B82F6", name)
+		}
+	}
+	if len(b.FooterLinks) > maxFooterLinks {
+		return fmt.Errorf("at most %d footer links are allowed", maxFooterLinks)
+	}
+	for _, link := range b.FooterLinks {
+		if strings.TrimSpace(link.Label) == "" || len(link.Label) > maxLabelLength {
+			return fmt.Errorf("footer link labels must be between 1 and %d characters", maxLabelLength)
+		}
+		if link.URL == "" {
+			return fmt.Errorf("footer link %q needs a URL", link.Label)
+		}
+		if err := validateURL("footer link URL", link.URL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadOverride reads the admins' branding from the database
+func LoadOverride(ctx context.Context, db *gorm.DB) error {
+	var row models.BrandingOverride
+	err := db.WithContext(ctx).First(&row, "id = ?", models.BrandingOverrideID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		setOverride(nil)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load branding: %w", err)
+	}
+
+	var b Branding
+	if err := json.Unmarshal([]byte(row.Branding), &b); err != nil {
+		return fmt.Errorf("failed to decode branding: %w", err)
+	}
+	setOverride(&b)
+	return nil
+}
+
+// SaveOverride validates and stores branding that wins over the configured
+// branding, field by field. Other instances pick it up through the cache
+// invalidation trigger.
+func SaveOverride(ctx context.Context, db *gorm.DB, b Branding, updatedBy *uuid.UUID) (*models.BrandingOverride, error) {
+	if err := Validate(b); err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode branding: %w", err)
+	}
+	row := models.BrandingOverride{
+		ID:        models.BrandingOverrideID,
+		Branding:  string(encoded),
+		UpdatedBy: updatedBy,
+		UpdatedAt: time.Now(),
+	}
+	if err := db.WithContext(ctx).Save(&row).Error; err != nil {
+		return nil, fmt.Errorf("failed to save branding: %w", err)
+	}
+
+	setOverride(&b)
+	return &row, nil
+}
+
+// ResetOverride drops the admins' branding, going back to the configured one
+func ResetOverride(ctx context.Context, db *gorm.DB) error {
+	if err := db.WithContext(ctx).Delete(&models.BrandingOverride{}, "id = ?", models.BrandingOverrideID).Error; err != nil {
+		return fmt.Errorf("failed to reset branding: %w", err)
+	}
+	setOverride(nil)
+	return nil
+}
+
+func setOverride(b *Branding) {
+	mu.Lock()
+	override = b
+	mu.Unlock()
+}
+
+// merge lays the set fields of over on top of base
+func merge(base, over Branding) Branding {
+	pick := func(value, fallback string) string {
+		if value != "" {
+			return value
+		}
+		return fallback
+	}
+
+	merged := Branding{
+		Name:    pick(over.Name, base.Name),
+		LogoURL: pick(over.LogoURL, base.LogoURL),
+		Colors: Palette{
+			Primary:    pick(over.Colors.Primary, base.Colors.Primary),
+			Secondary:  pick(over.Colors.Secondary, base.Colors.Secondary),
+			Accent:     pick(over.Colors.Accent, base.Colors.Accent),
+			Background: pick(over.Colors.Background, base.Colors.Background),
+			Text:       pick(over.Colors.Text, base.Colors.Text),
+		},
+		DefaultDeck: pick(over.DefaultDeck, base.DefaultDeck),
+		FooterLinks: base.FooterLinks,
+	}
+	// A nil list keeps the configured links; an empty one removes them
+	if over.FooterLinks != nil {
+		merged.FooterLinks = over.FooterLinks
+	}
+	return merged
+}
+
+// validateURL accepts absolute http(s) URLs and paths on this server
+func validateURL(field, raw string) error {
+	if raw == "" || strings.HasPrefix(raw, "/") && !strings.HasPrefix(raw, "//") {
+		return nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%s must be an http(s) URL or a path", field)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package branding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentMergesOverride(t *testing.T) {
+	t.Cleanup(func() {
+		Configure(Branding{Name: "DixitMe"})
+		setOverride(nil)
+	})
+
+	Configure(Branding{
+		Name:        "DixitMe",
+		LogoURL:     "/logo.svg",
+		Colors:      Palette{Primary: "#3B82F6", Accent: "#F59E0B"},
+		FooterLinks: []Link{{Label: "Privacy", URL: "/privacy"}},
+	})
+	assert.Equal(t, "DixitMe", Current().Name)
+
+	setOverride(&Branding{Name: "Story Night", Colors: Palette{Primary: "#111"}})
+	current := Current()
+	assert.Equal(t, "Story Night", current.Name)
+	assert.Equal(t, "/logo.svg", current.LogoURL)
+	assert.Equal(t, Palette{Primary: "#111", Accent: "#F59E0B"}, current.Colors)
+	assert.Equal(t, []Link{{Label: "Privacy", URL: "/privacy"}}, current.FooterLinks)
+
+	// An empty list removes the configured links
+	setOverride(&Branding{FooterLinks: []Link{}})
+	assert.Empty(t, Current().FooterLinks)
+	assert.Equal(t, "DixitMe", Current().Name)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(Branding{
+		LogoURL:     "https://cdn.example.com/logo.png",
+		Colors:      Palette{Primary: "#3B82F6", Text: "#fff"},
+		FooterLinks: []Link{{Label: "Terms", URL: "/terms"}},
+	}))
+
+	assert.Error(t, Validate(Branding{Colors: Palette{Primary: "blue"}}))
+	assert.Error(t, Validate(Branding{LogoURL: "javascript:alert(1)"}))
+	assert.Error(t, Validate(Branding{LogoURL: "//evil.example.com/logo.png"}))
+	assert.Error(t, Validate(Branding{FooterLinks: []Link{{Label: "", URL: "/terms"}}}))
+	assert.Error(t, Validate(Branding{FooterLinks: []Link{{Label: "Terms"}}}))
+}
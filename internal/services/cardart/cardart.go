@@ -0,0 +1,134 @@
+// Package cardart signs the URLs clients use to fetch card art lazily. Game
+// state payloads carry card IDs and one signed URL template instead of per-card
+// metadata; clients fill the template in for the variant they need.
+package cardart
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Art variants
+const (
+	VariantThumb = "thumb" // Hand and table display
+	VariantFull  = "full"  // Full resolution, for the reveal zoom
+)
+
+// Placeholders clients substitute in a template
+const (
+	CardIDPlaceholder  = "{card_id}"
+	VariantPlaceholder = "{variant}"
+)
+
+// URLTTL is how long a signed URL stays valid. Expiries are rounded to half of
+// it, so every payload sent in the same window carries the same template and
+// art stays cacheable.
+const URLTTL = time.Hour
+
+var (
+	ErrNotConfigured    = errors.New("card art signing is not configured")
+	ErrInvalidSignature = errors.New("invalid card art signature")
+	ErrExpired          = errors.New("card art URL has expired")
+)
+
+// Template is a signed URL template for card art
+type Template struct {
+	URL       string    `json:"url"` // Contains {card_id} and {variant}
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+var (
+	mu       sync.RWMutex
+	key      []byte
+	basePath string
+)
+
+// Configure enables signing with a key derived from secret. basePath is the API
+// prefix the art route is mounted under, e.g. /api/v2.
+func Configure(secret, apiBasePath string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	key = []byte(secret + ":card-art")
+	basePath = strings.TrimRight(apiBasePath, "/")
+}
+
+// IsVariant reports whether variant is a known art variant
+func IsVariant(variant string) bool {
+	return variant == VariantThumb || variant == VariantFull
+}
+
+// NewTemplate signs a URL template valid until the end of the current window.
+// It returns nil when signing is not configured.
+func NewTemplate(now time.Time) *Template {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if key == nil {
+		return nil
+	}
+
+	expiresAt := now.Truncate(URLTTL / 2).Add(URLTTL)
+	return &Template{
+		URL:       signedURL(CardIDPlaceholder, VariantPlaceholder, expiresAt),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// URL returns the signed art URL of one card, or "" when signing is not configured
+func URL(cardID int, variant string, now time.Time) string {
+	template := NewTemplate(now)
+	if template == nil {
+		return ""
+	}
+	return Expand(template.URL, cardID, variant)
+}
+
+// Expand fills a template in for one card and variant
+func Expand(template string, cardID int, variant string) string {
+	return strings.NewReplacer(CardIDPlaceholder, strconv.Itoa(cardID), VariantPlaceholder, variant).Replace(template)
+}
+
+// Verify checks the exp and sig query values of an art URL
+func Verify(exp, sig string, now time.Time) error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if key == nil {
+		return ErrNotConfigured
+	}
+
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	expected, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(expected, sign(unix)) {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > unix {
+		return ErrExpired
+	}
+	return nil
+}
+
+// signedURL builds an art URL. Callers must hold mu.
+func signedURL(cardID, variant string, expiresAt time.Time) string {
+	unix := expiresAt.Unix()
+	return fmt.Sprintf("%s/cards/%s/art/%s?exp=%d&sig=%s", basePath, cardID, variant, unix, hex.EncodeToString(sign(unix)))
+}
+
+// sign authenticates an expiry. The signature does not cover the card, so one
+// template serves a whole hand. Callers must hold mu.
+func sign(expiresAt int64) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strconv.FormatInt(expiresAt, 10)))
+	return mac.Sum(nil)[:16]
+}
@@ -0,0 +1,38 @@
+package cardart
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedURLRoundTrip(t *testing.T) {
+	Configure("secret", "/api/v2")
+	now := time.Date(2024, 1, 1, 12, 10, 0, 0, time.UTC)
+
+	template := NewTemplate(now)
+	require.NotNil(t, template)
+	assert.Contains(t, template.URL, CardIDPlaceholder)
+	assert.Equal(t, time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC), template.ExpiresAt)
+
+	// Templates are stable within a window
+	assert.Equal(t, template.URL, NewTemplate(now.Add(15*time.Minute)).URL)
+
+	expanded := Expand(template.URL, 42, VariantFull)
+	assert.True(t, strings.HasPrefix(expanded, "/api/v2/cards/42/art/full?"))
+
+	parsed, err := url.Parse(expanded)
+	require.NoError(t, err)
+	exp, sig := parsed.Query().Get("exp"), parsed.Query().Get("sig")
+
+	assert.NoError(t, Verify(exp, sig, now))
+	assert.ErrorIs(t, Verify(exp, sig, template.ExpiresAt.Add(time.Second)), ErrExpired)
+	assert.ErrorIs(t, Verify(exp, "00"+sig[2:], now), ErrInvalidSignature)
+
+	Configure("other-secret", "/api/v2")
+	assert.ErrorIs(t, Verify(exp, sig, now), ErrInvalidSignature)
+}
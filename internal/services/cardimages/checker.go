@@ -0,0 +1,253 @@
+// Package cardimages verifies that every active card has usable art, so games
+// never deal a card whose image is missing or broken.
+package cardimages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"dixitme/internal/cache"
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+	"dixitme/internal/storage"
+
+	"gorm.io/gorm"
+)
+
+// LocalCardsDir is where card images are served from when MinIO isn't used
+const LocalCardsDir = "./assets/cards"
+
+// Image sources
+const (
+	SourceMinIO = "minio"
+	SourceLocal = "local"
+)
+
+// ErrStorageUnavailable aborts a run that reaches a card stored in MinIO when
+// MinIO isn't configured, rather than declaring every such card broken
+var ErrStorageUnavailable = errors.New("image is stored in MinIO but MinIO is unavailable")
+
+// imageFault is a check failure that is the image's own fault: it is missing
+// or isn't a usable image. Any other failure says nothing about the image, so
+// only faults count as broken and may deactivate the card.
+type imageFault struct {
+	reason string
+}
+
+func (f *imageFault) Error() string {
+	return f.reason
+}
+
+// imageStore is the part of the MinIO client the checker uses
+type imageStore interface {
+	StatCardImage(ctx context.Context, cardID int, extension string) (*storage.CardImageInfo, error)
+	GetCardImage(cardID int, extension string) (io.ReadCloser, error)
+}
+
+// BrokenCard is an active card whose image failed the check
+type BrokenCard struct {
+	CardID   int    `json:"card_id"`
+	ImageURL string `json:"image_url"`
+	Source   string `json:"source"`
+	Reason   string `json:"reason"`
+}
+
+// Report summarizes an integrity check run. Unchecked cards hit a storage
+// error rather than a bad image; they are never deactivated.
+type Report struct {
+	StartedAt   time.Time    `json:"started_at"`
+	DurationMs  int64        `json:"duration_ms"`
+	Checked     int          `json:"checked"`
+	Healthy     int          `json:"healthy"`
+	Broken      []BrokenCard `json:"broken"`
+	Unchecked   []BrokenCard `json:"unchecked"`
+	Deactivated int          `json:"deactivated"`
+}
+
+// Checker verifies card images in MinIO or on local disk
+type Checker struct {
+	db       *gorm.DB
+	minio    imageStore
+	localDir string
+	stop     chan struct{}
+}
+
+// NewChecker creates a checker. minio may be nil when only local images are used.
+func NewChecker(db *gorm.DB, minio *storage.MinIOClient) *Checker {
+	checker := &Checker{
+		db:       db,
+		localDir: LocalCardsDir,
+		stop:     make(chan struct{}),
+	}
+	if minio != nil {
+		checker.minio = minio
+	}
+	return checker
+}
+
+// Start runs the check every interval until Stop is called
+func (c *Checker) Start(interval time.Duration, deactivate bool) {
+	logger.Info("Card image integrity job started", "interval", interval, "deactivate", deactivate)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := c.Run(context.Background(), deactivate); err != nil {
+					logger.Error("Card image integrity check failed", "error", err)
+				}
+			case <-c.stop:
+				logger.Info("Card image integrity job stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic job
+func (c *Checker) Stop() {
+	close(c.stop)
+}
+
+// Run checks every active card and, if deactivate is set, deactivates the
+// broken ones. It stops with ErrStorageUnavailable at the first card stored
+// in MinIO when there is no MinIO client.
+func (c *Checker) Run(ctx context.Context, deactivate bool) (*Report, error) {
+	report := &Report{StartedAt: time.Now(), Broken: make([]BrokenCard, 0), Unchecked: make([]BrokenCard, 0)}
+
+	var cards []models.Card
+	if err := c.db.WithContext(ctx).Where("is_active = ?", true).Order("id").Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("failed to load cards: %w", err)
+	}
+
+	for _, card := range cards {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		source, err := c.checkCard(ctx, card)
+		if errors.Is(err, ErrStorageUnavailable) {
+			return nil, err
+		}
+		report.Checked++
+		if err == nil {
+			report.Healthy++
+			continue
+		}
+
+		failed := BrokenCard{
+			CardID:   card.ID,
+			ImageURL: card.ImageURL,
+			Source:   source,
+			Reason:   err.Error(),
+		}
+		var fault *imageFault
+		if errors.As(err, &fault) {
+			report.Broken = append(report.Broken, failed)
+		} else {
+			report.Unchecked = append(report.Unchecked, failed)
+		}
+	}
+
+	if deactivate && len(report.Broken) > 0 {
+		ids := make([]int, 0, len(report.Broken))
+		for _, broken := range report.Broken {
+			ids = append(ids, broken.CardID)
+		}
+		res := c.db.WithContext(ctx).Model(&models.Card{}).Where("id IN ?", ids).Update("is_active", false)
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to deactivate broken cards: %w", res.Error)
+		}
+		report.Deactivated = int(res.RowsAffected)
+		cache.Invalidate(ctx, cache.ScopeCards)
+	}
+
+	report.DurationMs = time.Since(report.StartedAt).Milliseconds()
+
+	metrics.GetCounter("card_image_checks_total").Inc()
+	metrics.GetGauge("card_images_broken").Set(int64(len(report.Broken)))
+	metrics.GetGauge("card_images_unchecked").Set(int64(len(report.Unchecked)))
+	metrics.GetCounter("card_images_deactivated_total").Add(int64(report.Deactivated))
+
+	logger.Info("Card image integrity check completed",
+		"checked", report.Checked,
+		"broken", len(report.Broken),
+		"unchecked", len(report.Unchecked),
+		"deactivated", report.Deactivated,
+		"duration_ms", report.DurationMs)
+
+	return report, nil
+}
+
+// checkCard verifies a single card's image and reports where it looked. An
+// *imageFault means the image is broken; other errors mean it couldn't be checked.
+func (c *Checker) checkCard(ctx context.Context, card models.Card) (string, error) {
+	if card.ImageURL == "" {
+		return "", &imageFault{reason: "card has no image URL"}
+	}
+
+	// Seeded cards without MinIO point at the static /cards route
+	if strings.HasPrefix(card.ImageURL, "/cards/") {
+		return SourceLocal, c.checkLocal(filepath.Join(c.localDir, filepath.Base(card.ImageURL)))
+	}
+
+	if c.minio == nil {
+		return SourceMinIO, ErrStorageUnavailable
+	}
+
+	info, err := c.minio.StatCardImage(ctx, card.ID, card.Extension)
+	if errors.Is(err, storage.ErrImageNotFound) {
+		return SourceMinIO, &imageFault{reason: "image not found"}
+	}
+	if err != nil {
+		return SourceMinIO, err
+	}
+	return SourceMinIO, validateImage(info.Size, info.ContentType)
+}
+
+// checkLocal verifies an image file on disk, sniffing its content type
+func (c *Checker) checkLocal(path string) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &imageFault{reason: "image file not found"}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open image file: %w", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat image file: %w", err)
+	}
+
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return fmt.Errorf("failed to read image file: %w", err)
+	}
+
+	return validateImage(stat.Size(), http.DetectContentType(header[:n]))
+}
+
+// validateImage checks that an image object is non-empty and has an image content type
+func validateImage(size int64, contentType string) error {
+	if size <= 0 {
+		return &imageFault{reason: "image is empty"}
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return &imageFault{reason: fmt.Sprintf("invalid content type %q", contentType)}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package cardimages
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"dixitme/internal/models"
+	"dixitme/internal/storage"
+	"dixitme/internal/testutils/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateImage(t *testing.T) {
+	assert.NoError(t, validateImage(1024, "image/jpeg"))
+	assert.Error(t, validateImage(0, "image/jpeg"))
+	assert.Error(t, validateImage(1024, "text/html; charset=utf-8"))
+}
+
+func TestCheckLocal(t *testing.T) {
+	dir := t.TempDir()
+	checker := &Checker{localDir: dir}
+
+	// Minimal PNG signature is enough for content sniffing
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "1.png"), png, 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "2.jpg"), nil, 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "3.jpg"), []byte("<html></html>"), 0o644))
+
+	assert.NoError(t, checker.checkLocal(filepath.Join(dir, "1.png")))
+	assert.EqualError(t, checker.checkLocal(filepath.Join(dir, "2.jpg")), "image is empty")
+	assert.Error(t, checker.checkLocal(filepath.Join(dir, "3.jpg")))
+	assert.EqualError(t, checker.checkLocal(filepath.Join(dir, "4.jpg")), "image file not found")
+}
+
+// fakeStore answers stats from a table of card IDs; other cards are missing
+type fakeStore struct {
+	images map[int]*storage.CardImageInfo
+	err    error
+}
+
+func (s fakeStore) StatCardImage(_ context.Context, cardID int, _ string) (*storage.CardImageInfo, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if info, exists := s.images[cardID]; exists {
+		return info, nil
+	}
+	return nil, storage.ErrImageNotFound
+}
+
+func (s fakeStore) GetCardImage(int, string) (io.ReadCloser, error) {
+	return nil, errors.New("not implemented")
+}
+
+// activeCards returns the IDs of the cards still active after a run
+func activeCards(t *testing.T, checker *Checker) []int {
+	t.Helper()
+	var ids []int
+	require.NoError(t, checker.db.Model(&models.Card{}).Where("is_active = ?", true).Order("id").Pluck("id", &ids).Error)
+	return ids
+}
+
+func newStoredCardsChecker(t *testing.T, store imageStore) *Checker {
+	t.Helper()
+	db := testdb.Open(t, &models.Card{})
+	for id := 1; id <= 3; id++ {
+		require.NoError(t, db.Create(&models.Card{ID: id, ImageURL: "https://minio/cards/x.jpg", IsActive: true}).Error)
+	}
+	return &Checker{db: db, minio: store, localDir: t.TempDir()}
+}
+
+func TestRunDeactivatesOnlyBrokenImages(t *testing.T) {
+	checker := newStoredCardsChecker(t, fakeStore{images: map[int]*storage.CardImageInfo{
+		1: {Size: 2048, ContentType: "image/jpeg"},
+		2: {Size: 2048, ContentType: "text/html"},
+	}})
+
+	report, err := checker.Run(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, 1, report.Healthy)
+	require.Len(t, report.Broken, 2)
+	assert.Equal(t, "image not found", report.Broken[1].Reason)
+	assert.Empty(t, report.Unchecked)
+	assert.Equal(t, 2, report.Deactivated)
+	assert.Equal(t, []int{1}, activeCards(t, checker))
+}
+
+func TestRunNeverDeactivatesOnStorageErrors(t *testing.T) {
+	checker := newStoredCardsChecker(t, fakeStore{err: errors.New("failed to stat image: connection refused")})
+
+	report, err := checker.Run(context.Background(), true)
+	require.NoError(t, err)
+	assert.Empty(t, report.Broken)
+	assert.Len(t, report.Unchecked, 3)
+	assert.Zero(t, report.Deactivated)
+	assert.Equal(t, []int{1, 2, 3}, activeCards(t, checker))
+}
+
+func TestRunAbortsWithoutMinIO(t *testing.T) {
+	checker := newStoredCardsChecker(t, nil)
+
+	_, err := checker.Run(context.Background(), true)
+	assert.ErrorIs(t, err, ErrStorageUnavailable)
+	assert.Equal(t, []int{1, 2, 3}, activeCards(t, checker))
+}
@@ -0,0 +1,201 @@
+package cardimages
+
+import (
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register GIF decoding
+	_ "image/jpeg" // Register JPEG decoding
+	_ "image/png"  // Register PNG decoding
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Hamming distances between 64-bit perceptual hashes
+const (
+	DuplicateDistance = 5  // At or below: the same picture, re-encoded, resized or lightly edited
+	SimilarDistance   = 10 // At or below: close enough that a curator should take a look
+)
+
+// hashWidth x hashHeight is the thumbnail a difference hash compares; one extra
+// column gives 8 comparisons per row and 64 bits in total
+const (
+	hashWidth  = 9
+	hashHeight = 8
+)
+
+// SimilarCard is an active card whose image is close to another image
+type SimilarCard struct {
+	CardID     int     `json:"card_id"`
+	Title      string  `json:"title"`
+	ImageURL   string  `json:"image_url"`
+	Distance   int     `json:"distance"`   // Hamming distance between the hashes (0-64)
+	Similarity float64 `json:"similarity"` // 1 - distance/64
+	Duplicate  bool    `json:"duplicate"`  // Within DuplicateDistance
+}
+
+// PerceptualHash computes a 64-bit difference hash of an image. Re-encoding,
+// resizing and small edits barely change it, unlike a checksum.
+func PerceptualHash(r io.Reader) (uint64, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() < 1 || bounds.Dy() < 1 {
+		return 0, fmt.Errorf("image is empty")
+	}
+
+	// Average each cell of a 9x8 grid down to one grey value
+	var grid [hashHeight][hashWidth]float64
+	for gy := 0; gy < hashHeight; gy++ {
+		y0 := bounds.Min.Y + gy*bounds.Dy()/hashHeight
+		y1 := max(y0+1, bounds.Min.Y+(gy+1)*bounds.Dy()/hashHeight)
+		for gx := 0; gx < hashWidth; gx++ {
+			x0 := bounds.Min.X + gx*bounds.Dx()/hashWidth
+			x1 := max(x0+1, bounds.Min.X+(gx+1)*bounds.Dx()/hashWidth)
+
+			var sum float64
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					r, g, b, _ := img.At(x, y).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+				}
+			}
+			grid[gy][gx] = sum / float64((y1-y0)*(x1-x0))
+		}
+	}
+
+	// One bit per neighbouring pair: is the left cell brighter?
+	var hash uint64
+	for y := 0; y < hashHeight; y++ {
+		for x := 0; x < hashWidth-1; x++ {
+			hash <<= 1
+			if grid[y][x] > grid[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash, nil
+}
+
+// FormatHash encodes a hash the way it is stored on the card
+func FormatHash(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+// ParseHash decodes a stored hash
+func ParseHash(s string) (uint64, error) {
+	return strconv.ParseUint(s, 16, 64)
+}
+
+// HashDistance is the number of differing bits between two hashes
+func HashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// FindSimilar returns active cards whose image hash is within maxDistance of
+// hash, closest first. excludeCardID skips the card being compared (0 for none).
+func FindSimilar(ctx context.Context, db *gorm.DB, hash uint64, excludeCardID, maxDistance int) ([]SimilarCard, error) {
+	var cards []models.Card
+	if err := db.WithContext(ctx).
+		Where("is_active = ? AND image_hash <> '' AND id <> ?", true, excludeCardID).
+		Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("failed to load card hashes: %w", err)
+	}
+
+	similar := make([]SimilarCard, 0)
+	for _, card := range cards {
+		cardHash, err := ParseHash(card.ImageHash)
+		if err != nil {
+			continue
+		}
+		distance := HashDistance(hash, cardHash)
+		if distance > maxDistance {
+			continue
+		}
+		similar = append(similar, SimilarCard{
+			CardID:     card.ID,
+			Title:      card.Title,
+			ImageURL:   card.ImageURL,
+			Distance:   distance,
+			Similarity: 1 - float64(distance)/64,
+			Duplicate:  distance <= DuplicateDistance,
+		})
+	}
+
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Distance != similar[j].Distance {
+			return similar[i].Distance < similar[j].Distance
+		}
+		return similar[i].CardID < similar[j].CardID
+	})
+	return similar, nil
+}
+
+// BackfillHashes hashes the images of active cards that don't have a hash yet,
+// so uploads are also compared against cards added before hashing existed
+func (c *Checker) BackfillHashes(ctx context.Context) (int, error) {
+	var cards []models.Card
+	if err := c.db.WithContext(ctx).Where("is_active = ? AND (image_hash IS NULL OR image_hash = '')", true).Find(&cards).Error; err != nil {
+		return 0, fmt.Errorf("failed to load cards: %w", err)
+	}
+
+	hashed := 0
+	for _, card := range cards {
+		if err := ctx.Err(); err != nil {
+			return hashed, err
+		}
+
+		hash, err := c.hashCard(card)
+		if err != nil {
+			logger.Warn("Failed to hash card image", "card_id", card.ID, "error", err)
+			continue
+		}
+		if err := c.db.WithContext(ctx).Model(&models.Card{}).Where("id = ?", card.ID).Update("image_hash", FormatHash(hash)).Error; err != nil {
+			return hashed, fmt.Errorf("failed to store hash for card %d: %w", card.ID, err)
+		}
+		hashed++
+	}
+
+	logger.Info("Card image hashes backfilled", "hashed", hashed, "missing", len(cards))
+	return hashed, nil
+}
+
+// hashCard hashes a card's image from local disk or MinIO
+func (c *Checker) hashCard(card models.Card) (uint64, error) {
+	reader, err := c.OpenImage(card)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	return PerceptualHash(reader)
+}
+
+// OpenImage opens a card's image from local disk or MinIO. Callers close it.
+func (c *Checker) OpenImage(card models.Card) (io.ReadCloser, error) {
+	switch {
+	case strings.HasPrefix(card.ImageURL, "/cards/"):
+		file, err := os.Open(filepath.Join(c.localDir, filepath.Base(card.ImageURL)))
+		if err != nil {
+			return nil, fmt.Errorf("image file not found")
+		}
+		return file, nil
+	case c.minio != nil:
+		return c.minio.GetCardImage(card.ID, card.Extension)
+	default:
+		return nil, ErrStorageUnavailable
+	}
+}
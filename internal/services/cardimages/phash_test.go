@@ -0,0 +1,74 @@
+package cardimages
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gradient draws a test picture; flip mirrors it horizontally
+func gradient(width, height int, flip bool) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := uint8((x*255/width + y*96/height) % 256)
+			if (x/(width/4)+y/(height/3))%2 == 0 {
+				v /= 2
+			}
+			if flip {
+				img.Set(width-1-x, y, color.RGBA{v, v / 2, 255 - v, 255})
+			} else {
+				img.Set(x, y, color.RGBA{v, v / 2, 255 - v, 255})
+			}
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) *bytes.Buffer {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return &buf
+}
+
+func TestPerceptualHashSurvivesReencodingAndResizing(t *testing.T) {
+	original, err := PerceptualHash(encodePNG(t, gradient(360, 540, false)))
+	require.NoError(t, err)
+
+	var jpg bytes.Buffer
+	require.NoError(t, jpeg.Encode(&jpg, gradient(180, 270, false), &jpeg.Options{Quality: 60}))
+	resized, err := PerceptualHash(&jpg)
+	require.NoError(t, err)
+
+	assert.LessOrEqual(t, HashDistance(original, resized), DuplicateDistance)
+}
+
+func TestPerceptualHashTellsDifferentImagesApart(t *testing.T) {
+	original, err := PerceptualHash(encodePNG(t, gradient(360, 540, false)))
+	require.NoError(t, err)
+	mirrored, err := PerceptualHash(encodePNG(t, gradient(360, 540, true)))
+	require.NoError(t, err)
+
+	assert.Greater(t, HashDistance(original, mirrored), SimilarDistance)
+}
+
+func TestPerceptualHashRejectsNonImages(t *testing.T) {
+	_, err := PerceptualHash(bytes.NewBufferString("<html></html>"))
+	assert.Error(t, err)
+}
+
+func TestHashRoundTrip(t *testing.T) {
+	hash := uint64(0x00ff00ff12345678)
+	parsed, err := ParseHash(FormatHash(hash))
+	require.NoError(t, err)
+	assert.Equal(t, hash, parsed)
+	assert.Equal(t, "00ff00ff12345678", FormatHash(hash))
+	assert.Equal(t, 0, HashDistance(hash, parsed))
+	assert.Equal(t, 64, HashDistance(0, ^uint64(0)))
+}
@@ -0,0 +1,86 @@
+package clues
+
+// Phrase kinds offered as inspiration
+const (
+	KindWord    = "word"
+	KindIdiom   = "idiom"
+	KindProverb = "proverb"
+)
+
+// phrases groups inspiration by kind
+type phrases struct {
+	Words    []string
+	Idioms   []string
+	Proverbs []string
+}
+
+// tagPhrases holds inspiration for individual tags, keyed by tag slug
+var tagPhrases = map[string]phrases{
+	"happy":    {Words: []string{"Sunshine", "Glow", "Bliss"}, Idioms: []string{"On cloud nine", "Over the moon"}},
+	"sad":      {Words: []string{"Rain", "Echo", "Farewell"}, Idioms: []string{"Down in the dumps", "A heavy heart"}},
+	"angry":    {Words: []string{"Ember", "Thunder"}, Idioms: []string{"Seeing red", "Blow off steam"}},
+	"fear":     {Words: []string{"Shadow", "Shiver"}, Idioms: []string{"Afraid of your own shadow", "Cold feet"}},
+	"love":     {Words: []string{"Devotion", "Longing"}, Proverbs: []string{"Love is blind", "Absence makes the heart grow fonder"}},
+	"surprise": {Words: []string{"Gasp", "Unveiled"}, Idioms: []string{"Out of the blue", "Bolt from the blue"}},
+	"peaceful": {Words: []string{"Stillness", "Harbor"}, Idioms: []string{"Calm before the storm"}},
+	"forest":   {Words: []string{"Canopy", "Roots"}, Idioms: []string{"Can't see the forest for the trees", "Out of the woods"}},
+	"ocean":    {Words: []string{"Depths", "Tide"}, Idioms: []string{"A drop in the ocean", "Still waters run deep"}},
+	"mountain": {Words: []string{"Summit", "Ascent"}, Idioms: []string{"Make a mountain out of a molehill"}, Proverbs: []string{"Faith can move mountains"}},
+	"sky":      {Words: []string{"Horizon", "Infinity"}, Idioms: []string{"The sky's the limit", "Pie in the sky"}},
+	"desert":   {Words: []string{"Mirage", "Thirst"}, Idioms: []string{"An oasis in the desert"}},
+	"garden":   {Words: []string{"Bloom", "Seedling"}, Idioms: []string{"Lead down the garden path"}},
+	"storm":    {Words: []string{"Tempest", "Upheaval"}, Idioms: []string{"A storm in a teacup", "Weather the storm"}},
+	"magic":    {Words: []string{"Spell", "Illusion"}, Idioms: []string{"Like magic", "Smoke and mirrors"}},
+	"dragon":   {Words: []string{"Hoard", "Guardian"}, Idioms: []string{"Chasing the dragon"}},
+	"fairy":    {Words: []string{"Glimmer", "Wish"}, Idioms: []string{"A fairy-tale ending"}},
+	"wizard":   {Words: []string{"Wisdom", "Apprentice"}, Idioms: []string{"Pull a rabbit out of a hat"}},
+	"castle":   {Words: []string{"Fortress", "Kingdom"}, Idioms: []string{"Castles in the air"}, Proverbs: []string{"A man's home is his castle"}},
+	"treasure": {Words: []string{"Riches", "Buried"}, Proverbs: []string{"One man's trash is another man's treasure"}},
+	"quest":    {Words: []string{"Journey", "Calling"}, Proverbs: []string{"Not all those who wander are lost"}},
+	"bird":     {Words: []string{"Flight", "Nest"}, Idioms: []string{"Free as a bird", "A little bird told me"}, Proverbs: []string{"The early bird catches the worm"}},
+	"cat":      {Words: []string{"Curiosity", "Whiskers"}, Idioms: []string{"Let the cat out of the bag"}, Proverbs: []string{"Curiosity killed the cat"}},
+	"dog":      {Words: []string{"Loyalty", "Companion"}, Idioms: []string{"Let sleeping dogs lie"}, Proverbs: []string{"Every dog has its day"}},
+	"fish":     {Words: []string{"Current", "Scales"}, Idioms: []string{"A fish out of water", "Plenty of fish in the sea"}},
+	"horse":    {Words: []string{"Gallop", "Reins"}, Idioms: []string{"Hold your horses", "Straight from the horse's mouth"}},
+	"dance":    {Words: []string{"Rhythm", "Twirl"}, Idioms: []string{"It takes two to tango"}},
+	"music":    {Words: []string{"Harmony", "Melody"}, Idioms: []string{"Music to my ears", "Face the music"}},
+	"art":      {Words: []string{"Canvas", "Masterpiece"}, Proverbs: []string{"Beauty is in the eye of the beholder"}},
+	"reading":  {Words: []string{"Chapter", "Between the lines"}, Idioms: []string{"An open book"}},
+	"key":      {Words: []string{"Secret", "Threshold"}, Idioms: []string{"Under lock and key", "The key to success"}},
+	"mirror":   {Words: []string{"Reflection", "Twin"}, Idioms: []string{"Smoke and mirrors"}},
+	"clock":    {Words: []string{"Countdown", "Midnight"}, Idioms: []string{"Against the clock", "Turn back the clock"}, Proverbs: []string{"Time waits for no one"}},
+	"book":     {Words: []string{"Story", "Wisdom"}, Proverbs: []string{"Don't judge a book by its cover"}},
+	"candle":   {Words: []string{"Flicker", "Vigil"}, Idioms: []string{"Burn the candle at both ends"}},
+	"crown":    {Words: []string{"Reign", "Heir"}, Proverbs: []string{"Heavy is the head that wears the crown"}},
+	"dream":    {Words: []string{"Reverie", "Slumber"}, Idioms: []string{"A pipe dream", "Beyond my wildest dreams"}},
+	"memory":   {Words: []string{"Nostalgia", "Trace"}, Idioms: []string{"A trip down memory lane"}},
+	"hope":     {Words: []string{"Dawn", "Promise"}, Idioms: []string{"A glimmer of hope"}, Proverbs: []string{"Hope springs eternal"}},
+	"freedom":  {Words: []string{"Escape", "Wings"}, Idioms: []string{"Break free", "Spread your wings"}},
+	"mystery":  {Words: []string{"Riddle", "Veil"}, Idioms: []string{"A riddle wrapped in an enigma"}},
+	"balance":  {Words: []string{"Equilibrium", "Tightrope"}, Idioms: []string{"Hang in the balance"}},
+	"night":    {Words: []string{"Starlight", "Dusk"}, Idioms: []string{"A night owl"}, Proverbs: []string{"The darkest hour is just before the dawn"}},
+	"day":      {Words: []string{"Daybreak", "Noon"}, Idioms: []string{"Seize the day", "Call it a day"}},
+	"winter":   {Words: []string{"Frost", "Hibernation"}, Idioms: []string{"Break the ice"}},
+	"spring":   {Words: []string{"Renewal", "Blossom"}, Idioms: []string{"Spring in your step"}},
+	"summer":   {Words: []string{"Heatwave", "Abundance"}, Proverbs: []string{"One swallow does not make a summer"}},
+	"autumn":   {Words: []string{"Harvest", "Falling leaves"}, Idioms: []string{"The autumn of life"}},
+}
+
+// categoryPhrases is the fallback inspiration for tags without their own entry
+var categoryPhrases = map[string]phrases{
+	"emotion":  {Words: []string{"Feeling", "Mood", "Heart"}, Idioms: []string{"Wear your heart on your sleeve"}},
+	"nature":   {Words: []string{"Wild", "Organic", "Elements"}, Proverbs: []string{"Nature does nothing uselessly"}},
+	"fantasy":  {Words: []string{"Legend", "Enchanted", "Once upon a time"}, Idioms: []string{"Too good to be true"}},
+	"animal":   {Words: []string{"Instinct", "Wild heart"}, Idioms: []string{"The elephant in the room"}},
+	"activity": {Words: []string{"Motion", "Practice"}, Proverbs: []string{"Practice makes perfect"}},
+	"object":   {Words: []string{"Keepsake", "Relic"}, Idioms: []string{"More than meets the eye"}},
+	"abstract": {Words: []string{"Essence", "Paradox"}, Proverbs: []string{"Still waters run deep"}},
+	"time":     {Words: []string{"Moment", "Era", "Forever"}, Proverbs: []string{"Time heals all wounds"}},
+}
+
+// generalPhrases are offered when a card has no tags to draw from
+var generalPhrases = phrases{
+	Words:    []string{"Between worlds", "Hidden truth", "Distant echo", "Forgotten dream"},
+	Idioms:   []string{"Once in a blue moon", "Behind closed doors"},
+	Proverbs: []string{"Every cloud has a silver lining", "Actions speak louder than words"},
+}
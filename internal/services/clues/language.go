@@ -0,0 +1,165 @@
+package clues
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Language is a clue language the detector can recognise
+type Language struct {
+	Code string `json:"code"` // ISO 639-1
+	Name string `json:"name"`
+}
+
+// Languages lists the languages rooms may declare
+var Languages = []Language{
+	{Code: "en", Name: "English"},
+	{Code: "fr", Name: "French"},
+	{Code: "es", Name: "Spanish"},
+	{Code: "de", Name: "German"},
+	{Code: "it", Name: "Italian"},
+	{Code: "pt", Name: "Portuguese"},
+	{Code: "vi", Name: "Vietnamese"},
+	{Code: "ru", Name: "Russian"},
+	{Code: "el", Name: "Greek"},
+	{Code: "ar", Name: "Arabic"},
+	{Code: "he", Name: "Hebrew"},
+	{Code: "th", Name: "Thai"},
+	{Code: "zh", Name: "Chinese"},
+	{Code: "ja", Name: "Japanese"},
+	{Code: "ko", Name: "Korean"},
+}
+
+// IsSupportedLanguage reports whether code is one of Languages
+func IsSupportedLanguage(code string) bool {
+	for _, language := range Languages {
+		if language.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Latin-script languages are told apart by common short words and distinctive letters
+var (
+	latinStopwords = map[string][]string{
+		"en": {"the", "a", "an", "of", "and", "in", "to", "is", "on", "my", "your", "with", "for", "it", "at", "from", "when", "what", "who", "no", "not", "all", "out", "up", "time", "love", "dream", "night"},
+		"fr": {"le", "la", "les", "un", "une", "des", "de", "du", "et", "est", "dans", "pour", "sur", "avec", "qui", "que", "pas", "mon", "ma", "mes", "au", "aux", "nuit", "rêve", "amour"},
+		"es": {"el", "la", "los", "las", "un", "una", "de", "del", "y", "es", "en", "por", "para", "con", "que", "mi", "su", "al", "no", "sueño", "noche", "amor", "muy"},
+		"de": {"der", "die", "das", "ein", "eine", "und", "ist", "im", "in", "mit", "für", "von", "zu", "auf", "nicht", "mein", "dein", "den", "dem", "traum", "nacht", "liebe"},
+		"it": {"il", "lo", "la", "gli", "le", "un", "una", "di", "del", "della", "e", "è", "in", "con", "per", "che", "non", "mio", "sogno", "notte", "amore"},
+		"pt": {"o", "a", "os", "as", "um", "uma", "de", "do", "da", "e", "é", "em", "no", "na", "com", "para", "que", "não", "meu", "sonho", "noite", "amor"},
+		"vi": {"và", "của", "là", "có", "không", "một", "những", "trong", "cho", "người", "với", "đêm", "giấc", "mơ", "tình", "yêu"},
+	}
+	latinLetters = map[string]string{
+		"fr": "çœèêëîïûù",
+		"es": "ñ¿¡",
+		"de": "ßäöü",
+		"it": "ìò",
+		"pt": "ãõ",
+		"vi": "ăđơưạảấầẩẫậắằẳẵặẹẻẽếềểễệỉịọỏốồổỗộớờởỡợụủứừửữựỳỵỷỹ",
+	}
+)
+
+// DetectLanguage guesses the language of a clue. It returns the ISO 639-1 code
+// and true, or "" and false when the clue is too short or ambiguous to call.
+func DetectLanguage(text string) (string, bool) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return "", false
+	}
+
+	if code, ok := detectScript(text); ok {
+		return code, true
+	}
+
+	return detectLatin(text)
+}
+
+// detectScript recognises languages written in their own script
+func detectScript(text string) (string, bool) {
+	counts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			counts["ja"]++
+		case unicode.Is(unicode.Han, r):
+			counts["zh"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		}
+	}
+
+	// Kana anywhere means Japanese, even when most characters are kanji
+	if counts["ja"] > 0 {
+		counts["ja"] += counts["zh"]
+		delete(counts, "zh")
+	}
+
+	best, bestCount := "", 0
+	for code, count := range counts {
+		if count > bestCount {
+			best, bestCount = code, count
+		}
+	}
+	if letters == 0 || bestCount*2 <= letters {
+		return "", false
+	}
+	return best, true
+}
+
+// detectLatin scores Latin-script languages by stopwords and distinctive letters
+func detectLatin(text string) (string, bool) {
+	scores := make(map[string]int)
+
+	for code, letters := range latinLetters {
+		for _, r := range text {
+			if strings.ContainsRune(letters, r) {
+				scores[code] += 2
+			}
+		}
+	}
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, word := range words {
+		for code, stopwords := range latinStopwords {
+			for _, stopword := range stopwords {
+				if word == stopword {
+					scores[code]++
+					break
+				}
+			}
+		}
+	}
+
+	best, bestScore, tied := "", 0, false
+	for code, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, tied = code, score, false
+		case score == bestScore:
+			tied = true
+		}
+	}
+	if bestScore == 0 || tied {
+		return "", false
+	}
+	return best, true
+}
@@ -0,0 +1,38 @@
+package clues
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	cases := map[string]string{
+		"The end of the night":         "en",
+		"Le rêve de la nuit":           "fr",
+		"El sueño de una noche":        "es",
+		"Der Traum in der Nacht":       "de",
+		"Giấc mơ của một đêm":          "vi",
+		"Сон в летнюю ночь":            "ru",
+		"夢の中で":                         "ja",
+		"月亮代表我的心":                      "zh",
+		"꿈속에서":                         "ko",
+		"Η νύχτα":                      "el",
+		"O sonho não acabou":           "pt",
+		"Il sogno della notte è bello": "it",
+	}
+
+	for clue, expected := range cases {
+		code, ok := DetectLanguage(clue)
+		assert.True(t, ok, clue)
+		assert.Equal(t, expected, code, clue)
+	}
+}
+
+func TestDetectLanguageUndetermined(t *testing.T) {
+	for _, clue := range []string{"", "   ", "Serendipity", "42", "🔥"} {
+		code, ok := DetectLanguage(clue)
+		assert.False(t, ok, clue)
+		assert.Empty(t, code, clue)
+	}
+}
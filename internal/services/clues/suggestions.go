@@ -0,0 +1,111 @@
+// Package clues provides clue inspiration for storytellers: abstract words,
+// idioms and proverbs derived from a card's tags and a curated phrase bank.
+package clues
+
+import (
+	"math/rand"
+	"sort"
+
+	"dixitme/internal/models"
+)
+
+// Suggestion is a single piece of clue inspiration
+type Suggestion struct {
+	Text   string `json:"text"`
+	Kind   string `json:"kind"`             // word, idiom, proverb
+	Source string `json:"source,omitempty"` // Tag (or category) the suggestion was drawn from
+}
+
+// WeightedTag is a tag that applies to a card with a relevance weight
+type WeightedTag struct {
+	Tag    models.Tag
+	Weight float64
+}
+
+// Suggest returns up to limit suggestions for a card with the given tags.
+// Stronger tags contribute first; results mix words, idioms and proverbs.
+func Suggest(tags []WeightedTag, limit int, rng *rand.Rand) []Suggestion {
+	if limit <= 0 {
+		return []Suggestion{}
+	}
+
+	sorted := make([]WeightedTag, len(tags))
+	copy(sorted, tags)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Weight > sorted[j].Weight
+	})
+
+	var candidates []Suggestion
+	seen := make(map[string]bool)
+	add := func(source string, p phrases) {
+		for _, group := range []struct {
+			kind  string
+			texts []string
+		}{
+			{KindWord, p.Words},
+			{KindIdiom, p.Idioms},
+			{KindProverb, p.Proverbs},
+		} {
+			for _, text := range group.texts {
+				if seen[text] {
+					continue
+				}
+				seen[text] = true
+				candidates = append(candidates, Suggestion{Text: text, Kind: group.kind, Source: source})
+			}
+		}
+	}
+
+	categories := make(map[string]bool)
+	for _, wt := range sorted {
+		if p, exists := tagPhrases[wt.Tag.Slug]; exists {
+			add(wt.Tag.Name, p)
+		}
+		if wt.Tag.Category != "" && !categories[wt.Tag.Category] {
+			categories[wt.Tag.Category] = true
+			if p, exists := categoryPhrases[wt.Tag.Category]; exists {
+				add(wt.Tag.Category, p)
+			}
+		}
+	}
+	if len(candidates) < limit {
+		add("", generalPhrases)
+	}
+
+	return pick(candidates, limit, rng)
+}
+
+// pick chooses limit suggestions, rotating between kinds so the result stays varied
+// while earlier (more relevant) candidates are preferred within each kind
+func pick(candidates []Suggestion, limit int, rng *rand.Rand) []Suggestion {
+	byKind := map[string][]Suggestion{}
+	for _, candidate := range candidates {
+		byKind[candidate.Kind] = append(byKind[candidate.Kind], candidate)
+	}
+
+	// Light shuffle within the top of each kind so repeated requests differ
+	for _, group := range byKind {
+		window := min(len(group), 4)
+		rng.Shuffle(window, func(i, j int) { group[i], group[j] = group[j], group[i] })
+	}
+
+	result := make([]Suggestion, 0, limit)
+	for len(result) < limit {
+		added := false
+		for _, kind := range []string{KindWord, KindIdiom, KindProverb} {
+			if len(result) == limit {
+				break
+			}
+			if group := byKind[kind]; len(group) > 0 {
+				result = append(result, group[0])
+				byKind[kind] = group[1:]
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,289 @@
+// Package customdeck stores decks users assemble from existing cards, so a
+// group of friends can play a room with their own curated card set.
+package customdeck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxPerUser caps how many decks one user can save
+	MaxPerUser = 20
+
+	// MinCards is enough to deal full hands to the smallest table
+	MinCards = 18
+	// MaxCards caps the size of one deck
+	MaxCards = 500
+
+	maxNameLength        = 50
+	maxDescriptionLength = 500
+)
+
+var (
+	ErrNotFound  = errors.New("deck not found")
+	ErrNameTaken = errors.New("a deck with this name already exists")
+	ErrTooMany   = fmt.Errorf("at most %d decks can be saved", MaxPerUser)
+)
+
+// Spec is what a user saves in a deck
+type Spec struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CardIDs     []int  `json:"card_ids"`
+}
+
+// Deck is a saved deck with the IDs of its cards
+type Deck struct {
+	models.CustomDeck
+	CardIDs []int `json:"card_ids"` // Ascending
+}
+
+// validate checks a spec, trimming its text and sorting and deduplicating its cards
+func (s Spec) validate() (Spec, error) {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" || len(s.Name) > maxNameLength {
+		return s, fmt.Errorf("deck name must be between 1 and %d characters", maxNameLength)
+	}
+	s.Description = strings.TrimSpace(s.Description)
+	if len(s.Description) > maxDescriptionLength {
+		return s, fmt.Errorf("deck description must be at most %d characters", maxDescriptionLength)
+	}
+
+	seen := make(map[int]bool, len(s.CardIDs))
+	cards := make([]int, 0, len(s.CardIDs))
+	for _, cardID := range s.CardIDs {
+		if cardID <= 0 {
+			return s, fmt.Errorf("invalid card ID: %d", cardID)
+		}
+		if !seen[cardID] {
+			seen[cardID] = true
+			cards = append(cards, cardID)
+		}
+	}
+	if len(cards) < MinCards || len(cards) > MaxCards {
+		return s, fmt.Errorf("a deck must have between %d and %d different cards", MinCards, MaxCards)
+	}
+	sort.Ints(cards)
+	s.CardIDs = cards
+	return s, nil
+}
+
+// Create saves a new deck for a user
+func Create(ctx context.Context, db *gorm.DB, userID uuid.UUID, spec Spec) (*Deck, error) {
+	spec, err := spec.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := db.WithContext(ctx).Model(&models.CustomDeck{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to count decks: %w", err)
+	}
+	if count >= MaxPerUser {
+		return nil, ErrTooMany
+	}
+	if err := checkNameFree(ctx, db, userID, spec.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+	cards, err := activeCards(ctx, db, spec.CardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	row := models.CustomDeck{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Name:        spec.Name,
+		Description: spec.Description,
+		Cards:       cards,
+	}
+	if err := db.WithContext(ctx).Omit("Cards.*").Create(&row).Error; err != nil {
+		return nil, fmt.Errorf("failed to save deck: %w", err)
+	}
+	return &Deck{CustomDeck: row, CardIDs: spec.CardIDs}, nil
+}
+
+// List returns a user's decks in name order
+func List(ctx context.Context, db *gorm.DB, userID uuid.UUID) ([]Deck, error) {
+	var rows []models.CustomDeck
+	if err := db.WithContext(ctx).Where("user_id = ?", userID).Order("name").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list decks: %w", err)
+	}
+
+	deckIDs := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		deckIDs[i] = row.ID
+	}
+	cards, err := deckCards(ctx, db, deckIDs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	decks := make([]Deck, len(rows))
+	for i, row := range rows {
+		decks[i] = Deck{CustomDeck: row, CardIDs: cards[row.ID]}
+	}
+	return decks, nil
+}
+
+// Get loads one of a user's decks. Other users' decks are not found.
+func Get(ctx context.Context, db *gorm.DB, userID, deckID uuid.UUID) (*Deck, error) {
+	row, err := load(ctx, db, userID, deckID)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := deckCards(ctx, db, []uuid.UUID{deckID}, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Deck{CustomDeck: *row, CardIDs: cards[deckID]}, nil
+}
+
+// Update replaces the name, description and cards of one of a user's decks.
+// Rooms already playing with the deck keep the cards they were created with.
+func Update(ctx context.Context, db *gorm.DB, userID, deckID uuid.UUID, spec Spec) (*Deck, error) {
+	spec, err := spec.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := load(ctx, db, userID, deckID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNameFree(ctx, db, userID, spec.Name, deckID); err != nil {
+		return nil, err
+	}
+	cards, err := activeCards(ctx, db, spec.CardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	row.Name = spec.Name
+	row.Description = spec.Description
+	row.UpdatedAt = time.Now()
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(row).Error; err != nil {
+			return err
+		}
+		return tx.Model(row).Omit("Cards.*").Association("Cards").Replace(cards)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update deck: %w", err)
+	}
+	return &Deck{CustomDeck: *row, CardIDs: spec.CardIDs}, nil
+}
+
+// Delete removes one of a user's decks. Rooms already playing with it are not affected.
+func Delete(ctx context.Context, db *gorm.DB, userID, deckID uuid.UUID) error {
+	row, err := load(ctx, db, userID, deckID)
+	if err != nil {
+		return err
+	}
+	if err := db.WithContext(ctx).Select("Cards").Delete(row).Error; err != nil {
+		return fmt.Errorf("failed to delete deck: %w", err)
+	}
+	return nil
+}
+
+// Cards lists the active cards of any user's deck, in ascending order. Decks
+// are shared by ID, so whoever holds one can create a room with it.
+func Cards(ctx context.Context, db *gorm.DB, deckID uuid.UUID) ([]int, error) {
+	var count int64
+	if err := db.WithContext(ctx).Model(&models.CustomDeck{}).Where("id = ?", deckID).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to load deck: %w", err)
+	}
+	if count == 0 {
+		return nil, ErrNotFound
+	}
+	cards, err := deckCards(ctx, db, []uuid.UUID{deckID}, true)
+	if err != nil {
+		return nil, err
+	}
+	return cards[deckID], nil
+}
+
+func load(ctx context.Context, db *gorm.DB, userID, deckID uuid.UUID) (*models.CustomDeck, error) {
+	var row models.CustomDeck
+	if err := db.WithContext(ctx).First(&row, "id = ? AND user_id = ?", deckID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to load deck: %w", err)
+	}
+	return &row, nil
+}
+
+// checkNameFree makes sure none of the user's other decks has the name
+func checkNameFree(ctx context.Context, db *gorm.DB, userID uuid.UUID, name string, except uuid.UUID) error {
+	var count int64
+	if err := db.WithContext(ctx).Model(&models.CustomDeck{}).
+		Where("user_id = ? AND name = ? AND id <> ?", userID, name, except).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check deck name: %w", err)
+	}
+	if count > 0 {
+		return ErrNameTaken
+	}
+	return nil
+}
+
+// activeCards loads the cards of a spec, which must all exist and be active
+func activeCards(ctx context.Context, db *gorm.DB, cardIDs []int) ([]models.Card, error) {
+	var cards []models.Card
+	if err := db.WithContext(ctx).Where("id IN ? AND is_active = ?", cardIDs, true).Order("id").Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("failed to load cards: %w", err)
+	}
+	if len(cards) == len(cardIDs) {
+		return cards, nil
+	}
+
+	found := make(map[int]bool, len(cards))
+	for _, card := range cards {
+		found[card.ID] = true
+	}
+	for _, cardID := range cardIDs {
+		if !found[cardID] {
+			return nil, fmt.Errorf("unknown or inactive card: %d", cardID)
+		}
+	}
+	return cards, nil
+}
+
+// deckCards lists the card IDs of each deck in ascending order, optionally
+// only the cards that are still active
+func deckCards(ctx context.Context, db *gorm.DB, deckIDs []uuid.UUID, activeOnly bool) (map[uuid.UUID][]int, error) {
+	cards := make(map[uuid.UUID][]int, len(deckIDs))
+	if len(deckIDs) == 0 {
+		return cards, nil
+	}
+
+	var rows []struct {
+		CustomDeckID uuid.UUID
+		CardID       int
+	}
+	query := db.WithContext(ctx).Table("custom_deck_cards").
+		Select("custom_deck_cards.custom_deck_id, custom_deck_cards.card_id").
+		Where("custom_deck_cards.custom_deck_id IN ?", deckIDs)
+	if activeOnly {
+		query = query.Joins("JOIN cards ON cards.id = custom_deck_cards.card_id").
+			Where("cards.is_active = ?", true)
+	}
+	if err := query.Order("custom_deck_cards.card_id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load deck cards: %w", err)
+	}
+	for _, row := range rows {
+		cards[row.CustomDeckID] = append(cards[row.CustomDeckID], row.CardID)
+	}
+	return cards, nil
+}
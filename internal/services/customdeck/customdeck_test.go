@@ -0,0 +1,39 @@
+package customdeck
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cardRange(from, to int) []int {
+	cards := make([]int, 0, to-from+1)
+	for cardID := to; cardID >= from; cardID-- {
+		cards = append(cards, cardID)
+	}
+	return cards
+}
+
+func TestSpecValidate(t *testing.T) {
+	cards := append(cardRange(1, MinCards), 3, 5)
+
+	spec, err := Spec{Name: "  Movie night  ", Description: " Film stills ", CardIDs: cards}.validate()
+	require.NoError(t, err)
+	assert.Equal(t, "Movie night", spec.Name)
+	assert.Equal(t, "Film stills", spec.Description)
+	assert.Equal(t, cardRange(1, MinCards)[0], spec.CardIDs[len(spec.CardIDs)-1], "cards are sorted")
+	assert.Len(t, spec.CardIDs, MinCards, "duplicates are dropped")
+
+	_, err = Spec{Name: "", CardIDs: cards}.validate()
+	assert.Error(t, err)
+	_, err = Spec{Name: "Long", Description: strings.Repeat("a", maxDescriptionLength+1), CardIDs: cards}.validate()
+	assert.Error(t, err)
+	_, err = Spec{Name: "Small", CardIDs: cardRange(1, MinCards-1)}.validate()
+	assert.Error(t, err)
+	_, err = Spec{Name: "Big", CardIDs: cardRange(1, MaxCards+1)}.validate()
+	assert.Error(t, err)
+	_, err = Spec{Name: "Invalid", CardIDs: append(cardRange(1, MinCards), 0)}.validate()
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+// Package deckimport converts card decks made for other Dixit-like platforms
+// into DixitMe cards, tagging every imported card with a placeholder tag per
+// deck so curators can find and retag them later.
+package deckimport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxImageBytes bounds a single downloaded image; TTS sprite sheets holding
+// 70 cards are the largest inputs
+const maxImageBytes = 64 << 20
+
+// Deck is a parsed deck, ready to be imported
+type Deck struct {
+	Name   string
+	Source string // Where the deck was read from, for the placeholder tag's description
+	Cards  []Card
+}
+
+// Card is a card of a parsed deck. Its image is only read when it is imported.
+type Card struct {
+	Title       string
+	Description string
+	Extension   string
+	load        func(ctx context.Context) ([]byte, error)
+}
+
+// Image reads the card's image
+func (c Card) Image(ctx context.Context) ([]byte, error) {
+	return c.load(ctx)
+}
+
+var httpClient = &http.Client{Timeout: time.Minute}
+
+// fetch reads an image from an http(s) URL, a file:// URL or a path relative
+// to baseDir
+func fetch(ctx context.Context, location, baseDir string) ([]byte, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image URL %q: %w", location, err)
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download %s: %w", location, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to download %s: status %d", location, resp.StatusCode)
+		}
+		return readLimited(resp.Body, location)
+	}
+
+	path := strings.TrimPrefix(location, "file://")
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer f.Close()
+	return readLimited(f, path)
+}
+
+func readLimited(r io.Reader, name string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(data) > maxImageBytes {
+		return nil, fmt.Errorf("%s is larger than %d bytes", name, maxImageBytes)
+	}
+	return data, nil
+}
+
+// slugify turns a deck name into a tag slug
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// titleFromFilename turns a file name like "07_ancient-oak.jpg" into "Ancient Oak"
+func titleFromFilename(name string) string {
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' ' || r == '.'
+	})
+	// Drop a leading sequence number, which only orders the files
+	if len(words) > 1 && strings.IndexFunc(words[0], func(r rune) bool { return !unicode.IsDigit(r) }) == -1 {
+		words = words[1:]
+	}
+	for i, w := range words {
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,144 @@
+package deckimport
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSheet writes a PNG sheet of cols x rows cells of 20x30 pixels, each
+// cell filled with a distinct gray level
+func writeSheet(t *testing.T, path string, cols, rows int) {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, cols*20, rows*30))
+	for y := 0; y < rows*30; y++ {
+		for x := 0; x < cols*20; x++ {
+			cell := (y/30)*cols + x/20
+			img.SetGray(x, y, color.Gray{Y: uint8(40 * (cell + 1))})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+}
+
+func TestReadTTSDeck(t *testing.T) {
+	dir := t.TempDir()
+	writeSheet(t, filepath.Join(dir, "sheet.png"), 2, 2)
+	save := `{"ObjectStates": [{
+		"Name": "DeckCustom",
+		"Nickname": "Dreams",
+		"DeckIDs": [100, 101, 103],
+		"CustomDeck": {"1": {"FaceURL": "sheet.png", "NumWidth": 2, "NumHeight": 2}},
+		"ContainedObjects": [
+			{"Name": "Card", "Nickname": "Moon", "CardID": 100},
+			{"Name": "Card", "Nickname": "", "CardID": 101},
+			{"Name": "Card", "Nickname": "Tide", "Description": " Low water ", "CardID": 103}
+		]
+	}]}`
+	path := filepath.Join(dir, "dreams.json")
+	require.NoError(t, os.WriteFile(path, []byte(save), 0o644))
+
+	deck, err := ReadTTSDeck(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Dreams", deck.Name)
+	require.Len(t, deck.Cards, 3)
+	assert.Equal(t, "Moon", deck.Cards[0].Title)
+	assert.Equal(t, "Dreams #2", deck.Cards[1].Title)
+	assert.Equal(t, "Tide", deck.Cards[2].Title)
+	assert.Equal(t, "Low water", deck.Cards[2].Description)
+
+	// Card 103 is the bottom right cell of the sheet
+	data, err := deck.Cards[2].Image(context.Background())
+	require.NoError(t, err)
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 20, img.Bounds().Dx())
+	assert.Equal(t, 30, img.Bounds().Dy())
+	r, _, _, _ := img.At(10, 15).RGBA()
+	assert.InDelta(t, 160, r>>8, 3)
+}
+
+func TestReadTTSDeck_Errors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "deck.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"Name": "DeckCustom", "DeckIDs": [200]}`), 0o644))
+	_, err := ReadTTSDeck(path)
+	assert.ErrorContains(t, err, "missing custom deck")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"Name": "DeckCustom", "DeckIDs": [104],
+		"CustomDeck": {"1": {"FaceURL": "x.png", "NumWidth": 2, "NumHeight": 2}}}`), 0o644))
+	_, err = ReadTTSDeck(path)
+	assert.ErrorContains(t, err, "outside its 2x2 sheet")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"ObjectStates": [{"Name": "Bag"}]}`), 0o644))
+	_, err = ReadTTSDeck(path)
+	assert.ErrorContains(t, err, "no custom deck cards")
+}
+
+func TestReadFolder(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "night_sky")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	writeSheet(t, filepath.Join(dir, "02_falling-star.png"), 1, 1)
+	writeSheet(t, filepath.Join(dir, "01_moon.png"), 1, 1)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "01_moon.txt"), []byte("A full moon\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.md"), []byte("ignored"), 0o644))
+
+	deck, err := ReadFolder(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "Night Sky", deck.Name)
+	require.Len(t, deck.Cards, 2)
+	assert.Equal(t, "Moon", deck.Cards[0].Title)
+	assert.Equal(t, "A full moon", deck.Cards[0].Description)
+	assert.Equal(t, ".png", deck.Cards[0].Extension)
+	assert.Equal(t, "Falling Star", deck.Cards[1].Title)
+
+	_, err = ReadFolder(t.TempDir())
+	assert.ErrorContains(t, err, "no card images")
+}
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "dreams-of-the-sea", slugify("  Dreams of the Sea!"))
+	assert.Equal(t, "deck-2", slugify("Deck #2"))
+}
+
+func TestClaimHash(t *testing.T) {
+	im := NewImporter(nil, nil, Options{})
+	im.hashes = []uint64{0xFF00}
+
+	assert.False(t, im.claimHash(0xFF01), "near duplicate of an existing card")
+	assert.True(t, im.claimHash(0x00FF))
+	assert.False(t, im.claimHash(0x00FF), "duplicate of a card imported in the same run")
+
+	im = NewImporter(nil, nil, Options{AllowDuplicates: true})
+	im.hashes = []uint64{0xFF00}
+	assert.True(t, im.claimHash(0xFF00))
+}
+
+func TestImportRequiresAttributionToPublish(t *testing.T) {
+	deck := &Deck{Name: "Dreams"}
+
+	im := NewImporter(nil, nil, Options{DryRun: true, Active: true, Attribution: models.CardAttribution{Artist: "  "}})
+	_, err := im.Import(context.Background(), deck)
+	assert.ErrorIs(t, err, ErrMissingAttribution)
+
+	im = NewImporter(nil, nil, Options{DryRun: true, Active: true, Attribution: models.CardAttribution{
+		Artist:    "Jane Doe",
+		License:   "CC BY 4.0",
+		SourceURL: "ftp://example.com/dreams",
+	}})
+	_, err = im.Import(context.Background(), deck)
+	assert.EqualError(t, err, "source URL must be an http(s) URL")
+}
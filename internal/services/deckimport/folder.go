@@ -0,0 +1,67 @@
+package deckimport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// folderImageExtensions are the image files a folder deck may contain; they
+// match the formats the duplicate check can decode
+var folderImageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".gif":  true,
+}
+
+// ReadFolder reads a folder-of-images deck: every image in dir is a card,
+// titled after its file name, and an optional text file with the same base
+// name ("ancient_oak.txt" next to "ancient_oak.jpg") holds its description.
+// Files are imported in name order and the deck is named after the folder.
+func ReadFolder(dir string) (*Deck, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deck folder: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve deck folder: %w", err)
+	}
+	deck := &Deck{
+		Name:   titleFromFilename(filepath.Base(abs)),
+		Source: "folder " + filepath.Base(abs),
+	}
+
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || !folderImageExtensions[ext] {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+
+		var description string
+		if text, err := os.ReadFile(strings.TrimSuffix(path, filepath.Ext(path)) + ".txt"); err == nil {
+			description = strings.TrimSpace(string(text))
+		}
+
+		deck.Cards = append(deck.Cards, Card{
+			Title:       titleFromFilename(entry.Name()),
+			Description: description,
+			Extension:   ext,
+			load: func(ctx context.Context) ([]byte, error) {
+				return fetch(ctx, path, "")
+			},
+		})
+	}
+
+	if len(deck.Cards) == 0 {
+		return nil, fmt.Errorf("no card images found in %s", dir)
+	}
+	return deck, nil
+}
@@ -0,0 +1,267 @@
+package deckimport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"sync"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/cardimages"
+
+	"gorm.io/gorm"
+)
+
+// Placeholder tags: every deck gets a child of the shared root tag
+const (
+	rootTagSlug  = "imported"
+	rootTagName  = "Imported"
+	tagCategory  = "imported"
+	tagColor     = "#9CA3AF"
+	deckTagLabel = " (imported)"
+)
+
+// Store uploads card images (implemented by storage.MinIOClient)
+type Store interface {
+	PutCardImage(ctx context.Context, cardID int, extension string, data io.Reader, size int64, contentType string) (string, error)
+}
+
+// Options controls an import
+type Options struct {
+	Active          bool // Make imported cards playable right away instead of waiting for curation
+	AllowDuplicates bool // Import cards whose image duplicates an existing card
+	DryRun          bool // Read and check every card without writing anything
+	Workers         int  // Cards read and uploaded concurrently
+
+	// Attribution credited on every card of the deck. Custom decks can only
+	// be published (imported as Active) once it names the artist and license.
+	Attribution models.CardAttribution
+}
+
+// ErrMissingAttribution is returned when publishing a deck without an artist or license
+var ErrMissingAttribution = errors.New("decks need an artist and a license before they can be published")
+
+// Result summarizes an import
+type Result struct {
+	Tag        string // Slug of the deck's placeholder tag
+	Imported   int
+	Duplicates int
+	Failed     int
+}
+
+// Importer creates cards from parsed decks
+type Importer struct {
+	db    *gorm.DB
+	store Store
+	opts  Options
+
+	mu     sync.Mutex
+	hashes []uint64 // Existing and already imported images, for the duplicate check
+}
+
+// NewImporter creates an importer; store may be nil for dry runs
+func NewImporter(db *gorm.DB, store Store, opts Options) *Importer {
+	if opts.Workers <= 0 {
+		opts.Workers = 4
+	}
+	return &Importer{db: db, store: store, opts: opts}
+}
+
+// Import creates a card for every card of the deck, uploads its image and
+// tags it with the deck's placeholder tag. A card that fails is logged and
+// counted but does not stop the others.
+func (im *Importer) Import(ctx context.Context, deck *Deck) (*Result, error) {
+	if !im.opts.DryRun && im.store == nil {
+		return nil, fmt.Errorf("storage is required to import cards")
+	}
+	attribution, err := im.opts.Attribution.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if im.opts.Active && !attribution.Complete() {
+		return nil, ErrMissingAttribution
+	}
+	im.opts.Attribution = attribution
+	if err := im.loadHashes(ctx); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Tag: rootTagSlug + "-" + slugify(deck.Name)}
+	var tagID int
+	if !im.opts.DryRun {
+		tag, err := im.ensureTag(ctx, deck, result.Tag)
+		if err != nil {
+			return nil, err
+		}
+		tagID = tag.ID
+	}
+
+	var mu sync.Mutex
+	jobs := make(chan Card)
+	var wg sync.WaitGroup
+	for i := 0; i < im.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for card := range jobs {
+				duplicate, err := im.importCard(ctx, card, tagID)
+				mu.Lock()
+				switch {
+				case err != nil:
+					logger.Warn("Failed to import card", "deck", deck.Name, "title", card.Title, "error", err)
+					result.Failed++
+				case duplicate:
+					logger.Info("Skipped duplicate card", "deck", deck.Name, "title", card.Title)
+					result.Duplicates++
+				default:
+					result.Imported++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, card := range deck.Cards {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- card
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// importCard imports one card, reporting whether it was skipped as a duplicate
+func (im *Importer) importCard(ctx context.Context, card Card, tagID int) (bool, error) {
+	data, err := card.Image(ctx)
+	if err != nil {
+		return false, err
+	}
+	hash, err := cardimages.PerceptualHash(bytes.NewReader(data))
+	if err != nil {
+		return false, fmt.Errorf("unreadable image: %w", err)
+	}
+	if !im.claimHash(hash) {
+		return true, nil
+	}
+	if im.opts.DryRun {
+		return false, nil
+	}
+
+	contentType := mime.TypeByExtension(card.Extension)
+	if contentType == "" {
+		contentType = "image/jpeg"
+	}
+
+	// The card row and its relation are rolled back if the upload fails
+	return false, im.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		record := models.Card{
+			Title:           card.Title,
+			Description:     card.Description,
+			Extension:       card.Extension,
+			ImageHash:       cardimages.FormatHash(hash),
+			IsActive:        im.opts.Active,
+			Version:         1,
+			CardAttribution: im.opts.Attribution,
+		}
+		if err := tx.Create(&record).Error; err != nil {
+			return fmt.Errorf("failed to create card: %w", err)
+		}
+		// Create skips the zero value, so set the flag explicitly over the column default
+		if err := tx.Model(&record).Update("is_active", im.opts.Active).Error; err != nil {
+			return fmt.Errorf("failed to update card: %w", err)
+		}
+
+		url, err := im.store.PutCardImage(ctx, record.ID, card.Extension, bytes.NewReader(data), int64(len(data)), contentType)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&record).Update("image_url", url).Error; err != nil {
+			return fmt.Errorf("failed to update card: %w", err)
+		}
+
+		if err := tx.Create(&models.CardTag{CardID: record.ID, TagID: tagID, Weight: 1.0}).Error; err != nil {
+			return fmt.Errorf("failed to tag card: %w", err)
+		}
+		return nil
+	})
+}
+
+// loadHashes loads the hashes of active cards, the ones uploads are compared against
+func (im *Importer) loadHashes(ctx context.Context) error {
+	var stored []string
+	if err := im.db.WithContext(ctx).Model(&models.Card{}).
+		Where("is_active = ? AND image_hash <> ''", true).
+		Pluck("image_hash", &stored).Error; err != nil {
+		return fmt.Errorf("failed to load card hashes: %w", err)
+	}
+
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	im.hashes = im.hashes[:0]
+	for _, s := range stored {
+		if hash, err := cardimages.ParseHash(s); err == nil {
+			im.hashes = append(im.hashes, hash)
+		}
+	}
+	return nil
+}
+
+// claimHash records an image about to be imported, returning false if it
+// duplicates an existing card or a card imported earlier in this run
+func (im *Importer) claimHash(hash uint64) bool {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	if !im.opts.AllowDuplicates {
+		for _, existing := range im.hashes {
+			if cardimages.HashDistance(hash, existing) <= cardimages.DuplicateDistance {
+				return false
+			}
+		}
+	}
+	im.hashes = append(im.hashes, hash)
+	return true
+}
+
+// ensureTag returns the deck's placeholder tag, creating it and the shared
+// root tag on the first import
+func (im *Importer) ensureTag(ctx context.Context, deck *Deck, slug string) (*models.Tag, error) {
+	db := im.db.WithContext(ctx)
+
+	root := models.Tag{
+		Name:        rootTagName,
+		Slug:        rootTagSlug,
+		Description: "Parent of the placeholder tags given to imported decks",
+		Category:    tagCategory,
+		Color:       tagColor,
+		Weight:      1.0,
+		IsActive:    true,
+	}
+	if err := db.Where("slug = ?", root.Slug).FirstOrCreate(&root).Error; err != nil {
+		return nil, fmt.Errorf("failed to create tag %s: %w", root.Slug, err)
+	}
+
+	tag := models.Tag{
+		Name:        deck.Name + deckTagLabel,
+		Slug:        slug,
+		Description: fmt.Sprintf("Placeholder for cards imported from %s; retag them and remove this tag", deck.Source),
+		Category:    tagCategory,
+		Color:       tagColor,
+		Weight:      1.0,
+		ParentID:    &root.ID,
+		IsActive:    true,
+	}
+	if err := db.Where("slug = ?", tag.Slug).FirstOrCreate(&tag).Error; err != nil {
+		return nil, fmt.Errorf("failed to create tag %s: %w", tag.Slug, err)
+	}
+	return &tag, nil
+}
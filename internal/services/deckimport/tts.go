@@ -0,0 +1,235 @@
+package deckimport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/gif" // Register GIF decoding for sprite sheets
+	"image/jpeg"
+	_ "image/png" // Register PNG decoding for sprite sheets
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ttsSave is a Tabletop Simulator save file or saved object
+type ttsSave struct {
+	ObjectStates []ttsObject `json:"ObjectStates"`
+}
+
+// ttsObject is a TTS object; only the fields describing card decks are read
+type ttsObject struct {
+	Name             string              `json:"Name"`
+	Nickname         string              `json:"Nickname"`
+	Description      string              `json:"Description"`
+	CardID           int                 `json:"CardID"`
+	DeckIDs          []int               `json:"DeckIDs"`
+	CustomDeck       map[string]ttsSheet `json:"CustomDeck"`
+	ContainedObjects []ttsObject         `json:"ContainedObjects"`
+}
+
+// ttsSheet is a sprite sheet of card faces laid out in a grid
+type ttsSheet struct {
+	FaceURL   string `json:"FaceURL"`
+	NumWidth  int    `json:"NumWidth"`
+	NumHeight int    `json:"NumHeight"`
+}
+
+// ttsCard is a card found in a save, with the sheet its face is cut from
+type ttsCard struct {
+	title       string
+	description string
+	sheet       ttsSheet
+	index       int // Position on the sheet, row by row
+}
+
+// ReadTTSDeck reads the custom decks of a Tabletop Simulator save or saved
+// object file. TTS stores card faces as sprite sheets, so each card's image
+// is cut from its sheet; relative sheet paths are resolved against the
+// file's folder. Cards without a nickname are numbered.
+func ReadTTSDeck(path string) (*Deck, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TTS file: %w", err)
+	}
+
+	var save ttsSave
+	if err := json.Unmarshal(data, &save); err != nil {
+		return nil, fmt.Errorf("invalid TTS file: %w", err)
+	}
+	objects := save.ObjectStates
+	if len(objects) == 0 {
+		// Saved objects exported on their own hold a single object at the root
+		var obj ttsObject
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return nil, fmt.Errorf("invalid TTS file: %w", err)
+		}
+		objects = []ttsObject{obj}
+	}
+
+	var cards []ttsCard
+	for _, obj := range objects {
+		if err := collectTTSCards(obj, nil, &cards); err != nil {
+			return nil, err
+		}
+	}
+	if len(cards) == 0 {
+		return nil, fmt.Errorf("no custom deck cards found in %s", path)
+	}
+
+	deck := &Deck{
+		Name:   objects[0].Nickname,
+		Source: "Tabletop Simulator file " + filepath.Base(path),
+	}
+	if deck.Name == "" {
+		deck.Name = titleFromFilename(filepath.Base(path))
+	}
+
+	sheets := &sheetCache{baseDir: filepath.Dir(path), sheets: make(map[string]*loadedSheet)}
+	for i, card := range cards {
+		title := card.title
+		if title == "" {
+			title = fmt.Sprintf("%s #%d", deck.Name, i+1)
+		}
+		deck.Cards = append(deck.Cards, Card{
+			Title:       title,
+			Description: card.description,
+			Extension:   ".jpg",
+			load: func(ctx context.Context) ([]byte, error) {
+				return sheets.cut(ctx, card.sheet, card.index)
+			},
+		})
+	}
+	return deck, nil
+}
+
+// collectTTSCards walks an object and the objects it contains, resolving each
+// card against the nearest enclosing CustomDeck. Sheet keys are only unique
+// within a deck, so every object sees its own copy of the enclosing sheets.
+func collectTTSCards(obj ttsObject, sheets map[int]ttsSheet, cards *[]ttsCard) error {
+	if len(obj.CustomDeck) > 0 {
+		scoped := make(map[int]ttsSheet, len(sheets)+len(obj.CustomDeck))
+		for key, sheet := range sheets {
+			scoped[key] = sheet
+		}
+		for key, sheet := range obj.CustomDeck {
+			id, err := strconv.Atoi(key)
+			if err != nil {
+				return fmt.Errorf("invalid custom deck key %q", key)
+			}
+			scoped[id] = sheet
+		}
+		sheets = scoped
+	}
+
+	switch {
+	case strings.HasPrefix(obj.Name, "Card"):
+		return appendTTSCard(obj.CardID, obj.Nickname, obj.Description, sheets, cards)
+	case len(obj.ContainedObjects) > 0:
+		for _, child := range obj.ContainedObjects {
+			if err := collectTTSCards(child, sheets, cards); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, id := range obj.DeckIDs {
+			if err := appendTTSCard(id, "", "", sheets, cards); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendTTSCard resolves a TTS card ID, the sheet key times 100 plus the
+// position on the sheet
+func appendTTSCard(cardID int, title, description string, sheets map[int]ttsSheet, cards *[]ttsCard) error {
+	sheet, ok := sheets[cardID/100]
+	if !ok {
+		return fmt.Errorf("card %d refers to a missing custom deck", cardID)
+	}
+	if sheet.NumWidth <= 0 || sheet.NumHeight <= 0 {
+		sheet.NumWidth, sheet.NumHeight = 1, 1
+	}
+	index := cardID % 100
+	if index >= sheet.NumWidth*sheet.NumHeight {
+		return fmt.Errorf("card %d is outside its %dx%d sheet", cardID, sheet.NumWidth, sheet.NumHeight)
+	}
+	*cards = append(*cards, ttsCard{
+		title:       strings.TrimSpace(title),
+		description: strings.TrimSpace(description),
+		sheet:       sheet,
+		index:       index,
+	})
+	return nil
+}
+
+// sheetCache downloads and decodes each sprite sheet once, however many
+// cards are cut from it concurrently
+type sheetCache struct {
+	baseDir string
+	mu      sync.Mutex
+	sheets  map[string]*loadedSheet
+}
+
+type loadedSheet struct {
+	once  sync.Once
+	image image.Image
+	err   error
+}
+
+func (c *sheetCache) load(ctx context.Context, url string) (image.Image, error) {
+	c.mu.Lock()
+	sheet, ok := c.sheets[url]
+	if !ok {
+		sheet = &loadedSheet{}
+		c.sheets[url] = sheet
+	}
+	c.mu.Unlock()
+
+	sheet.once.Do(func() {
+		data, err := fetch(ctx, url, c.baseDir)
+		if err != nil {
+			sheet.err = err
+			return
+		}
+		sheet.image, _, sheet.err = image.Decode(bytes.NewReader(data))
+		if sheet.err != nil {
+			sheet.err = fmt.Errorf("failed to decode sheet %s: %w", url, sheet.err)
+		}
+	})
+	return sheet.image, sheet.err
+}
+
+// cut returns the JPEG-encoded face at index of a sheet
+func (c *sheetCache) cut(ctx context.Context, sheet ttsSheet, index int) ([]byte, error) {
+	img, err := c.load(ctx, sheet.FaceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx()/sheet.NumWidth, bounds.Dy()/sheet.NumHeight
+	col, row := index%sheet.NumWidth, index/sheet.NumWidth
+	rect := image.Rect(
+		bounds.Min.X+col*width, bounds.Min.Y+row*height,
+		bounds.Min.X+(col+1)*width, bounds.Min.Y+(row+1)*height,
+	)
+
+	sub, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	})
+	if !ok {
+		return nil, fmt.Errorf("sheet %s cannot be cropped", sheet.FaceURL)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, sub.SubImage(rect), &jpeg.Options{Quality: 92}); err != nil {
+		return nil, fmt.Errorf("failed to encode card image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,435 @@
+// Package deckpack is the marketplace of community deck packs: imported
+// decks an admin published, which players browse, install into their library
+// and rate once they have played with them. Installs are counted per pack to
+// rank the popular ones.
+package deckpack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxNameLength        = 80
+	maxAuthorLength      = 100
+	maxDescriptionLength = 1000
+
+	// PreviewCards is how many card images a listing shows
+	PreviewCards = 4
+
+	// MaxStars is the best rating; the worst is one star
+	MaxStars = 5
+)
+
+// Browse orders
+const (
+	SortPopular = "popular" // Most installed first
+	SortRating  = "rating"  // Best average rating first
+	SortNewest  = "newest"
+)
+
+var (
+	ErrNotFound  = errors.New("deck pack not found")
+	ErrNoCards   = errors.New("the deck has no playable cards to publish")
+	ErrNotPlayed = errors.New("finish a game with this pack before rating it")
+	ErrStars     = fmt.Errorf("rating must be between 1 and %d stars", MaxStars)
+)
+
+// Spec is what an admin publishes a deck with
+type Spec struct {
+	Slug        string `json:"slug"` // Tag of the imported deck
+	Name        string `json:"name"`
+	Author      string `json:"author"`
+	Description string `json:"description"`
+}
+
+// Pack is a marketplace listing
+type Pack struct {
+	models.DeckPack
+	Rating    float64  `json:"rating"` // Average stars, 0 while unrated
+	CardCount int      `json:"card_count"`
+	Previews  []string `json:"previews"` // Image URLs of the first few cards
+	Installed bool     `json:"installed"`
+}
+
+// Query selects a page of the marketplace
+type Query struct {
+	Search string
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+// validate checks a spec and trims its fields
+func (s Spec) validate() (Spec, error) {
+	s.Slug = strings.TrimSpace(s.Slug)
+	s.Name = strings.TrimSpace(s.Name)
+	s.Author = strings.TrimSpace(s.Author)
+	s.Description = strings.TrimSpace(s.Description)
+
+	if s.Slug == "" {
+		return s, fmt.Errorf("deck slug is required")
+	}
+	if s.Name == "" || len(s.Name) > maxNameLength {
+		return s, fmt.Errorf("pack name must be between 1 and %d characters", maxNameLength)
+	}
+	if s.Author == "" || len(s.Author) > maxAuthorLength {
+		return s, fmt.Errorf("author must be between 1 and %d characters", maxAuthorLength)
+	}
+	if len(s.Description) > maxDescriptionLength {
+		return s, fmt.Errorf("description must be at most %d characters", maxDescriptionLength)
+	}
+	return s, nil
+}
+
+// order is the ORDER BY clause of a browse order, popular for unknown ones
+func order(sort string) string {
+	switch sort {
+	case SortRating:
+		return "CASE WHEN rating_count = 0 THEN 0 ELSE rating_total::float / rating_count END DESC, rating_count DESC, installs DESC"
+	case SortNewest:
+		return "created_at DESC"
+	}
+	return "installs DESC, rating_count DESC, created_at DESC"
+}
+
+// average is a pack's average rating, 0 while unrated
+func average(pack models.DeckPack) float64 {
+	if pack.RatingCount == 0 {
+		return 0
+	}
+	return float64(pack.RatingTotal) / float64(pack.RatingCount)
+}
+
+// Publish lists an imported deck in the marketplace, or updates its listing.
+// A pack that was taken down is listed again.
+func Publish(ctx context.Context, db *gorm.DB, adminID uuid.UUID, spec Spec) (*Pack, error) {
+	spec, err := spec.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := cardCounts(ctx, db, []string{spec.Slug})
+	if err != nil {
+		return nil, err
+	}
+	if counts[spec.Slug] == 0 {
+		return nil, ErrNoCards
+	}
+
+	var pack models.DeckPack
+	err = db.WithContext(ctx).First(&pack, "slug = ?", spec.Slug).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		pack = models.DeckPack{ID: uuid.New(), Slug: spec.Slug}
+	case err != nil:
+		return nil, fmt.Errorf("failed to load deck pack: %w", err)
+	}
+	pack.Name = spec.Name
+	pack.Author = spec.Author
+	pack.Description = spec.Description
+	pack.Published = true
+	pack.PublishedBy = &adminID
+	pack.UpdatedAt = time.Now()
+	if err := db.WithContext(ctx).Save(&pack).Error; err != nil {
+		return nil, fmt.Errorf("failed to publish deck pack: %w", err)
+	}
+
+	packs, err := decorate(ctx, db, []models.DeckPack{pack}, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	return &packs[0], nil
+}
+
+// Unpublish takes a pack out of the marketplace. Libraries keep it.
+func Unpublish(ctx context.Context, db *gorm.DB, packID uuid.UUID) error {
+	result := db.WithContext(ctx).Model(&models.DeckPack{}).Where("id = ?", packID).Update("published", false)
+	if result.Error != nil {
+		return fmt.Errorf("failed to unpublish deck pack: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Browse returns a page of published packs and how many match in all.
+// userID, when set, marks the packs in that user's library.
+func Browse(ctx context.Context, db *gorm.DB, userID uuid.UUID, query Query) ([]Pack, int64, error) {
+	scope := db.WithContext(ctx).Model(&models.DeckPack{}).Where("published = ?", true)
+	if search := strings.TrimSpace(query.Search); search != "" {
+		like := "%" + strings.ToLower(search) + "%"
+		scope = scope.Where("LOWER(name) LIKE ? OR LOWER(author) LIKE ?", like, like)
+	}
+
+	var total int64
+	if err := scope.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count deck packs: %w", err)
+	}
+	var rows []models.DeckPack
+	if err := scope.Order(order(query.Sort)).Limit(query.Limit).Offset(query.Offset).Find(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list deck packs: %w", err)
+	}
+
+	packs, err := decorate(ctx, db, rows, userID)
+	return packs, total, err
+}
+
+// Get loads a published pack
+func Get(ctx context.Context, db *gorm.DB, userID, packID uuid.UUID) (*Pack, error) {
+	pack, err := load(ctx, db, packID)
+	if err != nil {
+		return nil, err
+	}
+	packs, err := decorate(ctx, db, []models.DeckPack{*pack}, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &packs[0], nil
+}
+
+// Library returns the packs a user installed, most recent first, including
+// packs taken down since
+func Library(ctx context.Context, db *gorm.DB, userID uuid.UUID) ([]Pack, error) {
+	var rows []models.DeckPack
+	if err := db.WithContext(ctx).
+		Joins("JOIN deck_pack_installs ON deck_pack_installs.pack_id = deck_packs.id").
+		Where("deck_pack_installs.user_id = ?", userID).
+		Order("deck_pack_installs.created_at DESC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load deck pack library: %w", err)
+	}
+	return decorate(ctx, db, rows, userID)
+}
+
+// Install adds a published pack to a user's library. Installing it again
+// changes nothing.
+func Install(ctx context.Context, db *gorm.DB, userID, packID uuid.UUID) (*Pack, error) {
+	if _, err := load(ctx, db, packID); err != nil {
+		return nil, err
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.DeckPackInstall{}).Where("pack_id = ? AND user_id = ?", packID, userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil // Already installed
+		}
+		if err := tx.Create(&models.DeckPackInstall{PackID: packID, UserID: userID}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.DeckPack{}).Where("id = ?", packID).
+			Update("installs", gorm.Expr("installs + 1")).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to install deck pack: %w", err)
+	}
+	return Get(ctx, db, userID, packID)
+}
+
+// Uninstall removes a pack from a user's library
+func Uninstall(ctx context.Context, db *gorm.DB, userID, packID uuid.UUID) error {
+	removed := false
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.DeckPackInstall{}, "pack_id = ? AND user_id = ?", packID, userID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if removed = result.RowsAffected > 0; !removed {
+			return nil
+		}
+		return tx.Model(&models.DeckPack{}).Where("id = ? AND installs > 0", packID).
+			Update("installs", gorm.Expr("installs - 1")).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to uninstall deck pack: %w", err)
+	}
+	if !removed {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Rate records a user's stars for a pack they finished a game with. Rating
+// again replaces their earlier rating.
+func Rate(ctx context.Context, db *gorm.DB, userID, packID uuid.UUID, stars int) (*Pack, error) {
+	if stars < 1 || stars > MaxStars {
+		return nil, ErrStars
+	}
+	pack, err := load(ctx, db, packID)
+	if err != nil {
+		return nil, err
+	}
+	played, err := playedWith(ctx, db, userID, pack.Slug)
+	if err != nil {
+		return nil, err
+	}
+	if !played {
+		return nil, ErrNotPlayed
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rating models.DeckPackRating
+		err := tx.First(&rating, "pack_id = ? AND user_id = ?", packID, userID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := tx.Create(&models.DeckPackRating{PackID: packID, UserID: userID, Stars: stars}).Error; err != nil {
+				return err
+			}
+			return tx.Model(&models.DeckPack{}).Where("id = ?", packID).Updates(map[string]interface{}{
+				"rating_count": gorm.Expr("rating_count + 1"),
+				"rating_total": gorm.Expr("rating_total + ?", stars),
+			}).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		previous := rating.Stars
+		if err := tx.Model(&rating).Update("stars", stars).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.DeckPack{}).Where("id = ?", packID).
+			Update("rating_total", gorm.Expr("rating_total + ?", stars-previous)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rate deck pack: %w", err)
+	}
+	return Get(ctx, db, userID, packID)
+}
+
+// load loads a published pack
+func load(ctx context.Context, db *gorm.DB, packID uuid.UUID) (*models.DeckPack, error) {
+	var pack models.DeckPack
+	if err := db.WithContext(ctx).First(&pack, "id = ? AND published = ?", packID, true).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to load deck pack: %w", err)
+	}
+	return &pack, nil
+}
+
+// playedWith reports whether the user saw out a game with the deck among its expansions
+func playedWith(ctx context.Context, db *gorm.DB, userID uuid.UUID, slug string) (bool, error) {
+	var count int64
+	if err := db.WithContext(ctx).Model(&models.Game{}).
+		Joins("JOIN game_players ON game_players.game_id = games.id").
+		Where("game_players.player_id = ? AND games.status = ?", userID, models.GameStatusCompleted).
+		Where("',' || games.expansions || ',' LIKE ?", "%,"+slug+",%").
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check deck pack games: %w", err)
+	}
+	return count > 0, nil
+}
+
+// decorate adds the card counts, previews, ratings and the user's installs to listings
+func decorate(ctx context.Context, db *gorm.DB, rows []models.DeckPack, userID uuid.UUID) ([]Pack, error) {
+	packs := make([]Pack, len(rows))
+	if len(rows) == 0 {
+		return packs, nil
+	}
+
+	slugs := make([]string, len(rows))
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		slugs[i], ids[i] = row.Slug, row.ID
+	}
+	counts, err := cardCounts(ctx, db, slugs)
+	if err != nil {
+		return nil, err
+	}
+	previews, err := previewImages(ctx, db, slugs)
+	if err != nil {
+		return nil, err
+	}
+	installed := make(map[uuid.UUID]bool)
+	if userID != uuid.Nil {
+		var packIDs []uuid.UUID
+		if err := db.WithContext(ctx).Model(&models.DeckPackInstall{}).
+			Where("user_id = ? AND pack_id IN ?", userID, ids).
+			Pluck("pack_id", &packIDs).Error; err != nil {
+			return nil, fmt.Errorf("failed to load deck pack installs: %w", err)
+		}
+		for _, id := range packIDs {
+			installed[id] = true
+		}
+	}
+
+	for i, row := range rows {
+		packs[i] = Pack{
+			DeckPack:  row,
+			Rating:    average(row),
+			CardCount: counts[row.Slug],
+			Previews:  previews[row.Slug],
+			Installed: installed[row.ID],
+		}
+		if packs[i].Previews == nil {
+			packs[i].Previews = []string{}
+		}
+	}
+	return packs, nil
+}
+
+// cardCounts counts the active cards of each deck
+func cardCounts(ctx context.Context, db *gorm.DB, slugs []string) (map[string]int, error) {
+	var rows []struct {
+		Slug  string
+		Cards int
+	}
+	if err := db.WithContext(ctx).Table("tags").
+		Select("tags.slug AS slug, COUNT(cards.id) AS cards").
+		Joins("JOIN card_tags ON card_tags.tag_id = tags.id").
+		Joins("JOIN cards ON cards.id = card_tags.card_id").
+		Where("tags.slug IN ? AND cards.is_active = ?", slugs, true).
+		Group("tags.slug").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count deck pack cards: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Slug] = row.Cards
+	}
+	return counts, nil
+}
+
+// previewImages lists the images of the first few active cards of each deck,
+// thumbnails where there are some
+func previewImages(ctx context.Context, db *gorm.DB, slugs []string) (map[string][]string, error) {
+	var rows []struct {
+		Slug  string
+		Image string
+	}
+	if err := db.WithContext(ctx).Raw(`
+		SELECT slug, image FROM (
+			SELECT tags.slug AS slug,
+				COALESCE(NULLIF(cards.thumbnail_url, ''), cards.image_url) AS image,
+				ROW_NUMBER() OVER (PARTITION BY tags.slug ORDER BY cards.id) AS n
+			FROM tags
+			JOIN card_tags ON card_tags.tag_id = tags.id
+			JOIN cards ON cards.id = card_tags.card_id
+			WHERE tags.slug IN ? AND cards.is_active = ?
+		) ranked
+		WHERE n <= ?
+		ORDER BY slug, n`, slugs, true, PreviewCards).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load deck pack previews: %w", err)
+	}
+
+	previews := make(map[string][]string, len(slugs))
+	for _, row := range rows {
+		previews[row.Slug] = append(previews[row.Slug], row.Image)
+	}
+	return previews, nil
+}
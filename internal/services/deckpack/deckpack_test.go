@@ -0,0 +1,35 @@
+package deckpack
+
+import (
+	"strings"
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecValidate(t *testing.T) {
+	spec, err := Spec{Slug: " imported-dreams ", Name: " Dreams ", Author: " Ana "}.validate()
+	require.NoError(t, err)
+	assert.Equal(t, Spec{Slug: "imported-dreams", Name: "Dreams", Author: "Ana"}, spec)
+
+	_, err = Spec{Name: "Dreams", Author: "Ana"}.validate()
+	assert.Error(t, err)
+	_, err = Spec{Slug: "imported-dreams", Name: "Dreams"}.validate()
+	assert.Error(t, err)
+	_, err = Spec{Slug: "imported-dreams", Name: strings.Repeat("x", maxNameLength+1), Author: "Ana"}.validate()
+	assert.Error(t, err)
+}
+
+func TestOrderDefaultsToPopular(t *testing.T) {
+	assert.Equal(t, order(SortPopular), order(""))
+	assert.Equal(t, order(SortPopular), order("unknown"))
+	assert.Contains(t, order(SortNewest), "created_at DESC")
+}
+
+func TestAverage(t *testing.T) {
+	assert.Zero(t, average(models.DeckPack{}))
+	assert.InDelta(t, 4.5, average(models.DeckPack{RatingCount: 2, RatingTotal: 9}), 0.001)
+}
@@ -0,0 +1,160 @@
+// Package experiments defines alternative game mechanics that rooms can opt
+// into while they are being evaluated. Each experiment sits behind a
+// deployment-wide feature flag; a room may only enable flagged-on experiments.
+package experiments
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"dixitme/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Experiment keys
+const (
+	WeightedVotes = "weighted_votes" // Voters stake 1-3 confidence on their guess
+	DoubleDown    = "double_down"    // Once per game, bet double on a guess
+)
+
+// Experiment describes an alternative mechanic
+type Experiment struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"` // Feature flag state on this deployment
+}
+
+var catalog = []Experiment{
+	{
+		Key:         WeightedVotes,
+		Name:        "Weighted votes",
+		Description: "Stake 1-3 confidence on your vote: a correct guess earns the extra points, a wrong one gives them to the card's owner.",
+	},
+	{
+		Key:         DoubleDown,
+		Name:        "Double down",
+		Description: "Once per game, double down on a guess for 3 extra points if right and 2 lost if wrong.",
+	},
+}
+
+var (
+	mu        sync.RWMutex
+	enabled   = make(map[string]bool)
+	overrides = make(map[string]bool) // Runtime flags from the feature_flags table
+)
+
+// Configure sets which experiments are flagged on. Unknown keys are ignored.
+func Configure(keys []string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	enabled = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if _, exists := find(key); exists {
+			enabled[key] = true
+		}
+	}
+}
+
+// SetOverrides replaces the runtime flags, which win over Configure. Unknown keys are ignored.
+func SetOverrides(flags map[string]bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	overrides = make(map[string]bool, len(flags))
+	for key, on := range flags {
+		if _, exists := find(key); exists {
+			overrides[key] = on
+		}
+	}
+}
+
+// LoadOverrides reads the runtime flags from the database
+func LoadOverrides(ctx context.Context, db *gorm.DB) error {
+	var flags []models.FeatureFlag
+	if err := db.WithContext(ctx).Find(&flags).Error; err != nil {
+		return fmt.Errorf("failed to load feature flags: %w", err)
+	}
+
+	loaded := make(map[string]bool, len(flags))
+	for _, flag := range flags {
+		loaded[flag.Key] = flag.Enabled
+	}
+	SetOverrides(loaded)
+	return nil
+}
+
+// IsKnown reports whether key names an experiment
+func IsKnown(key string) bool {
+	_, exists := find(key)
+	return exists
+}
+
+// IsEnabled reports whether an experiment is flagged on
+func IsEnabled(key string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return isEnabled(key)
+}
+
+// List returns every known experiment with its flag state
+func List() []Experiment {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]Experiment, 0, len(catalog))
+	for _, experiment := range catalog {
+		experiment.Enabled = isEnabled(experiment.Key)
+		list = append(list, experiment)
+	}
+	return list
+}
+
+// Validate checks that a room's opted-in experiments exist and are flagged on
+func Validate(keys []string) error {
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if _, exists := find(key); !exists {
+			return fmt.Errorf("unknown experiment %q", key)
+		}
+		if !IsEnabled(key) {
+			return fmt.Errorf("experiment %q is not available", key)
+		}
+		if seen[key] {
+			return fmt.Errorf("experiment %q listed twice", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Normalize returns a sorted copy of keys so assignments compare and store consistently
+func Normalize(keys []string) []string {
+	normalized := make([]string, len(keys))
+	copy(normalized, keys)
+	sort.Strings(normalized)
+	return normalized
+}
+
+// isEnabled resolves a flag. Callers must hold mu.
+func isEnabled(key string) bool {
+	if on, exists := overrides[key]; exists {
+		return on
+	}
+	return enabled[key]
+}
+
+func find(key string) (Experiment, bool) {
+	for _, experiment := range catalog {
+		if experiment.Key == key {
+			return experiment, true
+		}
+	}
+	return Experiment{}, false
+}
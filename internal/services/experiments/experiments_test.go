@@ -0,0 +1,46 @@
+package experiments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	Configure([]string{WeightedVotes, "not_a_real_experiment"})
+	defer Configure(nil)
+
+	assert.True(t, IsEnabled(WeightedVotes))
+	assert.False(t, IsEnabled(DoubleDown))
+	assert.False(t, IsEnabled("not_a_real_experiment"))
+
+	assert.NoError(t, Validate(nil))
+	assert.NoError(t, Validate([]string{WeightedVotes}))
+	assert.Error(t, Validate([]string{DoubleDown}))
+	assert.Error(t, Validate([]string{"unknown"}))
+	assert.Error(t, Validate([]string{WeightedVotes, WeightedVotes}))
+}
+
+func TestList(t *testing.T) {
+	Configure([]string{DoubleDown})
+	defer Configure(nil)
+
+	for _, experiment := range List() {
+		assert.Equal(t, experiment.Key == DoubleDown, experiment.Enabled)
+	}
+}
+
+func TestOverridesWinOverConfiguration(t *testing.T) {
+	Configure([]string{WeightedVotes})
+	defer Configure(nil)
+	SetOverrides(map[string]bool{WeightedVotes: false, DoubleDown: true, "unknown": true})
+	defer SetOverrides(nil)
+
+	assert.False(t, IsEnabled(WeightedVotes))
+	assert.True(t, IsEnabled(DoubleDown))
+	assert.False(t, IsEnabled("unknown"))
+
+	SetOverrides(nil)
+	assert.True(t, IsEnabled(WeightedVotes))
+	assert.False(t, IsEnabled(DoubleDown))
+}
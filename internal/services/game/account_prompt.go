@@ -0,0 +1,150 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Milestones at which guests are invited to create an account
+const (
+	MilestoneThirdGame = "third_game" // Finished their third game
+	MilestoneFirstWin  = "first_win"  // Won a game for the first time
+)
+
+// UpgradeTokenIssuer signs tokens that let a guest session become an account
+// in one step (implemented by auth.JWTService)
+type UpgradeTokenIssuer interface {
+	GenerateUpgradeToken(sessionID uuid.UUID, milestone string) (string, time.Time, error)
+}
+
+// SetUpgradeTokenIssuer enables account prompts for guests. Without an issuer none are sent.
+func (m *Manager) SetUpgradeTokenIssuer(issuer UpgradeTokenIssuer) {
+	m.mu.Lock()
+	m.upgradeTokens = issuer
+	m.mu.Unlock()
+}
+
+// accountPromptHook invites guests to create an account when a finished game
+// takes them past a milestone
+type accountPromptHook struct {
+	NopLifecycleHook
+	manager *Manager
+}
+
+func (h accountPromptHook) OnGameCompleted(game *GameState, result *GameResult) {
+	if game.Sandbox || h.manager.db == nil || result.Outcome != models.GameOutcomeCompleted {
+		return
+	}
+
+	// Guests play under their session ID, so only seated humans can be one
+	var candidates []uuid.UUID
+	for playerID, player := range game.Players {
+		if !player.IsBot && player.IsActive && !player.WasReplaced {
+			candidates = append(candidates, playerID)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	// The history is already persisted; counting it is left to the background
+	go h.manager.promptGuests(game.RoomCode, candidates, result.WinnerID)
+}
+
+// promptGuests sends an account prompt to every guest among players who just reached a milestone
+func (m *Manager) promptGuests(roomCode string, players []uuid.UUID, winnerID uuid.UUID) {
+	m.mu.RLock()
+	issuer := m.upgradeTokens
+	m.mu.RUnlock()
+	if issuer == nil {
+		return
+	}
+
+	ctx := context.Background()
+	for _, playerID := range players {
+		milestone, err := m.guestMilestone(ctx, playerID, playerID == winnerID)
+		if err != nil {
+			logger.Error("Failed to check guest milestones", "error", err, "player_id", playerID)
+			continue
+		}
+		if milestone == "" {
+			continue
+		}
+
+		token, expiresAt, err := issuer.GenerateUpgradeToken(playerID, milestone)
+		if err != nil {
+			logger.Error("Failed to generate upgrade token", "error", err, "player_id", playerID)
+			continue
+		}
+		m.sendAccountPrompt(roomCode, playerID, AccountPromptPayload{
+			Milestone:    milestone,
+			UpgradeToken: token,
+			ExpiresAt:    expiresAt,
+		})
+	}
+}
+
+// guestMilestone returns the milestone a guest's latest game took them past,
+// or "" for registered players and guests between milestones. Counts are
+// compared exactly, so each milestone is reached once.
+func (m *Manager) guestMilestone(ctx context.Context, playerID uuid.UUID, won bool) (string, error) {
+	var guestSessions int64
+	if err := m.db.WithContext(ctx).Model(&models.Session{}).
+		Where("id = ? AND auth_type = ? AND is_active = ?", playerID, models.AuthTypeGuest, true).
+		Count(&guestSessions).Error; err != nil {
+		return "", fmt.Errorf("failed to look up guest session: %w", err)
+	}
+	if guestSessions == 0 {
+		return "", nil
+	}
+
+	if won {
+		var wins int64
+		if err := m.db.WithContext(ctx).Model(&models.GameHistory{}).
+			Where("winner_id = ? AND outcome = ?", playerID, models.GameOutcomeCompleted).
+			Count(&wins).Error; err != nil {
+			return "", fmt.Errorf("failed to count wins: %w", err)
+		}
+		if wins == 1 {
+			return MilestoneFirstWin, nil
+		}
+	}
+
+	var finished int64
+	if err := m.db.WithContext(ctx).Model(&models.GameHistory{}).
+		Joins("JOIN game_players ON game_players.game_id = game_histories.game_id").
+		Where("game_players.player_id = ? AND game_histories.outcome = ?", playerID, models.GameOutcomeCompleted).
+		Count(&finished).Error; err != nil {
+		return "", fmt.Errorf("failed to count finished games: %w", err)
+	}
+	if finished == 3 {
+		return MilestoneThirdGame, nil
+	}
+	return "", nil
+}
+
+// sendAccountPrompt delivers a prompt if the guest is still connected to the room
+func (m *Manager) sendAccountPrompt(roomCode string, playerID uuid.UUID, payload AccountPromptPayload) {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return
+	}
+
+	game.mu.Lock()
+	err := m.SendToPlayer(game, playerID, MessageTypeAccountPrompt, payload)
+	game.mu.Unlock()
+	if err != nil {
+		logger.Debug("Account prompt not delivered", "error", err, "player_id", playerID, "room_code", roomCode)
+		return
+	}
+
+	metrics.GetCounter(metrics.Name("guest_account_prompts_total", "milestone", payload.Milestone)).Inc()
+	logger.Info("Guest invited to create an account", "player_id", playerID, "milestone", payload.Milestone)
+}
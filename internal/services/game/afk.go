@@ -0,0 +1,220 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// AFKThresholds are how long a disconnected player may stay inactive in each
+// phase before losing their seat: to a bot in a game, or outright in the lobby.
+// Zero leaves a phase to the next level: room, then deployment, then the pace's
+// AFK timeout.
+type AFKThresholds struct {
+	LobbySeconds       int `json:"lobby_seconds,omitempty"`       // Waiting for the game to start
+	StorytellerSeconds int `json:"storyteller_seconds,omitempty"` // Storyteller while the clue is due
+	SubmittingSeconds  int `json:"submitting_seconds,omitempty"`  // Players while their card is due
+	VotingSeconds      int `json:"voting_seconds,omitempty"`      // Voters while their vote is due
+}
+
+// DefaultAFKThresholds gives the storyteller longer than voters, and the lobby
+// longer than any round phase. They apply to the standard pace.
+func DefaultAFKThresholds() AFKThresholds {
+	return AFKThresholds{
+		LobbySeconds:       600,
+		StorytellerSeconds: 240,
+		SubmittingSeconds:  180,
+		VotingSeconds:      120,
+	}
+}
+
+// Validate checks that every set threshold is playable
+func (t AFKThresholds) Validate() error {
+	for name, seconds := range map[string]int{
+		"lobby":       t.LobbySeconds,
+		"storyteller": t.StorytellerSeconds,
+		"submitting":  t.SubmittingSeconds,
+		"voting":      t.VotingSeconds,
+	} {
+		if seconds != 0 && (seconds < 30 || seconds > 3600) {
+			return fmt.Errorf("%s AFK threshold must be off or between 30 and 3600 seconds", name)
+		}
+	}
+	return nil
+}
+
+// overlay returns t with the thresholds set in override replacing its own
+func (t AFKThresholds) overlay(override AFKThresholds) AFKThresholds {
+	pick := func(base, override int) int {
+		if override > 0 {
+			return override
+		}
+		return base
+	}
+	return AFKThresholds{
+		LobbySeconds:       pick(t.LobbySeconds, override.LobbySeconds),
+		StorytellerSeconds: pick(t.StorytellerSeconds, override.StorytellerSeconds),
+		SubmittingSeconds:  pick(t.SubmittingSeconds, override.SubmittingSeconds),
+		VotingSeconds:      pick(t.VotingSeconds, override.VotingSeconds),
+	}
+}
+
+// scaled stretches the thresholds by a factor, e.g. to match a faster pace
+func (t AFKThresholds) scaled(factor float64) AFKThresholds {
+	scale := func(seconds int) int {
+		return int(float64(seconds) * factor)
+	}
+	return AFKThresholds{
+		LobbySeconds:       scale(t.LobbySeconds),
+		StorytellerSeconds: scale(t.StorytellerSeconds),
+		SubmittingSeconds:  scale(t.SubmittingSeconds),
+		VotingSeconds:      scale(t.VotingSeconds),
+	}
+}
+
+// SetAFKThresholds replaces the deployment-wide AFK thresholds
+func (m *Manager) SetAFKThresholds(thresholds AFKThresholds) error {
+	if err := thresholds.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.afkThresholds = thresholds
+	m.mu.Unlock()
+	return nil
+}
+
+// roomAFKThresholds resolves a room's thresholds. The deployment's are set for
+// the standard pace and scaled to the room's pace; the room's own overrides
+// are taken as they are.
+func (m *Manager) roomAFKThresholds(game *GameState) AFKThresholds {
+	m.mu.RLock()
+	deployment := m.afkThresholds
+	m.mu.RUnlock()
+
+	factor := float64(game.Settings.Timing.AFKTimeout()) / float64(defaultAFKTimeout)
+	return deployment.scaled(factor).overlay(game.Settings.AFK)
+}
+
+// AFKTimeout is how long a player may be inactive in the room's current phase.
+// Phases without a threshold, like the storyteller waiting on votes, use the
+// pace's AFK timeout. The caller holds the game lock.
+func (m *Manager) AFKTimeout(game *GameState, playerID uuid.UUID) time.Duration {
+	thresholds := m.roomAFKThresholds(game)
+
+	seconds := 0
+	switch {
+	case game.Status == models.GameStatusWaiting:
+		seconds = thresholds.LobbySeconds
+	case game.Status == models.GameStatusInProgress && game.CurrentRound != nil:
+		round := game.CurrentRound
+		isStoryteller := round.StorytellerID == playerID
+		switch round.Status {
+		case models.RoundStatusStorytelling:
+			if isStoryteller {
+				seconds = thresholds.StorytellerSeconds
+			}
+		case models.RoundStatusSubmitting:
+			if !isStoryteller {
+				seconds = thresholds.SubmittingSeconds
+			}
+		case models.RoundStatusVoting:
+			if !isStoryteller {
+				seconds = thresholds.VotingSeconds
+			}
+		}
+	}
+
+	if seconds <= 0 {
+		return game.Settings.Timing.AFKTimeout()
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// afkTimeoutFor is the timeout the AFK checker applies to a player: a forced
+// timeout when one is given, or else the room's threshold for the phase
+func (m *Manager) afkTimeoutFor(game *GameState, playerID uuid.UUID, forced time.Duration) time.Duration {
+	if forced > 0 {
+		return forced
+	}
+	return m.AFKTimeout(game, playerID)
+}
+
+// allHumansAFK reports whether every human in a game is AFK
+func (m *Manager) allHumansAFK(game *GameState, forced time.Duration) bool {
+	game.mu.RLock()
+	defer game.mu.RUnlock()
+
+	humans := 0
+	for playerID, player := range game.Players {
+		if player.IsBot {
+			continue
+		}
+		humans++
+		if !player.IsAFK(m.afkTimeoutFor(game, playerID, forced)) {
+			return false
+		}
+	}
+	return humans > 0
+}
+
+// checkAFKPlayers runs the AFK checks on every room: games in progress hand
+// AFK players to bots or end when everyone is gone, lobbies free the seats of
+// players who never came back
+func (m *Manager) checkAFKPlayers() {
+	for roomCode, game := range m.GetAllGames() {
+		game.mu.RLock()
+		status := game.Status
+		game.mu.RUnlock()
+
+		switch status {
+		case models.GameStatusInProgress:
+			// First, check if all human players are AFK and end game if so
+			gameEnded, err := m.CheckAndHandleAllAFK(roomCode, 0)
+			if err != nil {
+				logger.Error("Failed to check if all players are AFK",
+					"room_code", roomCode,
+					"error", err)
+			}
+
+			// If game wasn't ended, try to replace individual AFK players with bots
+			if !gameEnded {
+				if _, err := m.CheckAndReplaceAFKPlayers(roomCode, 0); err != nil {
+					logger.Error("Failed to check and replace AFK players",
+						"room_code", roomCode,
+						"error", err)
+				}
+			}
+		case models.GameStatusWaiting:
+			m.removeAFKLobbyPlayers(roomCode, game)
+		}
+	}
+}
+
+// removeAFKLobbyPlayers frees the seats of lobby players who disconnected and
+// stayed away past the lobby threshold
+func (m *Manager) removeAFKLobbyPlayers(roomCode string, game *GameState) {
+	game.mu.RLock()
+	var afk []uuid.UUID
+	for playerID, player := range game.Players {
+		if player.IsDisconnected() && player.IsAFK(m.AFKTimeout(game, playerID)) {
+			afk = append(afk, playerID)
+		}
+	}
+	game.mu.RUnlock()
+
+	for _, playerID := range afk {
+		if _, err := m.RemovePlayer(roomCode, playerID); err != nil {
+			logger.Error("Failed to remove AFK lobby player",
+				"room_code", roomCode,
+				"player_id", playerID,
+				"error", err)
+			continue
+		}
+		logger.Info("Removed AFK player from lobby", "room_code", roomCode, "player_id", playerID)
+	}
+}
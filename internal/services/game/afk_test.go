@@ -0,0 +1,53 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAFKTimeoutByPhase(t *testing.T) {
+	m := &Manager{afkThresholds: DefaultAFKThresholds()}
+	storyteller, voter := uuid.New(), uuid.New()
+	game := &GameState{Status: models.GameStatusWaiting, Settings: DefaultGameSettings()}
+
+	assert.Equal(t, 10*time.Minute, m.AFKTimeout(game, voter))
+
+	game.Status = models.GameStatusInProgress
+	game.CurrentRound = &Round{StorytellerID: storyteller, Status: models.RoundStatusStorytelling}
+	assert.Equal(t, 4*time.Minute, m.AFKTimeout(game, storyteller))
+	// Nothing is due from the others yet, so the pace's timeout applies
+	assert.Equal(t, 3*time.Minute, m.AFKTimeout(game, voter))
+
+	game.CurrentRound.Status = models.RoundStatusVoting
+	assert.Equal(t, 2*time.Minute, m.AFKTimeout(game, voter))
+	assert.Equal(t, 3*time.Minute, m.AFKTimeout(game, storyteller))
+}
+
+func TestAFKTimeoutScalesWithPaceAndRoomOverrides(t *testing.T) {
+	m := &Manager{afkThresholds: DefaultAFKThresholds()}
+	voter := uuid.New()
+	game := &GameState{
+		Status:       models.GameStatusInProgress,
+		Settings:     GameSettings{Pace: PaceBlitz, Timing: pacePresets[PaceBlitz]},
+		CurrentRound: &Round{StorytellerID: uuid.New(), Status: models.RoundStatusVoting},
+	}
+
+	// Blitz has a third of the standard AFK timeout
+	assert.Equal(t, 40*time.Second, m.AFKTimeout(game, voter))
+
+	// Room overrides are taken as they are
+	game.Settings.AFK = AFKThresholds{VotingSeconds: 90}
+	assert.Equal(t, 90*time.Second, m.AFKTimeout(game, voter))
+}
+
+func TestAFKThresholdsValidate(t *testing.T) {
+	assert.NoError(t, AFKThresholds{}.Validate())
+	assert.NoError(t, DefaultAFKThresholds().Validate())
+	assert.Error(t, AFKThresholds{VotingSeconds: 10}.Validate())
+	assert.Error(t, AFKThresholds{LobbySeconds: 7200}.Validate())
+}
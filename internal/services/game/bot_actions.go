@@ -13,6 +13,7 @@ import (
 // BotService defines bot-related operations
 type BotService interface {
 	AddBot(roomCode string, botLevel string) (*GameState, error)
+	MaxStartingBots(rules GameRules) int
 	ProcessBotActions(gameState *GameState)
 }
 
@@ -41,11 +42,8 @@ func (m *Manager) processBotStorytelling(game *GameState) {
 		return
 	}
 
-	// Bot storyteller submits clue and card
-	go func() {
-		// Add small delay for realism
-		time.Sleep(time.Duration(2+rand.Intn(3)) * time.Second)
-
+	// Bot storyteller submits clue and card, after a small delay for realism
+	m.schedule(game, "bot_storytelling", time.Duration(2+rand.Intn(3))*time.Second, func() {
 		botManager := bot.GetBotManager()
 		botPlayer := botManager.GetBot(storytellerID)
 		if botPlayer == nil {
@@ -62,13 +60,20 @@ func (m *Manager) processBotStorytelling(game *GameState) {
 			logger.Error("Bot failed to select storyteller card", "error", err, "bot_id", storytellerID)
 			return
 		}
+		m.botDecided(game, storytellerID, botPlayer.LastDecision)
+
+		// Follow this round's rule twist, if any
+		clue = adaptClueForModifier(game.CurrentRound.Modifier, clue, rand.New(rand.NewSource(time.Now().UnixNano())))
+		clue = m.fitClueToRules(game, clue, selectedCard)
 
 		// Submit clue and card
 		err = m.SubmitClue(game.RoomCode, storytellerID, clue, selectedCard)
 		if err != nil {
 			logger.Error("Bot failed to submit clue", "error", err, "bot_id", storytellerID)
+			return
 		}
-	}()
+		game.analytics.recordBotAction(BotActionClue)
+	})
 }
 
 // processBotSubmissions handles bot card submissions
@@ -78,14 +83,13 @@ func (m *Manager) processBotSubmissions(game *GameState) {
 		if !player.IsBot || playerID == game.CurrentRound.StorytellerID {
 			continue
 		}
-		if _, hasSubmitted := game.CurrentRound.Submissions[playerID]; hasSubmitted {
+		if game.CurrentRound.submitted(playerID) {
 			continue
 		}
 
-		go func(botID uuid.UUID, botPlayer *Player) {
-			// Add random delay for realism
-			time.Sleep(time.Duration(3+rand.Intn(5)) * time.Second)
-
+		botID, botPlayer := playerID, player
+		// Add random delay for realism
+		m.schedule(game, "bot_submission", time.Duration(3+rand.Intn(5))*time.Second, func() {
 			botManager := bot.GetBotManager()
 			bot := botManager.GetBot(botID)
 			if bot == nil {
@@ -93,22 +97,28 @@ func (m *Manager) processBotSubmissions(game *GameState) {
 				return
 			}
 
-			// Update bot's hand
-			bot.UpdateHand(botPlayer.Hand)
-
-			// Bot selects card for clue
-			selectedCard, err := bot.SelectCardForClue(game.CurrentRound.Clue)
-			if err != nil {
-				logger.Error("Bot failed to select card for clue", "error", err, "bot_id", botID)
-				return
+			// Large games may take more than one card from each player
+			for owed := game.CurrentRound.cardsOwed(botID); owed > 0; owed-- {
+				// Update bot's hand
+				bot.UpdateHand(append([]int(nil), botPlayer.Hand...))
+
+				// Bot selects card for clue
+				selectedCard, err := bot.SelectCardForClue(game.CurrentRound.Clue)
+				if err != nil {
+					logger.Error("Bot failed to select card for clue", "error", err, "bot_id", botID)
+					return
+				}
+				m.botDecided(game, botID, bot.LastDecision)
+
+				// Submit card
+				err = m.SubmitCard(game.RoomCode, botID, selectedCard)
+				if err != nil {
+					logger.Error("Bot failed to submit card", "error", err, "bot_id", botID)
+					return
+				}
 			}
-
-			// Submit card
-			err = m.SubmitCard(game.RoomCode, botID, selectedCard)
-			if err != nil {
-				logger.Error("Bot failed to submit card", "error", err, "bot_id", botID)
-			}
-		}(playerID, player)
+			game.analytics.recordBotAction(BotActionSubmit)
+		})
 	}
 }
 
@@ -123,10 +133,9 @@ func (m *Manager) processBotVoting(game *GameState) {
 			continue
 		}
 
-		go func(botID uuid.UUID, botPlayer *Player) {
-			// Add random delay for realism
-			time.Sleep(time.Duration(2+rand.Intn(4)) * time.Second)
-
+		botID := playerID
+		// Add random delay for realism
+		m.schedule(game, "bot_vote", time.Duration(2+rand.Intn(4))*time.Second, func() {
 			botManager := bot.GetBotManager()
 			bot := botManager.GetBot(botID)
 			if bot == nil {
@@ -134,11 +143,10 @@ func (m *Manager) processBotVoting(game *GameState) {
 				return
 			}
 
-			// Get submitted cards for voting
-			submittedCards := make([]int, 0, len(game.CurrentRound.RevealedCards))
-			for _, revealedCard := range game.CurrentRound.RevealedCards {
-				submittedCards = append(submittedCards, revealedCard.CardID)
-			}
+			// Get submitted cards for voting; the bot's own card is left out
+			// since its hand no longer holds it once submitted, and so is a
+			// storyteller teammate's card in team games
+			submittedCards := game.votableCards(botID)
 
 			// Bot votes for card
 			selectedCard, err := bot.VoteForCard(submittedCards, game.CurrentRound.Clue, game.CurrentRound.StorytellerCard)
@@ -146,12 +154,26 @@ func (m *Manager) processBotVoting(game *GameState) {
 				logger.Error("Bot failed to vote for card", "error", err, "bot_id", botID)
 				return
 			}
+			m.botDecided(game, botID, bot.LastDecision)
 
 			// Submit vote
 			err = m.SubmitVote(game.RoomCode, botID, selectedCard)
 			if err != nil {
 				logger.Error("Bot failed to submit vote", "error", err, "bot_id", botID)
+				return
 			}
-		}(playerID, player)
+			game.analytics.recordBotAction(BotActionVote)
+		})
+	}
+}
+
+// votableCards lists the revealed cards a player may vote for: every card but their own
+func votableCards(round *Round, playerID uuid.UUID) []int {
+	cards := make([]int, 0, len(round.RevealedCards))
+	for _, revealedCard := range round.RevealedCards {
+		if revealedCard.PlayerID != playerID {
+			cards = append(cards, revealedCard.CardID)
+		}
 	}
+	return cards
 }
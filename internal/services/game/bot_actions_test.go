@@ -0,0 +1,92 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+	"dixitme/internal/testutils/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// untaggedDeck gives the bots' card analysis a database without any tags
+func untaggedDeck(t *testing.T) {
+	t.Helper()
+	previous := database.GetDB()
+	database.SetDB(testdb.Open(t, &models.Card{}, &models.Tag{}, &models.CardTag{}))
+	t.Cleanup(func() { database.SetDB(previous) })
+}
+
+func TestBotsPlayTheirSeats(t *testing.T) {
+	untaggedDeck(t)
+	m := NewEphemeralManager()
+	scheduler := &heldScheduler{}
+	m.SetScheduler(scheduler)
+
+	host, bob := uuid.New(), uuid.New()
+	_, err := m.CreateGameWithOptions("BOTS", host, "Alice", CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+	_, err = m.JoinGame("BOTS", bob, "Bob")
+	require.NoError(t, err)
+	_, err = m.AddBot("BOTS", "easy")
+	require.NoError(t, err)
+	require.NoError(t, m.StartGame("BOTS", host))
+
+	// Bob's seat goes to a bot mid-game, so both ways of seating a bot play
+	_, err = m.ReplacePlayerWithBot("BOTS", bob, "disconnected")
+	require.NoError(t, err)
+
+	gs := m.GetGame("BOTS")
+	for steps := 0; ; steps++ {
+		require.Less(t, steps, 50, "the round stalled")
+		gs.RLock()
+		round := gs.CurrentRound
+		status, storyteller, roundNumber := round.Status, round.StorytellerID, round.RoundNumber
+		card, submitted, voted := gs.Players[host].Hand[0], round.submitted(host), round.Votes[host] != nil
+		var storytellerCard int
+		for _, submission := range round.Submissions {
+			if submission.PlayerID != host {
+				storytellerCard = submission.CardID
+			}
+		}
+		gs.RUnlock()
+		if roundNumber > 1 || status == models.RoundStatusScoring || status == models.RoundStatusCompleted {
+			break
+		}
+
+		switch {
+		case status == models.RoundStatusStorytelling && storyteller == host:
+			require.NoError(t, m.SubmitClue("BOTS", host, "a quiet storm", card))
+		case status == models.RoundStatusSubmitting && storyteller != host && !submitted:
+			require.NoError(t, m.SubmitCard("BOTS", host, card))
+		case status == models.RoundStatusVoting && storyteller != host && !voted:
+			require.NoError(t, m.SubmitVote("BOTS", host, storytellerCard))
+		default:
+			require.NotEmpty(t, scheduler.events, "the bots have nothing scheduled in %s", status)
+			events := scheduler.events
+			scheduler.events = nil
+			for _, event := range events {
+				event()
+			}
+		}
+	}
+
+	// Every bot told the story or submitted a card, and voted unless telling it
+	gs.RLock()
+	defer gs.RUnlock()
+	bots := 0
+	for _, player := range gs.Players {
+		if !player.IsBot {
+			continue
+		}
+		bots++
+		assert.True(t, gs.CurrentRound.submitted(player.ID), "%s never put a card in", player.Name)
+		if player.ID != gs.CurrentRound.StorytellerID {
+			assert.NotNil(t, gs.CurrentRound.Votes[player.ID], "%s never voted", player.Name)
+		}
+	}
+	assert.Equal(t, 2, bots)
+}
@@ -0,0 +1,226 @@
+package game
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Moments a bot may react to in chat
+const (
+	BotChatFooled   = "fooled"    // The bot voted for a decoy
+	BotChatRoundWon = "round_won" // The bot scored the most points in the round
+)
+
+const (
+	// botChatChance is how often a bot speaks up when it has something to react to
+	botChatChance = 0.4
+	// botChatCooldown keeps a bot from chatting more than once in this long
+	botChatCooldown = 90 * time.Second
+	// botChatTimeout bounds a generator call, so a slow model is skipped rather than late
+	botChatTimeout = 3 * time.Second
+)
+
+// BotChatContext is what a generator knows about the moment a bot reacts to
+type BotChatContext struct {
+	Moment   string // BotChatFooled or BotChatRoundWon
+	BotName  string
+	Level    string // easy, medium or hard
+	Clue     string // The round's clue
+	Language string // Declared room language, empty for any
+}
+
+// BotChatLine is a bot's chat message or emote
+type BotChatLine struct {
+	Message     string
+	MessageType string // chat or emote
+}
+
+// BotChatGenerator writes bot chat lines. Generators backed by a language
+// model should return an error rather than block; the canned lines are used
+// instead.
+type BotChatGenerator interface {
+	Generate(ctx context.Context, moment BotChatContext) (BotChatLine, error)
+}
+
+// SetBotChatGenerator replaces the canned bot chat lines, e.g. with a language model
+func (m *Manager) SetBotChatGenerator(generator BotChatGenerator) {
+	m.mu.Lock()
+	m.botChat = generator
+	m.mu.Unlock()
+}
+
+// cannedBotChat picks from a fixed set of lines
+type cannedBotChat struct{}
+
+var cannedBotLines = map[string][]BotChatLine{
+	BotChatFooled: {
+		{Message: "Well, that one got me.", MessageType: "chat"},
+		{Message: "I was so sure about that card!", MessageType: "chat"},
+		{Message: "Nicely played, I fell for it.", MessageType: "chat"},
+		{Message: "Hmm, I need to recalibrate.", MessageType: "chat"},
+		{Message: "🤦", MessageType: "emote"},
+		{Message: "😅", MessageType: "emote"},
+	},
+	BotChatRoundWon: {
+		{Message: "That round went my way!", MessageType: "chat"},
+		{Message: "Beep boop, points acquired.", MessageType: "chat"},
+		{Message: "Good round, everyone.", MessageType: "chat"},
+		{Message: "🎉", MessageType: "emote"},
+		{Message: "😎", MessageType: "emote"},
+	},
+}
+
+func (cannedBotChat) Generate(_ context.Context, moment BotChatContext) (BotChatLine, error) {
+	lines := cannedBotLines[moment.Moment]
+	return lines[rand.Intn(len(lines))], nil
+}
+
+// botChatHook lets bots react in chat once a round is scored
+type botChatHook struct {
+	NopLifecycleHook
+	manager *Manager
+}
+
+func (h botChatHook) OnRoundCompleted(game *GameState, round *Round, points map[uuid.UUID]int) {
+	if game.Settings.SilentBots {
+		return
+	}
+
+	reactions := botReactions(game, round, points)
+	if len(reactions) == 0 || rand.Float64() >= botChatChance {
+		return
+	}
+
+	// One bot speaks per round, after a moment, as a person would
+	botID := pickReaction(reactions)
+	moment := BotChatContext{
+		Moment:   reactions[botID],
+		BotName:  game.Players[botID].Name,
+		Level:    game.Players[botID].BotLevel,
+		Clue:     round.Clue,
+		Language: game.Settings.Language,
+	}
+	roomCode := game.RoomCode
+	h.manager.after(time.Duration(1500+rand.Intn(2000))*time.Millisecond, func() {
+		h.manager.sendBotChat(roomCode, botID, moment)
+	})
+}
+
+// botReactions finds the bots with something to react to in a scored round,
+// and what. Winning the round takes precedence over being fooled.
+func botReactions(game *GameState, round *Round, points map[uuid.UUID]int) map[uuid.UUID]string {
+	reactions := make(map[uuid.UUID]string)
+	for playerID, vote := range round.Votes {
+		if player, exists := game.Players[playerID]; exists && player.IsBot && vote.CardID != round.StorytellerCard {
+			reactions[playerID] = BotChatFooled
+		}
+	}
+
+	best, leaders := 0, []uuid.UUID{}
+	for playerID, earned := range points {
+		switch {
+		case earned > best:
+			best, leaders = earned, []uuid.UUID{playerID}
+		case earned == best && earned > 0:
+			leaders = append(leaders, playerID)
+		}
+	}
+	if len(leaders) == 1 {
+		if player, exists := game.Players[leaders[0]]; exists && player.IsBot {
+			reactions[leaders[0]] = BotChatRoundWon
+		}
+	}
+	return reactions
+}
+
+// pickReaction chooses one of the reacting bots at random
+func pickReaction(reactions map[uuid.UUID]string) uuid.UUID {
+	candidates := make([]uuid.UUID, 0, len(reactions))
+	for botID := range reactions {
+		candidates = append(candidates, botID)
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// sendBotChat generates a bot's line and posts it, subject to the room's chat
+// controls and the bot's cooldown
+func (m *Manager) sendBotChat(roomCode string, botID uuid.UUID, moment BotChatContext) {
+	m.mu.RLock()
+	generator := m.botChat
+	m.mu.RUnlock()
+
+	line, err := cannedBotChat{}.Generate(context.Background(), moment)
+	if generator != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), botChatTimeout)
+		generated, genErr := generator.Generate(ctx, moment)
+		cancel()
+		if genErr != nil {
+			logger.Debug("Bot chat generator failed, using a canned line", "error", genErr, "room_code", roomCode)
+		} else if generated.Message != "" && len(generated.Message) <= 200 {
+			line, err = generated, nil
+		}
+	}
+	if err != nil {
+		return
+	}
+	if line.MessageType != "emote" {
+		line.MessageType = "chat"
+	}
+
+	game := m.getGame(roomCode)
+	if game == nil {
+		return
+	}
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	bot, exists := game.Players[botID]
+	if !exists || !bot.IsBot || game.Settings.SilentBots {
+		return
+	}
+	now := time.Now()
+	if !bot.lastChatAt.IsZero() && now.Sub(bot.lastChatAt) < botChatCooldown {
+		return
+	}
+	if err := game.Settings.Chat.check(line.MessageType, bot.lastChatAt, now); err != nil {
+		return
+	}
+
+	phase := "lobby"
+	if game.Status == models.GameStatusInProgress && game.CurrentRound != nil {
+		phase = string(game.CurrentRound.Status)
+	}
+
+	chatMessage := models.ChatMessage{
+		ID:          uuid.New(),
+		GameID:      game.ID,
+		PlayerID:    &botID,
+		Message:     line.Message,
+		MessageType: line.MessageType,
+		Phase:       phase,
+		IsVisible:   true,
+		CreatedAt:   now,
+	}
+	if err := m.repository(game).PersistChatMessage(context.Background(), &chatMessage); err != nil {
+		logger.Error("Failed to persist bot chat message", "error", err, "room_code", roomCode)
+		return
+	}
+	bot.lastChatAt = now
+
+	m.BroadcastToGame(game, MessageTypeChatMessage, ChatMessagePayload{
+		ID:          chatMessage.ID,
+		PlayerID:    &botID,
+		PlayerName:  bot.Name,
+		Message:     chatMessage.Message,
+		MessageType: chatMessage.MessageType,
+		Phase:       chatMessage.Phase,
+		IsBot:       true,
+		Timestamp:   chatMessage.CreatedAt,
+	})
+}
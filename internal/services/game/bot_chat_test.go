@@ -0,0 +1,46 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBotReactions(t *testing.T) {
+	storyteller, human, fooled, winner := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	game := &GameState{Players: map[uuid.UUID]*Player{
+		storyteller: {ID: storyteller},
+		human:       {ID: human},
+		fooled:      {ID: fooled, IsBot: true},
+		winner:      {ID: winner, IsBot: true},
+	}}
+	round := &Round{
+		StorytellerCard: 7,
+		Votes: map[uuid.UUID]*Vote{
+			human:  {PlayerID: human, CardID: 12},
+			fooled: {PlayerID: fooled, CardID: 12},
+			winner: {PlayerID: winner, CardID: 12},
+		},
+	}
+	points := map[uuid.UUID]int{storyteller: 0, human: 3, fooled: 0, winner: 4}
+
+	reactions := botReactions(game, round, points)
+	assert.Equal(t, map[uuid.UUID]string{fooled: BotChatFooled, winner: BotChatRoundWon}, reactions)
+
+	// A shared lead is nobody's round
+	points[human] = 4
+	reactions = botReactions(game, round, points)
+	assert.Equal(t, BotChatFooled, reactions[winner])
+}
+
+func TestCannedBotChatCoversEveryMoment(t *testing.T) {
+	for _, moment := range []string{BotChatFooled, BotChatRoundWon} {
+		line, err := cannedBotChat{}.Generate(context.Background(), BotChatContext{Moment: moment})
+		require.NoError(t, err)
+		assert.NotEmpty(t, line.Message)
+		assert.Contains(t, []string{"chat", "emote"}, line.MessageType)
+	}
+}
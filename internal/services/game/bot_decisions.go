@@ -0,0 +1,137 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/bot"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// botDecisionCandidates is how many of the best scored cards are stored per decision
+const botDecisionCandidates = 3
+
+var (
+	ErrBotDecisionsNotFound = errors.New("bot decisions not found")
+	ErrGameNotOver          = errors.New("game is not over yet")
+)
+
+// BotDecisionLog is every recorded bot decision of a finished game
+type BotDecisionLog struct {
+	GameID    uuid.UUID           `json:"game_id"`
+	RoomCode  string              `json:"room_code"`
+	Decisions []BotDecisionRecord `json:"decisions"`
+}
+
+// BotDecisionRecord is one bot decision with the candidates it weighed
+type BotDecisionRecord struct {
+	models.BotDecision
+	Candidates []bot.CardScore `json:"candidates"` // Best first
+}
+
+// SetBotDecisionLog turns storing bot decisions on or off
+func (m *Manager) SetBotDecisionLog(enabled bool) {
+	m.mu.Lock()
+	m.logBotDecisions = enabled
+	m.mu.Unlock()
+}
+
+// botDecided reports a bot's choice to the debug stream and, when enabled,
+// stores it for review after the game. It runs in the bot's scheduled
+// action, outside the game lock.
+func (m *Manager) botDecided(game *GameState, botID uuid.UUID, decision *bot.Decision) {
+	if decision == nil {
+		return
+	}
+	m.debugEvent(game, DebugEventBotDecision, map[string]interface{}{
+		"bot_id":   botID,
+		"decision": decision,
+	})
+
+	m.mu.RLock()
+	enabled := m.logBotDecisions
+	m.mu.RUnlock()
+	if !enabled {
+		return
+	}
+
+	candidates, err := json.Marshal(decision.Top(botDecisionCandidates))
+	if err != nil {
+		logger.Error("Failed to encode bot decision", "error", err, "bot_id", botID)
+		return
+	}
+
+	game.mu.RLock()
+	record := &models.BotDecision{
+		ID:          uuid.New(),
+		GameID:      game.ID,
+		RoundNumber: game.RoundNumber,
+		BotID:       botID,
+		Kind:        decision.Kind,
+		Difficulty:  string(decision.Difficulty),
+		Clue:        decision.Clue,
+		CardID:      decision.CardID,
+		Rationale:   decision.Rationale(),
+		Candidates:  string(candidates),
+		CreatedAt:   time.Now(),
+	}
+	repository := m.repository(game)
+	game.mu.RUnlock()
+
+	if err := repository.PersistBotDecision(context.Background(), record); err != nil {
+		logger.Error("Failed to persist bot decision", "error", err, "bot_id", botID, "room_code", game.RoomCode)
+	}
+}
+
+// PersistBotDecision saves a bot decision
+func (m *Manager) PersistBotDecision(ctx context.Context, decision *models.BotDecision) error {
+	if err := m.db.WithContext(ctx).Create(decision).Error; err != nil {
+		return fmt.Errorf("failed to persist bot decision: %w", err)
+	}
+	return nil
+}
+
+// GetBotDecisions returns the recorded bot decisions of the last game in a
+// room, once it is over. Decisions are only recorded while logging is on.
+func (m *Manager) GetBotDecisions(ctx context.Context, roomCode string) (*BotDecisionLog, error) {
+	db := m.db.WithContext(ctx)
+
+	var record models.Game
+	if err := db.Unscoped().Where("room_code = ?", roomCode).Order("created_at DESC").First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBotDecisionsNotFound
+		}
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+	if record.Status != models.GameStatusCompleted && record.Status != models.GameStatusAbandoned {
+		return nil, ErrGameNotOver
+	}
+
+	var rows []models.BotDecision
+	if err := db.Where("game_id = ?", record.ID).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load bot decisions: %w", err)
+	}
+
+	log := &BotDecisionLog{
+		GameID:    record.ID,
+		RoomCode:  record.RoomCode,
+		Decisions: make([]BotDecisionRecord, 0, len(rows)),
+	}
+	for _, row := range rows {
+		decision := BotDecisionRecord{BotDecision: row, Candidates: []bot.CardScore{}}
+		if row.Candidates != "" {
+			if err := json.Unmarshal([]byte(row.Candidates), &decision.Candidates); err != nil {
+				logger.Warn("Ignoring malformed bot decision candidates", "decision_id", row.ID, "error", err)
+			}
+		}
+		log.Decisions = append(log.Decisions, decision)
+	}
+	return log, nil
+}
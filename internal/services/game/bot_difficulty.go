@@ -0,0 +1,57 @@
+package game
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/bot"
+
+	"github.com/google/uuid"
+)
+
+// pickBotLevel chooses the difficulty of a bot the server seats itself, from
+// the admins' difficulty policy. Callers hold the game lock.
+func (m *Manager) pickBotLevel(game *GameState) string {
+	policy := bot.CurrentPolicy()
+
+	averageRating := models.DefaultRating
+	if policy.Mode == bot.PolicyMirror {
+		averageRating = m.averageHumanRating(game)
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return string(policy.Pick(len(game.Players), averageRating, rng))
+}
+
+// averageHumanRating is the mean rating of the room's humans. Players without
+// a rating count as the default rating.
+func (m *Manager) averageHumanRating(game *GameState) int {
+	var humans []uuid.UUID
+	for playerID, player := range game.Players {
+		if !player.IsBot {
+			humans = append(humans, playerID)
+		}
+	}
+	if len(humans) == 0 {
+		return models.DefaultRating
+	}
+
+	ratings, err := m.repository(game).GetPlayerRatings(context.Background(), humans)
+	if err != nil {
+		logger.Error("Failed to load ratings for the bot difficulty policy", "error", err, "room_code", game.RoomCode)
+		return models.DefaultRating
+	}
+
+	total := 0
+	for _, playerID := range humans {
+		rating, exists := ratings[playerID]
+		if !exists {
+			rating = models.DefaultRating
+		}
+		total += rating
+	}
+	return total / len(humans)
+}
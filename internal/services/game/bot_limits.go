@@ -0,0 +1,119 @@
+package game
+
+import (
+	"fmt"
+)
+
+// maxPlayersPerRoom is the standard number of seats in a room, and the most a
+// ranked room may have
+const maxPlayersPerRoom = 6
+
+// BotLimits caps bots per room so games can't be farmed against fields of bots
+type BotLimits struct {
+	MaxBots   int // Most bots a room may hold
+	MinHumans int // Humans needed to start; bots can't take the seats they need
+}
+
+// DefaultBotLimits allows every seat but one to be a bot
+func DefaultBotLimits() BotLimits {
+	return BotLimits{
+		MaxBots:   maxPlayersPerRoom - 1,
+		MinHumans: 1,
+	}
+}
+
+// SetBotLimits replaces the deployment-wide bot limits
+func (m *Manager) SetBotLimits(limits BotLimits) {
+	if limits.MinHumans < 1 {
+		limits.MinHumans = 1
+	}
+	if limits.MaxBots < 0 || limits.MaxBots > maxCasualPlayers-limits.MinHumans {
+		limits.MaxBots = maxCasualPlayers - limits.MinHumans
+	}
+
+	m.mu.Lock()
+	m.botLimits = limits
+	m.mu.Unlock()
+}
+
+// MaxStartingBots is the most bots a room with these rules can be created
+// with: every seat not kept for the humans it needs, within the bot cap
+func (m *Manager) MaxStartingBots(rules GameRules) int {
+	m.mu.RLock()
+	limits := m.botLimits
+	m.mu.RUnlock()
+
+	return max(0, min(limits.MaxBots, rules.PlayerLimit()-limits.MinHumans))
+}
+
+// roomBotCap is the bot cap for a room: the room's own cap when it is stricter
+func (l BotLimits) roomBotCap(settings GameSettings) int {
+	if settings.MaxBots > 0 && settings.MaxBots < l.MaxBots {
+		return settings.MaxBots
+	}
+	return l.MaxBots
+}
+
+// seatCounts returns the bots and seated humans in a game. Replaced humans have
+// handed their seat to a bot and no longer count.
+func seatCounts(game *GameState) (bots, humans int) {
+	for _, player := range game.Players {
+		switch {
+		case player.IsBot:
+			bots++
+		case !player.WasReplaced:
+			humans++
+		}
+	}
+	return bots, humans
+}
+
+// checkCanAddBot enforces the bot cap and keeps seats free for the humans still needed
+func (l BotLimits) checkCanAddBot(game *GameState) error {
+	bots, humans := seatCounts(game)
+
+	if limit := l.roomBotCap(game.Settings); bots+1 > limit {
+		return &GameError{
+			Code:    ErrCodeBotLimit,
+			Message: fmt.Sprintf("this room allows at most %d bots", limit),
+			Details: map[string]interface{}{"max_bots": limit, "bots": bots},
+		}
+	}
+
+	humansNeeded := max(0, l.MinHumans-humans)
+	if bots+humans+1+humansNeeded > game.Settings.Rules.PlayerLimit() {
+		return &GameError{
+			Code:    ErrCodeBotLimit,
+			Message: fmt.Sprintf("the remaining seats are reserved for humans (at least %d needed)", l.MinHumans),
+			Details: map[string]interface{}{"min_humans": l.MinHumans, "humans": humans},
+		}
+	}
+
+	return nil
+}
+
+// checkCanReplaceWithBot enforces the bot cap when an AFK human is swapped for a bot.
+// The human majority isn't checked: refusing would stall a game already underway.
+func (l BotLimits) checkCanReplaceWithBot(game *GameState) error {
+	bots, _ := seatCounts(game)
+	if limit := l.roomBotCap(game.Settings); bots+1 > limit {
+		return &GameError{
+			Code:    ErrCodeBotLimit,
+			Message: fmt.Sprintf("this room allows at most %d bots", limit),
+			Details: map[string]interface{}{"max_bots": limit, "bots": bots},
+		}
+	}
+	return nil
+}
+
+// checkEnoughHumans makes sure a game has the humans it needs to start
+func (l BotLimits) checkEnoughHumans(game *GameState) error {
+	if _, humans := seatCounts(game); humans < l.MinHumans {
+		return &GameError{
+			Code:    ErrCodeNotEnoughHumans,
+			Message: fmt.Sprintf("need at least %d human players to start", l.MinHumans),
+			Details: map[string]interface{}{"min_humans": l.MinHumans, "humans": humans},
+		}
+	}
+	return nil
+}
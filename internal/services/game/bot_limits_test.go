@@ -0,0 +1,81 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func roomWith(humans, bots int, settings GameSettings) *GameState {
+	gs := &GameState{Players: make(map[uuid.UUID]*Player), Settings: settings}
+	for i := 0; i < humans; i++ {
+		id := uuid.New()
+		gs.Players[id] = &Player{ID: id}
+	}
+	for i := 0; i < bots; i++ {
+		id := uuid.New()
+		gs.Players[id] = &Player{ID: id, IsBot: true}
+	}
+	return gs
+}
+
+func TestCheckCanAddBot(t *testing.T) {
+	limits := BotLimits{MaxBots: 3, MinHumans: 2}
+
+	assert.NoError(t, limits.checkCanAddBot(roomWith(1, 0, GameSettings{})))
+	assert.NoError(t, limits.checkCanAddBot(roomWith(2, 2, GameSettings{})))
+
+	// Server cap
+	err := limits.checkCanAddBot(roomWith(2, 3, GameSettings{}))
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeBotLimit}))
+
+	// Stricter room cap
+	err = limits.checkCanAddBot(roomWith(2, 1, GameSettings{MaxBots: 1}))
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeBotLimit}))
+
+	// A looser room cap doesn't override the server's
+	err = limits.checkCanAddBot(roomWith(2, 3, GameSettings{MaxBots: 5}))
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeBotLimit}))
+}
+
+func TestCheckCanAddBotReservesSeatsForHumans(t *testing.T) {
+	limits := BotLimits{MaxBots: 5, MinHumans: 2}
+
+	// One human and four bots would leave a single seat, but a second human is still needed
+	assert.NoError(t, limits.checkCanAddBot(roomWith(1, 3, GameSettings{})))
+	err := limits.checkCanAddBot(roomWith(1, 4, GameSettings{}))
+	gameErr, ok := AsGameError(err)
+	assert.True(t, ok)
+	assert.Equal(t, ErrCodeBotLimit, gameErr.Code)
+	assert.Equal(t, 2, gameErr.Details["min_humans"])
+}
+
+func TestCheckEnoughHumans(t *testing.T) {
+	limits := BotLimits{MaxBots: 4, MinHumans: 2}
+
+	assert.NoError(t, limits.checkEnoughHumans(roomWith(2, 1, GameSettings{})))
+
+	err := limits.checkEnoughHumans(roomWith(1, 2, GameSettings{}))
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeNotEnoughHumans}))
+
+	// Replaced humans don't count
+	gs := roomWith(2, 1, GameSettings{})
+	for _, player := range gs.Players {
+		if !player.IsBot {
+			player.WasReplaced = true
+			break
+		}
+	}
+	assert.Error(t, limits.checkEnoughHumans(gs))
+}
+
+func TestSetBotLimitsClampsToSeats(t *testing.T) {
+	m := &Manager{}
+	m.SetBotLimits(BotLimits{MaxBots: 10, MinHumans: 2})
+	assert.Equal(t, BotLimits{MaxBots: maxCasualPlayers - 2, MinHumans: 2}, m.botLimits)
+
+	m.SetBotLimits(BotLimits{MaxBots: 3, MinHumans: 0})
+	assert.Equal(t, BotLimits{MaxBots: 3, MinHumans: 1}, m.botLimits)
+}
@@ -0,0 +1,250 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dixitme/internal/events"
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+	"dixitme/internal/services/namepolicy"
+
+	"github.com/google/uuid"
+)
+
+// TakeoverRequest is a human asking the host for a bot's seat in a running
+// game: someone watching, or a player whose own seat went to a bot
+type TakeoverRequest struct {
+	BotID       uuid.UUID `json:"bot_id"`
+	PlayerID    uuid.UUID `json:"player_id"`
+	PlayerName  string    `json:"player_name"`
+	Returning   bool      `json:"returning"` // The player had a seat in this game before
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// TakeoverResolvedPayload tells the room and the requester how the host
+// answered a takeover request. Player is the seat's new occupant when approved.
+type TakeoverResolvedPayload struct {
+	BotID    uuid.UUID `json:"bot_id"`
+	PlayerID uuid.UUID `json:"player_id"`
+	Approved bool      `json:"approved"`
+	Player   *Player   `json:"player,omitempty"`
+}
+
+// RequestBotTakeover asks the host to hand a bot's seat to a human. Players
+// still in their own seat can't ask; a player a bot replaced can.
+func (m *Manager) RequestBotTakeover(roomCode string, playerID uuid.UUID, playerName string, botID uuid.UUID) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+	if playerID == models.SystemPlayerID {
+		return fmt.Errorf("player ID is reserved")
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if game.Status != models.GameStatusInProgress {
+		return fmt.Errorf("seats can only be taken over during a game")
+	}
+	seat, exists := game.Players[botID]
+	if !exists || !seat.IsBot || seat.WasReplaced {
+		return fmt.Errorf("bot not found in game")
+	}
+	if pending, exists := game.TakeoverRequests[botID]; exists {
+		if pending.PlayerID == playerID {
+			return nil // Asked already
+		}
+		return fmt.Errorf("someone already asked for this seat")
+	}
+	for _, pending := range game.TakeoverRequests {
+		if pending.PlayerID == playerID {
+			return fmt.Errorf("you already asked for another seat")
+		}
+	}
+
+	request := &TakeoverRequest{BotID: botID, PlayerID: playerID, RequestedAt: time.Now()}
+	if player, exists := game.Players[playerID]; exists {
+		if !player.WasReplaced {
+			return fmt.Errorf("already playing in this game")
+		}
+		request.PlayerName, request.Returning = player.Name, true
+	} else {
+		if err := m.checkRankedStanding(game, playerID); err != nil {
+			return err
+		}
+		name, err := namepolicy.Check(playerName)
+		if err != nil {
+			return err
+		}
+		request.PlayerName = name
+	}
+
+	if game.TakeoverRequests == nil {
+		game.TakeoverRequests = make(map[uuid.UUID]*TakeoverRequest)
+	}
+	game.TakeoverRequests[botID] = request
+	m.cacheGameState(game)
+
+	metrics.GetCounter(metrics.Name("bot_takeovers_total", "status", "requested")).Inc()
+	m.BroadcastToGame(game, MessageTypeTakeoverRequested, request)
+	m.SendSystemMessage(roomCode, i18n.Msg("{1} asks to take over {2}'s seat", request.PlayerName, seat.Name))
+	return nil
+}
+
+// ResolveBotTakeover answers the takeover request for a bot's seat on the
+// host's behalf. Approving it seats the requester in the bot's place.
+func (m *Manager) ResolveBotTakeover(roomCode string, hostID, botID uuid.UUID, approve bool) (*GameState, error) {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return nil, fmt.Errorf("game not found")
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if err := requireHost(game, hostID, "approve seat takeovers"); err != nil {
+		return nil, err
+	}
+	request, exists := game.TakeoverRequests[botID]
+	if !exists {
+		return nil, fmt.Errorf("no takeover request for this seat")
+	}
+	delete(game.TakeoverRequests, botID)
+
+	if !approve {
+		m.cacheGameState(game)
+		metrics.GetCounter(metrics.Name("bot_takeovers_total", "status", "declined")).Inc()
+		m.sendTakeoverResolved(game, TakeoverResolvedPayload{BotID: botID, PlayerID: request.PlayerID})
+		return game, nil
+	}
+
+	player, err := m.takeOverBotSeat(game, request)
+	if err != nil {
+		return nil, err
+	}
+	metrics.GetCounter(metrics.Name("bot_takeovers_total", "status", "approved")).Inc()
+	m.sendTakeoverResolved(game, TakeoverResolvedPayload{BotID: botID, PlayerID: player.ID, Approved: true, Player: player})
+	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
+	return game, nil
+}
+
+// takeOverBotSeat seats a human in a bot's place, the inverse of
+// replacePlayerWithBot: the human inherits the bot's score, seat, token and
+// hand, along with any move the bot made this round, and the bot retires.
+// Callers hold the game lock.
+func (m *Manager) takeOverBotSeat(game *GameState, request *TakeoverRequest) (*Player, error) {
+	seat, exists := game.Players[request.BotID]
+	if !exists || !seat.IsBot || seat.WasReplaced {
+		return nil, fmt.Errorf("bot not found in game")
+	}
+
+	player, returning := game.Players[request.PlayerID]
+	if returning && !player.WasReplaced {
+		return nil, fmt.Errorf("already playing in this game")
+	}
+	if !returning {
+		player = &Player{ID: request.PlayerID, Name: request.PlayerName}
+		if err := m.repository(game).PersistPlayer(context.Background(), &models.Player{
+			ID:       player.ID,
+			Name:     player.Name,
+			Type:     models.PlayerTypeHuman,
+			AuthType: models.AuthTypeGuest,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to persist player: %w", err)
+		}
+	}
+
+	previous := *player
+	player.Score = seat.Score
+	player.Position = seat.Position
+	player.Token = seat.Token
+	player.Team = seat.Team
+	player.Hand = seat.Hand
+	player.WasReplaced = false
+	player.ReplacementID = nil
+	player.IsActive = true
+	player.LastActivity = time.Now()
+	player.Connection = GetPlayerConnection(player.ID)
+	player.IsConnected = player.Connection != nil
+
+	repository := m.repository(game)
+	if returning {
+		// The seat's row from before the bot took it over is out of date
+		if err := repository.RemoveGamePlayer(context.Background(), game.ID, player.ID); err != nil {
+			*player = previous
+			return nil, fmt.Errorf("failed to persist player: %w", err)
+		}
+	}
+	if err := repository.PersistGamePlayer(context.Background(), game.ID, player); err != nil {
+		*player = previous
+		return nil, fmt.Errorf("failed to persist player: %w", err)
+	}
+
+	game.Players[player.ID] = player
+	seat.Hand = make([]int, 0)
+	seat.WasReplaced = true
+	seat.ReplacementID = &player.ID
+	seat.IsActive = false
+	seat.IsConnected = false
+	game.replaceInRotation(seat.ID, player.ID)
+	if game.CurrentRound != nil {
+		game.CurrentRound.reassign(seat.ID, player.ID)
+	}
+	game.LastActivity = time.Now()
+	m.cacheGameState(game)
+
+	events.Publish(m.bus, TopicSeatTakenOver, SeatTakenOver{Game: game, BotID: seat.ID, Player: player})
+	m.SendSystemMessage(game.RoomCode, i18n.Msg("{1} took over {2}'s seat", player.Name, seat.Name))
+	logger.Info("Bot seat taken over",
+		"room_code", game.RoomCode,
+		"bot_id", seat.ID,
+		"player_id", player.ID,
+		"returning", returning)
+	return player, nil
+}
+
+// sendTakeoverResolved tells the room how a takeover request went, and the
+// requester too while they aren't seated
+func (m *Manager) sendTakeoverResolved(game *GameState, payload TakeoverResolvedPayload) {
+	m.BroadcastToGame(game, MessageTypeTakeoverResolved, payload)
+	if player, seated := game.Players[payload.PlayerID]; seated && !player.WasReplaced {
+		return // The broadcast reached them
+	}
+	if conn := GetPlayerConnection(payload.PlayerID); conn != nil {
+		if err := conn.SendJSON(GameMessage{Type: MessageTypeTakeoverResolved, Payload: payload}); err != nil {
+			logger.Debug("Failed to tell requester about takeover", "error", err, "player_id", payload.PlayerID)
+		}
+	}
+}
+
+// reassign hands a seat's moves in this round to the seat's new occupant
+func (r *Round) reassign(fromID, toID uuid.UUID) {
+	if r.StorytellerID == fromID {
+		r.StorytellerID = toID
+	}
+	if submission, exists := r.Submissions[fromID]; exists {
+		delete(r.Submissions, fromID)
+		submission.PlayerID = toID
+		r.Submissions[toID] = submission
+	}
+	if vote, exists := r.Votes[fromID]; exists {
+		delete(r.Votes, fromID)
+		vote.PlayerID = toID
+		r.Votes[toID] = vote
+	}
+	for i := range r.RevealedCards {
+		if r.RevealedCards[i].PlayerID == fromID {
+			r.RevealedCards[i].PlayerID = toID
+		}
+	}
+	for i, playerID := range r.TimedOut {
+		if playerID == fromID {
+			r.TimedOut[i] = toID
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// takeoverGame seats Alice (the host) and Bob, with a bot in the third seat
+// that has already played a card this round
+func takeoverGame(t *testing.T) (*Manager, *GameState, uuid.UUID) {
+	t.Helper()
+	m, _, ids := kickVoteGame(t, "Alice", "Bob")
+	gs := m.games["KICK"]
+	gs.HostID = ids[0]
+
+	botID := uuid.New()
+	gs.Players[botID] = &Player{ID: botID, Name: "Bot Ada", IsBot: true, Position: 3, Score: 7, Hand: []int{4, 5, 6}, IsActive: true}
+	gs.StorytellerOrder = []uuid.UUID{ids[0], ids[1], botID}
+	gs.CurrentRound = &Round{
+		StorytellerID: ids[0],
+		Status:        models.RoundStatusSubmitting,
+		Submissions:   map[uuid.UUID]*CardSubmission{botID: {PlayerID: botID, CardID: 3}},
+		Votes:         map[uuid.UUID]*Vote{},
+	}
+	return m, gs, botID
+}
+
+func TestBotTakeoverSeatsNewPlayer(t *testing.T) {
+	m, gs, botID := takeoverGame(t)
+	carol := uuid.New()
+
+	require.NoError(t, m.RequestBotTakeover("KICK", carol, "Carol", botID))
+	assert.EqualError(t, m.RequestBotTakeover("KICK", uuid.New(), "Dave", botID), "someone already asked for this seat")
+	_, err := m.ResolveBotTakeover("KICK", carol, botID, true)
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeNotHost}))
+
+	_, err = m.ResolveBotTakeover("KICK", gs.HostID, botID, true)
+	require.NoError(t, err)
+	assert.Empty(t, gs.TakeoverRequests)
+
+	player := gs.Players[carol]
+	require.NotNil(t, player)
+	assert.Equal(t, 7, player.Score)
+	assert.Equal(t, 3, player.Position)
+	assert.Equal(t, []int{4, 5, 6}, player.Hand)
+	assert.Equal(t, carol, gs.StorytellerOrder[2])
+	require.Contains(t, gs.CurrentRound.Submissions, carol, "the bot's card this round counts as theirs")
+	assert.Equal(t, carol, gs.CurrentRound.Submissions[carol].PlayerID)
+
+	bot := gs.Players[botID]
+	assert.True(t, bot.WasReplaced)
+	assert.Equal(t, &carol, bot.ReplacementID)
+	assert.Empty(t, bot.Hand)
+	assert.EqualError(t, m.SubmitCard("KICK", botID, 4), "player not in game")
+}
+
+func TestBotTakeoverReturnsReplacedPlayer(t *testing.T) {
+	m, gs, botID := takeoverGame(t)
+	bobID := gs.StorytellerOrder[1]
+	bob := gs.Players[bobID]
+	bob.WasReplaced, bob.IsActive, bob.Score = true, false, 2
+
+	assert.EqualError(t, m.RequestBotTakeover("KICK", gs.HostID, "", botID), "already playing in this game")
+	require.NoError(t, m.RequestBotTakeover("KICK", bobID, "", botID))
+	assert.True(t, gs.TakeoverRequests[botID].Returning)
+	assert.Equal(t, "Bob", gs.TakeoverRequests[botID].PlayerName)
+
+	_, err := m.ResolveBotTakeover("KICK", gs.HostID, botID, true)
+	require.NoError(t, err)
+	assert.False(t, bob.WasReplaced)
+	assert.Nil(t, bob.ReplacementID)
+	assert.Equal(t, 7, bob.Score, "the seat's score comes with it")
+}
+
+func TestBotTakeoverDeclined(t *testing.T) {
+	m, gs, botID := takeoverGame(t)
+	carol := uuid.New()
+
+	require.NoError(t, m.RequestBotTakeover("KICK", carol, "Carol", botID))
+	_, err := m.ResolveBotTakeover("KICK", gs.HostID, botID, false)
+	require.NoError(t, err)
+	assert.NotContains(t, gs.Players, carol)
+	assert.False(t, gs.Players[botID].WasReplaced)
+
+	_, err = m.ResolveBotTakeover("KICK", gs.HostID, botID, true)
+	assert.EqualError(t, err, "no takeover request for this seat")
+}
@@ -4,14 +4,16 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"dixitme/internal/i18n"
 	"dixitme/internal/logger"
 
-	"github.com/gorilla/websocket"
+	"github.com/google/uuid"
 )
 
 // GameBroadcastService defines broadcasting operations
 type GameBroadcastService interface {
 	BroadcastToGame(gameState *GameState, messageType MessageType, message interface{})
+	SendToPlayer(gameState *GameState, playerID uuid.UUID, messageType MessageType, message interface{}) error
 }
 
 // BroadcastToGame sends a message to all connected players in a game
@@ -56,7 +58,7 @@ func (m *Manager) BroadcastToGame(game *GameState, messageType MessageType, payl
 			continue
 		}
 
-		var conn *websocket.Conn
+		var conn Connection
 
 		// Try player's stored connection first
 		if player.Connection != nil && player.IsConnected {
@@ -69,6 +71,7 @@ func (m *Manager) BroadcastToGame(game *GameState, messageType MessageType, payl
 				// Update the player's connection reference
 				player.Connection = conn
 				player.IsConnected = true
+				player.markReconnected(game)
 				log.Debug("Using global registry connection", "player_id", playerID, "player_name", player.Name)
 			} else {
 				log.Warn("No connection found for player", "player_id", playerID, "player_name", player.Name)
@@ -77,17 +80,23 @@ func (m *Manager) BroadcastToGame(game *GameState, messageType MessageType, payl
 
 		// Send message if we have a connection
 		if conn != nil {
-			if err := conn.WriteMessage(websocket.TextMessage, messageData); err != nil {
+			data, err := encodeForConnection(conn, playerID, messageType, payload, messageData)
+			if err != nil {
+				logger.Error("Failed to marshal message for player", "error", err, "player_id", playerID)
+				continue
+			}
+
+			if err := conn.Send(data); err != nil {
 				logger.Error("Failed to send message to player",
 					"error", err,
 					"player_id", playerID,
 					"room_code", game.RoomCode)
 				// Mark player as disconnected and clear connection
-				player.IsConnected = false
-				player.Connection = nil
+				player.MarkDisconnected(game, DisconnectSendFailed)
 			} else {
 				sentCount++
 				log.Debug("Message sent successfully", "player_id", playerID, "player_name", player.Name)
+				m.deliverPendingResync(game, player, conn, messageType)
 			}
 		}
 	}
@@ -97,4 +106,64 @@ func (m *Manager) BroadcastToGame(game *GameState, messageType MessageType, payl
 		"message_type", messageType,
 		"messages_sent", sentCount,
 		"total_players", len(game.Players))
+
+	// Players connected to other instances get the message through the room's channel
+	m.publishRoomEvent(game, messageType, payload, messageData, sentCount)
+}
+
+// encodeForConnection encodes a broadcast for one connection. messageData is
+// the shared v1 encoding; v2 game states and localized messages are encoded
+// per connection.
+func encodeForConnection(conn Connection, playerID uuid.UUID, messageType MessageType, payload interface{}, messageData []byte) ([]byte, error) {
+	data := messageData
+	var err error
+	if gameStatePayload, ok := payload.(GameStatePayload); ok && conn.ProtocolVersion() >= ProtocolV2 {
+		// v2 clients get a per-player view, so it can't share the v1 encoding
+		if data, err = json.Marshal(GameStateMessage(gameStatePayload.GameState, playerID, conn.ProtocolVersion())); err != nil {
+			return nil, fmt.Errorf("failed to marshal game state view: %w", err)
+		}
+	}
+	if localizer, ok := payload.(Localizer); ok && conn.Locale() != i18n.DefaultLocale {
+		if data, err = json.Marshal(GameMessage{Type: messageType, Payload: localizer.Localize(conn.Locale())}); err != nil {
+			return nil, fmt.Errorf("failed to marshal localized message: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// SendToPlayer sends a message to a single connected player in a game
+func (m *Manager) SendToPlayer(game *GameState, playerID uuid.UUID, messageType MessageType, payload interface{}) error {
+	player, exists := game.Players[playerID]
+	if !exists {
+		return fmt.Errorf("player not in game")
+	}
+
+	conn := player.Connection
+	if conn == nil || !player.IsConnected {
+		conn = GetPlayerConnection(playerID)
+	}
+	if conn == nil {
+		return fmt.Errorf("player is not connected")
+	}
+
+	message := GameMessage{Type: messageType, Payload: payload}
+	if gameStatePayload, ok := payload.(GameStatePayload); ok {
+		message = GameStateMessage(gameStatePayload.GameState, playerID, conn.ProtocolVersion())
+	}
+	if localizer, ok := payload.(Localizer); ok {
+		message.Payload = localizer.Localize(conn.Locale())
+	}
+
+	messageData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	if err := conn.Send(messageData); err != nil {
+		player.MarkDisconnected(game, DisconnectSendFailed)
+		return fmt.Errorf("failed to send message to player: %w", err)
+	}
+	m.deliverPendingResync(game, player, conn, messageType)
+
+	return nil
 }
@@ -0,0 +1,151 @@
+package game
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+
+	"github.com/google/uuid"
+)
+
+// ErrCodeServerAtCapacity is returned when the server takes no more rooms or connections
+const ErrCodeServerAtCapacity = "server_at_capacity"
+
+// capacityAlertRatio is the share of a limit at which capacity alerts fire
+const capacityAlertRatio = 0.9
+
+// Capacity resources, for metrics and error details
+const (
+	CapacityGames       = "games"
+	CapacityConnections = "connections"
+)
+
+// CapacityLimits protects the single-process manager from taking on more than
+// it can serve. Past a limit new rooms or connections are refused with a hint
+// to retry later; games and players already on the server are unaffected.
+type CapacityLimits struct {
+	MaxGames       int           // Rooms held in memory (0 = unlimited)
+	MaxConnections int           // Open client connections (0 = unlimited)
+	RetryAfter     time.Duration // How long refused clients should wait before retrying
+}
+
+// DefaultCapacityLimits leaves capacity unlimited
+func DefaultCapacityLimits() CapacityLimits {
+	return CapacityLimits{RetryAfter: 30 * time.Second}
+}
+
+// SetCapacityLimits replaces the deployment-wide capacity limits
+func (m *Manager) SetCapacityLimits(limits CapacityLimits) {
+	if limits.MaxGames < 0 {
+		limits.MaxGames = 0
+	}
+	if limits.MaxConnections < 0 {
+		limits.MaxConnections = 0
+	}
+	if limits.RetryAfter <= 0 {
+		limits.RetryAfter = DefaultCapacityLimits().RetryAfter
+	}
+
+	m.mu.Lock()
+	m.capacity = limits
+	m.mu.Unlock()
+
+	metrics.GetGauge(metrics.Name("capacity_limit", "resource", CapacityGames)).Set(int64(limits.MaxGames))
+	metrics.GetGauge(metrics.Name("capacity_limit", "resource", CapacityConnections)).Set(int64(limits.MaxConnections))
+}
+
+// checkGameCapacity refuses a new room when the server holds its maximum.
+// Callers hold the manager lock.
+func (m *Manager) checkGameCapacity() error {
+	games := len(m.games)
+	recordCapacityUsage(CapacityGames, games, m.capacity.MaxGames, &m.gamesAlerted)
+	if m.capacity.MaxGames == 0 || games < m.capacity.MaxGames {
+		return nil
+	}
+	return m.capacity.refuse(CapacityGames, m.capacity.MaxGames)
+}
+
+// CheckConnectionCapacity tells whether the server can accept a new connection
+// for a player. Players who already hold a connection or a seat are always let
+// back in, so a full server never strands a game in progress.
+func (m *Manager) CheckConnectionCapacity(playerID uuid.UUID) error {
+	m.mu.RLock()
+	limits := m.capacity
+	m.mu.RUnlock()
+
+	connectionsMutex.RLock()
+	connections := len(playerConnections)
+	_, reconnecting := playerConnections[playerID]
+	connectionsMutex.RUnlock()
+
+	recordCapacityUsage(CapacityConnections, connections, limits.MaxConnections, &m.connectionsAlerted)
+	if limits.MaxConnections == 0 || connections < limits.MaxConnections || reconnecting || m.holdsSeat(playerID) {
+		return nil
+	}
+	return limits.refuse(CapacityConnections, limits.MaxConnections)
+}
+
+// refuse builds the capacity error for a resource and counts the rejection
+func (l CapacityLimits) refuse(resource string, limit int) error {
+	metrics.GetCounter(metrics.Name("capacity_rejections_total", "resource", resource)).Inc()
+	return &GameError{
+		Code:    ErrCodeServerAtCapacity,
+		Message: "the server is full, please try again in a moment",
+		Details: map[string]interface{}{
+			"resource":            resource,
+			"limit":               limit,
+			"retry_after_seconds": int(math.Ceil(l.RetryAfter.Seconds())),
+		},
+	}
+}
+
+// holdsSeat tells whether a player is seated in any game
+func (m *Manager) holdsSeat(playerID uuid.UUID) bool {
+	for _, game := range m.GetAllGames() {
+		game.mu.RLock()
+		player, exists := game.Players[playerID]
+		seated := exists && !player.IsBot && !player.WasReplaced
+		game.mu.RUnlock()
+		if seated {
+			return true
+		}
+	}
+	return false
+}
+
+// recordCapacityUsage publishes a resource's usage and logs when it crosses
+// into or out of the alert zone near its limit
+func recordCapacityUsage(resource string, used, limit int, alerted *atomic.Bool) {
+	metrics.GetGauge(metrics.Name("capacity_used", "resource", resource)).Set(int64(used))
+	if limit == 0 {
+		return
+	}
+
+	high := float64(used) >= capacityAlertRatio*float64(limit)
+	alert := metrics.GetGauge(metrics.Name("capacity_alert", "resource", resource))
+	if high {
+		alert.Set(1)
+	} else {
+		alert.Set(0)
+	}
+	if alerted.CompareAndSwap(!high, high) {
+		if high {
+			logger.Warn("Server is nearing capacity", "resource", resource, "used", used, "limit", limit)
+		} else {
+			logger.Info("Server is back under its capacity alert level", "resource", resource, "used", used, "limit", limit)
+		}
+	}
+}
+
+// RetryAfterSeconds returns the retry hint of a capacity error
+func RetryAfterSeconds(err error) (int, bool) {
+	gameErr, ok := AsGameError(err)
+	if !ok || gameErr.Code != ErrCodeServerAtCapacity {
+		return 0, false
+	}
+	seconds, ok := gameErr.Details["retry_after_seconds"].(int)
+	return seconds, ok
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameCapacityRefusesNewRooms(t *testing.T) {
+	m := NewEphemeralManager()
+	m.SetCapacityLimits(CapacityLimits{MaxGames: 1, RetryAfter: 1500 * time.Millisecond})
+
+	_, err := m.CreateGameWithOptions("CAP1", uuid.New(), "Alice", CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+
+	_, err = m.CreateGameWithOptions("CAP2", uuid.New(), "Bob", CreateGameOptions{Sandbox: true})
+	assert.ErrorIs(t, err, &GameError{Code: ErrCodeServerAtCapacity})
+	retryAfter, ok := RetryAfterSeconds(err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, retryAfter)
+	assert.Nil(t, m.GetGame("CAP2"))
+}
+
+func TestConnectionCapacityLetsSeatedPlayersBackIn(t *testing.T) {
+	m := NewEphemeralManager()
+	m.SetCapacityLimits(CapacityLimits{MaxConnections: 1})
+
+	connected, seated := uuid.New(), uuid.New()
+	_, err := m.CreateGameWithOptions("SEATS", seated, "Alice", CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+
+	conn := &recordingConnection{}
+	RegisterPlayerConnection(connected, conn)
+	defer UnregisterPlayerConnection(connected, conn)
+
+	assert.ErrorIs(t, m.CheckConnectionCapacity(uuid.New()), &GameError{Code: ErrCodeServerAtCapacity})
+	assert.NoError(t, m.CheckConnectionCapacity(connected))
+	assert.NoError(t, m.CheckConnectionCapacity(seated))
+
+	// Unlimited by default
+	assert.NoError(t, NewEphemeralManager().CheckConnectionCapacity(uuid.New()))
+}
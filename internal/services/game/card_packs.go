@@ -0,0 +1,109 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"dixitme/internal/models"
+	"dixitme/internal/services/customdeck"
+
+	"github.com/google/uuid"
+)
+
+// resolvePacks lists the cards a room's deck is built from: those of the
+// named packs, or of the default packs when none are named. Every named pack
+// must exist and hold active cards. It returns nil, which deals the classic
+// deck, when the repository has no packs at all.
+func resolvePacks(repo GameRepository, slugs []string) ([]int, error) {
+	cardsByPack, err := repo.GetPackCards(context.Background(), slugs)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	var cards []int
+	add := func(packCards []int) {
+		for _, cardID := range packCards {
+			if !seen[cardID] {
+				seen[cardID] = true
+				cards = append(cards, cardID)
+			}
+		}
+	}
+	if len(slugs) == 0 {
+		for _, packCards := range cardsByPack {
+			add(packCards)
+		}
+	}
+	for _, slug := range slugs {
+		packCards := cardsByPack[slug]
+		if len(packCards) == 0 {
+			return nil, fmt.Errorf("unknown card pack or no active cards: %s", slug)
+		}
+		add(packCards)
+	}
+	sort.Ints(cards)
+	return cards, nil
+}
+
+// resolveRoomCards lists the cards a new room's deck is built from: those of
+// its custom deck when it has one, else those of its card packs
+func resolveRoomCards(repo GameRepository, slugs []string, deckID *uuid.UUID) ([]int, error) {
+	if deckID == nil {
+		return resolvePacks(repo, slugs)
+	}
+	if len(slugs) > 0 {
+		return nil, fmt.Errorf("a room plays with either card packs or a custom deck")
+	}
+	cards, err := repo.GetCustomDeckCards(context.Background(), *deckID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) == 0 {
+		return nil, fmt.Errorf("the deck has no active cards")
+	}
+	return cards, nil
+}
+
+// GetPackCards lists the active cards of each named card pack, or of each
+// default pack when no slugs are given
+func (m *Manager) GetPackCards(ctx context.Context, slugs []string) (map[string][]int, error) {
+	var rows []struct {
+		Slug   string
+		CardID int
+	}
+	query := m.db.WithContext(ctx).Table("card_packs").
+		Select("card_packs.slug AS slug, cards.id AS card_id").
+		Joins("JOIN card_pack_cards ON card_pack_cards.card_pack_id = card_packs.id").
+		Joins("JOIN cards ON cards.id = card_pack_cards.card_id").
+		Where("cards.is_active = ?", true)
+	if len(slugs) == 0 {
+		query = query.Where("card_packs.is_default = ?", true)
+	} else {
+		query = query.Where("card_packs.slug IN ?", slugs)
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load card pack cards: %w", err)
+	}
+
+	cards := make(map[string][]int)
+	for _, row := range rows {
+		cards[row.Slug] = append(cards[row.Slug], row.CardID)
+	}
+	return cards, nil
+}
+
+// GetCustomDeckCards lists the active cards of a custom deck
+func (m *Manager) GetCustomDeckCards(ctx context.Context, deckID uuid.UUID) ([]int, error) {
+	return customdeck.Cards(ctx, m.db, deckID)
+}
+
+// packsFromModel reads the card packs persisted with a game
+func packsFromModel(dbGame *models.Game) []string {
+	if dbGame.Packs == "" {
+		return nil
+	}
+	return strings.Split(dbGame.Packs, ",")
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// packRepository serves fixed card packs and custom decks
+type packRepository struct {
+	noopRepository
+	packs    map[string][]int
+	defaults []string
+	decks    map[uuid.UUID][]int
+}
+
+func (r packRepository) GetCustomDeckCards(ctx context.Context, deckID uuid.UUID) ([]int, error) {
+	cards, ok := r.decks[deckID]
+	if !ok {
+		return nil, errors.New("deck not found")
+	}
+	return cards, nil
+}
+
+func (r packRepository) GetPackCards(ctx context.Context, slugs []string) (map[string][]int, error) {
+	if len(slugs) == 0 {
+		slugs = r.defaults
+	}
+	cards := make(map[string][]int)
+	for _, slug := range slugs {
+		if packCards, ok := r.packs[slug]; ok {
+			cards[slug] = packCards
+		}
+	}
+	return cards, nil
+}
+
+func TestResolvePacks(t *testing.T) {
+	repo := packRepository{
+		packs:    map[string][]int{"a": {3, 1, 2}, "b": {2, 5, 4}, "c": {9}},
+		defaults: []string{"a", "b"},
+	}
+
+	cards, err := resolvePacks(repo, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, cards, "default packs, without duplicates")
+
+	cards, err = resolvePacks(repo, []string{"c", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 5, 9}, cards)
+
+	_, err = resolvePacks(repo, []string{"a", "missing"})
+	assert.Error(t, err)
+
+	cards, err = resolvePacks(noopRepository{}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, cards, "no packs deals the classic deck")
+}
+
+func TestResolveRoomCards(t *testing.T) {
+	deckID, emptyID := uuid.New(), uuid.New()
+	repo := packRepository{
+		packs:    map[string][]int{"a": {1, 2}},
+		defaults: []string{"a"},
+		decks:    map[uuid.UUID][]int{deckID: {4, 8, 15}, emptyID: nil},
+	}
+
+	cards, err := resolveRoomCards(repo, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, cards, "the default packs without a deck")
+
+	cards, err = resolveRoomCards(repo, nil, &deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 8, 15}, cards)
+
+	_, err = resolveRoomCards(repo, []string{"a"}, &deckID)
+	assert.Error(t, err, "packs and a deck don't mix")
+	_, err = resolveRoomCards(repo, nil, &emptyID)
+	assert.Error(t, err, "a deck whose cards were all retired")
+	missing := uuid.New()
+	_, err = resolveRoomCards(repo, nil, &missing)
+	assert.Error(t, err)
+}
+
+func TestShuffleWithPackCards(t *testing.T) {
+	seed := "pack-seed"
+	packs := []int{90, 7, 200, 12}
+
+	deck := ShuffledDeckOf(seed, deckCards(packs, []int{300}))
+	assert.ElementsMatch(t, []int{7, 12, 90, 200, 300}, deck)
+
+	verified, ok := VerifyShuffle(seed, ShuffleCommitment(seed, deck), packs, nil, []int{300})
+	assert.True(t, ok)
+	assert.Equal(t, deck, verified)
+	_, ok = VerifyShuffle(seed, ShuffleCommitment(seed, deck), nil, nil, []int{300})
+	assert.False(t, ok, "the proof needs the pack cards")
+}
+
+func TestSeenCardsCoverPackCards(t *testing.T) {
+	gs := &GameState{packCards: []int{10, 20, 30}, expansionCards: []int{40}, Deck: []int{20}}
+	assert.Equal(t, []int{10, 30, 40}, seenCards(gs))
+}
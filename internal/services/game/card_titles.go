@@ -0,0 +1,77 @@
+package game
+
+import (
+	"context"
+	"fmt"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+)
+
+// Card title reveal modes
+const (
+	CardTitlesNever  = "never"  // Classic: cards are only ever shown as art
+	CardTitlesReveal = "reveal" // Educational: titles and descriptions are shown once voting is over
+)
+
+// CardText is the title and description of a card
+type CardText struct {
+	Title       string
+	Description string
+}
+
+// validateCardTitles checks the card title reveal mode
+func (s GameSettings) validateCardTitles() error {
+	switch s.CardTitles {
+	case "", CardTitlesNever, CardTitlesReveal:
+		return nil
+	default:
+		return fmt.Errorf("card titles must be never or reveal")
+	}
+}
+
+// GetCardTexts loads the titles and descriptions of cards
+func (m *Manager) GetCardTexts(ctx context.Context, cardIDs []int) (map[int]CardText, error) {
+	if m.db == nil {
+		return nil, nil
+	}
+	var cards []models.Card
+	if err := m.db.WithContext(ctx).
+		Select("id", "title", "description").
+		Where("id IN ?", cardIDs).
+		Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("failed to load card texts: %w", err)
+	}
+
+	texts := make(map[int]CardText, len(cards))
+	for _, card := range cards {
+		texts[card.ID] = CardText{Title: card.Title, Description: card.Description}
+	}
+	return texts, nil
+}
+
+// revealCardTitles adds titles and descriptions to the revealed cards of a
+// round whose voting is over, in rooms that reveal them. Voting payloads are
+// built before this runs, so titles can never hint at the storyteller's card.
+func (m *Manager) revealCardTitles(game *GameState, round *Round) {
+	if game.Settings.CardTitles != CardTitlesReveal || len(round.RevealedCards) == 0 {
+		return
+	}
+
+	cardIDs := make([]int, 0, len(round.RevealedCards))
+	for _, card := range round.RevealedCards {
+		cardIDs = append(cardIDs, card.CardID)
+	}
+	texts, err := m.repository(game).GetCardTexts(context.Background(), cardIDs)
+	if err != nil {
+		logger.Error("Failed to load card titles for the reveal", "error", err, "room_code", game.RoomCode)
+		return
+	}
+
+	for i := range round.RevealedCards {
+		if text, ok := texts[round.RevealedCards[i].CardID]; ok {
+			round.RevealedCards[i].Title = text.Title
+			round.RevealedCards[i].Description = text.Description
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSettingsCardTitles(t *testing.T) {
+	settings := DefaultGameSettings()
+	settings.CardTitles = ""
+	validated, err := ValidateSettings(settings)
+	require.NoError(t, err)
+	assert.Equal(t, CardTitlesNever, validated.CardTitles)
+
+	settings.CardTitles = CardTitlesReveal
+	validated, err = ValidateSettings(settings)
+	require.NoError(t, err)
+	assert.Equal(t, CardTitlesReveal, validated.CardTitles)
+
+	settings.CardTitles = "always"
+	_, err = ValidateSettings(settings)
+	assert.Error(t, err)
+}
+
+func TestRevealCardTitlesClassicRoom(t *testing.T) {
+	m := &Manager{}
+	game := &GameState{RoomCode: "CLASSIC", Settings: DefaultGameSettings()}
+	round := &Round{RevealedCards: []RevealedCard{{CardID: 1}, {CardID: 2}}}
+
+	m.revealCardTitles(game, round)
+	for _, card := range round.RevealedCards {
+		assert.Empty(t, card.Title)
+		assert.Empty(t, card.Description)
+	}
+}
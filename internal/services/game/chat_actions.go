@@ -2,10 +2,12 @@ package game
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
+	"dixitme/internal/i18n"
 	"dixitme/internal/logger"
 	"dixitme/internal/models"
 
@@ -15,8 +17,12 @@ import (
 // ChatService defines chat-related operations
 type ChatService interface {
 	SendChatMessage(roomCode string, playerID uuid.UUID, message string, messageType string) error
-	GetChatHistory(roomCode string, playerID string, limit int) ([]ChatMessagePayload, error)
-	SendSystemMessage(roomCode string, message string) error
+	GetChatHistory(roomCode string, viewerID uuid.UUID, phase string, limit int, locale string) ([]ChatMessagePayload, error)
+	SendSystemMessage(roomCode string, message i18n.Message) error
+	SetChatModeration(roomCode string, playerID uuid.UUID, moderation ChatModeration) (*GameState, error)
+	ReportPlayer(roomCode string, reporterID, reportedID uuid.UUID, reason string) error
+	PurgeExpiredChatMessages(ctx context.Context) (*ChatPurgeResult, error)
+	GetChatRetentionPolicy() ChatRetentionPolicy
 }
 
 // SendChatMessage handles sending chat messages in a game
@@ -26,6 +32,9 @@ func (m *Manager) SendChatMessage(roomCode string, playerID uuid.UUID, message s
 		return fmt.Errorf("game not found")
 	}
 
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
 	player, exists := game.Players[playerID]
 	if !exists {
 		return fmt.Errorf("player not in game")
@@ -58,6 +67,23 @@ func (m *Manager) SendChatMessage(roomCode string, playerID uuid.UUID, message s
 		return fmt.Errorf("chat not allowed in current phase")
 	}
 
+	if currentPhase != "lobby" && messageType == "chat" {
+		if err := validateChatMessage(game.CurrentRound.Modifier, message); err != nil {
+			return err
+		}
+	}
+
+	// The host's chat controls apply to everyone else
+	now := time.Now()
+	if playerID != game.HostID {
+		if err := game.Settings.Chat.check(messageType, player.lastChatAt, now); err != nil {
+			return err
+		}
+	}
+
+	// Shadow-banned players' messages are only shown to themselves
+	hidden := m.IsShadowBanned(playerID)
+
 	// Create chat message
 	chatMessage := models.ChatMessage{
 		ID:          uuid.New(),
@@ -66,14 +92,15 @@ func (m *Manager) SendChatMessage(roomCode string, playerID uuid.UUID, message s
 		Message:     strings.TrimSpace(message),
 		MessageType: messageType,
 		Phase:       currentPhase,
-		IsVisible:   true,
-		CreatedAt:   time.Now(),
+		IsVisible:   !hidden,
+		CreatedAt:   now,
 	}
 
 	// Persist to database
-	if err := m.PersistChatMessage(context.Background(), &chatMessage); err != nil {
+	if err := m.repository(game).PersistChatMessage(context.Background(), &chatMessage); err != nil {
 		return fmt.Errorf("failed to persist chat message: %w", err)
 	}
+	player.lastChatAt = now
 
 	// Create payload
 	payload := ChatMessagePayload{
@@ -86,19 +113,32 @@ func (m *Manager) SendChatMessage(roomCode string, playerID uuid.UUID, message s
 		Timestamp:   chatMessage.CreatedAt,
 	}
 
+	if hidden {
+		if err := m.SendToPlayer(game, playerID, MessageTypeChatMessage, payload); err != nil {
+			logger.Debug("Shadow-banned chat message not echoed", "error", err, "player_id", playerID)
+		}
+		return nil
+	}
+
 	// Broadcast to all players in the game
+	game.analytics.recordChat(player, currentPhase)
 	m.BroadcastToGame(game, MessageTypeChatMessage, payload)
 
 	return nil
 }
 
-// GetChatHistory retrieves chat messages for a game and phase
-func (m *Manager) GetChatHistory(roomCode string, phase string, limit int) ([]ChatMessagePayload, error) {
+// GetChatHistory retrieves chat messages for a game and phase as viewerID sees
+// them, with system messages in the given locale
+func (m *Manager) GetChatHistory(roomCode string, viewerID uuid.UUID, phase string, limit int, locale string) ([]ChatMessagePayload, error) {
 	game := m.getGame(roomCode)
 	if game == nil {
 		return nil, fmt.Errorf("game not found")
 	}
+	return m.chatHistory(game, viewerID, phase, limit, locale)
+}
 
+// chatHistory loads a game's chat messages as viewerID sees them
+func (m *Manager) chatHistory(game *GameState, viewerID uuid.UUID, phase string, limit int, locale string) ([]ChatMessagePayload, error) {
 	if limit <= 0 {
 		limit = 50
 	}
@@ -107,7 +147,7 @@ func (m *Manager) GetChatHistory(roomCode string, phase string, limit int) ([]Ch
 	}
 
 	// Get messages from database
-	messages, err := m.GetChatMessages(context.Background(), game.ID, phase, limit)
+	messages, err := m.repository(game).GetChatMessages(context.Background(), game.ID, viewerID, phase, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chat messages: %w", err)
 	}
@@ -115,31 +155,74 @@ func (m *Manager) GetChatHistory(roomCode string, phase string, limit int) ([]Ch
 	// Convert to payload format
 	payloads := make([]ChatMessagePayload, 0, len(messages))
 	for _, msg := range messages {
-		var playerName string
-		if msg.PlayerID == nil {
-			playerName = "System"
+		// Live seats carry the latest name; players who have left fall back to their row
+		playerName := msg.Player.Name
+		if msg.PlayerID == nil || *msg.PlayerID == models.SystemPlayerID {
+			playerName = models.SystemPlayerName
 		} else if player, exists := game.Players[*msg.PlayerID]; exists {
 			playerName = player.Name
-		} else {
+		} else if playerName == "" {
 			playerName = "Unknown"
 		}
 
+		text := msg.Message
+		if msg.MessageKey != "" {
+			message := i18n.Message{Text: msg.MessageKey}
+			if msg.MessageArgs != "" {
+				if err := json.Unmarshal([]byte(msg.MessageArgs), &message.Args); err != nil {
+					logger.Warn("Ignoring malformed chat message arguments", "message_id", msg.ID, "error", err)
+				}
+			}
+			text = message.Localize(locale)
+		}
+
 		payloads = append(payloads, ChatMessagePayload{
 			ID:          msg.ID,
 			PlayerID:    msg.PlayerID,
 			PlayerName:  playerName,
-			Message:     msg.Message,
+			Message:     text,
 			MessageType: msg.MessageType,
 			Phase:       msg.Phase,
 			Timestamp:   msg.CreatedAt,
+			IsBot:       msg.Player.Type == models.PlayerTypeBot,
 		})
 	}
 
 	return payloads, nil
 }
 
-// SendSystemMessage sends a system message (e.g., "Player joined", "Round started")
-func (m *Manager) SendSystemMessage(roomCode string, message string) error {
+// sendJoinChatHistory sends a player taking their seat the room's latest
+// chat messages, as many as the retention policy's JoinHistory, as a chat
+// history reply for all phases. Nothing is sent for a quiet room or a player
+// without a live connection. Failures only cost the client a
+// get_chat_history round trip, so they are logged, not returned. Callers
+// hold the game lock.
+func (m *Manager) sendJoinChatHistory(game *GameState, playerID uuid.UUID, conn Connection) {
+	limit := m.GetChatRetentionPolicy().JoinHistory
+	if limit <= 0 || conn == nil {
+		return
+	}
+
+	messages, err := m.chatHistory(game, playerID, "", limit, conn.Locale())
+	if err != nil {
+		logger.Warn("Failed to load chat history for joining player", "error", err, "room_code", game.RoomCode, "player_id", playerID)
+		return
+	}
+	if len(messages) == 0 {
+		return
+	}
+	if err := conn.SendJSON(GameMessage{
+		Type:    MessageTypeChatHistory,
+		Payload: ChatHistoryPayload{Messages: messages},
+	}); err != nil {
+		logger.Debug("Join chat history not delivered", "error", err, "player_id", playerID)
+	}
+}
+
+// SendSystemMessage sends a system message (e.g., "Player joined", "Round started").
+// It is stored in the default locale along with its source, and each player
+// receives it in their connection's locale.
+func (m *Manager) SendSystemMessage(roomCode string, message i18n.Message) error {
 	game := m.getGame(roomCode)
 	if game == nil {
 		return fmt.Errorf("game not found")
@@ -151,12 +234,24 @@ func (m *Manager) SendSystemMessage(roomCode string, message string) error {
 		currentPhase = string(game.CurrentRound.Status)
 	}
 
-	// Create system message with nil player ID (for system messages)
+	var args string
+	if len(message.Args) > 0 {
+		encoded, err := json.Marshal(message.Args)
+		if err != nil {
+			return fmt.Errorf("failed to encode system message arguments: %w", err)
+		}
+		args = string(encoded)
+	}
+
+	// System messages are authored by the reserved system player
+	systemID := models.SystemPlayerID
 	chatMessage := models.ChatMessage{
 		ID:          uuid.New(),
 		GameID:      game.ID,
-		PlayerID:    nil,
-		Message:     message,
+		PlayerID:    &systemID,
+		Message:     message.String(),
+		MessageKey:  message.Text,
+		MessageArgs: args,
 		MessageType: "system",
 		Phase:       currentPhase,
 		IsVisible:   true,
@@ -164,20 +259,23 @@ func (m *Manager) SendSystemMessage(roomCode string, message string) error {
 	}
 
 	// Persist to database
-	if err := m.PersistChatMessage(context.Background(), &chatMessage); err != nil {
+	if err := m.repository(game).PersistChatMessage(context.Background(), &chatMessage); err != nil {
 		logger.Error("Failed to persist system message", "error", err)
 		// Continue anyway - system messages are not critical
 	}
 
 	// Create payload
-	payload := ChatMessagePayload{
-		ID:          chatMessage.ID,
-		PlayerID:    nil,
-		PlayerName:  "System",
-		Message:     chatMessage.Message,
-		MessageType: chatMessage.MessageType,
-		Phase:       chatMessage.Phase,
-		Timestamp:   chatMessage.CreatedAt,
+	payload := systemChatPayload{
+		ChatMessagePayload: ChatMessagePayload{
+			ID:          chatMessage.ID,
+			PlayerID:    chatMessage.PlayerID,
+			PlayerName:  models.SystemPlayerName,
+			Message:     chatMessage.Message,
+			MessageType: chatMessage.MessageType,
+			Phase:       chatMessage.Phase,
+			Timestamp:   chatMessage.CreatedAt,
+		},
+		message: message,
 	}
 
 	// Broadcast to all players in the game
@@ -0,0 +1,130 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// Error codes for chat messages refused by the room's moderation
+const (
+	ErrCodeChatFrozen     = "chat_frozen"
+	ErrCodeChatSlowMode   = "chat_slow_mode"
+	ErrCodeEmotesDisabled = "emotes_disabled"
+)
+
+// maxSlowModeSeconds is the longest slow mode interval a host may set
+const maxSlowModeSeconds = 300
+
+// ChatModeration holds the host's chat controls for a room. The host is not
+// bound by them, and system messages always go through.
+type ChatModeration struct {
+	SlowModeSeconds int  `json:"slow_mode_seconds"` // Each player may send one message per interval (0 = off)
+	EmotesDisabled  bool `json:"emotes_disabled"`
+	Frozen          bool `json:"frozen"` // Nobody but the host may chat
+}
+
+// Validate checks the slow mode interval
+func (c ChatModeration) Validate() error {
+	if c.SlowModeSeconds < 0 || c.SlowModeSeconds > maxSlowModeSeconds {
+		return fmt.Errorf("slow mode must be between 0 and %d seconds", maxSlowModeSeconds)
+	}
+	return nil
+}
+
+// check tells whether a player may send a message of the given type now.
+// lastChat is when the player last sent one (zero if never).
+func (c ChatModeration) check(messageType string, lastChat, now time.Time) error {
+	if c.Frozen {
+		return &GameError{Code: ErrCodeChatFrozen, Message: "the host has paused the chat"}
+	}
+	if c.EmotesDisabled && messageType == "emote" {
+		return &GameError{Code: ErrCodeEmotesDisabled, Message: "the host has turned off emotes"}
+	}
+	if c.SlowModeSeconds > 0 && !lastChat.IsZero() {
+		if wait := lastChat.Add(time.Duration(c.SlowModeSeconds) * time.Second).Sub(now); wait > 0 {
+			return &GameError{
+				Code:    ErrCodeChatSlowMode,
+				Message: fmt.Sprintf("slow mode is on: one message every %d seconds", c.SlowModeSeconds),
+				Details: map[string]interface{}{
+					"slow_mode_seconds":   c.SlowModeSeconds,
+					"retry_after_seconds": int(math.Ceil(wait.Seconds())),
+				},
+			}
+		}
+	}
+	return nil
+}
+
+// SetChatModeration replaces a room's chat controls. Only the host may change
+// them, at any point of the game; the room is told in chat when it changes.
+func (m *Manager) SetChatModeration(roomCode string, playerID uuid.UUID, moderation ChatModeration) (*GameState, error) {
+	if err := moderation.Validate(); err != nil {
+		return nil, err
+	}
+
+	game := m.getGame(roomCode)
+	if game == nil {
+		return nil, fmt.Errorf("game not found")
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if game.HostID != playerID {
+		return nil, fmt.Errorf("only the host can moderate the chat")
+	}
+
+	previous := game.Settings.Chat
+	game.Settings.Chat = moderation
+	game.LastActivity = time.Now()
+
+	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
+	for _, notice := range moderationNotices(previous, moderation) {
+		if err := m.SendSystemMessage(roomCode, notice); err != nil {
+			logger.Error("Failed to announce chat moderation", "error", err, "room_code", roomCode)
+		}
+	}
+
+	logger.Info("Chat moderation updated",
+		"room_code", roomCode,
+		"updated_by", playerID,
+		"slow_mode_seconds", moderation.SlowModeSeconds,
+		"emotes_disabled", moderation.EmotesDisabled,
+		"frozen", moderation.Frozen)
+
+	return game, nil
+}
+
+// moderationNotices returns the chat announcements for a change of controls
+func moderationNotices(previous, current ChatModeration) []i18n.Message {
+	var notices []i18n.Message
+	if current.Frozen != previous.Frozen {
+		if current.Frozen {
+			notices = append(notices, i18n.Msg("The host paused the chat"))
+		} else {
+			notices = append(notices, i18n.Msg("The host resumed the chat"))
+		}
+	}
+	if current.SlowModeSeconds != previous.SlowModeSeconds {
+		if current.SlowModeSeconds > 0 {
+			notices = append(notices, i18n.Msg("Slow mode is on: one message every {1} seconds", strconv.Itoa(current.SlowModeSeconds)))
+		} else {
+			notices = append(notices, i18n.Msg("Slow mode is off"))
+		}
+	}
+	if current.EmotesDisabled != previous.EmotesDisabled {
+		if current.EmotesDisabled {
+			notices = append(notices, i18n.Msg("The host turned off emotes"))
+		} else {
+			notices = append(notices, i18n.Msg("The host turned on emotes"))
+		}
+	}
+	return notices
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatModerationCheck(t *testing.T) {
+	now := time.Now()
+
+	assert.NoError(t, ChatModeration{}.check("emote", now, now))
+	assert.ErrorIs(t, ChatModeration{Frozen: true}.check("chat", time.Time{}, now), &GameError{Code: ErrCodeChatFrozen})
+	assert.ErrorIs(t, ChatModeration{EmotesDisabled: true}.check("emote", time.Time{}, now), &GameError{Code: ErrCodeEmotesDisabled})
+	assert.NoError(t, ChatModeration{EmotesDisabled: true}.check("chat", time.Time{}, now))
+
+	slow := ChatModeration{SlowModeSeconds: 10}
+	assert.NoError(t, slow.check("chat", time.Time{}, now))
+	assert.NoError(t, slow.check("chat", now.Add(-10*time.Second), now))
+	err := slow.check("chat", now.Add(-3*time.Second), now)
+	require.ErrorIs(t, err, &GameError{Code: ErrCodeChatSlowMode})
+	gameErr, _ := AsGameError(err)
+	assert.Equal(t, 7, gameErr.Details["retry_after_seconds"])
+}
+
+func TestChatModerationValidate(t *testing.T) {
+	assert.NoError(t, ChatModeration{SlowModeSeconds: maxSlowModeSeconds}.Validate())
+	assert.Error(t, ChatModeration{SlowModeSeconds: -1}.Validate())
+	assert.Error(t, ChatModeration{SlowModeSeconds: maxSlowModeSeconds + 1}.Validate())
+}
+
+func TestHostModeratesChat(t *testing.T) {
+	m := NewEphemeralManager()
+	host, guest := uuid.New(), uuid.New()
+	_, err := m.CreateGameWithOptions("MOD", host, "Alice", CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+	_, err = m.JoinGame("MOD", guest, "Bob")
+	require.NoError(t, err)
+
+	_, err = m.SetChatModeration("MOD", guest, ChatModeration{Frozen: true})
+	assert.EqualError(t, err, "only the host can moderate the chat")
+
+	game, err := m.SetChatModeration("MOD", host, ChatModeration{Frozen: true})
+	require.NoError(t, err)
+	assert.True(t, game.Settings.Chat.Frozen)
+
+	assert.ErrorIs(t, m.SendChatMessage("MOD", guest, "hello", "chat"), &GameError{Code: ErrCodeChatFrozen})
+	assert.NoError(t, m.SendChatMessage("MOD", host, "quiet please", "chat"))
+
+	// Lobby setting changes keep the host's chat controls
+	_, err = m.UpdateGameSettings("MOD", guest, DefaultGameSettings())
+	assert.ErrorIs(t, err, &GameError{Code: ErrCodeNotHost})
+	game, err = m.UpdateGameSettings("MOD", host, DefaultGameSettings())
+	require.NoError(t, err)
+	assert.True(t, game.Settings.Chat.Frozen)
+
+	_, err = m.SetChatModeration("MOD", host, ChatModeration{SlowModeSeconds: 30})
+	require.NoError(t, err)
+	assert.NoError(t, m.SendChatMessage("MOD", guest, "hello", "chat"))
+	assert.ErrorIs(t, m.SendChatMessage("MOD", guest, "hello again", "chat"), &GameError{Code: ErrCodeChatSlowMode})
+}
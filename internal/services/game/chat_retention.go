@@ -0,0 +1,186 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ChatRetentionPolicy controls how long chat messages are kept before being purged
+type ChatRetentionPolicy struct {
+	LobbyRetention time.Duration // Lobby chat older than this is purged (0 = never)
+	GameRetention  time.Duration // In-game chat older than this is purged (0 = keep while the game is archived)
+	PurgeInterval  time.Duration // How often the retention job runs
+	JoinHistory    int           // Latest messages sent to a player joining a room (0 = none, at most 100)
+}
+
+// DefaultChatRetentionPolicy purges lobby chat after 30 days and keeps in-game chat with its game
+func DefaultChatRetentionPolicy() ChatRetentionPolicy {
+	return ChatRetentionPolicy{
+		LobbyRetention: 30 * 24 * time.Hour,
+		GameRetention:  0,
+		PurgeInterval:  24 * time.Hour,
+		JoinHistory:    30,
+	}
+}
+
+// ChatPurgeResult summarizes a single retention run
+type ChatPurgeResult struct {
+	LobbyPurged    int64     `json:"lobby_purged"`
+	GamePurged     int64     `json:"game_purged"`
+	RoomPurged     int64     `json:"room_purged"`     // Purged through per-room overrides
+	OrphanedPurged int64     `json:"orphaned_purged"` // Chat left behind by deleted games
+	RoomOverrides  int       `json:"room_overrides"`
+	StartedAt      time.Time `json:"started_at"`
+	DurationMs     int64     `json:"duration_ms"`
+}
+
+// Total returns the number of messages purged in this run
+func (r ChatPurgeResult) Total() int64 {
+	return r.LobbyPurged + r.GamePurged + r.RoomPurged + r.OrphanedPurged
+}
+
+// SetChatRetentionPolicy replaces the chat retention policy used by the purge job
+func (m *Manager) SetChatRetentionPolicy(policy ChatRetentionPolicy) {
+	if policy.PurgeInterval <= 0 {
+		policy.PurgeInterval = DefaultChatRetentionPolicy().PurgeInterval
+	}
+
+	m.mu.Lock()
+	m.chatRetention = policy
+	m.mu.Unlock()
+
+	logger.Info("Chat retention policy updated",
+		"lobby_retention", policy.LobbyRetention,
+		"game_retention", policy.GameRetention,
+		"purge_interval", policy.PurgeInterval,
+		"join_history", policy.JoinHistory)
+}
+
+// GetChatRetentionPolicy returns the active chat retention policy
+func (m *Manager) GetChatRetentionPolicy() ChatRetentionPolicy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.chatRetention
+}
+
+// Chat retention service
+
+func (m *Manager) startChatRetentionService() {
+	logger.Info("Chat retention service started")
+
+	for {
+		timer := time.NewTimer(m.GetChatRetentionPolicy().PurgeInterval)
+		select {
+		case <-timer.C:
+			if _, err := m.PurgeExpiredChatMessages(context.Background()); err != nil {
+				logger.Error("Chat retention run failed", "error", err)
+			}
+		case <-m.stopChatRetention:
+			timer.Stop()
+			logger.Info("Chat retention service stopped")
+			return
+		}
+	}
+}
+
+// PurgeExpiredChatMessages deletes chat messages that fall outside the retention policy.
+// Rooms with a chat_retention_days override are purged by their own window and are
+// excluded from the deployment-wide lobby and game windows.
+func (m *Manager) PurgeExpiredChatMessages(ctx context.Context) (*ChatPurgeResult, error) {
+	log := logger.GetLogger()
+	policy := m.GetChatRetentionPolicy()
+	now := time.Now()
+	result := &ChatPurgeResult{StartedAt: now}
+
+	db := m.db.WithContext(ctx)
+
+	// Per-room overrides
+	var overrides []models.Game
+	if err := db.Select("id", "room_code", "chat_retention_days").
+		Where("chat_retention_days IS NOT NULL").
+		Find(&overrides).Error; err != nil {
+		return nil, fmt.Errorf("failed to load room retention overrides: %w", err)
+	}
+	result.RoomOverrides = len(overrides)
+
+	for _, game := range overrides {
+		cutoff := now.AddDate(0, 0, -*game.ChatRetentionDays)
+		res := db.Where("game_id = ? AND created_at < ?", game.ID, cutoff).Delete(&models.ChatMessage{})
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to purge chat for room %s: %w", game.RoomCode, res.Error)
+		}
+		result.RoomPurged += res.RowsAffected
+	}
+
+	overrideGames := db.Model(&models.Game{}).Select("id").Where("chat_retention_days IS NOT NULL")
+
+	// Deployment-wide lobby window
+	if policy.LobbyRetention > 0 {
+		res := m.purgeChatBefore(db, now.Add(-policy.LobbyRetention), overrideGames, "phase = ?", "lobby")
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to purge lobby chat: %w", res.Error)
+		}
+		result.LobbyPurged = res.RowsAffected
+	}
+
+	// Deployment-wide in-game window (disabled by default so chat stays with archived games)
+	if policy.GameRetention > 0 {
+		res := m.purgeChatBefore(db, now.Add(-policy.GameRetention), overrideGames, "phase <> ?", "lobby")
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to purge in-game chat: %w", res.Error)
+		}
+		result.GamePurged = res.RowsAffected
+	}
+
+	// Chat belonging to games that no longer exist (hard or soft deleted)
+	res := db.Where("game_id NOT IN (?)", db.Model(&models.Game{}).Select("id")).Delete(&models.ChatMessage{})
+	if res.Error != nil {
+		return nil, fmt.Errorf("failed to purge orphaned chat: %w", res.Error)
+	}
+	result.OrphanedPurged = res.RowsAffected
+
+	result.DurationMs = time.Since(now).Milliseconds()
+
+	metrics.GetCounter("chat_retention_runs_total").Inc()
+	metrics.GetCounter("chat_retention_purged_lobby_total").Add(result.LobbyPurged)
+	metrics.GetCounter("chat_retention_purged_game_total").Add(result.GamePurged)
+	metrics.GetCounter("chat_retention_purged_room_total").Add(result.RoomPurged)
+	metrics.GetCounter("chat_retention_purged_orphaned_total").Add(result.OrphanedPurged)
+	metrics.GetGauge("chat_retention_last_run_unix").Set(now.Unix())
+	metrics.GetGauge("chat_retention_last_purged").Set(result.Total())
+
+	log.Info("Chat retention run completed",
+		"lobby_purged", result.LobbyPurged,
+		"game_purged", result.GamePurged,
+		"room_purged", result.RoomPurged,
+		"orphaned_purged", result.OrphanedPurged,
+		"room_overrides", result.RoomOverrides,
+		"duration_ms", result.DurationMs)
+
+	return result, nil
+}
+
+// purgeChatBefore deletes messages older than cutoff matching the phase condition,
+// skipping rooms that define their own retention window
+func (m *Manager) purgeChatBefore(db *gorm.DB, cutoff time.Time, overrideGames *gorm.DB, phaseQuery string, phase string) *gorm.DB {
+	return db.Where(phaseQuery, phase).
+		Where("created_at < ?", cutoff).
+		Where("game_id NOT IN (?)", overrideGames).
+		Delete(&models.ChatMessage{})
+}
+
+// StopChatRetentionService stops the background chat retention job
+func (m *Manager) StopChatRetentionService() {
+	select {
+	case m.stopChatRetention <- true:
+	default:
+		// Service might not be running
+	}
+}
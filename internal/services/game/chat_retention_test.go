@@ -0,0 +1,131 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dixitme/internal/models"
+	"dixitme/internal/testutils/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// chatArchive is a database of rooms and their chat, aged as needed
+type chatArchive struct {
+	t  *testing.T
+	db *gorm.DB
+}
+
+func newChatArchive(t *testing.T) *chatArchive {
+	return &chatArchive{t: t, db: testdb.Open(t, &models.Game{}, &models.Player{}, &models.ChatMessage{})}
+}
+
+// room stores a room, keeping its chat for days when set
+func (a *chatArchive) room(roomCode string, days *int) uuid.UUID {
+	a.t.Helper()
+	gameID := uuid.New()
+	require.NoError(a.t, a.db.Create(&models.Game{ID: gameID, RoomCode: roomCode, ChatRetentionDays: days}).Error)
+	return gameID
+}
+
+// message stores a chat message written age ago in a phase
+func (a *chatArchive) message(gameID uuid.UUID, phase string, age time.Duration) uuid.UUID {
+	a.t.Helper()
+	messageID := uuid.New()
+	require.NoError(a.t, a.db.Create(&models.ChatMessage{
+		ID:        messageID,
+		GameID:    gameID,
+		Message:   "hello",
+		Phase:     phase,
+		IsVisible: true,
+		CreatedAt: time.Now().Add(-age),
+	}).Error)
+	return messageID
+}
+
+// kept reports which of the messages are still stored
+func (a *chatArchive) kept(messageIDs ...uuid.UUID) []bool {
+	a.t.Helper()
+	kept := make([]bool, len(messageIDs))
+	for i, messageID := range messageIDs {
+		var count int64
+		require.NoError(a.t, a.db.Model(&models.ChatMessage{}).Where("id = ?", messageID).Count(&count).Error)
+		kept[i] = count == 1
+	}
+	return kept
+}
+
+const retentionDay = 24 * time.Hour
+
+func TestPurgeFollowsDeploymentWindows(t *testing.T) {
+	archive := newChatArchive(t)
+	m := &Manager{db: archive.db, chatRetention: DefaultChatRetentionPolicy()}
+	room := archive.room("KEEP", nil)
+	oldLobby := archive.message(room, "lobby", 40*retentionDay)
+	newLobby := archive.message(room, "lobby", retentionDay)
+	oldGame := archive.message(room, "voting", 40*retentionDay)
+
+	result, err := m.PurgeExpiredChatMessages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.LobbyPurged)
+	assert.Equal(t, int64(0), result.GamePurged, "in-game chat stays with its game by default")
+	assert.Equal(t, []bool{false, true, true}, archive.kept(oldLobby, newLobby, oldGame))
+
+	// An in-game window purges old game chat too
+	policy := DefaultChatRetentionPolicy()
+	policy.GameRetention = 30 * retentionDay
+	m.SetChatRetentionPolicy(policy)
+	result, err = m.PurgeExpiredChatMessages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.GamePurged)
+	assert.Equal(t, []bool{true, false}, archive.kept(newLobby, oldGame))
+}
+
+func TestPurgeUsesRoomOverrides(t *testing.T) {
+	archive := newChatArchive(t)
+	m := &Manager{db: archive.db, chatRetention: DefaultChatRetentionPolicy()}
+
+	week, year := 7, 365
+	short := archive.room("SHORT", &week)
+	shortLobby := archive.message(short, "lobby", 10*retentionDay)
+	shortGame := archive.message(short, "voting", 10*retentionDay)
+	shortRecent := archive.message(short, "lobby", 3*retentionDay)
+	long := archive.room("LONG", &year)
+	longLobby := archive.message(long, "lobby", 40*retentionDay)
+
+	result, err := m.PurgeExpiredChatMessages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.RoomOverrides)
+	assert.Equal(t, int64(2), result.RoomPurged)
+	assert.Equal(t, int64(0), result.LobbyPurged, "rooms with their own window skip the deployment's")
+	assert.Equal(t, []bool{false, false, true, true}, archive.kept(shortLobby, shortGame, shortRecent, longLobby))
+}
+
+func TestPurgeRemovesChatOfDeletedGames(t *testing.T) {
+	archive := newChatArchive(t)
+	m := &Manager{db: archive.db, chatRetention: DefaultChatRetentionPolicy()}
+	deleted := archive.room("GONE", nil)
+	orphan := archive.message(deleted, "voting", time.Hour)
+	live := archive.room("LIVE", nil)
+	kept := archive.message(live, "voting", time.Hour)
+	require.NoError(t, archive.db.Delete(&models.Game{}, "id = ?", deleted).Error)
+
+	result, err := m.PurgeExpiredChatMessages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.OrphanedPurged)
+	assert.Equal(t, int64(1), result.Total())
+	assert.Equal(t, []bool{false, true}, archive.kept(orphan, kept))
+}
+
+func TestSetChatRetentionPolicyKeepsAPurgeInterval(t *testing.T) {
+	m := &Manager{}
+	m.SetChatRetentionPolicy(ChatRetentionPolicy{LobbyRetention: retentionDay})
+
+	policy := m.GetChatRetentionPolicy()
+	assert.Equal(t, retentionDay, policy.LobbyRetention)
+	assert.Equal(t, DefaultChatRetentionPolicy().PurgeInterval, policy.PurgeInterval)
+}
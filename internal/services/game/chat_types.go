@@ -3,6 +3,8 @@ package game
 import (
 	"time"
 
+	"dixitme/internal/i18n"
+
 	"github.com/google/uuid"
 )
 
@@ -15,6 +17,22 @@ type ChatMessagePayload struct {
 	MessageType string     `json:"message_type"` // chat, system, emote
 	Phase       string     `json:"phase"`
 	Timestamp   time.Time  `json:"timestamp"`
+
+	IsBot bool `json:"is_bot,omitempty"` // Sent by a bot
+}
+
+// systemChatPayload is a system chat message rendered in each recipient's
+// locale. It encodes as the default-locale ChatMessagePayload.
+type systemChatPayload struct {
+	ChatMessagePayload
+	message i18n.Message
+}
+
+// Localize renders the message in a locale
+func (p systemChatPayload) Localize(locale string) interface{} {
+	payload := p.ChatMessagePayload
+	payload.Message = p.message.Localize(locale)
+	return payload
 }
 
 type ChatHistoryPayload struct {
@@ -24,6 +24,7 @@ func (m *Manager) startCleanupService() {
 	for {
 		select {
 		case <-ticker.C:
+			m.checkAFKPlayers()
 			m.cleanupInactiveGames()
 		case <-m.stopCleanup:
 			logger.Info("Game cleanup service stopped")
@@ -43,30 +44,7 @@ func (m *Manager) cleanupInactiveGames() {
 	for roomCode, game := range m.games {
 		game.mu.RLock()
 
-		// Check for AFK players in active games first (before checking inactivity)
-		if game.Status == models.GameStatusInProgress {
-			game.mu.RUnlock()             // Unlock before calling functions
-			afkTimeout := 3 * time.Minute // 3 minutes AFK timeout
-
-			// First, check if all human players are AFK and end game if so
-			gameEnded, err := m.CheckAndHandleAllAFK(roomCode, afkTimeout)
-			if err != nil {
-				logger.Error("Failed to check if all players are AFK",
-					"room_code", roomCode,
-					"error", err)
-			}
-
-			// If game wasn't ended, try to replace individual AFK players with bots
-			if !gameEnded {
-				if _, err := m.CheckAndReplaceAFKPlayers(roomCode, afkTimeout); err != nil {
-					logger.Error("Failed to check and replace AFK players",
-						"room_code", roomCode,
-						"error", err)
-				}
-			}
-
-			game.mu.RLock() // Re-lock for the rest of the checks
-		}
+		// AFK players were handled by checkAFKPlayers before this pass
 
 		// Count active/connected players
 		activePlayerCount := 0
@@ -116,6 +94,10 @@ func (m *Manager) cleanupInactiveGames() {
 
 			// Mark game as abandoned in database
 			m.markGameAsAbandoned(game)
+
+			game.mu.Lock()
+			m.notifyGameRemoved(game, false)
+			game.mu.Unlock()
 		} else {
 			game.mu.RUnlock()
 		}
@@ -129,9 +111,12 @@ func (m *Manager) cleanupInactiveGames() {
 			"empty_room_codes", emptyRooms,
 			"occupied_room_codes", occupiedRooms)
 
-		// Remove from memory
+		// Remove from memory, and from the cache so the game isn't resumed
 		for _, roomCode := range toRemove {
 			delete(m.games, roomCode)
+			if err := m.DeleteGameFromRedis(context.Background(), roomCode); err != nil {
+				logger.Error("Failed to delete game from Redis", "error", err, "room_code", roomCode)
+			}
 		}
 	}
 }
@@ -149,7 +134,7 @@ func (m *Manager) broadcastGameClosure(game *GameState, reason string) {
 }
 
 func (m *Manager) markGameAsAbandoned(game *GameState) {
-	if err := m.UpdateGameStatus(context.Background(), game.ID, models.GameStatusAbandoned); err != nil {
+	if err := m.repository(game).UpdateGameStatus(context.Background(), game.ID, models.GameStatusAbandoned); err != nil {
 		logger.Error("Failed to mark game as abandoned",
 			"error", err,
 			"room_code", game.RoomCode,
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode/utf8"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/services/namepolicy"
+)
+
+// Error codes for clues refused by the clue rules
+const (
+	ErrCodeClueTooLong      = "clue_too_long"
+	ErrCodeClueTooManyWords = "clue_too_many_words"
+	ErrCodeClueProfanity    = "clue_profanity"
+	ErrCodeClueNamesCard    = "clue_names_card"
+)
+
+const (
+	// DefaultMaxClueLength is the longest clue, in characters, unless the
+	// deployment sets another cap
+	DefaultMaxClueLength = 100
+
+	// maxClueWordLimit is the highest word limit a host may set
+	maxClueWordLimit = 10
+)
+
+// fallbackClues replace generated clues the room's rules refuse
+var fallbackClues = []string{"Mystery", "Adventure", "Dream", "Journey", "Magic"}
+
+// SetMaxClueLength replaces the deployment-wide clue length cap (0 or less
+// restores the default)
+func (m *Manager) SetMaxClueLength(length int) {
+	m.maxClueLength.Store(int64(length))
+}
+
+// clueLengthCap is the longest clue the deployment accepts, in characters
+func (m *Manager) clueLengthCap() int {
+	if length := int(m.maxClueLength.Load()); length > 0 {
+		return length
+	}
+	return DefaultMaxClueLength
+}
+
+// validateClueWordLimit checks the host's clue word limit
+func (s GameSettings) validateClueWordLimit() error {
+	if s.ClueWordLimit < 0 || s.ClueWordLimit > maxClueWordLimit {
+		return fmt.Errorf("clue word limit must be between 0 and %d", maxClueWordLimit)
+	}
+	return nil
+}
+
+// checkClueText enforces the length cap, the room's word limit and the
+// blocked word list on a clue
+func checkClueText(clue string, maxLength, wordLimit int) error {
+	if strings.TrimSpace(clue) == "" {
+		return fmt.Errorf("clue is required")
+	}
+	if utf8.RuneCountInString(clue) > maxLength {
+		return &GameError{
+			Code:    ErrCodeClueTooLong,
+			Message: fmt.Sprintf("Clues can be at most %d characters", maxLength),
+			Details: map[string]interface{}{"max_length": maxLength},
+		}
+	}
+	if wordLimit > 0 && len(strings.Fields(clue)) > wordLimit {
+		return &GameError{
+			Code:    ErrCodeClueTooManyWords,
+			Message: fmt.Sprintf("Clues in this room can be at most %d words", wordLimit),
+			Details: map[string]interface{}{"word_limit": wordLimit},
+		}
+	}
+	if namepolicy.ContainsBlockedWord(clue) {
+		return &GameError{Code: ErrCodeClueProfanity, Message: "Please choose a different clue"}
+	}
+	return nil
+}
+
+// namesTitle reports whether a clue literally spells out a card title,
+// ignoring case and punctuation
+func namesTitle(clue, title string) bool {
+	title = normalizeTitle(title)
+	if title == "" {
+		return false
+	}
+	return strings.Contains(" "+normalizeTitle(clue)+" ", " "+title+" ")
+}
+
+// clueNamesCard reports whether a clue names the title of the storyteller's
+// card. A failed lookup lets the clue through rather than stall the round.
+func (m *Manager) clueNamesCard(game *GameState, clue string, cardID int) bool {
+	texts, err := m.repository(game).GetCardTexts(context.Background(), []int{cardID})
+	if err != nil {
+		logger.Error("Failed to load the card title to check a clue", "error", err, "room_code", game.RoomCode, "card_id", cardID)
+		return false
+	}
+	return namesTitle(clue, texts[cardID].Title)
+}
+
+// checkClue enforces the clue rules for the storyteller's card
+func (m *Manager) checkClue(game *GameState, clue string, cardID int) error {
+	if err := checkClueText(clue, m.clueLengthCap(), game.Settings.ClueWordLimit); err != nil {
+		return err
+	}
+	if m.clueNamesCard(game, clue, cardID) {
+		return &GameError{Code: ErrCodeClueNamesCard, Message: "Clues can't name the card's title"}
+	}
+	return nil
+}
+
+// fitClueToRules rewrites a generated clue (a bot's, or one picked for a
+// player out of time) so the clue rules accept it
+func (m *Manager) fitClueToRules(game *GameState, clue string, cardID int) string {
+	if limit := game.Settings.ClueWordLimit; limit > 0 {
+		if words := strings.Fields(clue); len(words) > limit {
+			clue = strings.Join(words[:limit], " ")
+		}
+	}
+	if maxLength := m.clueLengthCap(); utf8.RuneCountInString(clue) > maxLength {
+		clue = strings.TrimSpace(string([]rune(clue)[:maxLength]))
+	}
+	if checkClueText(clue, m.clueLengthCap(), game.Settings.ClueWordLimit) == nil && !m.clueNamesCard(game, clue, cardID) {
+		return clue
+	}
+	for _, i := range rand.Perm(len(fallbackClues)) {
+		if !m.clueNamesCard(game, fallbackClues[i], cardID) {
+			return fallbackClues[i]
+		}
+	}
+	return fallbackClues[0]
+}
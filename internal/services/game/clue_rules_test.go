@@ -0,0 +1,60 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func clueErrorCode(err error) string {
+	var gameErr *GameError
+	if errors.As(err, &gameErr) {
+		return gameErr.Code
+	}
+	return ""
+}
+
+func TestCheckClueText(t *testing.T) {
+	assert.NoError(t, checkClueText("A long way from home", 100, 0))
+	assert.Error(t, checkClueText("   ", 100, 0))
+	assert.Equal(t, ErrCodeClueTooLong, clueErrorCode(checkClueText(strings.Repeat("é", 11), 10, 0)))
+	assert.NoError(t, checkClueText(strings.Repeat("é", 10), 10, 0), "length counts characters, not bytes")
+	assert.Equal(t, ErrCodeClueTooManyWords, clueErrorCode(checkClueText("A long way from home", 100, 3)))
+	assert.NoError(t, checkClueText("Far from home", 100, 3))
+	assert.Equal(t, ErrCodeClueProfanity, clueErrorCode(checkClueText("Shit happens", 100, 0)))
+}
+
+func TestNamesTitle(t *testing.T) {
+	assert.True(t, namesTitle("the lighthouse", "The Lighthouse"))
+	assert.True(t, namesTitle("Alone at The Lighthouse!", "The Lighthouse"))
+	assert.False(t, namesTitle("Lighthouses", "The Lighthouse"))
+	assert.False(t, namesTitle("Lighthouse", "The Lighthouse"))
+	assert.False(t, namesTitle("Anything", ""))
+}
+
+func TestClueWordLimitSetting(t *testing.T) {
+	settings := DefaultGameSettings()
+	settings.ClueWordLimit = 3
+	_, err := ValidateSettings(settings)
+	assert.NoError(t, err)
+
+	settings.ClueWordLimit = maxClueWordLimit + 1
+	_, err = ValidateSettings(settings)
+	assert.Error(t, err)
+}
+
+func TestFitClueToRules(t *testing.T) {
+	m := NewEphemeralManager()
+	m.SetMaxClueLength(12)
+	game := &GameState{Sandbox: true, Settings: DefaultGameSettings()}
+	game.Settings.ClueWordLimit = 2
+
+	assert.Equal(t, "Far from", m.fitClueToRules(game, "Far from home", 1))
+	assert.Equal(t, "Extraordinar", m.fitClueToRules(game, "Extraordinarily", 1))
+	assert.Contains(t, fallbackClues, m.fitClueToRules(game, "Shit happens", 1))
+
+	m.SetMaxClueLength(0)
+	assert.Equal(t, DefaultMaxClueLength, m.clueLengthCap())
+}
@@ -0,0 +1,29 @@
+package game
+
+// Transport names reported by connections
+const (
+	TransportWebSocket   = "websocket"
+	TransportLongPolling = "long_polling"
+)
+
+// Connection is a transport-neutral channel to a single client.
+// The WebSocket and HTTP long-polling transports both implement it, so game
+// logic never needs to know how a player is connected.
+type Connection interface {
+	// Send delivers an already encoded message
+	Send(data []byte) error
+	// SendJSON encodes and delivers a message
+	SendJSON(v interface{}) error
+	// Transport names the underlying transport
+	Transport() string
+	// ProtocolVersion is the message protocol the client negotiated (ProtocolV1, ProtocolV2)
+	ProtocolVersion() int
+	// Locale is the language server-generated text is sent in (i18n.DefaultLocale when unknown)
+	Locale() string
+}
+
+// Localizer is implemented by payloads whose text depends on the recipient's
+// locale. Broadcasts render it once per locale in the room.
+type Localizer interface {
+	Localize(locale string) interface{}
+}
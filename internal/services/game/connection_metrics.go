@@ -0,0 +1,104 @@
+package game
+
+import (
+	"strconv"
+	"time"
+
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+)
+
+// Disconnect causes, as recorded in disconnects_total
+const (
+	DisconnectClosed     = "closed"      // The client closed the connection or stopped polling
+	DisconnectSendFailed = "send_failed" // Writing to the client failed
+	DisconnectLeft       = "left"        // The player left a game in progress
+)
+
+// Histogram buckets, in seconds. They are dense around the AFK timeouts
+// (3 minutes by default) so the thresholds can be tuned from the data.
+var (
+	disconnectDurationBuckets = []int64{5, 15, 30, 60, 90, 120, 180, 240, 300, 600, 1800}
+	afkIdleBuckets            = []int64{30, 60, 90, 120, 150, 180, 240, 300, 600, 1800}
+)
+
+// MarkDisconnected drops a player's connection and records why. The caller
+// holds the game lock.
+func (p *Player) MarkDisconnected(game *GameState, cause string) {
+	wasConnected := p.IsConnected
+	p.IsConnected = false
+	p.Connection = nil
+	p.Latency, p.rtt = "", 0
+	if p.IsBot || !wasConnected {
+		return
+	}
+
+	p.disconnectedAt = time.Now()
+	metrics.GetCounter(metrics.Name("disconnects_total", connectionLabels(game, "cause", cause)...)).Inc()
+}
+
+// markReconnected records how long a player was away, if they were. The
+// caller holds the game lock.
+func (p *Player) markReconnected(game *GameState) {
+	if p.disconnectedAt.IsZero() {
+		return
+	}
+	away := time.Since(p.disconnectedAt)
+	p.disconnectedAt = time.Time{}
+
+	labels := connectionLabels(game)
+	metrics.GetCounter(metrics.Name("reconnects_total", labels...)).Inc()
+	metrics.GetHistogram(metrics.Name("disconnect_duration_seconds", labels...), disconnectDurationBuckets).
+		Observe(int64(away.Seconds()))
+}
+
+// recordReconnectFailure counts a resume attempt that found no seat to return to
+func recordReconnectFailure(reason string) {
+	metrics.GetCounter(metrics.Name("reconnect_failures_total", "reason", reason)).Inc()
+}
+
+// afkCause tells whether an AFK player dropped their connection or left the game
+func afkCause(player *Player) string {
+	if !player.IsActive {
+		return DisconnectLeft
+	}
+	return DisconnectClosed
+}
+
+// recordAFKReplacement counts a player handed to a bot for inactivity, with
+// how long they had been idle
+func recordAFKReplacement(game *GameState, cause string, idle, timeout time.Duration) {
+	labels := connectionLabels(game, "cause", cause)
+	metrics.GetCounter(metrics.Name("afk_replacements_total", labels...)).Inc()
+	metrics.GetHistogram(metrics.Name("afk_idle_seconds", connectionLabels(game)...), afkIdleBuckets).
+		Observe(int64(idle.Seconds()))
+	pace := game.Settings.Pace
+	if pace == "" {
+		pace = PaceStandard
+	}
+	metrics.GetGauge(metrics.Name("afk_timeout_seconds", "pace", pace, "phase", gamePhase(game))).Set(int64(timeout.Seconds()))
+}
+
+// connectionLabels breaks connection metrics down by room size and game phase
+func connectionLabels(game *GameState, extra ...string) []string {
+	return append([]string{"room_size", strconv.Itoa(seatCount(game)), "phase", gamePhase(game)}, extra...)
+}
+
+// seatCount is the number of seats, not counting players who were replaced
+func seatCount(game *GameState) int {
+	seats := 0
+	for _, player := range game.Players {
+		if !player.WasReplaced {
+			seats++
+		}
+	}
+	return seats
+}
+
+// gamePhase is the round status during a game, or the game status otherwise
+func gamePhase(game *GameState) string {
+	if game.Status == models.GameStatusInProgress && game.CurrentRound != nil {
+		return string(game.CurrentRound.Status)
+	}
+	return string(game.Status)
+}
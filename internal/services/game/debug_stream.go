@@ -0,0 +1,98 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// Debug stream event kinds
+const (
+	DebugEventPhase       = "phase"        // The game or its round moved to another status
+	DebugEventTimer       = "timer"        // A delayed action was scheduled
+	DebugEventBotDecision = "bot_decision" // A bot chose a card; details carry every card's score
+)
+
+// WatchDebugStream subscribes a player to, or unsubscribes them from, a
+// room's debug stream: internal events mirrored to their connection as
+// debug_event messages. Only the host may watch, or an admin from anywhere.
+func (m *Manager) WatchDebugStream(roomCode string, playerID uuid.UUID, watch, admin bool) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+
+	game.mu.RLock()
+	host := game.HostID == playerID
+	game.mu.RUnlock()
+	if watch && !host && !admin {
+		return fmt.Errorf("only the host can watch the debug stream")
+	}
+
+	game.debugMu.Lock()
+	defer game.debugMu.Unlock()
+	if !watch {
+		delete(game.debugWatchers, playerID)
+		return nil
+	}
+	if game.debugWatchers == nil {
+		game.debugWatchers = make(map[uuid.UUID]bool)
+	}
+	game.debugWatchers[playerID] = true
+
+	logger.Info("Debug stream watcher added", "room_code", roomCode, "player_id", playerID, "admin", admin && !host)
+	return nil
+}
+
+// debugEvent sends an event to the room's debug watchers who are connected.
+// It costs next to nothing while nobody watches.
+func (m *Manager) debugEvent(game *GameState, kind string, details map[string]interface{}) {
+	game.debugMu.Lock()
+	watchers := make([]uuid.UUID, 0, len(game.debugWatchers))
+	for playerID := range game.debugWatchers {
+		watchers = append(watchers, playerID)
+	}
+	game.debugMu.Unlock()
+	if len(watchers) == 0 {
+		return
+	}
+
+	message := GameMessage{
+		Type: MessageTypeDebugEvent,
+		Payload: DebugEventPayload{
+			RoomCode: game.RoomCode,
+			Kind:     kind,
+			At:       time.Now(),
+			Details:  details,
+		},
+	}
+	for _, playerID := range watchers {
+		conn := GetPlayerConnection(playerID)
+		if conn == nil {
+			continue
+		}
+		if err := conn.SendJSON(message); err != nil {
+			logger.Debug("Debug event not delivered", "error", err, "player_id", playerID)
+		}
+	}
+}
+
+// debugPhase reports a status change. Callers must hold the game lock.
+func (m *Manager) debugPhase(game *GameState, status string) {
+	m.debugEvent(game, DebugEventPhase, map[string]interface{}{
+		"status": status,
+		"round":  game.RoundNumber,
+	})
+}
+
+// schedule runs f after d, like after, and reports the timer to the debug stream
+func (m *Manager) schedule(game *GameState, timer string, d time.Duration, f func()) {
+	m.debugEvent(game, DebugEventTimer, map[string]interface{}{
+		"timer":    timer,
+		"delay_ms": d.Milliseconds(),
+	})
+	m.after(d, f)
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugStreamWatchers(t *testing.T) {
+	host, guest, admin := uuid.New(), uuid.New(), uuid.New()
+	gs := &GameState{
+		RoomCode: "DEBUG",
+		HostID:   host,
+		Status:   models.GameStatusWaiting,
+		Players: map[uuid.UUID]*Player{
+			host:  {ID: host, Name: "Host"},
+			guest: {ID: guest, Name: "Guest"},
+		},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}}
+
+	hostConn, guestConn, adminConn := &recordingConnection{}, &recordingConnection{}, &recordingConnection{}
+	for id, conn := range map[uuid.UUID]*recordingConnection{host: hostConn, guest: guestConn, admin: adminConn} {
+		RegisterPlayerConnection(id, conn)
+		defer UnregisterPlayerConnection(id, conn)
+	}
+
+	assert.EqualError(t, m.WatchDebugStream("DEBUG", guest, true, false), "only the host can watch the debug stream")
+	require.NoError(t, m.WatchDebugStream("DEBUG", host, true, false))
+	require.NoError(t, m.WatchDebugStream("DEBUG", admin, true, true))
+
+	m.debugPhase(gs, string(models.RoundStatusVoting))
+	assert.Equal(t, []MessageType{MessageTypeDebugEvent}, hostConn.types())
+	assert.Equal(t, []MessageType{MessageTypeDebugEvent}, adminConn.types())
+	assert.Empty(t, guestConn.messages)
+
+	payload := hostConn.messages[0].Payload.(map[string]interface{})
+	assert.Equal(t, DebugEventPhase, payload["kind"])
+	assert.Equal(t, "voting", payload["details"].(map[string]interface{})["status"])
+
+	require.NoError(t, m.WatchDebugStream("DEBUG", host, false, false))
+	m.debugPhase(gs, string(models.RoundStatusScoring))
+	assert.Len(t, hostConn.messages, 1)
+	assert.Len(t, adminConn.messages, 2)
+}
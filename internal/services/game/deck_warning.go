@@ -0,0 +1,39 @@
+package game
+
+import (
+	"strconv"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+)
+
+// roundsLeftInDeck is how many more rounds can be played, the coming one
+// included, before refilling hands exhausts the deck. Every round costs each
+// seated player one card; the game ends after a round whose refill leaves
+// the deck empty and a hand short.
+func roundsLeftInDeck(deckRemaining, seatedPlayers int) int {
+	if seatedPlayers <= 0 {
+		return 1
+	}
+	return 1 + deckRemaining/seatedPlayers
+}
+
+// warnIfLastRound tells the room, once hands are refilled after a round,
+// that the coming round will be the last because the deck is running out.
+// Callers hold the game lock.
+func (m *Manager) warnIfLastRound(game *GameState) {
+	if roundsLeftInDeck(len(game.Deck), game.seatedPlayerCount()) > 1 {
+		return
+	}
+
+	logger.Info("Deck running out, next round is the last",
+		"room_code", game.RoomCode,
+		"round", game.RoundNumber,
+		"cards_remaining", len(game.Deck))
+
+	m.BroadcastToGame(game, MessageTypeDeckLow, DeckLowPayload{
+		DeckRemaining: len(game.Deck),
+		RoundsLeft:    1,
+	})
+	m.SendSystemMessage(game.RoomCode, i18n.Msg("Deck running low: round {1} is the last!", strconv.Itoa(game.RoundNumber+1)))
+}
@@ -0,0 +1,15 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundsLeftInDeck(t *testing.T) {
+	assert.Equal(t, 1, roundsLeftInDeck(0, 4), "the coming round is played from full hands")
+	assert.Equal(t, 1, roundsLeftInDeck(3, 4), "a refill the deck can't cover ends the game")
+	assert.Equal(t, 2, roundsLeftInDeck(4, 4), "an exact refill empties the deck but keeps hands full")
+	assert.Equal(t, 5, roundsLeftInDeck(33, 8))
+	assert.Equal(t, 1, roundsLeftInDeck(10, 0))
+}
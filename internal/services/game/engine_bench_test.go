@@ -0,0 +1,168 @@
+package game
+
+import (
+	"strconv"
+	"testing"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Benchmarks for the engine's hot paths, on a full room: 6 players and the
+// 84-card deck. Compare runs with scripts/benchcmp.
+
+// discardConnection is a connection that drops everything sent to it
+type discardConnection struct {
+	protocol int
+}
+
+func (c discardConnection) Send(data []byte) error       { return nil }
+func (c discardConnection) SendJSON(v interface{}) error { return nil }
+func (c discardConnection) Transport() string            { return "bench" }
+func (c discardConnection) ProtocolVersion() int         { return c.protocol }
+func (c discardConnection) Locale() string               { return i18n.DefaultLocale }
+
+// benchGame deals a 6-player game in the voting phase of round 1, with every
+// card submitted and every vote cast
+func benchGame(b *testing.B, protocol int) (*Manager, *GameState) {
+	b.Helper()
+	// The engine logs every broadcast at info level
+	logger.InitLogger(logger.Config{Level: "error", Format: "text"})
+
+	m := NewEphemeralManager()
+	game := &GameState{
+		ID:       uuid.New(),
+		RoomCode: "BENCH",
+		Players:  make(map[uuid.UUID]*Player, 6),
+		Status:   models.GameStatusInProgress,
+		Deck:     ShuffledDeck("bench"),
+		Settings: DefaultGameSettings(),
+		Sandbox:  true,
+	}
+	players := make([]uuid.UUID, 6)
+	for i := range players {
+		players[i] = uuid.New()
+		game.Players[players[i]] = &Player{
+			ID:          players[i],
+			Name:        "Player " + strconv.Itoa(i+1),
+			Position:    i,
+			Connection:  discardConnection{protocol: protocol},
+			IsConnected: true,
+			IsActive:    true,
+		}
+	}
+	m.dealCards(game)
+
+	round := &Round{
+		ID:              uuid.New(),
+		RoundNumber:     1,
+		StorytellerID:   players[0],
+		Clue:            "a quiet storm",
+		Status:          models.RoundStatusVoting,
+		StorytellerCard: game.Players[players[0]].Hand[0],
+		Submissions:     make(map[uuid.UUID]*CardSubmission),
+		Votes:           make(map[uuid.UUID]*Vote),
+	}
+	for _, playerID := range players[1:] {
+		round.Submissions[playerID] = &CardSubmission{PlayerID: playerID, CardID: game.Players[playerID].Hand[0]}
+	}
+	// Half find the storyteller's card, the rest are fooled by player 2
+	for i, playerID := range players[1:] {
+		cardID := round.StorytellerCard
+		if i%2 == 1 {
+			cardID = round.Submissions[players[1]].CardID
+		}
+		round.Votes[playerID] = &Vote{PlayerID: playerID, CardID: cardID}
+	}
+	game.CurrentRound = round
+	game.RoundNumber = 1
+	return m, game
+}
+
+func BenchmarkBroadcastToGame(b *testing.B) {
+	for _, protocol := range []int{ProtocolV1, ProtocolV2} {
+		b.Run("game_state_v"+strconv.Itoa(protocol), func(b *testing.B) {
+			m, game := benchGame(b, protocol)
+			payload := GameStatePayload{GameState: game}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.BroadcastToGame(game, MessageTypeGameState, payload)
+			}
+		})
+	}
+
+	b.Run("vote_submitted", func(b *testing.B) {
+		m, game := benchGame(b, ProtocolV1)
+		payload := map[string]interface{}{"player_id": uuid.New(), "votes_count": 3}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			m.BroadcastToGame(game, MessageTypeVoteSubmitted, payload)
+		}
+	})
+}
+
+func BenchmarkCalculateScores(b *testing.B) {
+	for _, variant := range []struct {
+		name    string
+		scoring ScoringOptions
+	}{
+		{"standard", DefaultGameSettings().Scoring},
+		{"all_modifiers", ScoringOptions{
+			StreakBonus: true, StreakThreshold: 3, StreakBonusPoints: 1,
+			DiminishingFooling: true, FoolingFullValue: 2,
+			StorytellerCap: true, StorytellerMaxLead: 10,
+		}},
+	} {
+		b.Run(variant.name, func(b *testing.B) {
+			m, game := benchGame(b, ProtocolV1)
+			game.Settings.Scoring = variant.scoring
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				game.history = nil // Score as a first round every time
+				m.calculateScores(game)
+			}
+		})
+	}
+}
+
+func BenchmarkShuffledDeck(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ShuffledDeck("bench-" + strconv.Itoa(i))
+	}
+}
+
+func BenchmarkDealCards(b *testing.B) {
+	m, game := benchGame(b, ProtocolV1)
+	deck := ShuffledDeck("bench")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.Deck = append(game.Deck[:0], deck...)
+		for _, player := range game.Players {
+			player.Hand = player.Hand[:0]
+		}
+		m.dealCards(game)
+	}
+}
+
+func BenchmarkRefillHands(b *testing.B) {
+	m, game := benchGame(b, ProtocolV1)
+	deck := ShuffledDeck("bench")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.Deck = append(game.Deck[:0], deck...)
+		// Everyone played a card this round
+		for _, player := range game.Players {
+			player.Hand = player.Hand[:5]
+		}
+		m.refillHands(game)
+	}
+}
@@ -0,0 +1,49 @@
+package game
+
+import "errors"
+
+// Error codes for rule violations clients can react to
+const (
+	ErrCodeBotLimit         = "bot_limit_reached"
+	ErrCodeNotEnoughHumans  = "not_enough_humans"
+	ErrCodeSelfVote         = "self_vote"
+	ErrCodeInvalidRoomCode  = "invalid_room_code"
+	ErrCodeRoomCodeTaken    = "room_code_taken"
+	ErrCodeRankedRestricted = "ranked_restricted"
+	ErrCodePasswordRequired = "password_required"
+	ErrCodeWrongPassword    = "wrong_password"
+	ErrCodeRoomElsewhere    = "room_elsewhere"
+	ErrCodeNotHost          = "not_host"
+	ErrCodeKickVoteActive   = "kick_vote_in_progress"
+	ErrCodeNotEnoughVoters  = "not_enough_voters"
+	ErrCodeTeammateVote     = "teammate_vote"
+	ErrCodeSeatTaken        = "seat_taken"
+	ErrCodeConnectionInUse  = "connection_in_use"
+)
+
+// GameError is a structured rule violation. Code is stable for clients to
+// switch on; Message is for display; Details carries the limits involved.
+type GameError struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+func (e *GameError) Error() string {
+	return e.Message
+}
+
+// Is matches game errors by code, so errors.Is(err, &GameError{Code: ErrCodeBotLimit}) works
+func (e *GameError) Is(target error) bool {
+	var other *GameError
+	return errors.As(target, &other) && other.Code == e.Code
+}
+
+// AsGameError unwraps a structured game error, if err is one
+func AsGameError(err error) (*GameError, bool) {
+	var gameErr *GameError
+	if errors.As(err, &gameErr) {
+		return gameErr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"strconv"
+
+	"dixitme/internal/events"
+	"dixitme/internal/metrics"
+)
+
+// registerEventMetrics counts the manager's domain events. Sandbox games
+// are left out, like everywhere else in the stats.
+func (m *Manager) registerEventMetrics() {
+	events.Subscribe(m.bus, TopicGameCreated, func(e GameCreated) {
+		if e.Game.Sandbox {
+			return
+		}
+		metrics.GetCounter(metrics.Name("games_created_total", "ranked", strconv.FormatBool(e.Game.Settings.Ranked))).Inc()
+	})
+	events.Subscribe(m.bus, TopicPlayerJoined, func(e PlayerJoined) {
+		if e.Game.Sandbox {
+			return
+		}
+		metrics.GetCounter("players_joined_total").Inc()
+	})
+	events.Subscribe(m.bus, TopicPlayerLeft, func(e PlayerLeft) {
+		if e.Game.Sandbox {
+			return
+		}
+		metrics.GetCounter(metrics.Name("players_left_total", "phase", gamePhase(e.Game))).Inc()
+	})
+	events.Subscribe(m.bus, TopicRoundCompleted, func(e RoundCompleted) {
+		if e.Game.Sandbox {
+			return
+		}
+		metrics.GetCounter("rounds_completed_total").Inc()
+	})
+	events.Subscribe(m.bus, TopicGameCompleted, func(e GameCompleted) {
+		if e.Game.Sandbox {
+			return
+		}
+		metrics.GetCounter(metrics.Name("games_completed_total", "status", string(e.Game.Status))).Inc()
+	})
+	events.Subscribe(m.bus, TopicTurnTimedOut, func(e TurnTimedOut) {
+		metrics.GetCounter(metrics.Name("turn_timeouts_total", "phase", string(e.Phase))).Inc()
+	})
+	events.Subscribe(m.bus, TopicStandInReported, func(e StandInReported) {
+		delivery := "ws"
+		if !e.Delivered {
+			delivery = "offline"
+		}
+		metrics.GetCounter(metrics.Name("stand_in_results_total", "delivery", delivery)).Inc()
+	})
+}
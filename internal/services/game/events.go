@@ -0,0 +1,116 @@
+package game
+
+import (
+	"dixitme/internal/events"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Domain events the manager publishes on its event bus. Handlers run
+// synchronously while the game is locked (the whole manager, for
+// GameCreated): they must not call back into the manager, and slow work
+// belongs in a goroutine working on copied data.
+var (
+	TopicGameCreated     = events.NewTopic[GameCreated]("game.created")
+	TopicGameStarted     = events.NewTopic[GameStarted]("game.started")
+	TopicGameRemoved     = events.NewTopic[GameRemoved]("game.removed")
+	TopicPlayerJoined    = events.NewTopic[PlayerJoined]("game.player_joined")
+	TopicPlayerLeft      = events.NewTopic[PlayerLeft]("game.player_left")
+	TopicPlayerReplaced  = events.NewTopic[PlayerReplaced]("game.player_replaced")
+	TopicRoundCompleted  = events.NewTopic[RoundCompleted]("game.round_completed")
+	TopicGameCompleted   = events.NewTopic[GameCompleted]("game.completed")
+	TopicStandInReported = events.NewTopic[StandInReported]("game.stand_in_reported")
+	TopicTurnTimedOut    = events.NewTopic[TurnTimedOut]("game.turn_timed_out")
+	TopicSettingsUpdated = events.NewTopic[SettingsUpdated]("game.settings_updated")
+	TopicSeatTakenOver   = events.NewTopic[SeatTakenOver]("game.seat_taken_over")
+)
+
+// GameCreated is published once a new room is stored and persisted
+type GameCreated struct {
+	Game *GameState
+}
+
+// GameStarted is published once the host starts the game and the first round is dealt
+type GameStarted struct {
+	Game *GameState
+}
+
+// GameRemoved is published when a room is unloaded before it finished:
+// deleted from the lobby by its host (Deleted, its record is gone too), or
+// closed by the cleanup service, which marks its record abandoned
+type GameRemoved struct {
+	Game    *GameState
+	Deleted bool
+}
+
+// PlayerJoined is published when a new player takes a seat in the lobby
+type PlayerJoined struct {
+	Game   *GameState
+	Player *Player
+}
+
+// PlayerLeft is published when a player leaves. In the lobby they lose their
+// seat (Removed); in a running game they are only marked away.
+type PlayerLeft struct {
+	Game    *GameState
+	Player  *Player
+	Removed bool
+}
+
+// PlayerReplaced is published when a bot takes over a player's seat
+type PlayerReplaced struct {
+	Game     *GameState
+	PlayerID uuid.UUID
+	BotID    uuid.UUID
+	Reason   string
+}
+
+// RoundCompleted is published after a round is scored, with each player's points for it
+type RoundCompleted struct {
+	Game   *GameState
+	Round  *Round
+	Points map[uuid.UUID]int
+}
+
+// GameCompleted is published when a game ends, played out or abandoned by
+// every human. Game.Status tells them apart; the outcome of an abandoned
+// casual game is empty.
+type GameCompleted struct {
+	Game   *GameState
+	Result *GameResult
+}
+
+// StandInReported is published when a player replaced by a bot is told how
+// their stand-in did. Delivered is false when they were offline, for
+// consumers that keep the report for later.
+type StandInReported struct {
+	PlayerID  uuid.UUID
+	Result    StandInResultPayload
+	Delivered bool
+}
+
+// TurnTimedOut is published when a player's time to move ran out and a bot
+// moved for them
+type TurnTimedOut struct {
+	Game     *GameState
+	PlayerID uuid.UUID
+	Phase    models.RoundStatus
+}
+
+// SettingsUpdated is published when the host changes a waiting room's settings
+type SettingsUpdated struct {
+	Game *GameState
+}
+
+// SeatTakenOver is published when a human takes over a bot's seat mid-game
+type SeatTakenOver struct {
+	Game   *GameState
+	BotID  uuid.UUID
+	Player *Player
+}
+
+// Events is the bus the manager publishes its domain events on
+func (m *Manager) Events() *events.Bus {
+	return m.bus
+}
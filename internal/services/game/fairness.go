@@ -0,0 +1,194 @@
+package game
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"dixitme/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrFairnessNotFound is returned when a room has no recorded shuffle
+var ErrFairnessNotFound = errors.New("fairness proof not found")
+
+// classicDeckSize is the number of cards in the classic deck (IDs 1-84), which
+// sandbox rooms and rooms created before card packs play with
+const classicDeckSize = 84
+
+// ShuffleAlgorithm describes how the deck order follows from the seed, so
+// players can recompute it with any SHA-256 implementation
+const ShuffleAlgorithm = "fisher-yates over the pack cards in ascending order (cards 1-84 when none are listed) " +
+	"followed by any expansion cards in ascending order, " +
+	"swapping index i (from the last index down to 1) with " +
+	"j = uint64be(sha256(seed || \":deck:\" || i)[0:8]) mod (i+1); " +
+	"commitment = hex(sha256(seed || \":\" || comma-separated deck order)); " +
+	"mulligans reshuffle with the same rule, labelled \":mulligan:<round>:<n>\"; " +
+	"with avoided cards (fresh cards rooms), the deck is instead sorted by descending key " +
+	"ln(u)/w, u = (uint64be(sha256(seed || \":fresh:\" || card)[0:8]) + 0.5) / 2^64, " +
+	"w = 0.25 for avoided cards and 1 otherwise, ties by card ID"
+
+// FairnessProof lets players check that the deck order was fixed when the
+// room was created. The seed stays secret until the game is over.
+type FairnessProof struct {
+	RoomCode   string            `json:"room_code"`
+	Status     models.GameStatus `json:"status"`
+	Algorithm  string            `json:"algorithm"`
+	Commitment string            `json:"commitment"` // Published when the room is created
+	Revealed   bool              `json:"revealed"`
+	Seed       string            `json:"seed,omitempty"`       // Hex, once the game is over
+	DeckOrder  []int             `json:"deck_order,omitempty"` // Initial deck, top card first
+	Verified   bool              `json:"verified"`             // The seed reproduces the commitment
+
+	// Cards the table saw recently, which a fresh cards shuffle weighs down.
+	// Public from the start, since the commitment is made with them.
+	AvoidedCards []int `json:"avoided_cards,omitempty"`
+
+	// Cards of the packs the room was created with; the classic deck when empty
+	PackCards []int `json:"pack_cards,omitempty"`
+
+	// Cards of the room's expansions, shuffled in after the pack cards when
+	// the game started
+	ExpansionCards []int `json:"expansion_cards,omitempty"`
+}
+
+// newShuffleSeed returns a fresh random seed in hex
+func newShuffleSeed() (string, error) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return "", fmt.Errorf("failed to generate shuffle seed: %w", err)
+	}
+	return hex.EncodeToString(seed), nil
+}
+
+// seededIntn returns a deterministic intn drawing from the seed under a label
+func seededIntn(seed, label string) func(int) int {
+	draw := 0
+	return func(n int) int {
+		sum := sha256.Sum256([]byte(seed + label + strconv.Itoa(draw)))
+		draw++
+		return int(binary.BigEndian.Uint64(sum[:8]) % uint64(n))
+	}
+}
+
+// classicDeckCards lists the cards of the classic deck
+func classicDeckCards() []int {
+	cards := make([]int, classicDeckSize)
+	for i := range cards {
+		cards[i] = i + 1
+	}
+	return cards
+}
+
+// deckCards lists the cards a deck is shuffled from: the pack cards (the
+// classic deck when there are none), then the expansion cards, each in
+// ascending order
+func deckCards(packs, expansion []int) []int {
+	cards := append([]int(nil), packs...)
+	if len(cards) == 0 {
+		cards = classicDeckCards()
+	}
+	sort.Ints(cards)
+	extra := append([]int(nil), expansion...)
+	sort.Ints(extra)
+	return append(cards, extra...)
+}
+
+// ShuffledDeck is the initial deck order a seed produces for the classic
+// deck, top card first
+func ShuffledDeck(seed string) []int {
+	return ShuffledDeckOf(seed, deckCards(nil, nil))
+}
+
+// ShuffledDeckOf is the order a seed shuffles the given cards into
+func ShuffledDeckOf(seed string, cards []int) []int {
+	deck := append([]int(nil), cards...)
+	for i := len(deck) - 1; i > 0; i-- {
+		sum := sha256.Sum256([]byte(seed + ":deck:" + strconv.Itoa(i)))
+		j := int(binary.BigEndian.Uint64(sum[:8]) % uint64(i+1))
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+	return deck
+}
+
+// ShuffleCommitment hashes a seed together with the deck order it produced
+func ShuffleCommitment(seed string, deck []int) string {
+	order := make([]string, len(deck))
+	for i, cardID := range deck {
+		order[i] = strconv.Itoa(cardID)
+	}
+	sum := sha256.Sum256([]byte(seed + ":" + strings.Join(order, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyShuffle recomputes the deck order of a revealed seed and reports
+// whether it matches the commitment published at room creation, or at the
+// start of a game with expansions or fresh cards
+func VerifyShuffle(seed, commitment string, packs, avoided, expansion []int) ([]int, bool) {
+	cards := deckCards(packs, expansion)
+	deck := ShuffledDeckOf(seed, cards)
+	if len(avoided) > 0 {
+		deck = FreshShuffledDeckOf(seed, cards, avoided)
+	}
+	return deck, ShuffleCommitment(seed, deck) == strings.ToLower(commitment)
+}
+
+// newFairnessProof builds the proof, revealing the seed only once the game is over
+func newFairnessProof(roomCode string, status models.GameStatus, seed, commitment string, packs, avoided, expansion []int) *FairnessProof {
+	proof := &FairnessProof{
+		RoomCode:       roomCode,
+		Status:         status,
+		Algorithm:      ShuffleAlgorithm,
+		Commitment:     commitment,
+		AvoidedCards:   avoided,
+		PackCards:      packs,
+		ExpansionCards: expansion,
+	}
+	if status == models.GameStatusCompleted || status == models.GameStatusAbandoned {
+		proof.Revealed = true
+		proof.Seed = seed
+		proof.DeckOrder, proof.Verified = VerifyShuffle(seed, commitment, packs, avoided, expansion)
+	}
+	return proof
+}
+
+// GetFairnessProof returns the shuffle commitment of a room, and its seed once the game is over
+func (m *Manager) GetFairnessProof(ctx context.Context, roomCode string) (*FairnessProof, error) {
+	if game := m.getGame(roomCode); game != nil {
+		game.mu.RLock()
+		defer game.mu.RUnlock()
+		// Games restored from Redis no longer hold the seed; the database does
+		if game.shuffleSeed != "" {
+			return newFairnessProof(game.RoomCode, game.Status, game.shuffleSeed, game.ShuffleCommitment, game.packCards, game.avoidedCards, game.expansionCards), nil
+		}
+	}
+	return m.LoadFairnessProof(ctx, roomCode)
+}
+
+// LoadFairnessProof loads the shuffle record of the last game in a room from the database
+func (m *Manager) LoadFairnessProof(ctx context.Context, roomCode string) (*FairnessProof, error) {
+	var record models.Game
+	if err := m.db.WithContext(ctx).Unscoped().
+		Where("room_code = ?", roomCode).
+		Order("created_at DESC").
+		First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFairnessNotFound
+		}
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+	if record.ShuffleCommitment == "" {
+		// Created before shuffles were committed
+		return nil, ErrFairnessNotFound
+	}
+	return newFairnessProof(record.RoomCode, record.Status, record.ShuffleSeed, record.ShuffleCommitment,
+		parseCardList(record.PackCards), parseCardList(record.AvoidedCards), parseCardList(record.ExpansionCards)), nil
+}
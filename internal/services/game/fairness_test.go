@@ -0,0 +1,58 @@
+package game
+
+import (
+	"sort"
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShuffledDeckIsDeterministicPermutation(t *testing.T) {
+	deck := ShuffledDeck("abc123")
+	assert.Equal(t, deck, ShuffledDeck("abc123"))
+	assert.NotEqual(t, deck, ShuffledDeck("abc124"))
+
+	sorted := append([]int{}, deck...)
+	sort.Ints(sorted)
+	for i, cardID := range sorted {
+		assert.Equal(t, i+1, cardID)
+	}
+}
+
+func TestVerifyShuffle(t *testing.T) {
+	seed, err := newShuffleSeed()
+	assert.NoError(t, err)
+	commitment := ShuffleCommitment(seed, ShuffledDeck(seed))
+
+	deck, ok := VerifyShuffle(seed, commitment, nil, nil, nil)
+	assert.True(t, ok)
+	assert.Equal(t, ShuffledDeck(seed), deck)
+
+	_, ok = VerifyShuffle(seed+"0", commitment, nil, nil, nil)
+	assert.False(t, ok)
+}
+
+func TestFairnessProofHidesSeedUntilGameOver(t *testing.T) {
+	seed := "feedface"
+	commitment := ShuffleCommitment(seed, ShuffledDeck(seed))
+
+	live := newFairnessProof("ABC123", models.GameStatusInProgress, seed, commitment, nil, nil, nil)
+	assert.False(t, live.Revealed)
+	assert.Empty(t, live.Seed)
+	assert.Empty(t, live.DeckOrder)
+
+	done := newFairnessProof("ABC123", models.GameStatusCompleted, seed, commitment, nil, nil, nil)
+	assert.True(t, done.Revealed)
+	assert.Equal(t, seed, done.Seed)
+	assert.True(t, done.Verified)
+}
+
+func TestSeededIntnStaysInRange(t *testing.T) {
+	intn := seededIntn("seed", ":mulligan:1:")
+	for n := 1; n < 50; n++ {
+		v := intn(n)
+		assert.True(t, v >= 0 && v < n)
+	}
+}
@@ -0,0 +1,232 @@
+package game
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Fresh cards tuning
+const (
+	freshCardsHistoryGames = 5    // A table's last games whose cards are weighed down
+	freshCardWeight        = 0.25 // Draw weight of a recently seen card; fresh ones weigh 1
+)
+
+// FreshShuffledDeck is the initial deck order a seed produces when the
+// avoided cards are weighed down: a weighted shuffle without replacement,
+// so recently seen cards tend to sink to the bottom of the classic deck
+func FreshShuffledDeck(seed string, avoided []int) []int {
+	return FreshShuffledDeckOf(seed, deckCards(nil, nil), avoided)
+}
+
+// FreshShuffledDeckOf is FreshShuffledDeck over the given cards
+func FreshShuffledDeckOf(seed string, cards, avoided []int) []int {
+	isAvoided := make(map[int]bool, len(avoided))
+	for _, cardID := range avoided {
+		isAvoided[cardID] = true
+	}
+
+	keys := make(map[int]float64, len(cards))
+	deck := append([]int(nil), cards...)
+	for _, cardID := range deck {
+		sum := sha256.Sum256([]byte(seed + ":fresh:" + strconv.Itoa(cardID)))
+		u := (float64(binary.BigEndian.Uint64(sum[:8])) + 0.5) / math.Exp2(64)
+		weight := 1.0
+		if isAvoided[cardID] {
+			weight = freshCardWeight
+		}
+		keys[cardID] = math.Log(u) / weight
+	}
+
+	sort.Slice(deck, func(i, j int) bool {
+		if keys[deck[i]] != keys[deck[j]] {
+			return keys[deck[i]] > keys[deck[j]]
+		}
+		return deck[i] < deck[j]
+	})
+	return deck
+}
+
+// tableKey identifies the group of humans at a table, whatever room they meet in
+func tableKey(game *GameState) string {
+	ids := make([]string, 0, len(game.Players))
+	for _, player := range game.Players {
+		if !player.IsBot {
+			ids = append(ids, player.ID.String())
+		}
+	}
+	sort.Strings(ids)
+	sum := sha256.Sum256([]byte(strings.Join(ids, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// applyFreshCards reshuffles the deck of a fresh cards room against the cards
+// its table saw in its last games, and commits to the new order. It runs when
+// the game starts, before any card is dealt.
+func (m *Manager) applyFreshCards(game *GameState) {
+	if !game.Settings.FreshCards || game.shuffleSeed == "" {
+		return
+	}
+
+	repo := m.repository(game)
+	avoided, err := repo.GetTableCards(context.Background(), tableKey(game), freshCardsHistoryGames)
+	if err != nil {
+		logger.Error("Failed to load table card history, keeping the plain shuffle", "error", err, "room_code", game.RoomCode)
+		return
+	}
+	if len(avoided) == 0 {
+		return
+	}
+
+	deck := FreshShuffledDeckOf(game.shuffleSeed, deckCards(game.packCards, game.expansionCards), avoided)
+	commitment := ShuffleCommitment(game.shuffleSeed, deck)
+	if err := repo.UpdateGameShuffle(context.Background(), game.ID, commitment, avoided, game.expansionCards); err != nil {
+		// The recorded commitment must match the deck, so keep the plain shuffle
+		logger.Error("Failed to record fresh cards shuffle, keeping the plain shuffle", "error", err, "room_code", game.RoomCode)
+		return
+	}
+
+	game.Deck = deck
+	game.ShuffleCommitment = commitment
+	game.avoidedCards = avoided
+
+	logger.Info("Deck reshuffled for fresh cards",
+		"room_code", game.RoomCode,
+		"avoided_cards", len(avoided))
+}
+
+// seenCards lists the cards that left the deck during a game: dealt to a
+// hand, played or voted on
+func seenCards(game *GameState) []int {
+	inDeck := make(map[int]bool, len(game.Deck))
+	for _, cardID := range game.Deck {
+		inDeck[cardID] = true
+	}
+	cards := deckCards(game.packCards, game.expansionCards)
+	seen := make([]int, 0, len(cards)-len(game.Deck))
+	for _, cardID := range cards {
+		if !inDeck[cardID] {
+			seen = append(seen, cardID)
+		}
+	}
+	return seen
+}
+
+// tableCardsHook records the cards every finished game showed its table, so
+// the table's later fresh cards games can favor the others. It records
+// whether or not the room used fresh cards, so turning it on works right away.
+type tableCardsHook struct {
+	NopLifecycleHook
+	manager *Manager
+}
+
+func (h tableCardsHook) OnGameCompleted(game *GameState, result *GameResult) {
+	if game.Sandbox || game.Status != models.GameStatusCompleted {
+		return
+	}
+
+	record := &models.TableCardHistory{
+		ID:        uuid.New(),
+		TableKey:  tableKey(game),
+		GameID:    game.ID,
+		CardIDs:   formatCardList(seenCards(game)),
+		CreatedAt: time.Now(),
+	}
+	if err := h.manager.repository(game).PersistTableCards(context.Background(), record); err != nil {
+		logger.Error("Failed to record table card history", "error", err, "room_code", game.RoomCode)
+	}
+}
+
+// GetTableCards returns the cards a table saw in its last games, deduplicated
+func (m *Manager) GetTableCards(ctx context.Context, tableKey string, games int) ([]int, error) {
+	var records []models.TableCardHistory
+	if err := m.db.WithContext(ctx).
+		Where("table_key = ?", tableKey).
+		Order("created_at DESC").
+		Limit(games).
+		Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to load table card history: %w", err)
+	}
+
+	seen := make(map[int]bool)
+	var cards []int
+	for _, record := range records {
+		for _, cardID := range parseCardList(record.CardIDs) {
+			if !seen[cardID] {
+				seen[cardID] = true
+				cards = append(cards, cardID)
+			}
+		}
+	}
+	sort.Ints(cards)
+	return cards, nil
+}
+
+// PersistTableCards stores a game's cards in its table's history, dropping
+// games too old to affect a shuffle
+func (m *Manager) PersistTableCards(ctx context.Context, record *models.TableCardHistory) error {
+	db := m.db.WithContext(ctx)
+	if err := db.Create(record).Error; err != nil {
+		return fmt.Errorf("failed to persist table card history: %w", err)
+	}
+
+	keep := db.Model(&models.TableCardHistory{}).
+		Select("id").
+		Where("table_key = ?", record.TableKey).
+		Order("created_at DESC").
+		Limit(freshCardsHistoryGames)
+	if err := db.Where("table_key = ? AND id NOT IN (?)", record.TableKey, keep).
+		Delete(&models.TableCardHistory{}).Error; err != nil {
+		return fmt.Errorf("failed to prune table card history: %w", err)
+	}
+	return nil
+}
+
+// UpdateGameShuffle records a game's recommitted shuffle
+func (m *Manager) UpdateGameShuffle(ctx context.Context, gameID uuid.UUID, commitment string, avoided, expansion []int) error {
+	if err := m.db.WithContext(ctx).Model(&models.Game{}).
+		Where("id = ?", gameID).
+		Updates(map[string]interface{}{
+			"shuffle_commitment": commitment,
+			"avoided_cards":      formatCardList(avoided),
+			"expansion_cards":    formatCardList(expansion),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update game shuffle: %w", err)
+	}
+	return nil
+}
+
+// formatCardList encodes card IDs as a comma-separated list
+func formatCardList(cards []int) string {
+	parts := make([]string, len(cards))
+	for i, cardID := range cards {
+		parts[i] = strconv.Itoa(cardID)
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseCardList decodes a comma-separated list of card IDs, skipping anything malformed
+func parseCardList(list string) []int {
+	if list == "" {
+		return nil
+	}
+	var cards []int
+	for _, part := range strings.Split(list, ",") {
+		if cardID, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			cards = append(cards, cardID)
+		}
+	}
+	return cards
+}
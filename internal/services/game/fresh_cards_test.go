@@ -0,0 +1,86 @@
+package game
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreshShuffledDeckIsDeterministicPermutation(t *testing.T) {
+	avoided := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	deck := FreshShuffledDeck("abc123", avoided)
+	assert.Equal(t, deck, FreshShuffledDeck("abc123", avoided))
+	assert.NotEqual(t, deck, FreshShuffledDeck("abc124", avoided))
+
+	sorted := append([]int(nil), deck...)
+	sort.Ints(sorted)
+	for i, cardID := range sorted {
+		require.Equal(t, i+1, cardID)
+	}
+}
+
+func TestFreshShuffledDeckSinksAvoidedCards(t *testing.T) {
+	avoided := make([]int, 0, classicDeckSize/2)
+	for cardID := 1; cardID <= classicDeckSize/2; cardID++ {
+		avoided = append(avoided, cardID)
+	}
+
+	// Over many seeds, the top of the deck should be mostly fresh cards
+	fresh, total := 0, 0
+	for i := 0; i < 50; i++ {
+		deck := FreshShuffledDeck(uuid.NewString(), avoided)
+		for _, cardID := range deck[:12] {
+			total++
+			if cardID > classicDeckSize/2 {
+				fresh++
+			}
+		}
+	}
+	assert.Greater(t, float64(fresh)/float64(total), 0.75)
+}
+
+func TestVerifyFreshShuffle(t *testing.T) {
+	seed := "0123456789abcdef"
+	avoided := []int{3, 14, 15}
+	commitment := ShuffleCommitment(seed, FreshShuffledDeck(seed, avoided))
+
+	deck, ok := VerifyShuffle(seed, commitment, nil, avoided, nil)
+	assert.True(t, ok)
+	assert.Equal(t, FreshShuffledDeck(seed, avoided), deck)
+
+	_, ok = VerifyShuffle(seed, commitment, nil, nil, nil)
+	assert.False(t, ok)
+}
+
+func TestTableKeyIgnoresBotsAndOrder(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	first := &GameState{Players: map[uuid.UUID]*Player{
+		alice:      {ID: alice},
+		bob:        {ID: bob},
+		uuid.New(): {IsBot: true},
+	}}
+	second := &GameState{Players: map[uuid.UUID]*Player{
+		bob:   {ID: bob},
+		alice: {ID: alice},
+	}}
+	assert.Equal(t, tableKey(first), tableKey(second))
+
+	second.Players[uuid.Nil] = &Player{ID: uuid.Nil}
+	assert.NotEqual(t, tableKey(first), tableKey(second))
+}
+
+func TestSeenCardsAndCardLists(t *testing.T) {
+	deck := make([]int, 0, classicDeckSize-3)
+	for cardID := 4; cardID <= classicDeckSize; cardID++ {
+		deck = append(deck, cardID)
+	}
+	seen := seenCards(&GameState{Deck: deck})
+	assert.Equal(t, []int{1, 2, 3}, seen)
+
+	assert.Equal(t, "1,2,3", formatCardList(seen))
+	assert.Equal(t, []int{1, 2, 3}, parseCardList("1, 2,x,3"))
+	assert.Nil(t, parseCardList(""))
+}
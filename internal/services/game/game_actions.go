@@ -2,15 +2,17 @@ package game
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
 	"strings"
 	"time"
 
-	"dixitme/internal/database"
+	"dixitme/internal/events"
+	"dixitme/internal/i18n"
 	"dixitme/internal/logger"
 	"dixitme/internal/models"
 	"dixitme/internal/services/bot"
+	"dixitme/internal/services/namepolicy"
 
 	"github.com/google/uuid"
 )
@@ -19,52 +21,170 @@ import (
 type GameService interface {
 	// Game lifecycle
 	CreateGame(roomCode string, creatorID uuid.UUID, creatorName string) (*GameState, error)
+	CreateGameWithOptions(roomCode string, creatorID uuid.UUID, creatorName string, opts CreateGameOptions) (*GameState, error)
 	JoinGame(roomCode string, playerID uuid.UUID, playerName string) (*GameState, error)
+	JoinGameWithOptions(roomCode string, playerID uuid.UUID, playerName string, opts JoinGameOptions) (*GameState, error)
 	AddBot(roomCode string, botLevel string) (*GameState, error)
+	AddBotAsHost(roomCode string, playerID uuid.UUID, botLevel string) (*GameState, error)
 	RemovePlayer(roomCode string, playerID uuid.UUID) (*GameState, error)
 	DeleteGame(roomCode string, playerID uuid.UUID) error
 	LeaveGame(roomCode string, playerID uuid.UUID) (*GameState, error)
 	StartGame(roomCode string, playerID uuid.UUID) error
 	GetGame(roomCode string) *GameState
 	GetActiveGamesCount() int
+	GetHostReport(ctx context.Context, roomCode string, requesterID uuid.UUID) (*HostReport, error)
+	GetScoreTimeline(ctx context.Context, roomCode string) (*ScoreTimeline, error)
+	GetFairnessProof(ctx context.Context, roomCode string) (*FairnessProof, error)
+	GetBotDecisions(ctx context.Context, roomCode string) (*BotDecisionLog, error)
+	GetReplay(ctx context.Context, roomCode string) (*GameReplay, error)
+	GetRoundSummary(ctx context.Context, roomCode string, roundNumber int) (*RoundSummary, error)
+	FindRoomHome(ctx context.Context, roomCode string) (*RoomHome, error)
+	UpdateGameSettings(roomCode string, playerID uuid.UUID, settings GameSettings) (*GameState, error)
+	Rematch(roomCode string, playerID uuid.UUID) (*GameState, error)
+}
+
+// CreateGameOptions are choices made when a room is created
+type CreateGameOptions struct {
+	Sandbox bool   // Ephemeral practice room: no database rows and no stats
+	Pace    string // Pace preset, standard when empty
+	Private bool   // Keep the room out of the lobby browser
+
+	// Password new players need to join. Protected rooms are always private.
+	Password string
+
+	// Tournament whose ladder receives the result. Callers check it exists.
+	TournamentID *uuid.UUID
+
+	// Full lobby settings, e.g. from a room template. Pace is ignored when set.
+	Settings *GameSettings
+
+	// Table rules, replacing those of the settings when set
+	Rules *GameRules
+
+	// Card packs the deck is built from, the default packs when empty
+	Packs []string
+
+	// Custom deck the deck is built from instead of card packs
+	DeckID *uuid.UUID
 }
 
 // CreateGame creates a new game with the given room code
 func (m *Manager) CreateGame(roomCode string, creatorID uuid.UUID, creatorName string) (*GameState, error) {
+	return m.CreateGameWithOptions(roomCode, creatorID, creatorName, CreateGameOptions{})
+}
+
+// CreateGameWithOptions creates a new game with the given room code and creation options
+func (m *Manager) CreateGameWithOptions(roomCode string, creatorID uuid.UUID, creatorName string, opts CreateGameOptions) (*GameState, error) {
+	if creatorID == models.SystemPlayerID {
+		return nil, fmt.Errorf("player ID is reserved")
+	}
+	roomCode, err := NormalizeRoomCode(roomCode)
+	if err != nil {
+		return nil, err
+	}
+	creatorName, err = namepolicy.Check(creatorName)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := DefaultGameSettings()
+	if opts.Sandbox && opts.TournamentID != nil {
+		return nil, fmt.Errorf("sandbox games cannot count for a tournament")
+	}
+	if opts.Settings != nil {
+		if settings, err = ValidateSettings(*opts.Settings); err != nil {
+			return nil, err
+		}
+		if settings.Ranked && opts.Sandbox {
+			return nil, fmt.Errorf("sandbox games can't be ranked")
+		}
+		// Chat controls are set live by the host, never carried over
+		settings.Chat = ChatModeration{}
+	} else if opts.Pace != "" {
+		if opts.Pace == PaceCustom {
+			return nil, fmt.Errorf("custom pace can be set from the lobby settings")
+		}
+		settings.Pace = opts.Pace
+		if err := settings.resolvePace(); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Rules != nil {
+		settings.Rules = *opts.Rules
+		if settings, err = ValidateSettings(settings); err != nil {
+			return nil, err
+		}
+	}
+	if (len(settings.Rules.Expansions) > 0 || len(opts.Packs) > 0 || opts.DeckID != nil) && opts.Sandbox {
+		return nil, fmt.Errorf("sandbox games play with the base cards only")
+	}
+	// Sandbox games never touch the database, so they play with the classic deck
+	var packCards []int
+	if !opts.Sandbox {
+		if packCards, err = resolveRoomCards(m, opts.Packs, opts.DeckID); err != nil {
+			return nil, err
+		}
+	}
+	passwordHash := ""
+	if opts.Password != "" {
+		if passwordHash, err = hashRoomPassword(opts.Password); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Private || passwordHash != "" {
+		settings.Private = true
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// Check if room code already exists
 	if _, exists := m.games[roomCode]; exists {
-		return nil, fmt.Errorf("room code already exists")
+		return nil, roomCodeTaken(roomCode)
+	}
+	if err := m.checkGameCapacity(); err != nil {
+		logger.Warn("Refused new room, server at capacity", "room_code", roomCode, "games", len(m.games))
+		return nil, err
 	}
 
 	// Create new game state
 	gameID := uuid.New()
 	now := time.Now()
 
-	// Initialize deck with all available cards (1-84 for standard Dixit)
-	deck := make([]int, 84)
-	for i := 0; i < 84; i++ {
-		deck[i] = i + 1
-	}
-	// Shuffle the deck
-	for i := len(deck) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
-		deck[i], deck[j] = deck[j], deck[i]
+	// Shuffle the deck from a secret seed and commit to the order, so players
+	// can check after the game that it wasn't changed along the way
+	seed, err := newShuffleSeed()
+	if err != nil {
+		return nil, err
 	}
+	deck := ShuffledDeckOf(seed, deckCards(packCards, nil))
 
 	game := &GameState{
 		ID:           gameID,
 		RoomCode:     roomCode,
+		HostID:       creatorID,
 		Players:      make(map[uuid.UUID]*Player),
 		Status:       models.GameStatusWaiting,
 		RoundNumber:  0,
-		MaxRounds:    999, // Will be determined by 30 points or empty deck
+		MaxRounds:    999, // Will be determined by the target score or empty deck
 		Deck:         deck,
 		UsedCards:    make([]int, 0),
+		Settings:     settings,
+		Sandbox:      opts.Sandbox,
+		Region:       m.region,
+		TournamentID: opts.TournamentID,
+		Packs:        opts.Packs,
+		DeckID:       opts.DeckID,
 		CreatedAt:    now,
 		LastActivity: now,
+		history:      NewScoringHistory(),
+		analytics:    newGameAnalytics(),
+
+		ShuffleCommitment: ShuffleCommitment(seed, deck),
+		shuffleSeed:       seed,
+		packCards:         packCards,
+		PasswordProtected: passwordHash != "",
+		passwordHash:      passwordHash,
 	}
 
 	// Add creator as first player
@@ -79,18 +199,20 @@ func (m *Manager) CreateGame(roomCode string, creatorID uuid.UUID, creatorName s
 		LastActivity: time.Now(),
 	}
 
+	m.assignToken(game, creator)
 	game.Players[creatorID] = creator
+	game.joinRotation(creatorID)
 
 	// Store in memory
 	m.games[roomCode] = game
 
 	// Persist to database
-	if err := m.PersistGame(context.Background(), game); err != nil {
+	if err := m.repository(game).PersistGame(context.Background(), game); err != nil {
 		delete(m.games, roomCode)
 		// Check if it's a duplicate key error
 		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") &&
 			strings.Contains(err.Error(), "games_room_code_key") {
-			return nil, fmt.Errorf("room code '%s' is already taken, please try a different one", roomCode)
+			return nil, roomCodeTaken(roomCode)
 		}
 		return nil, fmt.Errorf("failed to create game: %w", err)
 	}
@@ -102,7 +224,7 @@ func (m *Manager) CreateGame(roomCode string, creatorID uuid.UUID, creatorName s
 		Type:     models.PlayerTypeHuman,
 		AuthType: models.AuthTypeGuest,
 	}
-	if err := m.PersistPlayer(context.Background(), dbCreator); err != nil {
+	if err := m.repository(game).PersistPlayer(context.Background(), dbCreator); err != nil {
 		// If player already exists, it's not an error (e.g., rejoining with same ID)
 		if !strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
 			delete(m.games, roomCode)
@@ -111,7 +233,7 @@ func (m *Manager) CreateGame(roomCode string, creatorID uuid.UUID, creatorName s
 	}
 
 	// Persist creator as game player
-	if err := m.PersistGamePlayer(context.Background(), game.ID, creator); err != nil {
+	if err := m.repository(game).PersistGamePlayer(context.Background(), game.ID, creator); err != nil {
 		delete(m.games, roomCode)
 		return nil, fmt.Errorf("failed to persist creator game player: %w", err)
 	}
@@ -121,17 +243,60 @@ func (m *Manager) CreateGame(roomCode string, creatorID uuid.UUID, creatorName s
 		logger.Error("Failed to store game in Redis", "error", err, "room_code", roomCode)
 	}
 
+	if game.Sandbox {
+		logger.Info("Sandbox game created", "room_code", roomCode, "creator_id", creatorID)
+	}
+
+	m.notifyGameCreated(game)
+	m.sendResumeToken(game, creatorID)
+
 	return game, nil
 }
 
 // JoinGame adds a player to an existing game
 func (m *Manager) JoinGame(roomCode string, playerID uuid.UUID, playerName string) (*GameState, error) {
-	m.mu.RLock()
-	game, exists := m.games[roomCode]
-	m.mu.RUnlock()
+	return m.JoinGameWithOptions(roomCode, playerID, playerName, JoinGameOptions{})
+}
 
-	if !exists {
-		return nil, fmt.Errorf("game not found")
+// JoinGameOptions carries what a player brings to a room beyond their name
+type JoinGameOptions struct {
+	Password string // Room password, needed for a new seat in a protected room
+
+	// Verified is set when the caller proved they are the player, with a
+	// session JWT or a resume token for the room. Only they may take over a
+	// seat that is already theirs.
+	Verified bool
+}
+
+// JoinGameWithOptions seats a player in a room, or returns them to their seat
+func (m *Manager) JoinGameWithOptions(roomCode string, playerID uuid.UUID, playerName string, opts JoinGameOptions) (*GameState, error) {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return nil, m.roomNotHere(roomCode)
+	}
+	if playerID == models.SystemPlayerID {
+		return nil, fmt.Errorf("player ID is reserved")
+	}
+
+	// The code of a finished game leads on to its rematch lobby
+	for {
+		game.mu.RLock()
+		next := game.RematchRoomCode
+		game.mu.RUnlock()
+		rematch := m.getGame(next)
+		if next == "" || rematch == nil {
+			break
+		}
+		game, roomCode = rematch, next
+	}
+
+	game.mu.RLock()
+	_, seated := game.Players[playerID]
+	game.mu.RUnlock()
+	if !seated {
+		if err := game.checkPassword(opts.Password); err != nil {
+			return nil, err
+		}
 	}
 
 	game.mu.Lock()
@@ -140,17 +305,34 @@ func (m *Manager) JoinGame(roomCode string, playerID uuid.UUID, playerName strin
 	// Update activity
 	game.LastActivity = time.Now()
 
+	// Player IDs are stable per account, so a registered user joining from a
+	// second device takes over their existing seat. Player IDs are public, so
+	// a bare ID is not enough to take a seat over.
+	if existing, exists := game.Players[playerID]; exists {
+		if !opts.Verified {
+			return nil, &GameError{
+				Code:    ErrCodeSeatTaken,
+				Message: "player already in game, sign in or use your resume token to take over the seat",
+			}
+		}
+		return m.rejoinSeat(game, existing)
+	}
+
 	if game.Status != models.GameStatusWaiting {
 		return nil, fmt.Errorf("game already started")
 	}
 
-	if len(game.Players) >= 6 {
+	if len(game.Players) >= game.Settings.Rules.PlayerLimit() {
 		return nil, fmt.Errorf("game is full")
 	}
 
-	// Check if player already exists
-	if _, exists := game.Players[playerID]; exists {
-		return nil, fmt.Errorf("player already in game")
+	if err := m.checkRankedStanding(game, playerID); err != nil {
+		return nil, err
+	}
+
+	playerName, err := namepolicy.Check(playerName)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create new player
@@ -165,19 +347,34 @@ func (m *Manager) JoinGame(roomCode string, playerID uuid.UUID, playerName strin
 		LastActivity: time.Now(),
 	}
 
+	m.assignToken(game, player)
 	game.Players[playerID] = player
 
 	// Persist player
-	if err := m.PersistGamePlayer(context.Background(), game.ID, player); err != nil {
+	dbPlayer := &models.Player{
+		ID:       playerID,
+		Name:     playerName,
+		Type:     models.PlayerTypeHuman,
+		AuthType: models.AuthTypeGuest,
+	}
+	if err := m.repository(game).PersistPlayer(context.Background(), dbPlayer); err != nil {
+		delete(game.Players, playerID)
+		return nil, fmt.Errorf("failed to persist player: %w", err)
+	}
+
+	if err := m.repository(game).PersistGamePlayer(context.Background(), game.ID, player); err != nil {
 		delete(game.Players, playerID)
 		return nil, fmt.Errorf("failed to persist player: %w", err)
 	}
+	game.joinRotation(playerID)
 
 	// Update Redis
 	if err := m.StoreGameInRedis(context.Background(), game); err != nil {
 		logger.Error("Failed to update game in Redis", "error", err, "room_code", roomCode)
 	}
 
+	events.Publish(m.bus, TopicPlayerJoined, PlayerJoined{Game: game, Player: player})
+
 	// Broadcast player joined
 	m.BroadcastToGame(game, MessageTypePlayerJoined, PlayerJoinedPayload{Player: player})
 
@@ -185,7 +382,12 @@ func (m *Manager) JoinGame(roomCode string, playerID uuid.UUID, playerName strin
 	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
 
 	// Send system message
-	m.SendSystemMessage(roomCode, fmt.Sprintf("%s joined the game", playerName))
+	m.SendSystemMessage(roomCode, i18n.Msg("{1} joined the game", playerName))
+
+	m.sendResumeToken(game, playerID)
+	m.sendJoinChatHistory(game, playerID, GetPlayerConnection(playerID))
+
+	m.maybeAutoStart(game)
 
 	return game, nil
 }
@@ -194,6 +396,7 @@ func (m *Manager) JoinGame(roomCode string, playerID uuid.UUID, playerName strin
 func (m *Manager) AddBot(roomCode string, botLevel string) (*GameState, error) {
 	m.mu.RLock()
 	game, exists := m.games[roomCode]
+	limits := m.botLimits
 	m.mu.RUnlock()
 
 	if !exists {
@@ -207,35 +410,26 @@ func (m *Manager) AddBot(roomCode string, botLevel string) (*GameState, error) {
 		return nil, fmt.Errorf("cannot add bot to game in progress")
 	}
 
-	if len(game.Players) >= 6 {
+	if len(game.Players) >= game.Settings.Rules.PlayerLimit() {
 		return nil, fmt.Errorf("game is full")
 	}
 
-	// Create bot player
-	botNames := bot.GetBotNames()
-	botName := botNames[rand.Intn(len(botNames))]
+	if err := limits.checkCanAddBot(game); err != nil {
+		return nil, err
+	}
 
-	// Ensure unique bot name
-	for {
-		nameExists := false
-		for _, player := range game.Players {
-			if player.Name == botName {
-				nameExists = true
-				break
-			}
-		}
-		if !nameExists {
-			break
-		}
-		botName = botNames[rand.Intn(len(botNames))]
+	// Auto-fill bots leave their difficulty to the admins' policy
+	if botLevel == bot.LevelAuto {
+		botLevel = m.pickBotLevel(game)
 	}
 
+	// Create bot player, named in the room's language and unlike anyone in the room
+	botName := bot.GenerateName(game.Settings.Language, game.playerNames())
+
 	botID := uuid.New()
 
-	// Create bot in bot manager
-	botManager := bot.GetBotManager()
-	botPlayer := botManager.CreateBot(botName, bot.BotDifficulty(botLevel))
-	botPlayer.SetGameID(game.ID)
+	// Register the AI under the seat's ID, which is how its turns find it
+	bot.GetBotManager().RestoreBot(botID, botName, bot.BotDifficulty(botLevel), game.ID)
 
 	// Create game player
 	player := &Player{
@@ -252,6 +446,7 @@ func (m *Manager) AddBot(roomCode string, botLevel string) (*GameState, error) {
 		LastActivity: time.Now(),
 	}
 
+	m.assignToken(game, player)
 	game.Players[botID] = player
 
 	// Persist bot player to database
@@ -262,15 +457,16 @@ func (m *Manager) AddBot(roomCode string, botLevel string) (*GameState, error) {
 		BotLevel: botLevel,
 	}
 
-	if err := m.PersistPlayer(context.Background(), dbPlayer); err != nil {
+	if err := m.repository(game).PersistPlayer(context.Background(), dbPlayer); err != nil {
 		delete(game.Players, botID)
 		return nil, fmt.Errorf("failed to persist bot player: %w", err)
 	}
 
-	if err := m.PersistGamePlayer(context.Background(), game.ID, player); err != nil {
+	if err := m.repository(game).PersistGamePlayer(context.Background(), game.ID, player); err != nil {
 		delete(game.Players, botID)
 		return nil, fmt.Errorf("failed to persist bot game player: %w", err)
 	}
+	game.joinRotation(botID)
 
 	// Update Redis
 	if err := m.StoreGameInRedis(context.Background(), game); err != nil {
@@ -284,7 +480,9 @@ func (m *Manager) AddBot(roomCode string, botLevel string) (*GameState, error) {
 	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
 
 	// Send system message
-	m.SendSystemMessage(roomCode, fmt.Sprintf("Bot %s (%s difficulty) joined the game", botName, botLevel))
+	m.SendSystemMessage(roomCode, i18n.Msg("Bot {1} ({2} difficulty) joined the game", botName, botLevel))
+
+	m.maybeAutoStart(game)
 
 	logger.Info("Bot added to game", "bot_id", botID, "bot_name", botName, "bot_level", botLevel, "room_code", roomCode)
 
@@ -312,30 +510,37 @@ func (m *Manager) RemovePlayer(roomCode string, playerID uuid.UUID) (*GameState,
 	// Update activity timestamp
 	game.LastActivity = time.Now()
 
+	if player.VoiceJoined {
+		m.leaveVoice(game, player)
+	}
+
 	// Different behavior based on game status
 	if game.Status == models.GameStatusWaiting {
 		// In waiting state: completely remove player
 		delete(game.Players, playerID)
 
 		// Remove from database
-		db := database.GetDB()
-		if err := db.Where("game_id = ? AND player_id = ?", game.ID, playerID).Delete(&models.GamePlayer{}).Error; err != nil {
+		if err := m.repository(game).RemoveGamePlayer(context.Background(), game.ID, playerID); err != nil {
 			// Rollback memory change
 			game.Players[playerID] = player
 			return nil, fmt.Errorf("failed to remove player from database: %w", err)
 		}
+		game.leaveRotation(playerID)
+		m.migrateHost(game, playerID)
 
 		log.Info("Player completely removed from waiting game", "player_id", playerID, "player_name", player.Name, "room_code", roomCode)
 	} else {
 		// In active game: mark as inactive (AFK) instead of removing
 		player.IsActive = false
-		player.IsConnected = false
-		player.Connection = nil
+		player.MarkDisconnected(game, DisconnectLeft)
 		player.UpdateActivity() // Update activity timestamp
+		game.analytics.recordAFK(player, game.RoundNumber, AFKReasonWentAFK)
 
 		log.Info("Player marked as inactive in active game", "player_id", playerID, "player_name", player.Name, "room_code", roomCode)
 	}
 
+	events.Publish(m.bus, TopicPlayerLeft, PlayerLeft{Game: game, Player: player, Removed: game.Status == models.GameStatusWaiting})
+
 	// Broadcast player left
 	m.BroadcastToGame(game, MessageTypePlayerLeft, PlayerLeftPayload{PlayerID: playerID})
 
@@ -343,17 +548,17 @@ func (m *Manager) RemovePlayer(roomCode string, playerID uuid.UUID) (*GameState,
 	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
 
 	// Send system message
-	playerType := "Player"
-	if player.IsBot {
-		playerType = "Bot"
-	}
-
-	statusMessage := "left the game"
-	if game.Status != models.GameStatusWaiting {
-		statusMessage = "went AFK and may be replaced by a bot"
+	message := "Player {1} left the game"
+	switch {
+	case player.IsBot && game.Status != models.GameStatusWaiting:
+		message = "Bot {1} went AFK and may be replaced by a bot"
+	case player.IsBot:
+		message = "Bot {1} left the game"
+	case game.Status != models.GameStatusWaiting:
+		message = "Player {1} went AFK and may be replaced by a bot"
 	}
 
-	m.SendSystemMessage(roomCode, fmt.Sprintf("%s %s %s", playerType, player.Name, statusMessage))
+	m.SendSystemMessage(roomCode, i18n.Msg(message, player.Name))
 
 	return game, nil
 }
@@ -381,8 +586,9 @@ func (m *Manager) DeleteGame(roomCode string, playerID uuid.UUID) error {
 		return fmt.Errorf("cannot delete a game that has already started")
 	}
 
-	// Check if the player is the creator (first player or has admin rights)
-	// For simplicity, we'll allow any player to delete for now, but you could add creator validation
+	if err := requireHost(game, playerID, "delete the game"); err != nil {
+		return err
+	}
 
 	// Remove from memory first
 	m.mu.Lock()
@@ -390,20 +596,23 @@ func (m *Manager) DeleteGame(roomCode string, playerID uuid.UUID) error {
 	m.mu.Unlock()
 
 	// Remove from database
-	db := database.GetDB()
-	if err := db.Where("room_code = ?", roomCode).Delete(&models.Game{}).Error; err != nil {
+	if err := m.repository(game).DeleteGameRecord(context.Background(), roomCode); err != nil {
 		// Restore to memory if database deletion failed
 		m.mu.Lock()
 		m.games[roomCode] = game
 		m.mu.Unlock()
 		return fmt.Errorf("failed to delete game from database: %w", err)
 	}
+	if err := m.DeleteGameFromRedis(context.Background(), roomCode); err != nil {
+		log.Error("Failed to delete game from Redis", "error", err, "room_code", roomCode)
+	}
+	m.notifyGameRemoved(game, true)
 
 	// Broadcast game deletion to all players
 	m.BroadcastToGame(game, MessageTypeGameDeleted, GameDeletedPayload{RoomCode: roomCode})
 
 	// Send system message
-	m.SendSystemMessage(roomCode, "Game has been deleted by the lobby manager")
+	m.SendSystemMessage(roomCode, i18n.Msg("Game has been deleted by the lobby manager"))
 
 	log.Info("Game deleted", "room_code", roomCode, "deleted_by", playerID)
 
@@ -414,6 +623,7 @@ func (m *Manager) DeleteGame(roomCode string, playerID uuid.UUID) error {
 func (m *Manager) StartGame(roomCode string, playerID uuid.UUID) error {
 	m.mu.RLock()
 	game, exists := m.games[roomCode]
+	limits := m.botLimits
 	m.mu.RUnlock()
 
 	if !exists {
@@ -430,6 +640,12 @@ func (m *Manager) StartGame(roomCode string, playerID uuid.UUID) error {
 	if _, exists := game.Players[playerID]; !exists {
 		return fmt.Errorf("player not in game")
 	}
+	if game.Status != models.GameStatusWaiting {
+		return fmt.Errorf("game already started")
+	}
+	if err := requireHost(game, playerID, "start the game"); err != nil {
+		return err
+	}
 
 	// Check if game can start (minimum 3 players)
 	logger.Info("StartGame player count check",
@@ -450,12 +666,43 @@ func (m *Manager) StartGame(roomCode string, playerID uuid.UUID) error {
 		return fmt.Errorf("need at least 3 players to start")
 	}
 
-	if game.Status != models.GameStatusWaiting {
-		return fmt.Errorf("game already started")
+	if err := limits.checkEnoughHumans(game); err != nil {
+		return err
+	}
+	if err := checkRankedHumans(game); err != nil {
+		return err
+	}
+	for _, player := range ratedPlayers(game) {
+		if err := m.checkRankedStanding(game, player.ID); err != nil {
+			return err
+		}
+	}
+
+	// Large games fit the hand size to the table before the deck is checked
+	if err := m.applyTableVariant(game); err != nil {
+		return err
+	}
+
+	if err := m.checkStartRules(game); err != nil {
+		return err
+	}
+
+	// Expansions are shuffled in once they are confirmed to exist
+	if err := m.applyExpansions(game); err != nil {
+		return err
+	}
+
+	// Team games split the table before anyone is dealt in
+	if err := m.assignTeams(game); err != nil {
+		return err
 	}
 
 	// Initialize game
 	game.Status = models.GameStatusInProgress
+	m.debugPhase(game, string(models.GameStatusInProgress))
+
+	// Fresh cards rooms reshuffle now that the table is known, before anything is dealt
+	m.applyFreshCards(game)
 
 	// Deal cards to players
 	m.dealCards(game)
@@ -466,10 +713,19 @@ func (m *Manager) StartGame(roomCode string, playerID uuid.UUID) error {
 	}
 
 	// Update database
-	if err := m.UpdateGameStatus(context.Background(), game.ID, models.GameStatusInProgress); err != nil {
+	if err := m.repository(game).UpdateGameStatus(context.Background(), game.ID, models.GameStatusInProgress); err != nil {
 		return fmt.Errorf("failed to update game status: %w", err)
 	}
 
+	// Record the experiments this game was played with, for later analysis
+	if len(game.Settings.Experiments) > 0 {
+		if err := m.repository(game).PersistExperimentAssignments(context.Background(), game.ID, game.Settings.Experiments); err != nil {
+			logger.Error("Failed to record experiment assignments", "error", err, "room_code", roomCode)
+		}
+		logger.Info("Game started with experiments", "room_code", roomCode, "experiments", game.Settings.Experiments)
+	}
+	m.notifyGameStarted(game)
+
 	// Broadcast game started
 	m.BroadcastToGame(game, MessageTypeGameStarted, GameStartedPayload{GameState: game})
 
@@ -477,13 +733,24 @@ func (m *Manager) StartGame(roomCode string, playerID uuid.UUID) error {
 	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
 
 	// Send system message
-	m.SendSystemMessage(roomCode, "Game started! Let the storytelling begin!")
+	m.SendSystemMessage(roomCode, i18n.Msg("Game started! Let the storytelling begin!"))
 
 	return nil
 }
 
+// errReplacementCancelled is returned when a player came back before their
+// pending AFK replacement went through
+var errReplacementCancelled = errors.New("player is back, replacement cancelled")
+
 // ReplacePlayerWithBot replaces a disconnected or AFK player with a bot
 func (m *Manager) ReplacePlayerWithBot(roomCode string, playerID uuid.UUID, reason string) (*GameState, error) {
+	return m.replacePlayerWithBot(roomCode, playerID, reason, nil)
+}
+
+// replacePlayerWithBot replaces a player with a bot. When stillDue is set the
+// replacement only goes through if it holds once the game is locked, so a
+// player who reconnected in the meantime keeps their seat.
+func (m *Manager) replacePlayerWithBot(roomCode string, playerID uuid.UUID, reason string, stillDue func(game *GameState, player *Player) bool) (*GameState, error) {
 	log := logger.GetLogger()
 
 	game := m.getGame(roomCode)
@@ -504,38 +771,27 @@ func (m *Manager) ReplacePlayerWithBot(roomCode string, playerID uuid.UUID, reas
 	if player.IsBot || player.WasReplaced {
 		return nil, fmt.Errorf("cannot replace bot or already replaced player")
 	}
+	if stillDue != nil && !stillDue(game, player) {
+		return nil, errReplacementCancelled
+	}
 
-	// Choose bot difficulty based on game state or default to medium
-	botLevel := "medium"
-	if len(game.Players) <= 3 {
-		botLevel = "easy" // Easier for smaller games
+	m.mu.RLock()
+	limits := m.botLimits
+	m.mu.RUnlock()
+	if err := limits.checkCanReplaceWithBot(game); err != nil {
+		return nil, err
 	}
 
-	// Create bot player
-	botNames := bot.GetBotNames()
-	botName := fmt.Sprintf("Bot-%s", player.Name) // Use original player name as suffix
+	// The admins' difficulty policy decides how strong the replacement is
+	botLevel := m.pickBotLevel(game)
 
-	// Ensure unique bot name
-	for {
-		nameExists := false
-		for _, p := range game.Players {
-			if p.Name == botName {
-				nameExists = true
-				break
-			}
-		}
-		if !nameExists {
-			break
-		}
-		botName = botNames[rand.Intn(len(botNames))] + "-" + player.Name[:3]
-	}
+	// Create bot player, named after the original player where that's safe
+	botName := bot.ReplacementName(game.Settings.Language, player.Name, game.playerNames())
 
 	botID := uuid.New()
 
-	// Create bot in bot manager
-	botManager := bot.GetBotManager()
-	botPlayer := botManager.CreateBot(botName, bot.BotDifficulty(botLevel))
-	botPlayer.SetGameID(game.ID)
+	// Register the AI under the seat's ID, which is how its turns find it
+	bot.GetBotManager().RestoreBot(botID, botName, bot.BotDifficulty(botLevel), game.ID)
 
 	// Create replacement bot player that inherits from original player
 	replacementBot := &Player{
@@ -543,6 +799,8 @@ func (m *Manager) ReplacePlayerWithBot(roomCode string, playerID uuid.UUID, reas
 		Name:          botName,
 		Score:         player.Score,    // Keep the same score
 		Position:      player.Position, // Keep the same position
+		Token:         player.Token,    // Keep the same color and avatar
+		Team:          player.Team,     // Play on for the same team
 		Hand:          player.Hand,     // Keep the same cards
 		Connection:    nil,             // Bots don't have connections
 		IsConnected:   true,            // Bots are always "connected"
@@ -575,7 +833,7 @@ func (m *Manager) ReplacePlayerWithBot(roomCode string, playerID uuid.UUID, reas
 		BotLevel: botLevel,
 	}
 
-	if err := m.PersistPlayer(context.Background(), dbPlayer); err != nil {
+	if err := m.repository(game).PersistPlayer(context.Background(), dbPlayer); err != nil {
 		// Rollback changes
 		delete(game.Players, botID)
 		player.WasReplaced = false
@@ -584,7 +842,7 @@ func (m *Manager) ReplacePlayerWithBot(roomCode string, playerID uuid.UUID, reas
 		return nil, fmt.Errorf("failed to persist replacement bot: %w", err)
 	}
 
-	if err := m.PersistGamePlayer(context.Background(), game.ID, replacementBot); err != nil {
+	if err := m.repository(game).PersistGamePlayer(context.Background(), game.ID, replacementBot); err != nil {
 		// Rollback changes
 		delete(game.Players, botID)
 		player.WasReplaced = false
@@ -592,12 +850,22 @@ func (m *Manager) ReplacePlayerWithBot(roomCode string, playerID uuid.UUID, reas
 		player.IsActive = true
 		return nil, fmt.Errorf("failed to persist replacement bot game player: %w", err)
 	}
+	game.replaceInRotation(playerID, botID)
+
+	game.analytics.recordAFK(player, game.RoundNumber, AFKReasonReplaced)
+
+	// Leaving a ranked game to a bot is a forfeit, unlike in casual games
+	if game.Status == models.GameStatusInProgress {
+		m.recordForfeit(game, player, models.ForfeitReasonReplaced)
+	}
 
 	// Update Redis
 	if err := m.StoreGameInRedis(context.Background(), game); err != nil {
 		logger.Error("Failed to update game in Redis after player replacement", "error", err, "room_code", roomCode)
 	}
 
+	events.Publish(m.bus, TopicPlayerReplaced, PlayerReplaced{Game: game, PlayerID: playerID, BotID: botID, Reason: reason})
+
 	// Broadcast player replacement
 	m.BroadcastToGame(game, MessageTypePlayerReplaced, PlayerReplacedPayload{
 		OriginalPlayerID: playerID,
@@ -609,7 +877,7 @@ func (m *Manager) ReplacePlayerWithBot(roomCode string, playerID uuid.UUID, reas
 	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
 
 	// Send system message
-	m.SendSystemMessage(roomCode, fmt.Sprintf("%s was replaced by %s (%s)", player.Name, botName, reason))
+	m.SendSystemMessage(roomCode, i18n.Msg("{1} was replaced by {2} ({3})", player.Name, botName, reason))
 
 	log.Info("Player replaced with bot",
 		"original_player_id", playerID,
@@ -622,7 +890,8 @@ func (m *Manager) ReplacePlayerWithBot(roomCode string, playerID uuid.UUID, reas
 	return game, nil
 }
 
-// CheckAndReplaceAFKPlayers checks for AFK players and replaces them with bots during active games
+// CheckAndReplaceAFKPlayers checks for AFK players and replaces them with bots during active games.
+// A zero afkTimeout applies the room's per-phase thresholds; otherwise it is used for everyone.
 func (m *Manager) CheckAndReplaceAFKPlayers(roomCode string, afkTimeout time.Duration) (*GameState, error) {
 	log := logger.GetLogger()
 
@@ -642,10 +911,21 @@ func (m *Manager) CheckAndReplaceAFKPlayers(roomCode string, afkTimeout time.Dur
 	replacedCount := 0
 	for playerID, player := range game.Players {
 		// Check if player is AFK and should be replaced
-		if player.IsAFK(afkTimeout) && !player.WasReplaced {
-			// Unlock temporarily for the replacement operation
+		timeout := m.afkTimeoutFor(game, playerID, afkTimeout)
+		if player.IsAFK(timeout) && !player.WasReplaced {
+			idle, cause := time.Since(player.LastActivity), afkCause(player)
+			// Unlock temporarily for the replacement operation; a player who
+			// reconnects meanwhile cancels it
 			game.Unlock()
-			if _, err := m.ReplacePlayerWithBot(roomCode, playerID, "AFK timeout"); err != nil {
+			_, err := m.replacePlayerWithBot(roomCode, playerID, "AFK timeout", func(game *GameState, player *Player) bool {
+				return player.IsAFK(m.afkTimeoutFor(game, player.ID, afkTimeout))
+			})
+			if errors.Is(err, errReplacementCancelled) {
+				log.Info("AFK replacement cancelled, player is back", "player_id", playerID, "room_code", roomCode)
+				game.Lock()
+				continue
+			}
+			if err != nil {
 				log.Error("Failed to replace AFK player",
 					"player_id", playerID,
 					"player_name", player.Name,
@@ -654,6 +934,7 @@ func (m *Manager) CheckAndReplaceAFKPlayers(roomCode string, afkTimeout time.Dur
 				continue
 			}
 			game.Lock() // Re-lock after operation
+			recordAFKReplacement(game, cause, idle, timeout)
 			replacedCount++
 		}
 	}
@@ -682,15 +963,33 @@ func (m *Manager) EndGameDueToAllAFK(roomCode string) error {
 	// Mark game as abandoned
 	game.Status = models.GameStatusAbandoned
 	game.LastActivity = time.Now()
+	m.debugPhase(game, string(models.GameStatusAbandoned))
 
 	// Update database status
-	if err := m.UpdateGameStatus(context.Background(), game.ID, models.GameStatusAbandoned); err != nil {
+	if err := m.repository(game).UpdateGameStatus(context.Background(), game.ID, models.GameStatusAbandoned); err != nil {
 		log.Error("Failed to update game status to abandoned",
 			"room_code", roomCode,
 			"game_id", game.ID,
 			"error", err)
 	}
 
+	// A ranked game abandoned by every human is a forfeit for all of them
+	var outcome models.GameOutcome
+	if game.Settings.Ranked {
+		outcome = models.GameOutcomeForfeit
+		for _, player := range ratedPlayers(game) {
+			m.recordForfeit(game, player, models.ForfeitReasonAllAFK)
+		}
+	}
+	result := newGameResult(game, uuid.Nil, outcome)
+	if game.Settings.Ranked {
+		if err := m.repository(game).PersistGameCompletion(context.Background(), result); err != nil {
+			log.Error("Failed to persist ranked forfeit", "error", err, "room_code", roomCode)
+		}
+	}
+
+	m.notifyGameCompleted(game, result)
+
 	// Update Redis
 	if err := m.StoreGameInRedis(context.Background(), game); err != nil {
 		log.Error("Failed to update game in Redis after AFK abandonment", "error", err, "room_code", roomCode)
@@ -700,10 +999,11 @@ func (m *Manager) EndGameDueToAllAFK(roomCode string) error {
 	m.BroadcastToGame(game, MessageTypeGameCompleted, GameCompletedPayload{
 		FinalScores: make(map[uuid.UUID]int), // Empty scores since game was abandoned
 		Winner:      uuid.Nil,                // No winner
+		Outcome:     outcome,
 	})
 
 	// Send system message
-	m.SendSystemMessage(roomCode, "Game ended: All players went AFK")
+	m.SendSystemMessage(roomCode, i18n.Msg("Game ended: All players went AFK"))
 
 	log.Info("Game ended due to all players being AFK",
 		"room_code", roomCode,
@@ -712,7 +1012,8 @@ func (m *Manager) EndGameDueToAllAFK(roomCode string) error {
 	return nil
 }
 
-// CheckAndHandleAllAFK checks if all human players are AFK and ends the game if so
+// CheckAndHandleAllAFK checks if all human players are AFK and ends the game if so.
+// A zero afkTimeout applies the room's per-phase thresholds.
 func (m *Manager) CheckAndHandleAllAFK(roomCode string, afkTimeout time.Duration) (bool, error) {
 	game := m.getGame(roomCode)
 	if game == nil {
@@ -725,7 +1026,7 @@ func (m *Manager) CheckAndHandleAllAFK(roomCode string, afkTimeout time.Duration
 	}
 
 	// Check if all human players are AFK
-	if game.AreAllHumanPlayersAFK(afkTimeout) {
+	if m.allHumansAFK(game, afkTimeout) {
 		// End the game due to all players being AFK
 		if err := m.EndGameDueToAllAFK(roomCode); err != nil {
 			return false, fmt.Errorf("failed to end game due to all AFK: %w", err)
@@ -738,10 +1039,16 @@ func (m *Manager) CheckAndHandleAllAFK(roomCode string, afkTimeout time.Duration
 
 // Helper methods
 
+// GetGame returns a game by room code. A game this instance doesn't hold is
+// resumed from Redis when another instance or an earlier run cached it.
 func (m *Manager) GetGame(roomCode string) *GameState {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	return m.games[roomCode]
+	game := m.games[roomCode]
+	m.mu.RUnlock()
+	if game != nil {
+		return game
+	}
+	return m.resumeGameFromRedis(roomCode)
 }
 
 func (m *Manager) getGame(roomCode string) *GameState {
@@ -0,0 +1,338 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Standard Dixit table rules, used for anything a room leaves unset
+const (
+	defaultTargetScore = 30
+	defaultHandSize    = 6
+)
+
+// Bounds of the table rules
+const (
+	minPlayersPerRoom = 3
+	maxCasualPlayers  = 8 // Casual rooms may seat more than the standard six
+	minTargetScore    = 10
+	maxTargetScore    = 100
+	minHandSize       = 4
+	maxHandSize       = 8
+	minRoundTimer     = 30
+	maxRoundTimer     = 600
+	maxExpansions     = 10
+)
+
+// expansionPattern matches the tag slugs of imported decks
+var expansionPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// GameRules are the table rules of a room: how many seats it has, how the
+// game ends and which cards are played with
+type GameRules struct {
+	MaxPlayers        int         `json:"max_players"`         // Seats in the room, bots included
+	TargetScore       int         `json:"target_score"`        // Reaching it ends the game
+	HandSize          int         `json:"hand_size"`           // Cards each player holds
+	RoundTimerSeconds int         `json:"round_timer_seconds"` // Time limit of each round phase (0 = no limit)
+	PhaseTimers       PhaseTimers `json:"phase_timers"`        // Per-phase overrides of the round timer
+	Expansions        []string    `json:"expansions"`          // Imported decks (tag slugs) shuffled in with the base cards
+	LargeGame         bool        `json:"large_game"`          // Official variant rules: hand size, cards submitted and scoring fit the player count
+	TeamSize          int         `json:"team_size"`           // Players per team, 2 or 3 (0 = no teams)
+}
+
+// PhaseTimers give round phases their own time limits. A phase left at 0
+// uses the round timer.
+type PhaseTimers struct {
+	StorytellingSeconds int `json:"storytelling_seconds"`
+	SubmittingSeconds   int `json:"submitting_seconds"`
+	VotingSeconds       int `json:"voting_seconds"`
+}
+
+// DefaultGameRules returns the standard Dixit table rules
+func DefaultGameRules() GameRules {
+	return GameRules{
+		MaxPlayers:  maxPlayersPerRoom,
+		TargetScore: defaultTargetScore,
+		HandSize:    defaultHandSize,
+		Expansions:  []string{},
+	}
+}
+
+// normalize fills in rules left unset and deduplicates the expansions
+func (r *GameRules) normalize() {
+	if r.MaxPlayers == 0 {
+		r.MaxPlayers = maxPlayersPerRoom
+	}
+	if r.TargetScore == 0 {
+		r.TargetScore = defaultTargetScore
+	}
+	if r.HandSize == 0 {
+		r.HandSize = defaultHandSize
+	}
+
+	seen := make(map[string]bool, len(r.Expansions))
+	expansions := make([]string, 0, len(r.Expansions))
+	for _, slug := range r.Expansions {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if slug != "" && !seen[slug] {
+			seen[slug] = true
+			expansions = append(expansions, slug)
+		}
+	}
+	r.Expansions = expansions
+}
+
+// Validate checks that the rules make a playable table. Tables above the
+// standard six seats are for casual rooms only; ValidateSettings checks that.
+// Large games seat up to twelve.
+func (r GameRules) Validate() error {
+	seats := maxCasualPlayers
+	if r.LargeGame {
+		seats = maxLargeGamePlayers
+	}
+	if r.MaxPlayers < minPlayersPerRoom || r.MaxPlayers > seats {
+		return fmt.Errorf("max players must be between %d and %d", minPlayersPerRoom, seats)
+	}
+	if r.TargetScore < minTargetScore || r.TargetScore > maxTargetScore {
+		return fmt.Errorf("target score must be between %d and %d", minTargetScore, maxTargetScore)
+	}
+	if r.HandSize < minHandSize || r.HandSize > maxHandSize {
+		return fmt.Errorf("hand size must be between %d and %d", minHandSize, maxHandSize)
+	}
+	for name, seconds := range map[string]int{
+		"round":        r.RoundTimerSeconds,
+		"storytelling": r.PhaseTimers.StorytellingSeconds,
+		"submitting":   r.PhaseTimers.SubmittingSeconds,
+		"voting":       r.PhaseTimers.VotingSeconds,
+	} {
+		if seconds != 0 && (seconds < minRoundTimer || seconds > maxRoundTimer) {
+			return fmt.Errorf("%s timer must be off or between %d and %d seconds", name, minRoundTimer, maxRoundTimer)
+		}
+	}
+	if r.TeamSize != 0 && (r.TeamSize < minTeamSize || r.TeamSize > maxTeamSize) {
+		return fmt.Errorf("team size must be off or between %d and %d", minTeamSize, maxTeamSize)
+	}
+	if len(r.Expansions) > maxExpansions {
+		return fmt.Errorf("at most %d expansions can be played with", maxExpansions)
+	}
+	for _, slug := range r.Expansions {
+		if !expansionPattern.MatchString(slug) {
+			return fmt.Errorf("invalid expansion: %q", slug)
+		}
+	}
+	return nil
+}
+
+// PlayerLimit is the number of seats, for rooms whose rules were never set,
+// e.g. games restored from an older snapshot
+func (r GameRules) PlayerLimit() int {
+	if r.MaxPlayers <= 0 {
+		return maxPlayersPerRoom
+	}
+	return r.MaxPlayers
+}
+
+// WinningScore is the score that ends the game
+func (r GameRules) WinningScore() int {
+	if r.TargetScore <= 0 {
+		return defaultTargetScore
+	}
+	return r.TargetScore
+}
+
+// CardsPerHand is the number of cards hands are refilled to
+func (r GameRules) CardsPerHand() int {
+	if r.HandSize <= 0 {
+		return defaultHandSize
+	}
+	return r.HandSize
+}
+
+// PhaseTimeLimit is how long players have to move in a round phase, or zero
+// when the phase isn't timed
+func (r GameRules) PhaseTimeLimit(status models.RoundStatus) time.Duration {
+	seconds := 0
+	switch status {
+	case models.RoundStatusStorytelling:
+		seconds = r.PhaseTimers.StorytellingSeconds
+	case models.RoundStatusSubmitting:
+		seconds = r.PhaseTimers.SubmittingSeconds
+	case models.RoundStatusVoting:
+		seconds = r.PhaseTimers.VotingSeconds
+	default:
+		return 0
+	}
+	if seconds == 0 {
+		seconds = r.RoundTimerSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// rulesFromModel reads the table rules persisted with a game
+func rulesFromModel(dbGame *models.Game) GameRules {
+	rules := GameRules{
+		MaxPlayers:        dbGame.MaxPlayers,
+		TargetScore:       dbGame.TargetScore,
+		HandSize:          dbGame.HandSize,
+		RoundTimerSeconds: dbGame.RoundTimerSeconds,
+		PhaseTimers: PhaseTimers{
+			StorytellingSeconds: dbGame.StorytellingTimerSeconds,
+			SubmittingSeconds:   dbGame.SubmittingTimerSeconds,
+			VotingSeconds:       dbGame.VotingTimerSeconds,
+		},
+		LargeGame: dbGame.LargeGame,
+		TeamSize:  dbGame.TeamSize,
+	}
+	if dbGame.Expansions != "" {
+		rules.Expansions = strings.Split(dbGame.Expansions, ",")
+	}
+	rules.normalize()
+	return rules
+}
+
+// checkStartRules checks a game's rules against its table before it starts:
+// the seats taken and whether the deck can fill every hand. Callers hold the
+// game lock.
+func (m *Manager) checkStartRules(game *GameState) error {
+	rules := game.Settings.Rules
+	if len(game.Players) > rules.PlayerLimit() {
+		return fmt.Errorf("the room has %d players but only %d seats", len(game.Players), rules.PlayerLimit())
+	}
+	if err := validateTeams(rules.TeamSize, len(game.Players)); err != nil {
+		return err
+	}
+
+	expansion, err := m.resolveExpansions(game)
+	if err != nil {
+		return err
+	}
+	cards, needed := len(deckCards(game.packCards, expansion)), requiredDeckSize(len(game.Players), rules.CardsPerHand())
+	if cards < needed && len(game.Players) > maxPlayersPerRoom {
+		return fmt.Errorf("a table of %d needs %d cards but the deck has %d: add expansions", len(game.Players), needed, cards)
+	}
+	if cards < needed {
+		return fmt.Errorf("a deck of %d cards can't deal %d hands of %d", cards, len(game.Players), rules.CardsPerHand())
+	}
+	game.expansionCards = expansion
+	return nil
+}
+
+// requiredDeckSize is the number of cards a table needs. Every table needs
+// its hands dealt; tables above the standard six also need to refill them
+// until everyone has told a story once, which the base deck alone can't do.
+func requiredDeckSize(players, handSize int) int {
+	needed := players * handSize
+	if players > maxPlayersPerRoom {
+		needed += players * players
+	}
+	return needed
+}
+
+// resolveExpansions lists the cards of the room's expansions that aren't
+// already in its packs. Every expansion must exist and hold active cards.
+func (m *Manager) resolveExpansions(game *GameState) ([]int, error) {
+	slugs := game.Settings.Rules.Expansions
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+
+	cardsByExpansion, err := m.repository(game).GetExpansionCards(context.Background(), slugs)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	for _, cardID := range deckCards(game.packCards, nil) {
+		seen[cardID] = true
+	}
+	var expansion []int
+	for _, slug := range slugs {
+		cards := cardsByExpansion[slug]
+		if len(cards) == 0 {
+			return nil, fmt.Errorf("unknown expansion or no active cards: %s", slug)
+		}
+		for _, cardID := range cards {
+			if !seen[cardID] {
+				seen[cardID] = true
+				expansion = append(expansion, cardID)
+			}
+		}
+	}
+	return expansion, nil
+}
+
+// applyExpansions reshuffles the deck of a game with expansions over the pack
+// and expansion cards, and commits to the new order. It runs when the game
+// starts, before fresh cards and before anything is dealt.
+func (m *Manager) applyExpansions(game *GameState) error {
+	if len(game.expansionCards) == 0 || game.shuffleSeed == "" {
+		return nil
+	}
+
+	deck := ShuffledDeckOf(game.shuffleSeed, deckCards(game.packCards, game.expansionCards))
+	commitment := ShuffleCommitment(game.shuffleSeed, deck)
+	if err := m.repository(game).UpdateGameShuffle(context.Background(), game.ID, commitment, nil, game.expansionCards); err != nil {
+		// The recorded commitment must match the deck, or the proof fails
+		return fmt.Errorf("failed to record the expansion shuffle: %w", err)
+	}
+
+	game.Deck = deck
+	game.ShuffleCommitment = commitment
+
+	logger.Info("Deck reshuffled with expansions",
+		"room_code", game.RoomCode,
+		"expansions", game.Settings.Rules.Expansions,
+		"expansion_cards", len(game.expansionCards))
+	return nil
+}
+
+// GetExpansionCards lists the active cards tagged with each expansion slug
+func (m *Manager) GetExpansionCards(ctx context.Context, slugs []string) (map[string][]int, error) {
+	var rows []struct {
+		Slug   string
+		CardID int
+	}
+	if err := m.db.WithContext(ctx).Table("tags").
+		Select("tags.slug AS slug, cards.id AS card_id").
+		Joins("JOIN card_tags ON card_tags.tag_id = tags.id").
+		Joins("JOIN cards ON cards.id = card_tags.card_id").
+		Where("tags.slug IN ? AND cards.is_active = ?", slugs, true).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load expansion cards: %w", err)
+	}
+
+	cards := make(map[string][]int, len(slugs))
+	for _, row := range rows {
+		cards[row.Slug] = append(cards[row.Slug], row.CardID)
+	}
+	return cards, nil
+}
+
+// UpdateGameRules records a room's table rules
+func (m *Manager) UpdateGameRules(ctx context.Context, gameID uuid.UUID, rules GameRules) error {
+	if err := m.db.WithContext(ctx).Model(&models.Game{}).
+		Where("id = ?", gameID).
+		Updates(map[string]interface{}{
+			"max_players":                rules.MaxPlayers,
+			"target_score":               rules.TargetScore,
+			"hand_size":                  rules.HandSize,
+			"round_timer_seconds":        rules.RoundTimerSeconds,
+			"storytelling_timer_seconds": rules.PhaseTimers.StorytellingSeconds,
+			"submitting_timer_seconds":   rules.PhaseTimers.SubmittingSeconds,
+			"voting_timer_seconds":       rules.PhaseTimers.VotingSeconds,
+			"expansions":                 strings.Join(rules.Expansions, ","),
+			"large_game":                 rules.LargeGame,
+			"team_size":                  rules.TeamSize,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update game rules: %w", err)
+	}
+	return nil
+}
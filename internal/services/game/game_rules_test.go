@@ -0,0 +1,140 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSettingsNormalizesRules(t *testing.T) {
+	settings := DefaultGameSettings()
+	settings.Rules = GameRules{Expansions: []string{" Imported-Dreams ", "imported-dreams"}}
+
+	settings, err := ValidateSettings(settings)
+	require.NoError(t, err)
+	assert.Equal(t, maxPlayersPerRoom, settings.Rules.MaxPlayers)
+	assert.Equal(t, defaultTargetScore, settings.Rules.TargetScore)
+	assert.Equal(t, defaultHandSize, settings.Rules.HandSize)
+	assert.Equal(t, []string{"imported-dreams"}, settings.Rules.Expansions)
+}
+
+func TestGameRulesValidate(t *testing.T) {
+	valid := DefaultGameRules()
+	assert.NoError(t, valid.Validate())
+
+	for name, rules := range map[string]GameRules{
+		"too few seats":  {MaxPlayers: 2, TargetScore: 30, HandSize: 6},
+		"too many seats": {MaxPlayers: maxCasualPlayers + 1, TargetScore: 30, HandSize: 6},
+		"low target":     {MaxPlayers: 6, TargetScore: 5, HandSize: 6},
+		"tiny hand":      {MaxPlayers: 6, TargetScore: 30, HandSize: 3},
+		"short timer":    {MaxPlayers: 6, TargetScore: 30, HandSize: 6, RoundTimerSeconds: 10},
+		"bad expansion":  {MaxPlayers: 6, TargetScore: 30, HandSize: 6, Expansions: []string{"no spaces"}},
+		"long voting":    {MaxPlayers: 6, TargetScore: 30, HandSize: 6, PhaseTimers: PhaseTimers{VotingSeconds: 900}},
+	} {
+		assert.Error(t, rules.Validate(), name)
+	}
+
+	settings := DefaultGameSettings()
+	settings.Rules.MaxPlayers = 4
+	settings.Pace = PaceCustom
+	settings.Timing = PaceOptions{RevealDelaySeconds: 5, AFKTimeoutSeconds: 180, AutoStartPlayers: 5}
+	_, err := ValidateSettings(settings)
+	assert.Error(t, err, "auto-start can't wait for more players than there are seats")
+}
+
+func TestLargeTablesAreCasualOnly(t *testing.T) {
+	settings := DefaultGameSettings()
+	settings.Rules.MaxPlayers = 8
+	_, err := ValidateSettings(settings)
+	assert.NoError(t, err)
+
+	settings.Ranked = true
+	_, err = ValidateSettings(settings)
+	assert.Error(t, err)
+}
+
+func TestLargeTablesNeedExtendedDecks(t *testing.T) {
+	assert.Equal(t, 36, requiredDeckSize(6, 6), "standard tables only need their hands dealt")
+	assert.Equal(t, 42+49, requiredDeckSize(7, 6))
+	assert.Greater(t, requiredDeckSize(8, 6), classicDeckSize, "eight players need expansions")
+}
+
+func TestGameRulesFallBackToStandardRules(t *testing.T) {
+	var unset GameRules
+	assert.Equal(t, maxPlayersPerRoom, unset.PlayerLimit())
+	assert.Equal(t, 30, unset.WinningScore())
+	assert.Equal(t, 6, unset.CardsPerHand())
+
+	rules := rulesFromModel(&models.Game{MaxPlayers: 4, TargetScore: 20, HandSize: 7, Expansions: "imported-a,imported-b"})
+	assert.Equal(t, 4, rules.PlayerLimit())
+	assert.Equal(t, 20, rules.WinningScore())
+	assert.Equal(t, 7, rules.CardsPerHand())
+	assert.Equal(t, []string{"imported-a", "imported-b"}, rules.Expansions)
+}
+
+func TestShuffleWithExpansionCards(t *testing.T) {
+	seed := "expansion-seed"
+	expansion := []int{120, 101, 110}
+
+	assert.Equal(t, ShuffledDeck(seed), ShuffledDeckOf(seed, deckCards(nil, nil)), "base decks shuffle as before")
+
+	deck := ShuffledDeckOf(seed, deckCards(nil, expansion))
+	assert.Len(t, deck, classicDeckSize+len(expansion))
+	assert.Subset(t, deck, expansion)
+
+	verified, ok := VerifyShuffle(seed, ShuffleCommitment(seed, deck), nil, nil, expansion)
+	assert.True(t, ok)
+	assert.Equal(t, deck, verified)
+	_, ok = VerifyShuffle(seed, ShuffleCommitment(seed, deck), nil, nil, nil)
+	assert.False(t, ok, "the proof needs the expansion cards")
+}
+
+func TestPhaseTimeLimitFallsBackToRoundTimer(t *testing.T) {
+	rules := DefaultGameRules()
+	assert.Zero(t, rules.PhaseTimeLimit(models.RoundStatusVoting), "untimed by default")
+
+	rules.RoundTimerSeconds = 90
+	rules.PhaseTimers.StorytellingSeconds = 120
+	assert.Equal(t, 2*time.Minute, rules.PhaseTimeLimit(models.RoundStatusStorytelling))
+	assert.Equal(t, 90*time.Second, rules.PhaseTimeLimit(models.RoundStatusSubmitting))
+	assert.Equal(t, 90*time.Second, rules.PhaseTimeLimit(models.RoundStatusVoting))
+	assert.Zero(t, rules.PhaseTimeLimit(models.RoundStatusScoring))
+}
+
+func TestTimerAnnouncements(t *testing.T) {
+	assert.Equal(t, []time.Duration{30 * time.Second, finalWarning}, timerAnnouncements(30*time.Second))
+	assert.Equal(t, []time.Duration{2 * time.Minute, time.Minute, finalWarning}, timerAnnouncements(2*time.Minute))
+}
+
+func TestLateHumansAreThoseStillDue(t *testing.T) {
+	storyteller, late, done, botID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	game := &GameState{
+		Players: map[uuid.UUID]*Player{
+			storyteller: {ID: storyteller, Hand: []int{1, 2}},
+			late:        {ID: late, Hand: []int{3, 4}},
+			done:        {ID: done, Hand: []int{5}},
+			botID:       {ID: botID, IsBot: true, Hand: []int{7, 8}},
+		},
+		CurrentRound: &Round{
+			StorytellerID: storyteller,
+			Clue:          "tide",
+			Status:        models.RoundStatusSubmitting,
+			Submissions:   map[uuid.UUID]*CardSubmission{done: {PlayerID: done, CardID: 6}},
+			Votes:         map[uuid.UUID]*Vote{},
+		},
+	}
+
+	assert.Equal(t, []uuid.UUID{late}, lateHumans(game))
+
+	game.CurrentRound.Status = models.RoundStatusStorytelling
+	assert.Equal(t, []uuid.UUID{storyteller}, lateHumans(game))
+
+	game.CurrentRound.Status = models.RoundStatusVoting
+	game.CurrentRound.Votes[late] = &Vote{PlayerID: late, CardID: 6}
+	assert.Equal(t, []uuid.UUID{done}, lateHumans(game))
+}
@@ -0,0 +1,280 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dixitme/internal/events"
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/clues"
+	"dixitme/internal/services/experiments"
+
+	"github.com/google/uuid"
+)
+
+// Clue language enforcement levels
+const (
+	LanguageEnforcementOff    = "off"    // Detect and record only
+	LanguageEnforcementWarn   = "warn"   // Accept the clue but warn the room
+	LanguageEnforcementReject = "reject" // Refuse clues detected in another language
+)
+
+// GameSettings holds per-room options chosen in the lobby
+type GameSettings struct {
+	Scoring             ScoringOptions `json:"scoring"`
+	ClueSuggestions     bool           `json:"clue_suggestions"`               // Beginner aid: storytellers may request clue inspiration
+	VoiceChat           bool           `json:"voice_chat"`                     // Allow peer-to-peer voice with server-relayed signaling
+	PartyModifiers      bool           `json:"party_modifiers"`                // Give each round a random rule twist
+	Mulligan            bool           `json:"mulligan"`                       // Storytellers may exchange their hand once per game
+	Language            string         `json:"language,omitempty"`             // Declared room language (ISO 639-1), empty for any
+	LanguageEnforcement string         `json:"language_enforcement,omitempty"` // off, warn or reject clues in another language
+	ClueWordLimit       int            `json:"clue_word_limit,omitempty"`      // Most words a clue may have (0 = no limit)
+	MaxBots             int            `json:"max_bots,omitempty"`             // Room bot cap, stricter than the server's (0 = server cap)
+	Ranked              bool           `json:"ranked"`                         // Rated game: abandoning it costs a forfeit penalty
+	Private             bool           `json:"private"`                        // Left out of the lobby browser; joined by room code only
+	Pace                string         `json:"pace"`                           // blitz, standard, relaxed or custom
+	Timing              PaceOptions    `json:"timing"`                         // Set by the pace preset; only editable with the custom pace
+	Experiments         []string       `json:"experiments"`                    // Opted-in experimental mechanics (see services/experiments)
+
+	AFK  AFKThresholds  `json:"afk"`  // Room overrides of the deployment's AFK thresholds
+	Chat ChatModeration `json:"chat"` // Host chat controls, changed with SetChatModeration
+
+	SilentBots bool `json:"silent_bots"` // Bots never react in chat
+
+	CardTitles string `json:"card_titles"` // never (classic) or reveal titles once voting is over (educational)
+	FreshCards bool   `json:"fresh_cards"` // Bias the deck against cards the same group saw in its last games
+
+	Rules GameRules `json:"rules"` // Seats, target score, hand size, round timer and expansions
+}
+
+// validateLanguage checks the declared room language and enforcement level
+func (s GameSettings) validateLanguage() error {
+	if s.Language != "" && !clues.IsSupportedLanguage(s.Language) {
+		return fmt.Errorf("unsupported room language: %s", s.Language)
+	}
+	switch s.LanguageEnforcement {
+	case "", LanguageEnforcementOff, LanguageEnforcementWarn, LanguageEnforcementReject:
+	default:
+		return fmt.Errorf("language enforcement must be off, warn or reject")
+	}
+	if s.Language == "" && s.LanguageEnforcement != "" && s.LanguageEnforcement != LanguageEnforcementOff {
+		return fmt.Errorf("a room language is required to enforce it")
+	}
+	return nil
+}
+
+// HasExperiment reports whether the room has opted into an experiment
+func (s GameSettings) HasExperiment(key string) bool {
+	for _, experiment := range s.Experiments {
+		if experiment == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ScoringOptions toggles optional scoring modifiers on top of the standard Dixit rules
+type ScoringOptions struct {
+	StreakBonus        bool `json:"streak_bonus"`
+	StreakThreshold    int  `json:"streak_threshold,omitempty"`    // Consecutive correct guesses before the bonus applies
+	StreakBonusPoints  int  `json:"streak_bonus_points,omitempty"` // Extra points per round once the streak is reached
+	DiminishingFooling bool `json:"diminishing_fooling"`
+	FoolingFullValue   int  `json:"fooling_full_value,omitempty"` // Times a player can fool the same voter at full value
+	StorytellerCap     bool `json:"storyteller_cap"`
+	StorytellerMaxLead int  `json:"storyteller_max_lead,omitempty"` // Max lead a storyteller can build from storyteller points
+}
+
+// DefaultGameSettings returns settings matching the standard Dixit rules
+func DefaultGameSettings() GameSettings {
+	return GameSettings{
+		Scoring: ScoringOptions{
+			StreakThreshold:    3,
+			StreakBonusPoints:  1,
+			FoolingFullValue:   2,
+			StorytellerMaxLead: 10,
+		},
+		LanguageEnforcement: LanguageEnforcementOff,
+		Pace:                PaceStandard,
+		Timing:              pacePresets[PaceStandard],
+		Experiments:         []string{},
+		CardTitles:          CardTitlesNever,
+		Rules:               DefaultGameRules(),
+	}
+}
+
+// Validate checks that enabled modifiers have sensible parameters
+func (o ScoringOptions) Validate() error {
+	if o.StreakBonus {
+		if o.StreakThreshold < 2 {
+			return fmt.Errorf("streak threshold must be at least 2")
+		}
+		if o.StreakBonusPoints < 1 || o.StreakBonusPoints > 3 {
+			return fmt.Errorf("streak bonus points must be between 1 and 3")
+		}
+	}
+	if o.DiminishingFooling && o.FoolingFullValue < 1 {
+		return fmt.Errorf("fooling full value must be at least 1")
+	}
+	if o.StorytellerCap && o.StorytellerMaxLead < 1 {
+		return fmt.Errorf("storyteller max lead must be at least 1")
+	}
+	return nil
+}
+
+// ValidateSettings checks settings that do not depend on a room and returns
+// them normalized: pace presets resolved and experiments deduplicated
+func ValidateSettings(settings GameSettings) (GameSettings, error) {
+	if err := settings.Scoring.Validate(); err != nil {
+		return settings, err
+	}
+	if err := settings.validateLanguage(); err != nil {
+		return settings, err
+	}
+	if err := settings.validateClueWordLimit(); err != nil {
+		return settings, err
+	}
+	if err := settings.resolvePace(); err != nil {
+		return settings, err
+	}
+	if err := settings.AFK.Validate(); err != nil {
+		return settings, err
+	}
+	settings.Rules.normalize()
+	if err := settings.Rules.Validate(); err != nil {
+		return settings, err
+	}
+	if settings.Ranked && settings.Rules.MaxPlayers > maxPlayersPerRoom {
+		return settings, fmt.Errorf("ranked rooms seat at most %d players", maxPlayersPerRoom)
+	}
+	if settings.Ranked && (settings.Rules.LargeGame || settings.Rules.TeamSize != 0) {
+		return settings, fmt.Errorf("ranked rooms play the standard rules")
+	}
+	if settings.MaxBots < 0 || settings.MaxBots >= settings.Rules.MaxPlayers {
+		return settings, fmt.Errorf("max bots must be between 0 and %d", settings.Rules.MaxPlayers-1)
+	}
+	if settings.Timing.AutoStartPlayers > settings.Rules.MaxPlayers {
+		return settings, fmt.Errorf("auto-start can't wait for more players than the %d seats", settings.Rules.MaxPlayers)
+	}
+	if settings.LanguageEnforcement == "" {
+		settings.LanguageEnforcement = LanguageEnforcementOff
+	}
+	if err := settings.validateCardTitles(); err != nil {
+		return settings, err
+	}
+	if settings.CardTitles == "" {
+		settings.CardTitles = CardTitlesNever
+	}
+	if err := experiments.Validate(settings.Experiments); err != nil {
+		return settings, err
+	}
+	settings.Experiments = experiments.Normalize(settings.Experiments)
+	return settings, nil
+}
+
+// UpdateGameSettings replaces the settings of a game that has not started yet
+func (m *Manager) UpdateGameSettings(roomCode string, playerID uuid.UUID, settings GameSettings) (*GameState, error) {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return nil, fmt.Errorf("game not found")
+	}
+
+	settings, err := ValidateSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if _, exists := game.Players[playerID]; !exists {
+		return nil, fmt.Errorf("player not in game")
+	}
+	if err := requireHost(game, playerID, "change the settings"); err != nil {
+		return nil, err
+	}
+
+	if game.Status != models.GameStatusWaiting {
+		return nil, fmt.Errorf("settings can only be changed before the game starts")
+	}
+
+	if settings.Ranked && game.Sandbox {
+		return nil, fmt.Errorf("sandbox games can't be ranked")
+	}
+	if len(settings.Rules.Expansions) > 0 && game.Sandbox {
+		return nil, fmt.Errorf("sandbox games play with the base cards only")
+	}
+	if len(game.Players) > settings.Rules.MaxPlayers {
+		return nil, fmt.Errorf("the room already has %d players; remove some before lowering the seats", len(game.Players))
+	}
+
+	if bots, _ := seatCounts(game); settings.MaxBots > 0 && bots > settings.MaxBots {
+		return nil, &GameError{
+			Code:    ErrCodeBotLimit,
+			Message: fmt.Sprintf("the room already has %d bots; remove some before lowering the cap", bots),
+			Details: map[string]interface{}{"max_bots": settings.MaxBots, "bots": bots},
+		}
+	}
+
+	// Disconnect anyone already in voice when voice gets turned off
+	if game.Settings.VoiceChat && !settings.VoiceChat {
+		for _, player := range game.Players {
+			if player.VoiceJoined {
+				m.leaveVoice(game, player)
+			}
+		}
+	}
+
+	if settings.Pace != game.Settings.Pace {
+		if err := m.repository(game).UpdateGamePace(context.Background(), game.ID, settings.Pace); err != nil {
+			logger.Error("Failed to persist game pace", "error", err, "room_code", roomCode)
+		}
+	}
+
+	// A password makes a room private for good
+	if game.PasswordProtected {
+		settings.Private = true
+	}
+	if settings.Private != game.Settings.Private {
+		if err := m.repository(game).UpdateGameVisibility(context.Background(), game.ID, settings.Private); err != nil {
+			logger.Error("Failed to persist game visibility", "error", err, "room_code", roomCode)
+		}
+	}
+
+	if err := m.repository(game).UpdateGameRules(context.Background(), game.ID, settings.Rules); err != nil {
+		logger.Error("Failed to persist game rules", "error", err, "room_code", roomCode)
+	}
+
+	// Chat controls belong to the host and outlive lobby setting changes
+	settings.Chat = game.Settings.Chat
+	game.Settings = settings
+	game.LastActivity = time.Now()
+
+	// Lowering the auto-start threshold may already be met
+	m.maybeAutoStart(game)
+
+	events.Publish(m.bus, TopicSettingsUpdated, SettingsUpdated{Game: game})
+	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
+
+	logger.Info("Game settings updated",
+		"room_code", roomCode,
+		"updated_by", playerID,
+		"streak_bonus", settings.Scoring.StreakBonus,
+		"diminishing_fooling", settings.Scoring.DiminishingFooling,
+		"storyteller_cap", settings.Scoring.StorytellerCap,
+		"clue_suggestions", settings.ClueSuggestions,
+		"voice_chat", settings.VoiceChat,
+		"party_modifiers", settings.PartyModifiers,
+		"mulligan", settings.Mulligan,
+		"max_bots", settings.MaxBots,
+		"ranked", settings.Ranked,
+		"private", settings.Private,
+		"pace", settings.Pace,
+		"language", settings.Language,
+		"language_enforcement", settings.LanguageEnforcement,
+		"experiments", settings.Experiments,
+		"rules", settings.Rules)
+
+	return game, nil
+}
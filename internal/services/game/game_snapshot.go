@@ -0,0 +1,205 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/bot"
+
+	"github.com/google/uuid"
+)
+
+// gameSnapshotVersion is bumped whenever the snapshot layout changes, so an
+// instance never resumes a game from a snapshot it can't read
+const gameSnapshotVersion = 1
+
+// gameSnapshot is the cached form of a game: everything needed to resume it
+// after a restart or on another instance. Connections are left out; players
+// reconnect and are marked connected again.
+type gameSnapshot struct {
+	Version int        `json:"version"`
+	Game    *GameState `json:"game"`
+
+	ShuffleSeed  string             `json:"shuffle_seed"`
+	PasswordHash string             `json:"password_hash,omitempty"`
+	AvoidedCards []int              `json:"avoided_cards,omitempty"`
+	PackCards    []int              `json:"pack_cards,omitempty"`
+	Expansion    []int              `json:"expansion_cards,omitempty"`
+	Timeline     []RoundScoreSample `json:"timeline,omitempty"`
+	ReplaySeq    int                `json:"replay_seq,omitempty"`
+	Scoring      scoringSnapshot    `json:"scoring"`
+	SavedAt      time.Time          `json:"saved_at"`
+}
+
+// scoringSnapshot holds the cross-round scoring state of a ScoringHistory
+type scoringSnapshot struct {
+	Streaks     map[uuid.UUID]int               `json:"streaks,omitempty"`
+	Fooled      map[uuid.UUID]map[uuid.UUID]int `json:"fooled,omitempty"`
+	DoubleDowns map[uuid.UUID]bool              `json:"double_downs,omitempty"`
+}
+
+// encodeGameSnapshot serializes a game for the cache. Callers hold the game lock.
+func encodeGameSnapshot(game *GameState) ([]byte, error) {
+	snapshot := gameSnapshot{
+		Version:      gameSnapshotVersion,
+		Game:         game,
+		ShuffleSeed:  game.shuffleSeed,
+		PasswordHash: game.passwordHash,
+		AvoidedCards: game.avoidedCards,
+		PackCards:    game.packCards,
+		Expansion:    game.expansionCards,
+		Timeline:     game.timeline,
+		ReplaySeq:    game.replaySeq,
+		SavedAt:      time.Now(),
+	}
+	if game.history != nil {
+		snapshot.Scoring = scoringSnapshot{
+			Streaks:     game.history.streaks,
+			Fooled:      game.history.fooled,
+			DoubleDowns: game.history.doubleDowns,
+		}
+	}
+	return json.Marshal(snapshot)
+}
+
+// decodeGameSnapshot rebuilds a game from its cached form. It returns nil for
+// snapshots written in another layout, which callers treat as a cache miss.
+func decodeGameSnapshot(data []byte) (*GameState, error) {
+	var snapshot gameSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game snapshot: %w", err)
+	}
+	if snapshot.Version != gameSnapshotVersion || snapshot.Game == nil {
+		return nil, nil
+	}
+
+	game := snapshot.Game
+	game.shuffleSeed = snapshot.ShuffleSeed
+	game.passwordHash = snapshot.PasswordHash
+	game.avoidedCards = snapshot.AvoidedCards
+	game.packCards = snapshot.PackCards
+	game.expansionCards = snapshot.Expansion
+	game.timeline = snapshot.Timeline
+	game.replaySeq = snapshot.ReplaySeq
+	game.analytics = newGameAnalytics()
+	game.history = NewScoringHistory()
+	if snapshot.Scoring.Streaks != nil {
+		game.history.streaks = snapshot.Scoring.Streaks
+	}
+	if snapshot.Scoring.Fooled != nil {
+		game.history.fooled = snapshot.Scoring.Fooled
+	}
+	if snapshot.Scoring.DoubleDowns != nil {
+		game.history.doubleDowns = snapshot.Scoring.DoubleDowns
+	}
+
+	if game.Players == nil {
+		game.Players = make(map[uuid.UUID]*Player)
+	}
+	now := time.Now()
+	for _, player := range game.Players {
+		if player.Hand == nil {
+			player.Hand = make([]int, 0)
+		}
+		// Humans have to reconnect to this instance; bots are always connected
+		player.IsConnected = player.IsBot
+		player.LastActivity = now
+	}
+	if round := game.CurrentRound; round != nil {
+		if round.Submissions == nil {
+			round.Submissions = make(map[uuid.UUID]*CardSubmission)
+		}
+		if round.Votes == nil {
+			round.Votes = make(map[uuid.UUID]*Vote)
+		}
+	}
+	// Its ballots and expiry timer don't survive the move
+	game.KickVote = nil
+	game.LastActivity = now
+
+	return game, nil
+}
+
+// cacheGameState refreshes a game's cached snapshot after a change of state.
+// Callers hold the game lock.
+func (m *Manager) cacheGameState(game *GameState) {
+	if err := m.StoreGameInRedis(context.Background(), game); err != nil {
+		logger.Error("Failed to update game in Redis", "error", err, "room_code", game.RoomCode)
+	}
+}
+
+// resumeGameFromRedis loads a game this instance doesn't hold from the cache,
+// so a game survives a restart or moves to another instance. Only games that
+// are still open or being played are resumed.
+func (m *Manager) resumeGameFromRedis(roomCode string) *GameState {
+	if m.redisClient == nil {
+		return nil
+	}
+
+	game, err := m.LoadGameFromRedis(context.Background(), roomCode)
+	if err != nil || game == nil {
+		return nil
+	}
+	if game.Status != models.GameStatusWaiting && game.Status != models.GameStatusInProgress {
+		return nil
+	}
+
+	m.mu.Lock()
+	if existing, exists := m.games[roomCode]; exists {
+		// Resumed concurrently; keep the copy players may already be using
+		m.mu.Unlock()
+		return existing
+	}
+	m.games[roomCode] = game
+	m.mu.Unlock()
+
+	game.mu.Lock()
+	m.resumePendingEvents(game)
+	game.mu.Unlock()
+
+	logger.Info("Resumed game from Redis",
+		"room_code", roomCode,
+		"status", game.Status,
+		"round_number", game.RoundNumber,
+		"player_count", len(game.Players))
+	return game
+}
+
+// resumePendingEvents restarts what a resumed game was waiting on: its bots'
+// moves and, between rounds, the next round. Callers hold the game lock.
+func (m *Manager) resumePendingEvents(game *GameState) {
+	botManager := bot.GetBotManager()
+	for _, player := range game.Players {
+		if player.IsBot && player.IsActive {
+			botManager.RestoreBot(player.ID, player.Name, bot.BotDifficulty(player.BotLevel), game.ID)
+		}
+	}
+
+	if game.Status != models.GameStatusInProgress || game.CurrentRound == nil {
+		return
+	}
+	switch game.CurrentRound.Status {
+	case models.RoundStatusScoring, models.RoundStatusCompleted:
+		m.scheduleNextRound(game)
+	default:
+		m.startPhaseTimer(game)
+		m.ProcessBotActions(game)
+	}
+}
+
+// cachedGame returns the cached snapshot of a game loaded from the database,
+// or nil when the cache has none for it
+func (m *Manager) cachedGame(dbGame *models.Game) *GameState {
+	if m.redisClient == nil {
+		return nil
+	}
+	game, err := m.LoadGameFromRedis(context.Background(), dbGame.RoomCode)
+	if err != nil || game == nil || game.ID != dbGame.ID {
+		return nil
+	}
+	return game
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameSnapshotRoundTrip(t *testing.T) {
+	storyteller, voter, botID := uuid.New(), uuid.New(), uuid.New()
+	game := &GameState{
+		ID:       uuid.New(),
+		RoomCode: "SNAP01",
+		HostID:   storyteller,
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			storyteller: {ID: storyteller, Name: "Ana", Score: 3, Hand: []int{1, 2, 3}, IsConnected: true, IsActive: true},
+			voter:       {ID: voter, Name: "Ben", Score: 1, Hand: []int{4, 5}, IsConnected: true, IsActive: true},
+			botID:       {ID: botID, Name: "Bot", IsBot: true, BotLevel: "easy", IsConnected: true, IsActive: true},
+		},
+		CurrentRound: &Round{
+			ID:              uuid.New(),
+			RoundNumber:     2,
+			StorytellerID:   storyteller,
+			Clue:            "dream",
+			Status:          models.RoundStatusVoting,
+			StorytellerCard: 7,
+			Submissions:     map[uuid.UUID]*CardSubmission{voter: {PlayerID: voter, CardID: 8}},
+			Votes:           map[uuid.UUID]*Vote{voter: {PlayerID: voter, CardID: 7, Weight: 2}},
+		},
+		RoundNumber:       2,
+		MaxRounds:         10,
+		Deck:              []int{10, 11, 12},
+		UsedCards:         []int{7, 8},
+		Settings:          DefaultGameSettings(),
+		StorytellerOrder:  []uuid.UUID{storyteller, voter, botID},
+		NextStoryteller:   1,
+		ShuffleCommitment: "commitment",
+		shuffleSeed:       "seed",
+		avoidedCards:      []int{3, 4},
+		history:           NewScoringHistory(),
+		timeline:          []RoundScoreSample{{RoundNumber: 1, Scores: map[uuid.UUID]int{storyteller: 3}}},
+	}
+	game.history.streaks[voter] = 2
+	game.history.fooled[voter] = map[uuid.UUID]int{storyteller: 1}
+	game.history.doubleDowns[voter] = true
+
+	data, err := encodeGameSnapshot(game)
+	require.NoError(t, err)
+	restored, err := decodeGameSnapshot(data)
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+
+	assert.Equal(t, game.ID, restored.ID)
+	assert.Equal(t, game.Status, restored.Status)
+	assert.Equal(t, game.Deck, restored.Deck)
+	assert.Equal(t, game.UsedCards, restored.UsedCards)
+	assert.Equal(t, game.StorytellerOrder, restored.StorytellerOrder)
+	assert.Equal(t, game.NextStoryteller, restored.NextStoryteller)
+	assert.Equal(t, "seed", restored.shuffleSeed)
+	assert.Equal(t, []int{3, 4}, restored.avoidedCards)
+	assert.Len(t, restored.timeline, 1)
+
+	assert.Equal(t, []int{1, 2, 3}, restored.Players[storyteller].Hand)
+	assert.Equal(t, 3, restored.Players[storyteller].Score)
+	assert.False(t, restored.Players[storyteller].IsConnected, "humans must reconnect")
+	assert.True(t, restored.Players[botID].IsConnected)
+
+	round := restored.CurrentRound
+	require.NotNil(t, round)
+	assert.Equal(t, models.RoundStatusVoting, round.Status)
+	assert.Equal(t, 7, round.StorytellerCard)
+	assert.Equal(t, 8, round.Submissions[voter].CardID)
+	assert.Equal(t, 2, round.Votes[voter].Weight)
+
+	assert.Equal(t, 2, restored.history.Streak(voter))
+	assert.Equal(t, 1, restored.history.TimesFooled(voter, storyteller))
+	assert.True(t, restored.history.HasDoubledDown(voter))
+	assert.NotNil(t, restored.analytics)
+}
+
+func TestGameSnapshotIgnoresOtherLayouts(t *testing.T) {
+	restored, err := decodeGameSnapshot([]byte(`{"id":"x","room_code":"OLD","players":[]}`))
+	assert.NoError(t, err)
+	assert.Nil(t, restored)
+
+	_, err = decodeGameSnapshot([]byte(`not json`))
+	assert.Error(t, err)
+}
@@ -7,13 +7,13 @@ import (
 	"dixitme/internal/models"
 
 	"github.com/google/uuid"
-	"github.com/gorilla/websocket"
 )
 
 // GameState represents the in-memory state of an active game
 type GameState struct {
 	ID           uuid.UUID             `json:"id"`
 	RoomCode     string                `json:"room_code"`
+	HostID       uuid.UUID             `json:"host_id"` // Player who created the room
 	Players      map[uuid.UUID]*Player `json:"players"`
 	CurrentRound *Round                `json:"current_round"`
 	Status       models.GameStatus     `json:"status"`
@@ -21,9 +21,54 @@ type GameState struct {
 	MaxRounds    int                   `json:"max_rounds"`
 	Deck         []int                 `json:"deck"`       // Remaining cards in deck
 	UsedCards    []int                 `json:"used_cards"` // Cards that have been played
+	Settings     GameSettings          `json:"settings"`
+	Sandbox      bool                  `json:"sandbox"`          // Practice room that is never written to the database
+	Region       string                `json:"region,omitempty"` // Region of the instance the room was created on
 	CreatedAt    time.Time             `json:"created_at"`
 	LastActivity time.Time             `json:"last_activity"`
+	history      *ScoringHistory       `json:"-"` // Cross-round state for scoring modifiers
+	analytics    *gameAnalytics        `json:"-"` // Data for the post-game host report
+	timeline     []RoundScoreSample    `json:"-"` // Scores at the end of each round
+	replaySeq    int                   `json:"-"` // Number of the last replay event recorded
 	mu           sync.RWMutex          `json:"-"`
+
+	// Deck fairness: the commitment is public from the start, the seed is revealed once the game is over
+	ShuffleCommitment string `json:"shuffle_commitment"` // Hash of the seed and initial deck order
+	shuffleSeed       string
+	avoidedCards      []int // Recently seen cards a fresh cards shuffle weighed down
+	packCards         []int // Cards of the room's packs or custom deck, nil for the classic deck
+	expansionCards    []int // Cards of the room's expansions, shuffled in when the game started
+
+	// New players need the room password; seated players rejoin without it
+	PasswordProtected bool `json:"password_protected"`
+	passwordHash      string
+
+	// Tournament whose external ladder the result is reported to (nil for casual games)
+	TournamentID *uuid.UUID `json:"tournament_id,omitempty"`
+
+	// Card packs the deck was built from (the default packs when empty)
+	Packs []string `json:"packs,omitempty"`
+
+	// Custom deck the room plays with instead of card packs, if any
+	DeckID *uuid.UUID `json:"deck_id,omitempty"`
+
+	// Open vote on handing a disruptive player's seat to a bot, if any
+	KickVote *KickVote `json:"kick_vote,omitempty"`
+
+	// Lobby of the rematch that followed this game, once someone asked for one
+	RematchRoomCode string `json:"rematch_room_code,omitempty"`
+
+	// Humans waiting for the host to hand them a bot's seat, by bot ID
+	TakeoverRequests map[uuid.UUID]*TakeoverRequest `json:"takeover_requests,omitempty"`
+
+	// Storyteller rotation in seat order, and the index of the next storyteller in it
+	StorytellerOrder []uuid.UUID `json:"storyteller_order"`
+	NextStoryteller  int         `json:"next_storyteller"`
+
+	// Players and admins watching the room's debug stream. debugMu is a leaf
+	// lock, so events can be sent with or without mu held.
+	debugWatchers map[uuid.UUID]bool
+	debugMu       sync.Mutex
 }
 
 // Lock locks the game state for writing
@@ -36,6 +81,16 @@ func (gs *GameState) Unlock() {
 	gs.mu.Unlock()
 }
 
+// RLock locks the game state for reading
+func (gs *GameState) RLock() {
+	gs.mu.RLock()
+}
+
+// RUnlock unlocks the game state after reading
+func (gs *GameState) RUnlock() {
+	gs.mu.RUnlock()
+}
+
 // UpdateActivity updates the last activity timestamp
 func (gs *GameState) UpdateActivity() {
 	gs.mu.Lock()
@@ -86,21 +141,54 @@ func (gs *GameState) GetActiveHumanPlayerCount(afkDuration time.Duration) int {
 	return activeCount
 }
 
+// playerNames returns the names of everyone in the game. Callers hold the lock.
+func (gs *GameState) playerNames() []string {
+	names := make([]string, 0, len(gs.Players))
+	for _, player := range gs.Players {
+		names = append(names, player.Name)
+	}
+	return names
+}
+
+// seatedPlayerCount counts the players taking part in rounds: everyone but
+// those whose seat went to a bot stand-in. Callers hold the lock.
+func (gs *GameState) seatedPlayerCount() int {
+	count := 0
+	for _, player := range gs.Players {
+		if !player.WasReplaced {
+			count++
+		}
+	}
+	return count
+}
+
 // Player represents an active player in the game
 type Player struct {
-	ID            uuid.UUID       `json:"id"`
-	Name          string          `json:"name"`
-	Score         int             `json:"score"`
-	Position      int             `json:"position"`
-	Hand          []int           `json:"hand"` // Card IDs in player's hand
-	Connection    *websocket.Conn `json:"-"`    // WebSocket connection
-	IsConnected   bool            `json:"is_connected"`
-	IsActive      bool            `json:"is_active"`
-	IsBot         bool            `json:"is_bot"`
-	BotLevel      string          `json:"bot_level,omitempty"`      // easy, medium, hard
-	LastActivity  time.Time       `json:"last_activity"`            // Track when player was last active
-	WasReplaced   bool            `json:"was_replaced"`             // Flag to indicate if this player was replaced by a bot
-	ReplacementID *uuid.UUID      `json:"replacement_id,omitempty"` // ID of the bot that replaced this player
+	ID            uuid.UUID    `json:"id"`
+	Name          string       `json:"name"`
+	Score         int          `json:"score"`
+	Position      int          `json:"position"`
+	Hand          []int        `json:"hand"` // Card IDs in player's hand
+	Connection    Connection   `json:"-"`    // Client connection (WebSocket or long-polling)
+	IsConnected   bool         `json:"is_connected"`
+	IsActive      bool         `json:"is_active"`
+	IsBot         bool         `json:"is_bot"`
+	BotLevel      string       `json:"bot_level,omitempty"`      // easy, medium, hard
+	LastActivity  time.Time    `json:"last_activity"`            // Track when player was last active
+	WasReplaced   bool         `json:"was_replaced"`             // Flag to indicate if this player was replaced by a bot
+	ReplacementID *uuid.UUID   `json:"replacement_id,omitempty"` // ID of the bot that replaced this player
+	VoiceJoined   bool         `json:"voice_joined"`             // In the room's WebRTC voice channel
+	Speaking      bool         `json:"speaking"`                 // Speaking indicator reported by the client
+	Muted         bool         `json:"muted"`
+	MulliganUsed  bool         `json:"mulligan_used"`     // Exchanged their hand as storyteller this game
+	Token         string       `json:"token"`             // Room-scoped color and avatar, see PlayerTokens
+	Latency       LatencyLevel `json:"latency,omitempty"` // Coarse round-trip time, while connected over WebSocket
+	Team          int          `json:"team,omitempty"`    // Team number in team games, from 1
+
+	disconnectedAt time.Time     // When the connection dropped, for the reconnect metrics
+	resyncPending  bool          // An admin resync waits for the player to reconnect
+	lastChatAt     time.Time     // When the player last chatted, for slow mode
+	rtt            time.Duration // Last measured round-trip time
 }
 
 // UpdateActivity updates the player's last activity timestamp
@@ -143,24 +231,74 @@ type Round struct {
 	Submissions     map[uuid.UUID]*CardSubmission `json:"submissions"`
 	Votes           map[uuid.UUID]*Vote           `json:"votes"`
 	RevealedCards   []RevealedCard                `json:"revealed_cards,omitempty"`
+	Modifier        *RoundModifier                `json:"modifier,omitempty"`         // Rule twist in party modifiers mode
+	ClueLanguage    string                        `json:"clue_language,omitempty"`    // Detected language of the clue
+	PhaseDeadline   *time.Time                    `json:"phase_deadline,omitempty"`   // When the round timer runs out for this phase
+	PhaseSeconds    int                           `json:"phase_seconds,omitempty"`    // Length of this phase's timer
+	TimedOut        []uuid.UUID                   `json:"timed_out,omitempty"`        // Players a bot moved for when their time ran out
+	CardsPerPlayer  int                           `json:"cards_per_player,omitempty"` // Cards each non-storyteller submits, when more than one
 	CreatedAt       time.Time                     `json:"created_at"`
 }
 
 // CardSubmission represents a submitted card for the current round
 type CardSubmission struct {
-	PlayerID uuid.UUID `json:"player_id"`
-	CardID   int       `json:"card_id"`
+	PlayerID     uuid.UUID `json:"player_id"`
+	CardID       int       `json:"card_id"`
+	ExtraCardIDs []int     `json:"extra_card_ids,omitempty"` // Further cards, in rounds that take more than one
+}
+
+// Cards lists every card of the submission
+func (s *CardSubmission) Cards() []int {
+	return append([]int{s.CardID}, s.ExtraCardIDs...)
+}
+
+// cardsPerPlayer is the number of cards each non-storyteller submits
+func (r *Round) cardsPerPlayer() int {
+	if r.CardsPerPlayer < 1 {
+		return 1
+	}
+	return r.CardsPerPlayer
+}
+
+// cardsOwed is the number of cards a player has yet to submit
+func (r *Round) cardsOwed(playerID uuid.UUID) int {
+	owed := r.cardsPerPlayer()
+	if submission, exists := r.Submissions[playerID]; exists {
+		owed -= len(submission.Cards())
+	}
+	return max(owed, 0)
+}
+
+// submitted reports whether a player has submitted every card the round takes
+func (r *Round) submitted(playerID uuid.UUID) bool {
+	return r.cardsOwed(playerID) == 0
+}
+
+// completeSubmissions counts the players who have submitted every card
+func (r *Round) completeSubmissions() int {
+	complete := 0
+	for playerID := range r.Submissions {
+		if r.submitted(playerID) {
+			complete++
+		}
+	}
+	return complete
 }
 
 // Vote represents a player's vote
 type Vote struct {
-	PlayerID uuid.UUID `json:"player_id"`
-	CardID   int       `json:"card_id"`
+	PlayerID   uuid.UUID `json:"player_id"`
+	CardID     int       `json:"card_id"`
+	Weight     int       `json:"weight,omitempty"`      // Confidence stake, 1-3 (weighted_votes experiment)
+	DoubleDown bool      `json:"double_down,omitempty"` // Double-down bet (double_down experiment)
 }
 
 // RevealedCard represents a card shown during voting phase
 type RevealedCard struct {
-	CardID    int       `json:"card_id"`
-	PlayerID  uuid.UUID `json:"player_id"`
-	VoteCount int       `json:"vote_count"`
+	CardID      int       `json:"card_id"`
+	PlayerID    uuid.UUID `json:"player_id"`
+	PlayerToken string    `json:"player_token,omitempty"` // Token of the player who played the card
+	VoteCount   int       `json:"vote_count"`
+	Title       string    `json:"title,omitempty"`       // Only after voting, in rooms that reveal card titles
+	Description string    `json:"description,omitempty"` // Only after voting, in rooms that reveal card titles
 }
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"time"
+
+	"dixitme/internal/services/cardart"
+
+	"github.com/google/uuid"
+)
+
+// Client protocol versions
+const (
+	ProtocolV1 = 1 // Full game state, including every hand and the deck
+	ProtocolV2 = 2 // Public game state; each player only receives their own hand
+)
+
+// GameStateView is the v2 shape of a game state. Embedding keeps the public
+// fields in sync with GameState while the shadowing fields hide the deck and
+// discard pile, whose order would let clients predict the next draws.
+type GameStateView struct {
+	*GameState
+	Players       map[uuid.UUID]*PlayerView `json:"players"`
+	Deck          []int                     `json:"deck,omitempty"`       // Always empty in v2
+	UsedCards     []int                     `json:"used_cards,omitempty"` // Always empty in v2
+	DeckSize      int                       `json:"deck_size"`            // Same as DeckRemaining, kept for older v2 clients
+	DeckRemaining int                       `json:"deck_remaining"`
+	UsedCount     int                       `json:"used_count"`
+}
+
+// PlayerView is the v2 shape of a player, with their hand replaced by its size
+type PlayerView struct {
+	*Player
+	Hand     []int `json:"hand,omitempty"` // Always empty in v2
+	HandSize int   `json:"hand_size"`
+}
+
+// GameStateV2Payload carries a game state view and the recipient's own hand.
+// Cards are sent as IDs only; clients fetch art lazily through CardArt, or
+// resolve metadata in bulk with POST /cards/resolve.
+type GameStateV2Payload struct {
+	GameState *GameStateView    `json:"game_state"`
+	Hand      []int             `json:"hand"`               // The recipient's hand (empty for spectators)
+	CardArt   *cardart.Template `json:"card_art,omitempty"` // Signed art URL template, when signing is configured
+}
+
+// View builds the public v2 game state. Callers must hold the game lock.
+func (gs *GameState) View() *GameStateView {
+	players := make(map[uuid.UUID]*PlayerView, len(gs.Players))
+	for playerID, player := range gs.Players {
+		players[playerID] = &PlayerView{Player: player, HandSize: len(player.Hand)}
+	}
+
+	return &GameStateView{
+		GameState:     gs,
+		Players:       players,
+		DeckSize:      len(gs.Deck),
+		DeckRemaining: len(gs.Deck),
+		UsedCount:     len(gs.UsedCards),
+	}
+}
+
+// GameStateMessage builds the game state message for a recipient on the given protocol
+func GameStateMessage(gs *GameState, recipientID uuid.UUID, protocol int) GameMessage {
+	if protocol < ProtocolV2 {
+		return GameMessage{Type: MessageTypeGameState, Payload: GameStatePayload{GameState: gs}}
+	}
+
+	hand := []int{}
+	if player, exists := gs.Players[recipientID]; exists && player.Hand != nil {
+		hand = player.Hand
+	}
+	return GameMessage{
+		Type:    MessageTypeGameState,
+		Payload: GameStateV2Payload{GameState: gs.View(), Hand: hand, CardArt: cardart.NewTemplate(time.Now())},
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameStateMessageV2HidesOtherHands(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	gs := &GameState{
+		RoomCode: "ABCD",
+		Players: map[uuid.UUID]*Player{
+			alice: {ID: alice, Name: "Alice", Hand: []int{1, 2, 3}},
+			bob:   {ID: bob, Name: "Bob", Hand: []int{4, 5, 6}},
+		},
+		Deck:      []int{7, 8, 9, 10},
+		UsedCards: []int{11, 12},
+	}
+
+	data, err := json.Marshal(GameStateMessage(gs, alice, ProtocolV2))
+	require.NoError(t, err)
+
+	var decoded struct {
+		Payload struct {
+			GameState map[string]json.RawMessage `json:"game_state"`
+			Hand      []int                      `json:"hand"`
+		} `json:"payload"`
+	}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, []int{1, 2, 3}, decoded.Payload.Hand)
+	assert.NotContains(t, decoded.Payload.GameState, "deck")
+	assert.NotContains(t, decoded.Payload.GameState, "used_cards")
+	assert.JSONEq(t, "4", string(decoded.Payload.GameState["deck_size"]))
+	assert.JSONEq(t, "4", string(decoded.Payload.GameState["deck_remaining"]))
+	assert.JSONEq(t, "2", string(decoded.Payload.GameState["used_count"]))
+
+	var players map[string]map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(decoded.Payload.GameState["players"], &players))
+	for _, player := range players {
+		assert.NotContains(t, player, "hand")
+		assert.JSONEq(t, "3", string(player["hand_size"]))
+		assert.Contains(t, player, "name")
+	}
+}
+
+func TestGameStateMessageV1KeepsFullState(t *testing.T) {
+	alice := uuid.New()
+	gs := &GameState{
+		Players: map[uuid.UUID]*Player{alice: {ID: alice, Hand: []int{1, 2}}},
+		Deck:    []int{3},
+	}
+
+	message := GameStateMessage(gs, alice, ProtocolV1)
+	payload, ok := message.Payload.(GameStatePayload)
+	require.True(t, ok)
+	assert.Same(t, gs, payload.GameState)
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"dixitme/internal/events"
+
+	"github.com/google/uuid"
+)
+
+// GameLifecycleHook lets subsystems such as stats, achievements, webhooks and
+// ratings react to a game's lifecycle without the manager knowing about them.
+// It is a convenience over the manager's event bus: each method is a
+// subscriber of the matching topic (see events.go).
+//
+// Hooks run synchronously while the game is locked (the whole manager, for
+// OnGameCreated): they must not call back into the manager, and slow work
+// belongs in a goroutine working on copied data.
+type GameLifecycleHook interface {
+	// OnGameCreated runs once a new room is stored and persisted
+	OnGameCreated(game *GameState)
+	// OnRoundCompleted runs after a round is scored, with each player's points for it
+	OnRoundCompleted(game *GameState, round *Round, points map[uuid.UUID]int)
+	// OnGameCompleted runs when a game ends, played out or abandoned by every
+	// human. game.Status tells them apart; the outcome of an abandoned
+	// casual game is empty.
+	OnGameCompleted(game *GameState, result *GameResult)
+}
+
+// NopLifecycleHook implements every hook as a no-op, for embedding in hooks
+// that only care about some events
+type NopLifecycleHook struct{}
+
+func (NopLifecycleHook) OnGameCreated(*GameState) {}
+
+func (NopLifecycleHook) OnRoundCompleted(*GameState, *Round, map[uuid.UUID]int) {}
+
+func (NopLifecycleHook) OnGameCompleted(*GameState, *GameResult) {}
+
+// RegisterLifecycleHook subscribes a hook to the lifecycle topics. Hooks run
+// in registration order.
+func (m *Manager) RegisterLifecycleHook(hook GameLifecycleHook) {
+	events.Subscribe(m.bus, TopicGameCreated, func(e GameCreated) {
+		hook.OnGameCreated(e.Game)
+	})
+	events.Subscribe(m.bus, TopicRoundCompleted, func(e RoundCompleted) {
+		hook.OnRoundCompleted(e.Game, e.Round, e.Points)
+	})
+	events.Subscribe(m.bus, TopicGameCompleted, func(e GameCompleted) {
+		hook.OnGameCompleted(e.Game, e.Result)
+	})
+}
+
+// registerBuiltinHooks adds the manager's own lifecycle side effects
+func (m *Manager) registerBuiltinHooks() {
+	m.RegisterLifecycleHook(hostReportHook{manager: m})
+	m.RegisterLifecycleHook(botChatHook{manager: m})
+	m.RegisterLifecycleHook(accountPromptHook{manager: m})
+	m.RegisterLifecycleHook(standInNoticeHook{manager: m})
+	m.RegisterLifecycleHook(tableCardsHook{manager: m})
+	m.RegisterLifecycleHook(replayHook{manager: m})
+	m.registerEventMetrics()
+}
+
+func (m *Manager) notifyGameCreated(game *GameState) {
+	events.Publish(m.bus, TopicGameCreated, GameCreated{Game: game})
+}
+
+func (m *Manager) notifyGameStarted(game *GameState) {
+	events.Publish(m.bus, TopicGameStarted, GameStarted{Game: game})
+}
+
+func (m *Manager) notifyGameRemoved(game *GameState, deleted bool) {
+	events.Publish(m.bus, TopicGameRemoved, GameRemoved{Game: game, Deleted: deleted})
+}
+
+func (m *Manager) notifyRoundCompleted(game *GameState, round *Round, points map[uuid.UUID]int) {
+	events.Publish(m.bus, TopicRoundCompleted, RoundCompleted{Game: game, Round: round, Points: points})
+}
+
+func (m *Manager) notifyGameCompleted(game *GameState, result *GameResult) {
+	events.Publish(m.bus, TopicGameCompleted, GameCompleted{Game: game, Result: result})
+}
+
+// hostReportHook stores the host report when a game ends
+type hostReportHook struct {
+	NopLifecycleHook
+	manager *Manager
+}
+
+func (h hostReportHook) OnGameCompleted(game *GameState, _ *GameResult) {
+	h.manager.finalizeHostReport(game)
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"dixitme/internal/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHook notes the lifecycle events it sees
+type recordingHook struct {
+	NopLifecycleHook
+	name   string
+	events *[]string
+}
+
+func (h recordingHook) OnGameCreated(game *GameState) {
+	*h.events = append(*h.events, h.name+":created:"+game.RoomCode)
+}
+
+func (h recordingHook) OnGameCompleted(game *GameState, _ *GameResult) {
+	*h.events = append(*h.events, h.name+":completed:"+game.RoomCode)
+}
+
+// panickingHook fails on every game it sees
+type panickingHook struct {
+	NopLifecycleHook
+}
+
+func (panickingHook) OnGameCreated(*GameState) { panic("boom") }
+
+func TestLifecycleHooksRunInRegistrationOrder(t *testing.T) {
+	m := NewEphemeralManager()
+	var events []string
+	m.RegisterLifecycleHook(recordingHook{name: "stats", events: &events})
+	m.RegisterLifecycleHook(panickingHook{})
+	m.RegisterLifecycleHook(recordingHook{name: "webhooks", events: &events})
+
+	game, err := m.CreateGameWithOptions("HOOK", uuid.New(), "Alice", CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+
+	// A panicking hook doesn't stop the others or the game
+	assert.Equal(t, []string{"stats:created:HOOK", "webhooks:created:HOOK"}, events)
+	assert.Same(t, game, m.GetGame("HOOK"))
+
+	m.notifyGameCompleted(game, newGameResult(game, uuid.Nil, ""))
+	assert.Equal(t, "webhooks:completed:HOOK", events[len(events)-1])
+}
+
+func TestGameStartAndRemovalArePublished(t *testing.T) {
+	m := NewEphemeralManager()
+	var started []string
+	var removed []GameRemoved
+	events.Subscribe(m.Events(), TopicGameStarted, func(e GameStarted) { started = append(started, e.Game.RoomCode) })
+	events.Subscribe(m.Events(), TopicGameRemoved, func(e GameRemoved) { removed = append(removed, e) })
+
+	hostID := uuid.New()
+	for _, roomCode := range []string{"PLAY", "GONE", "IDLE"} {
+		_, err := m.CreateGameWithOptions(roomCode, hostID, "Alice", CreateGameOptions{Sandbox: true})
+		require.NoError(t, err)
+	}
+	for i := 0; i < 2; i++ {
+		_, err := m.AddBot("PLAY", "easy")
+		require.NoError(t, err)
+	}
+	require.NoError(t, m.StartGame("PLAY", hostID))
+	assert.Equal(t, []string{"PLAY"}, started)
+
+	require.NoError(t, m.DeleteGame("GONE", hostID))
+	require.Len(t, removed, 1)
+	assert.Equal(t, "GONE", removed[0].Game.RoomCode)
+	assert.True(t, removed[0].Deleted)
+
+	idle := m.GetGame("IDLE")
+	idle.Lock()
+	idle.LastActivity = time.Now().Add(-time.Hour)
+	idle.Unlock()
+	m.cleanupInactiveGames()
+	require.Len(t, removed, 2)
+	assert.Equal(t, "IDLE", removed[1].Game.RoomCode)
+	assert.False(t, removed[1].Deleted, "closed rooms keep their record")
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"fmt"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// requireHost refuses a lobby control to anyone but the host. Callers hold the game lock.
+func requireHost(game *GameState, playerID uuid.UUID, action string) error {
+	if game.HostID == playerID {
+		return nil
+	}
+	return &GameError{
+		Code:    ErrCodeNotHost,
+		Message: fmt.Sprintf("only the host can %s", action),
+		Details: map[string]interface{}{"host_id": game.HostID},
+	}
+}
+
+// checkHost runs requireHost on a room, for controls that lock the game themselves
+func (m *Manager) checkHost(roomCode string, playerID uuid.UUID, action string) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+	game.mu.RLock()
+	defer game.mu.RUnlock()
+	return requireHost(game, playerID, action)
+}
+
+// AddBotAsHost adds a bot on behalf of a player, who must be the host
+func (m *Manager) AddBotAsHost(roomCode string, playerID uuid.UUID, botLevel string) (*GameState, error) {
+	if err := m.checkHost(roomCode, playerID, "add bots"); err != nil {
+		return nil, err
+	}
+	return m.AddBot(roomCode, botLevel)
+}
+
+// KickPlayer removes someone else from the lobby on the host's behalf
+func (m *Manager) KickPlayer(roomCode string, hostID, playerID uuid.UUID) (*GameState, error) {
+	if err := m.checkHost(roomCode, hostID, "remove players"); err != nil {
+		return nil, err
+	}
+	return m.RemovePlayer(roomCode, playerID)
+}
+
+// nextHost picks who takes over from a leaving host: the human with the
+// earliest seat, so the choice matches a room reloaded from the database.
+// It returns uuid.Nil when no human is left.
+func (gs *GameState) nextHost(leavingID uuid.UUID) uuid.UUID {
+	var next *Player
+	for playerID, player := range gs.Players {
+		if playerID == leavingID || player.IsBot {
+			continue
+		}
+		if next == nil || player.Position < next.Position ||
+			(player.Position == next.Position && playerID.String() < next.ID.String()) {
+			next = player
+		}
+	}
+	if next == nil {
+		return uuid.Nil
+	}
+	return next.ID
+}
+
+// migrateHost hands the lobby to another human when the host leaves it.
+// Callers hold the game lock.
+func (m *Manager) migrateHost(game *GameState, leavingID uuid.UUID) {
+	if game.HostID != leavingID {
+		return
+	}
+	nextID := game.nextHost(leavingID)
+	if nextID == uuid.Nil {
+		return // Only bots are left; the cleanup service closes the room
+	}
+
+	game.HostID = nextID
+	next := game.Players[nextID]
+	m.BroadcastToGame(game, MessageTypeHostChanged, HostChangedPayload{HostID: nextID, PreviousHostID: leavingID})
+	m.SendSystemMessage(game.RoomCode, i18n.Msg("{1} is now the host", next.Name))
+	logger.Info("Host migrated", "room_code", game.RoomCode, "previous_host", leavingID, "host", nextID)
+}
@@ -0,0 +1,333 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// AFK incident reasons
+const (
+	AFKReasonWentAFK  = "went_afk"        // Player left or disconnected mid-game
+	AFKReasonReplaced = "replaced_by_bot" // Player was handed over to a bot
+)
+
+// Bot intervention kinds
+const (
+	BotActionClue   = "clue"
+	BotActionSubmit = "submission"
+	BotActionVote   = "vote"
+)
+
+// Host report errors
+var (
+	ErrNotHost        = errors.New("only the host can view the report")
+	ErrReportNotReady = errors.New("report is available once the game has ended")
+	ErrReportNotFound = errors.New("report not found")
+)
+
+// HostReport is the post-game summary shown to the room's host
+type HostReport struct {
+	GameID          uuid.UUID         `json:"game_id"`
+	RoomCode        string            `json:"room_code"`
+	HostID          uuid.UUID         `json:"host_id"`
+	Status          models.GameStatus `json:"status"`
+	StartedAt       *time.Time        `json:"started_at,omitempty"`
+	EndedAt         *time.Time        `json:"ended_at,omitempty"`
+	DurationSeconds float64           `json:"duration_seconds"`
+	RoundsPlayed    int               `json:"rounds_played"`
+	Pace            PaceStats         `json:"pace"`
+	Chat            ChatActivity      `json:"chat"`
+	AFKIncidents    []AFKIncident     `json:"afk_incidents"`
+	Bots            BotInterventions  `json:"bots"`
+	GeneratedAt     time.Time         `json:"generated_at"`
+}
+
+// PaceStats summarizes how long rounds and phases took
+type PaceStats struct {
+	AverageRoundSeconds float64            `json:"average_round_seconds"`
+	AveragePhaseSeconds map[string]float64 `json:"average_phase_seconds"` // Keyed by round status
+	SlowestPhase        string             `json:"slowest_phase,omitempty"`
+}
+
+// ChatActivity summarizes player chat during the game
+type ChatActivity struct {
+	TotalMessages int                 `json:"total_messages"`
+	ByPhase       map[string]int      `json:"by_phase"`
+	ByPlayer      []PlayerChatSummary `json:"by_player"`
+}
+
+// PlayerChatSummary is a single player's chat message count
+type PlayerChatSummary struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Name     string    `json:"name"`
+	Messages int       `json:"messages"`
+}
+
+// AFKIncident records a player dropping out of an active game
+type AFKIncident struct {
+	PlayerID    uuid.UUID `json:"player_id"`
+	Name        string    `json:"name"`
+	RoundNumber int       `json:"round_number"`
+	Reason      string    `json:"reason"`
+	At          time.Time `json:"at"`
+}
+
+// BotInterventions counts how much bots carried the game
+type BotInterventions struct {
+	Replacements int `json:"replacements"` // Humans handed over to bots
+	Clues        int `json:"clues"`
+	Submissions  int `json:"submissions"`
+	Votes        int `json:"votes"`
+}
+
+// gameAnalytics collects pace, chat, AFK and bot data while a game runs.
+// It has its own lock because chat is recorded without holding the game lock.
+// A nil *gameAnalytics (e.g. a game restored from Redis) records nothing.
+type gameAnalytics struct {
+	mu sync.Mutex
+
+	startedAt      time.Time
+	endedAt        time.Time
+	phase          string
+	phaseStartedAt time.Time
+	phaseDurations map[string][]time.Duration
+
+	chatByPlayer map[uuid.UUID]int
+	chatNames    map[uuid.UUID]string
+	chatByPhase  map[string]int
+
+	afkIncidents []AFKIncident
+	bots         BotInterventions
+}
+
+func newGameAnalytics() *gameAnalytics {
+	return &gameAnalytics{
+		phaseDurations: make(map[string][]time.Duration),
+		chatByPlayer:   make(map[uuid.UUID]int),
+		chatNames:      make(map[uuid.UUID]string),
+		chatByPhase:    make(map[string]int),
+		afkIncidents:   make([]AFKIncident, 0),
+	}
+}
+
+// enterPhase closes the current phase and starts timing the next one
+func (a *gameAnalytics) enterPhase(phase models.RoundStatus) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	if a.startedAt.IsZero() {
+		a.startedAt = now
+	}
+	a.closePhase(now)
+	a.phase = string(phase)
+	a.phaseStartedAt = now
+}
+
+// finish closes the last phase when the game ends
+func (a *gameAnalytics) finish() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	a.closePhase(now)
+	a.phase = ""
+	a.endedAt = now
+}
+
+// closePhase records the running phase's duration (mu must be held)
+func (a *gameAnalytics) closePhase(now time.Time) {
+	if a.phase == "" {
+		return
+	}
+	a.phaseDurations[a.phase] = append(a.phaseDurations[a.phase], now.Sub(a.phaseStartedAt))
+}
+
+func (a *gameAnalytics) recordChat(player *Player, phase string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.chatByPlayer[player.ID]++
+	a.chatNames[player.ID] = player.Name
+	a.chatByPhase[phase]++
+}
+
+func (a *gameAnalytics) recordAFK(player *Player, roundNumber int, reason string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.afkIncidents = append(a.afkIncidents, AFKIncident{
+		PlayerID:    player.ID,
+		Name:        player.Name,
+		RoundNumber: roundNumber,
+		Reason:      reason,
+		At:          time.Now(),
+	})
+	if reason == AFKReasonReplaced {
+		a.bots.Replacements++
+	}
+}
+
+func (a *gameAnalytics) recordBotAction(kind string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch kind {
+	case BotActionClue:
+		a.bots.Clues++
+	case BotActionSubmit:
+		a.bots.Submissions++
+	case BotActionVote:
+		a.bots.Votes++
+	}
+}
+
+// report builds a host report from the collected data
+func (a *gameAnalytics) report(game *GameState) *HostReport {
+	report := &HostReport{
+		GameID:       game.ID,
+		RoomCode:     game.RoomCode,
+		HostID:       game.HostID,
+		Status:       game.Status,
+		RoundsPlayed: game.RoundNumber,
+		Pace:         PaceStats{AveragePhaseSeconds: make(map[string]float64)},
+		Chat:         ChatActivity{ByPhase: make(map[string]int), ByPlayer: make([]PlayerChatSummary, 0)},
+		AFKIncidents: make([]AFKIncident, 0),
+		GeneratedAt:  time.Now(),
+	}
+	if a == nil {
+		return report
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.startedAt.IsZero() {
+		startedAt := a.startedAt
+		report.StartedAt = &startedAt
+
+		end := report.GeneratedAt
+		if !a.endedAt.IsZero() {
+			endedAt := a.endedAt
+			report.EndedAt = &endedAt
+			end = endedAt
+		}
+		report.DurationSeconds = end.Sub(startedAt).Seconds()
+		if game.RoundNumber > 0 {
+			report.Pace.AverageRoundSeconds = report.DurationSeconds / float64(game.RoundNumber)
+		}
+	}
+
+	slowest := 0.0
+	for phase, durations := range a.phaseDurations {
+		var total time.Duration
+		for _, duration := range durations {
+			total += duration
+		}
+		average := total.Seconds() / float64(len(durations))
+		report.Pace.AveragePhaseSeconds[phase] = average
+		if average > slowest {
+			slowest = average
+			report.Pace.SlowestPhase = phase
+		}
+	}
+
+	for phase, count := range a.chatByPhase {
+		report.Chat.ByPhase[phase] = count
+		report.Chat.TotalMessages += count
+	}
+	for playerID, count := range a.chatByPlayer {
+		report.Chat.ByPlayer = append(report.Chat.ByPlayer, PlayerChatSummary{
+			PlayerID: playerID,
+			Name:     a.chatNames[playerID],
+			Messages: count,
+		})
+	}
+	sort.Slice(report.Chat.ByPlayer, func(i, j int) bool {
+		return report.Chat.ByPlayer[i].Messages > report.Chat.ByPlayer[j].Messages
+	})
+
+	report.AFKIncidents = append(report.AFKIncidents, a.afkIncidents...)
+	report.Bots = a.bots
+
+	return report
+}
+
+// finalizeHostReport closes the game's analytics and stores the host report (game lock must be held)
+func (m *Manager) finalizeHostReport(game *GameState) {
+	game.analytics.finish()
+
+	report := game.analytics.report(game)
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		logger.Error("Failed to encode host report", "error", err, "room_code", game.RoomCode)
+		return
+	}
+
+	record := &models.GameReport{
+		ID:       uuid.New(),
+		GameID:   game.ID,
+		RoomCode: game.RoomCode,
+		HostID:   game.HostID,
+		Report:   string(encoded),
+	}
+	if err := m.repository(game).PersistGameReport(context.Background(), record); err != nil {
+		logger.Error("Failed to persist host report", "error", err, "room_code", game.RoomCode)
+	}
+}
+
+// GetHostReport returns the post-game report for a room. Only the host may view it,
+// and only once the game has ended.
+func (m *Manager) GetHostReport(ctx context.Context, roomCode string, requesterID uuid.UUID) (*HostReport, error) {
+	if game := m.getGame(roomCode); game != nil {
+		game.mu.RLock()
+		defer game.mu.RUnlock()
+
+		if game.HostID != requesterID {
+			return nil, ErrNotHost
+		}
+		if game.Status != models.GameStatusCompleted && game.Status != models.GameStatusAbandoned {
+			return nil, ErrReportNotReady
+		}
+		return game.analytics.report(game), nil
+	}
+
+	record, err := m.LoadGameReport(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if record.HostID != requesterID {
+		return nil, ErrNotHost
+	}
+
+	var report HostReport
+	if err := json.Unmarshal([]byte(record.Report), &report); err != nil {
+		return nil, fmt.Errorf("failed to decode host report: %w", err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameAnalyticsReport(t *testing.T) {
+	alice := &Player{ID: uuid.New(), Name: "Alice"}
+	bob := &Player{ID: uuid.New(), Name: "Bob"}
+	game := &GameState{ID: uuid.New(), RoomCode: "ROOM1", HostID: alice.ID, RoundNumber: 2, Status: models.GameStatusCompleted}
+
+	analytics := newGameAnalytics()
+	analytics.enterPhase(models.RoundStatusStorytelling)
+	analytics.enterPhase(models.RoundStatusVoting)
+	analytics.recordChat(alice, "voting")
+	analytics.recordChat(alice, "voting")
+	analytics.recordChat(bob, "lobby")
+	analytics.recordAFK(bob, 2, AFKReasonWentAFK)
+	analytics.recordAFK(bob, 2, AFKReasonReplaced)
+	analytics.recordBotAction(BotActionVote)
+	analytics.recordBotAction(BotActionClue)
+	analytics.finish()
+
+	report := analytics.report(game)
+
+	assert.Equal(t, alice.ID, report.HostID)
+	assert.NotNil(t, report.StartedAt)
+	assert.NotNil(t, report.EndedAt)
+	assert.Contains(t, report.Pace.AveragePhaseSeconds, "storytelling")
+	assert.Contains(t, report.Pace.AveragePhaseSeconds, "voting")
+
+	assert.Equal(t, 3, report.Chat.TotalMessages)
+	assert.Equal(t, 2, report.Chat.ByPhase["voting"])
+	assert.Equal(t, "Alice", report.Chat.ByPlayer[0].Name)
+	assert.Equal(t, 2, report.Chat.ByPlayer[0].Messages)
+
+	assert.Len(t, report.AFKIncidents, 2)
+	assert.Equal(t, BotInterventions{Replacements: 1, Clues: 1, Votes: 1}, report.Bots)
+}
+
+func TestGameAnalyticsPhaseDurations(t *testing.T) {
+	analytics := newGameAnalytics()
+	start := time.Now()
+	analytics.phase = "voting"
+	analytics.phaseStartedAt = start
+	analytics.closePhase(start.Add(10 * time.Second))
+	analytics.phaseStartedAt = start
+	analytics.closePhase(start.Add(20 * time.Second))
+
+	report := analytics.report(&GameState{})
+	assert.InDelta(t, 15.0, report.Pace.AveragePhaseSeconds["voting"], 0.001)
+	assert.Equal(t, "voting", report.Pace.SlowestPhase)
+}
+
+func TestNilGameAnalyticsIsSafe(t *testing.T) {
+	var analytics *gameAnalytics
+	analytics.enterPhase(models.RoundStatusVoting)
+	analytics.recordBotAction(BotActionVote)
+	analytics.finish()
+
+	report := analytics.report(&GameState{RoomCode: "ROOM2"})
+	assert.Equal(t, "ROOM2", report.RoomCode)
+	assert.Empty(t, report.AFKIncidents)
+}
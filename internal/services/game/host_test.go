@@ -0,0 +1,37 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextHostPicksEarliestHumanSeat(t *testing.T) {
+	host, bot, bea, carl := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	gs := &GameState{
+		HostID: host,
+		Players: map[uuid.UUID]*Player{
+			host: {ID: host, Position: 1},
+			bot:  {ID: bot, Position: 2, IsBot: true},
+			carl: {ID: carl, Position: 4},
+			bea:  {ID: bea, Position: 3},
+		},
+	}
+	assert.Equal(t, bea, gs.nextHost(host))
+
+	delete(gs.Players, bea)
+	delete(gs.Players, carl)
+	assert.Equal(t, uuid.Nil, gs.nextHost(host), "bots never host")
+}
+
+func TestRequireHost(t *testing.T) {
+	host := uuid.New()
+	gs := &GameState{HostID: host}
+	assert.NoError(t, requireHost(gs, host, "start the game"))
+
+	err := requireHost(gs, uuid.New(), "start the game")
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeNotHost}))
+	assert.EqualError(t, err, "only the host can start the game")
+}
@@ -0,0 +1,247 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// kickVoteDuration is how long the table has to decide a kick vote
+const kickVoteDuration = time.Minute
+
+// minKickVoters is how many players besides the target a kick vote needs, so
+// two players can't vote out everyone else at the table
+const minKickVoters = 2
+
+// kickReplacementReason is the PlayerReplaced reason of a player voted out
+const kickReplacementReason = "Kicked by vote"
+
+// Kick vote statuses, sent with every progress update
+const (
+	KickVoteOpen    = "open"
+	KickVotePassed  = "passed"
+	KickVoteFailed  = "failed"
+	KickVoteExpired = "expired"
+)
+
+// KickVote is a vote, open to every human at the table but its target, on
+// handing a disruptive player's seat to a bot. It passes with a majority of
+// the players who could vote when it started. Ballots are secret; only the
+// tally is shown.
+type KickVote struct {
+	ID        uuid.UUID `json:"id"`
+	TargetID  uuid.UUID `json:"target_id"`
+	StartedBy uuid.UUID `json:"started_by"`
+	Yes       int       `json:"yes"`
+	No        int       `json:"no"`
+	Voters    int       `json:"voters"` // Players who may vote
+	Needed    int       `json:"needed"` // Yes votes that carry it
+	ExpiresAt time.Time `json:"expires_at"`
+	ballots   map[uuid.UUID]bool
+}
+
+// KickVotePayload reports a kick vote's progress, and its outcome once decided
+type KickVotePayload struct {
+	*KickVote
+	Status string `json:"status"`
+}
+
+// kickVotesNeeded is the majority of voters
+func kickVotesNeeded(voters int) int {
+	return voters/2 + 1
+}
+
+// cast records a ballot and returns the vote's status
+func (v *KickVote) cast(voterID uuid.UUID, kick bool) string {
+	v.ballots[voterID] = kick
+	if kick {
+		v.Yes++
+	} else {
+		v.No++
+	}
+	return v.status()
+}
+
+// status is passed once enough players voted yes, and failed once too many
+// voted no for it to pass
+func (v *KickVote) status() string {
+	switch {
+	case v.Yes >= v.Needed:
+		return KickVotePassed
+	case v.Voters-v.No < v.Needed:
+		return KickVoteFailed
+	}
+	return KickVoteOpen
+}
+
+// kickable reports whether a player is a human still playing their own seat
+func kickable(player *Player) bool {
+	return player != nil && !player.IsBot && !player.WasReplaced && player.IsActive
+}
+
+// kickVoters counts the players who may vote on kicking targetID. Callers hold the game lock.
+func (gs *GameState) kickVoters(targetID uuid.UUID) int {
+	voters := 0
+	for playerID, player := range gs.Players {
+		if playerID != targetID && kickable(player) {
+			voters++
+		}
+	}
+	return voters
+}
+
+// StartKickVote opens a vote on kicking targetID out of a running game. The
+// player starting it votes yes.
+func (m *Manager) StartKickVote(roomCode string, starterID, targetID uuid.UUID) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if game.Status != models.GameStatusInProgress {
+		return fmt.Errorf("kick votes are only held during a game")
+	}
+	if game.KickVote != nil {
+		return &GameError{
+			Code:    ErrCodeKickVoteActive,
+			Message: "a kick vote is already in progress",
+			Details: map[string]interface{}{"target_id": game.KickVote.TargetID, "expires_at": game.KickVote.ExpiresAt},
+		}
+	}
+	starter := game.Players[starterID]
+	if !kickable(starter) {
+		return fmt.Errorf("player not in game")
+	}
+	if starterID == targetID {
+		return fmt.Errorf("cannot start a kick vote against yourself")
+	}
+	target, exists := game.Players[targetID]
+	if !exists {
+		return fmt.Errorf("player not found in game")
+	}
+	if !kickable(target) {
+		return fmt.Errorf("only players still in their seat can be kicked")
+	}
+	voters := game.kickVoters(targetID)
+	if voters < minKickVoters {
+		return &GameError{
+			Code:    ErrCodeNotEnoughVoters,
+			Message: fmt.Sprintf("a kick vote needs at least %d other players", minKickVoters),
+			Details: map[string]interface{}{"voters": voters, "min_voters": minKickVoters},
+		}
+	}
+
+	vote := &KickVote{
+		ID:        uuid.New(),
+		TargetID:  targetID,
+		StartedBy: starterID,
+		Voters:    voters,
+		Needed:    kickVotesNeeded(voters),
+		ExpiresAt: time.Now().Add(kickVoteDuration),
+		ballots:   make(map[uuid.UUID]bool),
+	}
+	vote.cast(starterID, true)
+	game.KickVote = vote
+
+	metrics.GetCounter(metrics.Name("kick_votes_total", "status", "started")).Inc()
+	logger.Info("Kick vote started", "room_code", roomCode, "started_by", starterID, "target_id", targetID, "needed", vote.Needed)
+
+	m.BroadcastToGame(game, MessageTypeKickVote, KickVotePayload{KickVote: vote, Status: KickVoteOpen})
+	m.SendSystemMessage(roomCode, i18n.Msg("{1} started a vote to kick {2}", starter.Name, target.Name))
+
+	voteID := vote.ID
+	m.schedule(game, "kick_vote", kickVoteDuration, func() {
+		m.expireKickVote(roomCode, voteID)
+	})
+	return nil
+}
+
+// CastKickVote records a player's ballot on the open kick vote. A vote that
+// passes hands the target's seat to a bot.
+func (m *Manager) CastKickVote(roomCode string, voterID uuid.UUID, kick bool) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+
+	game.mu.Lock()
+	vote := game.KickVote
+	if vote == nil {
+		game.mu.Unlock()
+		return fmt.Errorf("no kick vote in progress")
+	}
+	if voterID == vote.TargetID {
+		game.mu.Unlock()
+		return fmt.Errorf("cannot vote on your own kick")
+	}
+	if !kickable(game.Players[voterID]) {
+		game.mu.Unlock()
+		return fmt.Errorf("player not in game")
+	}
+	if _, voted := vote.ballots[voterID]; voted {
+		game.mu.Unlock()
+		return fmt.Errorf("already voted")
+	}
+
+	status := vote.cast(voterID, kick)
+	if !kickable(game.Players[vote.TargetID]) {
+		// The target left or was replaced while the table was voting
+		status = KickVoteFailed
+	}
+	m.BroadcastToGame(game, MessageTypeKickVote, KickVotePayload{KickVote: vote, Status: status})
+	if status == KickVoteOpen {
+		game.mu.Unlock()
+		return nil
+	}
+
+	game.KickVote = nil
+	targetName := game.Players[vote.TargetID].Name
+	metrics.GetCounter(metrics.Name("kick_votes_total", "status", status)).Inc()
+	logger.Info("Kick vote decided", "room_code", roomCode, "target_id", vote.TargetID, "status", status, "yes", vote.Yes, "no", vote.No)
+	if status == KickVoteFailed {
+		m.SendSystemMessage(roomCode, i18n.Msg("The vote to kick {1} failed", targetName))
+		game.mu.Unlock()
+		return nil
+	}
+	game.mu.Unlock()
+
+	// The replacement locks the game itself, and announces it to the room
+	_, err := m.replacePlayerWithBot(roomCode, vote.TargetID, kickReplacementReason, func(game *GameState, player *Player) bool {
+		return game.Status == models.GameStatusInProgress
+	})
+	if err != nil {
+		logger.Error("Failed to replace kicked player", "error", err, "room_code", roomCode, "player_id", vote.TargetID)
+	}
+	return nil
+}
+
+// expireKickVote closes a kick vote that ran out of time without a decision
+func (m *Manager) expireKickVote(roomCode string, voteID uuid.UUID) {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	vote := game.KickVote
+	if vote == nil || vote.ID != voteID {
+		return // Decided before the time ran out
+	}
+	game.KickVote = nil
+	metrics.GetCounter(metrics.Name("kick_votes_total", "status", KickVoteExpired)).Inc()
+	m.BroadcastToGame(game, MessageTypeKickVote, KickVotePayload{KickVote: vote, Status: KickVoteExpired})
+	if target, exists := game.Players[vote.TargetID]; exists {
+		m.SendSystemMessage(roomCode, i18n.Msg("The vote to kick {1} failed", target.Name))
+	}
+}
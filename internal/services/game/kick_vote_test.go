@@ -0,0 +1,106 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// heldScheduler keeps delayed events until a test runs them
+type heldScheduler struct {
+	events []func()
+}
+
+func (s *heldScheduler) AfterFunc(_ time.Duration, f func()) {
+	s.events = append(s.events, f)
+}
+
+func kickVoteGame(t *testing.T, names ...string) (*Manager, *heldScheduler, []uuid.UUID) {
+	t.Helper()
+	m := NewEphemeralManager()
+	scheduler := &heldScheduler{}
+	m.SetScheduler(scheduler)
+
+	gs := &GameState{
+		ID:        uuid.New(),
+		RoomCode:  "KICK",
+		Status:    models.GameStatusInProgress,
+		Sandbox:   true,
+		Players:   make(map[uuid.UUID]*Player),
+		analytics: newGameAnalytics(),
+	}
+	ids := make([]uuid.UUID, len(names))
+	for i, name := range names {
+		ids[i] = uuid.New()
+		gs.Players[ids[i]] = &Player{ID: ids[i], Name: name, Position: i + 1, IsActive: true, IsConnected: true}
+	}
+	m.games[gs.RoomCode] = gs
+	return m, scheduler, ids
+}
+
+func TestKickVoteStatus(t *testing.T) {
+	vote := &KickVote{Voters: 4, Needed: kickVotesNeeded(4), ballots: map[uuid.UUID]bool{}}
+	assert.Equal(t, 3, vote.Needed)
+	assert.Equal(t, KickVoteOpen, vote.cast(uuid.New(), true))
+	assert.Equal(t, KickVoteOpen, vote.cast(uuid.New(), false))
+	assert.Equal(t, KickVoteFailed, vote.cast(uuid.New(), false), "two no votes of four leave too few to pass")
+
+	vote = &KickVote{Voters: 3, Needed: kickVotesNeeded(3), ballots: map[uuid.UUID]bool{}}
+	vote.cast(uuid.New(), true)
+	assert.Equal(t, KickVotePassed, vote.cast(uuid.New(), true))
+}
+
+func TestKickVotePassesAndReplacesTarget(t *testing.T) {
+	m, _, ids := kickVoteGame(t, "Alice", "Bob", "Cleo", "Dan")
+	alice, bob, dan := ids[0], ids[1], ids[3]
+
+	require.NoError(t, m.StartKickVote("KICK", alice, dan))
+	gs := m.games["KICK"]
+	require.NotNil(t, gs.KickVote)
+	assert.Equal(t, 3, gs.KickVote.Voters)
+	assert.Equal(t, 1, gs.KickVote.Yes)
+
+	err := m.StartKickVote("KICK", bob, alice)
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeKickVoteActive}))
+	assert.EqualError(t, m.CastKickVote("KICK", dan, false), "cannot vote on your own kick")
+	assert.EqualError(t, m.CastKickVote("KICK", alice, true), "already voted")
+
+	require.NoError(t, m.CastKickVote("KICK", bob, true))
+	assert.Nil(t, gs.KickVote)
+	assert.True(t, gs.Players[dan].WasReplaced)
+	require.NotNil(t, gs.Players[dan].ReplacementID)
+	assert.True(t, gs.Players[*gs.Players[dan].ReplacementID].IsBot)
+}
+
+func TestKickVoteFailsAndExpires(t *testing.T) {
+	m, scheduler, ids := kickVoteGame(t, "Alice", "Bob", "Cleo")
+	alice, bob, cleo := ids[0], ids[1], ids[2]
+	gs := m.games["KICK"]
+
+	require.NoError(t, m.StartKickVote("KICK", alice, cleo))
+	require.NoError(t, m.CastKickVote("KICK", bob, false))
+	assert.Nil(t, gs.KickVote)
+	assert.False(t, gs.Players[cleo].WasReplaced)
+
+	require.NoError(t, m.StartKickVote("KICK", bob, cleo))
+	require.Len(t, scheduler.events, 2)
+	scheduler.events[0]() // The first vote's timer doesn't close the second
+	require.NotNil(t, gs.KickVote)
+	scheduler.events[1]()
+	assert.Nil(t, gs.KickVote)
+	assert.EqualError(t, m.CastKickVote("KICK", alice, true), "no kick vote in progress")
+}
+
+func TestKickVoteNeedsEnoughVoters(t *testing.T) {
+	m, _, ids := kickVoteGame(t, "Alice", "Bob")
+
+	err := m.StartKickVote("KICK", ids[0], ids[1])
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeNotEnoughVoters}))
+	assert.EqualError(t, m.StartKickVote("KICK", ids[0], ids[0]), "cannot start a kick vote against yourself")
+}
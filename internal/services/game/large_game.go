@@ -0,0 +1,104 @@
+package game
+
+import (
+	"context"
+
+	"dixitme/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// maxLargeGamePlayers is the most seats a room playing the large-game
+// variant can have
+const maxLargeGamePlayers = 12
+
+// tableVariant is how the official rules adapt a game to its player count
+type tableVariant struct {
+	HandSize       int // Cards each player holds
+	CardsPerPlayer int // Cards each non-storyteller submits to a round
+	FoolingCap     int // Most points a player scores from votes on their cards in a round (0 = no cap)
+}
+
+// variantFor returns the official variant rules for a table. Three players
+// each submit two cards so there are enough to choose from; tables above the
+// standard six hold smaller hands and cap fooling points, so the many
+// submissions don't turn a round into a lottery.
+func variantFor(players int) tableVariant {
+	switch {
+	case players <= minPlayersPerRoom:
+		return tableVariant{HandSize: 7, CardsPerPlayer: 2}
+	case players <= maxPlayersPerRoom:
+		return tableVariant{HandSize: defaultHandSize, CardsPerPlayer: 1}
+	case players <= maxCasualPlayers:
+		return tableVariant{HandSize: defaultHandSize, CardsPerPlayer: 1, FoolingCap: 3}
+	default:
+		return tableVariant{HandSize: 5, CardsPerPlayer: 1, FoolingCap: 3}
+	}
+}
+
+// applyTableVariant fits the hand size of a large-game room to the table it
+// starts with, and records it. Callers hold the game lock.
+func (m *Manager) applyTableVariant(game *GameState) error {
+	rules := &game.Settings.Rules
+	if !rules.LargeGame {
+		return nil
+	}
+
+	variant := variantFor(len(game.Players))
+	if rules.HandSize == variant.HandSize {
+		return nil
+	}
+	rules.HandSize = variant.HandSize
+	if err := m.repository(game).UpdateGameRules(context.Background(), game.ID, *rules); err != nil {
+		return err
+	}
+
+	logger.Info("Large-game variant applied",
+		"room_code", game.RoomCode,
+		"players", len(game.Players),
+		"hand_size", variant.HandSize,
+		"cards_per_player", variant.CardsPerPlayer)
+	return nil
+}
+
+// tableScoring is the base scoring of a round: standard Dixit scoring, or
+// team scoring in team games, with the variant's fooling cap for large games
+func (gs *GameState) tableScoring() ScoringStrategy {
+	var strategy ScoringStrategy = DixitScoring{}
+	if gs.Settings.Rules.TeamSize != 0 {
+		strategy = TeamScoring{Teams: gs.playerTeams()}
+	}
+	if !gs.Settings.Rules.LargeGame {
+		return strategy
+	}
+	if limit := variantFor(gs.seatedPlayerCount()).FoolingCap; limit > 0 {
+		strategy = FoolingCap{Next: strategy, Max: limit}
+	}
+	return strategy
+}
+
+// FoolingCap limits the points a player scores in a round from votes on the
+// cards they submitted
+type FoolingCap struct {
+	Next ScoringStrategy
+	Max  int
+}
+
+// Score removes fooling points beyond the cap
+func (f FoolingCap) Score(sc *ScoringContext) map[uuid.UUID]int {
+	points := f.Next.Score(sc)
+
+	fooled := make(map[uuid.UUID]int)
+	for _, vote := range sc.Round.Votes {
+		if submitterID, ok := cardSubmitter(sc.Round, vote.CardID); ok {
+			fooled[submitterID]++
+		}
+	}
+	for submitterID, votes := range fooled {
+		if votes > f.Max {
+			points[submitterID] -= votes - f.Max
+		}
+	}
+
+	return points
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariantFor(t *testing.T) {
+	assert.Equal(t, tableVariant{HandSize: 7, CardsPerPlayer: 2}, variantFor(3))
+	assert.Equal(t, tableVariant{HandSize: 6, CardsPerPlayer: 1}, variantFor(6))
+	assert.Equal(t, tableVariant{HandSize: 6, CardsPerPlayer: 1, FoolingCap: 3}, variantFor(8))
+	assert.Equal(t, tableVariant{HandSize: 5, CardsPerPlayer: 1, FoolingCap: 3}, variantFor(12))
+}
+
+func TestLargeGameSeats(t *testing.T) {
+	rules := DefaultGameRules()
+	rules.MaxPlayers = 12
+	assert.Error(t, rules.Validate())
+
+	rules.LargeGame = true
+	assert.NoError(t, rules.Validate())
+	rules.MaxPlayers = 13
+	assert.Error(t, rules.Validate())
+}
+
+func TestFoolingCap(t *testing.T) {
+	storyteller, decoy := uuid.New(), uuid.New()
+	round := &Round{
+		StorytellerID:   storyteller,
+		StorytellerCard: 1,
+		Submissions:     map[uuid.UUID]*CardSubmission{decoy: {PlayerID: decoy, CardID: 2}},
+		Votes:           map[uuid.UUID]*Vote{},
+	}
+	scores := map[uuid.UUID]int{storyteller: 0, decoy: 0}
+	for i := 0; i < 5; i++ {
+		voter := uuid.New()
+		scores[voter] = 0
+		round.Votes[voter] = &Vote{PlayerID: voter, CardID: 2}
+	}
+	voter := uuid.New()
+	scores[voter] = 0
+	round.Votes[voter] = &Vote{PlayerID: voter, CardID: 1}
+
+	sc := &ScoringContext{Round: round, Scores: scores, History: NewScoringHistory()}
+	assert.Equal(t, 5, DixitScoring{}.Score(sc)[decoy])
+	assert.Equal(t, 3, FoolingCap{Next: DixitScoring{}, Max: 3}.Score(sc)[decoy])
+}
+
+func TestSubmitCardTakesEveryCardTheRoundNeeds(t *testing.T) {
+	m, _, ids := kickVoteGame(t, "Alice", "Bob", "Cleo")
+	alice, bob, cleo := ids[0], ids[1], ids[2]
+	gs := m.games["KICK"]
+	gs.Players[bob].Hand = []int{10, 11, 12}
+	gs.Players[cleo].Hand = []int{20, 21, 22}
+	gs.CurrentRound = &Round{
+		StorytellerID:   alice,
+		StorytellerCard: 1,
+		Status:          models.RoundStatusSubmitting,
+		CardsPerPlayer:  2,
+		Submissions:     map[uuid.UUID]*CardSubmission{},
+		Votes:           map[uuid.UUID]*Vote{},
+	}
+
+	require.NoError(t, m.SubmitCard("KICK", bob, 10))
+	assert.False(t, gs.CurrentRound.submitted(bob))
+	require.NoError(t, m.SubmitCard("KICK", bob, 11))
+	assert.EqualError(t, m.SubmitCard("KICK", bob, 12), "card already submitted")
+
+	require.NoError(t, m.SubmitCard("KICK", cleo, 20))
+	assert.Equal(t, models.RoundStatusSubmitting, gs.CurrentRound.Status)
+	require.NoError(t, m.SubmitCard("KICK", cleo, 21))
+	assert.Equal(t, models.RoundStatusVoting, gs.CurrentRound.Status)
+	assert.Len(t, gs.CurrentRound.RevealedCards, 5)
+
+	submitter, ok := cardSubmitter(gs.CurrentRound, 11)
+	assert.True(t, ok)
+	assert.Equal(t, bob, submitter)
+}
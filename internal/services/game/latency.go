@@ -0,0 +1,71 @@
+package game
+
+import (
+	"time"
+
+	"dixitme/internal/metrics"
+
+	"github.com/google/uuid"
+)
+
+// LatencyLevel is a coarse rating of a player's round-trip time, shown next
+// to their name so the table knows who is lagging
+type LatencyLevel string
+
+const (
+	LatencyGood LatencyLevel = "good"
+	LatencyFair LatencyLevel = "fair"
+	LatencyPoor LatencyLevel = "poor"
+)
+
+// Round-trip times separating the latency levels
+const (
+	fairLatency = 150 * time.Millisecond
+	poorLatency = 400 * time.Millisecond
+)
+
+// rttBuckets are the round-trip time histogram buckets, in milliseconds
+var rttBuckets = []int64{25, 50, 100, 150, 250, 400, 700, 1000, 2000}
+
+// latencyLevel rates a round-trip time
+func latencyLevel(rtt time.Duration) LatencyLevel {
+	switch {
+	case rtt < fairLatency:
+		return LatencyGood
+	case rtt < poorLatency:
+		return LatencyFair
+	default:
+		return LatencyPoor
+	}
+}
+
+// RecordLatency stores a round-trip time measured on a player's connection
+// in every game they are seated in
+func (m *Manager) RecordLatency(playerID uuid.UUID, rtt time.Duration) {
+	metrics.GetHistogram("connection_rtt_milliseconds", rttBuckets).Observe(rtt.Milliseconds())
+
+	for _, game := range m.GetAllGames() {
+		game.Lock()
+		if player, exists := game.Players[playerID]; exists && !player.IsBot {
+			player.rtt = rtt
+			player.Latency = latencyLevel(rtt)
+		}
+		game.Unlock()
+	}
+}
+
+// PlayerLatency is the last round-trip time measured for a connected player,
+// for grouping players by latency
+func (m *Manager) PlayerLatency(playerID uuid.UUID) (time.Duration, bool) {
+	for _, game := range m.GetAllGames() {
+		game.Lock()
+		player, exists := game.Players[playerID]
+		if exists && player.IsConnected && player.rtt > 0 {
+			rtt := player.rtt
+			game.Unlock()
+			return rtt, true
+		}
+		game.Unlock()
+	}
+	return 0, false
+}
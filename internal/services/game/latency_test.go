@@ -0,0 +1,15 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyLevel(t *testing.T) {
+	assert.Equal(t, LatencyGood, latencyLevel(40*time.Millisecond))
+	assert.Equal(t, LatencyFair, latencyLevel(fairLatency))
+	assert.Equal(t, LatencyFair, latencyLevel(300*time.Millisecond))
+	assert.Equal(t, LatencyPoor, latencyLevel(poorLatency))
+}
@@ -2,15 +2,16 @@ package game
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"dixitme/internal/database"
+	"dixitme/internal/events"
 	"dixitme/internal/logger"
 	"dixitme/internal/models"
 	redisClient "dixitme/internal/redis"
 
 	"github.com/google/uuid"
-	"github.com/gorilla/websocket"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
@@ -20,7 +21,7 @@ var managerOnce sync.Once
 
 // Global connection registry within the game package to avoid import cycles
 var (
-	playerConnections = make(map[uuid.UUID]*websocket.Conn)
+	playerConnections = make(map[uuid.UUID]Connection)
 	connectionsMutex  sync.RWMutex
 )
 
@@ -32,6 +33,54 @@ type Manager struct {
 	inactiveTimeout time.Duration
 	stopCleanup     chan bool
 
+	// Chat retention
+	chatRetention     ChatRetentionPolicy
+	stopChatRetention chan bool
+
+	// Signs resume tokens for reconnecting players (nil disables them)
+	resumeTokens ResumeTokenIssuer
+
+	// Deployment-wide bot caps
+	botLimits BotLimits
+
+	// Store bot decisions for post-game review
+	logBotDecisions bool
+
+	// Writes bot chat lines (nil uses the canned lines)
+	botChat BotChatGenerator
+
+	// Signs account upgrade tokens for guests (nil disables account prompts)
+	upgradeTokens UpgradeTokenIssuer
+
+	// Deployment-wide AFK thresholds per phase, for the standard pace
+	afkThresholds AFKThresholds
+
+	// Deployment-wide clue length cap, see SetMaxClueLength
+	maxClueLength atomic.Int64
+
+	// Deployment-wide caps on rooms and connections
+	capacity           CapacityLimits
+	gamesAlerted       atomic.Bool
+	connectionsAlerted atomic.Bool
+
+	// Runs delayed events (nil uses the wall clock)
+	scheduler Scheduler
+
+	// Carries domain events to the subsystems reacting to them
+	bus *events.Bus
+
+	// Players whose chat only reaches themselves
+	shadowBans   map[uuid.UUID]bool
+	shadowBansMu sync.RWMutex
+
+	// Identifies this instance on the rooms' pub/sub channels
+	instanceID string
+	stopRelay  func()
+
+	// Where this instance runs, see SetDeployment
+	region    string
+	publicURL string
+
 	// Injected dependencies
 	db          *gorm.DB
 	redisClient *redis.Client
@@ -46,11 +95,23 @@ func NewManager(db *gorm.DB, redisClient *redis.Client) *Manager {
 		stopCleanup:     make(chan bool),
 		db:              db,
 		redisClient:     redisClient,
+		instanceID:      uuid.New().String(),
+		bus:             events.NewBus(),
+
+		chatRetention:     DefaultChatRetentionPolicy(),
+		stopChatRetention: make(chan bool),
+
+		botLimits:     DefaultBotLimits(),
+		afkThresholds: DefaultAFKThresholds(),
+		capacity:      DefaultCapacityLimits(),
 	}
+	manager.registerBuiltinHooks()
 	// Load active games from database
 	go manager.loadActiveGamesFromDatabase()
 	// Start the cleanup goroutine
 	go manager.startCleanupService()
+	// Start the chat retention job
+	go manager.startChatRetentionService()
 	return manager
 }
 
@@ -61,6 +122,7 @@ type FullGameService interface {
 	GamePlayService
 	BotService
 	ChatService
+	VoiceService
 	GameCleanupService
 	GameBroadcastService
 	GamePersistenceService
@@ -79,22 +141,51 @@ func GetManager() *Manager {
 	return globalManager
 }
 
+// SetManager makes the given manager the one returned by GetManager, so WebSocket
+// handlers share game state with the injected REST services
+func SetManager(m *Manager) {
+	managerOnce.Do(func() {})
+	globalManager = m
+}
+
 // Connection management functions
-func RegisterPlayerConnection(playerID uuid.UUID, conn *websocket.Conn) {
+
+// RegisterPlayerConnection makes conn the player's connection and returns the
+// connection it replaced, if any. The newest connection always wins; callers
+// hand the replaced one to Manager.TakeOverConnection. Transports use
+// ClaimPlayerConnection, which only lets a proven newcomer win.
+func RegisterPlayerConnection(playerID uuid.UUID, conn Connection) Connection {
 	connectionsMutex.Lock()
 	defer connectionsMutex.Unlock()
+	return registerConnectionLocked(playerID, conn)
+}
+
+// registerConnectionLocked registers conn; the caller holds connectionsMutex
+func registerConnectionLocked(playerID uuid.UUID, conn Connection) Connection {
+	previous := playerConnections[playerID]
 	playerConnections[playerID] = conn
 	log := logger.GetLogger()
-	log.Info("Registered player connection", "player_id", playerID, "total_connections", len(playerConnections))
+	log.Info("Registered player connection", "player_id", playerID, "transport", conn.Transport(), "total_connections", len(playerConnections))
+	if previous == conn {
+		return nil
+	}
+	return previous
 }
 
-func UnregisterPlayerConnection(playerID uuid.UUID) {
+// UnregisterPlayerConnection removes conn if it is still the player's
+// connection and reports whether it was, so a connection that was taken over
+// doesn't unregister its successor when it closes
+func UnregisterPlayerConnection(playerID uuid.UUID, conn Connection) bool {
 	connectionsMutex.Lock()
 	defer connectionsMutex.Unlock()
+	if playerConnections[playerID] != conn {
+		return false
+	}
 	delete(playerConnections, playerID)
+	return true
 }
 
-func GetPlayerConnection(playerID uuid.UUID) *websocket.Conn {
+func GetPlayerConnection(playerID uuid.UUID) Connection {
 	connectionsMutex.RLock()
 	defer connectionsMutex.RUnlock()
 	conn := playerConnections[playerID]
@@ -118,7 +209,21 @@ func (m *Manager) loadActiveGamesFromDatabase() {
 	}
 
 	loadedCount := 0
+	resumedCount := 0
 	for _, dbGame := range dbGames {
+		// Prefer the cached snapshot, which also has the hands, deck and round in play
+		if gameState := m.cachedGame(&dbGame); gameState != nil {
+			m.mu.Lock()
+			m.games[dbGame.RoomCode] = gameState
+			m.mu.Unlock()
+			gameState.mu.Lock()
+			m.resumePendingEvents(gameState)
+			gameState.mu.Unlock()
+			loadedCount++
+			resumedCount++
+			continue
+		}
+
 		// Convert database game to in-memory GameState
 		gameState := m.convertDBGameToGameState(&dbGame)
 		if gameState != nil {
@@ -129,7 +234,7 @@ func (m *Manager) loadActiveGamesFromDatabase() {
 		}
 	}
 
-	log.Info("Successfully loaded games from database", "count", loadedCount)
+	log.Info("Successfully loaded games from database", "count", loadedCount, "resumed_from_redis", resumedCount)
 }
 
 // convertDBGameToGameState converts a database Game model to in-memory GameState
@@ -149,6 +254,8 @@ func (m *Manager) convertDBGameToGameState(dbGame *models.Game) *GameState {
 			IsConnected:  false,          // Will be set when WebSocket connects
 			BotLevel:     dbGamePlayer.Player.BotLevel,
 			Position:     dbGamePlayer.Position,
+			Token:        dbGamePlayer.Token,
+			Team:         dbGamePlayer.Team,
 			LastActivity: time.Now(), // Set to now when loading from database
 		}
 		players[dbGamePlayer.Player.ID] = player
@@ -168,7 +275,24 @@ func (m *Manager) convertDBGameToGameState(dbGame *models.Game) *GameState {
 		MaxRounds:    10, // Default value
 		Deck:         make([]int, 0),
 		UsedCards:    make([]int, 0),
+		Settings:     DefaultGameSettings(),
+		TournamentID: dbGame.TournamentID,
+		Region:       dbGame.Region,
+		Packs:        packsFromModel(dbGame),
+		DeckID:       dbGame.DeckID,
+		history:      NewScoringHistory(),
+
+		ShuffleCommitment: dbGame.ShuffleCommitment,
+		shuffleSeed:       dbGame.ShuffleSeed,
+		PasswordProtected: dbGame.PasswordHash != "",
+		passwordHash:      dbGame.PasswordHash,
+		avoidedCards:      parseCardList(dbGame.AvoidedCards),
+		packCards:         parseCardList(dbGame.PackCards),
+		expansionCards:    parseCardList(dbGame.ExpansionCards),
 	}
+	gameState.Settings.Rules = rulesFromModel(dbGame)
+	gameState.Settings.Private = dbGame.Private
+	gameState.HostID = gameState.nextHost(uuid.Nil)
 
 	log.Debug("Converted database game to in-memory state",
 		"room_code", dbGame.RoomCode,
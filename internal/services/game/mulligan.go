@@ -0,0 +1,90 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Mulligan lets the storyteller exchange their whole hand before giving a clue,
+// once per game. The old cards are shuffled back into the deck and the same
+// number drawn, and the room is told the mulligan was used.
+func (m *Manager) Mulligan(roomCode string, playerID uuid.UUID) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if !game.Settings.Mulligan {
+		return fmt.Errorf("mulligans are not enabled in this room")
+	}
+	if game.Status != models.GameStatusInProgress || game.CurrentRound == nil {
+		return fmt.Errorf("game is not in progress")
+	}
+
+	round := game.CurrentRound
+	if round.StorytellerID != playerID {
+		return fmt.Errorf("only the storyteller can take a mulligan")
+	}
+	if round.Status != models.RoundStatusStorytelling {
+		return fmt.Errorf("a mulligan must be taken before giving a clue")
+	}
+
+	player, exists := game.Players[playerID]
+	if !exists {
+		return fmt.Errorf("player not in game")
+	}
+	if player.MulliganUsed {
+		return fmt.Errorf("you have already used your mulligan this game")
+	}
+	if len(game.Deck) == 0 {
+		return fmt.Errorf("the deck is empty")
+	}
+
+	exchanged := len(player.Hand)
+	mulliganHand(game, player, seededIntn(game.shuffleSeed, fmt.Sprintf(":mulligan:%d:", round.RoundNumber)))
+	player.MulliganUsed = true
+	player.UpdateActivity()
+	game.LastActivity = time.Now()
+
+	m.BroadcastToGame(game, MessageTypeMulliganUsed, MulliganUsedPayload{
+		PlayerID:   playerID,
+		PlayerName: player.Name,
+		Cards:      exchanged,
+	})
+	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
+	m.SendSystemMessage(roomCode, i18n.Msg("{1} took a mulligan and drew a new hand", player.Name))
+
+	logger.Info("Mulligan used",
+		"room_code", roomCode,
+		"player_id", playerID,
+		"round", round.RoundNumber,
+		"cards", exchanged)
+
+	return nil
+}
+
+// mulliganHand shuffles a player's hand back into the deck and draws a new one of the same size
+func mulliganHand(game *GameState, player *Player, intn func(int) int) {
+	handSize := len(player.Hand)
+
+	game.Deck = append(game.Deck, player.Hand...)
+	player.Hand = make([]int, 0, handSize)
+	for i := len(game.Deck) - 1; i > 0; i-- {
+		j := intn(i + 1)
+		game.Deck[i], game.Deck[j] = game.Deck[j], game.Deck[i]
+	}
+
+	for len(player.Hand) < handSize && len(game.Deck) > 0 {
+		player.Hand = append(player.Hand, game.Deck[0])
+		game.Deck = game.Deck[1:]
+	}
+}
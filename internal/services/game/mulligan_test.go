@@ -0,0 +1,62 @@
+package game
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMulliganHandKeepsCardsInPlay(t *testing.T) {
+	player := &Player{ID: uuid.New(), Hand: []int{1, 2, 3, 4, 5, 6}}
+	gs := &GameState{Deck: []int{7, 8, 9, 10, 11, 12, 13, 14}}
+
+	// Reversing swaps keep the old hand away from the top of the deck
+	mulliganHand(gs, player, func(n int) int { return n - 1 })
+
+	assert.Len(t, player.Hand, 6)
+	assert.Len(t, gs.Deck, 8)
+
+	all := append(append([]int{}, player.Hand...), gs.Deck...)
+	sort.Ints(all)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, all)
+}
+
+func TestMulliganHandWithShortDeck(t *testing.T) {
+	player := &Player{ID: uuid.New(), Hand: []int{1, 2, 3}}
+	gs := &GameState{Deck: []int{4}}
+
+	mulliganHand(gs, player, func(n int) int { return 0 })
+
+	// The hand is refilled to its old size from the old cards and the deck
+	assert.Len(t, player.Hand, 3)
+	assert.Len(t, gs.Deck, 1)
+}
+
+func TestMulliganRules(t *testing.T) {
+	storyteller := &Player{ID: uuid.New(), Name: "Alice", Hand: []int{1, 2, 3, 4, 5, 6}}
+	other := &Player{ID: uuid.New(), Name: "Bob", Hand: []int{7, 8, 9, 10, 11, 12}}
+	gs := &GameState{
+		RoomCode: "MULLIG",
+		Sandbox:  true,
+		Status:   "in_progress",
+		Players:  map[uuid.UUID]*Player{storyteller.ID: storyteller, other.ID: other},
+		Deck:     []int{13, 14, 15, 16, 17, 18, 19, 20},
+		Settings: GameSettings{Mulligan: true},
+		CurrentRound: &Round{
+			RoundNumber:   1,
+			StorytellerID: storyteller.ID,
+			Status:        "storytelling",
+		},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}}
+
+	assert.EqualError(t, m.Mulligan(gs.RoomCode, other.ID), "only the storyteller can take a mulligan")
+	assert.NoError(t, m.Mulligan(gs.RoomCode, storyteller.ID))
+	assert.True(t, storyteller.MulliganUsed)
+	assert.EqualError(t, m.Mulligan(gs.RoomCode, storyteller.ID), "you have already used your mulligan this game")
+
+	gs.Settings.Mulligan = false
+	assert.EqualError(t, m.Mulligan(gs.RoomCode, other.ID), "mulligans are not enabled in this room")
+}
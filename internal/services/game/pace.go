@@ -0,0 +1,120 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Pace presets
+const (
+	PaceBlitz    = "blitz"
+	PaceStandard = "standard"
+	PaceRelaxed  = "relaxed"
+	PaceCustom   = "custom" // Host-tuned timing
+)
+
+// Fallbacks for rooms whose timing was never set, e.g. games restored from Redis
+const (
+	defaultRevealDelay = 5 * time.Second
+	defaultAFKTimeout  = 3 * time.Minute
+)
+
+// PaceOptions are the timing values a pace preset bundles
+type PaceOptions struct {
+	RevealDelaySeconds int `json:"reveal_delay_seconds"` // Pause on the round results before the next round
+	AFKTimeoutSeconds  int `json:"afk_timeout_seconds"`  // Inactivity before a player is handed to a bot
+	AutoStartPlayers   int `json:"auto_start_players"`   // Start as soon as this many players are seated (0 = host starts)
+}
+
+var pacePresets = map[string]PaceOptions{
+	PaceBlitz:    {RevealDelaySeconds: 2, AFKTimeoutSeconds: 60, AutoStartPlayers: 4},
+	PaceStandard: {RevealDelaySeconds: 5, AFKTimeoutSeconds: 180},
+	PaceRelaxed:  {RevealDelaySeconds: 10, AFKTimeoutSeconds: 600},
+}
+
+// PacePresets returns the named pace presets
+func PacePresets() map[string]PaceOptions {
+	presets := make(map[string]PaceOptions, len(pacePresets))
+	for name, options := range pacePresets {
+		presets[name] = options
+	}
+	return presets
+}
+
+// Validate checks that custom timing values are playable
+func (o PaceOptions) Validate() error {
+	if o.RevealDelaySeconds < 1 || o.RevealDelaySeconds > 60 {
+		return fmt.Errorf("reveal delay must be between 1 and 60 seconds")
+	}
+	if o.AFKTimeoutSeconds < 30 || o.AFKTimeoutSeconds > 3600 {
+		return fmt.Errorf("AFK timeout must be between 30 and 3600 seconds")
+	}
+	if o.AutoStartPlayers != 0 && (o.AutoStartPlayers < 3 || o.AutoStartPlayers > maxCasualPlayers) {
+		return fmt.Errorf("auto-start must be off or between 3 and %d players", maxCasualPlayers)
+	}
+	return nil
+}
+
+// RevealDelay is how long round results stay up before the next round
+func (o PaceOptions) RevealDelay() time.Duration {
+	if o.RevealDelaySeconds <= 0 {
+		return defaultRevealDelay
+	}
+	return time.Duration(o.RevealDelaySeconds) * time.Second
+}
+
+// AFKTimeout is how long a player may be inactive before a bot takes over
+func (o PaceOptions) AFKTimeout() time.Duration {
+	if o.AFKTimeoutSeconds <= 0 {
+		return defaultAFKTimeout
+	}
+	return time.Duration(o.AFKTimeoutSeconds) * time.Second
+}
+
+// resolvePace fills in the timing of a preset, or validates custom timing
+func (s *GameSettings) resolvePace() error {
+	if s.Pace == "" {
+		s.Pace = PaceStandard
+	}
+	if s.Pace == PaceCustom {
+		return s.Timing.Validate()
+	}
+	preset, ok := pacePresets[s.Pace]
+	if !ok {
+		return fmt.Errorf("unknown pace: %s", s.Pace)
+	}
+	s.Timing = preset
+	return nil
+}
+
+// maybeAutoStart starts a waiting game once it has the players its pace asks
+// for. Callers hold the game lock; the start runs once they release it.
+func (m *Manager) maybeAutoStart(game *GameState) {
+	threshold := game.Settings.Timing.AutoStartPlayers
+	if threshold == 0 || game.Status != models.GameStatusWaiting || len(game.Players) < threshold {
+		return
+	}
+
+	// Start on behalf of the host, or of anyone seated if the host has left
+	starterID := game.HostID
+	if _, exists := game.Players[starterID]; !exists {
+		for playerID := range game.Players {
+			starterID = playerID
+			break
+		}
+	}
+
+	roomCode := game.RoomCode
+	go func(starterID uuid.UUID) {
+		if err := m.StartGame(roomCode, starterID); err != nil {
+			logger.Warn("Auto-start failed", "room_code", roomCode, "error", err)
+			return
+		}
+		logger.Info("Game auto-started", "room_code", roomCode, "players", threshold)
+	}(starterID)
+}
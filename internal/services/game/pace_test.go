@@ -0,0 +1,51 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvePaceAppliesPreset(t *testing.T) {
+	settings := GameSettings{Pace: PaceBlitz, Timing: PaceOptions{RevealDelaySeconds: 30}}
+	assert.NoError(t, settings.resolvePace())
+	assert.Equal(t, pacePresets[PaceBlitz], settings.Timing)
+
+	settings = GameSettings{}
+	assert.NoError(t, settings.resolvePace())
+	assert.Equal(t, PaceStandard, settings.Pace)
+	assert.Equal(t, pacePresets[PaceStandard], settings.Timing)
+
+	settings = GameSettings{Pace: "turbo"}
+	assert.Error(t, settings.resolvePace())
+}
+
+func TestResolvePaceValidatesCustomTiming(t *testing.T) {
+	custom := PaceOptions{RevealDelaySeconds: 8, AFKTimeoutSeconds: 240, AutoStartPlayers: 5}
+	settings := GameSettings{Pace: PaceCustom, Timing: custom}
+	assert.NoError(t, settings.resolvePace())
+	assert.Equal(t, custom, settings.Timing)
+
+	settings.Timing.AutoStartPlayers = 2
+	assert.Error(t, settings.resolvePace())
+
+	settings.Timing = PaceOptions{RevealDelaySeconds: 0, AFKTimeoutSeconds: 240}
+	assert.Error(t, settings.resolvePace())
+}
+
+func TestPaceOptionsFallBackWhenUnset(t *testing.T) {
+	var unset PaceOptions
+	assert.Equal(t, defaultRevealDelay, unset.RevealDelay())
+	assert.Equal(t, defaultAFKTimeout, unset.AFKTimeout())
+
+	blitz := pacePresets[PaceBlitz]
+	assert.Equal(t, 2*time.Second, blitz.RevealDelay())
+	assert.Equal(t, time.Minute, blitz.AFKTimeout())
+}
+
+func TestPacePresetsAreValid(t *testing.T) {
+	for name, preset := range PacePresets() {
+		assert.NoError(t, preset.Validate(), name)
+	}
+}
@@ -0,0 +1,193 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode"
+)
+
+// Party modifier keys
+const (
+	ModifierOneWordClue = "one_word_clue"
+	ModifierEmojiChat   = "emoji_chat"
+	ModifierSongTitle   = "song_title"
+)
+
+// RoundModifier is a rule twist applied to a single round in party modifiers mode
+type RoundModifier struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+var partyModifiers = []RoundModifier{
+	{
+		Key:         ModifierOneWordClue,
+		Name:        "One word",
+		Description: "The storyteller's clue must be a single word.",
+	},
+	{
+		Key:         ModifierEmojiChat,
+		Name:        "Emoji only",
+		Description: "Chat is limited to emoji this round.",
+	},
+	{
+		Key:         ModifierSongTitle,
+		Name:        "Name that tune",
+		Description: "The storyteller's clue must be a song title from the song list.",
+	},
+}
+
+// songTitles are the accepted clues for the song title modifier
+var songTitles = []string{
+	"Bohemian Rhapsody", "Yesterday", "Imagine", "Hey Jude", "Let It Be",
+	"Here Comes the Sun", "Yellow Submarine", "Strawberry Fields Forever",
+	"Hotel California", "Stairway to Heaven", "Smells Like Teen Spirit",
+	"Wonderwall", "Purple Rain", "Thriller", "Billie Jean", "Like a Rolling Stone",
+	"Blowin' in the Wind", "Respect", "What a Wonderful World", "Fly Me to the Moon",
+	"Over the Rainbow", "Moon River", "Blue Suede Shoes", "Hound Dog",
+	"Johnny B. Goode", "Good Vibrations", "Dancing Queen", "Waterloo",
+	"Rocket Man", "Tiny Dancer", "Space Oddity", "Heroes", "Under Pressure",
+	"Don't Stop Me Now", "Another One Bites the Dust", "Sweet Child o' Mine",
+	"Born to Run", "Dancing in the Dark", "Africa", "Take On Me", "Toxic",
+	"Hallelujah", "Creep", "Clocks", "Yellow", "Viva la Vida", "Rolling in the Deep",
+	"Shake It Off", "Bad Guy", "Happy", "Uptown Funk", "Lose Yourself",
+	"Crazy in Love", "Umbrella", "Hurt", "Jolene", "Ring of Fire", "Landslide",
+	"Dreams", "Wish You Were Here", "Comfortably Numb", "Paint It Black",
+	"Satisfaction", "Sympathy for the Devil", "Riders on the Storm", "Light My Fire",
+	"Whole Lotta Love", "Black Hole Sun", "Losing My Religion", "Everybody Hurts",
+	"Somewhere Only We Know", "Mr. Brightside", "Seven Nation Army", "Feeling Good",
+	"Summertime", "Autumn Leaves", "Stormy Weather", "Smoke on the Water",
+	"Eye of the Tiger", "Highway to Hell", "Back in Black", "Sweet Dreams",
+	"Girls Just Want to Have Fun", "Time After Time", "Every Breath You Take",
+	"Message in a Bottle", "With or Without You", "One", "Nothing Else Matters",
+	"Enter Sandman", "Zombie", "Linger", "La Vie en Rose", "Clair de Lune",
+	"Ode to Joy", "Greensleeves", "Amazing Grace", "Yesterday Once More",
+}
+
+// pickRoundModifier chooses a random modifier, avoiding an immediate repeat
+func pickRoundModifier(previous *RoundModifier, rng *rand.Rand) *RoundModifier {
+	candidates := make([]RoundModifier, 0, len(partyModifiers))
+	for _, modifier := range partyModifiers {
+		if previous == nil || modifier.Key != previous.Key {
+			candidates = append(candidates, modifier)
+		}
+	}
+	modifier := candidates[rng.Intn(len(candidates))]
+	return &modifier
+}
+
+// validateClue enforces a round modifier's clue rule
+func validateClue(modifier *RoundModifier, clue string) error {
+	if modifier == nil {
+		return nil
+	}
+
+	switch modifier.Key {
+	case ModifierOneWordClue:
+		if len(strings.Fields(clue)) != 1 {
+			return fmt.Errorf("this round's clue must be a single word")
+		}
+	case ModifierSongTitle:
+		if !isSongTitle(clue) {
+			return fmt.Errorf("this round's clue must be a title from the song list")
+		}
+	}
+	return nil
+}
+
+// validateChatMessage enforces a round modifier's chat rule
+func validateChatMessage(modifier *RoundModifier, message string) error {
+	if modifier != nil && modifier.Key == ModifierEmojiChat && !isEmojiOnly(message) {
+		return fmt.Errorf("only emoji are allowed in chat this round")
+	}
+	return nil
+}
+
+// adaptClueForModifier rewrites a generated clue (e.g. a bot's) so it satisfies the modifier
+func adaptClueForModifier(modifier *RoundModifier, clue string, rng *rand.Rand) string {
+	if modifier == nil {
+		return clue
+	}
+
+	switch modifier.Key {
+	case ModifierOneWordClue:
+		// Keep the longest word, which is usually the most evocative
+		best := ""
+		for _, word := range strings.Fields(clue) {
+			word = strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) })
+			if len(word) > len(best) {
+				best = word
+			}
+		}
+		if best != "" {
+			return best
+		}
+	case ModifierSongTitle:
+		if !isSongTitle(clue) {
+			return songTitles[rng.Intn(len(songTitles))]
+		}
+	}
+	return clue
+}
+
+// isSongTitle matches a clue against the song list, ignoring case and punctuation
+func isSongTitle(clue string) bool {
+	normalized := normalizeTitle(clue)
+	if normalized == "" {
+		return false
+	}
+	for _, title := range songTitles {
+		if normalizeTitle(title) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+		} else if unicode.IsSpace(r) && b.Len() > 0 {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// isEmojiOnly reports whether a message contains at least one emoji and nothing but emoji and spaces
+func isEmojiOnly(message string) bool {
+	hasEmoji := false
+	for _, r := range message {
+		switch {
+		case unicode.IsSpace(r):
+		case isEmojiJoiner(r):
+		case isEmoji(r):
+			hasEmoji = true
+		default:
+			return false
+		}
+	}
+	return hasEmoji
+}
+
+func isEmoji(r rune) bool {
+	return (r >= 0x1F000 && r <= 0x1FAFF) || // Pictographs, emoticons, transport, symbols, flags
+		(r >= 0x2600 && r <= 0x27BF) || // Miscellaneous symbols and dingbats
+		(r >= 0x2300 && r <= 0x23FF) || // Technical symbols (⌚, ⏰)
+		(r >= 0x2B00 && r <= 0x2BFF) || // Stars, arrows (⭐, ⬆)
+		(r >= 0x2190 && r <= 0x21FF) || // Arrows
+		r == 0x00A9 || r == 0x00AE || r == 0x203C || r == 0x2049 || r == 0x2122 ||
+		r == 0x2139 || r == 0x3030 || r == 0x303D || r == 0x3297 || r == 0x3299
+}
+
+// isEmojiJoiner matches the invisible code points that build composite emoji
+func isEmojiJoiner(r rune) bool {
+	return r == 0x200D || // Zero width joiner
+		r == 0xFE0F || r == 0xFE0E || // Variation selectors
+		r == 0x20E3 || // Combining keycap
+		(r >= 0xE0020 && r <= 0xE007F) // Tag characters (subdivision flags)
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateClueOneWord(t *testing.T) {
+	modifier := &RoundModifier{Key: ModifierOneWordClue}
+
+	assert.NoError(t, validateClue(modifier, "Moonlight"))
+	assert.NoError(t, validateClue(modifier, "  Moonlight "))
+	assert.Error(t, validateClue(modifier, "Midnight sun"))
+	assert.Error(t, validateClue(modifier, ""))
+}
+
+func TestValidateClueSongTitle(t *testing.T) {
+	modifier := &RoundModifier{Key: ModifierSongTitle}
+
+	assert.NoError(t, validateClue(modifier, "Bohemian Rhapsody"))
+	assert.NoError(t, validateClue(modifier, "sweet child o mine"))
+	assert.NoError(t, validateClue(modifier, "Mr Brightside!"))
+	assert.Error(t, validateClue(modifier, "A song about the sea"))
+}
+
+func TestValidateClueWithoutModifier(t *testing.T) {
+	assert.NoError(t, validateClue(nil, "any clue at all"))
+	assert.NoError(t, validateClue(&RoundModifier{Key: ModifierEmojiChat}, "any clue at all"))
+}
+
+func TestIsEmojiOnly(t *testing.T) {
+	assert.True(t, isEmojiOnly("😂"))
+	assert.True(t, isEmojiOnly("🔥 👍 ⭐"))
+	assert.True(t, isEmojiOnly("👨‍👩‍👧 ❤️"))
+	assert.False(t, isEmojiOnly("lol 😂"))
+	assert.False(t, isEmojiOnly("   "))
+	assert.False(t, isEmojiOnly(""))
+}
+
+func TestPickRoundModifierAvoidsRepeat(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	previous := &RoundModifier{Key: ModifierOneWordClue}
+
+	for i := 0; i < 50; i++ {
+		modifier := pickRoundModifier(previous, rng)
+		assert.NotEqual(t, previous.Key, modifier.Key)
+		previous = modifier
+	}
+}
+
+func TestAdaptClueForModifier(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	oneWord := adaptClueForModifier(&RoundModifier{Key: ModifierOneWordClue}, "a quiet, wandering dream", rng)
+	assert.NoError(t, validateClue(&RoundModifier{Key: ModifierOneWordClue}, oneWord))
+	assert.Equal(t, "wandering", oneWord)
+
+	song := adaptClueForModifier(&RoundModifier{Key: ModifierSongTitle}, "a quiet dream", rng)
+	assert.NoError(t, validateClue(&RoundModifier{Key: ModifierSongTitle}, song))
+}
@@ -0,0 +1,204 @@
+package game
+
+import (
+	"encoding"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files")
+
+// wsPayloads lists the payload every message type is sent with. Keep it in
+// step with the MessageType constants.
+var wsPayloads = []struct {
+	Type    MessageType
+	Payload interface{}
+}{
+	{MessageTypePlayerJoined, PlayerJoinedPayload{}},
+	{MessageTypePlayerLeft, PlayerLeftPayload{}},
+	{MessageTypePlayerReplaced, PlayerReplacedPayload{}},
+	{MessageTypeGameStarted, GameStartedPayload{}},
+	{MessageTypeRoundStarted, RoundStartedPayload{}},
+	{MessageTypeClueSubmitted, ClueSubmittedPayload{}},
+	{MessageTypeCardSubmitted, CardSubmittedPayload{}},
+	{MessageTypeVotingStarted, VotingStartedPayload{}},
+	{MessageTypeVoteSubmitted, VoteSubmittedPayload{}},
+	{MessageTypeRoundCompleted, RoundCompletedPayload{}},
+	{MessageTypeGameCompleted, GameCompletedPayload{}},
+	{MessageTypeGameDeleted, GameDeletedPayload{}},
+	{MessageTypeError, ErrorPayload{}},
+	{MessageTypeGameState, GameStatePayload{}},
+	{MessageTypeGameState, GameStateV2Payload{}},
+	{MessageTypeChatMessage, ChatMessagePayload{}},
+	{MessageTypeChatHistory, ChatHistoryPayload{}},
+	{MessageTypeVoicePeerJoined, VoicePeerJoinedPayload{}},
+	{MessageTypeVoicePeerLeft, VoicePeerLeftPayload{}},
+	{MessageTypeVoiceSignal, VoiceSignalPayload{}},
+	{MessageTypeVoiceState, VoiceStatePayload{}},
+	{MessageTypeResumeToken, ResumeTokenPayload{}},
+	{MessageTypeSessionReplaced, ErrorPayload{}},
+	{MessageTypeMulliganUsed, MulliganUsedPayload{}},
+	{MessageTypeAccountPrompt, AccountPromptPayload{}},
+	{MessageTypeStandInResult, StandInResultPayload{}},
+	{MessageTypePhaseTimer, PhaseTimerPayload{}},
+	{MessageTypeSeatRestored, SeatRestoredPayload{}},
+	{MessageTypeDeckLow, DeckLowPayload{}},
+	{MessageTypeHostChanged, HostChangedPayload{}},
+	{MessageTypeKickVote, KickVotePayload{}},
+	{MessageTypeTakeoverRequested, TakeoverRequest{}},
+	{MessageTypeTakeoverResolved, TakeoverResolvedPayload{}},
+	{MessageTypeRematch, RematchPayload{}},
+	{MessageTypeDebugEvent, DebugEventPayload{}},
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// payloadSchema describes the JSON clients receive for each message type:
+// the fields of every object reachable from the payloads and their kinds
+type payloadSchema struct {
+	objects map[string][]string
+}
+
+func (s *payloadSchema) kind(t reflect.Type) string {
+	switch {
+	case t == timeType:
+		return "time"
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return "custom"
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return "string"
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return s.kind(t.Elem()) + "?"
+	case reflect.Slice, reflect.Array:
+		return "[]" + s.kind(t.Elem())
+	case reflect.Map:
+		return "map[" + s.kind(t.Key()) + "]" + s.kind(t.Elem())
+	case reflect.Struct:
+		s.object(t)
+		return t.Name()
+	case reflect.Interface:
+		return "any"
+	case reflect.Bool:
+		return "bool"
+	case reflect.String:
+		return "string"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "integer"
+	}
+}
+
+// object records the fields of a struct the first time it is reached
+func (s *payloadSchema) object(t reflect.Type) {
+	if _, seen := s.objects[t.Name()]; seen {
+		return
+	}
+	s.objects[t.Name()] = nil // Cuts cycles
+	s.objects[t.Name()] = s.fields(t)
+}
+
+func (s *payloadSchema) fields(t reflect.Type) []string {
+	// Fields of the struct itself shadow those of embedded structs
+	own := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+			own[name] = true
+		}
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			// Embedded structs are flattened into the parent object
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			for _, line := range s.fields(embedded) {
+				if name, _, _ := strings.Cut(line, ":"); !own[name] {
+					fields = append(fields, line)
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		line := name + ": " + s.kind(field.Type)
+		if strings.Contains(options, "omitempty") {
+			line += " (omitempty)"
+		}
+		fields = append(fields, line)
+	}
+	return fields
+}
+
+// describeWSPayloads renders the schema of every message payload
+func describeWSPayloads() string {
+	schema := &payloadSchema{objects: make(map[string][]string)}
+
+	var b strings.Builder
+	b.WriteString("# Payloads by message type\n")
+	for _, message := range wsPayloads {
+		fmt.Fprintf(&b, "%s: %s\n", message.Type, schema.kind(reflect.TypeOf(message.Payload)))
+	}
+
+	names := make([]string, 0, len(schema.objects))
+	for name := range schema.objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n%s\n", name)
+		for _, field := range schema.objects[name] {
+			fmt.Fprintf(&b, "  %s\n", field)
+		}
+	}
+	return b.String()
+}
+
+// TestWSPayloadSchemasAreFrozen fails when a payload sent to clients changes
+// shape. Renaming, removing or retyping a field breaks web clients in the
+// wild; if a change is intended, rerun with -update and review the diff.
+func TestWSPayloadSchemasAreFrozen(t *testing.T) {
+	golden := filepath.Join("testdata", "ws_payloads.golden")
+	got := describeWSPayloads()
+
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+		require.NoError(t, os.WriteFile(golden, []byte(got), 0o644))
+	}
+
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err, "run go test -run TestWSPayloadSchemasAreFrozen -update to create the golden file")
+	assert.Equal(t, string(want), got, "a WebSocket payload changed shape; rerun with -update if that is intended")
+}
@@ -2,8 +2,9 @@ package game
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"dixitme/internal/logger"
@@ -20,14 +21,28 @@ type GamePersistenceService interface {
 	PersistGame(ctx context.Context, game *GameState) error
 	PersistPlayer(ctx context.Context, player *models.Player) error
 	PersistGamePlayer(ctx context.Context, gameID uuid.UUID, player *Player) error
+	RemoveGamePlayer(ctx context.Context, gameID, playerID uuid.UUID) error
+	UpdatePlayerTeams(ctx context.Context, gameID uuid.UUID, teams map[uuid.UUID]int) error
+	DeleteGameRecord(ctx context.Context, roomCode string) error
 	UpdateGameStatus(ctx context.Context, gameID uuid.UUID, status models.GameStatus) error
+	UpdateGamePace(ctx context.Context, gameID uuid.UUID, pace string) error
+	UpdateGameVisibility(ctx context.Context, gameID uuid.UUID, private bool) error
 	PersistRound(ctx context.Context, gameID uuid.UUID, round *Round) error
 	UpdateRound(ctx context.Context, round *Round) error
 	PersistCardSubmission(ctx context.Context, roundID, playerID uuid.UUID, cardID int) error
-	PersistVote(ctx context.Context, roundID, playerID uuid.UUID, cardID int) error
-	PersistGameCompletion(ctx context.Context, gameID, winnerID uuid.UUID) error
+	PersistVote(ctx context.Context, roundID uuid.UUID, vote *Vote) error
+	PersistGameCompletion(ctx context.Context, result *GameResult) error
+	PersistForfeit(ctx context.Context, forfeit *models.GameForfeit) error
+	GetPlayerRatings(ctx context.Context, playerIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	PersistRoundScores(ctx context.Context, gameID uuid.UUID, sample RoundScoreSample) error
 	PersistChatMessage(ctx context.Context, chatMessage *models.ChatMessage) error
-	GetChatMessages(ctx context.Context, gameID uuid.UUID, phase string, limit int) ([]models.ChatMessage, error)
+	GetChatMessages(ctx context.Context, gameID, viewerID uuid.UUID, phase string, limit int) ([]models.ChatMessage, error)
+	PersistPlayerReport(ctx context.Context, report *models.PlayerReport) error
+	PersistGameReport(ctx context.Context, report *models.GameReport) error
+	PersistExperimentAssignments(ctx context.Context, gameID uuid.UUID, experiments []string) error
+	LoadGameReport(ctx context.Context, roomCode string) (*models.GameReport, error)
+	LoadScoreTimeline(ctx context.Context, roomCode string) (*ScoreTimeline, error)
+	LoadFairnessProof(ctx context.Context, roomCode string) (*FairnessProof, error)
 
 	// Redis operations with context support
 	StoreGameInRedis(ctx context.Context, game *GameState) error
@@ -46,7 +61,30 @@ func (m *Manager) PersistGame(ctx context.Context, game *GameState) error {
 		Status:       game.Status,
 		CurrentRound: game.RoundNumber,
 		MaxRounds:    game.MaxRounds,
+		Pace:         game.Settings.Pace,
+		Private:      game.Settings.Private,
+		Region:       game.Region,
 		CreatedAt:    game.CreatedAt,
+
+		ShuffleSeed:       game.shuffleSeed,
+		PasswordHash:      game.passwordHash,
+		ShuffleCommitment: game.ShuffleCommitment,
+		TournamentID:      game.TournamentID,
+		Packs:             strings.Join(game.Packs, ","),
+		PackCards:         formatCardList(game.packCards),
+		DeckID:            game.DeckID,
+
+		MaxPlayers:        game.Settings.Rules.MaxPlayers,
+		TargetScore:       game.Settings.Rules.TargetScore,
+		HandSize:          game.Settings.Rules.HandSize,
+		RoundTimerSeconds: game.Settings.Rules.RoundTimerSeconds,
+		Expansions:        strings.Join(game.Settings.Rules.Expansions, ","),
+		LargeGame:         game.Settings.Rules.LargeGame,
+		TeamSize:          game.Settings.Rules.TeamSize,
+
+		StorytellingTimerSeconds: game.Settings.Rules.PhaseTimers.StorytellingSeconds,
+		SubmittingTimerSeconds:   game.Settings.Rules.PhaseTimers.SubmittingSeconds,
+		VotingTimerSeconds:       game.Settings.Rules.PhaseTimers.VotingSeconds,
 	}
 
 	if err := m.db.WithContext(ctx).Create(dbGame).Error; err != nil {
@@ -66,6 +104,15 @@ func (m *Manager) PersistGame(ctx context.Context, game *GameState) error {
 func (m *Manager) PersistPlayer(ctx context.Context, player *models.Player) error {
 	log := logger.GetLogger()
 
+	// Human player IDs are user IDs for registered accounts; link them
+	if player.Type != models.PlayerTypeBot && player.UserID == nil {
+		var user models.User
+		if err := m.db.WithContext(ctx).Select("id", "auth_type").First(&user, "id = ?", player.ID).Error; err == nil {
+			player.UserID = &user.ID
+			player.AuthType = user.AuthType
+		}
+	}
+
 	// Use FirstOrCreate to handle existing players
 	var existingPlayer models.Player
 	result := m.db.WithContext(ctx).Where("id = ?", player.ID).FirstOrCreate(&existingPlayer, player)
@@ -100,6 +147,8 @@ func (m *Manager) PersistGamePlayer(ctx context.Context, gameID uuid.UUID, playe
 		Score:    player.Score,
 		Position: player.Position,
 		IsActive: player.IsActive,
+		Token:    player.Token,
+		Team:     player.Team,
 	}
 
 	if err := m.db.WithContext(ctx).Create(dbGamePlayer).Error; err != nil {
@@ -116,6 +165,43 @@ func (m *Manager) PersistGamePlayer(ctx context.Context, gameID uuid.UUID, playe
 	return nil
 }
 
+// UpdatePlayerTeams records the teams players were split into
+func (m *Manager) UpdatePlayerTeams(ctx context.Context, gameID uuid.UUID, teams map[uuid.UUID]int) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for playerID, team := range teams {
+			if err := tx.Model(&models.GamePlayer{}).
+				Where("game_id = ? AND player_id = ?", gameID, playerID).
+				Update("team", team).Error; err != nil {
+				return fmt.Errorf("failed to update player teams: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (m *Manager) RemoveGamePlayer(ctx context.Context, gameID, playerID uuid.UUID) error {
+	if err := m.db.WithContext(ctx).
+		Where("game_id = ? AND player_id = ?", gameID, playerID).
+		Delete(&models.GamePlayer{}).Error; err != nil {
+		logger.Error("Failed to remove game player",
+			"game_id", gameID,
+			"player_id", playerID,
+			"error", err)
+		return fmt.Errorf("failed to remove game player: %w", err)
+	}
+	return nil
+}
+
+func (m *Manager) DeleteGameRecord(ctx context.Context, roomCode string) error {
+	if err := m.db.WithContext(ctx).Where("room_code = ?", roomCode).Delete(&models.Game{}).Error; err != nil {
+		logger.Error("Failed to delete game",
+			"room_code", roomCode,
+			"error", err)
+		return fmt.Errorf("failed to delete game %s: %w", roomCode, err)
+	}
+	return nil
+}
+
 func (m *Manager) UpdateGameStatus(ctx context.Context, gameID uuid.UUID, status models.GameStatus) error {
 	log := logger.GetLogger()
 
@@ -144,6 +230,22 @@ func (m *Manager) UpdateGameStatus(ctx context.Context, gameID uuid.UUID, status
 	return nil
 }
 
+// UpdateGamePace records a room's pace preset for the lobby browser
+func (m *Manager) UpdateGamePace(ctx context.Context, gameID uuid.UUID, pace string) error {
+	if err := m.db.WithContext(ctx).Model(&models.Game{}).Where("id = ?", gameID).Update("pace", pace).Error; err != nil {
+		return fmt.Errorf("failed to update game pace: %w", err)
+	}
+	return nil
+}
+
+// UpdateGameVisibility records whether a room is hidden from the lobby browser
+func (m *Manager) UpdateGameVisibility(ctx context.Context, gameID uuid.UUID, private bool) error {
+	if err := m.db.WithContext(ctx).Model(&models.Game{}).Where("id = ?", gameID).Update("private", private).Error; err != nil {
+		return fmt.Errorf("failed to update game visibility: %w", err)
+	}
+	return nil
+}
+
 func (m *Manager) PersistRound(ctx context.Context, gameID uuid.UUID, round *Round) error {
 	log := logger.GetLogger()
 
@@ -179,8 +281,10 @@ func (m *Manager) UpdateRound(ctx context.Context, round *Round) error {
 	log := logger.GetLogger()
 
 	updates := map[string]interface{}{
-		"clue":   round.Clue,
-		"status": round.Status,
+		"clue":             round.Clue,
+		"clue_language":    round.ClueLanguage,
+		"status":           round.Status,
+		"storyteller_card": round.StorytellerCard,
 	}
 
 	result := m.db.WithContext(ctx).Model(&models.GameRound{}).
@@ -227,78 +331,188 @@ func (m *Manager) PersistCardSubmission(ctx context.Context, roundID, playerID u
 	return nil
 }
 
-func (m *Manager) PersistVote(ctx context.Context, roundID, playerID uuid.UUID, cardID int) error {
+func (m *Manager) PersistVote(ctx context.Context, roundID uuid.UUID, vote *Vote) error {
 	log := logger.GetLogger()
 
-	vote := &models.Vote{
-		ID:       uuid.New(),
-		RoundID:  roundID,
-		PlayerID: playerID,
-		CardID:   cardID,
+	dbVote := &models.Vote{
+		ID:         uuid.New(),
+		RoundID:    roundID,
+		PlayerID:   vote.PlayerID,
+		CardID:     vote.CardID,
+		Weight:     max(vote.Weight, 1),
+		DoubleDown: vote.DoubleDown,
 	}
 
-	if err := m.db.WithContext(ctx).Create(vote).Error; err != nil {
+	if err := m.db.WithContext(ctx).Create(dbVote).Error; err != nil {
 		log.Error("Failed to persist vote",
 			"round_id", roundID,
-			"player_id", playerID,
-			"card_id", cardID,
+			"player_id", vote.PlayerID,
+			"card_id", vote.CardID,
 			"error", err)
 		return fmt.Errorf("failed to persist vote: %w", err)
 	}
 
 	log.Debug("Vote persisted successfully",
 		"round_id", roundID,
-		"player_id", playerID,
-		"card_id", cardID)
+		"player_id", vote.PlayerID,
+		"card_id", vote.CardID)
 	return nil
 }
 
-func (m *Manager) PersistGameCompletion(ctx context.Context, gameID, winnerID uuid.UUID) error {
+// PersistGameCompletion records how a game ended: its final status, a history
+// record and, for ranked games, the players' rating changes
+func (m *Manager) PersistGameCompletion(ctx context.Context, result *GameResult) error {
 	log := logger.GetLogger()
 
+	status := models.GameStatusCompleted
+	if result.Outcome != models.GameOutcomeCompleted {
+		status = models.GameStatusAbandoned
+	}
+
 	// Use transaction for game completion operations
 	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Update game status
 		if err := tx.Model(&models.Game{}).
-			Where("id = ?", gameID).
+			Where("id = ?", result.GameID).
 			Updates(map[string]interface{}{
-				"status":     models.GameStatusCompleted,
+				"status":     status,
 				"updated_at": time.Now(),
 			}).Error; err != nil {
 			log.Error("Failed to update game completion status in transaction",
-				"game_id", gameID,
+				"game_id", result.GameID,
 				"error", err)
 			return fmt.Errorf("failed to update game completion status: %w", err)
 		}
 
 		// Create game history record
 		gameHistory := &models.GameHistory{
-			ID:       uuid.New(),
-			GameID:   gameID,
-			WinnerID: winnerID,
-			// Additional fields would be populated from game state
-			CreatedAt: time.Now(),
+			ID:          uuid.New(),
+			GameID:      result.GameID,
+			WinnerID:    result.WinnerID,
+			TotalRounds: result.TotalRounds,
+			Duration:    int(result.Duration.Minutes()),
+			Ranked:      result.Ranked,
+			Outcome:     result.Outcome,
+			CreatedAt:   time.Now(),
 		}
 
 		if err := tx.Create(gameHistory).Error; err != nil {
 			log.Error("Failed to persist game history in transaction",
-				"game_id", gameID,
-				"winner_id", winnerID,
+				"game_id", result.GameID,
+				"winner_id", result.WinnerID,
 				"error", err)
 			return fmt.Errorf("failed to persist game history: %w", err)
 		}
 
+		for playerID, delta := range result.RatingChanges {
+			if err := applyRatingChange(tx, playerID, delta, playerID == result.WinnerID, false); err != nil {
+				log.Error("Failed to update rating in transaction",
+					"game_id", result.GameID,
+					"player_id", playerID,
+					"error", err)
+				return err
+			}
+		}
+
 		log.Info("Game completion persisted successfully",
-			"game_id", gameID,
-			"winner_id", winnerID)
+			"game_id", result.GameID,
+			"winner_id", result.WinnerID,
+			"outcome", result.Outcome,
+			"ranked", result.Ranked)
 		return nil
 	})
 }
 
+// PersistForfeit records a ranked forfeit and takes the penalty off the player's rating
+func (m *Manager) PersistForfeit(ctx context.Context, forfeit *models.GameForfeit) error {
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(forfeit).Error; err != nil {
+			return err
+		}
+		return applyRatingChange(tx, forfeit.PlayerID, -forfeit.RatingPenalty, false, true)
+	})
+	if err != nil {
+		logger.Error("Failed to persist forfeit",
+			"game_id", forfeit.GameID,
+			"player_id", forfeit.PlayerID,
+			"error", err)
+		return fmt.Errorf("failed to persist forfeit: %w", err)
+	}
+	return nil
+}
+
+// GetPreferredToken returns the player token a registered account asked for,
+// or "" for guests and accounts without a preference
+func (m *Manager) GetPreferredToken(ctx context.Context, playerID uuid.UUID) (string, error) {
+	var tokens []string
+	if err := m.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", playerID).
+		Pluck("preferred_token", &tokens).Error; err != nil {
+		return "", fmt.Errorf("failed to load preferred token: %w", err)
+	}
+	if len(tokens) == 0 {
+		return "", nil
+	}
+	return tokens[0], nil
+}
+
+// GetPlayerRatings returns the ratings of the given players. Players who have
+// never finished a ranked game are missing from the result.
+func (m *Manager) GetPlayerRatings(ctx context.Context, playerIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	ratings := make(map[uuid.UUID]int, len(playerIDs))
+	if len(playerIDs) == 0 {
+		return ratings, nil
+	}
+
+	var rows []models.PlayerRating
+	if err := m.db.WithContext(ctx).Where("player_id IN ?", playerIDs).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load player ratings: %w", err)
+	}
+	for _, row := range rows {
+		ratings[row.PlayerID] = row.Rating
+	}
+	return ratings, nil
+}
+
+// applyRatingChange adds one ranked result to a player's rating, creating the rating on first use
+func applyRatingChange(tx *gorm.DB, playerID uuid.UUID, delta int, won, forfeited bool) error {
+	rating := models.PlayerRating{PlayerID: playerID, Rating: models.DefaultRating}
+	if err := tx.Where("player_id = ?", playerID).FirstOrCreate(&rating).Error; err != nil {
+		return fmt.Errorf("failed to load rating: %w", err)
+	}
+
+	updates := map[string]interface{}{
+		"rating":       gorm.Expr("GREATEST(rating + ?, ?)", delta, minRating),
+		"ranked_games": gorm.Expr("ranked_games + 1"),
+		"updated_at":   time.Now(),
+	}
+	if won {
+		updates["ranked_wins"] = gorm.Expr("ranked_wins + 1")
+	}
+	if forfeited {
+		updates["forfeits"] = gorm.Expr("forfeits + 1")
+	}
+
+	if err := tx.Model(&models.PlayerRating{}).Where("player_id = ?", playerID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update rating: %w", err)
+	}
+	return nil
+}
+
 func (m *Manager) PersistChatMessage(ctx context.Context, chatMessage *models.ChatMessage) error {
 	log := logger.GetLogger()
 
-	if err := m.db.WithContext(ctx).Create(chatMessage).Error; err != nil {
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(chatMessage).Error; err != nil {
+			return err
+		}
+		// is_visible defaults to true, so a false value has to be written explicitly
+		if !chatMessage.IsVisible {
+			return tx.Model(chatMessage).Update("is_visible", false).Error
+		}
+		return nil
+	})
+	if err != nil {
 		log.Error("Failed to persist chat message",
 			"message_id", chatMessage.ID,
 			"game_id", chatMessage.GameID,
@@ -314,11 +528,17 @@ func (m *Manager) PersistChatMessage(ctx context.Context, chatMessage *models.Ch
 	return nil
 }
 
-func (m *Manager) GetChatMessages(ctx context.Context, gameID uuid.UUID, phase string, limit int) ([]models.ChatMessage, error) {
+// GetChatMessages returns a game's chat as viewerID sees it: hidden messages
+// are only included for their author
+func (m *Manager) GetChatMessages(ctx context.Context, gameID, viewerID uuid.UUID, phase string, limit int) ([]models.ChatMessage, error) {
 	log := logger.GetLogger()
 
 	var messages []models.ChatMessage
-	query := m.db.WithContext(ctx).Where("game_id = ?", gameID)
+	// Unscoped so messages from deleted players keep their author's name
+	query := m.db.WithContext(ctx).
+		Preload("Player", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("game_id = ?", gameID).
+		Where("is_visible = ? OR player_id = ?", true, viewerID)
 
 	if phase != "" {
 		query = query.Where("phase = ?", phase)
@@ -340,6 +560,83 @@ func (m *Manager) GetChatMessages(ctx context.Context, gameID uuid.UUID, phase s
 	return messages, nil
 }
 
+// PersistGameReport saves a game's host report, replacing any earlier one
+func (m *Manager) PersistPlayerReport(ctx context.Context, report *models.PlayerReport) error {
+	// One report per reporter, reported player and game; repeats are ignored
+	existing := models.PlayerReport{}
+	err := m.db.WithContext(ctx).
+		Where("game_id = ? AND reporter_id = ? AND reported_id = ?", report.GameID, report.ReporterID, report.ReportedID).
+		Attrs(report).
+		FirstOrCreate(&existing).Error
+	if err != nil {
+		logger.GetLogger().Error("Failed to persist player report",
+			"game_id", report.GameID,
+			"reported_id", report.ReportedID,
+			"error", err)
+		return fmt.Errorf("failed to persist player report: %w", err)
+	}
+	return nil
+}
+
+func (m *Manager) PersistGameReport(ctx context.Context, report *models.GameReport) error {
+	log := logger.GetLogger()
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("game_id = ?", report.GameID).Delete(&models.GameReport{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(report).Error
+	})
+	if err != nil {
+		log.Error("Failed to persist game report",
+			"game_id", report.GameID,
+			"error", err)
+		return fmt.Errorf("failed to persist game report: %w", err)
+	}
+
+	log.Debug("Game report persisted successfully", "game_id", report.GameID, "room_code", report.RoomCode)
+	return nil
+}
+
+// PersistExperimentAssignments records which experiments a game was played with
+func (m *Manager) PersistExperimentAssignments(ctx context.Context, gameID uuid.UUID, experiments []string) error {
+	if len(experiments) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	assignments := make([]models.GameExperiment, 0, len(experiments))
+	for _, experiment := range experiments {
+		assignments = append(assignments, models.GameExperiment{
+			ID:         uuid.New(),
+			GameID:     gameID,
+			Experiment: experiment,
+			AssignedAt: now,
+		})
+	}
+
+	if err := m.db.WithContext(ctx).Create(&assignments).Error; err != nil {
+		logger.Error("Failed to persist experiment assignments",
+			"game_id", gameID,
+			"experiments", experiments,
+			"error", err)
+		return fmt.Errorf("failed to persist experiment assignments: %w", err)
+	}
+	return nil
+}
+
+// LoadGameReport returns the most recent host report for a room code
+func (m *Manager) LoadGameReport(ctx context.Context, roomCode string) (*models.GameReport, error) {
+	var report models.GameReport
+	if err := m.db.WithContext(ctx).Where("room_code = ?", roomCode).Order("created_at DESC").First(&report).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to load game report: %w", err)
+	}
+	return &report, nil
+}
+
 // Redis operations with improved implementation and structured logging
 
 func (m *Manager) StoreGameInRedis(ctx context.Context, game *GameState) error {
@@ -350,32 +647,8 @@ func (m *Manager) StoreGameInRedis(ctx context.Context, game *GameState) error {
 		return nil // Redis not configured, not an error
 	}
 
-	// Create a comprehensive game representation for Redis
-	gameData := map[string]interface{}{
-		"id":           game.ID.String(),
-		"room_code":    game.RoomCode,
-		"status":       string(game.Status),
-		"player_count": len(game.Players),
-		"round_number": game.RoundNumber,
-		"max_rounds":   game.MaxRounds,
-		"created_at":   game.CreatedAt.Format(time.RFC3339),
-		"updated_at":   time.Now().Format(time.RFC3339),
-	}
-
-	// Add player information
-	var players []map[string]interface{}
-	for _, player := range game.Players {
-		players = append(players, map[string]interface{}{
-			"id":       player.ID.String(),
-			"name":     player.Name,
-			"score":    player.Score,
-			"is_bot":   player.IsBot,
-			"position": player.Position,
-		})
-	}
-	gameData["players"] = players
-
-	data, err := json.Marshal(gameData)
+	// Snapshot the whole game, so it can be resumed from the cache alone
+	data, err := encodeGameSnapshot(game)
 	if err != nil {
 		log.Error("Failed to marshal game data for Redis",
 			"game_id", game.ID,
@@ -395,6 +668,9 @@ func (m *Manager) StoreGameInRedis(ctx context.Context, game *GameState) error {
 			"error", err)
 		return fmt.Errorf("failed to store game in Redis: %w", err)
 	}
+	if err := m.storeRoomHome(ctx, game.RoomCode, expiration); err != nil {
+		log.Warn("Failed to store room home in Redis", "room_code", game.RoomCode, "error", err)
+	}
 
 	log.Debug("Game stored in Redis successfully",
 		"game_id", game.ID,
@@ -428,24 +704,27 @@ func (m *Manager) LoadGameFromRedis(ctx context.Context, roomCode string) (*Game
 		return nil, fmt.Errorf("failed to load game from Redis: %w", err)
 	}
 
-	var gameData map[string]interface{}
-	if err := json.Unmarshal([]byte(data), &gameData); err != nil {
+	game, err := decodeGameSnapshot([]byte(data))
+	if err != nil {
 		log.Error("Failed to unmarshal game data from Redis",
 			"room_code", roomCode,
 			"key", key,
 			"error", err)
 		return nil, fmt.Errorf("failed to unmarshal game data: %w", err)
 	}
+	if game == nil {
+		log.Warn("Ignoring game cached in an unknown format",
+			"room_code", roomCode,
+			"key", key)
+		return nil, nil // Treated as not found, so the database is used instead
+	}
 
-	// TODO: Implement full GameState reconstruction from Redis data
-	// This is a placeholder implementation that documents the partial caching approach
-	log.Warn("LoadGameFromRedis partially implemented - only basic validation performed",
+	log.Debug("Game loaded from Redis successfully",
+		"game_id", game.ID,
 		"room_code", roomCode,
-		"data_keys", len(gameData))
-
-	// For now, return nil to indicate we should load from database
-	// In a full implementation, you would reconstruct the complete GameState here
-	return nil, nil
+		"status", game.Status,
+		"round_number", game.RoundNumber)
+	return game, nil
 }
 
 func (m *Manager) DeleteGameFromRedis(ctx context.Context, roomCode string) error {
@@ -457,7 +736,7 @@ func (m *Manager) DeleteGameFromRedis(ctx context.Context, roomCode string) erro
 	}
 
 	key := "game:" + roomCode
-	result := m.redisClient.Del(ctx, key)
+	result := m.redisClient.Del(ctx, key, roomHomeKey(roomCode))
 
 	if err := result.Err(); err != nil {
 		log.Error("Failed to delete game from Redis",
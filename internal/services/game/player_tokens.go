@@ -0,0 +1,82 @@
+package game
+
+import (
+	"context"
+
+	"dixitme/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// PlayerToken is the color and avatar a player is shown with in a room.
+// Clients render tokens by ID, so replays look the same as the live game.
+type PlayerToken struct {
+	ID     string `json:"id"`
+	Color  string `json:"color"` // Hex color
+	Avatar string `json:"avatar"`
+}
+
+// PlayerTokens are the tokens handed out in a room, in assignment order.
+// There are more than the six seats, so a preferred token is often free.
+var PlayerTokens = []PlayerToken{
+	{ID: "fox", Color: "#F97316", Avatar: "fox"},
+	{ID: "whale", Color: "#3B82F6", Avatar: "whale"},
+	{ID: "frog", Color: "#22C55E", Avatar: "frog"},
+	{ID: "owl", Color: "#8B5CF6", Avatar: "owl"},
+	{ID: "bee", Color: "#EAB308", Avatar: "bee"},
+	{ID: "cat", Color: "#EC4899", Avatar: "cat"},
+	{ID: "turtle", Color: "#14B8A6", Avatar: "turtle"},
+	{ID: "wolf", Color: "#6B7280", Avatar: "wolf"},
+}
+
+// IsPlayerToken reports whether id names a token
+func IsPlayerToken(id string) bool {
+	for _, token := range PlayerTokens {
+		if token.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// pickToken returns the preferred token if no one in the room holds it,
+// otherwise the first free one. Replaced players keep their token for their
+// bot, so it stays taken. Callers must hold the game lock.
+func (gs *GameState) pickToken(preferred string) string {
+	taken := make(map[string]bool, len(gs.Players))
+	for _, player := range gs.Players {
+		taken[player.Token] = true
+	}
+
+	if IsPlayerToken(preferred) && !taken[preferred] {
+		return preferred
+	}
+	for _, token := range PlayerTokens {
+		if !taken[token.ID] {
+			return token.ID
+		}
+	}
+	return PlayerTokens[len(gs.Players)%len(PlayerTokens)].ID
+}
+
+// playerToken returns a seated player's token, or "" if they are gone
+func (gs *GameState) playerToken(playerID uuid.UUID) string {
+	if player, exists := gs.Players[playerID]; exists {
+		return player.Token
+	}
+	return ""
+}
+
+// assignToken gives a player joining the room their token, honoring the
+// preference saved on a registered account. Call before the player is seated
+// and persisted.
+func (m *Manager) assignToken(game *GameState, player *Player) {
+	var preferred string
+	if !player.IsBot {
+		var err error
+		if preferred, err = m.repository(game).GetPreferredToken(context.Background(), player.ID); err != nil {
+			logger.Warn("Failed to load preferred player token", "error", err, "player_id", player.ID)
+		}
+	}
+	player.Token = game.pickToken(preferred)
+}
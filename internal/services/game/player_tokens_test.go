@@ -0,0 +1,40 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickToken(t *testing.T) {
+	gs := &GameState{Players: make(map[uuid.UUID]*Player)}
+	seat := func(token string) {
+		id := uuid.New()
+		gs.Players[id] = &Player{ID: id, Token: token}
+	}
+
+	assert.Equal(t, "fox", gs.pickToken(""))
+	seat("fox")
+	assert.Equal(t, "whale", gs.pickToken("fox"), "a preference someone holds falls back to the first free token")
+	assert.Equal(t, "owl", gs.pickToken("owl"))
+	assert.Equal(t, "whale", gs.pickToken("unicorn"))
+}
+
+func TestJoiningPlayersGetDistinctTokens(t *testing.T) {
+	m := NewEphemeralManager()
+	_, err := m.CreateGameWithOptions("TOKENS", uuid.New(), "Alice", CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+	_, err = m.JoinGame("TOKENS", uuid.New(), "Bob")
+	require.NoError(t, err)
+	gs, err := m.AddBot("TOKENS", "easy")
+	require.NoError(t, err)
+
+	seen := make(map[string]bool)
+	for _, player := range gs.Players {
+		assert.True(t, IsPlayerToken(player.Token))
+		assert.False(t, seen[player.Token], "token %s handed out twice", player.Token)
+		seen[player.Token] = true
+	}
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Ranked play tuning
+const (
+	rankedMinHumans = 2   // Ranked games need human opponents to be rated against
+	rankedKFactor   = 32  // Largest rating swing a single game can cause
+	forfeitPenalty  = 25  // Rating lost for abandoning a ranked game
+	minRating       = 100 // Ratings never drop below this floor
+)
+
+// GameResult is what gets recorded when a game ends
+type GameResult struct {
+	GameID        uuid.UUID
+	WinnerID      uuid.UUID
+	Outcome       models.GameOutcome
+	Ranked        bool
+	TotalRounds   int
+	Duration      time.Duration
+	RatingChanges map[uuid.UUID]int // Rating deltas of rated players (ranked completions only)
+}
+
+// newGameResult summarises a game for its history record
+func newGameResult(game *GameState, winnerID uuid.UUID, outcome models.GameOutcome) *GameResult {
+	return &GameResult{
+		GameID:      game.ID,
+		WinnerID:    winnerID,
+		Outcome:     outcome,
+		Ranked:      game.Settings.Ranked,
+		TotalRounds: game.RoundNumber,
+		Duration:    time.Since(game.CreatedAt),
+	}
+}
+
+// ratedResult is a rated player's standing at the end of a ranked game
+type ratedResult struct {
+	PlayerID uuid.UUID
+	Rating   int
+	Score    int
+}
+
+// ratingChanges computes Elo rating deltas from final scores. Each pair of rated
+// players counts as a head-to-head match and the K-factor is split across
+// opponents, so the swing stays the same whatever the table size.
+func ratingChanges(results []ratedResult) map[uuid.UUID]int {
+	changes := make(map[uuid.UUID]int, len(results))
+	if len(results) < 2 {
+		return changes
+	}
+
+	k := float64(rankedKFactor) / float64(len(results)-1)
+	for _, player := range results {
+		var delta float64
+		for _, opponent := range results {
+			if opponent.PlayerID == player.PlayerID {
+				continue
+			}
+			expected := 1 / (1 + math.Pow(10, float64(opponent.Rating-player.Rating)/400))
+			actual := 0.5
+			if player.Score > opponent.Score {
+				actual = 1
+			} else if player.Score < opponent.Score {
+				actual = 0
+			}
+			delta += k * (actual - expected)
+		}
+		changes[player.PlayerID] = int(math.Round(delta))
+	}
+	return changes
+}
+
+// ratedPlayers returns the players a ranked game rates: humans still in their
+// seat. Bots are never rated and replaced players already took a forfeit.
+func ratedPlayers(game *GameState) []*Player {
+	var players []*Player
+	for _, player := range game.Players {
+		if !player.IsBot && !player.WasReplaced {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+// checkRankedHumans makes sure a ranked game has enough humans to be rated
+func checkRankedHumans(game *GameState) error {
+	if !game.Settings.Ranked {
+		return nil
+	}
+	if humans := len(ratedPlayers(game)); humans < rankedMinHumans {
+		return &GameError{
+			Code:    ErrCodeNotEnoughHumans,
+			Message: "ranked games need at least 2 human players",
+			Details: map[string]interface{}{"min_humans": rankedMinHumans, "humans": humans, "ranked": true},
+		}
+	}
+	return nil
+}
+
+// applyRankedResult fills in the rating changes of a ranked game played to the end
+func (m *Manager) applyRankedResult(game *GameState, result *GameResult) {
+	players := ratedPlayers(game)
+	ids := make([]uuid.UUID, 0, len(players))
+	for _, player := range players {
+		ids = append(ids, player.ID)
+	}
+
+	ratings, err := m.repository(game).GetPlayerRatings(context.Background(), ids)
+	if err != nil {
+		logger.Error("Failed to load ratings, skipping rating changes", "error", err, "room_code", game.RoomCode)
+		return
+	}
+
+	results := make([]ratedResult, 0, len(players))
+	for _, player := range players {
+		rating, exists := ratings[player.ID]
+		if !exists {
+			rating = models.DefaultRating
+		}
+		results = append(results, ratedResult{PlayerID: player.ID, Rating: rating, Score: player.Score})
+	}
+	result.RatingChanges = ratingChanges(results)
+}
+
+// recordForfeit charges a player the forfeit penalty for abandoning a ranked game
+func (m *Manager) recordForfeit(game *GameState, player *Player, reason string) {
+	if !game.Settings.Ranked || player.IsBot {
+		return
+	}
+
+	forfeit := &models.GameForfeit{
+		ID:            uuid.New(),
+		GameID:        game.ID,
+		PlayerID:      player.ID,
+		Reason:        reason,
+		RatingPenalty: forfeitPenalty,
+		CreatedAt:     time.Now(),
+	}
+	if err := m.repository(game).PersistForfeit(context.Background(), forfeit); err != nil {
+		logger.Error("Failed to record ranked forfeit",
+			"error", err,
+			"room_code", game.RoomCode,
+			"player_id", player.ID,
+			"reason", reason)
+		return
+	}
+
+	logger.Info("Ranked forfeit recorded",
+		"room_code", game.RoomCode,
+		"player_id", player.ID,
+		"reason", reason,
+		"penalty", forfeitPenalty)
+}
@@ -0,0 +1,218 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Ranked standings
+const (
+	StandingGood     = "good"     // No restrictions
+	StandingWarned   = "warned"   // One more forfeit brings a cooldown
+	StandingCooldown = "cooldown" // Short wait before the next ranked game
+	StandingBanned   = "banned"   // Temporarily barred from ranked play
+)
+
+// rankedStandingWindow is how long a forfeit counts against a player's standing
+const rankedStandingWindow = 30 * 24 * time.Hour
+
+// rankedPenaltyTier is the restriction applied once a player has forfeited
+// Forfeits ranked games within the window. Restrictions run from the latest forfeit.
+type rankedPenaltyTier struct {
+	Forfeits    int
+	Status      string
+	Restriction time.Duration
+}
+
+// rankedPenaltyTiers escalate from a warning to a week-long ban, highest last
+var rankedPenaltyTiers = []rankedPenaltyTier{
+	{Forfeits: 2, Status: StandingWarned},
+	{Forfeits: 3, Status: StandingCooldown, Restriction: 30 * time.Minute},
+	{Forfeits: 4, Status: StandingBanned, Restriction: 24 * time.Hour},
+	{Forfeits: 6, Status: StandingBanned, Restriction: 7 * 24 * time.Hour},
+}
+
+// ErrForfeitNotFound is returned when waiving a forfeit that doesn't exist
+var ErrForfeitNotFound = errors.New("forfeit not found")
+
+// RankedStanding is how a player's recent ranked forfeits limit their ranked play
+type RankedStanding struct {
+	PlayerID        uuid.UUID            `json:"player_id"`
+	Status          string               `json:"status"`
+	Forfeits        int                  `json:"forfeits"`     // Forfeits within the window, waived ones excluded
+	RankedGames     int                  `json:"ranked_games"` // Ranked games played within the window
+	ForfeitRate     float64              `json:"forfeit_rate"`
+	RestrictedUntil *time.Time           `json:"restricted_until,omitempty"`
+	NextExpiry      *time.Time           `json:"next_expiry,omitempty"` // When the oldest counted forfeit stops counting
+	WindowDays      int                  `json:"window_days"`
+	RecentForfeits  []models.GameForfeit `json:"recent_forfeits"`
+}
+
+// Restricted reports whether the player can't start or join ranked games right now
+func (s *RankedStanding) Restricted(now time.Time) bool {
+	return s.RestrictedUntil != nil && now.Before(*s.RestrictedUntil)
+}
+
+// rankedStanding works out a player's standing from their unwaived forfeits
+// within the window, newest first, and the ranked games they played in it
+func rankedStanding(playerID uuid.UUID, forfeits []models.GameForfeit, rankedGames int, now time.Time) *RankedStanding {
+	if forfeits == nil {
+		forfeits = []models.GameForfeit{}
+	}
+	if rankedGames < len(forfeits) {
+		rankedGames = len(forfeits)
+	}
+
+	standing := &RankedStanding{
+		PlayerID:       playerID,
+		Status:         StandingGood,
+		Forfeits:       len(forfeits),
+		RankedGames:    rankedGames,
+		WindowDays:     int(rankedStandingWindow / (24 * time.Hour)),
+		RecentForfeits: forfeits,
+	}
+	if len(forfeits) == 0 {
+		return standing
+	}
+	standing.ForfeitRate = float64(len(forfeits)) / float64(rankedGames)
+
+	expiry := forfeits[len(forfeits)-1].CreatedAt.Add(rankedStandingWindow)
+	standing.NextExpiry = &expiry
+
+	var tier *rankedPenaltyTier
+	for i := range rankedPenaltyTiers {
+		if len(forfeits) >= rankedPenaltyTiers[i].Forfeits {
+			tier = &rankedPenaltyTiers[i]
+		}
+	}
+	if tier == nil {
+		return standing
+	}
+
+	standing.Status = tier.Status
+	if tier.Restriction > 0 {
+		until := forfeits[0].CreatedAt.Add(tier.Restriction)
+		if now.Before(until) {
+			standing.RestrictedUntil = &until
+		} else {
+			// Served; the player stays on notice until forfeits expire
+			standing.Status = StandingWarned
+		}
+	}
+	return standing
+}
+
+// GetRankedRecord loads a player's unwaived forfeits since a time, newest
+// first, and how many ranked games they played in that time
+func (m *Manager) GetRankedRecord(ctx context.Context, playerID uuid.UUID, since time.Time) ([]models.GameForfeit, int, error) {
+	db := m.db.WithContext(ctx)
+
+	var forfeits []models.GameForfeit
+	if err := db.Where("player_id = ? AND created_at >= ? AND waived_at IS NULL", playerID, since).
+		Order("created_at DESC").
+		Find(&forfeits).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load forfeits: %w", err)
+	}
+
+	var games int64
+	if err := db.Model(&models.GameHistory{}).
+		Joins("JOIN game_players ON game_players.game_id = game_histories.game_id").
+		Where("game_players.player_id = ? AND game_histories.ranked = ? AND game_histories.created_at >= ?", playerID, true, since).
+		Count(&games).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count ranked games: %w", err)
+	}
+	return forfeits, int(games), nil
+}
+
+// GetRankedStanding returns a player's current ranked standing
+func (m *Manager) GetRankedStanding(ctx context.Context, playerID uuid.UUID) (*RankedStanding, error) {
+	if m.db == nil {
+		return nil, fmt.Errorf("ranked standing needs a database")
+	}
+
+	now := time.Now()
+	forfeits, games, err := m.GetRankedRecord(ctx, playerID, now.Add(-rankedStandingWindow))
+	if err != nil {
+		return nil, err
+	}
+	return rankedStanding(playerID, forfeits, games, now), nil
+}
+
+// checkRankedStanding keeps restricted players out of ranked games. Lookup
+// failures let the player through rather than blocking ranked play for everyone.
+func (m *Manager) checkRankedStanding(game *GameState, playerID uuid.UUID) error {
+	if !game.Settings.Ranked {
+		return nil
+	}
+
+	now := time.Now()
+	forfeits, games, err := m.repository(game).GetRankedRecord(context.Background(), playerID, now.Add(-rankedStandingWindow))
+	if err != nil {
+		logger.Error("Failed to check ranked standing", "error", err, "room_code", game.RoomCode, "player_id", playerID)
+		return nil
+	}
+
+	standing := rankedStanding(playerID, forfeits, games, now)
+	if !standing.Restricted(now) {
+		return nil
+	}
+	return &GameError{
+		Code:    ErrCodeRankedRestricted,
+		Message: fmt.Sprintf("ranked play is restricted until %s after repeated forfeits", standing.RestrictedUntil.UTC().Format(time.RFC3339)),
+		Details: map[string]interface{}{
+			"player_id":        playerID,
+			"status":           standing.Status,
+			"forfeits":         standing.Forfeits,
+			"restricted_until": standing.RestrictedUntil,
+		},
+	}
+}
+
+// WaiveForfeit settles an appeal in the player's favour: the forfeit stops
+// counting towards their ranked standing. The rating penalty is not refunded.
+func (m *Manager) WaiveForfeit(ctx context.Context, forfeitID uuid.UUID, reason string, waivedBy *uuid.UUID) (*models.GameForfeit, error) {
+	if m.db == nil {
+		return nil, fmt.Errorf("waiving forfeits needs a database")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if len(reason) > maxReportReasonLength {
+		return nil, fmt.Errorf("reason must be at most %d characters", maxReportReasonLength)
+	}
+
+	var forfeit models.GameForfeit
+	if err := m.db.WithContext(ctx).First(&forfeit, "id = ?", forfeitID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrForfeitNotFound
+		}
+		return nil, fmt.Errorf("failed to load forfeit: %w", err)
+	}
+	if forfeit.WaivedAt != nil {
+		return &forfeit, nil
+	}
+
+	now := time.Now()
+	forfeit.WaivedAt = &now
+	forfeit.WaivedBy = waivedBy
+	forfeit.WaiveReason = reason
+	if err := m.db.WithContext(ctx).Model(&forfeit).
+		Select("waived_at", "waived_by", "waive_reason").
+		Updates(&forfeit).Error; err != nil {
+		return nil, fmt.Errorf("failed to waive forfeit: %w", err)
+	}
+
+	logger.Info("Ranked forfeit waived",
+		"forfeit_id", forfeit.ID,
+		"player_id", forfeit.PlayerID,
+		"waived_by", waivedBy)
+	return &forfeit, nil
+}
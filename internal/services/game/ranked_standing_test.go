@@ -0,0 +1,70 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// forfeitsAgo builds forfeits that happened the given durations ago, newest first
+func forfeitsAgo(now time.Time, ago ...time.Duration) []models.GameForfeit {
+	forfeits := make([]models.GameForfeit, 0, len(ago))
+	for _, d := range ago {
+		forfeits = append(forfeits, models.GameForfeit{ID: uuid.New(), CreatedAt: now.Add(-d)})
+	}
+	return forfeits
+}
+
+func TestRankedStandingTiers(t *testing.T) {
+	now := time.Now()
+	playerID := uuid.New()
+
+	standing := rankedStanding(playerID, nil, 5, now)
+	assert.Equal(t, StandingGood, standing.Status)
+	assert.Zero(t, standing.ForfeitRate)
+	assert.NotNil(t, standing.RecentForfeits)
+
+	standing = rankedStanding(playerID, forfeitsAgo(now, time.Hour), 4, now)
+	assert.Equal(t, StandingGood, standing.Status)
+	assert.InDelta(t, 0.25, standing.ForfeitRate, 0.001)
+
+	standing = rankedStanding(playerID, forfeitsAgo(now, time.Hour, 2*time.Hour), 4, now)
+	assert.Equal(t, StandingWarned, standing.Status)
+	assert.False(t, standing.Restricted(now))
+
+	standing = rankedStanding(playerID, forfeitsAgo(now, time.Minute, time.Hour, 2*time.Hour), 4, now)
+	assert.Equal(t, StandingCooldown, standing.Status)
+	require.NotNil(t, standing.RestrictedUntil)
+	assert.WithinDuration(t, now.Add(29*time.Minute), *standing.RestrictedUntil, time.Second)
+
+	standing = rankedStanding(playerID, forfeitsAgo(now, time.Hour, 2*time.Hour, 3*time.Hour, 4*time.Hour), 4, now)
+	assert.Equal(t, StandingBanned, standing.Status)
+	assert.True(t, standing.Restricted(now))
+	assert.WithinDuration(t, now.Add(23*time.Hour), *standing.RestrictedUntil, time.Second)
+	assert.Equal(t, 1.0, standing.ForfeitRate)
+
+	standing = rankedStanding(playerID, forfeitsAgo(now, time.Hour, 2*time.Hour, 3*time.Hour, 4*time.Hour, 5*time.Hour, 6*time.Hour), 10, now)
+	assert.WithinDuration(t, now.Add(7*24*time.Hour-time.Hour), *standing.RestrictedUntil, time.Second)
+}
+
+func TestRankedStandingServedRestriction(t *testing.T) {
+	now := time.Now()
+	oldest := 10 * 24 * time.Hour
+
+	standing := rankedStanding(uuid.New(), forfeitsAgo(now, 2*time.Hour, 3*time.Hour, oldest), 6, now)
+	assert.Equal(t, StandingWarned, standing.Status)
+	assert.Nil(t, standing.RestrictedUntil)
+	require.NotNil(t, standing.NextExpiry)
+	assert.WithinDuration(t, now.Add(rankedStandingWindow-oldest), *standing.NextExpiry, time.Second)
+}
+
+func TestCheckRankedStandingSkipsCasualGames(t *testing.T) {
+	m := &Manager{}
+	game := &GameState{RoomCode: "CASUAL", Settings: DefaultGameSettings()}
+	assert.NoError(t, m.checkRankedStanding(game, uuid.New()))
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatingChangesEqualRatings(t *testing.T) {
+	winner, loser := uuid.New(), uuid.New()
+
+	changes := ratingChanges([]ratedResult{
+		{PlayerID: winner, Rating: 1200, Score: 30},
+		{PlayerID: loser, Rating: 1200, Score: 20},
+	})
+
+	assert.Equal(t, 16, changes[winner])
+	assert.Equal(t, -16, changes[loser])
+}
+
+func TestRatingChangesFavouriteGainsLess(t *testing.T) {
+	favourite, underdog := uuid.New(), uuid.New()
+
+	changes := ratingChanges([]ratedResult{
+		{PlayerID: favourite, Rating: 1600, Score: 30},
+		{PlayerID: underdog, Rating: 1200, Score: 20},
+	})
+	assert.Equal(t, 3, changes[favourite])
+
+	upset := ratingChanges([]ratedResult{
+		{PlayerID: favourite, Rating: 1600, Score: 20},
+		{PlayerID: underdog, Rating: 1200, Score: 30},
+	})
+	assert.Equal(t, 29, upset[underdog])
+}
+
+func TestRatingChangesSplitsKAcrossTable(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+
+	changes := ratingChanges([]ratedResult{
+		{PlayerID: ids[0], Rating: 1200, Score: 30},
+		{PlayerID: ids[1], Rating: 1200, Score: 25},
+		{PlayerID: ids[2], Rating: 1200, Score: 25},
+		{PlayerID: ids[3], Rating: 1200, Score: 10},
+	})
+
+	// Beating everyone is worth the same as a head-to-head win
+	assert.Equal(t, 16, changes[ids[0]])
+	assert.Equal(t, 0, changes[ids[1]])
+	assert.Equal(t, 0, changes[ids[2]])
+	assert.Equal(t, -16, changes[ids[3]])
+}
+
+func TestRatingChangesNeedsOpponents(t *testing.T) {
+	assert.Empty(t, ratingChanges([]ratedResult{{PlayerID: uuid.New(), Rating: 1200, Score: 30}}))
+}
+
+func TestRatedPlayersExcludesBotsAndReplaced(t *testing.T) {
+	gs := roomWith(2, 1, GameSettings{Ranked: true})
+	replaced := uuid.New()
+	gs.Players[replaced] = &Player{ID: replaced, WasReplaced: true}
+
+	players := ratedPlayers(gs)
+	assert.Len(t, players, 2)
+	for _, player := range players {
+		assert.False(t, player.IsBot)
+		assert.False(t, player.WasReplaced)
+	}
+}
+
+func TestCheckRankedHumans(t *testing.T) {
+	assert.NoError(t, checkRankedHumans(roomWith(1, 2, GameSettings{})))
+	assert.NoError(t, checkRankedHumans(roomWith(2, 1, GameSettings{Ranked: true})))
+
+	err := checkRankedHumans(roomWith(1, 2, GameSettings{Ranked: true}))
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeNotEnoughHumans}))
+}
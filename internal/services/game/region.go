@@ -0,0 +1,105 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// roomHomePrefix prefixes the Redis key naming the instance that hosts a room
+const roomHomePrefix = "room:home:"
+
+// roomHomeLookupTimeout bounds the Redis lookup made for a room this instance doesn't host
+const roomHomeLookupTimeout = 2 * time.Second
+
+// Instance describes a server instance: where it runs and how clients reach it
+type Instance struct {
+	InstanceID string `json:"instance_id"`
+	Region     string `json:"region,omitempty"`
+	URL        string `json:"url,omitempty"` // Public base URL, empty when clients can't address the instance directly
+}
+
+// RoomHome is the instance hosting a room. Local is set when it is this one.
+type RoomHome struct {
+	Instance
+	Local bool `json:"local"`
+}
+
+// SetDeployment labels this instance with its region and public URL. Call
+// it before serving: the values are read without locking.
+func (m *Manager) SetDeployment(region, publicURL string) {
+	m.region = region
+	m.publicURL = publicURL
+}
+
+// Instance describes this instance
+func (m *Manager) Instance() Instance {
+	return Instance{InstanceID: m.instanceID, Region: m.region, URL: m.publicURL}
+}
+
+func roomHomeKey(roomCode string) string {
+	return roomHomePrefix + roomCode
+}
+
+// storeRoomHome records this instance as the room's host, for as long as its snapshot lives
+func (m *Manager) storeRoomHome(ctx context.Context, roomCode string, expiration time.Duration) error {
+	data, err := json.Marshal(m.Instance())
+	if err != nil {
+		return fmt.Errorf("failed to marshal room home: %w", err)
+	}
+	if err := m.redisClient.Set(ctx, roomHomeKey(roomCode), data, expiration).Err(); err != nil {
+		return fmt.Errorf("failed to store room home: %w", err)
+	}
+	return nil
+}
+
+// FindRoomHome tells which instance hosts a room, so players can be sent
+// there instead of hopping through the relay. It returns nil when no
+// instance is known to host it.
+func (m *Manager) FindRoomHome(ctx context.Context, roomCode string) (*RoomHome, error) {
+	if m.getGame(roomCode) != nil {
+		return &RoomHome{Instance: m.Instance(), Local: true}, nil
+	}
+	if m.redisClient == nil {
+		return nil, nil
+	}
+
+	data, err := m.redisClient.Get(ctx, roomHomeKey(roomCode)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load room home: %w", err)
+	}
+	var home RoomHome
+	if err := json.Unmarshal(data, &home.Instance); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal room home: %w", err)
+	}
+	home.Local = home.InstanceID == m.instanceID
+	return &home, nil
+}
+
+// roomNotHere is the error for a room missing from this instance: it points
+// to the instance hosting it when there is one
+func (m *Manager) roomNotHere(roomCode string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), roomHomeLookupTimeout)
+	defer cancel()
+
+	home, err := m.FindRoomHome(ctx, roomCode)
+	if err != nil {
+		logger.Warn("Failed to look up room home", "error", err, "room_code", roomCode)
+	}
+	if home == nil || home.Local {
+		return fmt.Errorf("game not found")
+	}
+	return &GameError{
+		Code:    ErrCodeRoomElsewhere,
+		Message: "this room is hosted on another server",
+		Details: map[string]interface{}{"region": home.Region, "url": home.URL, "instance_id": home.InstanceID},
+	}
+}
@@ -0,0 +1,26 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindRoomHome(t *testing.T) {
+	m := &Manager{games: map[string]*GameState{"LOCAL": {RoomCode: "LOCAL"}}, instanceID: "api-1"}
+	m.SetDeployment("eu-west", "https://eu.dixit.example")
+
+	home, err := m.FindRoomHome(context.Background(), "LOCAL")
+	require.NoError(t, err)
+	require.NotNil(t, home)
+	assert.True(t, home.Local)
+	assert.Equal(t, Instance{InstanceID: "api-1", Region: "eu-west", URL: "https://eu.dixit.example"}, home.Instance)
+
+	// Without Redis, rooms elsewhere can't be found
+	home, err = m.FindRoomHome(context.Background(), "AWAY")
+	require.NoError(t, err)
+	assert.Nil(t, home)
+	assert.EqualError(t, m.roomNotHere("AWAY"), "game not found")
+}
@@ -0,0 +1,184 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+	"dixitme/internal/services/bot"
+
+	"github.com/google/uuid"
+)
+
+// rematchCodeAttempts bounds the search for a free room code for a rematch
+const rematchCodeAttempts = 5
+
+// RematchPayload points the players of a finished game to its rematch lobby
+type RematchPayload struct {
+	RoomCode         string    `json:"room_code"`
+	PreviousRoomCode string    `json:"previous_room_code"`
+	RequestedBy      uuid.UUID `json:"requested_by"`
+}
+
+// Rematch opens a new lobby for the table of a finished game: the humans who
+// saw it out and the bots still seated, with scores, hands and deck reset and
+// the same settings. Room codes stay with their game, so the lobby gets a new
+// code; the finished room links to it, and joining through the old code lands
+// in the rematch. Asking again returns the same lobby.
+func (m *Manager) Rematch(roomCode string, playerID uuid.UUID) (*GameState, error) {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return nil, fmt.Errorf("game not found")
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if game.Status != models.GameStatusCompleted {
+		return nil, fmt.Errorf("a rematch can only follow a finished game")
+	}
+	requester, exists := game.Players[playerID]
+	if !exists || requester.IsBot {
+		return nil, fmt.Errorf("player not in game")
+	}
+	if game.RematchRoomCode != "" {
+		if rematch := m.getGame(game.RematchRoomCode); rematch != nil {
+			return rematch, nil
+		}
+		return nil, fmt.Errorf("the rematch has already closed")
+	}
+
+	// Everyone who saw the game out comes back, in seat order, and so does
+	// the player asking even if a bot took their seat
+	var humans, bots []*Player
+	for _, player := range game.playersBySeat() {
+		switch {
+		case player.IsBot && !player.WasReplaced:
+			bots = append(bots, player)
+		case !player.IsBot && (!player.WasReplaced || player.ID == playerID):
+			humans = append(humans, player)
+		}
+	}
+	host := requester
+	if previous, exists := game.Players[game.HostID]; exists && !previous.WasReplaced {
+		host = previous
+	}
+
+	settings := game.Settings
+	rematch, err := m.createRematchLobby(host, CreateGameOptions{Sandbox: game.Sandbox, Settings: &settings, Packs: game.Packs, DeckID: game.DeckID})
+	if err != nil {
+		return nil, err
+	}
+
+	rematch.mu.Lock()
+	rematch.PasswordProtected = game.PasswordProtected
+	rematch.passwordHash = game.passwordHash
+	for _, player := range humans {
+		if player.ID != host.ID {
+			m.seatRematchPlayer(rematch, player)
+		}
+	}
+	for _, player := range bots {
+		m.seatRematchPlayer(rematch, player)
+	}
+	m.cacheGameState(rematch)
+	rematch.mu.Unlock()
+
+	game.RematchRoomCode = rematch.RoomCode
+	m.cacheGameState(game)
+
+	metrics.GetCounter(metrics.Name("rematches_total", "sandbox", fmt.Sprint(game.Sandbox))).Inc()
+	logger.Info("Rematch created",
+		"room_code", roomCode,
+		"rematch_room_code", rematch.RoomCode,
+		"requested_by", playerID,
+		"players", len(rematch.Players))
+
+	payload := RematchPayload{RoomCode: rematch.RoomCode, PreviousRoomCode: roomCode, RequestedBy: playerID}
+	m.BroadcastToGame(game, MessageTypeRematch, payload)
+	m.SendSystemMessage(roomCode, i18n.Msg("{1} started a rematch", requester.Name))
+
+	rematch.mu.Lock()
+	m.BroadcastToGame(rematch, MessageTypeGameState, GameStatePayload{GameState: rematch})
+	rematch.mu.Unlock()
+	return rematch, nil
+}
+
+// createRematchLobby creates the rematch room under a fresh code, hosted by host
+func (m *Manager) createRematchLobby(host *Player, opts CreateGameOptions) (*GameState, error) {
+	for attempt := 0; ; attempt++ {
+		roomCode, err := GenerateRoomCode()
+		if err != nil {
+			return nil, err
+		}
+		rematch, err := m.CreateGameWithOptions(roomCode, host.ID, host.Name, opts)
+		if errors.Is(err, &GameError{Code: ErrCodeRoomCodeTaken}) && attempt+1 < rematchCodeAttempts {
+			continue
+		}
+		return rematch, err
+	}
+}
+
+// seatRematchPlayer brings a player of the finished game into its rematch
+// with a clean slate. Bots keep their name and level. A player who can't be
+// persisted is left out. Callers hold the rematch's lock.
+func (m *Manager) seatRematchPlayer(rematch *GameState, previous *Player) {
+	player := &Player{
+		ID:           previous.ID,
+		Name:         previous.Name,
+		Position:     len(rematch.Players) + 1,
+		Hand:         make([]int, 0),
+		IsActive:     true,
+		IsBot:        previous.IsBot,
+		BotLevel:     previous.BotLevel,
+		LastActivity: time.Now(),
+	}
+	dbPlayer := &models.Player{ID: player.ID, Name: player.Name, Type: models.PlayerTypeHuman, AuthType: models.AuthTypeGuest}
+	if player.IsBot {
+		player.IsConnected = true // Bots are always "connected"
+		dbPlayer = &models.Player{ID: player.ID, Name: player.Name, Type: models.PlayerTypeBot, BotLevel: player.BotLevel}
+		// The AI is found by the seat's ID, and may still be registered from the finished game
+		bot.GetBotManager().RestoreBot(player.ID, player.Name, bot.BotDifficulty(player.BotLevel), rematch.ID).SetGameID(rematch.ID)
+	} else {
+		player.Connection = GetPlayerConnection(player.ID)
+		player.IsConnected = player.Connection != nil
+	}
+
+	m.assignToken(rematch, player)
+	repository := m.repository(rematch)
+	if err := repository.PersistPlayer(context.Background(), dbPlayer); err != nil {
+		logger.Error("Failed to seat player in rematch", "error", err, "room_code", rematch.RoomCode, "player_id", player.ID)
+		return
+	}
+	if err := repository.PersistGamePlayer(context.Background(), rematch.ID, player); err != nil {
+		logger.Error("Failed to seat player in rematch", "error", err, "room_code", rematch.RoomCode, "player_id", player.ID)
+		return
+	}
+
+	rematch.Players[player.ID] = player
+	rematch.joinRotation(player.ID)
+	if !player.IsBot {
+		m.sendResumeToken(rematch, player.ID)
+	}
+}
+
+// playersBySeat lists every player who had a seat, in seat order. Callers hold the game lock.
+func (gs *GameState) playersBySeat() []*Player {
+	players := make([]*Player, 0, len(gs.Players))
+	for _, player := range gs.Players {
+		players = append(players, player)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Position != players[j].Position {
+			return players[i].Position < players[j].Position
+		}
+		return players[i].ID.String() < players[j].ID.String()
+	})
+	return players
+}
@@ -0,0 +1,50 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+	"dixitme/internal/services/bot"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRematchReseatsTheTable(t *testing.T) {
+	m, gs, botID := takeoverGame(t)
+	alice, bob := gs.StorytellerOrder[0], gs.StorytellerOrder[1]
+	gone := uuid.New()
+	gs.Players[gone] = &Player{ID: gone, Name: "Gone", Position: 4, WasReplaced: true}
+	gs.Players[alice].Score = 30
+
+	_, err := m.Rematch("KICK", alice)
+	assert.EqualError(t, err, "a rematch can only follow a finished game")
+
+	gs.Status = models.GameStatusCompleted
+	_, err = m.Rematch("KICK", uuid.New())
+	assert.EqualError(t, err, "player not in game")
+
+	rematch, err := m.Rematch("KICK", bob)
+	require.NoError(t, err)
+	assert.NotEqual(t, "KICK", rematch.RoomCode)
+	assert.Equal(t, rematch.RoomCode, gs.RematchRoomCode)
+	assert.Equal(t, models.GameStatusWaiting, rematch.Status)
+	assert.Equal(t, alice, rematch.HostID, "the host keeps hosting")
+	assert.Equal(t, []uuid.UUID{alice, bob, botID}, rematch.StorytellerOrder)
+	assert.NotContains(t, rematch.Players, gone)
+	assert.Zero(t, rematch.Players[alice].Score)
+	assert.True(t, rematch.Players[botID].IsBot)
+	require.NotNil(t, bot.GetBotManager().GetBot(botID), "the bot's turns find it by its seat")
+	assert.Equal(t, rematch.ID, bot.GetBotManager().GetBot(botID).GameID)
+
+	again, err := m.Rematch("KICK", alice)
+	require.NoError(t, err)
+	assert.Same(t, rematch, again)
+
+	carol := uuid.New()
+	joined, err := m.JoinGame("KICK", carol, "Carol")
+	require.NoError(t, err)
+	assert.Same(t, rematch, joined, "the old code leads to the rematch")
+	assert.Contains(t, rematch.Players, carol)
+}
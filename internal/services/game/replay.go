@@ -0,0 +1,210 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrReplayNotFound is returned when a room has no recorded game to replay
+var ErrReplayNotFound = errors.New("replay not found")
+
+// Replay event types, in the order they happen within a round
+const (
+	ReplayRoundStarted  = "round_started"  // PlayerID is the storyteller
+	ReplayClueGiven     = "clue_given"     // The storyteller's clue and card
+	ReplayCardSubmitted = "card_submitted" // A player's card for the clue
+	ReplayVotingStarted = "voting_started" // Table holds the cards in the order they were revealed
+	ReplayVoteCast      = "vote_cast"      // A player's vote
+	ReplayRoundScored   = "round_scored"   // Points earned in the round, and scores after it
+	ReplayGameEnded     = "game_ended"     // Final scores; PlayerID is the winner, if any
+)
+
+// GameReplay is the ordered move log of a finished game
+type GameReplay struct {
+	GameID   uuid.UUID         `json:"game_id"`
+	RoomCode string            `json:"room_code"`
+	Status   models.GameStatus `json:"status"`
+	Players  []ReplayPlayer    `json:"players"` // In seat order
+	Events   []ReplayEvent     `json:"events"`  // In the order they were played
+}
+
+// ReplayPlayer is a seat at the replayed table
+type ReplayPlayer struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	IsBot    bool      `json:"is_bot"`
+	Position int       `json:"position"`
+	Team     int       `json:"team,omitempty"`
+}
+
+// ReplayEvent is one move of a replay
+type ReplayEvent struct {
+	Seq         int               `json:"seq"`
+	Type        string            `json:"type"`
+	RoundNumber int               `json:"round_number"`
+	PlayerID    *uuid.UUID        `json:"player_id,omitempty"`
+	CardID      int               `json:"card_id,omitempty"`
+	Clue        string            `json:"clue,omitempty"`
+	Table       []int             `json:"table,omitempty"`
+	Points      map[uuid.UUID]int `json:"points,omitempty"`
+	Scores      map[uuid.UUID]int `json:"scores,omitempty"`
+	At          time.Time         `json:"at"`
+}
+
+// replayDetails holds the fields of an event stored as JSON
+type replayDetails struct {
+	Table  []int             `json:"table,omitempty"`
+	Points map[uuid.UUID]int `json:"points,omitempty"`
+	Scores map[uuid.UUID]int `json:"scores,omitempty"`
+}
+
+// recordReplay numbers a move and persists it to the game's replay log.
+// Callers hold the game lock. A failed write is logged rather than failing the move.
+func (m *Manager) recordReplay(game *GameState, event ReplayEvent) {
+	game.replaySeq++
+	record := &models.ReplayEvent{
+		ID:          uuid.New(),
+		GameID:      game.ID,
+		Seq:         game.replaySeq,
+		RoundNumber: game.RoundNumber,
+		Type:        event.Type,
+		PlayerID:    event.PlayerID,
+		CardID:      event.CardID,
+		Clue:        event.Clue,
+		CreatedAt:   time.Now(),
+	}
+	if len(event.Table) > 0 || len(event.Points) > 0 || len(event.Scores) > 0 {
+		details, err := json.Marshal(replayDetails{Table: event.Table, Points: event.Points, Scores: event.Scores})
+		if err != nil {
+			logger.Error("Failed to encode replay event", "error", err, "room_code", game.RoomCode, "type", event.Type)
+			return
+		}
+		record.Details = string(details)
+	}
+
+	if err := m.repository(game).PersistReplayEvent(context.Background(), record); err != nil {
+		logger.Error("Failed to persist replay event", "error", err, "room_code", game.RoomCode, "type", event.Type)
+	}
+}
+
+// currentScores copies every player's score
+func currentScores(game *GameState) map[uuid.UUID]int {
+	scores := make(map[uuid.UUID]int, len(game.Players))
+	for playerID, player := range game.Players {
+		scores[playerID] = player.Score
+	}
+	return scores
+}
+
+// replayHook records the end of each round and of the game
+type replayHook struct {
+	NopLifecycleHook
+	manager *Manager
+}
+
+func (h replayHook) OnRoundCompleted(game *GameState, _ *Round, points map[uuid.UUID]int) {
+	h.manager.recordReplay(game, ReplayEvent{Type: ReplayRoundScored, Points: points, Scores: currentScores(game)})
+}
+
+func (h replayHook) OnGameCompleted(game *GameState, result *GameResult) {
+	event := ReplayEvent{Type: ReplayGameEnded, Scores: currentScores(game)}
+	if result != nil && result.WinnerID != uuid.Nil {
+		winnerID := result.WinnerID
+		event.PlayerID = &winnerID
+	}
+	h.manager.recordReplay(game, event)
+}
+
+// PersistReplayEvent saves a move of a game's replay log
+func (m *Manager) PersistReplayEvent(ctx context.Context, event *models.ReplayEvent) error {
+	if err := m.db.WithContext(ctx).Create(event).Error; err != nil {
+		return fmt.Errorf("failed to persist replay event: %w", err)
+	}
+	return nil
+}
+
+// GetReplay returns the move log of the last game in a room, once it is over.
+// Games played before moves were recorded have no events.
+func (m *Manager) GetReplay(ctx context.Context, roomCode string) (*GameReplay, error) {
+	if m.db == nil {
+		return nil, ErrReplayNotFound
+	}
+	db := m.db.WithContext(ctx)
+
+	var record models.Game
+	if err := db.Unscoped().Where("room_code = ?", roomCode).Order("created_at DESC").First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReplayNotFound
+		}
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+	if record.Status != models.GameStatusCompleted && record.Status != models.GameStatusAbandoned {
+		return nil, ErrGameNotOver
+	}
+
+	var seats []models.GamePlayer
+	if err := db.Preload("Player", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+		Where("game_id = ?", record.ID).Find(&seats).Error; err != nil {
+		return nil, fmt.Errorf("failed to load players: %w", err)
+	}
+
+	var rows []models.ReplayEvent
+	if err := db.Where("game_id = ?", record.ID).Order("seq ASC, created_at ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load replay events: %w", err)
+	}
+	return buildReplay(record, seats, rows), nil
+}
+
+// buildReplay assembles a replay from its stored rows
+func buildReplay(record models.Game, seats []models.GamePlayer, rows []models.ReplayEvent) *GameReplay {
+	replay := &GameReplay{
+		GameID:   record.ID,
+		RoomCode: record.RoomCode,
+		Status:   record.Status,
+		Players:  make([]ReplayPlayer, 0, len(seats)),
+		Events:   make([]ReplayEvent, 0, len(rows)),
+	}
+	for _, seat := range seats {
+		replay.Players = append(replay.Players, ReplayPlayer{
+			ID:       seat.PlayerID,
+			Name:     seat.Player.Name,
+			IsBot:    seat.Player.Type == models.PlayerTypeBot,
+			Position: seat.Position,
+			Team:     seat.Team,
+		})
+	}
+	sort.SliceStable(replay.Players, func(i, j int) bool {
+		return replay.Players[i].Position < replay.Players[j].Position
+	})
+
+	for _, row := range rows {
+		event := ReplayEvent{
+			Seq:         row.Seq,
+			Type:        row.Type,
+			RoundNumber: row.RoundNumber,
+			PlayerID:    row.PlayerID,
+			CardID:      row.CardID,
+			Clue:        row.Clue,
+			At:          row.CreatedAt,
+		}
+		if row.Details != "" {
+			var details replayDetails
+			if err := json.Unmarshal([]byte(row.Details), &details); err != nil {
+				logger.Warn("Ignoring malformed replay event details", "event_id", row.ID, "error", err)
+			}
+			event.Table, event.Points, event.Scores = details.Table, details.Points, details.Scores
+		}
+		replay.Events = append(replay.Events, event)
+	}
+	return replay
+}
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReplay(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	seats := []models.GamePlayer{
+		{PlayerID: bob, Position: 2, Player: models.Player{Name: "Bob", Type: models.PlayerTypeBot}},
+		{PlayerID: alice, Position: 1, Player: models.Player{Name: "Alice"}},
+	}
+	rows := []models.ReplayEvent{
+		{Seq: 1, RoundNumber: 1, Type: ReplayRoundStarted, PlayerID: &alice},
+		{Seq: 2, RoundNumber: 1, Type: ReplayVotingStarted, Details: `{"table":[12,7]}`},
+		{Seq: 3, RoundNumber: 1, Type: ReplayRoundScored, Details: `{"points":{"` + alice.String() + `":3},"scores":{"` + alice.String() + `":5}}`},
+		{Seq: 4, RoundNumber: 1, Type: ReplayGameEnded, Details: `not json`},
+	}
+
+	replay := buildReplay(models.Game{RoomCode: "ABC123", Status: models.GameStatusCompleted}, seats, rows)
+
+	require.Len(t, replay.Players, 2)
+	assert.Equal(t, "Alice", replay.Players[0].Name, "players are in seat order")
+	assert.True(t, replay.Players[1].IsBot)
+
+	require.Len(t, replay.Events, 4)
+	assert.Equal(t, &alice, replay.Events[0].PlayerID)
+	assert.Equal(t, []int{12, 7}, replay.Events[1].Table)
+	assert.Equal(t, map[uuid.UUID]int{alice: 3}, replay.Events[2].Points)
+	assert.Equal(t, map[uuid.UUID]int{alice: 5}, replay.Events[2].Scores)
+	assert.Equal(t, ReplayGameEnded, replay.Events[3].Type, "malformed details don't drop the event")
+}
+
+func TestReplayEventsAreNumberedInOrder(t *testing.T) {
+	m := NewEphemeralManager()
+	alice := uuid.New()
+	game := &GameState{Sandbox: true, RoundNumber: 1, Players: map[uuid.UUID]*Player{alice: {ID: alice, Score: 3}}}
+
+	m.recordReplay(game, ReplayEvent{Type: ReplayRoundStarted, PlayerID: &alice})
+	replayHook{manager: m}.OnRoundCompleted(game, nil, map[uuid.UUID]int{alice: 3})
+	replayHook{manager: m}.OnGameCompleted(game, &GameResult{WinnerID: alice})
+	assert.Equal(t, 3, game.replaySeq)
+
+	snapshot, err := encodeGameSnapshot(game)
+	require.NoError(t, err)
+	restored, err := decodeGameSnapshot(snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, 3, restored.replaySeq, "numbering carries on after a restart")
+}
@@ -0,0 +1,155 @@
+package game
+
+import (
+	"context"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// GameRepository is the durable storage gameplay is recorded to.
+// The Manager itself is the database-backed implementation; sandbox games use noopRepository.
+type GameRepository interface {
+	PersistGame(ctx context.Context, game *GameState) error
+	PersistPlayer(ctx context.Context, player *models.Player) error
+	PersistGamePlayer(ctx context.Context, gameID uuid.UUID, player *Player) error
+	RemoveGamePlayer(ctx context.Context, gameID, playerID uuid.UUID) error
+	UpdatePlayerTeams(ctx context.Context, gameID uuid.UUID, teams map[uuid.UUID]int) error
+	DeleteGameRecord(ctx context.Context, roomCode string) error
+	UpdateGameStatus(ctx context.Context, gameID uuid.UUID, status models.GameStatus) error
+	UpdateGamePace(ctx context.Context, gameID uuid.UUID, pace string) error
+	UpdateGameVisibility(ctx context.Context, gameID uuid.UUID, private bool) error
+	PersistRound(ctx context.Context, gameID uuid.UUID, round *Round) error
+	UpdateRound(ctx context.Context, round *Round) error
+	PersistCardSubmission(ctx context.Context, roundID, playerID uuid.UUID, cardID int) error
+	PersistVote(ctx context.Context, roundID uuid.UUID, vote *Vote) error
+	PersistGameCompletion(ctx context.Context, result *GameResult) error
+	PersistForfeit(ctx context.Context, forfeit *models.GameForfeit) error
+	GetPlayerRatings(ctx context.Context, playerIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	PersistRoundScores(ctx context.Context, gameID uuid.UUID, sample RoundScoreSample) error
+	PersistChatMessage(ctx context.Context, chatMessage *models.ChatMessage) error
+	GetChatMessages(ctx context.Context, gameID, viewerID uuid.UUID, phase string, limit int) ([]models.ChatMessage, error)
+	PersistPlayerReport(ctx context.Context, report *models.PlayerReport) error
+	PersistGameReport(ctx context.Context, report *models.GameReport) error
+	PersistExperimentAssignments(ctx context.Context, gameID uuid.UUID, experiments []string) error
+	GetPreferredToken(ctx context.Context, playerID uuid.UUID) (string, error)
+	PersistBotDecision(ctx context.Context, decision *models.BotDecision) error
+	GetRankedRecord(ctx context.Context, playerID uuid.UUID, since time.Time) ([]models.GameForfeit, int, error)
+	GetCardTexts(ctx context.Context, cardIDs []int) (map[int]CardText, error)
+	GetTableCards(ctx context.Context, tableKey string, games int) ([]int, error)
+	PersistTableCards(ctx context.Context, record *models.TableCardHistory) error
+	UpdateGameShuffle(ctx context.Context, gameID uuid.UUID, commitment string, avoided, expansion []int) error
+	UpdateGameRules(ctx context.Context, gameID uuid.UUID, rules GameRules) error
+	GetExpansionCards(ctx context.Context, slugs []string) (map[string][]int, error)
+	GetPackCards(ctx context.Context, slugs []string) (map[string][]int, error)
+	GetCustomDeckCards(ctx context.Context, deckID uuid.UUID) ([]int, error)
+	PersistReplayEvent(ctx context.Context, event *models.ReplayEvent) error
+}
+
+// noopRepository discards every write so sandbox games never touch the database
+type noopRepository struct{}
+
+func (noopRepository) PersistGame(ctx context.Context, game *GameState) error         { return nil }
+func (noopRepository) PersistPlayer(ctx context.Context, player *models.Player) error { return nil }
+func (noopRepository) PersistGamePlayer(ctx context.Context, gameID uuid.UUID, player *Player) error {
+	return nil
+}
+func (noopRepository) RemoveGamePlayer(ctx context.Context, gameID, playerID uuid.UUID) error {
+	return nil
+}
+func (noopRepository) UpdatePlayerTeams(ctx context.Context, gameID uuid.UUID, teams map[uuid.UUID]int) error {
+	return nil
+}
+func (noopRepository) DeleteGameRecord(ctx context.Context, roomCode string) error { return nil }
+func (noopRepository) UpdateGameStatus(ctx context.Context, gameID uuid.UUID, status models.GameStatus) error {
+	return nil
+}
+func (noopRepository) UpdateGamePace(ctx context.Context, gameID uuid.UUID, pace string) error {
+	return nil
+}
+func (noopRepository) UpdateGameVisibility(ctx context.Context, gameID uuid.UUID, private bool) error {
+	return nil
+}
+func (noopRepository) PersistRound(ctx context.Context, gameID uuid.UUID, round *Round) error {
+	return nil
+}
+func (noopRepository) UpdateRound(ctx context.Context, round *Round) error { return nil }
+func (noopRepository) PersistCardSubmission(ctx context.Context, roundID, playerID uuid.UUID, cardID int) error {
+	return nil
+}
+func (noopRepository) PersistVote(ctx context.Context, roundID uuid.UUID, vote *Vote) error {
+	return nil
+}
+func (noopRepository) PersistGameCompletion(ctx context.Context, result *GameResult) error {
+	return nil
+}
+func (noopRepository) PersistForfeit(ctx context.Context, forfeit *models.GameForfeit) error {
+	return nil
+}
+func (noopRepository) GetPlayerRatings(ctx context.Context, playerIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	return map[uuid.UUID]int{}, nil
+}
+func (noopRepository) PersistRoundScores(ctx context.Context, gameID uuid.UUID, sample RoundScoreSample) error {
+	return nil
+}
+func (noopRepository) PersistChatMessage(ctx context.Context, chatMessage *models.ChatMessage) error {
+	return nil
+}
+func (noopRepository) GetChatMessages(ctx context.Context, gameID, viewerID uuid.UUID, phase string, limit int) ([]models.ChatMessage, error) {
+	return []models.ChatMessage{}, nil
+}
+func (noopRepository) PersistPlayerReport(ctx context.Context, report *models.PlayerReport) error {
+	return nil
+}
+func (noopRepository) PersistGameReport(ctx context.Context, report *models.GameReport) error {
+	return nil
+}
+func (noopRepository) PersistExperimentAssignments(ctx context.Context, gameID uuid.UUID, experiments []string) error {
+	return nil
+}
+func (noopRepository) GetPreferredToken(ctx context.Context, playerID uuid.UUID) (string, error) {
+	return "", nil
+}
+func (noopRepository) PersistBotDecision(ctx context.Context, decision *models.BotDecision) error {
+	return nil
+}
+func (noopRepository) GetRankedRecord(ctx context.Context, playerID uuid.UUID, since time.Time) ([]models.GameForfeit, int, error) {
+	return nil, 0, nil
+}
+func (noopRepository) GetCardTexts(ctx context.Context, cardIDs []int) (map[int]CardText, error) {
+	return nil, nil
+}
+func (noopRepository) GetTableCards(ctx context.Context, tableKey string, games int) ([]int, error) {
+	return nil, nil
+}
+func (noopRepository) PersistTableCards(ctx context.Context, record *models.TableCardHistory) error {
+	return nil
+}
+func (noopRepository) UpdateGameShuffle(ctx context.Context, gameID uuid.UUID, commitment string, avoided, expansion []int) error {
+	return nil
+}
+func (noopRepository) UpdateGameRules(ctx context.Context, gameID uuid.UUID, rules GameRules) error {
+	return nil
+}
+func (noopRepository) GetExpansionCards(ctx context.Context, slugs []string) (map[string][]int, error) {
+	return nil, nil
+}
+func (noopRepository) GetPackCards(ctx context.Context, slugs []string) (map[string][]int, error) {
+	return nil, nil
+}
+func (noopRepository) GetCustomDeckCards(ctx context.Context, deckID uuid.UUID) ([]int, error) {
+	return nil, nil
+}
+func (noopRepository) PersistReplayEvent(ctx context.Context, event *models.ReplayEvent) error {
+	return nil
+}
+
+// repository returns the storage a game's records should be written to
+func (m *Manager) repository(game *GameState) GameRepository {
+	if game.Sandbox {
+		return noopRepository{}
+	}
+	return m
+}
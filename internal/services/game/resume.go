@@ -0,0 +1,205 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrSeatUnavailable is returned when a resume token's seat can no longer be reclaimed
+var ErrSeatUnavailable = errors.New("seat is no longer available")
+
+// ResumeTokenIssuer signs room-scoped resume tokens (implemented by auth.JWTService)
+type ResumeTokenIssuer interface {
+	GenerateResumeToken(playerID uuid.UUID, roomCode string) (string, time.Time, error)
+}
+
+// SetResumeTokenIssuer enables resume tokens. Without an issuer none are sent.
+func (m *Manager) SetResumeTokenIssuer(issuer ResumeTokenIssuer) {
+	m.mu.Lock()
+	m.resumeTokens = issuer
+	m.mu.Unlock()
+}
+
+// sendResumeToken gives a connected human player a fresh token for their seat.
+// Players without a live connection get one when they next join or resume.
+func (m *Manager) sendResumeToken(game *GameState, playerID uuid.UUID) {
+	if m.resumeTokens == nil {
+		return
+	}
+	if player, exists := game.Players[playerID]; !exists || player.IsBot {
+		return
+	}
+
+	token, expiresAt, err := m.resumeTokens.GenerateResumeToken(playerID, game.RoomCode)
+	if err != nil {
+		logger.Error("Failed to generate resume token", "error", err, "player_id", playerID, "room_code", game.RoomCode)
+		return
+	}
+
+	if err := m.SendToPlayer(game, playerID, MessageTypeResumeToken, ResumeTokenPayload{
+		RoomCode:  game.RoomCode,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		logger.Debug("Resume token not delivered", "error", err, "player_id", playerID, "room_code", game.RoomCode)
+	}
+}
+
+// refreshResumeTokens reissues tokens to every human player before the old ones expire
+func (m *Manager) refreshResumeTokens(game *GameState) {
+	for playerID, player := range game.Players {
+		if !player.IsBot && player.IsConnected {
+			m.sendResumeToken(game, playerID)
+		}
+	}
+}
+
+// ResumeSeat reattaches a reconnecting player to their seat, identified by a
+// validated resume token, and sends them the current game state
+func (m *Manager) ResumeSeat(roomCode string, playerID uuid.UUID, conn Connection) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		recordReconnectFailure("game_gone")
+		return fmt.Errorf("%w: game not found", ErrSeatUnavailable)
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	player, exists := game.Players[playerID]
+	if !exists || player.IsBot || player.WasReplaced {
+		reason := "not_seated"
+		if exists && player.WasReplaced {
+			reason = "replaced"
+		}
+		recordReconnectFailure(reason)
+		return ErrSeatUnavailable
+	}
+
+	player.markReconnected(game)
+	player.Connection = conn
+	player.IsConnected = true
+	player.IsActive = true
+	player.UpdateActivity()
+	game.LastActivity = time.Now()
+
+	// Everyone sees the player back online; the player gets the state and chat they missed
+	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
+	m.sendResumeToken(game, playerID)
+	m.sendJoinChatHistory(game, playerID, conn)
+
+	logger.Info("Player resumed seat",
+		"room_code", roomCode,
+		"player_id", playerID,
+		"transport", conn.Transport())
+
+	return nil
+}
+
+// ReconnectPlayer gives a returning player their seats back when they connect
+// again without a resume token, e.g. after their network dropped: every live
+// game they are still seated in is rebound to the new connection, which gets
+// the game state, their hand and whether the round waits on them. Being back
+// cancels any pending AFK replacement. It returns the rooms restored.
+//
+// Player IDs are in every game state, so callers only reconnect players who
+// signed in with a session JWT; guests come back with their resume token
+// through ResumeSeat.
+func (m *Manager) ReconnectPlayer(playerID uuid.UUID, conn Connection) []string {
+	var restored []string
+	for roomCode, game := range m.GetAllGames() {
+		game.mu.Lock()
+		if m.restoreSeat(game, playerID, conn) {
+			restored = append(restored, roomCode)
+		}
+		game.mu.Unlock()
+	}
+	return restored
+}
+
+// restoreSeat rebinds a disconnected player's seat to their new connection.
+// Seats still connected, handed to a bot or left on purpose stay as they are.
+// Callers hold the game lock.
+func (m *Manager) restoreSeat(game *GameState, playerID uuid.UUID, conn Connection) bool {
+	if game.Status != models.GameStatusWaiting && game.Status != models.GameStatusInProgress {
+		return false
+	}
+	player, exists := game.Players[playerID]
+	if !exists || player.IsBot || player.WasReplaced || !player.IsActive || player.IsConnected {
+		return false
+	}
+
+	player.markReconnected(game)
+	player.Connection = conn
+	player.IsConnected = true
+	player.UpdateActivity() // No longer AFK, so no replacement is due
+	game.LastActivity = time.Now()
+
+	// Everyone sees the player back online; the player gets the state they missed
+	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
+
+	restored := SeatRestoredPayload{
+		RoomCode: game.RoomCode,
+		GameID:   game.ID,
+		Status:   game.Status,
+		Hand:     append([]int{}, player.Hand...),
+	}
+	if round := game.CurrentRound; round != nil && game.Status == models.GameStatusInProgress {
+		restored.Phase = round.Status
+		restored.MoveDue = moveDue(round, playerID)
+		restored.PhaseDeadline = round.PhaseDeadline
+	}
+	if err := m.SendToPlayer(game, playerID, MessageTypeSeatRestored, restored); err != nil {
+		logger.Debug("Seat restore not delivered", "error", err, "player_id", playerID, "room_code", game.RoomCode)
+	}
+	m.sendResumeToken(game, playerID)
+	m.sendJoinChatHistory(game, playerID, conn)
+
+	logger.Info("Player reconnected to seat",
+		"room_code", game.RoomCode,
+		"player_id", playerID,
+		"transport", conn.Transport())
+	return true
+}
+
+// rejoinSeat hands an existing seat to the player's newest connection. The
+// previous connection, if still open, is told its session moved elsewhere.
+func (m *Manager) rejoinSeat(game *GameState, player *Player) (*GameState, error) {
+	if player.IsBot || player.WasReplaced {
+		return nil, ErrSeatUnavailable
+	}
+
+	current := GetPlayerConnection(player.ID)
+	if previous := player.Connection; previous != nil && previous != current {
+		if err := previous.SendJSON(GameMessage{
+			Type:    MessageTypeSessionReplaced,
+			Payload: ErrorPayload{Message: "You joined this game from another device"},
+		}); err != nil {
+			logger.Debug("Previous connection already gone", "error", err, "player_id", player.ID)
+		}
+	}
+
+	if current != nil {
+		player.markReconnected(game)
+	}
+	player.Connection = current
+	player.IsConnected = current != nil
+	player.IsActive = true
+	player.UpdateActivity()
+
+	m.BroadcastToGame(game, MessageTypeGameState, GameStatePayload{GameState: game})
+	m.sendResumeToken(game, player.ID)
+	m.sendJoinChatHistory(game, player.ID, current)
+
+	logger.Info("Player rejoined existing seat",
+		"room_code", game.RoomCode,
+		"player_id", player.ID)
+
+	return game, nil
+}
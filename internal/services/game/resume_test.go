@@ -0,0 +1,274 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/models"
+	"dixitme/internal/testutils/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingConnection captures messages sent to a client
+type recordingConnection struct {
+	messages []GameMessage
+}
+
+func (c *recordingConnection) Send(data []byte) error {
+	var message GameMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return err
+	}
+	c.messages = append(c.messages, message)
+	return nil
+}
+
+func (c *recordingConnection) SendJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.Send(data)
+}
+
+func (c *recordingConnection) Transport() string { return "test" }
+
+func (c *recordingConnection) ProtocolVersion() int { return ProtocolV1 }
+
+func (c *recordingConnection) Locale() string { return i18n.DefaultLocale }
+
+func (c *recordingConnection) types() []MessageType {
+	types := make([]MessageType, 0, len(c.messages))
+	for _, message := range c.messages {
+		types = append(types, message.Type)
+	}
+	return types
+}
+
+func TestJoinGameFromSecondDeviceTakesOverSeat(t *testing.T) {
+	playerID := uuid.New()
+	oldDevice, newDevice := &recordingConnection{}, &recordingConnection{}
+
+	gs := &GameState{
+		RoomCode: "SEAT",
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			playerID: {ID: playerID, Name: "Alice", Connection: oldDevice, IsConnected: true, IsActive: true},
+		},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}}
+
+	RegisterPlayerConnection(playerID, newDevice)
+	defer UnregisterPlayerConnection(playerID, newDevice)
+
+	joined, err := m.JoinGameWithOptions(gs.RoomCode, playerID, "Alice", JoinGameOptions{Verified: true})
+	require.NoError(t, err)
+	assert.Same(t, gs, joined)
+	assert.Len(t, gs.Players, 1)
+	assert.Equal(t, Connection(newDevice), gs.Players[playerID].Connection)
+
+	assert.Contains(t, oldDevice.types(), MessageTypeSessionReplaced)
+	assert.Contains(t, newDevice.types(), MessageTypeGameState)
+}
+
+func TestJoinGameRejectsReplacedSeat(t *testing.T) {
+	playerID := uuid.New()
+	gs := &GameState{
+		RoomCode: "GONE",
+		Status:   models.GameStatusInProgress,
+		Players:  map[uuid.UUID]*Player{playerID: {ID: playerID, WasReplaced: true}},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}}
+
+	_, err := m.JoinGameWithOptions(gs.RoomCode, playerID, "Alice", JoinGameOptions{Verified: true})
+	assert.ErrorIs(t, err, ErrSeatUnavailable)
+}
+
+func TestJoinGameRefusesUnprovenSeatTakeover(t *testing.T) {
+	playerID := uuid.New()
+	seated := &recordingConnection{}
+
+	gs := &GameState{
+		RoomCode: "CLAIM",
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			playerID: {ID: playerID, Name: "Alice", Connection: seated, IsConnected: true, IsActive: true},
+		},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}}
+
+	// Knowing a player ID is not enough to take their seat
+	_, err := m.JoinGame(gs.RoomCode, playerID, "Mallory")
+	assert.ErrorIs(t, err, &GameError{Code: ErrCodeSeatTaken})
+	assert.Equal(t, Connection(seated), gs.Players[playerID].Connection)
+	assert.Empty(t, seated.messages)
+}
+
+func TestReconnectPlayerRestoresSeats(t *testing.T) {
+	playerID, storyteller := uuid.New(), uuid.New()
+	playing := &GameState{
+		ID:       uuid.New(),
+		RoomCode: "BACK",
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			playerID:    {ID: playerID, Name: "Alice", Hand: []int{4, 8}, IsActive: true},
+			storyteller: {ID: storyteller, Name: "Bob", IsActive: true, IsConnected: true},
+		},
+		CurrentRound: &Round{
+			StorytellerID: storyteller,
+			Status:        models.RoundStatusSubmitting,
+			Submissions:   map[uuid.UUID]*CardSubmission{},
+		},
+	}
+	replaced := &GameState{
+		RoomCode: "LOST",
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			playerID: {ID: playerID, Name: "Alice", WasReplaced: true},
+		},
+	}
+	otherTab := &recordingConnection{}
+	connected := &GameState{
+		RoomCode: "HERE",
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			playerID: {ID: playerID, Name: "Alice", IsActive: true, IsConnected: true, Connection: otherTab},
+		},
+	}
+	m := &Manager{games: map[string]*GameState{playing.RoomCode: playing, replaced.RoomCode: replaced, connected.RoomCode: connected}}
+
+	conn := &recordingConnection{}
+	RegisterPlayerConnection(playerID, conn)
+	defer UnregisterPlayerConnection(playerID, conn)
+
+	assert.Equal(t, []string{"BACK"}, m.ReconnectPlayer(playerID, conn))
+	assert.Equal(t, Connection(otherTab), connected.Players[playerID].Connection, "a seat still connected isn't taken")
+	player := playing.Players[playerID]
+	assert.True(t, player.IsConnected)
+	assert.Equal(t, Connection(conn), player.Connection)
+	assert.False(t, player.IsAFK(time.Nanosecond), "being back cancels the AFK replacement")
+	assert.False(t, replaced.Players[playerID].IsConnected)
+
+	require.Equal(t, []MessageType{MessageTypeGameState, MessageTypeSeatRestored}, conn.types())
+	restored := conn.messages[1].Payload.(map[string]interface{})
+	assert.Equal(t, "BACK", restored["room_code"])
+	assert.Equal(t, []interface{}{4.0, 8.0}, restored["hand"])
+	assert.Equal(t, true, restored["move_due"])
+}
+
+func TestReplacementCancelledWhenPlayerIsBack(t *testing.T) {
+	playerID := uuid.New()
+	gs := &GameState{
+		RoomCode: "RACE",
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			playerID: {ID: playerID, Name: "Alice", IsActive: true, IsConnected: true},
+		},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}}
+
+	_, err := m.replacePlayerWithBot(gs.RoomCode, playerID, "AFK timeout", func(_ *GameState, player *Player) bool {
+		return player.IsAFK(time.Minute)
+	})
+	assert.ErrorIs(t, err, errReplacementCancelled)
+	assert.False(t, gs.Players[playerID].WasReplaced)
+}
+
+// chatRoom is a stored room holding one chat message, served by a manager
+// that sends the latest messages to players taking their seats
+func chatRoom(t *testing.T) (*Manager, *GameState) {
+	t.Helper()
+	db := testdb.Open(t, &models.Game{}, &models.Player{}, &models.GamePlayer{}, &models.ChatMessage{})
+	authorID := uuid.New()
+	gs := &GameState{
+		ID:       uuid.New(),
+		RoomCode: "CHAT",
+		Status:   models.GameStatusWaiting,
+		Players: map[uuid.UUID]*Player{
+			authorID: {ID: authorID, Name: "Bob", IsActive: true, IsConnected: true},
+		},
+	}
+	require.NoError(t, db.Create(&models.Game{ID: gs.ID, RoomCode: gs.RoomCode}).Error)
+	require.NoError(t, db.Create(&models.Player{ID: authorID, Name: "Bob"}).Error)
+	require.NoError(t, db.Create(&models.ChatMessage{ID: uuid.New(), GameID: gs.ID, PlayerID: &authorID, Message: "hello", Phase: "lobby", IsVisible: true}).Error)
+
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}, db: db, chatRetention: DefaultChatRetentionPolicy()}
+	return m, gs
+}
+
+// chatHistoryOf returns the messages of the chat history conn was sent
+func chatHistoryOf(t *testing.T, conn *recordingConnection) []string {
+	t.Helper()
+	for _, message := range conn.messages {
+		if message.Type != MessageTypeChatHistory {
+			continue
+		}
+		var texts []string
+		payload := message.Payload.(map[string]interface{})
+		for _, entry := range payload["messages"].([]interface{}) {
+			texts = append(texts, entry.(map[string]interface{})["message"].(string))
+		}
+		return texts
+	}
+	return nil
+}
+
+func TestTakingSeatSendsRecentChat(t *testing.T) {
+	playerID := uuid.New()
+
+	t.Run("join", func(t *testing.T) {
+		m, gs := chatRoom(t)
+		conn := &recordingConnection{}
+		RegisterPlayerConnection(playerID, conn)
+		defer UnregisterPlayerConnection(playerID, conn)
+
+		_, err := m.JoinGameWithOptions(gs.RoomCode, playerID, "Alice", JoinGameOptions{Verified: true})
+		require.NoError(t, err)
+		assert.Contains(t, chatHistoryOf(t, conn), "hello")
+	})
+
+	t.Run("rejoin", func(t *testing.T) {
+		m, gs := chatRoom(t)
+		gs.Players[playerID] = &Player{ID: playerID, Name: "Alice", IsActive: true}
+		conn := &recordingConnection{}
+		RegisterPlayerConnection(playerID, conn)
+		defer UnregisterPlayerConnection(playerID, conn)
+
+		_, err := m.JoinGameWithOptions(gs.RoomCode, playerID, "Alice", JoinGameOptions{Verified: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"hello"}, chatHistoryOf(t, conn))
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		m, gs := chatRoom(t)
+		gs.Players[playerID] = &Player{ID: playerID, Name: "Alice", IsActive: true}
+		conn := &recordingConnection{}
+
+		require.NoError(t, m.ResumeSeat(gs.RoomCode, playerID, conn))
+		assert.Equal(t, []string{"hello"}, chatHistoryOf(t, conn))
+	})
+
+	t.Run("reconnect", func(t *testing.T) {
+		m, gs := chatRoom(t)
+		gs.Players[playerID] = &Player{ID: playerID, Name: "Alice", IsActive: true}
+		conn := &recordingConnection{}
+
+		assert.Equal(t, []string{"CHAT"}, m.ReconnectPlayer(playerID, conn))
+		assert.Equal(t, []string{"hello"}, chatHistoryOf(t, conn))
+	})
+
+	t.Run("nothing sent when the policy keeps none", func(t *testing.T) {
+		m, gs := chatRoom(t)
+		m.chatRetention.JoinHistory = 0
+		gs.Players[playerID] = &Player{ID: playerID, Name: "Alice", IsActive: true}
+		conn := &recordingConnection{}
+
+		require.NoError(t, m.ResumeSeat(gs.RoomCode, playerID, conn))
+		assert.NotContains(t, conn.types(), MessageTypeChatHistory)
+	})
+}
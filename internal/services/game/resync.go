@@ -0,0 +1,77 @@
+package game
+
+import (
+	"errors"
+
+	"dixitme/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned by ResyncPlayer
+var (
+	ErrResyncGameNotFound   = errors.New("game not found")
+	ErrResyncPlayerNotFound = errors.New("player not in game")
+	ErrResyncBot            = errors.New("bots have no client to resync")
+)
+
+// ResyncResult tells whether a resync reached the player or waits for their reconnect
+type ResyncResult string
+
+const (
+	ResyncDelivered ResyncResult = "delivered"
+	ResyncQueued    ResyncResult = "queued"
+)
+
+// ResyncPlayer rebuilds a player's view of the game and sends it down their
+// connection. Offline players get it on the next message after they reconnect.
+// It is a support tool for clients stuck on a stale UI.
+func (m *Manager) ResyncPlayer(roomCode string, playerID uuid.UUID) (ResyncResult, error) {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return "", ErrResyncGameNotFound
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	player, exists := game.Players[playerID]
+	if !exists {
+		return "", ErrResyncPlayerNotFound
+	}
+	if player.IsBot {
+		return "", ErrResyncBot
+	}
+
+	if err := m.SendToPlayer(game, playerID, MessageTypeGameState, GameStatePayload{GameState: game}); err != nil {
+		player.resyncPending = true
+		logger.Info("Queued game state resync until player reconnects",
+			"room_code", roomCode,
+			"player_id", playerID,
+			"reason", err)
+		return ResyncQueued, nil
+	}
+
+	player.resyncPending = false
+	logger.Info("Resynced game state to player", "room_code", roomCode, "player_id", playerID)
+	return ResyncDelivered, nil
+}
+
+// deliverPendingResync sends a queued resync once a message reaches the
+// player again. A game state message is itself a resync. Callers hold the game lock.
+func (m *Manager) deliverPendingResync(game *GameState, player *Player, conn Connection, sent MessageType) {
+	if !player.resyncPending {
+		return
+	}
+	player.resyncPending = false
+	if sent == MessageTypeGameState {
+		return
+	}
+
+	if err := conn.SendJSON(GameStateMessage(game, player.ID, conn.ProtocolVersion())); err != nil {
+		player.resyncPending = true
+		logger.Debug("Queued resync not delivered", "error", err, "player_id", player.ID)
+		return
+	}
+	logger.Info("Delivered queued game state resync", "room_code", game.RoomCode, "player_id", player.ID)
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResyncPlayerSendsStateToConnectedPlayer(t *testing.T) {
+	playerID := uuid.New()
+	conn := &recordingConnection{}
+	gs := &GameState{
+		RoomCode: "SYNC",
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			playerID: {ID: playerID, Name: "Alice", Connection: conn, IsConnected: true, IsActive: true},
+		},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}}
+
+	result, err := m.ResyncPlayer(gs.RoomCode, playerID)
+	require.NoError(t, err)
+	assert.Equal(t, ResyncDelivered, result)
+	assert.Equal(t, []MessageType{MessageTypeGameState}, conn.types())
+}
+
+func TestResyncPlayerQueuesUntilReconnect(t *testing.T) {
+	offlineID, otherID := uuid.New(), uuid.New()
+	gs := &GameState{
+		RoomCode: "LATE",
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			offlineID: {ID: offlineID, Name: "Alice", IsActive: true},
+			otherID:   {ID: otherID, Name: "Bob", Connection: &recordingConnection{}, IsConnected: true, IsActive: true},
+		},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}}
+
+	result, err := m.ResyncPlayer(gs.RoomCode, offlineID)
+	require.NoError(t, err)
+	assert.Equal(t, ResyncQueued, result)
+
+	// The next message after reconnecting is followed by the queued state
+	conn := &recordingConnection{}
+	RegisterPlayerConnection(offlineID, conn)
+	defer UnregisterPlayerConnection(offlineID, conn)
+
+	m.BroadcastToGame(gs, MessageTypeChatMessage, ErrorPayload{Message: "hi"})
+	assert.Equal(t, []MessageType{MessageTypeChatMessage, MessageTypeGameState}, conn.types())
+
+	m.BroadcastToGame(gs, MessageTypeChatMessage, ErrorPayload{Message: "again"})
+	assert.Equal(t, []MessageType{MessageTypeChatMessage, MessageTypeGameState, MessageTypeChatMessage}, conn.types())
+}
+
+func TestResyncPlayerErrors(t *testing.T) {
+	botID := uuid.New()
+	gs := &GameState{
+		RoomCode: "BOTS",
+		Players:  map[uuid.UUID]*Player{botID: {ID: botID, Name: "Bot", IsBot: true}},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}}
+
+	_, err := m.ResyncPlayer("NONE", botID)
+	assert.ErrorIs(t, err, ErrResyncGameNotFound)
+	_, err = m.ResyncPlayer(gs.RoomCode, uuid.New())
+	assert.ErrorIs(t, err, ErrResyncPlayerNotFound)
+	_, err = m.ResyncPlayer(gs.RoomCode, botID)
+	assert.ErrorIs(t, err, ErrResyncBot)
+}
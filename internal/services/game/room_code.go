@@ -0,0 +1,86 @@
+package game
+
+import (
+	"strings"
+
+	"dixitme/internal/utils"
+)
+
+// Room code limits. Generated codes are six characters; codes picked by
+// players may be a little shorter or longer.
+const (
+	MinRoomCodeLength       = 3
+	MaxRoomCodeLength       = 8
+	generatedRoomCodeLength = 6
+)
+
+// reservedRoomCodes collide with routes or read as official rooms
+var reservedRoomCodes = map[string]bool{
+	"ADMIN":   true,
+	"API":     true,
+	"WS":      true,
+	"STATIC":  true,
+	"HEALTH":  true,
+	"METRICS": true,
+	"LOGIN":   true,
+	"LOGOUT":  true,
+	"SYSTEM":  true,
+	"SUPPORT": true,
+	"DIXIT":   true,
+	"DIXITME": true,
+}
+
+// NormalizeRoomCode trims and uppercases a client-supplied room code and
+// checks its length, characters and that it isn't reserved. The error is a
+// GameError whose details carry the reason.
+func NormalizeRoomCode(roomCode string) (string, error) {
+	roomCode = strings.ToUpper(strings.TrimSpace(roomCode))
+
+	invalid := func(reason, message string) error {
+		return &GameError{
+			Code:    ErrCodeInvalidRoomCode,
+			Message: message,
+			Details: map[string]interface{}{
+				"reason":     reason,
+				"min_length": MinRoomCodeLength,
+				"max_length": MaxRoomCodeLength,
+			},
+		}
+	}
+
+	if len(roomCode) < MinRoomCodeLength || len(roomCode) > MaxRoomCodeLength {
+		return "", invalid("length", "Room code must be 3 to 8 characters")
+	}
+	for _, r := range roomCode {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return "", invalid("charset", "Room code may only use letters and digits")
+		}
+	}
+	if reservedRoomCodes[roomCode] {
+		return "", invalid("reserved", "That room code is reserved, please pick another")
+	}
+	return roomCode, nil
+}
+
+// GenerateRoomCode returns a random room code that passes NormalizeRoomCode.
+// It can still collide with a live room.
+func GenerateRoomCode() (string, error) {
+	for {
+		roomCode, err := utils.GenerateRandomString(generatedRoomCodeLength)
+		if err != nil {
+			return "", err
+		}
+		if !reservedRoomCodes[roomCode] {
+			return roomCode, nil
+		}
+	}
+}
+
+// roomCodeTaken is returned when a room with the code exists already
+func roomCodeTaken(roomCode string) error {
+	return &GameError{
+		Code:    ErrCodeRoomCodeTaken,
+		Message: "room code '" + roomCode + "' is already taken, please try a different one",
+		Details: map[string]interface{}{"room_code": roomCode},
+	}
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeRoomCode(t *testing.T) {
+	code, err := NormalizeRoomCode("  ab12cd ")
+	require.NoError(t, err)
+	assert.Equal(t, "AB12CD", code)
+
+	for input, reason := range map[string]string{
+		"AB":        "length",
+		"ABCDEFGHI": "length",
+		"AB-12":     "charset",
+		"ÉCOLE":     "charset",
+		"admin":     "reserved",
+		" API ":     "reserved",
+	} {
+		_, err := NormalizeRoomCode(input)
+		gameErr, ok := AsGameError(err)
+		require.True(t, ok, input)
+		assert.Equal(t, ErrCodeInvalidRoomCode, gameErr.Code, input)
+		assert.Equal(t, reason, gameErr.Details["reason"], input)
+	}
+}
+
+func TestCreateGameNormalizesRoomCode(t *testing.T) {
+	m := NewEphemeralManager()
+	gs, err := m.CreateGameWithOptions(" lobby ", uuid.New(), "Alice", CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+	assert.Equal(t, "LOBBY", gs.RoomCode)
+
+	_, err = m.CreateGameWithOptions("Lobby", uuid.New(), "Bob", CreateGameOptions{Sandbox: true})
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeRoomCodeTaken}))
+
+	_, err = m.CreateGameWithOptions("admin", uuid.New(), "Carol", CreateGameOptions{Sandbox: true})
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeInvalidRoomCode}))
+}
@@ -0,0 +1,46 @@
+package game
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Room password length bounds. The minimum counts characters; the maximum
+// counts bytes, since bcrypt refuses passwords longer than 72 bytes.
+const (
+	minRoomPasswordLength = 4
+	maxRoomPasswordBytes  = 72
+)
+
+// hashRoomPassword checks a new room password and hashes it for storage
+func hashRoomPassword(password string) (string, error) {
+	if utf8.RuneCountInString(password) < minRoomPasswordLength {
+		return "", fmt.Errorf("room password must be at least %d characters", minRoomPasswordLength)
+	}
+	if len(password) > maxRoomPasswordBytes {
+		return "", fmt.Errorf("room password must be at most %d bytes", maxRoomPasswordBytes)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash room password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// checkPassword lets a new player into a password-protected room. The hash
+// never changes once the room exists, so it is read without the game lock,
+// keeping the deliberately slow comparison from holding up the game.
+func (gs *GameState) checkPassword(password string) error {
+	if gs.passwordHash == "" {
+		return nil
+	}
+	if password == "" {
+		return &GameError{Code: ErrCodePasswordRequired, Message: "this room needs a password"}
+	}
+	if bcrypt.CompareHashAndPassword([]byte(gs.passwordHash), []byte(password)) != nil {
+		return &GameError{Code: ErrCodeWrongPassword, Message: "wrong room password"}
+	}
+	return nil
+}
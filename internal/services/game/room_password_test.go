@@ -0,0 +1,40 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomPassword(t *testing.T) {
+	_, err := hashRoomPassword("abc")
+	assert.Error(t, err, "too short")
+
+	hash, err := hashRoomPassword("moonlit")
+	require.NoError(t, err)
+	assert.NotEqual(t, "moonlit", hash)
+
+	gs := &GameState{passwordHash: hash}
+	assert.NoError(t, gs.checkPassword("moonlit"))
+	assert.True(t, errors.Is(gs.checkPassword(""), &GameError{Code: ErrCodePasswordRequired}))
+	assert.True(t, errors.Is(gs.checkPassword("sunlit"), &GameError{Code: ErrCodeWrongPassword}))
+
+	assert.NoError(t, (&GameState{}).checkPassword("anything"), "open rooms ignore passwords")
+}
+
+func TestRoomPasswordLengthCountsBytes(t *testing.T) {
+	// 24 characters of three bytes each is bcrypt's limit exactly
+	hash, err := hashRoomPassword(strings.Repeat("月", 24))
+	require.NoError(t, err)
+	assert.NoError(t, (&GameState{passwordHash: hash}).checkPassword(strings.Repeat("月", 24)))
+
+	_, err = hashRoomPassword(strings.Repeat("月", 25))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at most 72 bytes", "a validation error, not a hashing failure")
+
+	_, err = hashRoomPassword("月光")
+	assert.Error(t, err, "too short, counted in characters")
+}
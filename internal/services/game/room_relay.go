@@ -0,0 +1,187 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// roomChannelPrefix prefixes the Redis pub/sub channel of each room
+const roomChannelPrefix = "room:events:"
+
+// roomEvent is a broadcast relayed to the other instances hosting players of
+// the same room. It carries the v1 encoding every client understands plus
+// what a relaying instance needs to build per-player and localized variants.
+type roomEvent struct {
+	Origin   string          `json:"origin"` // Instance that broadcast the event
+	RoomCode string          `json:"room_code"`
+	Type     MessageType     `json:"type"`
+	Players  []uuid.UUID     `json:"players"` // Human players the event is for
+	Message  json.RawMessage `json:"message"`
+	System   *i18n.Message   `json:"system,omitempty"` // Source text of a localized system chat message
+}
+
+func roomChannel(roomCode string) string {
+	return roomChannelPrefix + roomCode
+}
+
+func encodeRoomEvent(origin string, game *GameState, messageType MessageType, payload interface{}, messageData []byte) ([]byte, error) {
+	event := roomEvent{
+		Origin:   origin,
+		RoomCode: game.RoomCode,
+		Type:     messageType,
+		Message:  messageData,
+	}
+	for playerID, player := range game.Players {
+		if !player.IsBot {
+			event.Players = append(event.Players, playerID)
+		}
+	}
+	if system, ok := payload.(systemChatPayload); ok {
+		event.System = &system.message
+	}
+	return json.Marshal(event)
+}
+
+func decodeRoomEvent(data []byte) (*roomEvent, error) {
+	var event roomEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal room event: %w", err)
+	}
+	return &event, nil
+}
+
+// payload rebuilds the broadcast payload from the v1 encoding, for the kinds
+// of payload that are encoded per connection
+func (e *roomEvent) payload() (interface{}, error) {
+	var message struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(e.Message, &message); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal relayed message: %w", err)
+	}
+
+	switch {
+	case e.Type == MessageTypeGameState:
+		var payload GameStatePayload
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal relayed game state: %w", err)
+		}
+		if payload.GameState == nil {
+			return nil, fmt.Errorf("relayed game state is empty")
+		}
+		return payload, nil
+	case e.System != nil:
+		var payload ChatMessagePayload
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal relayed chat message: %w", err)
+		}
+		return systemChatPayload{ChatMessagePayload: payload, message: *e.System}, nil
+	}
+	return nil, nil
+}
+
+// publishRoomEvent hands a broadcast to the other instances, unless every
+// human in the room is connected here
+func (m *Manager) publishRoomEvent(game *GameState, messageType MessageType, payload interface{}, messageData []byte, localRecipients int) {
+	if m.redisClient == nil {
+		return
+	}
+	humans := 0
+	for _, player := range game.Players {
+		if !player.IsBot {
+			humans++
+		}
+	}
+	if localRecipients >= humans {
+		return
+	}
+
+	data, err := encodeRoomEvent(m.instanceID, game, messageType, payload, messageData)
+	if err != nil {
+		logger.Error("Failed to encode room event", "error", err, "room_code", game.RoomCode)
+		return
+	}
+	if err := m.redisClient.Publish(context.Background(), roomChannel(game.RoomCode), data).Err(); err != nil {
+		logger.Error("Failed to publish room event", "error", err, "room_code", game.RoomCode)
+	}
+}
+
+// StartRoomRelay subscribes to the rooms' pub/sub channels so broadcasts made
+// on other instances reach the players connected to this one
+func (m *Manager) StartRoomRelay() {
+	if m.redisClient == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pubsub := m.redisClient.PSubscribe(ctx, roomChannelPrefix+"*")
+	m.stopRelay = func() {
+		cancel()
+		pubsub.Close()
+	}
+
+	go func() {
+		logger.Info("Room relay started", "instance_id", m.instanceID)
+		for msg := range pubsub.Channel() {
+			m.relayRoomEvent(strings.TrimPrefix(msg.Channel, roomChannelPrefix), []byte(msg.Payload))
+		}
+		logger.Info("Room relay stopped")
+	}()
+}
+
+// StopRoomRelay stops relaying other instances' broadcasts
+func (m *Manager) StopRoomRelay() {
+	if m.stopRelay != nil {
+		m.stopRelay()
+	}
+}
+
+// relayRoomEvent delivers another instance's broadcast to the room's players
+// connected to this one
+func (m *Manager) relayRoomEvent(roomCode string, data []byte) {
+	event, err := decodeRoomEvent(data)
+	if err != nil {
+		logger.Error("Failed to decode room event", "error", err, "room_code", roomCode)
+		return
+	}
+	if event.Origin == m.instanceID {
+		return
+	}
+	payload, err := event.payload()
+	if err != nil {
+		logger.Error("Failed to decode relayed payload", "error", err, "room_code", roomCode)
+		return
+	}
+
+	relayed := 0
+	for _, playerID := range event.Players {
+		conn := GetPlayerConnection(playerID)
+		if conn == nil {
+			continue
+		}
+		data, err := encodeForConnection(conn, playerID, event.Type, payload, event.Message)
+		if err != nil {
+			logger.Error("Failed to encode relayed message", "error", err, "player_id", playerID)
+			continue
+		}
+		if err := conn.Send(data); err != nil {
+			logger.Error("Failed to relay message to player", "error", err, "player_id", playerID, "room_code", roomCode)
+			continue
+		}
+		relayed++
+	}
+	if relayed > 0 {
+		logger.Debug("Relayed room event",
+			"room_code", roomCode,
+			"message_type", event.Type,
+			"origin", event.Origin,
+			"messages_sent", relayed)
+	}
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomEventRebuildsPerConnectionPayloads(t *testing.T) {
+	human, botID := uuid.New(), uuid.New()
+	game := &GameState{
+		ID:       uuid.New(),
+		RoomCode: "RELAY1",
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			human: {ID: human, Name: "Ana", Hand: []int{1, 2}},
+			botID: {ID: botID, Name: "Bot", IsBot: true},
+		},
+	}
+
+	payload := GameStatePayload{GameState: game}
+	messageData, err := json.Marshal(GameMessage{Type: MessageTypeGameState, Payload: payload})
+	require.NoError(t, err)
+	data, err := encodeRoomEvent("origin", game, MessageTypeGameState, payload, messageData)
+	require.NoError(t, err)
+
+	event, err := decodeRoomEvent(data)
+	require.NoError(t, err)
+	assert.Equal(t, "RELAY1", event.RoomCode)
+	assert.Equal(t, []uuid.UUID{human}, event.Players, "bots have no connections to relay to")
+
+	rebuilt, err := event.payload()
+	require.NoError(t, err)
+	state, ok := rebuilt.(GameStatePayload)
+	require.True(t, ok)
+	assert.Equal(t, game.ID, state.GameState.ID)
+	assert.Equal(t, []int{1, 2}, state.GameState.Players[human].Hand)
+
+	system := systemChatPayload{
+		ChatMessagePayload: ChatMessagePayload{ID: uuid.New(), Message: "Ana joined", MessageType: "system"},
+		message:            i18n.Msg("{0} joined", "Ana"),
+	}
+	messageData, err = json.Marshal(GameMessage{Type: MessageTypeChatMessage, Payload: system})
+	require.NoError(t, err)
+	data, err = encodeRoomEvent("origin", game, MessageTypeChatMessage, system, messageData)
+	require.NoError(t, err)
+	event, err = decodeRoomEvent(data)
+	require.NoError(t, err)
+
+	rebuilt, err = event.payload()
+	require.NoError(t, err)
+	chat, ok := rebuilt.(systemChatPayload)
+	require.True(t, ok)
+	assert.Equal(t, system.ID, chat.ID)
+	assert.Equal(t, system.message, chat.message)
+}
+
+func TestRelayRoomEventSkipsOwnBroadcasts(t *testing.T) {
+	m := NewEphemeralManager()
+	m.instanceID = "local"
+	playerID := uuid.New()
+	conn := &recordingConnection{}
+	RegisterPlayerConnection(playerID, conn)
+	defer UnregisterPlayerConnection(playerID, conn)
+
+	messageData, err := json.Marshal(GameMessage{Type: MessageTypePlayerJoined, Payload: map[string]string{"name": "Ana"}})
+	require.NoError(t, err)
+	event := func(origin string) []byte {
+		data, err := json.Marshal(roomEvent{Origin: origin, RoomCode: "RELAY2", Type: MessageTypePlayerJoined, Players: []uuid.UUID{playerID}, Message: messageData})
+		require.NoError(t, err)
+		return data
+	}
+
+	m.relayRoomEvent("RELAY2", event("local"))
+	assert.Empty(t, conn.messages)
+
+	m.relayRoomEvent("RELAY2", event("remote"))
+	require.Len(t, conn.messages, 1)
+	assert.Equal(t, MessageTypePlayerJoined, conn.messages[0].Type)
+}
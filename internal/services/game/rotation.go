@@ -0,0 +1,107 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Storyteller rotation. Players take turns in seat order, kept as an explicit
+// list on the game state: map iteration order is random, and a position
+// derived from the player count shifts whenever players join or leave.
+// Callers must hold the game lock.
+
+// joinRotation seats a player at the end of the rotation
+func (gs *GameState) joinRotation(playerID uuid.UUID) {
+	if gs.rotationIndex(playerID) == -1 {
+		gs.StorytellerOrder = append(gs.StorytellerOrder, playerID)
+	}
+}
+
+// leaveRotation removes a player, keeping the next storyteller unchanged
+// unless it was the player leaving
+func (gs *GameState) leaveRotation(playerID uuid.UUID) {
+	i := gs.rotationIndex(playerID)
+	if i == -1 {
+		return
+	}
+	gs.StorytellerOrder = append(gs.StorytellerOrder[:i], gs.StorytellerOrder[i+1:]...)
+	if i < gs.NextStoryteller {
+		gs.NextStoryteller--
+	}
+	if gs.NextStoryteller >= len(gs.StorytellerOrder) {
+		gs.NextStoryteller = 0
+	}
+}
+
+// replaceInRotation gives a replacement the seat, and so the turns, of the
+// player it replaces
+func (gs *GameState) replaceInRotation(oldID, newID uuid.UUID) {
+	if i := gs.rotationIndex(oldID); i != -1 {
+		gs.StorytellerOrder[i] = newID
+		return
+	}
+	gs.joinRotation(newID)
+}
+
+// nextStorytellerID returns the player whose turn it is to tell and advances
+// the rotation. Players who left the game lose their turn; if nobody is
+// active the seat whose turn it is tells anyway.
+func (gs *GameState) nextStorytellerID() uuid.UUID {
+	gs.syncRotation()
+	if len(gs.StorytellerOrder) == 0 {
+		return uuid.Nil
+	}
+
+	pick := gs.NextStoryteller
+	for offset := 0; offset < len(gs.StorytellerOrder); offset++ {
+		i := (gs.NextStoryteller + offset) % len(gs.StorytellerOrder)
+		if player := gs.Players[gs.StorytellerOrder[i]]; player.IsActive {
+			pick = i
+			break
+		}
+	}
+	gs.NextStoryteller = (pick + 1) % len(gs.StorytellerOrder)
+	return gs.StorytellerOrder[pick]
+}
+
+// syncRotation repairs the rotation against the players, e.g. for a game
+// restored from a snapshot taken before the rotation existed: seats of
+// players who are gone or replaced are dropped, and players missing a seat
+// are added in position order
+func (gs *GameState) syncRotation() {
+	for i := len(gs.StorytellerOrder) - 1; i >= 0; i-- {
+		if player, ok := gs.Players[gs.StorytellerOrder[i]]; !ok || player.WasReplaced {
+			gs.leaveRotation(gs.StorytellerOrder[i])
+		}
+	}
+
+	var missing []*Player
+	for _, player := range gs.Players {
+		if !player.WasReplaced && gs.rotationIndex(player.ID) == -1 {
+			missing = append(missing, player)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].Position != missing[j].Position {
+			return missing[i].Position < missing[j].Position
+		}
+		return missing[i].ID.String() < missing[j].ID.String()
+	})
+	for _, player := range missing {
+		gs.joinRotation(player.ID)
+	}
+
+	if gs.NextStoryteller < 0 || gs.NextStoryteller >= len(gs.StorytellerOrder) {
+		gs.NextStoryteller = 0
+	}
+}
+
+func (gs *GameState) rotationIndex(playerID uuid.UUID) int {
+	for i, id := range gs.StorytellerOrder {
+		if id == playerID {
+			return i
+		}
+	}
+	return -1
+}
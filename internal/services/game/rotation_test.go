@@ -0,0 +1,95 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func rotationGame(n int) (*GameState, []uuid.UUID) {
+	game := &GameState{Players: make(map[uuid.UUID]*Player)}
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+		game.Players[ids[i]] = &Player{ID: ids[i], Position: i + 1, IsActive: true}
+		game.joinRotation(ids[i])
+	}
+	return game, ids
+}
+
+func TestRotationEqualTurns(t *testing.T) {
+	game, ids := rotationGame(4)
+
+	turns := make(map[uuid.UUID]int)
+	for round := 0; round < 12; round++ {
+		id := game.nextStorytellerID()
+		assert.Equal(t, ids[round%4], id)
+		turns[id]++
+	}
+	for _, id := range ids {
+		assert.Equal(t, 3, turns[id])
+	}
+}
+
+func TestRotationReplacementKeepsSeat(t *testing.T) {
+	game, ids := rotationGame(3)
+	assert.Equal(t, ids[0], game.nextStorytellerID())
+
+	bot := uuid.New()
+	game.Players[ids[1]].WasReplaced = true
+	game.Players[ids[1]].IsActive = false
+	game.Players[bot] = &Player{ID: bot, IsActive: true, IsBot: true}
+	game.replaceInRotation(ids[1], bot)
+
+	assert.Equal(t, bot, game.nextStorytellerID())
+	assert.Equal(t, ids[2], game.nextStorytellerID())
+	assert.Equal(t, ids[0], game.nextStorytellerID())
+}
+
+func TestRotationLeaveKeepsNextStoryteller(t *testing.T) {
+	game, ids := rotationGame(4)
+	game.nextStorytellerID()
+	game.nextStorytellerID() // ids[2] is next
+
+	delete(game.Players, ids[0])
+	game.leaveRotation(ids[0])
+	assert.Equal(t, ids[2], game.nextStorytellerID())
+
+	// Removing the player whose turn is next hands it to the following seat
+	delete(game.Players, ids[3])
+	game.leaveRotation(ids[3])
+	assert.Equal(t, ids[1], game.nextStorytellerID())
+}
+
+func TestRotationSkipsInactivePlayers(t *testing.T) {
+	game, ids := rotationGame(3)
+	game.Players[ids[0]].IsActive = false
+
+	assert.Equal(t, ids[1], game.nextStorytellerID())
+	assert.Equal(t, ids[2], game.nextStorytellerID())
+	assert.Equal(t, ids[1], game.nextStorytellerID())
+
+	// With nobody active the rotation still moves on
+	game.Players[ids[1]].IsActive = false
+	game.Players[ids[2]].IsActive = false
+	assert.Equal(t, ids[2], game.nextStorytellerID())
+	assert.Equal(t, ids[0], game.nextStorytellerID())
+}
+
+func TestRotationSyncRebuildsFromPositions(t *testing.T) {
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	game := &GameState{Players: map[uuid.UUID]*Player{
+		third:  {ID: third, Position: 3, IsActive: true},
+		first:  {ID: first, Position: 1, IsActive: true},
+		second: {ID: second, Position: 2, IsActive: true},
+	}}
+
+	assert.Equal(t, first, game.nextStorytellerID())
+	assert.Equal(t, []uuid.UUID{first, second, third}, game.StorytellerOrder)
+
+	// A seat whose player is gone is dropped
+	game.StorytellerOrder = append(game.StorytellerOrder, uuid.New())
+	assert.Equal(t, second, game.nextStorytellerID())
+	assert.Len(t, game.StorytellerOrder, 3)
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"strconv"
 	"time"
 
+	"dixitme/internal/i18n"
 	"dixitme/internal/logger"
 	"dixitme/internal/models"
+	"dixitme/internal/services/clues"
+	"dixitme/internal/services/experiments"
 
 	"github.com/google/uuid"
 )
@@ -17,6 +21,12 @@ type GamePlayService interface {
 	SubmitClue(roomCode string, playerID uuid.UUID, clue string, cardID int) error
 	SubmitCard(roomCode string, playerID uuid.UUID, cardID int) error
 	SubmitVote(roomCode string, playerID uuid.UUID, cardID int) error
+	SubmitVoteWithOptions(roomCode string, playerID uuid.UUID, cardID int, opts VoteOptions) error
+	Mulligan(roomCode string, playerID uuid.UUID) error
+	StartKickVote(roomCode string, starterID, targetID uuid.UUID) error
+	CastKickVote(roomCode string, voterID uuid.UUID, kick bool) error
+	RequestBotTakeover(roomCode string, playerID uuid.UUID, playerName string, botID uuid.UUID) error
+	ResolveBotTakeover(roomCode string, hostID, botID uuid.UUID, approve bool) (*GameState, error)
 }
 
 // SubmitClue handles storyteller submitting a clue
@@ -41,6 +51,27 @@ func (m *Manager) SubmitClue(roomCode string, playerID uuid.UUID, clue string, c
 		return fmt.Errorf("not in storytelling phase")
 	}
 
+	if err := validateClue(game.CurrentRound.Modifier, clue); err != nil {
+		return err
+	}
+
+	language, detected := clues.DetectLanguage(clue)
+	languageWarning := ""
+	if detected && game.Settings.Language != "" && language != game.Settings.Language {
+		// Bots only write English clues, so they are warned about rather than rejected
+		enforcement := game.Settings.LanguageEnforcement
+		if enforcement == LanguageEnforcementReject && game.Players[playerID].IsBot {
+			enforcement = LanguageEnforcementWarn
+		}
+
+		switch enforcement {
+		case LanguageEnforcementReject:
+			return fmt.Errorf("clue must be in the room language (%s)", game.Settings.Language)
+		case LanguageEnforcementWarn:
+			languageWarning = fmt.Sprintf("This clue looks like %s, but the room plays in %s", language, game.Settings.Language)
+		}
+	}
+
 	// Validate card is in player's hand
 	player := game.Players[playerID]
 	cardInHand := false
@@ -55,10 +86,18 @@ func (m *Manager) SubmitClue(roomCode string, playerID uuid.UUID, clue string, c
 		return fmt.Errorf("card not in player's hand")
 	}
 
+	if err := m.checkClue(game, clue, cardID); err != nil {
+		return err
+	}
+
 	// Set clue and storyteller card
 	game.CurrentRound.Clue = clue
+	game.CurrentRound.ClueLanguage = language
 	game.CurrentRound.StorytellerCard = cardID
 	game.CurrentRound.Status = models.RoundStatusSubmitting
+	game.analytics.enterPhase(models.RoundStatusSubmitting)
+	m.debugPhase(game, string(models.RoundStatusSubmitting))
+	m.startPhaseTimer(game)
 
 	// Remove card from storyteller's hand and add to used cards
 	for i, handCard := range player.Hand {
@@ -70,12 +109,22 @@ func (m *Manager) SubmitClue(roomCode string, playerID uuid.UUID, clue string, c
 	}
 
 	// Persist round update
-	if err := m.UpdateRound(context.Background(), game.CurrentRound); err != nil {
+	if err := m.repository(game).UpdateRound(context.Background(), game.CurrentRound); err != nil {
 		return fmt.Errorf("failed to update round: %w", err)
 	}
+	m.recordReplay(game, ReplayEvent{Type: ReplayClueGiven, PlayerID: &playerID, CardID: cardID, Clue: clue})
+
+	m.cacheGameState(game)
 
 	// Broadcast clue submitted
-	m.BroadcastToGame(game, MessageTypeClueSubmitted, ClueSubmittedPayload{Clue: clue})
+	m.BroadcastToGame(game, MessageTypeClueSubmitted, ClueSubmittedPayload{
+		Clue:            clue,
+		Language:        language,
+		LanguageWarning: languageWarning,
+	})
+
+	// Bots submit their cards for the clue
+	m.ProcessBotActions(game)
 
 	return nil
 }
@@ -94,6 +143,11 @@ func (m *Manager) SubmitCard(roomCode string, playerID uuid.UUID, cardID int) er
 		return fmt.Errorf("no active round")
 	}
 
+	// A retired bot's scheduled move may still arrive
+	if player, seated := game.Players[playerID]; !seated || player.WasReplaced {
+		return fmt.Errorf("player not in game")
+	}
+
 	if game.CurrentRound.StorytellerID == playerID {
 		return fmt.Errorf("storyteller cannot submit cards")
 	}
@@ -103,7 +157,7 @@ func (m *Manager) SubmitCard(roomCode string, playerID uuid.UUID, cardID int) er
 	}
 
 	// Check if player already submitted
-	if _, exists := game.CurrentRound.Submissions[playerID]; exists {
+	if game.CurrentRound.submitted(playerID) {
 		return fmt.Errorf("card already submitted")
 	}
 
@@ -121,10 +175,14 @@ func (m *Manager) SubmitCard(roomCode string, playerID uuid.UUID, cardID int) er
 		return fmt.Errorf("card not in player's hand")
 	}
 
-	// Add submission
-	game.CurrentRound.Submissions[playerID] = &CardSubmission{
-		PlayerID: playerID,
-		CardID:   cardID,
+	// Add submission; rounds taking more than one card add to it
+	if submission, exists := game.CurrentRound.Submissions[playerID]; exists {
+		submission.ExtraCardIDs = append(submission.ExtraCardIDs, cardID)
+	} else {
+		game.CurrentRound.Submissions[playerID] = &CardSubmission{
+			PlayerID: playerID,
+			CardID:   cardID,
+		}
 	}
 
 	// Remove card from player's hand and add to used cards
@@ -137,24 +195,38 @@ func (m *Manager) SubmitCard(roomCode string, playerID uuid.UUID, cardID int) er
 	}
 
 	// Persist submission
-	if err := m.PersistCardSubmission(context.Background(), game.CurrentRound.ID, playerID, cardID); err != nil {
+	if err := m.repository(game).PersistCardSubmission(context.Background(), game.CurrentRound.ID, playerID, cardID); err != nil {
 		return fmt.Errorf("failed to persist submission: %w", err)
 	}
+	m.recordReplay(game, ReplayEvent{Type: ReplayCardSubmitted, PlayerID: &playerID, CardID: cardID})
 
 	// Check if all players submitted
-	expectedSubmissions := len(game.Players) - 1 // Exclude storyteller
-	if len(game.CurrentRound.Submissions) == expectedSubmissions {
+	expectedSubmissions := game.seatedPlayerCount() - 1 // Exclude storyteller
+	if game.CurrentRound.completeSubmissions() == expectedSubmissions {
 		m.startVotingPhase(game)
 	}
 
+	m.cacheGameState(game)
+
 	// Broadcast card submitted
 	m.BroadcastToGame(game, MessageTypeCardSubmitted, CardSubmittedPayload{PlayerID: playerID})
 
 	return nil
 }
 
+// VoteOptions are optional stakes placed on a vote by experimental mechanics
+type VoteOptions struct {
+	Weight     int  // Confidence stake, 1-3; requires the weighted_votes experiment
+	DoubleDown bool // Requires the double_down experiment; once per game
+}
+
 // SubmitVote handles player voting
 func (m *Manager) SubmitVote(roomCode string, playerID uuid.UUID, cardID int) error {
+	return m.SubmitVoteWithOptions(roomCode, playerID, cardID, VoteOptions{})
+}
+
+// SubmitVoteWithOptions handles player voting with experimental vote stakes
+func (m *Manager) SubmitVoteWithOptions(roomCode string, playerID uuid.UUID, cardID int, opts VoteOptions) error {
 	game := m.getGame(roomCode)
 	if game == nil {
 		return fmt.Errorf("game not found")
@@ -167,6 +239,11 @@ func (m *Manager) SubmitVote(roomCode string, playerID uuid.UUID, cardID int) er
 		return fmt.Errorf("no active round")
 	}
 
+	// A retired bot's scheduled move may still arrive
+	if player, seated := game.Players[playerID]; !seated || player.WasReplaced {
+		return fmt.Errorf("player not in game")
+	}
+
 	if game.CurrentRound.StorytellerID == playerID {
 		return fmt.Errorf("storyteller cannot vote")
 	}
@@ -180,47 +257,97 @@ func (m *Manager) SubmitVote(roomCode string, playerID uuid.UUID, cardID int) er
 		return fmt.Errorf("already voted")
 	}
 
-	// Validate card is among revealed cards
-	validCard := false
-	for _, revealedCard := range game.CurrentRound.RevealedCards {
-		if revealedCard.CardID == cardID {
-			validCard = true
+	// Validate card is among revealed cards, and not the voter's own
+	var selected *RevealedCard
+	for i := range game.CurrentRound.RevealedCards {
+		if game.CurrentRound.RevealedCards[i].CardID == cardID {
+			selected = &game.CurrentRound.RevealedCards[i]
 			break
 		}
 	}
 
-	if !validCard {
+	if selected == nil {
 		return fmt.Errorf("invalid card selection")
 	}
+	if selected.PlayerID == playerID {
+		return &GameError{
+			Code:    ErrCodeSelfVote,
+			Message: "you cannot vote for your own card",
+			Details: map[string]interface{}{"card_id": cardID},
+		}
+	}
+	if selected.PlayerID == game.CurrentRound.StorytellerID && game.teammates(playerID, selected.PlayerID) {
+		return &GameError{
+			Code:    ErrCodeTeammateVote,
+			Message: "the storyteller is your teammate: vote for another card",
+			Details: map[string]interface{}{"card_id": cardID},
+		}
+	}
+
+	if err := validateVoteOptions(game, playerID, opts); err != nil {
+		return err
+	}
 
 	// Add vote
-	game.CurrentRound.Votes[playerID] = &Vote{
-		PlayerID: playerID,
-		CardID:   cardID,
+	vote := &Vote{
+		PlayerID:   playerID,
+		CardID:     cardID,
+		Weight:     opts.Weight,
+		DoubleDown: opts.DoubleDown,
 	}
+	game.CurrentRound.Votes[playerID] = vote
 
 	// Persist vote
-	if err := m.PersistVote(context.Background(), game.CurrentRound.ID, playerID, cardID); err != nil {
+	if err := m.repository(game).PersistVote(context.Background(), game.CurrentRound.ID, vote); err != nil {
 		return fmt.Errorf("failed to persist vote: %w", err)
 	}
+	m.recordReplay(game, ReplayEvent{Type: ReplayVoteCast, PlayerID: &playerID, CardID: cardID})
 
 	// Check if all players voted
-	expectedVotes := len(game.Players) - 1 // Exclude storyteller
+	expectedVotes := game.seatedPlayerCount() - 1 // Exclude storyteller
 	if len(game.CurrentRound.Votes) == expectedVotes {
 		m.completeRound(game)
 	}
 
+	m.cacheGameState(game)
+
 	// Broadcast vote submitted
 	m.BroadcastToGame(game, MessageTypeVoteSubmitted, VoteSubmittedPayload{PlayerID: playerID})
 
 	return nil
 }
 
+// validateVoteOptions checks that vote stakes are allowed by the room's experiments
+func validateVoteOptions(game *GameState, playerID uuid.UUID, opts VoteOptions) error {
+	if opts.Weight != 0 {
+		if !game.Settings.HasExperiment(experiments.WeightedVotes) {
+			return fmt.Errorf("weighted votes are not enabled for this room")
+		}
+		if opts.Weight < 1 || opts.Weight > maxVoteWeight {
+			return fmt.Errorf("vote weight must be between 1 and %d", maxVoteWeight)
+		}
+	}
+
+	if opts.DoubleDown {
+		if !game.Settings.HasExperiment(experiments.DoubleDown) {
+			return fmt.Errorf("double down is not enabled for this room")
+		}
+		if game.history.HasDoubledDown(playerID) {
+			return fmt.Errorf("double down already used this game")
+		}
+	}
+
+	return nil
+}
+
 // Card dealing and deck management
 
 func (m *Manager) dealCards(game *GameState) {
 	for _, player := range game.Players {
-		for len(player.Hand) < 6 && len(game.Deck) > 0 {
+		if player.WasReplaced {
+			continue // Their stand-in holds the hand
+		}
+		for len(player.Hand) < game.Settings.Rules.CardsPerHand() && len(game.Deck) > 0 {
 			if !m.drawCard(game, player) {
 				break
 			}
@@ -243,7 +370,10 @@ func (m *Manager) drawCard(game *GameState, player *Player) bool {
 
 func (m *Manager) refillHands(game *GameState) {
 	for _, player := range game.Players {
-		for len(player.Hand) < 6 && len(game.Deck) > 0 {
+		if player.WasReplaced {
+			continue // Their stand-in holds the hand
+		}
+		for len(player.Hand) < game.Settings.Rules.CardsPerHand() && len(game.Deck) > 0 {
 			if !m.drawCard(game, player) {
 				break
 			}
@@ -257,16 +387,7 @@ func (m *Manager) startNewRound(game *GameState) error {
 	game.RoundNumber++
 
 	// Choose storyteller (rotate through players)
-	storytellerIndex := (game.RoundNumber - 1) % len(game.Players)
-	var storytellerID uuid.UUID
-	i := 0
-	for playerID := range game.Players {
-		if i == storytellerIndex {
-			storytellerID = playerID
-			break
-		}
-		i++
-	}
+	storytellerID := game.nextStorytellerID()
 
 	// Create new round
 	round := &Round{
@@ -279,18 +400,45 @@ func (m *Manager) startNewRound(game *GameState) error {
 		CreatedAt:     time.Now(),
 	}
 
+	// Large games take as many cards from each player as the table's variant says
+	if game.Settings.Rules.LargeGame {
+		if cards := variantFor(game.seatedPlayerCount()).CardsPerPlayer; cards > 1 {
+			round.CardsPerPlayer = cards
+		}
+	}
+
+	// Party modifiers mode gives every round a fresh rule twist
+	if game.Settings.PartyModifiers {
+		var previous *RoundModifier
+		if game.CurrentRound != nil {
+			previous = game.CurrentRound.Modifier
+		}
+		round.Modifier = pickRoundModifier(previous, rand.New(rand.NewSource(time.Now().UnixNano())))
+	}
+
 	game.CurrentRound = round
+	game.analytics.enterPhase(models.RoundStatusStorytelling)
+	m.debugPhase(game, string(models.RoundStatusStorytelling))
+	m.startPhaseTimer(game)
 
 	// Persist round
-	if err := m.PersistRound(context.Background(), game.ID, round); err != nil {
+	if err := m.repository(game).PersistRound(context.Background(), game.ID, round); err != nil {
 		return fmt.Errorf("failed to persist round: %w", err)
 	}
+	m.recordReplay(game, ReplayEvent{Type: ReplayRoundStarted, PlayerID: &storytellerID})
+	m.cacheGameState(game)
 
 	// Broadcast round started
 	m.BroadcastToGame(game, MessageTypeRoundStarted, RoundStartedPayload{
 		Round: round,
 	})
 
+	if round.Modifier != nil {
+		m.SendSystemMessage(game.RoomCode, i18n.Msg("Round {1} twist - {2}: {3}", strconv.Itoa(round.RoundNumber), round.Modifier.Name, round.Modifier.Description))
+	}
+
+	m.refreshResumeTokens(game)
+
 	// Process bot storytelling if storyteller is a bot
 	m.ProcessBotActions(game)
 
@@ -305,22 +453,29 @@ func (m *Manager) startNewRound(game *GameState) error {
 func (m *Manager) startVotingPhase(game *GameState) {
 	round := game.CurrentRound
 	round.Status = models.RoundStatusVoting
+	game.analytics.enterPhase(models.RoundStatusVoting)
+	m.debugPhase(game, string(models.RoundStatusVoting))
+	m.startPhaseTimer(game)
 
 	// Create revealed cards (shuffle submissions + storyteller card)
 	revealedCards := make([]RevealedCard, 0, len(round.Submissions)+1)
 
 	// Add storyteller card
 	revealedCards = append(revealedCards, RevealedCard{
-		CardID:   round.StorytellerCard,
-		PlayerID: round.StorytellerID,
+		CardID:      round.StorytellerCard,
+		PlayerID:    round.StorytellerID,
+		PlayerToken: game.playerToken(round.StorytellerID),
 	})
 
 	// Add other submissions
 	for _, submission := range round.Submissions {
-		revealedCards = append(revealedCards, RevealedCard{
-			CardID:   submission.CardID,
-			PlayerID: submission.PlayerID,
-		})
+		for _, cardID := range submission.Cards() {
+			revealedCards = append(revealedCards, RevealedCard{
+				CardID:      cardID,
+				PlayerID:    submission.PlayerID,
+				PlayerToken: game.playerToken(submission.PlayerID),
+			})
+		}
 	}
 
 	// Shuffle revealed cards
@@ -332,9 +487,14 @@ func (m *Manager) startVotingPhase(game *GameState) {
 	round.RevealedCards = revealedCards
 
 	// Update round in database
-	if err := m.UpdateRound(context.Background(), round); err != nil {
+	if err := m.repository(game).UpdateRound(context.Background(), round); err != nil {
 		logger.Error("Failed to update round for voting phase", "error", err)
 	}
+	table := make([]int, len(revealedCards))
+	for i, card := range revealedCards {
+		table[i] = card.CardID
+	}
+	m.recordReplay(game, ReplayEvent{Type: ReplayVotingStarted, Table: table})
 
 	// Broadcast voting started
 	m.BroadcastToGame(game, MessageTypeVotingStarted, VotingStartedPayload{
@@ -350,12 +510,20 @@ func (m *Manager) startVotingPhase(game *GameState) {
 func (m *Manager) completeRound(game *GameState) {
 	round := game.CurrentRound
 	round.Status = models.RoundStatusScoring
+	game.analytics.enterPhase(models.RoundStatusScoring)
+	m.debugPhase(game, string(models.RoundStatusScoring))
 
 	// Calculate scores
+	previousScores := make(map[uuid.UUID]int, len(game.Players))
+	for playerID, player := range game.Players {
+		previousScores[playerID] = player.Score
+	}
 	newScores := m.calculateScores(game)
+	m.recordRoundScores(game, previousScores)
+	m.revealCardTitles(game, round)
 
 	// Update round status
-	if err := m.UpdateRound(context.Background(), round); err != nil {
+	if err := m.repository(game).UpdateRound(context.Background(), round); err != nil {
 		logger.Error("Failed to update round completion", "error", err)
 	}
 
@@ -364,18 +532,20 @@ func (m *Manager) completeRound(game *GameState) {
 		Scores:        newScores,
 		RevealedCards: round.RevealedCards,
 	})
+	m.notifyRoundCompleted(game, round, newScores)
 
 	// Check if game should end according to Dixit rules:
-	// 1. Any player reaches 30 points
+	// 1. Any player reaches the room's target score (30 points by default)
 	// 2. Deck is empty (no more cards to draw)
 	shouldEnd := false
-	var endReason string
+	var endReason i18n.Message
 
-	// Check for 30 points
+	// Check for the target score
+	targetScore := game.Settings.Rules.WinningScore()
 	for _, player := range game.Players {
-		if player.Score >= 30 {
+		if player.Score >= targetScore {
 			shouldEnd = true
-			endReason = fmt.Sprintf("Game ended: %s reached 30 points!", player.Name)
+			endReason = i18n.Msg("Game ended: {1} reached {2} points!", player.Name, strconv.Itoa(targetScore))
 			break
 		}
 	}
@@ -386,12 +556,12 @@ func (m *Manager) completeRound(game *GameState) {
 		initialDeckSize := len(game.Deck)
 		m.refillHands(game)
 
-		// If deck is empty and any player has less than 6 cards, game ends
+		// If deck is empty and any player has a short hand, game ends
 		if len(game.Deck) == 0 {
 			for _, player := range game.Players {
-				if len(player.Hand) < 6 {
+				if !player.WasReplaced && len(player.Hand) < game.Settings.Rules.CardsPerHand() {
 					shouldEnd = true
-					endReason = "Game ended: No more cards in deck!"
+					endReason = i18n.Msg("Game ended: No more cards in deck!")
 					break
 				}
 			}
@@ -407,65 +577,61 @@ func (m *Manager) completeRound(game *GameState) {
 		}
 	}
 
+	if !shouldEnd {
+		m.warnIfLastRound(game)
+	}
+
 	if shouldEnd {
 		// Send end reason message
 		m.SendSystemMessage(game.RoomCode, endReason)
 		m.completeGame(game)
 	} else {
-		// Start next round after a delay
-		go func() {
-			time.Sleep(5 * time.Second)
-			m.startNewRound(game)
-		}()
+		m.scheduleNextRound(game)
 	}
 }
 
+// scheduleNextRound starts the next round once players have had time to see the results
+func (m *Manager) scheduleNextRound(game *GameState) {
+	m.schedule(game, "next_round", game.Settings.Timing.RevealDelay(), func() {
+		game.mu.Lock()
+		defer game.mu.Unlock()
+		if game.Status != models.GameStatusInProgress {
+			return // Ended while the results were up
+		}
+		if err := m.startNewRound(game); err != nil {
+			logger.Error("Failed to start next round", "error", err, "room_code", game.RoomCode)
+		}
+	})
+}
+
 func (m *Manager) calculateScores(game *GameState) map[uuid.UUID]int {
 	round := game.CurrentRound
-	storytellerID := round.StorytellerID
 
-	// Count votes for storyteller's card
-	storytellerVotes := 0
-	totalVoters := len(round.Votes)
-
-	for _, vote := range round.Votes {
-		if vote.CardID == round.StorytellerCard {
-			storytellerVotes++
-		}
+	previousScores := make(map[uuid.UUID]int, len(game.Players))
+	for playerID, player := range game.Players {
+		previousScores[playerID] = player.Score
 	}
 
-	// Dixit scoring rules
-	if storytellerVotes == 0 || storytellerVotes == totalVoters {
-		// All or none guessed correctly: Storyteller gets 0, others get 2
-		for playerID, player := range game.Players {
-			if playerID != storytellerID {
-				player.Score += 2
-			}
-		}
-	} else {
-		// Some guessed correctly: Storyteller + correct guessers get 3
-		game.Players[storytellerID].Score += 3
-
-		for _, vote := range round.Votes {
-			if vote.CardID == round.StorytellerCard {
-				game.Players[vote.PlayerID].Score += 3
-			}
-		}
+	if game.history == nil {
+		game.history = NewScoringHistory()
 	}
 
-	// Count votes for each card and award points
-	cardVotes := make(map[int]int)
-	for _, vote := range round.Votes {
-		cardVotes[vote.CardID]++
-	}
+	// Score the round with the room's configured modifiers and experiments
+	strategy := buildScoringStrategy(game.tableScoring(), game.Settings.Scoring, game.Settings.Experiments...)
+	points := strategy.Score(&ScoringContext{
+		Round:   round,
+		Scores:  previousScores,
+		History: game.history,
+	})
 
-	// Award points for votes received (except storyteller's card)
-	for _, submission := range round.Submissions {
-		if votes, exists := cardVotes[submission.CardID]; exists {
-			game.Players[submission.PlayerID].Score += votes
+	for playerID, earned := range points {
+		if player, exists := game.Players[playerID]; exists {
+			player.Score += earned
 		}
 	}
 
+	game.history.Record(round)
+
 	// Return current scores
 	scores := make(map[uuid.UUID]int)
 	for playerID, player := range game.Players {
@@ -475,14 +641,19 @@ func (m *Manager) calculateScores(game *GameState) map[uuid.UUID]int {
 	logger.Info("Round scoring completed",
 		"room_code", game.RoomCode,
 		"round", game.RoundNumber,
-		"storyteller_votes", storytellerVotes,
-		"total_voters", totalVoters)
+		"total_voters", len(round.Votes),
+		"streak_bonus", game.Settings.Scoring.StreakBonus,
+		"diminishing_fooling", game.Settings.Scoring.DiminishingFooling,
+		"storyteller_cap", game.Settings.Scoring.StorytellerCap,
+		"large_game", game.Settings.Rules.LargeGame,
+		"experiments", game.Settings.Experiments)
 
 	return scores
 }
 
 func (m *Manager) completeGame(game *GameState) {
 	game.Status = models.GameStatusCompleted
+	m.debugPhase(game, string(models.GameStatusCompleted))
 
 	// Find winner (highest score)
 	var winnerID uuid.UUID
@@ -498,15 +669,21 @@ func (m *Manager) completeGame(game *GameState) {
 	}
 
 	// Update game status in database
-	if err := m.UpdateGameStatus(context.Background(), game.ID, models.GameStatusCompleted); err != nil {
+	if err := m.repository(game).UpdateGameStatus(context.Background(), game.ID, models.GameStatusCompleted); err != nil {
 		logger.Error("Failed to update game completion status", "error", err)
 	}
 
-	// Persist game completion
-	if err := m.PersistGameCompletion(context.Background(), game.ID, winnerID); err != nil {
+	// Persist game completion, with rating changes for ranked games
+	result := newGameResult(game, winnerID, models.GameOutcomeCompleted)
+	if game.Settings.Ranked {
+		m.applyRankedResult(game, result)
+	}
+	if err := m.repository(game).PersistGameCompletion(context.Background(), result); err != nil {
 		logger.Error("Failed to persist game completion", "error", err)
 	}
 
+	m.notifyGameCompleted(game, result)
+
 	// Broadcast game completed
 	finalScores := make(map[uuid.UUID]int)
 	for playerID, player := range game.Players {
@@ -514,8 +691,11 @@ func (m *Manager) completeGame(game *GameState) {
 	}
 
 	m.BroadcastToGame(game, MessageTypeGameCompleted, GameCompletedPayload{
-		Winner:      winnerID,
-		FinalScores: finalScores,
+		Winner:        winnerID,
+		FinalScores:   finalScores,
+		Outcome:       result.Outcome,
+		RatingChanges: result.RatingChanges,
+		Teams:         game.TeamStandings(),
 	})
 
 	logger.Info("Game completed",
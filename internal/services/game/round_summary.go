@@ -0,0 +1,120 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrRoundNotFound is returned when a room has no completed round with the requested number
+var ErrRoundNotFound = errors.New("round not found")
+
+// RoundSummary is a completed round as shown on its shareable image
+type RoundSummary struct {
+	GameID          uuid.UUID          `json:"game_id"`
+	RoomCode        string             `json:"room_code"`
+	RoundNumber     int                `json:"round_number"`
+	Clue            string             `json:"clue"`
+	StorytellerName string             `json:"storyteller_name"`
+	StorytellerCard int                `json:"storyteller_card"` // 0 for rounds recorded before the card was stored
+	Cards           []RoundSummaryCard `json:"cards"`            // Storyteller's card first, then by votes
+}
+
+// RoundSummaryCard is one card on the table and the votes it drew
+type RoundSummaryCard struct {
+	CardID      int    `json:"card_id"`
+	OwnerName   string `json:"owner_name"`
+	Storyteller bool   `json:"storyteller"`
+	Votes       int    `json:"votes"`
+}
+
+// GetRoundSummary returns a completed round of the last recorded game in a room.
+// Rounds still in play are never returned, so votes cannot leak early.
+func (m *Manager) GetRoundSummary(ctx context.Context, roomCode string, roundNumber int) (*RoundSummary, error) {
+	if m.db == nil {
+		return nil, ErrRoundNotFound
+	}
+	db := m.db.WithContext(ctx)
+
+	var record models.Game
+	if err := db.Unscoped().Where("room_code = ?", roomCode).Order("created_at DESC").First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundNotFound
+		}
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+
+	var round models.GameRound
+	if err := db.Preload("Submissions").Preload("Votes").
+		Where("game_id = ? AND round_number = ? AND status = ?", record.ID, roundNumber, models.RoundStatusCompleted).
+		First(&round).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundNotFound
+		}
+		return nil, fmt.Errorf("failed to load round: %w", err)
+	}
+
+	playerIDs := []uuid.UUID{round.StorytellerID}
+	for _, submission := range round.Submissions {
+		playerIDs = append(playerIDs, submission.PlayerID)
+	}
+	var players []models.Player
+	if err := db.Unscoped().Select("id", "name").Where("id IN ?", playerIDs).Find(&players).Error; err != nil {
+		return nil, fmt.Errorf("failed to load players: %w", err)
+	}
+	names := make(map[uuid.UUID]string, len(players))
+	for _, player := range players {
+		names[player.ID] = player.Name
+	}
+
+	return buildRoundSummary(record, round, names), nil
+}
+
+// buildRoundSummary tallies a round's votes per card
+func buildRoundSummary(record models.Game, round models.GameRound, names map[uuid.UUID]string) *RoundSummary {
+	votes := make(map[int]int)
+	for _, vote := range round.Votes {
+		votes[vote.CardID]++
+	}
+
+	summary := &RoundSummary{
+		GameID:          record.ID,
+		RoomCode:        record.RoomCode,
+		RoundNumber:     round.RoundNumber,
+		Clue:            round.Clue,
+		StorytellerName: names[round.StorytellerID],
+		StorytellerCard: round.StorytellerCard,
+		Cards:           make([]RoundSummaryCard, 0, len(round.Submissions)+1),
+	}
+	if round.StorytellerCard != 0 {
+		summary.Cards = append(summary.Cards, RoundSummaryCard{
+			CardID:      round.StorytellerCard,
+			OwnerName:   names[round.StorytellerID],
+			Storyteller: true,
+			Votes:       votes[round.StorytellerCard],
+		})
+	}
+
+	decoys := make([]RoundSummaryCard, 0, len(round.Submissions))
+	for _, submission := range round.Submissions {
+		decoys = append(decoys, RoundSummaryCard{
+			CardID:    submission.CardID,
+			OwnerName: names[submission.PlayerID],
+			Votes:     votes[submission.CardID],
+		})
+	}
+	sort.Slice(decoys, func(i, j int) bool {
+		if decoys[i].Votes != decoys[j].Votes {
+			return decoys[i].Votes > decoys[j].Votes
+		}
+		return decoys[i].CardID < decoys[j].CardID
+	})
+	summary.Cards = append(summary.Cards, decoys...)
+	return summary
+}
@@ -0,0 +1,38 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRoundSummaryTalliesVotes(t *testing.T) {
+	storyteller, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	round := models.GameRound{
+		RoundNumber:     2,
+		StorytellerID:   storyteller,
+		StorytellerCard: 7,
+		Submissions: []models.CardSubmission{
+			{PlayerID: bob, CardID: 12},
+			{PlayerID: carol, CardID: 30},
+		},
+		Votes: []models.Vote{
+			{PlayerID: bob, CardID: 7},
+			{PlayerID: carol, CardID: 12},
+			{PlayerID: uuid.New(), CardID: 12},
+		},
+	}
+	names := map[uuid.UUID]string{storyteller: "Alice", bob: "Bob", carol: "Carol"}
+
+	summary := buildRoundSummary(models.Game{RoomCode: "ABC123"}, round, names)
+
+	assert.Equal(t, "Alice", summary.StorytellerName)
+	assert.Equal(t, []RoundSummaryCard{
+		{CardID: 7, OwnerName: "Alice", Storyteller: true, Votes: 1},
+		{CardID: 12, OwnerName: "Bob", Votes: 2},
+		{CardID: 30, OwnerName: "Carol", Votes: 0},
+	}, summary.Cards)
+}
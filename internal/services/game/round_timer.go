@@ -0,0 +1,248 @@
+package game
+
+import (
+	"time"
+
+	"dixitme/internal/events"
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/bot"
+
+	"github.com/google/uuid"
+)
+
+// timedOutMove is a move made for a player whose time ran out
+type timedOutMove struct {
+	playerID uuid.UUID
+	clue     string
+	cardID   int
+	extra    []int // Further cards, in rounds that take more than one
+}
+
+// finalWarning is how long before a phase's timer runs out players are
+// reminded of it
+const finalWarning = 10 * time.Second
+
+// startPhaseTimer gives the players of the round phase that just began the
+// phase's time limit to move, and schedules the countdown broadcasts. Callers
+// hold the game lock.
+func (m *Manager) startPhaseTimer(game *GameState) {
+	round := game.CurrentRound
+	if round == nil {
+		return
+	}
+	d := game.Settings.Rules.PhaseTimeLimit(round.Status)
+	if d <= 0 {
+		round.PhaseDeadline, round.PhaseSeconds = nil, 0
+		return
+	}
+
+	deadline := time.Now().Add(d)
+	round.PhaseDeadline = &deadline
+	round.PhaseSeconds = int(d.Seconds())
+
+	roomCode, roundID, status := game.RoomCode, round.ID, round.Status
+	for _, remaining := range timerAnnouncements(d) {
+		m.schedule(game, "phase_timer", d-remaining, func() {
+			m.announcePhaseTimer(game, roundID, status)
+		})
+	}
+	m.schedule(game, "round_timer", d, func() {
+		m.expirePhase(roomCode, roundID, status)
+	})
+}
+
+// timerAnnouncements lists the times left at which a phase of length d
+// broadcasts its countdown: when it starts, halfway through longer phases
+// and shortly before it runs out
+func timerAnnouncements(d time.Duration) []time.Duration {
+	announcements := []time.Duration{d}
+	if d >= time.Minute {
+		announcements = append(announcements, d/2)
+	}
+	if d > 2*finalWarning {
+		announcements = append(announcements, finalWarning)
+	}
+	return announcements
+}
+
+// announcePhaseTimer tells the room how long is left of a phase, unless the
+// phase is already over
+func (m *Manager) announcePhaseTimer(game *GameState, roundID uuid.UUID, status models.RoundStatus) {
+	game.mu.RLock()
+	defer game.mu.RUnlock()
+
+	round := game.CurrentRound
+	if game.Status != models.GameStatusInProgress || round == nil || round.ID != roundID ||
+		round.Status != status || round.PhaseDeadline == nil {
+		return
+	}
+
+	remaining := time.Until(*round.PhaseDeadline).Round(time.Second)
+	if remaining < 0 {
+		remaining = 0
+	}
+	m.BroadcastToGame(game, MessageTypePhaseTimer, PhaseTimerPayload{
+		RoundID:          roundID,
+		Phase:            status,
+		Deadline:         *round.PhaseDeadline,
+		Seconds:          round.PhaseSeconds,
+		RemainingSeconds: int(remaining.Seconds()),
+	})
+}
+
+// expirePhase moves for the players still due in a round phase once its
+// timer has run out, so a slow player can't hold up the table. Moves are
+// picked by an easy bot from the player's own hand.
+func (m *Manager) expirePhase(roomCode string, roundID uuid.UUID, status models.RoundStatus) {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return
+	}
+
+	game.mu.RLock()
+	round := game.CurrentRound
+	if game.Status != models.GameStatusInProgress || round == nil || round.ID != roundID || round.Status != status {
+		game.mu.RUnlock()
+		return // The phase ended in time
+	}
+	moves := timedOutMoves(game)
+	if status == models.RoundStatusStorytelling {
+		for i := range moves {
+			moves[i].clue = m.fitClueToRules(game, moves[i].clue, moves[i].cardID)
+		}
+	}
+	game.mu.RUnlock()
+
+	for _, move := range moves {
+		var err error
+		switch status {
+		case models.RoundStatusStorytelling:
+			err = m.SubmitClue(roomCode, move.playerID, move.clue, move.cardID)
+		case models.RoundStatusSubmitting:
+			err = m.SubmitCard(roomCode, move.playerID, move.cardID)
+			for _, cardID := range move.extra {
+				if err != nil {
+					break
+				}
+				err = m.SubmitCard(roomCode, move.playerID, cardID)
+			}
+		case models.RoundStatusVoting:
+			err = m.SubmitVote(roomCode, move.playerID, move.cardID)
+		}
+		if err != nil {
+			logger.Warn("Failed to move for a player out of time",
+				"error", err,
+				"room_code", roomCode,
+				"player_id", move.playerID,
+				"phase", status)
+			continue
+		}
+		m.recordTimeout(game, roundID, move.playerID, status)
+	}
+}
+
+// recordTimeout notes on the round that a bot moved for a player out of
+// time, and tells the room
+func (m *Manager) recordTimeout(game *GameState, roundID, playerID uuid.UUID, status models.RoundStatus) {
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if round := game.CurrentRound; round != nil && round.ID == roundID {
+		round.TimedOut = append(round.TimedOut, playerID)
+	}
+	events.Publish(m.bus, TopicTurnTimedOut, TurnTimedOut{Game: game, PlayerID: playerID, Phase: status})
+
+	name := playerID.String()
+	if player, exists := game.Players[playerID]; exists {
+		name = player.Name
+	}
+	m.SendSystemMessage(game.RoomCode, i18n.Msg("Time ran out: a bot played for {1}", name))
+
+	logger.Info("Round timer ran out, moved for player",
+		"room_code", game.RoomCode,
+		"player_id", playerID,
+		"phase", status)
+}
+
+// timedOutMoves picks the moves of the humans still due in the current
+// phase. Callers hold the game lock.
+func timedOutMoves(game *GameState) []timedOutMove {
+	round := game.CurrentRound
+	var moves []timedOutMove
+	for _, playerID := range lateHumans(game) {
+		player := game.Players[playerID]
+		ai := &bot.BotPlayer{ID: playerID, Name: player.Name, Difficulty: bot.BotEasy}
+		ai.UpdateHand(append([]int(nil), player.Hand...))
+
+		move := timedOutMove{playerID: playerID}
+		var err error
+		switch round.Status {
+		case models.RoundStatusStorytelling:
+			move.cardID, move.clue, err = ai.SelectCardAsStoryteller()
+		case models.RoundStatusSubmitting:
+			move.cardID, err = ai.SelectCardForClue(round.Clue)
+			for picked, owed := move.cardID, round.cardsOwed(playerID)-1; err == nil && owed > 0; owed-- {
+				ai.UpdateHand(withoutCard(ai.Hand, picked))
+				if picked, err = ai.SelectCardForClue(round.Clue); err == nil {
+					move.extra = append(move.extra, picked)
+				}
+			}
+		case models.RoundStatusVoting:
+			move.cardID, err = ai.VoteForCard(game.votableCards(playerID), round.Clue, round.StorytellerCard)
+		}
+		if err != nil {
+			logger.Warn("No move to make for a player out of time", "error", err, "player_id", playerID)
+			continue
+		}
+		moves = append(moves, move)
+	}
+	return moves
+}
+
+// lateHumans lists the humans who still owe a move in the current phase.
+// Bots move on their own. Callers hold the game lock.
+func lateHumans(game *GameState) []uuid.UUID {
+	var late []uuid.UUID
+	for playerID, player := range game.Players {
+		if player.IsBot || player.WasReplaced {
+			continue
+		}
+		if moveDue(game.CurrentRound, playerID) {
+			late = append(late, playerID)
+		}
+	}
+	return late
+}
+
+// moveDue reports whether a player still owes a move in the round's current
+// phase. Callers hold the game lock.
+func moveDue(round *Round, playerID uuid.UUID) bool {
+	if round == nil {
+		return false
+	}
+	isStoryteller := playerID == round.StorytellerID
+
+	switch round.Status {
+	case models.RoundStatusStorytelling:
+		return isStoryteller
+	case models.RoundStatusSubmitting:
+		return !isStoryteller && !round.submitted(playerID)
+	case models.RoundStatusVoting:
+		_, voted := round.Votes[playerID]
+		return !isStoryteller && !voted
+	}
+	return false
+}
+
+// withoutCard returns a copy of the hand without a card
+func withoutCard(hand []int, cardID int) []int {
+	rest := make([]int, 0, len(hand))
+	for _, handCard := range hand {
+		if handCard != cardID {
+			rest = append(rest, handCard)
+		}
+	}
+	return rest
+}
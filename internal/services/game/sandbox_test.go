@@ -0,0 +1,110 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"dixitme/internal/models"
+	"dixitme/internal/testutils/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// forbiddenDB is a database that fails the test on any statement
+func forbiddenDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := testdb.Open(t)
+	forbid := func(tx *gorm.DB) {
+		t.Errorf("sandbox game reached the database: %s", tx.Statement.Table)
+		tx.AddError(errors.New("sandbox games have no database"))
+	}
+	callbacks := db.Callback()
+	require.NoError(t, callbacks.Create().Before("gorm:create").Register("sandbox:create", forbid))
+	require.NoError(t, callbacks.Query().Before("gorm:query").Register("sandbox:query", forbid))
+	require.NoError(t, callbacks.Update().Before("gorm:update").Register("sandbox:update", forbid))
+	require.NoError(t, callbacks.Delete().Before("gorm:delete").Register("sandbox:delete", forbid))
+	require.NoError(t, callbacks.Row().Before("gorm:row").Register("sandbox:row", forbid))
+	require.NoError(t, callbacks.Raw().Before("gorm:raw").Register("sandbox:raw", forbid))
+	return db
+}
+
+// playSandboxGame plays a three player sandbox room to the end: each round
+// the storyteller gives a clue, the others submit their first card and vote
+// for the storyteller's
+func playSandboxGame(t *testing.T, m *Manager) *recordingConnection {
+	t.Helper()
+	scheduler := &heldScheduler{}
+	m.SetScheduler(scheduler)
+
+	host, bob, cara := uuid.New(), uuid.New(), uuid.New()
+	hostConn := &recordingConnection{}
+	RegisterPlayerConnection(host, hostConn)
+	defer UnregisterPlayerConnection(host, hostConn)
+
+	rules := DefaultGameRules()
+	rules.TargetScore = minTargetScore
+	_, err := m.CreateGameWithOptions("SANDBOX", host, "Alice", CreateGameOptions{Sandbox: true, Rules: &rules})
+	require.NoError(t, err)
+	_, err = m.JoinGame("SANDBOX", bob, "Bob")
+	require.NoError(t, err)
+	_, err = m.JoinGame("SANDBOX", cara, "Cara")
+	require.NoError(t, err)
+	require.NoError(t, m.StartGame("SANDBOX", host))
+
+	gs := m.GetGame("SANDBOX")
+	for rounds := 0; ; rounds++ {
+		gs.RLock()
+		if gs.Status != models.GameStatusInProgress {
+			gs.RUnlock()
+			break
+		}
+		require.Less(t, rounds, 20, "the game never ended")
+		storyteller := gs.CurrentRound.StorytellerID
+		hands := make(map[uuid.UUID]int, len(gs.Players))
+		for playerID, player := range gs.Players {
+			hands[playerID] = player.Hand[0]
+		}
+		gs.RUnlock()
+
+		require.NoError(t, m.SubmitClue("SANDBOX", storyteller, "a quiet storm", hands[storyteller]))
+		for playerID, cardID := range hands {
+			if playerID != storyteller {
+				require.NoError(t, m.SubmitCard("SANDBOX", playerID, cardID))
+			}
+		}
+		for playerID := range hands {
+			if playerID != storyteller {
+				require.NoError(t, m.SubmitVote("SANDBOX", playerID, hands[storyteller]))
+			}
+		}
+
+		// The next round starts after the reveal
+		events := scheduler.events
+		scheduler.events = nil
+		for _, event := range events {
+			event()
+		}
+	}
+
+	gs.RLock()
+	defer gs.RUnlock()
+	assert.Equal(t, models.GameStatusCompleted, gs.Status)
+	assert.Greater(t, gs.RoundNumber, 1)
+	return hostConn
+}
+
+func TestSandboxGamePlaysWithoutDatabase(t *testing.T) {
+	hostConn := playSandboxGame(t, NewEphemeralManager())
+	assert.Contains(t, hostConn.types(), MessageTypeGameCompleted)
+}
+
+func TestSandboxGameNeverTouchesDatabase(t *testing.T) {
+	m := NewEphemeralManager()
+	m.db = forbiddenDB(t)
+
+	hostConn := playSandboxGame(t, m)
+	assert.Contains(t, hostConn.types(), MessageTypeGameCompleted)
+}
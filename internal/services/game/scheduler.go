@@ -0,0 +1,56 @@
+package game
+
+import (
+	"time"
+
+	"dixitme/internal/events"
+)
+
+// Scheduler runs delayed game events: the next round after the reveal, and
+// bots' thinking time. Tests swap in a manual one to control time.
+type Scheduler interface {
+	AfterFunc(d time.Duration, f func())
+}
+
+// realScheduler runs events on the wall clock
+type realScheduler struct{}
+
+func (realScheduler) AfterFunc(d time.Duration, f func()) {
+	time.AfterFunc(d, f)
+}
+
+// SetScheduler replaces the scheduler of delayed game events
+func (m *Manager) SetScheduler(scheduler Scheduler) {
+	m.mu.Lock()
+	m.scheduler = scheduler
+	m.mu.Unlock()
+}
+
+// after runs f once d has passed, on the manager's scheduler
+func (m *Manager) after(d time.Duration, f func()) {
+	m.mu.RLock()
+	scheduler := m.scheduler
+	m.mu.RUnlock()
+
+	if scheduler == nil {
+		scheduler = realScheduler{}
+	}
+	scheduler.AfterFunc(d, f)
+}
+
+// NewEphemeralManager creates a manager without a database, Redis or
+// background jobs. It can only host sandbox games, which never touch storage,
+// and exists for in-process simulations such as the scenario tests.
+func NewEphemeralManager() *Manager {
+	manager := &Manager{
+		games:         make(map[string]*GameState),
+		stopCleanup:   make(chan bool),
+		chatRetention: DefaultChatRetentionPolicy(),
+		botLimits:     DefaultBotLimits(),
+		afkThresholds: DefaultAFKThresholds(),
+		capacity:      DefaultCapacityLimits(),
+		bus:           events.NewBus(),
+	}
+	manager.registerBuiltinHooks()
+	return manager
+}
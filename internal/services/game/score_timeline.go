@@ -0,0 +1,201 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrTimelineNotFound is returned when a room has no recorded game
+var ErrTimelineNotFound = errors.New("score timeline not found")
+
+// ScoreTimeline is the score-over-time history of a game, one entry per completed round
+type ScoreTimeline struct {
+	GameID   uuid.UUID          `json:"game_id"`
+	RoomCode string             `json:"room_code"`
+	Status   models.GameStatus  `json:"status"`
+	Players  []TimelinePlayer   `json:"players"`
+	Rounds   []RoundScoreSample `json:"rounds"`
+}
+
+// TimelinePlayer identifies a series in the timeline
+type TimelinePlayer struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	IsBot bool      `json:"is_bot"`
+	Token string    `json:"token,omitempty"`
+}
+
+// RoundScoreSample holds every player's scores at the end of a round
+type RoundScoreSample struct {
+	RoundID     uuid.UUID         `json:"round_id"`
+	RoundNumber int               `json:"round_number"`
+	Scores      map[uuid.UUID]int `json:"scores"` // Cumulative score after the round
+	Earned      map[uuid.UUID]int `json:"earned"` // Points earned in the round
+}
+
+// recordRoundScores appends the scores at the end of the current round to the
+// game's timeline and persists them. previous holds the scores before the round.
+func (m *Manager) recordRoundScores(game *GameState, previous map[uuid.UUID]int) {
+	round := game.CurrentRound
+	sample := RoundScoreSample{
+		RoundID:     round.ID,
+		RoundNumber: round.RoundNumber,
+		Scores:      make(map[uuid.UUID]int, len(game.Players)),
+		Earned:      make(map[uuid.UUID]int, len(game.Players)),
+	}
+	for playerID, player := range game.Players {
+		sample.Scores[playerID] = player.Score
+		sample.Earned[playerID] = player.Score - previous[playerID]
+	}
+	game.timeline = append(game.timeline, sample)
+
+	if err := m.repository(game).PersistRoundScores(context.Background(), game.ID, sample); err != nil {
+		logger.Error("Failed to persist round scores", "error", err, "room_code", game.RoomCode, "round", round.RoundNumber)
+	}
+}
+
+// liveScoreTimeline builds the timeline of a game held in memory
+func liveScoreTimeline(game *GameState) *ScoreTimeline {
+	timeline := &ScoreTimeline{
+		GameID:   game.ID,
+		RoomCode: game.RoomCode,
+		Status:   game.Status,
+		Players:  make([]TimelinePlayer, 0, len(game.Players)),
+		Rounds:   append([]RoundScoreSample{}, game.timeline...),
+	}
+	for _, player := range game.Players {
+		timeline.Players = append(timeline.Players, TimelinePlayer{ID: player.ID, Name: player.Name, IsBot: player.IsBot, Token: player.Token})
+	}
+	sortTimelinePlayers(timeline.Players)
+	return timeline
+}
+
+// sortTimelinePlayers orders series by name so charts keep stable colours
+func sortTimelinePlayers(players []TimelinePlayer) {
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Name != players[j].Name {
+			return players[i].Name < players[j].Name
+		}
+		return players[i].ID.String() < players[j].ID.String()
+	})
+}
+
+// GetScoreTimeline returns the score history of a live game, or of the last recorded game in the room
+func (m *Manager) GetScoreTimeline(ctx context.Context, roomCode string) (*ScoreTimeline, error) {
+	if game := m.getGame(roomCode); game != nil {
+		game.mu.RLock()
+		defer game.mu.RUnlock()
+		return liveScoreTimeline(game), nil
+	}
+	return m.LoadScoreTimeline(ctx, roomCode)
+}
+
+// PersistRoundScores saves every player's scores at the end of a round
+func (m *Manager) PersistRoundScores(ctx context.Context, gameID uuid.UUID, sample RoundScoreSample) error {
+	if len(sample.Scores) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	rows := make([]models.RoundScore, 0, len(sample.Scores))
+	for playerID, score := range sample.Scores {
+		rows = append(rows, models.RoundScore{
+			ID:          uuid.New(),
+			GameID:      gameID,
+			RoundID:     sample.RoundID,
+			RoundNumber: sample.RoundNumber,
+			PlayerID:    playerID,
+			Score:       score,
+			Earned:      sample.Earned[playerID],
+			CreatedAt:   now,
+		})
+	}
+
+	if err := m.db.WithContext(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to persist round scores: %w", err)
+	}
+	return nil
+}
+
+// LoadScoreTimeline rebuilds the timeline of the last recorded game in a room from the database
+func (m *Manager) LoadScoreTimeline(ctx context.Context, roomCode string) (*ScoreTimeline, error) {
+	db := m.db.WithContext(ctx)
+
+	// Finished games may have been deleted from the lobby, but their scores remain
+	var record models.Game
+	if err := db.Unscoped().Where("room_code = ?", roomCode).Order("created_at DESC").First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTimelineNotFound
+		}
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+
+	var rows []models.RoundScore
+	if err := db.Where("game_id = ?", record.ID).Order("round_number ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load round scores: %w", err)
+	}
+
+	timeline := &ScoreTimeline{
+		GameID:   record.ID,
+		RoomCode: record.RoomCode,
+		Status:   record.Status,
+		Players:  []TimelinePlayer{},
+		Rounds:   []RoundScoreSample{},
+	}
+
+	playerIDs := make(map[uuid.UUID]bool)
+	for _, row := range rows {
+		last := len(timeline.Rounds) - 1
+		if last < 0 || timeline.Rounds[last].RoundID != row.RoundID {
+			timeline.Rounds = append(timeline.Rounds, RoundScoreSample{
+				RoundID:     row.RoundID,
+				RoundNumber: row.RoundNumber,
+				Scores:      make(map[uuid.UUID]int),
+				Earned:      make(map[uuid.UUID]int),
+			})
+			last++
+		}
+		timeline.Rounds[last].Scores[row.PlayerID] = row.Score
+		timeline.Rounds[last].Earned[row.PlayerID] = row.Earned
+		playerIDs[row.PlayerID] = true
+	}
+
+	if len(playerIDs) > 0 {
+		ids := make([]uuid.UUID, 0, len(playerIDs))
+		for id := range playerIDs {
+			ids = append(ids, id)
+		}
+		var players []models.Player
+		if err := db.Unscoped().Where("id IN ?", ids).Find(&players).Error; err != nil {
+			return nil, fmt.Errorf("failed to load players: %w", err)
+		}
+		var seats []models.GamePlayer
+		if err := db.Where("game_id = ?", record.ID).Find(&seats).Error; err != nil {
+			return nil, fmt.Errorf("failed to load game players: %w", err)
+		}
+		tokens := make(map[uuid.UUID]string, len(seats))
+		for _, seat := range seats {
+			tokens[seat.PlayerID] = seat.Token
+		}
+		for _, player := range players {
+			timeline.Players = append(timeline.Players, TimelinePlayer{
+				ID:    player.ID,
+				Name:  player.Name,
+				IsBot: player.Type == models.PlayerTypeBot,
+				Token: tokens[player.ID],
+			})
+		}
+		sortTimelinePlayers(timeline.Players)
+	}
+
+	return timeline, nil
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordRoundScores(t *testing.T) {
+	alice := &Player{ID: uuid.New(), Name: "Alice"}
+	bob := &Player{ID: uuid.New(), Name: "Bob", IsBot: true}
+	gs := &GameState{
+		RoomCode: "TIMELN",
+		Sandbox:  true,
+		Players:  map[uuid.UUID]*Player{alice.ID: alice, bob.ID: bob},
+	}
+	m := &Manager{}
+
+	gs.CurrentRound = &Round{ID: uuid.New(), RoundNumber: 1}
+	alice.Score, bob.Score = 3, 0
+	m.recordRoundScores(gs, map[uuid.UUID]int{})
+
+	gs.CurrentRound = &Round{ID: uuid.New(), RoundNumber: 2}
+	previous := map[uuid.UUID]int{alice.ID: 3, bob.ID: 0}
+	alice.Score, bob.Score = 5, 3
+	m.recordRoundScores(gs, previous)
+
+	timeline := liveScoreTimeline(gs)
+	assert.Equal(t, []TimelinePlayer{
+		{ID: alice.ID, Name: "Alice"},
+		{ID: bob.ID, Name: "Bob", IsBot: true},
+	}, timeline.Players)
+	assert.Len(t, timeline.Rounds, 2)
+
+	assert.Equal(t, 1, timeline.Rounds[0].RoundNumber)
+	assert.Equal(t, 3, timeline.Rounds[0].Scores[alice.ID])
+	assert.Equal(t, 3, timeline.Rounds[0].Earned[alice.ID])
+
+	assert.Equal(t, 2, timeline.Rounds[1].RoundNumber)
+	assert.Equal(t, 5, timeline.Rounds[1].Scores[alice.ID])
+	assert.Equal(t, 2, timeline.Rounds[1].Earned[alice.ID])
+	assert.Equal(t, 3, timeline.Rounds[1].Earned[bob.ID])
+}
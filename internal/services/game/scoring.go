@@ -0,0 +1,316 @@
+package game
+
+import (
+	"dixitme/internal/services/experiments"
+
+	"github.com/google/uuid"
+)
+
+// Experimental vote stake parameters
+const (
+	maxVoteWeight     = 3
+	doubleDownBonus   = 3 // Extra points for a correct double-down
+	doubleDownPenalty = 2 // Points lost on a wrong double-down
+)
+
+// ScoringContext is everything a scoring strategy needs to score one round
+type ScoringContext struct {
+	Round   *Round
+	Scores  map[uuid.UUID]int // Scores before this round
+	History *ScoringHistory
+}
+
+// ScoringStrategy calculates the points each player earns in a round.
+// Strategies can be wrapped by decorators that adjust the points of the strategy they wrap.
+type ScoringStrategy interface {
+	Score(sc *ScoringContext) map[uuid.UUID]int
+}
+
+// NewScoringStrategy builds the scoring strategy for a room from its options
+// and any experiments the room has opted into
+func NewScoringStrategy(opts ScoringOptions, experimentKeys ...string) ScoringStrategy {
+	return buildScoringStrategy(DixitScoring{}, opts, experimentKeys...)
+}
+
+// buildScoringStrategy wraps a base strategy with the room's options and
+// experiments
+func buildScoringStrategy(strategy ScoringStrategy, opts ScoringOptions, experimentKeys ...string) ScoringStrategy {
+
+	for _, key := range experimentKeys {
+		switch key {
+		case experiments.WeightedVotes:
+			strategy = WeightedVotes{Next: strategy}
+		case experiments.DoubleDown:
+			strategy = DoubleDownBets{Next: strategy}
+		}
+	}
+
+	if opts.DiminishingFooling {
+		strategy = DiminishingFooling{Next: strategy, FullValue: opts.FoolingFullValue}
+	}
+	if opts.StreakBonus {
+		strategy = StreakBonus{Next: strategy, Threshold: opts.StreakThreshold, Points: opts.StreakBonusPoints}
+	}
+	// Applied last so the cap sees every other adjustment
+	if opts.StorytellerCap {
+		strategy = StorytellerCap{Next: strategy, MaxLead: opts.StorytellerMaxLead}
+	}
+
+	return strategy
+}
+
+// DixitScoring implements the standard Dixit scoring rules
+type DixitScoring struct{}
+
+// Score awards storyteller, correct-guess and fooling points
+func (DixitScoring) Score(sc *ScoringContext) map[uuid.UUID]int {
+	round := sc.Round
+	points := make(map[uuid.UUID]int, len(sc.Scores))
+	for playerID := range sc.Scores {
+		points[playerID] = 0
+	}
+
+	storytellerVotes := 0
+	for _, vote := range round.Votes {
+		if vote.CardID == round.StorytellerCard {
+			storytellerVotes++
+		}
+	}
+
+	if storytellerVotes == 0 || storytellerVotes == len(round.Votes) {
+		// All or none guessed correctly: Storyteller gets 0, others get 2
+		for playerID := range sc.Scores {
+			if playerID != round.StorytellerID {
+				points[playerID] += 2
+			}
+		}
+	} else {
+		// Some guessed correctly: Storyteller + correct guessers get 3
+		points[round.StorytellerID] += 3
+		for _, vote := range round.Votes {
+			if vote.CardID == round.StorytellerCard {
+				points[vote.PlayerID] += 3
+			}
+		}
+	}
+
+	// One point per vote received on a submitted card
+	for _, vote := range round.Votes {
+		if submitterID, ok := cardSubmitter(round, vote.CardID); ok {
+			points[submitterID]++
+		}
+	}
+
+	return points
+}
+
+// StreakBonus awards extra points to players on a run of consecutive correct guesses
+type StreakBonus struct {
+	Next      ScoringStrategy
+	Threshold int // Streak length (including this round) at which the bonus starts
+	Points    int
+}
+
+// Score adds the streak bonus to the wrapped strategy's points
+func (s StreakBonus) Score(sc *ScoringContext) map[uuid.UUID]int {
+	points := s.Next.Score(sc)
+
+	for _, vote := range sc.Round.Votes {
+		if vote.CardID != sc.Round.StorytellerCard {
+			continue
+		}
+		if sc.History.Streak(vote.PlayerID)+1 >= s.Threshold {
+			points[vote.PlayerID] += s.Points
+		}
+	}
+
+	return points
+}
+
+// DiminishingFooling reduces the value of repeatedly fooling the same voter.
+// After FullValue times, only every other fooling of that voter scores a point.
+type DiminishingFooling struct {
+	Next      ScoringStrategy
+	FullValue int
+}
+
+// Score removes fooling points that have lost their value
+func (d DiminishingFooling) Score(sc *ScoringContext) map[uuid.UUID]int {
+	points := d.Next.Score(sc)
+
+	for _, vote := range sc.Round.Votes {
+		submitterID, ok := cardSubmitter(sc.Round, vote.CardID)
+		if !ok {
+			continue
+		}
+		times := sc.History.TimesFooled(submitterID, vote.PlayerID) + 1
+		if times > d.FullValue && (times-d.FullValue)%2 == 1 {
+			points[submitterID]--
+		}
+	}
+
+	return points
+}
+
+// StorytellerCap stops a storyteller from extending their lead beyond MaxLead
+// points over the next best player
+type StorytellerCap struct {
+	Next    ScoringStrategy
+	MaxLead int
+}
+
+// Score trims the storyteller's points so their lead stays within the cap
+func (c StorytellerCap) Score(sc *ScoringContext) map[uuid.UUID]int {
+	points := c.Next.Score(sc)
+	storytellerID := sc.Round.StorytellerID
+
+	if points[storytellerID] <= 0 {
+		return points
+	}
+
+	bestOther, hasOther := 0, false
+	for playerID, score := range sc.Scores {
+		if playerID == storytellerID {
+			continue
+		}
+		if total := score + points[playerID]; !hasOther || total > bestOther {
+			bestOther, hasOther = total, true
+		}
+	}
+	if !hasOther {
+		return points
+	}
+
+	limit := bestOther + c.MaxLead
+	current := sc.Scores[storytellerID]
+	if current+points[storytellerID] > limit {
+		points[storytellerID] = max(0, limit-current)
+	}
+
+	return points
+}
+
+// WeightedVotes lets voters stake extra confidence on their guess (weighted_votes experiment).
+// Each point of weight above 1 is won by a correct guesser, or handed to the owner of the
+// card a wrong guesser picked.
+type WeightedVotes struct {
+	Next ScoringStrategy
+}
+
+// Score moves the staked points to the guesser or the card owner
+func (w WeightedVotes) Score(sc *ScoringContext) map[uuid.UUID]int {
+	points := w.Next.Score(sc)
+
+	for _, vote := range sc.Round.Votes {
+		stake := vote.Weight - 1
+		if stake <= 0 {
+			continue
+		}
+		if vote.CardID == sc.Round.StorytellerCard {
+			points[vote.PlayerID] += stake
+		} else if submitterID, ok := cardSubmitter(sc.Round, vote.CardID); ok {
+			points[submitterID] += stake
+		}
+	}
+
+	return points
+}
+
+// DoubleDownBets applies once-per-game double-down bets (double_down experiment).
+// A correct bet earns a bonus; a wrong one costs points, never taking a score below zero.
+type DoubleDownBets struct {
+	Next ScoringStrategy
+}
+
+// Score adds the bonus or penalty for each double-down vote
+func (d DoubleDownBets) Score(sc *ScoringContext) map[uuid.UUID]int {
+	points := d.Next.Score(sc)
+
+	for _, vote := range sc.Round.Votes {
+		if !vote.DoubleDown {
+			continue
+		}
+		if vote.CardID == sc.Round.StorytellerCard {
+			points[vote.PlayerID] += doubleDownBonus
+		} else {
+			points[vote.PlayerID] = max(points[vote.PlayerID]-doubleDownPenalty, -sc.Scores[vote.PlayerID])
+		}
+	}
+
+	return points
+}
+
+// ScoringHistory tracks cross-round state needed by the scoring modifiers
+type ScoringHistory struct {
+	streaks     map[uuid.UUID]int
+	fooled      map[uuid.UUID]map[uuid.UUID]int // submitter -> voter -> times fooled
+	doubleDowns map[uuid.UUID]bool              // Players who have used their double-down
+}
+
+// NewScoringHistory creates an empty scoring history
+func NewScoringHistory() *ScoringHistory {
+	return &ScoringHistory{
+		streaks:     make(map[uuid.UUID]int),
+		fooled:      make(map[uuid.UUID]map[uuid.UUID]int),
+		doubleDowns: make(map[uuid.UUID]bool),
+	}
+}
+
+// HasDoubledDown reports whether the player has used their double-down this game
+func (h *ScoringHistory) HasDoubledDown(playerID uuid.UUID) bool {
+	if h == nil {
+		return false
+	}
+	return h.doubleDowns[playerID]
+}
+
+// Streak returns the player's current run of consecutive correct guesses
+func (h *ScoringHistory) Streak(playerID uuid.UUID) int {
+	if h == nil {
+		return 0
+	}
+	return h.streaks[playerID]
+}
+
+// TimesFooled returns how often submitter has drawn a vote from voter
+func (h *ScoringHistory) TimesFooled(submitterID, voterID uuid.UUID) int {
+	if h == nil {
+		return 0
+	}
+	return h.fooled[submitterID][voterID]
+}
+
+// Record updates streaks and fooling counts after a round has been scored.
+// Storytellers don't vote, so their streak carries over to the next round.
+func (h *ScoringHistory) Record(round *Round) {
+	for _, vote := range round.Votes {
+		if vote.DoubleDown {
+			h.doubleDowns[vote.PlayerID] = true
+		}
+
+		if vote.CardID == round.StorytellerCard {
+			h.streaks[vote.PlayerID]++
+			continue
+		}
+
+		h.streaks[vote.PlayerID] = 0
+		if submitterID, ok := cardSubmitter(round, vote.CardID); ok {
+			if h.fooled[submitterID] == nil {
+				h.fooled[submitterID] = make(map[uuid.UUID]int)
+			}
+			h.fooled[submitterID][vote.PlayerID]++
+		}
+	}
+}
+
+// cardSubmitter finds the non-storyteller player who submitted a card
+func cardSubmitter(round *Round, cardID int) (uuid.UUID, bool) {
+	for _, submission := range round.Submissions {
+		for _, submitted := range submission.Cards() {
+			if submitted == cardID {
+				return submission.PlayerID, true
+			}
+		}
+	}
+	return uuid.Nil, false
+}
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/services/experiments"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// newScoringRound builds a round where storyteller plays card 1 and each
+// other player submits card i+2; votes maps voter index to card ID
+func newScoringRound(players []uuid.UUID, votes map[int]int) *Round {
+	round := &Round{
+		StorytellerID:   players[0],
+		StorytellerCard: 1,
+		Submissions:     make(map[uuid.UUID]*CardSubmission),
+		Votes:           make(map[uuid.UUID]*Vote),
+	}
+	for i, playerID := range players[1:] {
+		round.Submissions[playerID] = &CardSubmission{PlayerID: playerID, CardID: i + 2}
+	}
+	for voter, cardID := range votes {
+		round.Votes[players[voter]] = &Vote{PlayerID: players[voter], CardID: cardID}
+	}
+	return round
+}
+
+func newScoringPlayers(n int) ([]uuid.UUID, map[uuid.UUID]int) {
+	players := make([]uuid.UUID, n)
+	scores := make(map[uuid.UUID]int, n)
+	for i := range players {
+		players[i] = uuid.New()
+		scores[players[i]] = 0
+	}
+	return players, scores
+}
+
+func TestDixitScoring(t *testing.T) {
+	players, scores := newScoringPlayers(4)
+
+	t.Run("some guessed correctly", func(t *testing.T) {
+		// Player 1 finds the storyteller card, players 2 and 3 vote for player 1's card
+		round := newScoringRound(players, map[int]int{1: 1, 2: 2, 3: 2})
+		points := DixitScoring{}.Score(&ScoringContext{Round: round, Scores: scores})
+
+		assert.Equal(t, 3, points[players[0]])
+		assert.Equal(t, 5, points[players[1]])
+		assert.Equal(t, 0, points[players[2]])
+		assert.Equal(t, 0, points[players[3]])
+	})
+
+	t.Run("everyone guessed correctly", func(t *testing.T) {
+		round := newScoringRound(players, map[int]int{1: 1, 2: 1, 3: 1})
+		points := DixitScoring{}.Score(&ScoringContext{Round: round, Scores: scores})
+
+		assert.Equal(t, 0, points[players[0]])
+		for _, playerID := range players[1:] {
+			assert.Equal(t, 2, points[playerID])
+		}
+	})
+}
+
+func TestStreakBonus(t *testing.T) {
+	players, scores := newScoringPlayers(4)
+	history := NewScoringHistory()
+	strategy := NewScoringStrategy(ScoringOptions{StreakBonus: true, StreakThreshold: 2, StreakBonusPoints: 1})
+
+	// Player 1 finds the storyteller card, players 2 and 3 vote for each other's cards
+	round := newScoringRound(players, map[int]int{1: 1, 2: 4, 3: 3})
+	points := strategy.Score(&ScoringContext{Round: round, Scores: scores, History: history})
+	assert.Equal(t, 3, points[players[1]], "no bonus before the threshold")
+	history.Record(round)
+
+	points = strategy.Score(&ScoringContext{Round: round, Scores: scores, History: history})
+	assert.Equal(t, 4, points[players[1]], "bonus once the streak reaches the threshold")
+}
+
+func TestDiminishingFooling(t *testing.T) {
+	players, scores := newScoringPlayers(4)
+	history := NewScoringHistory()
+	strategy := NewScoringStrategy(ScoringOptions{DiminishingFooling: true, FoolingFullValue: 1})
+
+	// Player 2 votes for player 1's card every round
+	round := newScoringRound(players, map[int]int{1: 1, 2: 2, 3: 1})
+	var fooling []int
+	for i := 0; i < 4; i++ {
+		points := strategy.Score(&ScoringContext{Round: round, Scores: scores, History: history})
+		fooling = append(fooling, points[players[1]]-3)
+		history.Record(round)
+	}
+
+	assert.Equal(t, []int{1, 0, 1, 0}, fooling)
+}
+
+func TestStorytellerCap(t *testing.T) {
+	players, scores := newScoringPlayers(4)
+	scores[players[0]] = 20
+	scores[players[1]] = 10
+	strategy := NewScoringStrategy(ScoringOptions{StorytellerCap: true, StorytellerMaxLead: 8})
+
+	// Player 2 finds the storyteller card; player 1 ends the round on 11 as the best other score
+	round := newScoringRound(players, map[int]int{1: 3, 2: 1, 3: 2})
+	points := strategy.Score(&ScoringContext{Round: round, Scores: scores})
+
+	assert.Equal(t, 0, points[players[0]], "storyteller already leads by more than the cap")
+	assert.Equal(t, 1, points[players[1]])
+	assert.Equal(t, 4, points[players[2]])
+}
+
+func TestWeightedVotes(t *testing.T) {
+	players, scores := newScoringPlayers(4)
+	strategy := NewScoringStrategy(ScoringOptions{}, experiments.WeightedVotes)
+
+	// Player 1 finds the storyteller card, players 2 and 3 vote for player 1's card
+	round := newScoringRound(players, map[int]int{1: 1, 2: 2, 3: 2})
+	round.Votes[players[1]].Weight = 3
+	round.Votes[players[2]].Weight = 2
+	points := strategy.Score(&ScoringContext{Round: round, Scores: scores, History: NewScoringHistory()})
+
+	// 3 for the correct guess, 2 for fooling, 2 staked on the guess, 1 staked against them
+	assert.Equal(t, 8, points[players[1]])
+	assert.Equal(t, 0, points[players[2]])
+	assert.Equal(t, 3, points[players[0]], "storyteller is unaffected by stakes")
+}
+
+func TestDoubleDownBets(t *testing.T) {
+	players, scores := newScoringPlayers(4)
+	scores[players[2]] = 1
+	history := NewScoringHistory()
+	strategy := NewScoringStrategy(ScoringOptions{}, experiments.DoubleDown)
+
+	round := newScoringRound(players, map[int]int{1: 1, 2: 2, 3: 2})
+	round.Votes[players[1]].DoubleDown = true
+	round.Votes[players[2]].DoubleDown = true
+	points := strategy.Score(&ScoringContext{Round: round, Scores: scores, History: history})
+
+	assert.Equal(t, 8, points[players[1]], "correct double-down earns the bonus")
+	assert.Equal(t, -1, points[players[2]], "wrong double-down never takes a score below zero")
+
+	history.Record(round)
+	assert.True(t, history.HasDoubledDown(players[1]))
+	assert.True(t, history.HasDoubledDown(players[2]))
+	assert.False(t, history.HasDoubledDown(players[3]))
+}
+
+func TestScoringOptionsValidate(t *testing.T) {
+	assert.NoError(t, DefaultGameSettings().Scoring.Validate())
+	assert.Error(t, ScoringOptions{StreakBonus: true, StreakThreshold: 1, StreakBonusPoints: 1}.Validate())
+	assert.Error(t, ScoringOptions{StorytellerCap: true}.Validate())
+}
@@ -0,0 +1,199 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// maxReportReasonLength caps the free-text reason on reports and bans
+const maxReportReasonLength = 500
+
+// ShadowBanSummary is a shadow ban with the reports its player has collected
+type ShadowBanSummary struct {
+	models.ShadowBan
+	ReportCount     int64 `json:"report_count"`      // All reports against the player
+	ReportsSinceBan int64 `json:"reports_since_ban"` // Reports filed while the ban was in place
+}
+
+// LoadShadowBans loads every shadow-banned player into memory. Call it once at startup.
+func (m *Manager) LoadShadowBans(ctx context.Context) error {
+	if m.db == nil {
+		return nil
+	}
+
+	var bans []models.ShadowBan
+	if err := m.db.WithContext(ctx).Find(&bans).Error; err != nil {
+		return fmt.Errorf("failed to load shadow bans: %w", err)
+	}
+
+	m.shadowBansMu.Lock()
+	m.shadowBans = make(map[uuid.UUID]bool, len(bans))
+	for _, ban := range bans {
+		m.shadowBans[ban.PlayerID] = true
+	}
+	m.shadowBansMu.Unlock()
+
+	logger.Info("Loaded shadow bans", "count", len(bans))
+	return nil
+}
+
+// IsShadowBanned reports whether a player's chat only reaches themselves
+func (m *Manager) IsShadowBanned(playerID uuid.UUID) bool {
+	m.shadowBansMu.RLock()
+	defer m.shadowBansMu.RUnlock()
+	return m.shadowBans[playerID]
+}
+
+// ShadowBan hides a player's chat from everyone else, in every room, from now
+// on. Their votes and cards still count, and reports against them keep
+// accumulating for moderators to review.
+func (m *Manager) ShadowBan(ctx context.Context, playerID uuid.UUID, reason string, bannedBy *uuid.UUID) (*models.ShadowBan, error) {
+	if m.db == nil {
+		return nil, fmt.Errorf("shadow bans need a database")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if len(reason) > maxReportReasonLength {
+		return nil, fmt.Errorf("reason must be at most %d characters", maxReportReasonLength)
+	}
+
+	var player models.Player
+	if err := m.db.WithContext(ctx).Select("id", "type").First(&player, "id = ?", playerID).Error; err != nil {
+		return nil, fmt.Errorf("player not found")
+	}
+	if player.Type != models.PlayerTypeHuman {
+		return nil, fmt.Errorf("bots cannot be shadow-banned")
+	}
+
+	ban := models.ShadowBan{PlayerID: playerID, Reason: reason, BannedBy: bannedBy, CreatedAt: time.Now()}
+	if err := m.db.WithContext(ctx).Save(&ban).Error; err != nil {
+		return nil, fmt.Errorf("failed to save shadow ban: %w", err)
+	}
+
+	m.shadowBansMu.Lock()
+	if m.shadowBans == nil {
+		m.shadowBans = make(map[uuid.UUID]bool)
+	}
+	m.shadowBans[playerID] = true
+	m.shadowBansMu.Unlock()
+
+	logger.Info("Player shadow-banned", "player_id", playerID, "banned_by", bannedBy)
+	return &ban, nil
+}
+
+// LiftShadowBan lets a player's chat reach everyone again. Messages sent
+// during the ban stay hidden.
+func (m *Manager) LiftShadowBan(ctx context.Context, playerID uuid.UUID) error {
+	if m.db == nil {
+		return fmt.Errorf("shadow bans need a database")
+	}
+
+	result := m.db.WithContext(ctx).Delete(&models.ShadowBan{}, "player_id = ?", playerID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to lift shadow ban: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("shadow ban not found")
+	}
+
+	m.shadowBansMu.Lock()
+	delete(m.shadowBans, playerID)
+	m.shadowBansMu.Unlock()
+
+	logger.Info("Shadow ban lifted", "player_id", playerID)
+	return nil
+}
+
+// ListShadowBans returns every shadow ban, most reported players first
+func (m *Manager) ListShadowBans(ctx context.Context) ([]ShadowBanSummary, error) {
+	if m.db == nil {
+		return []ShadowBanSummary{}, nil
+	}
+
+	var summaries []ShadowBanSummary
+	err := m.db.WithContext(ctx).
+		Table("shadow_bans").
+		Select(`shadow_bans.*,
+			COUNT(player_reports.id) AS report_count,
+			COUNT(player_reports.id) FILTER (WHERE player_reports.created_at >= shadow_bans.created_at) AS reports_since_ban`).
+		Joins("LEFT JOIN player_reports ON player_reports.reported_id = shadow_bans.player_id").
+		Group("shadow_bans.player_id").
+		Order("report_count DESC, shadow_bans.created_at DESC").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shadow bans: %w", err)
+	}
+	return summaries, nil
+}
+
+// PlayerReports returns the reports filed against a player, newest first
+func (m *Manager) PlayerReports(ctx context.Context, playerID uuid.UUID, limit int) ([]models.PlayerReport, error) {
+	if m.db == nil {
+		return []models.PlayerReport{}, nil
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	var reports []models.PlayerReport
+	if err := m.db.WithContext(ctx).Where("reported_id = ?", playerID).
+		Order("created_at DESC").Limit(limit).Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("failed to load player reports: %w", err)
+	}
+	return reports, nil
+}
+
+// ReportPlayer records a report against another player in the same room.
+// Reporting the same player twice in one game counts once.
+func (m *Manager) ReportPlayer(roomCode string, reporterID, reportedID uuid.UUID, reason string) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if len(reason) > maxReportReasonLength {
+		return fmt.Errorf("reason must be at most %d characters", maxReportReasonLength)
+	}
+	if reporterID == reportedID {
+		return fmt.Errorf("cannot report yourself")
+	}
+
+	game.mu.RLock()
+	_, reporterSeated := game.Players[reporterID]
+	reported, reportedSeated := game.Players[reportedID]
+	reportedIsBot := reportedSeated && reported.IsBot
+	game.mu.RUnlock()
+
+	if !reporterSeated {
+		return fmt.Errorf("player not in game")
+	}
+	if !reportedSeated {
+		return fmt.Errorf("reported player not found in game")
+	}
+	if reportedIsBot {
+		return fmt.Errorf("bots cannot be reported")
+	}
+
+	report := &models.PlayerReport{
+		ID:         uuid.New(),
+		GameID:     game.ID,
+		ReporterID: reporterID,
+		ReportedID: reportedID,
+		Reason:     reason,
+		CreatedAt:  time.Now(),
+	}
+	if err := m.repository(game).PersistPlayerReport(context.Background(), report); err != nil {
+		return err
+	}
+
+	logger.Info("Player reported", "room_code", roomCode, "reporter_id", reporterID, "reported_id", reportedID)
+	return nil
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShadowBannedChatOnlyReachesAuthor(t *testing.T) {
+	m := NewEphemeralManager()
+	host, guest := uuid.New(), uuid.New()
+	_, err := m.CreateGameWithOptions("SHADOW", host, "Alice", CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+	game, err := m.JoinGame("SHADOW", guest, "Bob")
+	require.NoError(t, err)
+
+	hostConn, guestConn := &recordingConnection{}, &recordingConnection{}
+	game.Players[host].Connection, game.Players[host].IsConnected = hostConn, true
+	game.Players[guest].Connection, game.Players[guest].IsConnected = guestConn, true
+
+	m.shadowBans = map[uuid.UUID]bool{guest: true}
+	require.NoError(t, m.SendChatMessage("SHADOW", guest, "you all cheat", "chat"))
+	assert.Equal(t, []MessageType{MessageTypeChatMessage}, guestConn.types())
+	assert.Empty(t, hostConn.types())
+
+	require.NoError(t, m.SendChatMessage("SHADOW", host, "welcome", "chat"))
+	assert.Equal(t, []MessageType{MessageTypeChatMessage}, hostConn.types())
+	assert.Len(t, guestConn.types(), 2)
+}
+
+func TestReportPlayer(t *testing.T) {
+	m := NewEphemeralManager()
+	host, guest := uuid.New(), uuid.New()
+	_, err := m.CreateGameWithOptions("REPORT", host, "Alice", CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+	_, err = m.JoinGame("REPORT", guest, "Bob")
+	require.NoError(t, err)
+
+	assert.NoError(t, m.ReportPlayer("REPORT", host, guest, "spam"))
+	assert.EqualError(t, m.ReportPlayer("REPORT", host, host, ""), "cannot report yourself")
+	assert.EqualError(t, m.ReportPlayer("REPORT", host, uuid.New(), ""), "reported player not found in game")
+	assert.EqualError(t, m.ReportPlayer("REPORT", uuid.New(), guest, ""), "player not in game")
+}
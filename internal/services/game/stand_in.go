@@ -0,0 +1,77 @@
+package game
+
+import (
+	"sort"
+
+	"dixitme/internal/events"
+	"dixitme/internal/models"
+)
+
+// standInNoticeHook tells players who were replaced by a bot how their
+// stand-in did over their connection, and publishes the report for those
+// who are offline to find later (e.g. in their activity feed)
+type standInNoticeHook struct {
+	NopLifecycleHook
+	manager *Manager
+}
+
+func (h standInNoticeHook) OnGameCompleted(game *GameState, result *GameResult) {
+	if game.Sandbox || game.Status != models.GameStatusCompleted {
+		return
+	}
+
+	var scores []FinalScore
+	for playerID, player := range game.Players {
+		if !player.WasReplaced || player.ReplacementID == nil || player.IsBot {
+			continue
+		}
+		standIn, exists := game.Players[*player.ReplacementID]
+		if !exists {
+			continue
+		}
+		if scores == nil {
+			scores = finalScores(game)
+		}
+
+		payload := StandInResultPayload{
+			GameID:      game.ID,
+			RoomCode:    game.RoomCode,
+			Outcome:     result.Outcome,
+			StandInID:   standIn.ID,
+			StandInName: standIn.Name,
+			StandInWon:  standIn.ID == result.WinnerID,
+			Scores:      scores,
+		}
+
+		delivered := h.manager.SendToPlayer(game, playerID, MessageTypeStandInResult, payload) == nil
+		events.Publish(h.manager.bus, TopicStandInReported, StandInReported{
+			PlayerID:  playerID,
+			Result:    payload,
+			Delivered: delivered,
+		})
+	}
+}
+
+// finalScores lists the scores of the players seated at the end, highest
+// first. Callers must hold the game lock.
+func finalScores(game *GameState) []FinalScore {
+	scores := make([]FinalScore, 0, len(game.Players))
+	for _, player := range game.Players {
+		if player.WasReplaced {
+			continue
+		}
+		scores = append(scores, FinalScore{
+			PlayerID: player.ID,
+			Name:     player.Name,
+			Score:    player.Score,
+			IsBot:    player.IsBot,
+		})
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Name < scores[j].Name
+	})
+	return scores
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/events"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandInResultReachesReplacedPlayers(t *testing.T) {
+	online, offline, host := uuid.New(), uuid.New(), uuid.New()
+	onlineBot, offlineBot := uuid.New(), uuid.New()
+
+	gs := &GameState{
+		ID:       uuid.New(),
+		RoomCode: "STANDIN",
+		Status:   models.GameStatusCompleted,
+		Players: map[uuid.UUID]*Player{
+			host:       {ID: host, Name: "Host", Score: 20, IsActive: true},
+			online:     {ID: online, Name: "Alice", WasReplaced: true, ReplacementID: &onlineBot},
+			offline:    {ID: offline, Name: "Bob", WasReplaced: true, ReplacementID: &offlineBot},
+			onlineBot:  {ID: onlineBot, Name: "Alice (bot)", Score: 31, IsBot: true, IsActive: true},
+			offlineBot: {ID: offlineBot, Name: "Bob (bot)", Score: 12, IsBot: true, IsActive: true},
+		},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}, bus: events.NewBus()}
+	var undelivered []uuid.UUID
+	events.Subscribe(m.Events(), TopicStandInReported, func(e StandInReported) {
+		if !e.Delivered {
+			undelivered = append(undelivered, e.PlayerID)
+		}
+	})
+
+	conn := &recordingConnection{}
+	RegisterPlayerConnection(online, conn)
+	defer UnregisterPlayerConnection(online, conn)
+
+	standInNoticeHook{manager: m}.OnGameCompleted(gs, &GameResult{
+		GameID:   gs.ID,
+		WinnerID: onlineBot,
+		Outcome:  models.GameOutcomeCompleted,
+	})
+
+	require.Equal(t, []MessageType{MessageTypeStandInResult}, conn.types())
+	payload := conn.messages[0].Payload.(map[string]interface{})
+	assert.Equal(t, true, payload["stand_in_won"])
+	assert.Equal(t, onlineBot.String(), payload["stand_in_id"])
+	scores := payload["scores"].([]interface{})
+	require.Len(t, scores, 3, "replaced players are represented by their bots")
+	assert.Equal(t, "Alice (bot)", scores[0].(map[string]interface{})["name"])
+
+	assert.Equal(t, []uuid.UUID{offline}, undelivered, "offline players' reports are left for the feed")
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+
+	"github.com/google/uuid"
+)
+
+// Closer is implemented by connections the server can close itself, so a
+// connection that was taken over doesn't linger until the client hangs up
+type Closer interface {
+	Close() error
+}
+
+// connectionInUse refuses a new connection that only named the player ID of
+// someone still connected
+func connectionInUse() error {
+	return &GameError{
+		Code:    ErrCodeConnectionInUse,
+		Message: "this player is already connected; sign in or use your resume token to move here",
+	}
+}
+
+// CheckConnectionTakeover tells whether a new connection may take over the
+// player's current one. Player IDs are in every game state, so only a
+// newcomer who proved the ID, with a session JWT or a resume token, may.
+func CheckConnectionTakeover(playerID uuid.UUID, proven bool) error {
+	if proven || GetPlayerConnection(playerID) == nil {
+		return nil
+	}
+	return connectionInUse()
+}
+
+// ClaimPlayerConnection registers conn like RegisterPlayerConnection, unless
+// the player is still connected and the newcomer didn't prove the player ID,
+// checking and registering in one step
+func ClaimPlayerConnection(playerID uuid.UUID, conn Connection, proven bool) (Connection, error) {
+	connectionsMutex.Lock()
+	defer connectionsMutex.Unlock()
+	if previous := playerConnections[playerID]; !proven && previous != nil && previous != conn {
+		return nil, connectionInUse()
+	}
+	return registerConnectionLocked(playerID, conn), nil
+}
+
+// TakeOverConnection moves a player from a replaced connection, e.g. an older
+// browser tab, to their newest one. The old client is told its session moved
+// and closed where the transport allows; seats still bound to the old
+// connection are rebound, so the player keeps receiving game messages
+// without being marked disconnected in between.
+func (m *Manager) TakeOverConnection(playerID uuid.UUID, previous, current Connection) {
+	if previous == nil || previous == current {
+		return
+	}
+
+	if err := previous.SendJSON(GameMessage{
+		Type:    MessageTypeSessionReplaced,
+		Payload: ErrorPayload{Message: "You connected from another tab or device"},
+	}); err != nil {
+		logger.Debug("Previous connection already gone", "error", err, "player_id", playerID)
+	}
+	if closer, ok := previous.(Closer); ok {
+		if err := closer.Close(); err != nil {
+			logger.Debug("Failed to close previous connection", "error", err, "player_id", playerID)
+		}
+	}
+
+	for _, game := range m.GetAllGames() {
+		game.mu.Lock()
+		if player, exists := game.Players[playerID]; exists && player.Connection == previous {
+			player.Connection = current
+		}
+		game.mu.Unlock()
+	}
+
+	metrics.GetCounter(metrics.Name("connection_takeovers_total", "transport", current.Transport())).Inc()
+	logger.Info("Connection taken over",
+		"player_id", playerID,
+		"previous_transport", previous.Transport(),
+		"transport", current.Transport())
+}
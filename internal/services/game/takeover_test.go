@@ -0,0 +1,90 @@
+package game
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// closingConnection is a recording connection the server can close
+type closingConnection struct {
+	recordingConnection
+	closed bool
+}
+
+func (c *closingConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestRegistryNewestConnectionWins(t *testing.T) {
+	playerID := uuid.New()
+	first, second := &recordingConnection{}, &recordingConnection{}
+
+	assert.Nil(t, RegisterPlayerConnection(playerID, first))
+	assert.Nil(t, RegisterPlayerConnection(playerID, first), "registering the same connection again replaces nothing")
+	assert.Equal(t, Connection(first), RegisterPlayerConnection(playerID, second))
+
+	// The first tab closing must not unregister the second
+	assert.False(t, UnregisterPlayerConnection(playerID, first))
+	assert.Equal(t, Connection(second), GetPlayerConnection(playerID))
+
+	assert.True(t, UnregisterPlayerConnection(playerID, second))
+	assert.Nil(t, GetPlayerConnection(playerID))
+}
+
+func TestClaimPlayerConnectionNeedsProofToReplace(t *testing.T) {
+	playerID := uuid.New()
+	first, second := &recordingConnection{}, &recordingConnection{}
+
+	previous, err := ClaimPlayerConnection(playerID, first, false)
+	require.NoError(t, err, "a guest may connect when nobody else is")
+	assert.Nil(t, previous)
+	defer UnregisterPlayerConnection(playerID, first)
+
+	// Player IDs are public, so naming one doesn't take its connection
+	assert.ErrorIs(t, CheckConnectionTakeover(playerID, false), &GameError{Code: ErrCodeConnectionInUse})
+	_, err = ClaimPlayerConnection(playerID, second, false)
+	assert.ErrorIs(t, err, &GameError{Code: ErrCodeConnectionInUse})
+	assert.Equal(t, Connection(first), GetPlayerConnection(playerID))
+
+	assert.NoError(t, CheckConnectionTakeover(playerID, true))
+	previous, err = ClaimPlayerConnection(playerID, second, true)
+	require.NoError(t, err)
+	defer UnregisterPlayerConnection(playerID, second)
+	assert.Equal(t, Connection(first), previous)
+}
+
+func TestTakeOverConnection(t *testing.T) {
+	playerID := uuid.New()
+	oldTab, newTab := &closingConnection{}, &recordingConnection{}
+
+	gs := &GameState{
+		RoomCode: "TABS",
+		Status:   models.GameStatusInProgress,
+		Players: map[uuid.UUID]*Player{
+			playerID: {ID: playerID, Name: "Alice", Connection: oldTab, IsConnected: true, IsActive: true},
+		},
+	}
+	m := &Manager{games: map[string]*GameState{gs.RoomCode: gs}}
+
+	RegisterPlayerConnection(playerID, oldTab)
+	previous := RegisterPlayerConnection(playerID, newTab)
+	defer UnregisterPlayerConnection(playerID, newTab)
+	m.TakeOverConnection(playerID, previous, newTab)
+
+	assert.Equal(t, []MessageType{MessageTypeSessionReplaced}, oldTab.types())
+	assert.True(t, oldTab.closed)
+
+	player := gs.Players[playerID]
+	assert.Equal(t, Connection(newTab), player.Connection)
+	assert.True(t, player.IsConnected)
+
+	m.BroadcastToGame(gs, MessageTypeGameState, GameStatePayload{GameState: gs})
+	assert.Equal(t, []MessageType{MessageTypeGameState}, newTab.types())
+	assert.Len(t, oldTab.messages, 1)
+}
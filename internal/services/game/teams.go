@@ -0,0 +1,187 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"dixitme/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// Team sizes a room can play with (0 = every player for themselves)
+const (
+	minTeamSize = 2
+	maxTeamSize = 3
+)
+
+// TeamStanding is a team's shared score
+type TeamStanding struct {
+	Team    int         `json:"team"`
+	Players []uuid.UUID `json:"players"`
+	Score   int         `json:"score"` // Sum of the members' scores
+}
+
+// validateTeams checks that a team size can split a table into teams
+func validateTeams(teamSize, players int) error {
+	if teamSize == 0 {
+		return nil
+	}
+	if players%teamSize != 0 || players/teamSize < 2 {
+		return fmt.Errorf("a table of %d can't be split into teams of %d", players, teamSize)
+	}
+	return nil
+}
+
+// assignTeams splits the table into teams by seat, alternating so teammates
+// don't tell stories back to back, and records them. Callers hold the game lock.
+func (m *Manager) assignTeams(game *GameState) error {
+	teamSize := game.Settings.Rules.TeamSize
+	if teamSize == 0 {
+		return nil
+	}
+	if err := validateTeams(teamSize, len(game.Players)); err != nil {
+		return err
+	}
+
+	teamCount := len(game.Players) / teamSize
+	teams := make(map[uuid.UUID]int, len(game.Players))
+	for i, player := range game.playersBySeat() {
+		player.Team = i%teamCount + 1
+		teams[player.ID] = player.Team
+	}
+	if err := m.repository(game).UpdatePlayerTeams(context.Background(), game.ID, teams); err != nil {
+		return err
+	}
+
+	logger.Info("Teams assigned", "room_code", game.RoomCode, "teams", teamCount, "team_size", teamSize)
+	return nil
+}
+
+// teammates reports whether two players are on the same team
+func (gs *GameState) teammates(a, b uuid.UUID) bool {
+	playerA, okA := gs.Players[a]
+	playerB, okB := gs.Players[b]
+	return okA && okB && a != b && playerA.Team != 0 && playerA.Team == playerB.Team
+}
+
+// playerTeams maps players to their teams
+func (gs *GameState) playerTeams() map[uuid.UUID]int {
+	teams := make(map[uuid.UUID]int, len(gs.Players))
+	for playerID, player := range gs.Players {
+		if player.Team != 0 {
+			teams[playerID] = player.Team
+		}
+	}
+	return teams
+}
+
+// TeamStandings ranks the teams by their shared score, or returns nil when
+// the game isn't played in teams. A replaced player's score is counted once,
+// through whoever holds their seat. Callers hold the game lock.
+func (gs *GameState) TeamStandings() []TeamStanding {
+	byTeam := make(map[int]*TeamStanding)
+	for _, player := range gs.playersBySeat() {
+		if player.Team == 0 || player.WasReplaced {
+			continue
+		}
+		standing, exists := byTeam[player.Team]
+		if !exists {
+			standing = &TeamStanding{Team: player.Team}
+			byTeam[player.Team] = standing
+		}
+		standing.Players = append(standing.Players, player.ID)
+		standing.Score += player.Score
+	}
+	if len(byTeam) == 0 {
+		return nil
+	}
+
+	standings := make([]TeamStanding, 0, len(byTeam))
+	for _, standing := range byTeam {
+		standings = append(standings, *standing)
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Score != standings[j].Score {
+			return standings[i].Score > standings[j].Score
+		}
+		return standings[i].Team < standings[j].Team
+	})
+	return standings
+}
+
+// votableCards lists the revealed cards a player may vote for: not their
+// own, and not the storyteller's when they are on the storyteller's team.
+// Callers hold the game lock.
+func (gs *GameState) votableCards(playerID uuid.UUID) []int {
+	round := gs.CurrentRound
+	cards := votableCards(round, playerID)
+	if !gs.teammates(playerID, round.StorytellerID) {
+		return cards
+	}
+	allowed := make([]int, 0, len(cards))
+	for _, cardID := range cards {
+		if cardID != round.StorytellerCard {
+			allowed = append(allowed, cardID)
+		}
+	}
+	return allowed
+}
+
+// TeamScoring scores a round of a team game. It follows the standard rules,
+// except that the storyteller's teammates, who can't vote for the
+// storyteller's card, don't count toward everyone or no one finding it and
+// share the storyteller's fate when that happens, and votes between
+// teammates fool no one.
+type TeamScoring struct {
+	Teams map[uuid.UUID]int
+}
+
+// Score awards storyteller, correct-guess and fooling points
+func (t TeamScoring) Score(sc *ScoringContext) map[uuid.UUID]int {
+	round := sc.Round
+	points := make(map[uuid.UUID]int, len(sc.Scores))
+	for playerID := range sc.Scores {
+		points[playerID] = 0
+	}
+	sameTeam := func(a, b uuid.UUID) bool {
+		return a != b && t.Teams[a] != 0 && t.Teams[a] == t.Teams[b]
+	}
+
+	guessers, found := 0, 0
+	for _, vote := range round.Votes {
+		if sameTeam(vote.PlayerID, round.StorytellerID) {
+			continue
+		}
+		guessers++
+		if vote.CardID == round.StorytellerCard {
+			found++
+		}
+	}
+
+	if found == 0 || found == guessers {
+		// All or none of the other teams found it: they get 2, the storyteller's team nothing
+		for playerID := range sc.Scores {
+			if playerID != round.StorytellerID && !sameTeam(playerID, round.StorytellerID) {
+				points[playerID] += 2
+			}
+		}
+	} else {
+		points[round.StorytellerID] += 3
+		for _, vote := range round.Votes {
+			if vote.CardID == round.StorytellerCard {
+				points[vote.PlayerID] += 3
+			}
+		}
+	}
+
+	// One point per vote from another team received on a submitted card
+	for _, vote := range round.Votes {
+		if submitterID, ok := cardSubmitter(round, vote.CardID); ok && !sameTeam(submitterID, vote.PlayerID) {
+			points[submitterID]++
+		}
+	}
+
+	return points
+}
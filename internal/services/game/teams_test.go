@@ -0,0 +1,88 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignTeamsAlternatesSeats(t *testing.T) {
+	m, _, ids := kickVoteGame(t, "Alice", "Bob", "Cleo", "Dan")
+	gs := m.games["KICK"]
+
+	gs.Settings.Rules.TeamSize = 3
+	assert.EqualError(t, m.assignTeams(gs), "a table of 4 can't be split into teams of 3")
+
+	gs.Settings.Rules.TeamSize = 2
+	require.NoError(t, m.assignTeams(gs))
+	assert.Equal(t, []int{1, 2, 1, 2}, []int{gs.Players[ids[0]].Team, gs.Players[ids[1]].Team, gs.Players[ids[2]].Team, gs.Players[ids[3]].Team})
+	assert.True(t, gs.teammates(ids[0], ids[2]))
+	assert.False(t, gs.teammates(ids[0], ids[1]))
+	assert.False(t, gs.teammates(ids[0], ids[0]))
+}
+
+func TestTeammateCannotVoteForStorytellerCard(t *testing.T) {
+	m, gs, ids := votingGame()
+	gs.CurrentRound.StorytellerCard = 10
+	for i, id := range ids {
+		gs.Players[id].Team = i%2 + 1
+	}
+
+	assert.Equal(t, []int{11, 13}, gs.votableCards(ids[2]))
+	assert.Equal(t, []int{10, 12, 13}, gs.votableCards(ids[1]))
+
+	err := m.SubmitVote(gs.RoomCode, ids[2], 10)
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeTeammateVote}))
+	require.NoError(t, m.SubmitVote(gs.RoomCode, ids[1], 10))
+}
+
+func TestTeamScoring(t *testing.T) {
+	storyteller, mate, rivalA, rivalB := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	teams := map[uuid.UUID]int{storyteller: 1, mate: 1, rivalA: 2, rivalB: 2}
+	round := &Round{
+		StorytellerID:   storyteller,
+		StorytellerCard: 1,
+		Submissions: map[uuid.UUID]*CardSubmission{
+			mate:   {PlayerID: mate, CardID: 2},
+			rivalA: {PlayerID: rivalA, CardID: 3},
+			rivalB: {PlayerID: rivalB, CardID: 4},
+		},
+		Votes: map[uuid.UUID]*Vote{
+			mate:   {PlayerID: mate, CardID: 3},
+			rivalA: {PlayerID: rivalA, CardID: 1},
+			rivalB: {PlayerID: rivalB, CardID: 3},
+		},
+	}
+	scores := map[uuid.UUID]int{storyteller: 0, mate: 0, rivalA: 0, rivalB: 0}
+	sc := &ScoringContext{Round: round, Scores: scores, History: NewScoringHistory()}
+
+	// One of the two rivals found the card; the teammate's vote doesn't count
+	// toward that, and a vote from a teammate fools no one
+	points := TeamScoring{Teams: teams}.Score(sc)
+	assert.Equal(t, map[uuid.UUID]int{storyteller: 3, mate: 0, rivalA: 4, rivalB: 0}, points)
+
+	// Both rivals found it: the storyteller's team gets nothing
+	round.Votes[rivalB].CardID = 1
+	points = TeamScoring{Teams: teams}.Score(sc)
+	assert.Equal(t, map[uuid.UUID]int{storyteller: 0, mate: 0, rivalA: 3, rivalB: 2}, points)
+}
+
+func TestTeamStandings(t *testing.T) {
+	_, gs, ids := votingGame()
+	assert.Nil(t, gs.TeamStandings())
+
+	for i, id := range ids {
+		gs.Players[id].Team = i%2 + 1
+		gs.Players[id].Position = i + 1
+		gs.Players[id].Score = 5 * (i + 1)
+	}
+	gs.Players[ids[3]].WasReplaced = true
+
+	assert.Equal(t, []TeamStanding{
+		{Team: 1, Players: []uuid.UUID{ids[0], ids[2]}, Score: 20},
+		{Team: 2, Players: []uuid.UUID{ids[1]}, Score: 10},
+	}, gs.TeamStandings())
+}
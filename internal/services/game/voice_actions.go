@@ -0,0 +1,163 @@
+package game
+
+import (
+	"fmt"
+
+	"dixitme/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// VoiceService defines WebRTC voice signaling operations.
+// Audio flows peer-to-peer; the server only relays signaling and presence.
+type VoiceService interface {
+	JoinVoice(roomCode string, playerID uuid.UUID) error
+	LeaveVoice(roomCode string, playerID uuid.UUID) error
+	RelayVoiceSignal(roomCode string, fromID, toID uuid.UUID, signal VoiceSignal) error
+	SetVoiceState(roomCode string, playerID uuid.UUID, speaking, muted bool) error
+}
+
+// JoinVoice adds a player to the room's voice channel
+func (m *Manager) JoinVoice(roomCode string, playerID uuid.UUID) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if !game.Settings.VoiceChat {
+		return fmt.Errorf("voice chat is disabled for this room")
+	}
+
+	player, exists := game.Players[playerID]
+	if !exists || player.IsBot {
+		return fmt.Errorf("player not in game")
+	}
+
+	player.VoiceJoined = true
+	player.Speaking = false
+	player.UpdateActivity()
+
+	m.BroadcastToGame(game, MessageTypeVoicePeerJoined, VoicePeerJoinedPayload{
+		Peer:  voicePeer(player),
+		Peers: voicePeers(game),
+	})
+
+	logger.Info("Player joined voice", "room_code", roomCode, "player_id", playerID)
+	return nil
+}
+
+// LeaveVoice removes a player from the room's voice channel
+func (m *Manager) LeaveVoice(roomCode string, playerID uuid.UUID) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	player, exists := game.Players[playerID]
+	if !exists || !player.VoiceJoined {
+		return nil
+	}
+
+	m.leaveVoice(game, player)
+	return nil
+}
+
+// leaveVoice clears a player's voice state and tells their peers to hang up (game lock must be held)
+func (m *Manager) leaveVoice(game *GameState, player *Player) {
+	player.VoiceJoined = false
+	player.Speaking = false
+
+	m.BroadcastToGame(game, MessageTypeVoicePeerLeft, VoicePeerLeftPayload{PlayerID: player.ID})
+
+	logger.Info("Player left voice", "room_code", game.RoomCode, "player_id", player.ID)
+}
+
+// RelayVoiceSignal forwards an offer, answer or ICE candidate to a single peer
+func (m *Manager) RelayVoiceSignal(roomCode string, fromID, toID uuid.UUID, signal VoiceSignal) error {
+	switch signal.SignalType {
+	case VoiceSignalOffer, VoiceSignalAnswer:
+		if signal.SDP == "" {
+			return fmt.Errorf("sdp is required for %s", signal.SignalType)
+		}
+	case VoiceSignalICECandidate:
+		if len(signal.Candidate) == 0 {
+			return fmt.Errorf("candidate is required for ice_candidate")
+		}
+	default:
+		return fmt.Errorf("invalid signal type")
+	}
+	if len(signal.SDP)+len(signal.Candidate) > maxVoiceSignalSize {
+		return fmt.Errorf("signal too large")
+	}
+
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	from, exists := game.Players[fromID]
+	if !exists || !from.VoiceJoined {
+		return fmt.Errorf("join voice before signaling")
+	}
+	to, exists := game.Players[toID]
+	if !exists || !to.VoiceJoined {
+		return fmt.Errorf("peer is not in voice")
+	}
+
+	return m.SendToPlayer(game, toID, MessageTypeVoiceSignal, VoiceSignalPayload{
+		From:        fromID,
+		To:          toID,
+		VoiceSignal: signal,
+	})
+}
+
+// SetVoiceState updates and broadcasts a player's speaking indicator and mute state
+func (m *Manager) SetVoiceState(roomCode string, playerID uuid.UUID, speaking, muted bool) error {
+	game := m.getGame(roomCode)
+	if game == nil {
+		return fmt.Errorf("game not found")
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	player, exists := game.Players[playerID]
+	if !exists || !player.VoiceJoined {
+		return fmt.Errorf("player not in voice")
+	}
+
+	// Muted players can't be speaking
+	player.Speaking = speaking && !muted
+	player.Muted = muted
+
+	m.BroadcastToGame(game, MessageTypeVoiceState, VoiceStatePayload{Peer: voicePeer(player)})
+	return nil
+}
+
+func voicePeer(player *Player) VoicePeer {
+	return VoicePeer{
+		PlayerID: player.ID,
+		Name:     player.Name,
+		Speaking: player.Speaking,
+		Muted:    player.Muted,
+	}
+}
+
+func voicePeers(game *GameState) []VoicePeer {
+	peers := make([]VoicePeer, 0)
+	for _, player := range game.Players {
+		if player.VoiceJoined {
+			peers = append(peers, voicePeer(player))
+		}
+	}
+	return peers
+}
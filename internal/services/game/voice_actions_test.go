@@ -0,0 +1,143 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// voiceRoom seats players in a room with voice chat on, each on their own
+// recording connection. The first player hosts.
+func voiceRoom(m *Manager, roomCode string, names ...string) ([]uuid.UUID, []*recordingConnection) {
+	gs := &GameState{
+		ID:       uuid.New(),
+		RoomCode: roomCode,
+		Status:   models.GameStatusWaiting,
+		Sandbox:  true,
+		Settings: DefaultGameSettings(),
+		Players:  make(map[uuid.UUID]*Player),
+	}
+	gs.Settings.VoiceChat = true
+
+	ids := make([]uuid.UUID, len(names))
+	conns := make([]*recordingConnection, len(names))
+	for i, name := range names {
+		ids[i], conns[i] = uuid.New(), &recordingConnection{}
+		gs.Players[ids[i]] = &Player{ID: ids[i], Name: name, Position: i + 1, Connection: conns[i], IsConnected: true, IsActive: true}
+	}
+	gs.HostID = ids[0]
+	m.games[roomCode] = gs
+	return ids, conns
+}
+
+// voiceSignals returns the signals a connection was relayed
+func voiceSignals(t *testing.T, conn *recordingConnection) []VoiceSignalPayload {
+	t.Helper()
+	var signals []VoiceSignalPayload
+	for _, message := range conn.messages {
+		if message.Type != MessageTypeVoiceSignal {
+			continue
+		}
+		data, err := json.Marshal(message.Payload)
+		require.NoError(t, err)
+		var signal VoiceSignalPayload
+		require.NoError(t, json.Unmarshal(data, &signal))
+		signals = append(signals, signal)
+	}
+	return signals
+}
+
+func TestVoiceSignalsReachOnlyTheirPeer(t *testing.T) {
+	m := NewEphemeralManager()
+	ids, conns := voiceRoom(m, "TALK", "Alice", "Bob", "Cara")
+	alice, bob := ids[0], ids[1]
+	require.NoError(t, m.JoinVoice("TALK", alice))
+	require.NoError(t, m.JoinVoice("TALK", bob))
+
+	offer := VoiceSignal{SignalType: VoiceSignalOffer, SDP: "v=0 offer"}
+	answer := VoiceSignal{SignalType: VoiceSignalAnswer, SDP: "v=0 answer"}
+	candidate := VoiceSignal{SignalType: VoiceSignalICECandidate, Candidate: json.RawMessage(`{"candidate":"candidate:1 1 udp 2122260223 10.0.0.2 49152 typ host","sdpMid":"0"}`)}
+	require.NoError(t, m.RelayVoiceSignal("TALK", alice, bob, offer))
+	require.NoError(t, m.RelayVoiceSignal("TALK", bob, alice, answer))
+	require.NoError(t, m.RelayVoiceSignal("TALK", alice, bob, candidate))
+
+	toBob := voiceSignals(t, conns[1])
+	require.Len(t, toBob, 2)
+	assert.Equal(t, VoiceSignalPayload{From: alice, To: bob, VoiceSignal: offer}, toBob[0])
+	assert.Equal(t, alice, toBob[1].From)
+	assert.JSONEq(t, string(candidate.Candidate), string(toBob[1].Candidate))
+
+	toAlice := voiceSignals(t, conns[0])
+	require.Len(t, toAlice, 1)
+	assert.Equal(t, VoiceSignalPayload{From: bob, To: alice, VoiceSignal: answer}, toAlice[0])
+
+	assert.Empty(t, voiceSignals(t, conns[2]), "signals are not broadcast")
+}
+
+func TestVoiceSignalRefusals(t *testing.T) {
+	m := NewEphemeralManager()
+	ids, conns := voiceRoom(m, "TALK", "Alice", "Bob", "Cara")
+	alice, bob, cara := ids[0], ids[1], ids[2]
+	awayIDs, awayConns := voiceRoom(m, "AWAY", "Dan")
+	dan := awayIDs[0]
+	require.NoError(t, m.JoinVoice("TALK", alice))
+	require.NoError(t, m.JoinVoice("TALK", bob))
+	require.NoError(t, m.JoinVoice("AWAY", dan))
+
+	offer := VoiceSignal{SignalType: VoiceSignalOffer, SDP: "v=0"}
+	cases := []struct {
+		name     string
+		roomCode string
+		from, to uuid.UUID
+		signal   VoiceSignal
+		err      string
+	}{
+		{"peer in another room", "TALK", alice, dan, offer, "peer is not in voice"},
+		{"sender in another room", "TALK", dan, alice, offer, "join voice before signaling"},
+		{"peer not in voice", "TALK", alice, cara, offer, "peer is not in voice"},
+		{"sender not in voice", "TALK", cara, alice, offer, "join voice before signaling"},
+		{"unknown peer", "TALK", alice, uuid.New(), offer, "peer is not in voice"},
+		{"unknown room", "GONE", alice, bob, offer, "game not found"},
+		{"unknown signal type", "TALK", alice, bob, VoiceSignal{SignalType: "hangup"}, "invalid signal type"},
+		{"offer without sdp", "TALK", alice, bob, VoiceSignal{SignalType: VoiceSignalOffer}, "sdp is required"},
+		{"candidate missing", "TALK", alice, bob, VoiceSignal{SignalType: VoiceSignalICECandidate}, "candidate is required"},
+		{"oversized", "TALK", alice, bob, VoiceSignal{SignalType: VoiceSignalOffer, SDP: strings.Repeat("a", maxVoiceSignalSize+1)}, "signal too large"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := m.RelayVoiceSignal(tc.roomCode, tc.from, tc.to, tc.signal)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	for _, conn := range append(conns, awayConns...) {
+		assert.Empty(t, voiceSignals(t, conn), "nothing was relayed")
+	}
+}
+
+func TestVoiceFollowsRoomSetting(t *testing.T) {
+	m := NewEphemeralManager()
+	ids, conns := voiceRoom(m, "TALK", "Alice", "Bob", "Cara")
+	alice, bob := ids[0], ids[1]
+	require.NoError(t, m.JoinVoice("TALK", alice))
+	require.NoError(t, m.JoinVoice("TALK", bob))
+
+	gs := m.games["TALK"]
+	settings := gs.Settings
+	settings.VoiceChat = false
+	_, err := m.UpdateGameSettings("TALK", alice, settings)
+	require.NoError(t, err)
+
+	assert.False(t, gs.Players[alice].VoiceJoined)
+	assert.False(t, gs.Players[bob].VoiceJoined)
+	assert.Contains(t, conns[2].types(), MessageTypeVoicePeerLeft, "everyone hangs up")
+	assert.EqualError(t, m.JoinVoice("TALK", alice), "voice chat is disabled for this room")
+	assert.EqualError(t, m.RelayVoiceSignal("TALK", alice, bob, VoiceSignal{SignalType: VoiceSignalOffer, SDP: "v=0"}), "join voice before signaling")
+}
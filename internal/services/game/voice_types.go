@@ -0,0 +1,51 @@
+package game
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// WebRTC signal types relayed between peers
+const (
+	VoiceSignalOffer        = "offer"
+	VoiceSignalAnswer       = "answer"
+	VoiceSignalICECandidate = "ice_candidate"
+)
+
+// maxVoiceSignalSize caps SDP/ICE payloads relayed through the server
+const maxVoiceSignalSize = 16 * 1024
+
+// VoiceSignal is an opaque WebRTC signaling message relayed to a single peer
+type VoiceSignal struct {
+	SignalType string          `json:"signal_type"`         // offer, answer, ice_candidate
+	SDP        string          `json:"sdp,omitempty"`       // Session description for offers and answers
+	Candidate  json.RawMessage `json:"candidate,omitempty"` // RTCIceCandidateInit for ICE relay
+}
+
+// Voice message payloads
+type VoicePeer struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Name     string    `json:"name"`
+	Speaking bool      `json:"speaking"`
+	Muted    bool      `json:"muted"`
+}
+
+type VoicePeerJoinedPayload struct {
+	Peer  VoicePeer   `json:"peer"`
+	Peers []VoicePeer `json:"peers"` // Everyone currently in voice, so the newcomer can send offers
+}
+
+type VoicePeerLeftPayload struct {
+	PlayerID uuid.UUID `json:"player_id"`
+}
+
+type VoiceSignalPayload struct {
+	From uuid.UUID `json:"from"`
+	To   uuid.UUID `json:"to"`
+	VoiceSignal
+}
+
+type VoiceStatePayload struct {
+	Peer VoicePeer `json:"peer"`
+}
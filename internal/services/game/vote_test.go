@@ -0,0 +1,78 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func votingGame() (*Manager, *GameState, []uuid.UUID) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	gs := &GameState{
+		RoomCode: "VOTING",
+		Sandbox:  true,
+		Status:   models.GameStatusInProgress,
+		Players:  make(map[uuid.UUID]*Player),
+		CurrentRound: &Round{
+			RoundNumber:   1,
+			StorytellerID: ids[0],
+			Status:        models.RoundStatusVoting,
+			RevealedCards: []RevealedCard{
+				{CardID: 10, PlayerID: ids[0]},
+				{CardID: 11, PlayerID: ids[1]},
+				{CardID: 12, PlayerID: ids[2]},
+				{CardID: 13, PlayerID: ids[3]},
+			},
+			Votes: make(map[uuid.UUID]*Vote),
+		},
+		history: NewScoringHistory(),
+	}
+	for _, id := range ids {
+		gs.Players[id] = &Player{ID: id, IsActive: true}
+	}
+	return &Manager{games: map[string]*GameState{gs.RoomCode: gs}}, gs, ids
+}
+
+func TestSubmitVoteRejectsOwnCard(t *testing.T) {
+	m, gs, ids := votingGame()
+
+	err := m.SubmitVote(gs.RoomCode, ids[1], 11)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, &GameError{Code: ErrCodeSelfVote}))
+	gameErr, ok := AsGameError(err)
+	require.True(t, ok)
+	assert.Equal(t, 11, gameErr.Details["card_id"])
+	assert.Empty(t, gs.CurrentRound.Votes)
+
+	// The rejected vote doesn't use up the player's vote
+	require.NoError(t, m.SubmitVote(gs.RoomCode, ids[1], 12))
+	assert.Equal(t, 12, gs.CurrentRound.Votes[ids[1]].CardID)
+}
+
+func TestSubmitVoteUnknownCard(t *testing.T) {
+	m, gs, ids := votingGame()
+	assert.EqualError(t, m.SubmitVote(gs.RoomCode, ids[1], 99), "invalid card selection")
+}
+
+func TestVotableCardsExcludeOwnCard(t *testing.T) {
+	_, gs, ids := votingGame()
+
+	assert.Equal(t, []int{10, 12, 13}, votableCards(gs.CurrentRound, ids[1]))
+	assert.Equal(t, []int{10, 11, 12}, votableCards(gs.CurrentRound, ids[3]))
+}
+
+func TestReplacedPlayersAreNotWaitedOn(t *testing.T) {
+	_, gs, ids := votingGame()
+	assert.Equal(t, 4, gs.seatedPlayerCount())
+
+	standIn := uuid.New()
+	gs.Players[ids[3]].WasReplaced = true
+	gs.Players[ids[3]].ReplacementID = &standIn
+	gs.Players[standIn] = &Player{ID: standIn, IsBot: true, IsActive: true}
+	assert.Equal(t, 4, gs.seatedPlayerCount(), "the stand-in votes in their place")
+}
@@ -1,6 +1,10 @@
 package game
 
 import (
+	"time"
+
+	"dixitme/internal/models"
+
 	"github.com/google/uuid"
 )
 
@@ -14,22 +18,40 @@ type GameMessage struct {
 type MessageType string
 
 const (
-	MessageTypePlayerJoined   MessageType = "player_joined"
-	MessageTypePlayerLeft     MessageType = "player_left"
-	MessageTypePlayerReplaced MessageType = "player_replaced"
-	MessageTypeGameStarted    MessageType = "game_started"
-	MessageTypeRoundStarted   MessageType = "round_started"
-	MessageTypeClueSubmitted  MessageType = "clue_submitted"
-	MessageTypeCardSubmitted  MessageType = "card_submitted"
-	MessageTypeVotingStarted  MessageType = "voting_started"
-	MessageTypeVoteSubmitted  MessageType = "vote_submitted"
-	MessageTypeRoundCompleted MessageType = "round_completed"
-	MessageTypeGameCompleted  MessageType = "game_completed"
-	MessageTypeGameDeleted    MessageType = "game_deleted"
-	MessageTypeError          MessageType = "error"
-	MessageTypeGameState      MessageType = "game_state"
-	MessageTypeChatMessage    MessageType = "chat_message"
-	MessageTypeChatHistory    MessageType = "chat_history"
+	MessageTypePlayerJoined      MessageType = "player_joined"
+	MessageTypePlayerLeft        MessageType = "player_left"
+	MessageTypePlayerReplaced    MessageType = "player_replaced"
+	MessageTypeGameStarted       MessageType = "game_started"
+	MessageTypeRoundStarted      MessageType = "round_started"
+	MessageTypeClueSubmitted     MessageType = "clue_submitted"
+	MessageTypeCardSubmitted     MessageType = "card_submitted"
+	MessageTypeVotingStarted     MessageType = "voting_started"
+	MessageTypeVoteSubmitted     MessageType = "vote_submitted"
+	MessageTypeRoundCompleted    MessageType = "round_completed"
+	MessageTypeGameCompleted     MessageType = "game_completed"
+	MessageTypeGameDeleted       MessageType = "game_deleted"
+	MessageTypeError             MessageType = "error"
+	MessageTypeGameState         MessageType = "game_state"
+	MessageTypeChatMessage       MessageType = "chat_message"
+	MessageTypeChatHistory       MessageType = "chat_history"
+	MessageTypeVoicePeerJoined   MessageType = "voice_peer_joined"
+	MessageTypeVoicePeerLeft     MessageType = "voice_peer_left"
+	MessageTypeVoiceSignal       MessageType = "voice_signal"
+	MessageTypeVoiceState        MessageType = "voice_state"
+	MessageTypeResumeToken       MessageType = "resume_token"
+	MessageTypeSessionReplaced   MessageType = "session_replaced"
+	MessageTypeMulliganUsed      MessageType = "mulligan_used"
+	MessageTypeAccountPrompt     MessageType = "account_prompt"
+	MessageTypeStandInResult     MessageType = "stand_in_result"
+	MessageTypePhaseTimer        MessageType = "phase_timer"
+	MessageTypeSeatRestored      MessageType = "seat_restored"
+	MessageTypeDeckLow           MessageType = "deck_low"
+	MessageTypeHostChanged       MessageType = "host_changed"
+	MessageTypeKickVote          MessageType = "kick_vote"
+	MessageTypeTakeoverRequested MessageType = "takeover_requested"
+	MessageTypeTakeoverResolved  MessageType = "takeover_resolved"
+	MessageTypeRematch           MessageType = "rematch"
+	MessageTypeDebugEvent        MessageType = "debug_event"
 )
 
 // WebSocket message payloads
@@ -55,8 +77,46 @@ type RoundStartedPayload struct {
 	Round *Round `json:"round"`
 }
 
+// SeatRestoredPayload tells a reconnecting player which seat they are back
+// in, with their private hand and whether the round is waiting on them
+type SeatRestoredPayload struct {
+	RoomCode      string             `json:"room_code"`
+	GameID        uuid.UUID          `json:"game_id"`
+	Status        models.GameStatus  `json:"status"`
+	Phase         models.RoundStatus `json:"phase,omitempty"`
+	Hand          []int              `json:"hand"`
+	MoveDue       bool               `json:"move_due"`
+	PhaseDeadline *time.Time         `json:"phase_deadline,omitempty"`
+}
+
+// PhaseTimerPayload tells players how long they have left to move. Clients
+// count down from RemainingSeconds rather than the deadline, so their clock
+// needn't match the server's.
+type PhaseTimerPayload struct {
+	RoundID          uuid.UUID          `json:"round_id"`
+	Phase            models.RoundStatus `json:"phase"`
+	Deadline         time.Time          `json:"deadline"`
+	Seconds          int                `json:"seconds"`
+	RemainingSeconds int                `json:"remaining_seconds"`
+}
+
+// HostChangedPayload announces who runs the lobby after the host left it
+type HostChangedPayload struct {
+	HostID         uuid.UUID `json:"host_id"`
+	PreviousHostID uuid.UUID `json:"previous_host_id"`
+}
+
+// DeckLowPayload warns players, a round ahead, that the game will end
+// because the deck can no longer refill their hands
+type DeckLowPayload struct {
+	DeckRemaining int `json:"deck_remaining"`
+	RoundsLeft    int `json:"rounds_left"`
+}
+
 type ClueSubmittedPayload struct {
-	Clue string `json:"clue"`
+	Clue            string `json:"clue"`
+	Language        string `json:"language,omitempty"`         // Detected clue language
+	LanguageWarning string `json:"language_warning,omitempty"` // Set when the clue doesn't match the room language
 }
 
 type CardSubmittedPayload struct {
@@ -77,8 +137,17 @@ type RoundCompletedPayload struct {
 }
 
 type GameCompletedPayload struct {
-	FinalScores map[uuid.UUID]int `json:"final_scores"`
-	Winner      uuid.UUID         `json:"winner"`
+	FinalScores   map[uuid.UUID]int  `json:"final_scores"`
+	Winner        uuid.UUID          `json:"winner"`
+	Outcome       models.GameOutcome `json:"outcome,omitempty"`
+	RatingChanges map[uuid.UUID]int  `json:"rating_changes,omitempty"` // Ranked games only
+	Teams         []TeamStanding     `json:"teams,omitempty"`          // Team leaderboard, best first; team games only
+}
+
+type MulliganUsedPayload struct {
+	PlayerID   uuid.UUID `json:"player_id"`
+	PlayerName string    `json:"player_name"`
+	Cards      int       `json:"cards"` // Number of cards exchanged
 }
 
 type GameDeletedPayload struct {
@@ -87,7 +156,47 @@ type GameDeletedPayload struct {
 }
 
 type ErrorPayload struct {
-	Message string `json:"message"`
+	Message string                 `json:"message"`
+	Code    string                 `json:"code,omitempty"`    // Set for structured game errors
+	Details map[string]interface{} `json:"details,omitempty"` // Limits involved in a structured error
+}
+
+type ResumeTokenPayload struct {
+	RoomCode  string    `json:"room_code"`
+	Token     string    `json:"token"`      // Present as ?resume_token= when reconnecting
+	ExpiresAt time.Time `json:"expires_at"` // A fresh token is sent every round
+}
+
+type AccountPromptPayload struct {
+	Milestone    string    `json:"milestone"`     // third_game or first_win
+	UpgradeToken string    `json:"upgrade_token"` // Present to POST /auth/upgrade to keep this guest's history
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// StandInResultPayload tells a player replaced by a bot how the game ended
+type StandInResultPayload struct {
+	GameID      uuid.UUID          `json:"game_id"`
+	RoomCode    string             `json:"room_code"`
+	Outcome     models.GameOutcome `json:"outcome"`
+	StandInID   uuid.UUID          `json:"stand_in_id"`
+	StandInName string             `json:"stand_in_name"`
+	StandInWon  bool               `json:"stand_in_won"`
+	Scores      []FinalScore       `json:"scores"` // Highest first
+}
+
+type FinalScore struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Name     string    `json:"name"`
+	Score    int       `json:"score"`
+	IsBot    bool      `json:"is_bot"`
+}
+
+// DebugEventPayload is one entry of a room's debug stream
+type DebugEventPayload struct {
+	RoomCode string                 `json:"room_code"`
+	Kind     string                 `json:"kind"`
+	At       time.Time              `json:"at"`
+	Details  map[string]interface{} `json:"details"`
 }
 
 type GameStatePayload struct {
@@ -0,0 +1,84 @@
+package ladder
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"dixitme/internal/models"
+)
+
+const challongeBaseURL = "https://api.challonge.com/v1"
+
+// ChallongeAdapter reports results through the Challonge v1 API. Challonge
+// matches are head-to-head, so only two-player games can be reported; the
+// open match between the two participants is completed with their scores.
+type ChallongeAdapter struct {
+	api apiClient
+}
+
+// NewChallongeAdapter creates an adapter for the public Challonge API
+func NewChallongeAdapter() *ChallongeAdapter {
+	return &ChallongeAdapter{api: newAPIClient(challongeBaseURL)}
+}
+
+type challongeMatch struct {
+	Match struct {
+		ID        int `json:"id"`
+		Player1ID int `json:"player1_id"`
+		Player2ID int `json:"player2_id"`
+	} `json:"match"`
+}
+
+// ReportResult completes the open match between the game's two participants
+func (a *ChallongeAdapter) ReportResult(ctx context.Context, tournament models.Tournament, result Result, participants []Participant) error {
+	if len(participants) != 2 {
+		return Permanent(fmt.Errorf("challonge matches are head-to-head, game had %d players", len(participants)))
+	}
+
+	ids := make(map[int]Participant, 2)
+	for _, participant := range participants {
+		id, err := strconv.Atoi(participant.ExternalID)
+		if err != nil {
+			return Permanent(fmt.Errorf("challonge participant ID %q is not numeric", participant.ExternalID))
+		}
+		ids[id] = participant
+	}
+
+	tournamentPath := "/tournaments/" + url.PathEscape(tournament.ExternalID)
+	query := url.Values{"api_key": {tournament.APIKey}, "state": {"open"}}
+
+	var matches []challongeMatch
+	if err := a.api.do(ctx, http.MethodGet, tournamentPath+"/matches.json?"+query.Encode(), nil, nil, &matches); err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		player1, ok1 := ids[m.Match.Player1ID]
+		player2, ok2 := ids[m.Match.Player2ID]
+		if !ok1 || !ok2 || m.Match.Player1ID == m.Match.Player2ID {
+			continue
+		}
+
+		winner := "tie"
+		if player1.Rank < player2.Rank {
+			winner = player1.ExternalID
+		} else if player2.Rank < player1.Rank {
+			winner = player2.ExternalID
+		}
+
+		body := map[string]interface{}{
+			"api_key": tournament.APIKey,
+			"match": map[string]string{
+				"scores_csv": fmt.Sprintf("%d-%d", player1.Score, player2.Score),
+				"winner_id":  winner,
+			},
+		}
+		path := fmt.Sprintf("%s/matches/%d.json", tournamentPath, m.Match.ID)
+		return a.api.do(ctx, http.MethodPut, path, nil, body, nil)
+	}
+
+	return Permanent(fmt.Errorf("no open challonge match between participants %s and %s", participants[0].ExternalID, participants[1].ExternalID))
+}
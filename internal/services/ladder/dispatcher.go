@@ -0,0 +1,277 @@
+package ladder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// queueSize bounds the completed games waiting to be written to the outbox
+	queueSize = 256
+
+	// MaxAttempts is how often a delivery is tried before it is marked failed
+	MaxAttempts = 8
+
+	baseBackoff   = time.Minute
+	maxBackoff    = time.Hour
+	pollInterval  = 30 * time.Second
+	deliveryBatch = 20
+)
+
+// ErrDeliveryNotFound is returned when retrying a delivery that does not exist
+var ErrDeliveryNotFound = errors.New("ladder delivery not found")
+
+// completedGame is a tournament game's result on its way to the outbox
+type completedGame struct {
+	tournamentID uuid.UUID
+	result       Result
+}
+
+// Dispatcher records completed tournament games in the ladder outbox and
+// delivers them to their platform, retrying with exponential backoff. It
+// implements game.GameLifecycleHook.
+type Dispatcher struct {
+	game.NopLifecycleHook
+
+	db       *gorm.DB
+	adapters map[string]Adapter
+	queue    chan completedGame
+	wake     chan struct{}
+	closed   chan struct{}
+
+	mu      sync.RWMutex
+	stopped bool
+}
+
+// NewDispatcher creates a dispatcher delivering through adapters, keyed by
+// platform. Call Start before registering it with the game manager.
+func NewDispatcher(db *gorm.DB, adapters map[string]Adapter) *Dispatcher {
+	return &Dispatcher{
+		db:       db,
+		adapters: adapters,
+		queue:    make(chan completedGame, queueSize),
+		wake:     make(chan struct{}, 1),
+		closed:   make(chan struct{}),
+	}
+}
+
+// Start records and delivers results in the background until Stop is called
+func (d *Dispatcher) Start() {
+	go func() {
+		defer close(d.closed)
+
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case completed, ok := <-d.queue:
+				if !ok {
+					return
+				}
+				d.record(completed)
+				d.deliverDue(context.Background())
+			case <-d.wake:
+				d.deliverDue(context.Background())
+			case <-ticker.C:
+				d.deliverDue(context.Background())
+			}
+		}
+	}()
+}
+
+// Stop records the results still queued and stops the dispatcher. Pending
+// deliveries stay in the outbox and are picked up after a restart.
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+	d.stopped = true
+	close(d.queue)
+	d.mu.Unlock()
+	<-d.closed
+}
+
+// OnGameCompleted queues the result of a tournament game
+func (d *Dispatcher) OnGameCompleted(gs *game.GameState, _ *game.GameResult) {
+	if gs.Sandbox || gs.TournamentID == nil {
+		return
+	}
+
+	completed := completedGame{tournamentID: *gs.TournamentID, result: newResult(gs, time.Now())}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.stopped {
+		return
+	}
+
+	select {
+	case d.queue <- completed:
+	default:
+		metrics.GetCounter("ladder_dropped_results_total").Inc()
+		logger.Warn("Ladder queue full, dropping tournament result",
+			"tournament_id", completed.tournamentID,
+			"game_id", completed.result.GameID)
+	}
+}
+
+// Retry puts a delivery back in line for an immediate attempt, with its attempts reset
+func (d *Dispatcher) Retry(ctx context.Context, deliveryID uuid.UUID) (*models.LadderDelivery, error) {
+	var delivery models.LadderDelivery
+	if err := d.db.WithContext(ctx).First(&delivery, "id = ?", deliveryID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to load ladder delivery: %w", err)
+	}
+	if delivery.Status == models.LadderDeliveryDelivered {
+		return nil, fmt.Errorf("result was already delivered")
+	}
+
+	delivery.Status = models.LadderDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := d.db.WithContext(ctx).Save(&delivery).Error; err != nil {
+		return nil, fmt.Errorf("failed to requeue ladder delivery: %w", err)
+	}
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return &delivery, nil
+}
+
+// record writes a result to the outbox. A game is only recorded once per tournament.
+func (d *Dispatcher) record(completed completedGame) {
+	encoded, err := json.Marshal(completed.result)
+	if err != nil {
+		logger.Error("Failed to encode tournament result", "error", err, "game_id", completed.result.GameID)
+		return
+	}
+
+	delivery := models.LadderDelivery{}
+	err = d.db.Where(models.LadderDelivery{TournamentID: completed.tournamentID, GameID: completed.result.GameID}).
+		Attrs(models.LadderDelivery{
+			ID:            uuid.New(),
+			Status:        models.LadderDeliveryPending,
+			NextAttemptAt: time.Now(),
+			Result:        string(encoded),
+		}).
+		FirstOrCreate(&delivery).Error
+	if err != nil {
+		metrics.GetCounter("ladder_dropped_results_total").Inc()
+		logger.Error("Failed to record tournament result",
+			"error", err,
+			"tournament_id", completed.tournamentID,
+			"game_id", completed.result.GameID)
+	}
+}
+
+// deliverDue attempts every pending delivery whose backoff has elapsed
+func (d *Dispatcher) deliverDue(ctx context.Context) {
+	var due []models.LadderDelivery
+	if err := d.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", models.LadderDeliveryPending, time.Now()).
+		Order("next_attempt_at").
+		Limit(deliveryBatch).
+		Find(&due).Error; err != nil {
+		logger.Error("Failed to load due ladder deliveries", "error", err)
+		return
+	}
+
+	for i := range due {
+		d.attempt(ctx, &due[i])
+	}
+}
+
+// attempt delivers one result and records the outcome
+func (d *Dispatcher) attempt(ctx context.Context, delivery *models.LadderDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+
+	err := d.deliver(ctx, delivery)
+	switch {
+	case err == nil:
+		delivery.Status = models.LadderDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	case IsPermanent(err) || delivery.Attempts >= MaxAttempts:
+		delivery.Status = models.LadderDeliveryFailed
+		delivery.LastError = err.Error()
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(backoff(delivery.Attempts))
+	}
+	metrics.GetCounter(metrics.Name("ladder_delivery_attempts_total", "status", delivery.Status)).Inc()
+
+	if err != nil {
+		logger.Warn("Ladder delivery failed",
+			"error", err,
+			"delivery_id", delivery.ID,
+			"game_id", delivery.GameID,
+			"attempts", delivery.Attempts,
+			"status", delivery.Status)
+	} else {
+		logger.Info("Tournament result delivered", "delivery_id", delivery.ID, "game_id", delivery.GameID)
+	}
+
+	if err := d.db.WithContext(ctx).Save(delivery).Error; err != nil {
+		logger.Error("Failed to update ladder delivery", "error", err, "delivery_id", delivery.ID)
+	}
+}
+
+// deliver resolves the tournament, adapter and participant mapping and reports the result
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.LadderDelivery) error {
+	var tournament models.Tournament
+	if err := d.db.WithContext(ctx).First(&tournament, "id = ?", delivery.TournamentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Permanent(fmt.Errorf("tournament no longer exists"))
+		}
+		return fmt.Errorf("failed to load tournament: %w", err)
+	}
+	if !tournament.IsActive {
+		return Permanent(fmt.Errorf("tournament is inactive"))
+	}
+
+	adapter, exists := d.adapters[tournament.Platform]
+	if !exists {
+		return Permanent(fmt.Errorf("no adapter for platform %q", tournament.Platform))
+	}
+
+	var result Result
+	if err := json.Unmarshal([]byte(delivery.Result), &result); err != nil {
+		return Permanent(fmt.Errorf("failed to decode result: %w", err))
+	}
+
+	externalIDs, err := participantIDs(ctx, d.db, tournament.ID)
+	if err != nil {
+		return err
+	}
+	participants, err := mapParticipants(result, externalIDs)
+	if err != nil {
+		return err
+	}
+
+	return adapter.ReportResult(ctx, tournament, result, participants)
+}
+
+// backoff is the wait after the given number of failed attempts
+func backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxBackoff)
+}
@@ -0,0 +1,83 @@
+package ladder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// requestTimeout bounds one call to a platform API
+const requestTimeout = 15 * time.Second
+
+// apiClient is the JSON HTTP client shared by the adapters
+type apiClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newAPIClient(baseURL string) apiClient {
+	return apiClient{baseURL: baseURL, client: &http.Client{Timeout: requestTimeout}}
+}
+
+// do sends a JSON request and decodes the response into out, when given.
+// Timeouts, rate limiting and server errors are retryable; other client
+// errors are permanent.
+func (c apiClient) do(ctx context.Context, method, path string, header http.Header, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return Permanent(fmt.Errorf("failed to encode request: %w", err))
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to build request: %w", err))
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Errors end up in the outbox and logs, so they name the path without the
+	// query string, which may carry an API key
+	endpoint := req.URL.Path
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%s %s failed: %w", method, endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		err := fmt.Errorf("%s %s returned %d: %s", method, endpoint, resp.StatusCode, bytes.TrimSpace(snippet))
+		if resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return err
+		}
+		return Permanent(err)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, endpoint, err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Package ladder pushes completed tournament games to external tournament
+// platforms such as Challonge and Toornament. Results are written to an
+// outbox when a game completes and delivered in the background, with retries,
+// so platform outages never hold up a game.
+package ladder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"dixitme/internal/models"
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+)
+
+// Standing is one player's final position in a game
+type Standing struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Name     string    `json:"name"`
+	Score    int       `json:"score"`
+	Rank     int       `json:"rank"` // 1 for the winner; tied players share a rank
+}
+
+// Result is the snapshot of a completed game that is delivered to a platform
+type Result struct {
+	GameID      uuid.UUID  `json:"game_id"`
+	RoomCode    string     `json:"room_code"`
+	CompletedAt time.Time  `json:"completed_at"`
+	Standings   []Standing `json:"standings"` // Human players, best first
+}
+
+// Participant is a standing mapped to the platform's participant ID
+type Participant struct {
+	Standing
+	ExternalID string
+}
+
+// Adapter reports results to one tournament platform
+type Adapter interface {
+	// ReportResult records a finished match between participants. Errors
+	// wrapped with Permanent are not retried.
+	ReportResult(ctx context.Context, tournament models.Tournament, result Result, participants []Participant) error
+}
+
+// permanentError marks a failure that retrying cannot fix, such as a
+// rejected API key or a match that does not exist on the platform
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// IsPlatform reports whether platform has a built-in adapter
+func IsPlatform(platform string) bool {
+	return platform == models.LadderPlatformChallonge || platform == models.LadderPlatformToornament
+}
+
+// DefaultAdapters returns the built-in adapters keyed by platform
+func DefaultAdapters() map[string]Adapter {
+	return map[string]Adapter{
+		models.LadderPlatformChallonge:  NewChallongeAdapter(),
+		models.LadderPlatformToornament: NewToornamentAdapter(),
+	}
+}
+
+// newResult snapshots a completed game's human standings. Callers must hold the game lock.
+func newResult(gs *game.GameState, completedAt time.Time) Result {
+	standings := make([]Standing, 0, len(gs.Players))
+	for _, player := range gs.Players {
+		if player.IsBot {
+			continue
+		}
+		standings = append(standings, Standing{PlayerID: player.ID, Name: player.Name, Score: player.Score})
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Score != standings[j].Score {
+			return standings[i].Score > standings[j].Score
+		}
+		return standings[i].PlayerID.String() < standings[j].PlayerID.String()
+	})
+	for i := range standings {
+		if i > 0 && standings[i].Score == standings[i-1].Score {
+			standings[i].Rank = standings[i-1].Rank
+		} else {
+			standings[i].Rank = i + 1
+		}
+	}
+
+	return Result{
+		GameID:      gs.ID,
+		RoomCode:    gs.RoomCode,
+		CompletedAt: completedAt,
+		Standings:   standings,
+	}
+}
+
+// mapParticipants pairs every standing with its participant ID. A player
+// without a mapping is a permanent error until an admin adds one and retries.
+func mapParticipants(result Result, externalIDs map[uuid.UUID]string) ([]Participant, error) {
+	participants := make([]Participant, 0, len(result.Standings))
+	for _, standing := range result.Standings {
+		externalID, exists := externalIDs[standing.PlayerID]
+		if !exists {
+			return nil, Permanent(fmt.Errorf("player %s (%s) has no participant mapping", standing.Name, standing.PlayerID))
+		}
+		participants = append(participants, Participant{Standing: standing, ExternalID: externalID})
+	}
+	return participants, nil
+}
@@ -0,0 +1,106 @@
+package ladder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"dixitme/internal/models"
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResultRanksHumansAndSharesTies(t *testing.T) {
+	alice, bob, carol, bot := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	gs := &game.GameState{
+		ID:       uuid.New(),
+		RoomCode: "ABC123",
+		Players: map[uuid.UUID]*game.Player{
+			alice: {ID: alice, Name: "Alice", Score: 30},
+			bob:   {ID: bob, Name: "Bob", Score: 25},
+			carol: {ID: carol, Name: "Carol", Score: 30},
+			bot:   {ID: bot, Name: "Bot", Score: 40, IsBot: true},
+		},
+	}
+
+	result := newResult(gs, time.Now())
+
+	require.Len(t, result.Standings, 3)
+	assert.Equal(t, 1, result.Standings[0].Rank)
+	assert.Equal(t, 1, result.Standings[1].Rank)
+	assert.Equal(t, bob, result.Standings[2].PlayerID)
+	assert.Equal(t, 3, result.Standings[2].Rank)
+}
+
+func TestMapParticipantsRequiresEveryPlayer(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	result := Result{Standings: []Standing{{PlayerID: alice, Rank: 1}, {PlayerID: bob, Rank: 2}}}
+
+	_, err := mapParticipants(result, map[uuid.UUID]string{alice: "11"})
+	assert.True(t, IsPermanent(err))
+
+	participants, err := mapParticipants(result, map[uuid.UUID]string{alice: "11", bob: "22"})
+	require.NoError(t, err)
+	assert.Equal(t, "22", participants[1].ExternalID)
+}
+
+func TestBackoffDoublesUpToMax(t *testing.T) {
+	assert.Equal(t, time.Minute, backoff(1))
+	assert.Equal(t, 2*time.Minute, backoff(2))
+	assert.Equal(t, 8*time.Minute, backoff(4))
+	assert.Equal(t, time.Hour, backoff(MaxAttempts))
+}
+
+func TestChallongeReportsOpenMatch(t *testing.T) {
+	var reported map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tournaments/spring/matches.json":
+			w.Write([]byte(`[{"match":{"id":7,"player1_id":22,"player2_id":11}}]`))
+		case r.Method == http.MethodPut && r.URL.Path == "/tournaments/spring/matches/7.json":
+			json.NewDecoder(r.Body).Decode(&reported)
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	adapter := &ChallongeAdapter{api: newAPIClient(server.URL)}
+	participants := []Participant{
+		{Standing: Standing{Score: 30, Rank: 1}, ExternalID: "11"},
+		{Standing: Standing{Score: 25, Rank: 2}, ExternalID: "22"},
+	}
+
+	err := adapter.ReportResult(context.Background(), models.Tournament{ExternalID: "spring", APIKey: "key"}, Result{}, participants)
+	require.NoError(t, err)
+
+	match := reported["match"].(map[string]interface{})
+	assert.Equal(t, "25-30", match["scores_csv"])
+	assert.Equal(t, "11", match["winner_id"])
+}
+
+func TestAPIClientClassifiesFailures(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	api := newAPIClient(server.URL)
+
+	err := api.do(context.Background(), http.MethodGet, "/x?api_key=secret", nil, nil, nil)
+	require.Error(t, err)
+	assert.False(t, IsPermanent(err))
+	assert.NotContains(t, err.Error(), "secret")
+
+	status = http.StatusUnauthorized
+	err = api.do(context.Background(), http.MethodGet, "/x", nil, nil, nil)
+	assert.True(t, IsPermanent(err))
+}
@@ -0,0 +1,115 @@
+package ladder
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"dixitme/internal/models"
+)
+
+const toornamentBaseURL = "https://api.toornament.com"
+
+// ToornamentAdapter reports results through the Toornament organizer v2 API.
+// Toornament matches can have any number of opponents, so free-for-all games
+// are reported with every participant's rank and score.
+type ToornamentAdapter struct {
+	api apiClient
+}
+
+// NewToornamentAdapter creates an adapter for the public Toornament API
+func NewToornamentAdapter() *ToornamentAdapter {
+	return &ToornamentAdapter{api: newAPIClient(toornamentBaseURL)}
+}
+
+type toornamentMatch struct {
+	ID        string `json:"id"`
+	Opponents []struct {
+		Number      int `json:"number"`
+		Participant *struct {
+			ID string `json:"id"`
+		} `json:"participant"`
+	} `json:"opponents"`
+}
+
+type toornamentOpponent struct {
+	Number int    `json:"number"`
+	Result string `json:"result"` // win, draw or loss
+	Rank   int    `json:"rank"`
+	Score  int    `json:"score"`
+}
+
+// ReportResult completes the pending match whose opponents are exactly the game's participants
+func (a *ToornamentAdapter) ReportResult(ctx context.Context, tournament models.Tournament, result Result, participants []Participant) error {
+	byExternalID := make(map[string]Participant, len(participants))
+	externalIDs := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		byExternalID[participant.ExternalID] = participant
+		externalIDs = append(externalIDs, participant.ExternalID)
+	}
+	sort.Strings(externalIDs)
+
+	header := http.Header{
+		"X-Api-Key":     {tournament.APIKey},
+		"Authorization": {"Bearer " + tournament.AccessToken},
+		"Range":         {"matches=0-49"},
+	}
+	query := url.Values{
+		"tournament_ids":  {tournament.ExternalID},
+		"participant_ids": {strings.Join(externalIDs, ",")},
+		"statuses":        {"pending,running"},
+	}
+
+	var matches []toornamentMatch
+	if err := a.api.do(ctx, http.MethodGet, "/organizer/v2/matches?"+query.Encode(), header, nil, &matches); err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		if len(m.Opponents) != len(participants) {
+			continue
+		}
+
+		opponents := make([]toornamentOpponent, 0, len(m.Opponents))
+		for _, opponent := range m.Opponents {
+			if opponent.Participant == nil {
+				break
+			}
+			participant, exists := byExternalID[opponent.Participant.ID]
+			if !exists {
+				break
+			}
+			opponents = append(opponents, toornamentOpponent{
+				Number: opponent.Number,
+				Result: toornamentResult(participant, participants),
+				Rank:   participant.Rank,
+				Score:  participant.Score,
+			})
+		}
+		if len(opponents) != len(participants) {
+			continue
+		}
+
+		header.Del("Range")
+		body := map[string]interface{}{"status": "completed", "opponents": opponents}
+		return a.api.do(ctx, http.MethodPatch, "/organizer/v2/matches/"+url.PathEscape(m.ID), header, body, nil)
+	}
+
+	return Permanent(fmt.Errorf("no pending toornament match between participants %s", strings.Join(externalIDs, ", ")))
+}
+
+// toornamentResult is win for a sole winner, draw for winners sharing first place, and loss otherwise
+func toornamentResult(participant Participant, participants []Participant) string {
+	if participant.Rank != 1 {
+		return "loss"
+	}
+	for _, other := range participants {
+		if other.ExternalID != participant.ExternalID && other.Rank == 1 {
+			return "draw"
+		}
+	}
+	return "win"
+}
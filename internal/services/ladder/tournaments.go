@@ -0,0 +1,130 @@
+package ladder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrTournamentNotFound is returned for unknown or inactive tournaments
+var ErrTournamentNotFound = errors.New("tournament not found")
+
+// CreateTournament validates and stores a tournament
+func CreateTournament(ctx context.Context, db *gorm.DB, tournament *models.Tournament) error {
+	tournament.Name = strings.TrimSpace(tournament.Name)
+	tournament.ExternalID = strings.TrimSpace(tournament.ExternalID)
+	switch {
+	case tournament.Name == "":
+		return fmt.Errorf("tournament name is required")
+	case tournament.ExternalID == "":
+		return fmt.Errorf("external tournament ID is required")
+	case !IsPlatform(tournament.Platform):
+		return fmt.Errorf("unsupported platform %q", tournament.Platform)
+	case tournament.APIKey == "":
+		return fmt.Errorf("API key is required")
+	case tournament.Platform == models.LadderPlatformToornament && tournament.AccessToken == "":
+		return fmt.Errorf("toornament needs an access token as well as the API key")
+	}
+
+	tournament.ID = uuid.New()
+	tournament.IsActive = true
+	if err := db.WithContext(ctx).Create(tournament).Error; err != nil {
+		return fmt.Errorf("failed to create tournament: %w", err)
+	}
+	return nil
+}
+
+// ListTournaments returns every tournament, newest first
+func ListTournaments(ctx context.Context, db *gorm.DB) ([]models.Tournament, error) {
+	var tournaments []models.Tournament
+	if err := db.WithContext(ctx).Order("created_at DESC").Find(&tournaments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list tournaments: %w", err)
+	}
+	return tournaments, nil
+}
+
+// ActiveTournament loads a tournament games can still be created for
+func ActiveTournament(ctx context.Context, db *gorm.DB, tournamentID uuid.UUID) (*models.Tournament, error) {
+	var tournament models.Tournament
+	if err := db.WithContext(ctx).First(&tournament, "id = ? AND is_active = ?", tournamentID, true).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTournamentNotFound
+		}
+		return nil, fmt.Errorf("failed to load tournament: %w", err)
+	}
+	return &tournament, nil
+}
+
+// SetParticipants maps players to their participant IDs on the platform.
+// Existing mappings of the given players are replaced; an empty external ID removes one.
+func SetParticipants(ctx context.Context, db *gorm.DB, tournamentID uuid.UUID, externalIDs map[uuid.UUID]string) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Tournament{}).Where("id = ?", tournamentID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to load tournament: %w", err)
+		}
+		if count == 0 {
+			return ErrTournamentNotFound
+		}
+
+		now := time.Now()
+		for playerID, externalID := range externalIDs {
+			externalID = strings.TrimSpace(externalID)
+			if err := tx.Delete(&models.TournamentParticipant{}, "tournament_id = ? AND player_id = ?", tournamentID, playerID).Error; err != nil {
+				return fmt.Errorf("failed to update participant mapping: %w", err)
+			}
+			if externalID == "" {
+				continue
+			}
+			participant := models.TournamentParticipant{
+				TournamentID: tournamentID,
+				PlayerID:     playerID,
+				ExternalID:   externalID,
+				CreatedAt:    now,
+			}
+			if err := tx.Create(&participant).Error; err != nil {
+				return fmt.Errorf("failed to update participant mapping: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ListDeliveries returns a tournament's deliveries, newest first, optionally filtered by status
+func ListDeliveries(ctx context.Context, db *gorm.DB, tournamentID uuid.UUID, status string, limit int) ([]models.LadderDelivery, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	query := db.WithContext(ctx).Where("tournament_id = ?", tournamentID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var deliveries []models.LadderDelivery
+	if err := query.Order("created_at DESC").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list ladder deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// participantIDs loads a tournament's player to participant ID mapping
+func participantIDs(ctx context.Context, db *gorm.DB, tournamentID uuid.UUID) (map[uuid.UUID]string, error) {
+	var participants []models.TournamentParticipant
+	if err := db.WithContext(ctx).Where("tournament_id = ?", tournamentID).Find(&participants).Error; err != nil {
+		return nil, fmt.Errorf("failed to load participant mapping: %w", err)
+	}
+
+	externalIDs := make(map[uuid.UUID]string, len(participants))
+	for _, participant := range participants {
+		externalIDs[participant.PlayerID] = participant.ExternalID
+	}
+	return externalIDs, nil
+}
@@ -0,0 +1,100 @@
+// Package mail sends transactional email such as password resets. Without an
+// SMTP server configured, messages are written to the log instead, so
+// development deployments work without one.
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"dixitme/internal/logger"
+)
+
+// Config holds the outgoing mail configuration
+type Config struct {
+	SMTPAddr string // host:port of the SMTP server (empty = log messages instead)
+	Username string // SMTP auth username (empty = no auth)
+	Password string
+	From     string // Sender address
+}
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends email
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New returns an SMTP mailer, or a log mailer when no SMTP server is configured
+func New(cfg Config) Mailer {
+	if cfg.SMTPAddr == "" {
+		return LogMailer{}
+	}
+	return &SMTPMailer{cfg: cfg}
+}
+
+// LogMailer logs messages instead of sending them
+type LogMailer struct{}
+
+func (LogMailer) Send(ctx context.Context, msg Message) error {
+	logger.Info("Email not sent, no SMTP server configured",
+		"to", msg.To,
+		"subject", msg.Subject,
+		"body", msg.Body)
+	return nil
+}
+
+// SMTPMailer sends messages through an SMTP server
+type SMTPMailer struct {
+	cfg Config
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return fmt.Errorf("invalid mail header")
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		host, _, err := net.SplitHostPort(m.cfg.SMTPAddr)
+		if err != nil {
+			return fmt.Errorf("invalid SMTP address: %w", err)
+		}
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, host)
+	}
+
+	body := strings.Join([]string{
+		"From: " + m.cfg.From,
+		"To: " + msg.To,
+		"Subject: " + msg.Subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		msg.Body,
+	}, "\r\n")
+
+	// net/smtp has no context support; run it aside so callers can give up
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(m.cfg.SMTPAddr, auth, m.cfg.From, []string{msg.To}, []byte(body))
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,19 @@
+package mail
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFallsBackToLogMailer(t *testing.T) {
+	assert.IsType(t, LogMailer{}, New(Config{}))
+	assert.IsType(t, &SMTPMailer{}, New(Config{SMTPAddr: "smtp.example.com:587"}))
+}
+
+func TestSMTPMailerRejectsHeaderInjection(t *testing.T) {
+	mailer := New(Config{SMTPAddr: "smtp.example.com:587", From: "no-reply@example.com"})
+	err := mailer.Send(context.Background(), Message{To: "a@example.com\r\nBcc: b@example.com", Subject: "Hi"})
+	assert.EqualError(t, err, "invalid mail header")
+}
@@ -0,0 +1,206 @@
+// Package namepolicy validates the names players pick for themselves, so
+// guests can't take offensive names or pass for the system or staff
+package namepolicy
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"dixitme/internal/services/bot"
+)
+
+// Name length limits, in characters
+const (
+	MinLength = 2
+	MaxLength = 32
+)
+
+// Error codes returned to clients
+const (
+	CodeRequired     = "name_required"
+	CodeTooShort     = "name_too_short"
+	CodeTooLong      = "name_too_long"
+	CodeInvalidChars = "name_invalid_characters"
+	CodeProfanity    = "name_profanity"
+	CodeReserved     = "name_reserved"
+)
+
+// PolicyError explains why a name was rejected
+type PolicyError struct {
+	Code    string `json:"code"`
+	Message string `json:"error"`
+}
+
+func (e *PolicyError) Error() string {
+	return e.Message
+}
+
+// impersonationWords can't appear anywhere in a name, e.g. "Admin Tom" or "[SYSTEM]"
+var impersonationWords = map[string]bool{
+	"system": true, "admin": true, "administrator": true, "moderator": true,
+	"staff": true, "dixitme": true, "official": true,
+}
+
+// defaultBlockedWords is a deliberately short built-in list, matched as whole
+// words after folding look-alike characters; deployments extend it with AddBlockedWords
+var defaultBlockedWords = []string{
+	"fuck", "fucker", "fucking", "shit", "bitch", "cunt", "cock", "pussy",
+	"asshole", "bastard", "whore", "slut", "nigger", "nigga", "faggot",
+	"retard", "rape", "rapist", "nazi", "hitler",
+}
+
+var (
+	blockedMu    sync.RWMutex
+	blockedWords = wordSet(defaultBlockedWords)
+)
+
+// AddBlockedWords blocks extra words on top of the built-in list
+func AddBlockedWords(words []string) {
+	blockedMu.Lock()
+	defer blockedMu.Unlock()
+	for _, word := range words {
+		if word = foldWord(word); word != "" {
+			blockedWords[word] = true
+		}
+	}
+}
+
+// Check validates a name and returns it cleaned up: trimmed, with runs of
+// whitespace collapsed to one space. Rejections are *PolicyError.
+func Check(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", &PolicyError{Code: CodeRequired, Message: "Name is required"}
+	}
+
+	length := utf8.RuneCountInString(name)
+	if length < MinLength {
+		return "", &PolicyError{Code: CodeTooShort, Message: "Name must be at least 2 characters"}
+	}
+	if length > MaxLength {
+		return "", &PolicyError{Code: CodeTooLong, Message: "Name must be at most 32 characters"}
+	}
+
+	hasAlphanumeric := false
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			hasAlphanumeric = true
+		case unicode.Is(unicode.Mn, r) || r == ' ' || strings.ContainsRune("-_.'", r):
+		default:
+			return "", &PolicyError{Code: CodeInvalidChars, Message: "Name may only contain letters, digits, spaces and - _ . '"}
+		}
+	}
+	if !hasAlphanumeric {
+		return "", &PolicyError{Code: CodeInvalidChars, Message: "Name must contain a letter or digit"}
+	}
+
+	words := nameWords(name)
+	joined := strings.Join(words, "")
+	if bot.IsReservedName(joined) {
+		return "", &PolicyError{Code: CodeReserved, Message: "That name is reserved"}
+	}
+
+	blockedMu.RLock()
+	defer blockedMu.RUnlock()
+	if blockedWords[joined] {
+		return "", &PolicyError{Code: CodeProfanity, Message: "Please choose a different name"}
+	}
+	for _, word := range words {
+		if impersonationWords[word] {
+			return "", &PolicyError{Code: CodeReserved, Message: "Names can't pose as the system or staff"}
+		}
+		if blockedWords[word] {
+			return "", &PolicyError{Code: CodeProfanity, Message: "Please choose a different name"}
+		}
+	}
+
+	return name, nil
+}
+
+// ContainsBlockedWord reports whether text has a blocked word in it, matched
+// the way names are, so other text players write (e.g. clues) shares the list
+func ContainsBlockedWord(text string) bool {
+	words := nameWords(text)
+	blockedMu.RLock()
+	defer blockedMu.RUnlock()
+	if blockedWords[strings.Join(words, "")] {
+		return true
+	}
+	for _, word := range words {
+		if blockedWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// nameWords splits a name into folded words, treating punctuation and case
+// changes as separators so "xXAdminXx" and "Admin_Tom" both yield "admin"
+func nameWords(name string) []string {
+	var words []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, current.String())
+			current.Reset()
+		}
+	}
+
+	var previous rune
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			previous = 0
+			continue
+		}
+		if unicode.IsUpper(r) && previous != 0 && unicode.IsLower(previous) {
+			flush()
+		}
+		current.WriteRune(fold(r))
+		previous = r
+	}
+	flush()
+
+	// "x" padding is a common way to dodge word matching
+	for i, word := range words {
+		if trimmed := strings.Trim(word, "x"); len(trimmed) >= 3 {
+			words[i] = trimmed
+		}
+	}
+	return words
+}
+
+// lookalikes maps digits and Cyrillic/Greek homoglyphs to Latin letters
+var lookalikes = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b',
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'х': 'x', 'у': 'y',
+	'і': 'i', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'м': 'm', 'т': 't', 'н': 'h',
+	'α': 'a', 'ο': 'o', 'ι': 'i', 'ν': 'v', 'ѵ': 'v',
+}
+
+func fold(r rune) rune {
+	r = unicode.ToLower(r)
+	if mapped, ok := lookalikes[r]; ok {
+		return mapped
+	}
+	return r
+}
+
+func foldWord(word string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(word) {
+		b.WriteRune(fold(r))
+	}
+	return b.String()
+}
+
+func wordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[foldWord(word)] = true
+	}
+	return set
+}
@@ -0,0 +1,62 @@
+package namepolicy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func checkCode(t *testing.T, name string) string {
+	t.Helper()
+	_, err := Check(name)
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) {
+		return ""
+	}
+	return policyErr.Code
+}
+
+func TestCheckCleansValidNames(t *testing.T) {
+	name, err := Check("  Mary   Jane ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Mary Jane", name)
+
+	for _, valid := range []string{"Nguyễn Văn An", "O'Brien", "tom_92", "Zoë", "Amélie Algo"} {
+		_, err := Check(valid)
+		assert.NoError(t, err, valid)
+	}
+}
+
+func TestCheckLengthAndCharset(t *testing.T) {
+	assert.Equal(t, CodeRequired, checkCode(t, "   "))
+	assert.Equal(t, CodeTooShort, checkCode(t, "A"))
+	assert.Equal(t, CodeTooLong, checkCode(t, "Abcdefghijklmnopqrstuvwxyz abcdefg"))
+	assert.Equal(t, CodeInvalidChars, checkCode(t, "<script>"))
+	assert.Equal(t, CodeInvalidChars, checkCode(t, "zero​width"))
+	assert.Equal(t, CodeInvalidChars, checkCode(t, "---"))
+}
+
+func TestCheckImpersonation(t *testing.T) {
+	for _, name := range []string{"System", "ADMIN", "Admin Tom", "xXAdminXx", "SysTem", "Аdmin", "adm1n", "Moderator_Sam"} {
+		assert.Equal(t, CodeReserved, checkCode(t, name), name)
+	}
+	assert.Equal(t, "", checkCode(t, "Sam Systems"))
+}
+
+func TestCheckProfanity(t *testing.T) {
+	assert.Equal(t, CodeProfanity, checkCode(t, "Shit Head"))
+	assert.Equal(t, CodeProfanity, checkCode(t, "5h1t"))
+	assert.Equal(t, "", checkCode(t, "Scunthorpe"))
+	assert.Equal(t, "", checkCode(t, "Cockburn"))
+
+	AddBlockedWords([]string{"Grumbleweed"})
+	assert.Equal(t, CodeProfanity, checkCode(t, "grumbleweed"))
+}
+
+func TestContainsBlockedWord(t *testing.T) {
+	assert.True(t, ContainsBlockedWord("What the f*ck, shit happens"))
+	assert.True(t, ContainsBlockedWord("ShitStorm"))
+	assert.False(t, ContainsBlockedWord("A cockatoo in Scunthorpe"))
+	assert.False(t, ContainsBlockedWord("The long way home"))
+}
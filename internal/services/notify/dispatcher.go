@@ -0,0 +1,211 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+	"dixitme/internal/services/mail"
+	"dixitme/internal/services/presence"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxAttempts is how often a notification is tried before it is marked failed
+	MaxAttempts = 5
+
+	retryBackoff  = 5 * time.Minute
+	pollInterval  = time.Minute
+	deliveryBatch = 50
+)
+
+// Notification is a message for one user
+type Notification struct {
+	Kind    string // e.g. turn_reminder or digest
+	Subject string
+	Body    string
+	Urgent  bool // Account and security mail that can't wait for quiet hours to end
+}
+
+// Dispatcher is the one way notifications reach users. It writes them to the
+// notification outbox and emails them in the background: right away, or once
+// the user's quiet hours are over.
+type Dispatcher struct {
+	db     *gorm.DB
+	mailer mail.Mailer
+	wake   chan struct{}
+	stop   chan struct{}
+	closed chan struct{}
+}
+
+// NewDispatcher creates a dispatcher sending through mailer
+func NewDispatcher(db *gorm.DB, mailer mail.Mailer) *Dispatcher {
+	return &Dispatcher{
+		db:     db,
+		mailer: mailer,
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+}
+
+// Start delivers due notifications in the background until Stop is called
+func (d *Dispatcher) Start() {
+	go func() {
+		defer close(d.closed)
+
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-d.stop:
+				return
+			case <-d.wake:
+				d.deliverDue(context.Background())
+			case <-ticker.C:
+				d.deliverDue(context.Background())
+			}
+		}
+	}()
+}
+
+// Stop stops the dispatcher. Pending notifications stay in the outbox and
+// are picked up after a restart.
+func (d *Dispatcher) Stop() {
+	close(d.stop)
+	<-d.closed
+}
+
+// Send queues a notification for a user, held until their quiet hours are
+// over unless it is urgent. Non-urgent notifications to a user in do not
+// disturb mode are dropped.
+func (d *Dispatcher) Send(ctx context.Context, userID uuid.UUID, n Notification) error {
+	var user models.User
+	if err := d.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("user not found")
+		}
+		return fmt.Errorf("failed to load user: %w", err)
+	}
+
+	if !n.Urgent && !presence.Of(&user).Interruptible() {
+		metrics.GetCounter(metrics.Name("notifications_suppressed_total", "kind", n.Kind)).Inc()
+		logger.Debug("Notification dropped for do not disturb", "user_id", userID, "kind", n.Kind)
+		return nil
+	}
+
+	now := time.Now()
+	deliverAt := now
+	if !n.Urgent {
+		if until, quiet := PreferencesOf(&user).QuietUntil(now); quiet {
+			deliverAt = until
+		}
+	}
+
+	notification := models.Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Kind:      n.Kind,
+		Subject:   n.Subject,
+		Body:      n.Body,
+		Urgent:    n.Urgent,
+		Status:    models.NotificationPending,
+		DeliverAt: deliverAt,
+		CreatedAt: now,
+	}
+	if err := d.db.WithContext(ctx).Create(&notification).Error; err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
+	}
+
+	if deliverAt.After(now) {
+		logger.Debug("Notification held for quiet hours", "user_id", userID, "kind", n.Kind, "deliver_at", deliverAt)
+		return nil
+	}
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// deliverDue sends every pending notification whose time has come
+func (d *Dispatcher) deliverDue(ctx context.Context) {
+	var due []models.Notification
+	if err := d.db.WithContext(ctx).
+		Where("status = ? AND deliver_at <= ?", models.NotificationPending, time.Now()).
+		Order("deliver_at").
+		Limit(deliveryBatch).
+		Find(&due).Error; err != nil {
+		logger.Error("Failed to load due notifications", "error", err)
+		return
+	}
+
+	for i := range due {
+		d.attempt(ctx, &due[i])
+	}
+}
+
+// attempt sends one notification and records the outcome. Quiet hours are
+// checked again, as the user may have changed them since it was queued.
+func (d *Dispatcher) attempt(ctx context.Context, notification *models.Notification) {
+	now := time.Now()
+
+	var user models.User
+	err := d.db.WithContext(ctx).First(&user, "id = ?", notification.UserID).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && (!user.IsActive || user.Email == "")):
+		notification.Status = models.NotificationFailed
+		notification.LastError = "user has no reachable email address"
+		d.save(ctx, notification)
+		return
+	case err != nil:
+		logger.Error("Failed to load notification recipient", "error", err, "notification_id", notification.ID)
+		return
+	}
+
+	if !notification.Urgent {
+		if until, quiet := PreferencesOf(&user).QuietUntil(now); quiet {
+			notification.DeliverAt = until
+			d.save(ctx, notification)
+			return
+		}
+	}
+
+	notification.Attempts++
+	err = d.mailer.Send(ctx, mail.Message{To: user.Email, Subject: notification.Subject, Body: notification.Body})
+	switch {
+	case err == nil:
+		notification.Status = models.NotificationSent
+		notification.SentAt = &now
+		notification.LastError = ""
+	case notification.Attempts >= MaxAttempts:
+		notification.Status = models.NotificationFailed
+		notification.LastError = err.Error()
+	default:
+		notification.LastError = err.Error()
+		notification.DeliverAt = now.Add(retryBackoff)
+	}
+	metrics.GetCounter(metrics.Name("notification_attempts_total", "status", notification.Status)).Inc()
+
+	if err != nil {
+		logger.Warn("Notification delivery failed",
+			"error", err,
+			"notification_id", notification.ID,
+			"user_id", notification.UserID,
+			"attempts", notification.Attempts)
+	}
+	d.save(ctx, notification)
+}
+
+func (d *Dispatcher) save(ctx context.Context, notification *models.Notification) {
+	if err := d.db.WithContext(ctx).Save(notification).Error; err != nil {
+		logger.Error("Failed to update notification", "error", err, "notification_id", notification.ID)
+	}
+}
@@ -0,0 +1,119 @@
+// Package notify delivers notifications such as turn reminders and digests to
+// users by email. Every notification goes through the Dispatcher, which holds
+// non-urgent ones until the user's quiet hours are over, in their time zone.
+package notify
+
+import (
+	"fmt"
+	"time"
+
+	"dixitme/internal/models"
+)
+
+// clockLayout is how quiet hours are written
+const clockLayout = "15:04"
+
+// Preferences are a user's notification settings
+type Preferences struct {
+	TimeZone        string `json:"time_zone"`         // IANA name such as Europe/Paris (empty = UTC)
+	QuietHoursStart string `json:"quiet_hours_start"` // HH:MM in the time zone (empty = no quiet hours)
+	QuietHoursEnd   string `json:"quiet_hours_end"`   // HH:MM; before the start for quiet hours over midnight
+}
+
+// PreferencesOf returns the notification preferences stored on a user
+func PreferencesOf(user *models.User) Preferences {
+	return Preferences{
+		TimeZone:        user.TimeZone,
+		QuietHoursStart: user.QuietHoursStart,
+		QuietHoursEnd:   user.QuietHoursEnd,
+	}
+}
+
+// Validate checks the time zone and quiet hours
+func (p Preferences) Validate() error {
+	if p.TimeZone != "" {
+		if p.TimeZone == "Local" {
+			return fmt.Errorf("unknown time zone %q", p.TimeZone)
+		}
+		if _, err := time.LoadLocation(p.TimeZone); err != nil {
+			return fmt.Errorf("unknown time zone %q", p.TimeZone)
+		}
+	}
+
+	if (p.QuietHoursStart == "") != (p.QuietHoursEnd == "") {
+		return fmt.Errorf("quiet hours need both a start and an end")
+	}
+	if p.QuietHoursStart == "" {
+		return nil
+	}
+	start, err := parseClock(p.QuietHoursStart)
+	if err != nil {
+		return fmt.Errorf("quiet hours start must be HH:MM")
+	}
+	end, err := parseClock(p.QuietHoursEnd)
+	if err != nil {
+		return fmt.Errorf("quiet hours end must be HH:MM")
+	}
+	if start == end {
+		return fmt.Errorf("quiet hours can't start and end at the same time")
+	}
+	return nil
+}
+
+// Location returns the user's time zone, UTC when unset or unknown
+func (p Preferences) Location() *time.Location {
+	if p.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// QuietUntil reports whether now falls in the user's quiet hours and, if so,
+// when they end
+func (p Preferences) QuietUntil(now time.Time) (time.Time, bool) {
+	if p.QuietHoursStart == "" || p.QuietHoursEnd == "" {
+		return time.Time{}, false
+	}
+	start, err := parseClock(p.QuietHoursStart)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := parseClock(p.QuietHoursEnd)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	loc := p.Location()
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+
+	var quiet bool
+	if start < end {
+		quiet = minute >= start && minute < end
+	} else {
+		// Quiet hours over midnight, e.g. 22:00 to 07:00
+		quiet = minute >= start || minute < end
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+	if !until.After(local) {
+		until = time.Date(local.Year(), local.Month(), local.Day()+1, end/60, end%60, 0, 0, loc)
+	}
+	return until, true
+}
+
+// parseClock returns the minutes since midnight of an HH:MM time
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse(clockLayout, clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
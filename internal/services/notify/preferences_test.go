@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferencesValidate(t *testing.T) {
+	assert.NoError(t, Preferences{}.Validate())
+	assert.NoError(t, Preferences{TimeZone: "Europe/Paris", QuietHoursStart: "22:00", QuietHoursEnd: "07:30"}.Validate())
+
+	assert.Error(t, Preferences{TimeZone: "Mars/Olympus"}.Validate())
+	assert.Error(t, Preferences{TimeZone: "Local"}.Validate())
+	assert.Error(t, Preferences{QuietHoursStart: "22:00"}.Validate())
+	assert.Error(t, Preferences{QuietHoursStart: "25:00", QuietHoursEnd: "07:00"}.Validate())
+	assert.Error(t, Preferences{QuietHoursStart: "07:00", QuietHoursEnd: "07:00"}.Validate())
+}
+
+func TestQuietUntilOverMidnight(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	prefs := Preferences{TimeZone: "Europe/Paris", QuietHoursStart: "22:00", QuietHoursEnd: "07:00"}
+
+	// 03:00 in Paris is quiet until 07:00 the same morning
+	until, quiet := prefs.QuietUntil(time.Date(2026, 3, 10, 3, 0, 0, 0, paris))
+	assert.True(t, quiet)
+	assert.Equal(t, time.Date(2026, 3, 10, 7, 0, 0, 0, paris), until)
+
+	// 23:15 is quiet until 07:00 the next morning
+	until, quiet = prefs.QuietUntil(time.Date(2026, 3, 10, 23, 15, 0, 0, paris))
+	assert.True(t, quiet)
+	assert.Equal(t, time.Date(2026, 3, 11, 7, 0, 0, 0, paris), until)
+
+	// Quiet hours are read in the user's zone, whatever zone now is in
+	_, quiet = prefs.QuietUntil(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+	assert.False(t, quiet)
+	_, quiet = prefs.QuietUntil(time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC))
+	assert.True(t, quiet)
+}
+
+func TestQuietUntilDaytime(t *testing.T) {
+	prefs := Preferences{QuietHoursStart: "13:00", QuietHoursEnd: "14:00"}
+
+	until, quiet := prefs.QuietUntil(time.Date(2026, 3, 10, 13, 30, 0, 0, time.UTC))
+	assert.True(t, quiet)
+	assert.Equal(t, time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC), until)
+
+	_, quiet = prefs.QuietUntil(time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC))
+	assert.False(t, quiet)
+	_, quiet = Preferences{}.QuietUntil(time.Now())
+	assert.False(t, quiet)
+}
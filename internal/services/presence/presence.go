@@ -0,0 +1,48 @@
+// Package presence is how registered users choose to appear to others.
+// Do not disturb keeps a user visible but holds back anything that would
+// interrupt them, such as invites and non-urgent notifications; appearing
+// offline hides them from other players' online lists as well.
+package presence
+
+import (
+	"fmt"
+
+	"dixitme/internal/models"
+)
+
+// Mode is a user's chosen presence
+type Mode string
+
+const (
+	Online       Mode = "online"
+	DoNotDisturb Mode = "do_not_disturb"
+	Invisible    Mode = "invisible" // Appear offline
+)
+
+// Parse checks a presence mode sent by a client
+func Parse(value string) (Mode, error) {
+	switch mode := Mode(value); mode {
+	case Online, DoNotDisturb, Invisible:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown presence mode %q", value)
+}
+
+// Of returns the presence mode stored on a user. Accounts that never chose
+// one are online.
+func Of(user *models.User) Mode {
+	if mode, err := Parse(user.Presence); err == nil {
+		return mode
+	}
+	return Online
+}
+
+// Visible reports whether others may see the user online while they are connected
+func (m Mode) Visible() bool {
+	return m != Invisible
+}
+
+// Interruptible reports whether invites and non-urgent notifications reach the user
+func (m Mode) Interruptible() bool {
+	return m != DoNotDisturb
+}
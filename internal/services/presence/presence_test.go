@@ -0,0 +1,42 @@
+package presence
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	mode, err := Parse("do_not_disturb")
+	assert.NoError(t, err)
+	assert.Equal(t, DoNotDisturb, mode)
+
+	_, err = Parse("away")
+	assert.Error(t, err)
+	_, err = Parse("")
+	assert.Error(t, err)
+}
+
+func TestOfDefaultsToOnline(t *testing.T) {
+	assert.Equal(t, Online, Of(&models.User{}))
+	assert.Equal(t, Online, Of(&models.User{Presence: "bogus"}))
+	assert.Equal(t, Invisible, Of(&models.User{Presence: "invisible"}))
+}
+
+func TestModes(t *testing.T) {
+	tests := []struct {
+		mode          Mode
+		visible       bool
+		interruptible bool
+	}{
+		{Online, true, true},
+		{DoNotDisturb, true, false},
+		{Invisible, false, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.visible, tt.mode.Visible(), tt.mode)
+		assert.Equal(t, tt.interruptible, tt.mode.Interruptible(), tt.mode)
+	}
+}
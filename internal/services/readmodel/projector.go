@@ -0,0 +1,303 @@
+// Package readmodel keeps denormalized read tables for game listings and
+// player history. A projector consumes the game manager's lifecycle events and
+// writes them in the background, so the live engine never waits on the
+// listing queries' tables.
+package readmodel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"dixitme/internal/cache"
+	"dixitme/internal/events"
+	"dixitme/internal/logger"
+	"dixitme/internal/metrics"
+	"dixitme/internal/models"
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// queueSize bounds the events waiting to be written. When it fills up events
+// are dropped; Rebuild repairs the tables from the write model.
+const queueSize = 256
+
+// invalidates lists the response cache scopes each projected event makes
+// stale. Bot and chat stats read the write model, which the game manager
+// has already updated when the event is projected.
+var invalidates = map[string][]string{
+	"game_created":    {cache.ScopeGames},
+	"lobby_updated":   {cache.ScopeGames},
+	"game_started":    {cache.ScopeGames},
+	"game_closed":     {cache.ScopeGames},
+	"game_deleted":    {cache.ScopeGames},
+	"round_completed": {cache.ScopeGames, cache.ScopeChat},
+	"game_completed":  {cache.ScopeGames, cache.ScopeBots, cache.ScopeChat},
+}
+
+// projection is a snapshot of game state ready to be written. A deleted
+// projection removes the game's listing instead.
+type projection struct {
+	event   string
+	summary models.GameSummary
+	stats   []models.PlayerGameStat
+	deleted bool
+}
+
+// Projector projects game lifecycle events into the read tables. It
+// implements game.GameLifecycleHook.
+type Projector struct {
+	db     *gorm.DB
+	queue  chan projection
+	closed chan struct{}
+
+	mu      sync.RWMutex
+	stopped bool
+}
+
+// NewProjector creates a projector writing to db. Call Start before
+// registering it with the game manager.
+func NewProjector(db *gorm.DB) *Projector {
+	return &Projector{
+		db:     db,
+		queue:  make(chan projection, queueSize),
+		closed: make(chan struct{}),
+	}
+}
+
+// Start writes queued projections in order until Stop is called
+func (p *Projector) Start() {
+	go func() {
+		defer close(p.closed)
+		for proj := range p.queue {
+			if err := p.write(context.Background(), proj); err != nil {
+				metrics.GetCounter(metrics.Name("readmodel_write_failures_total", "event", proj.event)).Inc()
+				logger.Error("Failed to project game event",
+					"error", err,
+					"event", proj.event,
+					"game_id", proj.summary.GameID)
+				continue
+			}
+			cache.Invalidate(context.Background(), invalidates[proj.event]...)
+		}
+	}()
+}
+
+// Stop writes the projections still queued and stops the projector
+func (p *Projector) Stop() {
+	p.mu.Lock()
+	p.stopped = true
+	close(p.queue)
+	p.mu.Unlock()
+	<-p.closed
+}
+
+// OnGameCreated lists the new room
+func (p *Projector) OnGameCreated(gs *game.GameState) {
+	if gs.Sandbox {
+		return
+	}
+	p.enqueue(projection{event: "game_created", summary: summarize(gs, nil)})
+}
+
+// OnRoundCompleted refreshes the room's status, players and round count
+func (p *Projector) OnRoundCompleted(gs *game.GameState, _ *game.Round, _ map[uuid.UUID]int) {
+	if gs.Sandbox {
+		return
+	}
+	p.enqueue(projection{event: "round_completed", summary: summarize(gs, nil)})
+}
+
+// OnGameCompleted records the result and every human player's standing
+func (p *Projector) OnGameCompleted(gs *game.GameState, result *game.GameResult) {
+	if gs.Sandbox {
+		return
+	}
+	summary := summarize(gs, result)
+	p.enqueue(projection{
+		event:   "game_completed",
+		summary: summary,
+		stats:   playerStats(gs, result, *summary.CompletedAt),
+	})
+}
+
+// Subscribe keeps waiting rooms' listings current between rounds, so the
+// lobby browser sees seats fill up and visibility change, and follows rooms
+// as they start and as they are deleted or closed before finishing
+func (p *Projector) Subscribe(bus *events.Bus) {
+	events.Subscribe(bus, game.TopicGameStarted, func(e game.GameStarted) { p.onGameStarted(e.Game) })
+	events.Subscribe(bus, game.TopicGameRemoved, func(e game.GameRemoved) { p.onGameRemoved(e.Game, e.Deleted) })
+	events.Subscribe(bus, game.TopicPlayerJoined, func(e game.PlayerJoined) { p.onLobbyUpdated(e.Game) })
+	events.Subscribe(bus, game.TopicPlayerLeft, func(e game.PlayerLeft) {
+		if e.Removed {
+			p.onLobbyUpdated(e.Game)
+		}
+	})
+	events.Subscribe(bus, game.TopicSettingsUpdated, func(e game.SettingsUpdated) { p.onLobbyUpdated(e.Game) })
+}
+
+// onLobbyUpdated refreshes a waiting room's listing. Callers hold the game lock.
+func (p *Projector) onLobbyUpdated(gs *game.GameState) {
+	if gs.Sandbox || gs.Status != models.GameStatusWaiting {
+		return
+	}
+	p.enqueue(projection{event: "lobby_updated", summary: summarize(gs, nil)})
+}
+
+// onGameStarted takes a started room out of the lobby. Callers hold the game lock.
+func (p *Projector) onGameStarted(gs *game.GameState) {
+	if gs.Sandbox {
+		return
+	}
+	p.enqueue(projection{event: "game_started", summary: summarize(gs, nil)})
+}
+
+// onGameRemoved drops a deleted room's listing, and lists a room closed before
+// it finished as abandoned. Finished games keep their row. Callers hold the
+// game lock.
+func (p *Projector) onGameRemoved(gs *game.GameState, deleted bool) {
+	if gs.Sandbox {
+		return
+	}
+	if deleted {
+		p.enqueue(projection{event: "game_deleted", summary: models.GameSummary{GameID: gs.ID}, deleted: true})
+		return
+	}
+	if gs.Status == models.GameStatusCompleted || gs.Status == models.GameStatusAbandoned {
+		return
+	}
+	summary := summarize(gs, nil)
+	summary.Status = models.GameStatusAbandoned
+	p.enqueue(projection{event: "game_closed", summary: summary})
+}
+
+// enqueue hands a projection to the writer without blocking the game
+func (p *Projector) enqueue(proj projection) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.stopped {
+		return
+	}
+
+	select {
+	case p.queue <- proj:
+	default:
+		metrics.GetCounter(metrics.Name("readmodel_dropped_events_total", "event", proj.event)).Inc()
+		logger.Warn("Read model queue full, dropping game event",
+			"event", proj.event,
+			"game_id", proj.summary.GameID)
+	}
+}
+
+// write stores a projection's rows in one transaction
+func (p *Projector) write(ctx context.Context, proj projection) error {
+	if proj.deleted {
+		if err := p.db.WithContext(ctx).Delete(&models.GameSummary{}, "game_id = ?", proj.summary.GameID).Error; err != nil {
+			return fmt.Errorf("failed to delete game summary: %w", err)
+		}
+		return nil
+	}
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&proj.summary).Error; err != nil {
+			return fmt.Errorf("failed to save game summary: %w", err)
+		}
+		for i := range proj.stats {
+			if err := tx.Save(&proj.stats[i]).Error; err != nil {
+				return fmt.Errorf("failed to save player game stat: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// summarize builds a game's listing row. result is nil until the game ends.
+// Callers hold the game lock.
+func summarize(gs *game.GameState, result *game.GameResult) models.GameSummary {
+	summary := models.GameSummary{
+		GameID:       gs.ID,
+		RoomCode:     gs.RoomCode,
+		Status:       gs.Status,
+		Pace:         gs.Settings.Pace,
+		Ranked:       gs.Settings.Ranked,
+		Private:      gs.Settings.Private,
+		Region:       gs.Region,
+		HostID:       gs.HostID,
+		MaxPlayers:   gs.Settings.Rules.PlayerLimit(),
+		RoundsPlayed: gs.RoundNumber,
+		CreatedAt:    gs.CreatedAt,
+		UpdatedAt:    time.Now(),
+	}
+	for _, player := range gs.Players {
+		if player.IsBot {
+			summary.BotCount++
+		} else {
+			summary.PlayerCount++
+		}
+	}
+
+	if result != nil {
+		completedAt := gs.CreatedAt.Add(result.Duration)
+		summary.CompletedAt = &completedAt
+		summary.Outcome = result.Outcome
+		if winner, exists := gs.Players[result.WinnerID]; exists {
+			winnerID := result.WinnerID
+			summary.WinnerID = &winnerID
+			summary.WinnerName = winner.Name
+		}
+	}
+	return summary
+}
+
+// playerStats builds each human player's history row for a finished game.
+// Bots have no history. Callers hold the game lock.
+func playerStats(gs *game.GameState, result *game.GameResult, completedAt time.Time) []models.PlayerGameStat {
+	scores := make([]int, 0, len(gs.Players))
+	for _, player := range gs.Players {
+		scores = append(scores, player.Score)
+	}
+	sortScores(scores)
+
+	stats := make([]models.PlayerGameStat, 0, len(gs.Players))
+	for playerID, player := range gs.Players {
+		if player.IsBot {
+			continue
+		}
+		stats = append(stats, models.PlayerGameStat{
+			GameID:          gs.ID,
+			PlayerID:        playerID,
+			RoomCode:        gs.RoomCode,
+			PlayerName:      player.Name,
+			Score:           player.Score,
+			Placement:       placement(scores, player.Score),
+			Won:             playerID == result.WinnerID,
+			WasReplaced:     player.WasReplaced,
+			Ranked:          result.Ranked,
+			RatingChange:    result.RatingChanges[playerID],
+			Outcome:         result.Outcome,
+			TotalRounds:     result.TotalRounds,
+			PlayerCount:     len(gs.Players),
+			DurationMinutes: int(result.Duration.Minutes()),
+			CompletedAt:     completedAt,
+		})
+	}
+	return stats
+}
+
+// sortScores sorts scores high to low
+func sortScores(scores []int) {
+	sort.Sort(sort.Reverse(sort.IntSlice(scores)))
+}
+
+// placement ranks a score among scores sorted high to low; ties share a place
+func placement(sortedScores []int, score int) int {
+	for i, s := range sortedScores {
+		if s == score {
+			return i + 1
+		}
+	}
+	return len(sortedScores)
+}
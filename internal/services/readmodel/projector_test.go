@@ -0,0 +1,209 @@
+package readmodel
+
+import (
+	"testing"
+	"time"
+
+	"dixitme/internal/events"
+	"dixitme/internal/models"
+	"dixitme/internal/services/game"
+	"dixitme/internal/testutils/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func finishedGame() (*game.GameState, uuid.UUID, uuid.UUID, uuid.UUID) {
+	alice, bob, bot := uuid.New(), uuid.New(), uuid.New()
+	gs := &game.GameState{
+		ID:          uuid.New(),
+		RoomCode:    "READ",
+		HostID:      alice,
+		Status:      models.GameStatusCompleted,
+		RoundNumber: 9,
+		CreatedAt:   time.Date(2026, 10, 1, 20, 0, 0, 0, time.UTC),
+		Settings:    game.GameSettings{Pace: game.PaceStandard, Ranked: true},
+		Players: map[uuid.UUID]*game.Player{
+			alice: {ID: alice, Name: "Alice", Score: 31},
+			bob:   {ID: bob, Name: "Bob", Score: 24},
+			bot:   {ID: bot, Name: "Robo", Score: 24, IsBot: true},
+		},
+	}
+	return gs, alice, bob, bot
+}
+
+func TestSummarizeFinishedGame(t *testing.T) {
+	gs, alice, _, _ := finishedGame()
+	result := &game.GameResult{
+		GameID:      gs.ID,
+		WinnerID:    alice,
+		Outcome:     models.GameOutcomeCompleted,
+		Ranked:      true,
+		TotalRounds: 9,
+		Duration:    40 * time.Minute,
+	}
+
+	summary := summarize(gs, result)
+	assert.Equal(t, 2, summary.PlayerCount)
+	assert.Equal(t, 1, summary.BotCount)
+	assert.Equal(t, 9, summary.RoundsPlayed)
+	require.NotNil(t, summary.WinnerID)
+	assert.Equal(t, alice, *summary.WinnerID)
+	assert.Equal(t, "Alice", summary.WinnerName)
+	require.NotNil(t, summary.CompletedAt)
+	assert.Equal(t, gs.CreatedAt.Add(40*time.Minute), *summary.CompletedAt)
+
+	// Lobby rows have no result yet
+	assert.Nil(t, summarize(gs, nil).CompletedAt)
+	assert.False(t, summary.Private)
+
+	gs.Settings.Private = true
+	assert.True(t, summarize(gs, nil).Private, "private rooms stay out of the lobby browser")
+}
+
+func TestPlayerStatsRankHumansOnly(t *testing.T) {
+	gs, alice, bob, _ := finishedGame()
+	result := &game.GameResult{
+		WinnerID:      alice,
+		Outcome:       models.GameOutcomeCompleted,
+		Ranked:        true,
+		TotalRounds:   9,
+		Duration:      40 * time.Minute,
+		RatingChanges: map[uuid.UUID]int{alice: 12, bob: -12},
+	}
+
+	stats := playerStats(gs, result, time.Now())
+	require.Len(t, stats, 2)
+	byPlayer := map[uuid.UUID]models.PlayerGameStat{}
+	for _, stat := range stats {
+		byPlayer[stat.PlayerID] = stat
+	}
+
+	assert.True(t, byPlayer[alice].Won)
+	assert.Equal(t, 1, byPlayer[alice].Placement)
+	assert.Equal(t, 12, byPlayer[alice].RatingChange)
+	// Bob ties with the bot for second place
+	assert.Equal(t, 2, byPlayer[bob].Placement)
+	assert.Equal(t, -12, byPlayer[bob].RatingChange)
+	assert.Equal(t, 3, byPlayer[bob].PlayerCount)
+	assert.Equal(t, 40, byPlayer[bob].DurationMinutes)
+}
+
+func TestRebuildGameFromStoredRecords(t *testing.T) {
+	alice, bot := uuid.New(), uuid.New()
+	g := models.Game{
+		ID:           uuid.New(),
+		RoomCode:     "OLD",
+		Status:       models.GameStatusCompleted,
+		CurrentRound: 7,
+		Private:      true,
+		Players: []models.GamePlayer{
+			{PlayerID: alice, Position: 1, Score: 30, Player: models.Player{Name: "Alice", Type: models.PlayerTypeHuman}},
+			{PlayerID: bot, Position: 2, Score: 18, Player: models.Player{Name: "Robo", Type: models.PlayerTypeBot}},
+		},
+	}
+	history := models.GameHistory{GameID: g.ID, WinnerID: alice, TotalRounds: 7, Duration: 25, Outcome: models.GameOutcomeCompleted}
+
+	summary, stats := rebuildGame(g, history, true)
+	assert.Equal(t, alice, summary.HostID)
+	assert.Equal(t, "Alice", summary.WinnerName)
+	assert.True(t, summary.Private)
+	require.Len(t, stats, 1)
+	assert.Equal(t, 25, stats[0].DurationMinutes)
+	assert.True(t, stats[0].Won)
+
+	_, stats = rebuildGame(g, models.GameHistory{}, false)
+	assert.Empty(t, stats)
+}
+
+// projectLifecycle runs a projector over a test database, publishes the
+// events through a bus and waits for them to be written
+func projectLifecycle(t *testing.T, publish func(bus *events.Bus)) *gorm.DB {
+	t.Helper()
+	db := testdb.Open(t, &models.GameSummary{}, &models.PlayerGameStat{})
+	projector := NewProjector(db)
+	projector.Start()
+	bus := events.NewBus()
+	projector.Subscribe(bus)
+
+	publish(bus)
+	projector.Stop()
+	return db
+}
+
+func lobbyGame() *game.GameState {
+	host := uuid.New()
+	return &game.GameState{
+		ID:        uuid.New(),
+		RoomCode:  "LOBBY",
+		HostID:    host,
+		Status:    models.GameStatusWaiting,
+		CreatedAt: time.Now(),
+		Players:   map[uuid.UUID]*game.Player{host: {ID: host, Name: "Alice"}},
+	}
+}
+
+func TestProjectGameStartTakesRoomOutOfLobby(t *testing.T) {
+	gs := lobbyGame()
+	db := projectLifecycle(t, func(bus *events.Bus) {
+		events.Publish(bus, game.TopicSettingsUpdated, game.SettingsUpdated{Game: gs})
+		gs.Status = models.GameStatusInProgress
+		events.Publish(bus, game.TopicGameStarted, game.GameStarted{Game: gs})
+	})
+
+	var summary models.GameSummary
+	require.NoError(t, db.First(&summary, "game_id = ?", gs.ID).Error)
+	assert.Equal(t, models.GameStatusInProgress, summary.Status)
+}
+
+func TestProjectDeletedRoomDropsItsListing(t *testing.T) {
+	gs := lobbyGame()
+	// Also how a room whose bots couldn't be seated is rolled back
+	db := projectLifecycle(t, func(bus *events.Bus) {
+		events.Publish(bus, game.TopicSettingsUpdated, game.SettingsUpdated{Game: gs})
+		events.Publish(bus, game.TopicGameRemoved, game.GameRemoved{Game: gs, Deleted: true})
+	})
+
+	var count int64
+	require.NoError(t, db.Model(&models.GameSummary{}).Where("game_id = ?", gs.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestProjectClosedRoomAsAbandoned(t *testing.T) {
+	waiting, playing, finished := lobbyGame(), lobbyGame(), lobbyGame()
+	playing.Status = models.GameStatusInProgress
+	db := projectLifecycle(t, func(bus *events.Bus) {
+		for _, gs := range []*game.GameState{waiting, playing, finished} {
+			events.Publish(bus, game.TopicSettingsUpdated, game.SettingsUpdated{Game: gs})
+		}
+		finished.Status = models.GameStatusCompleted
+		for _, gs := range []*game.GameState{waiting, playing, finished} {
+			events.Publish(bus, game.TopicGameRemoved, game.GameRemoved{Game: gs})
+		}
+	})
+
+	status := func(gs *game.GameState) models.GameStatus {
+		var summary models.GameSummary
+		require.NoError(t, db.First(&summary, "game_id = ?", gs.ID).Error)
+		return summary.Status
+	}
+	assert.Equal(t, models.GameStatusAbandoned, status(waiting))
+	assert.Equal(t, models.GameStatusAbandoned, status(playing))
+	// Finished games keep their result; closing only unloads them
+	assert.Equal(t, models.GameStatusWaiting, status(finished))
+}
+
+func TestSandboxRoomsAreNotProjected(t *testing.T) {
+	gs := lobbyGame()
+	gs.Sandbox = true
+	db := projectLifecycle(t, func(bus *events.Bus) {
+		events.Publish(bus, game.TopicGameStarted, game.GameStarted{Game: gs})
+		events.Publish(bus, game.TopicGameRemoved, game.GameRemoved{Game: gs})
+	})
+
+	var count int64
+	require.NoError(t, db.Model(&models.GameSummary{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
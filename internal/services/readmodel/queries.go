@@ -0,0 +1,215 @@
+package readmodel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dixitme/internal/cache"
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Lobby browser visibilities
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+	VisibilityAll     = "all"
+)
+
+// GameFilter narrows a game listing
+type GameFilter struct {
+	Pace       string
+	Status     models.GameStatus
+	Visibility string // public, private or all (the default)
+	HasSpace   bool   // Only rooms with a free seat
+	Region     string
+	Limit      int
+	Offset     int
+}
+
+// ListGames returns game summaries, newest first, and how many match the filter
+func ListGames(ctx context.Context, db *gorm.DB, filter GameFilter) ([]models.GameSummary, int64, error) {
+	query := db.WithContext(ctx).Model(&models.GameSummary{})
+	if filter.Pace != "" {
+		query = query.Where("pace = ?", filter.Pace)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	switch filter.Visibility {
+	case VisibilityPublic:
+		query = query.Where("private = ?", false)
+	case VisibilityPrivate:
+		query = query.Where("private = ?", true)
+	}
+	if filter.Region != "" {
+		query = query.Where("region = ?", filter.Region)
+	}
+	if filter.HasSpace {
+		query = query.Where("player_count + bot_count < max_players")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count games: %w", err)
+	}
+
+	summaries := make([]models.GameSummary, 0)
+	if err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&summaries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list games: %w", err)
+	}
+	return summaries, total, nil
+}
+
+// PlayerHistory returns a player's finished games, most recent first, and how many there are
+func PlayerHistory(ctx context.Context, db *gorm.DB, playerID uuid.UUID, limit, offset int) ([]models.PlayerGameStat, int64, error) {
+	query := db.WithContext(ctx).Model(&models.PlayerGameStat{}).Where("player_id = ?", playerID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count player games: %w", err)
+	}
+
+	stats := make([]models.PlayerGameStat, 0)
+	if err := query.Order("completed_at DESC").Limit(limit).Offset(offset).Find(&stats).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load player history: %w", err)
+	}
+	return stats, total, nil
+}
+
+// RebuildReport tells how many rows a rebuild wrote
+type RebuildReport struct {
+	Games       int   `json:"games"`
+	PlayerStats int   `json:"player_stats"`
+	DurationMs  int64 `json:"duration_ms"`
+}
+
+// Rebuild recomputes the read tables from the games, game players and game
+// history tables. It backfills games from before the projector existed and
+// repairs events the projector dropped or failed to write.
+func Rebuild(ctx context.Context, db *gorm.DB) (*RebuildReport, error) {
+	started := time.Now()
+	report := &RebuildReport{}
+
+	var games []models.Game
+	if err := db.WithContext(ctx).Preload("Players.Player").Find(&games).Error; err != nil {
+		return nil, fmt.Errorf("failed to load games: %w", err)
+	}
+
+	var histories []models.GameHistory
+	if err := db.WithContext(ctx).Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("failed to load game history: %w", err)
+	}
+	historyByGame := make(map[uuid.UUID]models.GameHistory, len(histories))
+	for _, history := range histories {
+		historyByGame[history.GameID] = history
+	}
+
+	for _, g := range games {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		history, finished := historyByGame[g.ID]
+		summary, stats := rebuildGame(g, history, finished)
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&summary).Error; err != nil {
+				return err
+			}
+			for i := range stats {
+				if err := tx.Save(&stats[i]).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to rebuild game %s: %w", g.ID, err)
+		}
+		report.Games++
+		report.PlayerStats += len(stats)
+	}
+
+	cache.Invalidate(ctx, cache.ScopeGames)
+	report.DurationMs = time.Since(started).Milliseconds()
+	logger.Info("Read models rebuilt",
+		"games", report.Games,
+		"player_stats", report.PlayerStats,
+		"duration_ms", report.DurationMs)
+
+	return report, nil
+}
+
+// rebuildGame derives a game's read rows from its stored records. Rating
+// changes aren't stored per game, so rebuilt rows carry none.
+func rebuildGame(g models.Game, history models.GameHistory, finished bool) (models.GameSummary, []models.PlayerGameStat) {
+	summary := models.GameSummary{
+		GameID:       g.ID,
+		RoomCode:     g.RoomCode,
+		Status:       g.Status,
+		Pace:         g.Pace,
+		Private:      g.Private,
+		Region:       g.Region,
+		MaxPlayers:   g.MaxPlayers,
+		RoundsPlayed: g.CurrentRound,
+		CreatedAt:    g.CreatedAt,
+		UpdatedAt:    time.Now(),
+	}
+
+	scores := make([]int, 0, len(g.Players))
+	for _, gp := range g.Players {
+		if gp.Player.Type == models.PlayerTypeBot {
+			summary.BotCount++
+		} else {
+			summary.PlayerCount++
+		}
+		scores = append(scores, gp.Score)
+		if gp.Position == 1 {
+			summary.HostID = gp.PlayerID
+		}
+	}
+	if !finished {
+		return summary, nil
+	}
+
+	completedAt := history.CreatedAt
+	summary.CompletedAt = &completedAt
+	summary.Outcome = history.Outcome
+	summary.Ranked = history.Ranked
+	summary.RoundsPlayed = history.TotalRounds
+	if history.WinnerID != uuid.Nil {
+		winnerID := history.WinnerID
+		summary.WinnerID = &winnerID
+	}
+
+	sortScores(scores)
+	stats := make([]models.PlayerGameStat, 0, len(g.Players))
+	for _, gp := range g.Players {
+		if gp.PlayerID == history.WinnerID {
+			summary.WinnerName = gp.Player.Name
+		}
+		if gp.Player.Type == models.PlayerTypeBot {
+			continue
+		}
+		stats = append(stats, models.PlayerGameStat{
+			GameID:          g.ID,
+			PlayerID:        gp.PlayerID,
+			RoomCode:        g.RoomCode,
+			PlayerName:      gp.Player.Name,
+			Score:           gp.Score,
+			Placement:       placement(scores, gp.Score),
+			Won:             gp.PlayerID == history.WinnerID,
+			Ranked:          history.Ranked,
+			Outcome:         history.Outcome,
+			TotalRounds:     history.TotalRounds,
+			PlayerCount:     len(g.Players),
+			DurationMinutes: history.Duration,
+			CompletedAt:     completedAt,
+		})
+	}
+	return summary, stats
+}
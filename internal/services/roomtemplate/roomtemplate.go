@@ -0,0 +1,220 @@
+// Package roomtemplate stores hosts' favorite room setups, so a new room can
+// be created with the same settings and bot fill in one request.
+package roomtemplate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"dixitme/internal/models"
+	"dixitme/internal/services/bot"
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxPerUser caps how many templates one user can save
+	MaxPerUser = 20
+
+	maxNameLength = 50
+	maxBots       = 5
+)
+
+var (
+	ErrNotFound  = errors.New("room template not found")
+	ErrNameTaken = errors.New("a room template with this name already exists")
+	ErrTooMany   = fmt.Errorf("at most %d room templates can be saved", MaxPerUser)
+)
+
+// Spec is what a host saves in a template
+type Spec struct {
+	Name     string            `json:"name"`
+	Settings game.GameSettings `json:"settings"`
+	Bots     int               `json:"bots"`      // Bots seated when a room is created
+	BotLevel string            `json:"bot_level"` // easy, medium, hard or auto (the bot difficulty policy)
+	Sandbox  bool              `json:"sandbox"`   // Practice rooms that are never persisted
+}
+
+// Template is a saved template with its decoded settings
+type Template struct {
+	models.RoomTemplate
+	Settings game.GameSettings `json:"settings"`
+}
+
+// validate checks a spec and normalizes its name and settings
+func (s Spec) validate() (Spec, error) {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" || len(s.Name) > maxNameLength {
+		return s, fmt.Errorf("template name must be between 1 and %d characters", maxNameLength)
+	}
+	if s.Bots < 0 || s.Bots > maxBots {
+		return s, fmt.Errorf("bots must be between 0 and %d", maxBots)
+	}
+	if s.BotLevel == "" {
+		s.BotLevel = "medium"
+	}
+	if s.BotLevel != "easy" && s.BotLevel != "medium" && s.BotLevel != "hard" && s.BotLevel != bot.LevelAuto {
+		return s, fmt.Errorf("invalid bot level. Must be easy, medium, hard or auto")
+	}
+
+	settings, err := game.ValidateSettings(s.Settings)
+	if err != nil {
+		return s, err
+	}
+	if settings.Ranked && s.Sandbox {
+		return s, fmt.Errorf("sandbox games can't be ranked")
+	}
+	// Chat controls are set live by the host, never saved
+	settings.Chat = game.ChatModeration{}
+	s.Settings = settings
+	return s, nil
+}
+
+// Create saves a new template for a user
+func Create(ctx context.Context, db *gorm.DB, userID uuid.UUID, spec Spec) (*Template, error) {
+	spec, err := spec.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := db.WithContext(ctx).Model(&models.RoomTemplate{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to count room templates: %w", err)
+	}
+	if count >= MaxPerUser {
+		return nil, ErrTooMany
+	}
+	if err := checkNameFree(ctx, db, userID, spec.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	row := models.RoomTemplate{ID: uuid.New(), UserID: userID}
+	if err := apply(&row, spec); err != nil {
+		return nil, err
+	}
+	if err := db.WithContext(ctx).Create(&row).Error; err != nil {
+		return nil, fmt.Errorf("failed to save room template: %w", err)
+	}
+	return &Template{RoomTemplate: row, Settings: spec.Settings}, nil
+}
+
+// List returns a user's templates in name order
+func List(ctx context.Context, db *gorm.DB, userID uuid.UUID) ([]Template, error) {
+	var rows []models.RoomTemplate
+	if err := db.WithContext(ctx).Where("user_id = ?", userID).Order("name").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list room templates: %w", err)
+	}
+
+	templates := make([]Template, 0, len(rows))
+	for _, row := range rows {
+		template, err := decode(row)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *template)
+	}
+	return templates, nil
+}
+
+// Get loads one of a user's templates. Other users' templates are not found.
+func Get(ctx context.Context, db *gorm.DB, userID, templateID uuid.UUID) (*Template, error) {
+	row, err := load(ctx, db, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	return decode(*row)
+}
+
+// Update replaces one of a user's templates
+func Update(ctx context.Context, db *gorm.DB, userID, templateID uuid.UUID, spec Spec) (*Template, error) {
+	spec, err := spec.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := load(ctx, db, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNameFree(ctx, db, userID, spec.Name, templateID); err != nil {
+		return nil, err
+	}
+
+	if err := apply(row, spec); err != nil {
+		return nil, err
+	}
+	if err := db.WithContext(ctx).Save(row).Error; err != nil {
+		return nil, fmt.Errorf("failed to update room template: %w", err)
+	}
+	return &Template{RoomTemplate: *row, Settings: spec.Settings}, nil
+}
+
+// Delete removes one of a user's templates
+func Delete(ctx context.Context, db *gorm.DB, userID, templateID uuid.UUID) error {
+	result := db.WithContext(ctx).Delete(&models.RoomTemplate{}, "id = ? AND user_id = ?", templateID, userID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete room template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func load(ctx context.Context, db *gorm.DB, userID, templateID uuid.UUID) (*models.RoomTemplate, error) {
+	var row models.RoomTemplate
+	if err := db.WithContext(ctx).First(&row, "id = ? AND user_id = ?", templateID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to load room template: %w", err)
+	}
+	return &row, nil
+}
+
+// checkNameFree makes sure none of the user's other templates has the name
+func checkNameFree(ctx context.Context, db *gorm.DB, userID uuid.UUID, name string, except uuid.UUID) error {
+	var count int64
+	if err := db.WithContext(ctx).Model(&models.RoomTemplate{}).
+		Where("user_id = ? AND name = ? AND id <> ?", userID, name, except).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check room template name: %w", err)
+	}
+	if count > 0 {
+		return ErrNameTaken
+	}
+	return nil
+}
+
+// apply copies a validated spec onto a row
+func apply(row *models.RoomTemplate, spec Spec) error {
+	encoded, err := json.Marshal(spec.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to encode room settings: %w", err)
+	}
+	row.Name = spec.Name
+	row.Settings = string(encoded)
+	row.Bots = spec.Bots
+	row.BotLevel = spec.BotLevel
+	row.Sandbox = spec.Sandbox
+	row.UpdatedAt = time.Now()
+	return nil
+}
+
+// decode reads a row's settings on top of the defaults, so settings added
+// since the template was saved start out at their default
+func decode(row models.RoomTemplate) (*Template, error) {
+	settings := game.DefaultGameSettings()
+	if row.Settings != "" {
+		if err := json.Unmarshal([]byte(row.Settings), &settings); err != nil {
+			return nil, fmt.Errorf("failed to decode room template %s: %w", row.ID, err)
+		}
+	}
+	return &Template{RoomTemplate: row, Settings: settings}, nil
+}
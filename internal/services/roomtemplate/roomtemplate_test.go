@@ -0,0 +1,42 @@
+package roomtemplate
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+	"dixitme/internal/services/game"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecValidate(t *testing.T) {
+	settings := game.DefaultGameSettings()
+	settings.Pace = game.PaceBlitz
+	settings.Chat.Frozen = true
+
+	spec, err := Spec{Name: "  Friday night  ", Settings: settings, Bots: 2}.validate()
+	require.NoError(t, err)
+	assert.Equal(t, "Friday night", spec.Name)
+	assert.Equal(t, "medium", spec.BotLevel)
+	assert.Equal(t, game.PacePresets()[game.PaceBlitz], spec.Settings.Timing)
+	assert.False(t, spec.Settings.Chat.Frozen, "chat controls are not saved")
+
+	_, err = Spec{Name: "", Settings: settings}.validate()
+	assert.Error(t, err)
+	_, err = Spec{Name: "Bots", Settings: settings, Bots: 6}.validate()
+	assert.Error(t, err)
+	_, err = Spec{Name: "Bots", Settings: settings, BotLevel: "expert"}.validate()
+	assert.Error(t, err)
+
+	settings.Ranked = true
+	_, err = Spec{Name: "Practice", Settings: settings, Sandbox: true}.validate()
+	assert.Error(t, err)
+}
+
+func TestDecodeFillsNewSettingsWithDefaults(t *testing.T) {
+	template, err := decode(models.RoomTemplate{Name: "Old", Settings: `{"mulligan":true}`})
+	require.NoError(t, err)
+	assert.True(t, template.Settings.Mulligan)
+	assert.Equal(t, game.PaceStandard, template.Settings.Pace)
+}
@@ -0,0 +1,77 @@
+package roundimage
+
+import (
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register GIF decoding
+	_ "image/jpeg" // Register JPEG decoding
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	redisClient "dixitme/internal/redis"
+	"dixitme/internal/services/cardimages"
+	"dixitme/internal/services/game"
+
+	"gorm.io/gorm"
+)
+
+// CacheTTL is how long a rendered image is kept. Completed rounds do not change.
+const CacheTTL = 24 * time.Hour
+
+// renderVersion is part of cache keys and ETags; bump it when the layout changes
+const renderVersion = 1
+
+// ETag identifies a round's rendered image
+func ETag(summary *game.RoundSummary) string {
+	return fmt.Sprintf(`"round-%s-%d-v%d"`, summary.GameID, summary.RoundNumber, renderVersion)
+}
+
+// Cached returns a round's image if it was rendered recently by any instance
+func Cached(ctx context.Context, summary *game.RoundSummary) ([]byte, bool) {
+	client := redisClient.GetClient()
+	if client == nil {
+		return nil, false
+	}
+	data, err := client.Get(ctx, cacheKey(summary)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Store caches a round's rendered image
+func Store(ctx context.Context, summary *game.RoundSummary, data []byte) {
+	client := redisClient.GetClient()
+	if client == nil {
+		return
+	}
+	if err := client.Set(ctx, cacheKey(summary), data, CacheTTL).Err(); err != nil {
+		logger.Warn("Failed to cache round image", "error", err, "game_id", summary.GameID, "round", summary.RoundNumber)
+	}
+}
+
+// LoadArt decodes a card's image from local disk or MinIO
+func LoadArt(db *gorm.DB, images *cardimages.Checker, cardID int) (image.Image, error) {
+	var card models.Card
+	if err := db.First(&card, cardID).Error; err != nil {
+		return nil, fmt.Errorf("card not found")
+	}
+
+	reader, err := images.OpenImage(card)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	art, _, err := image.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode card image: %w", err)
+	}
+	return art, nil
+}
+
+func cacheKey(summary *game.RoundSummary) string {
+	return fmt.Sprintf("roundimage:v%d:%s:%d", renderVersion, summary.GameID, summary.RoundNumber)
+}
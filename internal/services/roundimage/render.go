@@ -0,0 +1,167 @@
+// Package roundimage renders a completed round as a shareable PNG: the clue,
+// the storyteller's card and how the votes fell. Rendering uses only the
+// standard library, with a built-in bitmap font.
+package roundimage
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+
+	"dixitme/internal/services/game"
+)
+
+// Image size, the common link preview size
+const (
+	Width  = 1200
+	Height = 630
+)
+
+var (
+	background  = color.RGBA{0x1f, 0x29, 0x37, 0xff}
+	panel       = color.RGBA{0x37, 0x41, 0x51, 0xff}
+	muted       = color.RGBA{0x9c, 0xa3, 0xaf, 0xff}
+	foreground  = color.RGBA{0xf9, 0xfa, 0xfb, 0xff}
+	gold        = color.RGBA{0xf5, 0x9e, 0x0b, 0xff}
+	decoyBar    = color.RGBA{0x60, 0xa5, 0xfa, 0xff}
+	placeholder = color.RGBA{0x4b, 0x55, 0x63, 0xff}
+)
+
+// Layout
+const (
+	margin      = 40
+	artWidth    = 366
+	artHeight   = 550
+	border      = 6
+	textLeft    = margin + artWidth + 2*border + 48
+	textRight   = Width - margin
+	barsTop     = 330
+	rowHeight   = 34
+	maxRows     = 7
+	labelChars  = 12
+	barLeft     = textLeft + 13*6*3 // Room for labelChars at scale 3 and a gap
+	maxBarWidth = textRight - barLeft - 60
+)
+
+// Render draws a round summary. art is the storyteller's card, or nil to draw
+// a placeholder in its place.
+func Render(summary *game.RoundSummary, art image.Image) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	fill(img, img.Bounds(), background)
+
+	// The storyteller's card, framed in gold
+	frame := image.Rect(margin, margin, margin+artWidth+2*border, margin+artHeight+2*border)
+	fill(img, frame, gold)
+	slot := frame.Inset(border)
+	if art != nil {
+		fill(img, slot, panel)
+		drawScaled(img, slot, art)
+	} else {
+		fill(img, slot, placeholder)
+		drawText(img, slot.Min.X+(slot.Dx()-textWidth("?", 12))/2, slot.Min.Y+(slot.Dy()-glyphHeight*12)/2, 12, "?", muted)
+	}
+
+	y := margin + 10
+	drawText(img, textLeft, y, 3, fmt.Sprintf("Round %d", summary.RoundNumber), muted)
+	y += 50
+
+	for _, line := range wrapText(`"`+summary.Clue+`"`, (textRight-textLeft)/36, 3) {
+		drawText(img, textLeft, y, 6, line, foreground)
+		y += 58
+	}
+	if summary.StorytellerName != "" {
+		drawText(img, textLeft, y+4, 3, "by "+summary.StorytellerName, gold)
+	}
+
+	drawVotes(img, summary.Cards)
+
+	footer := "DixitMe - room " + summary.RoomCode
+	drawText(img, textRight-textWidth(footer, 2), Height-margin-glyphHeight*2, 2, footer, muted)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode round image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// drawVotes draws one bar per card, the storyteller's in gold
+func drawVotes(img *image.RGBA, cards []game.RoundSummaryCard) {
+	mostVotes := 1
+	for _, card := range cards {
+		mostVotes = max(mostVotes, card.Votes)
+	}
+
+	for i, card := range cards {
+		if i == maxRows {
+			break
+		}
+		y := barsTop + i*rowHeight
+
+		label := card.OwnerName
+		if len([]rune(label)) > labelChars {
+			label = string([]rune(label)[:labelChars-1]) + "."
+		}
+		barColor := decoyBar
+		if card.Storyteller {
+			barColor = gold
+		}
+		drawText(img, textLeft, y+2, 3, label, foreground)
+
+		width := card.Votes * maxBarWidth / mostVotes
+		if width > 0 {
+			fill(img, image.Rect(barLeft, y, barLeft+width, y+rowHeight-10), barColor)
+		}
+		drawText(img, barLeft+width+12, y+2, 3, strconv.Itoa(card.Votes), muted)
+	}
+}
+
+// fill paints a rectangle, clipped to the image
+func fill(img *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	rect = rect.Intersect(img.Bounds())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+// drawScaled fits src inside rect, keeping its aspect ratio and centring it.
+// Every destination pixel averages the source pixels it covers, so large
+// images shrink without aliasing.
+func drawScaled(dst *image.RGBA, rect image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	if sb.Dx() == 0 || sb.Dy() == 0 {
+		return
+	}
+
+	w, h := rect.Dx(), sb.Dy()*rect.Dx()/sb.Dx()
+	if h > rect.Dy() {
+		w, h = sb.Dx()*rect.Dy()/sb.Dy(), rect.Dy()
+	}
+	if w == 0 || h == 0 {
+		return
+	}
+	ox, oy := rect.Min.X+(rect.Dx()-w)/2, rect.Min.Y+(rect.Dy()-h)/2
+
+	for y := 0; y < h; y++ {
+		sy0 := sb.Min.Y + y*sb.Dy()/h
+		sy1 := max(sb.Min.Y+(y+1)*sb.Dy()/h, sy0+1)
+		for x := 0; x < w; x++ {
+			sx0 := sb.Min.X + x*sb.Dx()/w
+			sx1 := max(sb.Min.X+(x+1)*sb.Dx()/w, sx0+1)
+
+			var r, g, b, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, _ := src.At(sx, sy).RGBA()
+					r, g, b, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), n+1
+				}
+			}
+			dst.SetRGBA(ox+x, oy+y, color.RGBA{uint8(r / n >> 8), uint8(g / n >> 8), uint8(b / n >> 8), 0xff})
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package roundimage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderProducesPNG(t *testing.T) {
+	summary := &game.RoundSummary{
+		GameID:          uuid.New(),
+		RoomCode:        "ABC123",
+		RoundNumber:     3,
+		Clue:            "A quiet storm in a teacup, Ünïcode too",
+		StorytellerName: "Alice",
+		StorytellerCard: 7,
+		Cards: []game.RoundSummaryCard{
+			{CardID: 7, OwnerName: "Alice", Storyteller: true, Votes: 2},
+			{CardID: 12, OwnerName: "Bob with a very long name", Votes: 1},
+			{CardID: 30, OwnerName: "Carol"},
+		},
+	}
+	art := image.NewUniform(color.RGBA{0xff, 0, 0, 0xff})
+
+	for _, withArt := range []image.Image{nil, &image.RGBA{}, subImage(art, 400, 600)} {
+		data, err := Render(summary, withArt)
+		require.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, Width, Height), img.Bounds())
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, []string{"HELLO", "WORLD"}, wrapText("hello world", 8, 3))
+	assert.Equal(t, []string{"ABCDE", "FGH"}, wrapText("abcdefgh", 5, 3))
+	assert.Equal(t, []string{"ONE TWO", "THRE..."}, wrapText("one two three four", 7, 2))
+	assert.Equal(t, "?", normalizeText("é"))
+}
+
+func subImage(src image.Image, w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, src.At(x, y))
+		}
+	}
+	return img
+}
@@ -0,0 +1,159 @@
+package statsexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Dataset files of one export run, in the order they are written
+const (
+	FileRounds      = "rounds.csv.gz"
+	FileSubmissions = "submissions.csv.gz"
+	FileVotes       = "votes.csv.gz"
+	FileCardTags    = "card_tags.csv.gz"
+	FileManifest    = "manifest.json"
+)
+
+// SchemaVersion is bumped whenever a dataset's columns change
+const SchemaVersion = 1
+
+var (
+	roundColumns      = []string{"game", "round", "storyteller", "storyteller_is_bot", "clue", "clue_language", "storyteller_card", "players", "votes", "correct_votes", "outcome"}
+	submissionColumns = []string{"game", "round", "player", "player_is_bot", "card", "is_storyteller", "votes_received"}
+	voteColumns       = []string{"game", "round", "voter", "voter_is_bot", "card", "card_owner", "correct", "weight"}
+	cardTagColumns    = []string{"card", "tag", "weight"}
+)
+
+// Round outcomes, as scored: nobody or everybody finding the storyteller's
+// card is scored the same way
+const (
+	OutcomeAllCorrect  = "all_correct"
+	OutcomeNoneCorrect = "none_correct"
+	OutcomePartial     = "partial"
+)
+
+// anonymizer replaces game and player IDs with keyed hashes. The same ID maps
+// to the same value in every export, so rows can be joined across runs, but
+// cannot be traced back without the key.
+type anonymizer struct {
+	key []byte
+}
+
+func (a anonymizer) id(id uuid.UUID) string {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write(id[:])
+	return hex.EncodeToString(mac.Sum(nil)[:12])
+}
+
+// cardTag is one tag of a card, with its slug
+type cardTag struct {
+	CardID int
+	Slug   string
+	Weight float64
+}
+
+// datasets holds the rows of one export run
+type datasets struct {
+	rounds      [][]string
+	submissions [][]string
+	votes       [][]string
+	cardTags    [][]string
+}
+
+// buildDatasets turns completed rounds into dataset rows. bots holds the IDs
+// of bot players; tags are the tags of every card in the rounds.
+func buildDatasets(anon anonymizer, rounds []models.GameRound, bots map[uuid.UUID]bool, tags []cardTag) datasets {
+	var out datasets
+	for _, round := range rounds {
+		game := anon.id(round.GameID)
+		number := strconv.Itoa(round.RoundNumber)
+
+		owners := make(map[int]uuid.UUID, len(round.Submissions))
+		received := make(map[int]int, len(round.Submissions))
+		for _, submission := range round.Submissions {
+			owners[submission.CardID] = submission.PlayerID
+		}
+
+		correct := 0
+		for _, vote := range round.Votes {
+			received[vote.CardID]++
+			isCorrect := vote.CardID == round.StorytellerCard
+			if isCorrect {
+				correct++
+			}
+			owner := ""
+			if ownerID, exists := owners[vote.CardID]; exists {
+				owner = anon.id(ownerID)
+			}
+			weight := vote.Weight
+			if weight < 1 {
+				weight = 1
+			}
+			out.votes = append(out.votes, []string{
+				game, number, anon.id(vote.PlayerID), strconv.FormatBool(bots[vote.PlayerID]),
+				strconv.Itoa(vote.CardID), owner, strconv.FormatBool(isCorrect), strconv.Itoa(weight),
+			})
+		}
+
+		for _, submission := range round.Submissions {
+			out.submissions = append(out.submissions, []string{
+				game, number, anon.id(submission.PlayerID), strconv.FormatBool(bots[submission.PlayerID]),
+				strconv.Itoa(submission.CardID), strconv.FormatBool(submission.PlayerID == round.StorytellerID),
+				strconv.Itoa(received[submission.CardID]),
+			})
+		}
+
+		out.rounds = append(out.rounds, []string{
+			game, number, anon.id(round.StorytellerID), strconv.FormatBool(bots[round.StorytellerID]),
+			round.Clue, round.ClueLanguage, strconv.Itoa(round.StorytellerCard),
+			strconv.Itoa(len(round.Submissions)), strconv.Itoa(len(round.Votes)), strconv.Itoa(correct),
+			roundOutcome(correct, len(round.Votes)),
+		})
+	}
+
+	for _, tag := range tags {
+		out.cardTags = append(out.cardTags, []string{
+			strconv.Itoa(tag.CardID), tag.Slug, strconv.FormatFloat(tag.Weight, 'f', -1, 64),
+		})
+	}
+	return out
+}
+
+func roundOutcome(correct, votes int) string {
+	switch {
+	case correct == votes:
+		return OutcomeAllCorrect
+	case correct == 0:
+		return OutcomeNoneCorrect
+	default:
+		return OutcomePartial
+	}
+}
+
+// encodeCSV writes a gzip-compressed CSV file with a header row
+func encodeCSV(columns []string, rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	w := csv.NewWriter(zw)
+
+	if err := w.Write(columns); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write CSV rows: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+package statsexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDatasets(t *testing.T) {
+	anon := anonymizer{key: []byte("test")}
+	gameID, storyteller, alice, bot := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	round := models.GameRound{
+		GameID:          gameID,
+		RoundNumber:     1,
+		StorytellerID:   storyteller,
+		Clue:            "lost at sea",
+		ClueLanguage:    "en",
+		StorytellerCard: 7,
+		Submissions: []models.CardSubmission{
+			{PlayerID: storyteller, CardID: 7},
+			{PlayerID: alice, CardID: 12},
+			{PlayerID: bot, CardID: 30},
+		},
+		Votes: []models.Vote{
+			{PlayerID: alice, CardID: 7, Weight: 1},
+			{PlayerID: bot, CardID: 12},
+		},
+	}
+
+	data := buildDatasets(anon, []models.GameRound{round}, map[uuid.UUID]bool{bot: true}, []cardTag{{CardID: 7, Slug: "sea", Weight: 0.5}})
+
+	require.Len(t, data.rounds, 1)
+	assert.Equal(t, []string{anon.id(gameID), "1", anon.id(storyteller), "false", "lost at sea", "en", "7", "3", "2", "1", OutcomePartial}, data.rounds[0])
+
+	require.Len(t, data.votes, 2)
+	assert.Equal(t, []string{anon.id(gameID), "1", anon.id(bot), "true", "12", anon.id(alice), "false", "1"}, data.votes[1])
+
+	require.Len(t, data.submissions, 3)
+	assert.Equal(t, []string{anon.id(gameID), "1", anon.id(alice), "false", "12", "false", "1"}, data.submissions[1])
+
+	assert.Equal(t, [][]string{{"7", "sea", "0.5"}}, data.cardTags)
+}
+
+func TestAnonymizerIsStableAndKeyed(t *testing.T) {
+	id := uuid.New()
+	assert.Equal(t, anonymizer{key: []byte("a")}.id(id), anonymizer{key: []byte("a")}.id(id))
+	assert.NotEqual(t, anonymizer{key: []byte("a")}.id(id), anonymizer{key: []byte("b")}.id(id))
+	assert.NotContains(t, anonymizer{key: []byte("a")}.id(id), id.String())
+}
+
+func TestEncodeCSV(t *testing.T) {
+	encoded, err := encodeCSV([]string{"card", "tag"}, [][]string{{"7", "sea, storm"}})
+	require.NoError(t, err)
+
+	zr, err := gzip.NewReader(bytes.NewReader(encoded))
+	require.NoError(t, err)
+	records, err := csv.NewReader(zr).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"card", "tag"}, {"7", "sea, storm"}}, records)
+}
@@ -0,0 +1,297 @@
+// Package statsexport writes anonymized datasets of completed games to object
+// storage on a schedule, for training the bots offline without ad-hoc dumps
+// of the production database.
+package statsexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxGamesPerRun bounds one run; a backlog is worked off over several runs
+	maxGamesPerRun = 5000
+
+	// staleRunAfter is when a run that never finished, e.g. because its
+	// instance died, stops blocking its window
+	staleRunAfter = time.Hour
+)
+
+// Uploader stores export files (implemented by storage.MinIOClient)
+type Uploader interface {
+	EnsureBucket(ctx context.Context, bucket string) error
+	PutObject(ctx context.Context, bucket, objectName string, data io.Reader, size int64, contentType string) error
+}
+
+// minioUploader adapts the MinIO client to Uploader
+type minioUploader struct {
+	client *storage.MinIOClient
+}
+
+func (u minioUploader) EnsureBucket(ctx context.Context, bucket string) error {
+	return u.client.EnsureBucket(ctx, bucket)
+}
+
+func (u minioUploader) PutObject(ctx context.Context, bucket, objectName string, data io.Reader, size int64, contentType string) error {
+	return u.client.PutObject(ctx, bucket, objectName, data, size, contentType)
+}
+
+// Manifest describes the files of one export run
+type Manifest struct {
+	SchemaVersion int            `json:"schema_version"`
+	WindowStart   time.Time      `json:"window_start"` // Games completed after this...
+	WindowEnd     time.Time      `json:"window_end"`   // ...up to and including this
+	Games         int            `json:"games"`
+	Rows          map[string]int `json:"rows"` // Data rows per file
+}
+
+// Exporter runs the export every interval
+type Exporter struct {
+	db       *gorm.DB
+	uploader Uploader
+	bucket   string
+	anon     anonymizer
+	stop     chan struct{}
+}
+
+// NewExporter creates an exporter writing to bucket. key anonymizes game and
+// player IDs and must stay the same between runs for them to stay joinable.
+func NewExporter(db *gorm.DB, client *storage.MinIOClient, bucket, key string) *Exporter {
+	return &Exporter{
+		db:       db,
+		uploader: minioUploader{client: client},
+		bucket:   bucket,
+		anon:     anonymizer{key: []byte(key + ":stats-export")},
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the export every interval until Stop is called
+func (e *Exporter) Start(interval time.Duration) {
+	logger.Info("Stats export job started", "interval", interval, "bucket", e.bucket)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := e.Run(context.Background()); err != nil {
+					logger.Error("Stats export failed", "error", err)
+				}
+			case <-e.stop:
+				logger.Info("Stats export job stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic job
+func (e *Exporter) Stop() {
+	close(e.stop)
+}
+
+// Run exports the games completed since the previous run. It returns nil
+// when another instance is already exporting the same window.
+func (e *Exporter) Run(ctx context.Context) (*models.StatsExport, error) {
+	run, err := e.claim(ctx)
+	if err != nil || run == nil {
+		return nil, err
+	}
+
+	if err := e.export(ctx, run); err != nil {
+		// Release the window so the next run retries it
+		if releaseErr := e.db.WithContext(ctx).Delete(run).Error; releaseErr != nil {
+			logger.Error("Failed to release stats export window", "error", releaseErr, "export_id", run.ID)
+		}
+		return nil, err
+	}
+
+	logger.Info("Stats export completed",
+		"export_id", run.ID,
+		"games", run.Games,
+		"window_start", run.WindowStart,
+		"window_end", run.WindowEnd,
+		"prefix", run.Prefix)
+	return run, nil
+}
+
+// claim reserves the window after the last completed run
+func (e *Exporter) claim(ctx context.Context) (*models.StatsExport, error) {
+	db := e.db.WithContext(ctx)
+
+	if err := db.Where("status = ? AND updated_at < ?", models.StatsExportRunning, time.Now().Add(-staleRunAfter)).
+		Delete(&models.StatsExport{}).Error; err != nil {
+		return nil, fmt.Errorf("failed to clear stale stats exports: %w", err)
+	}
+
+	var last models.StatsExport
+	start := time.Time{}
+	err := db.Where("status = ?", models.StatsExportCompleted).Order("window_end DESC").Limit(1).Find(&last).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the last stats export: %w", err)
+	}
+	if last.ID != uuid.Nil {
+		start = last.WindowEnd
+	}
+
+	run := &models.StatsExport{
+		ID:          uuid.New(),
+		WindowStart: start,
+		Status:      models.StatsExportRunning,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := db.Create(run).Error; err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			logger.Info("Stats export window already claimed by another instance", "window_start", start)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim stats export window: %w", err)
+	}
+	return run, nil
+}
+
+// export writes the window's datasets and marks the run completed
+func (e *Exporter) export(ctx context.Context, run *models.StatsExport) error {
+	db := e.db.WithContext(ctx)
+	now := time.Now()
+
+	var histories []models.GameHistory
+	if err := db.Where("outcome = ? AND created_at > ? AND created_at <= ?", models.GameOutcomeCompleted, run.WindowStart, now).
+		Order("created_at").Limit(maxGamesPerRun).Find(&histories).Error; err != nil {
+		return fmt.Errorf("failed to load completed games: %w", err)
+	}
+
+	run.WindowEnd = now
+	if len(histories) == maxGamesPerRun {
+		// The rest is left to the next run
+		run.WindowEnd = histories[len(histories)-1].CreatedAt
+	}
+	run.Games = len(histories)
+	run.Prefix = fmt.Sprintf("games/%s/%s/", run.WindowEnd.UTC().Format("2006-01-02"), run.ID)
+
+	if len(histories) > 0 {
+		if err := e.writeDatasets(ctx, run, histories); err != nil {
+			return err
+		}
+	}
+
+	run.Status = models.StatsExportCompleted
+	run.UpdatedAt = time.Now()
+	if err := db.Save(run).Error; err != nil {
+		return fmt.Errorf("failed to record stats export: %w", err)
+	}
+	return nil
+}
+
+// writeDatasets loads the games' rounds and uploads every dataset file
+func (e *Exporter) writeDatasets(ctx context.Context, run *models.StatsExport, histories []models.GameHistory) error {
+	db := e.db.WithContext(ctx)
+
+	gameIDs := make([]uuid.UUID, 0, len(histories))
+	for _, history := range histories {
+		gameIDs = append(gameIDs, history.GameID)
+	}
+
+	var rounds []models.GameRound
+	if err := db.Preload("Submissions").Preload("Votes").
+		Where("game_id IN ? AND status = ?", gameIDs, models.RoundStatusCompleted).
+		Order("game_id, round_number").Find(&rounds).Error; err != nil {
+		return fmt.Errorf("failed to load rounds: %w", err)
+	}
+
+	var botIDs []uuid.UUID
+	if err := db.Model(&models.Player{}).Unscoped().
+		Where("type = ? AND id IN (?)", models.PlayerTypeBot,
+			db.Model(&models.GamePlayer{}).Select("player_id").Where("game_id IN ?", gameIDs)).
+		Pluck("id", &botIDs).Error; err != nil {
+		return fmt.Errorf("failed to load bot players: %w", err)
+	}
+	bots := make(map[uuid.UUID]bool, len(botIDs))
+	for _, id := range botIDs {
+		bots[id] = true
+	}
+
+	cardIDs := make(map[int]bool)
+	for _, round := range rounds {
+		for _, submission := range round.Submissions {
+			cardIDs[submission.CardID] = true
+		}
+	}
+	ids := make([]int, 0, len(cardIDs))
+	for id := range cardIDs {
+		ids = append(ids, id)
+	}
+
+	var tags []cardTag
+	if len(ids) > 0 {
+		if err := db.Table("card_tags").
+			Select("card_tags.card_id, tags.slug, card_tags.weight").
+			Joins("JOIN tags ON tags.id = card_tags.tag_id").
+			Where("card_tags.card_id IN ?", ids).
+			Order("card_tags.card_id, tags.slug").
+			Scan(&tags).Error; err != nil {
+			return fmt.Errorf("failed to load card tags: %w", err)
+		}
+	}
+
+	data := buildDatasets(e.anon, rounds, bots, tags)
+	files := []struct {
+		name    string
+		columns []string
+		rows    [][]string
+	}{
+		{FileRounds, roundColumns, data.rounds},
+		{FileSubmissions, submissionColumns, data.submissions},
+		{FileVotes, voteColumns, data.votes},
+		{FileCardTags, cardTagColumns, data.cardTags},
+	}
+
+	if err := e.uploader.EnsureBucket(ctx, e.bucket); err != nil {
+		return err
+	}
+
+	manifest := Manifest{
+		SchemaVersion: SchemaVersion,
+		WindowStart:   run.WindowStart,
+		WindowEnd:     run.WindowEnd,
+		Games:         run.Games,
+		Rows:          make(map[string]int, len(files)),
+	}
+	for _, file := range files {
+		encoded, err := encodeCSV(file.columns, file.rows)
+		if err != nil {
+			return err
+		}
+		if err := e.upload(ctx, run.Prefix+file.name, encoded, "application/gzip"); err != nil {
+			return err
+		}
+		manifest.Rows[file.name] = len(file.rows)
+	}
+
+	// The manifest goes last, so a run with a manifest is complete
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return e.upload(ctx, run.Prefix+FileManifest, encoded, "application/json")
+}
+
+func (e *Exporter) upload(ctx context.Context, objectName string, data []byte, contentType string) error {
+	return e.uploader.PutObject(ctx, e.bucket, objectName, bytes.NewReader(data), int64(len(data)), contentType)
+}
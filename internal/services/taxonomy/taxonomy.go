@@ -0,0 +1,239 @@
+// Package taxonomy maintains the parent/child hierarchy between tags and
+// expands tag matches up and down the tree with decaying weights.
+package taxonomy
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultDecay is the weight multiplier applied per level away from the matched tag
+	DefaultDecay = 0.5
+	// DefaultMaxDepth limits how many levels an expansion walks
+	DefaultMaxDepth = 3
+
+	cacheTTL = time.Minute
+)
+
+// Direction controls which way an expansion walks the tree
+type Direction string
+
+const (
+	DirectionNone Direction = "none"
+	DirectionUp   Direction = "up"   // Towards broader tags (dragon -> fantasy)
+	DirectionDown Direction = "down" // Towards narrower tags (fantasy -> dragon)
+	DirectionBoth Direction = "both"
+)
+
+// ParseDirection converts a query value to a Direction, defaulting to fallback
+func ParseDirection(value string, fallback Direction) Direction {
+	switch Direction(value) {
+	case DirectionNone, DirectionUp, DirectionDown, DirectionBoth:
+		return Direction(value)
+	default:
+		return fallback
+	}
+}
+
+// Taxonomy is an immutable snapshot of the tag tree
+type Taxonomy struct {
+	tags     map[int]models.Tag
+	parents  map[int]int
+	children map[int][]int
+}
+
+// Node is a tag with its children, used to render the tree
+type Node struct {
+	models.Tag
+	Children []*Node `json:"children"`
+}
+
+// New builds a taxonomy from a list of tags
+func New(tags []models.Tag) *Taxonomy {
+	t := &Taxonomy{
+		tags:     make(map[int]models.Tag, len(tags)),
+		parents:  make(map[int]int),
+		children: make(map[int][]int),
+	}
+
+	for _, tag := range tags {
+		t.tags[tag.ID] = tag
+	}
+	for _, tag := range tags {
+		if tag.ParentID == nil {
+			continue
+		}
+		if _, exists := t.tags[*tag.ParentID]; !exists {
+			continue
+		}
+		t.parents[tag.ID] = *tag.ParentID
+		t.children[*tag.ParentID] = append(t.children[*tag.ParentID], tag.ID)
+	}
+	for id := range t.children {
+		sort.Ints(t.children[id])
+	}
+
+	return t
+}
+
+// Tag returns the tag with the given ID
+func (t *Taxonomy) Tag(id int) (models.Tag, bool) {
+	tag, exists := t.tags[id]
+	return tag, exists
+}
+
+// Ancestors returns the parent chain of a tag, nearest first
+func (t *Taxonomy) Ancestors(id int) []int {
+	var ancestors []int
+	seen := map[int]bool{id: true}
+	for {
+		parent, exists := t.parents[id]
+		if !exists || seen[parent] {
+			return ancestors
+		}
+		ancestors = append(ancestors, parent)
+		seen[parent] = true
+		id = parent
+	}
+}
+
+// Expand returns the tag itself (weight 1) plus related tags in the given
+// direction, each weighted by decay^distance, up to maxDepth levels away
+func (t *Taxonomy) Expand(id int, direction Direction, decay float64, maxDepth int) map[int]float64 {
+	weights := map[int]float64{id: 1.0}
+
+	if direction == DirectionUp || direction == DirectionBoth {
+		weight := 1.0
+		for depth, ancestor := range t.Ancestors(id) {
+			if depth >= maxDepth {
+				break
+			}
+			weight *= decay
+			setMax(weights, ancestor, weight)
+		}
+	}
+
+	if direction == DirectionDown || direction == DirectionBoth {
+		level := []int{id}
+		weight := 1.0
+		for depth := 0; depth < maxDepth && len(level) > 0; depth++ {
+			weight *= decay
+			var next []int
+			for _, current := range level {
+				for _, child := range t.children[current] {
+					if _, visited := weights[child]; visited {
+						continue
+					}
+					weights[child] = weight
+					next = append(next, child)
+				}
+			}
+			level = next
+		}
+	}
+
+	return weights
+}
+
+// ExpandAll expands several tags and keeps the highest weight for each result
+func (t *Taxonomy) ExpandAll(ids []int, direction Direction, decay float64, maxDepth int) map[int]float64 {
+	weights := make(map[int]float64)
+	for _, id := range ids {
+		for related, weight := range t.Expand(id, direction, decay, maxDepth) {
+			setMax(weights, related, weight)
+		}
+	}
+	return weights
+}
+
+// ValidateParent checks that making parentID the parent of id keeps the tree acyclic
+func (t *Taxonomy) ValidateParent(id, parentID int) error {
+	if id == parentID {
+		return fmt.Errorf("a tag cannot be its own parent")
+	}
+	if _, exists := t.tags[parentID]; !exists {
+		return fmt.Errorf("parent tag %d not found", parentID)
+	}
+	for _, ancestor := range t.Ancestors(parentID) {
+		if ancestor == id {
+			return fmt.Errorf("tag %d is already an ancestor of tag %d", id, parentID)
+		}
+	}
+	return nil
+}
+
+// Tree returns the root tags with their descendants
+func (t *Taxonomy) Tree() []*Node {
+	var build func(id int) *Node
+	build = func(id int) *Node {
+		node := &Node{Tag: t.tags[id], Children: make([]*Node, 0, len(t.children[id]))}
+		for _, child := range t.children[id] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	roots := make([]int, 0)
+	for id := range t.tags {
+		if _, hasParent := t.parents[id]; !hasParent {
+			roots = append(roots, id)
+		}
+	}
+	sort.Ints(roots)
+
+	tree := make([]*Node, 0, len(roots))
+	for _, id := range roots {
+		tree = append(tree, build(id))
+	}
+	return tree
+}
+
+func setMax(weights map[int]float64, id int, weight float64) {
+	if current, exists := weights[id]; !exists || weight > current {
+		weights[id] = weight
+	}
+}
+
+// Cached loading
+
+var (
+	cacheMu  sync.Mutex
+	cached   *Taxonomy
+	cachedAt time.Time
+)
+
+// Load returns the current taxonomy, reloading it from the database when the cache is stale
+func Load(db *gorm.DB) (*Taxonomy, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	if cached != nil && time.Since(cachedAt) < cacheTTL {
+		return cached, nil
+	}
+
+	var tags []models.Tag
+	if err := db.Where("is_active = ?", true).Find(&tags).Error; err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
+
+	cached = New(tags)
+	cachedAt = time.Now()
+	logger.Debug("Tag taxonomy loaded", "tags", len(tags))
+
+	return cached, nil
+}
+
+// Invalidate drops the cached taxonomy so the next Load reads fresh data
+func Invalidate() {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cached = nil
+}
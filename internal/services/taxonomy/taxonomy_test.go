@@ -0,0 +1,48 @@
+package taxonomy
+
+import (
+	"testing"
+
+	"dixitme/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int { return &v }
+
+// fantasy(1) -> creature(2) -> dragon(3); fantasy(1) -> castle(4)
+func newTestTaxonomy() *Taxonomy {
+	return New([]models.Tag{
+		{ID: 1, Name: "Fantasy"},
+		{ID: 2, Name: "Fantasy Creature", ParentID: intPtr(1)},
+		{ID: 3, Name: "Dragon", ParentID: intPtr(2)},
+		{ID: 4, Name: "Castle", ParentID: intPtr(1)},
+	})
+}
+
+func TestExpand(t *testing.T) {
+	tree := newTestTaxonomy()
+
+	assert.Equal(t, map[int]float64{3: 1, 2: 0.5, 1: 0.25}, tree.Expand(3, DirectionUp, 0.5, 3))
+	assert.Equal(t, map[int]float64{1: 1, 2: 0.5, 4: 0.5, 3: 0.25}, tree.Expand(1, DirectionDown, 0.5, 3))
+	assert.Equal(t, map[int]float64{1: 1, 2: 0.5, 4: 0.5}, tree.Expand(1, DirectionDown, 0.5, 1))
+	assert.Equal(t, map[int]float64{2: 1}, tree.Expand(2, DirectionNone, 0.5, 3))
+}
+
+func TestValidateParent(t *testing.T) {
+	tree := newTestTaxonomy()
+
+	assert.NoError(t, tree.ValidateParent(4, 2))
+	assert.Error(t, tree.ValidateParent(1, 3), "moving a root under its descendant creates a cycle")
+	assert.Error(t, tree.ValidateParent(2, 2))
+	assert.Error(t, tree.ValidateParent(2, 99))
+}
+
+func TestTree(t *testing.T) {
+	roots := newTestTaxonomy().Tree()
+
+	assert.Len(t, roots, 1)
+	assert.Equal(t, 1, roots[0].ID)
+	assert.Len(t, roots[0].Children, 2)
+	assert.Equal(t, 3, roots[0].Children[0].Children[0].ID)
+}
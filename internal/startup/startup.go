@@ -0,0 +1,233 @@
+// Package startup brings the server's subsystems up in order, retrying the
+// ones that depend on other services, and takes them down in reverse order.
+package startup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"dixitme/internal/logger"
+)
+
+// Status is where a component is in its life
+type Status string
+
+const (
+	StatusStarting Status = "starting"
+	StatusReady    Status = "ready"
+	StatusDegraded Status = "degraded" // An optional component failed; the server runs without it
+	StatusFailed   Status = "failed"   // A required component failed; the server can't run
+	StatusStopped  Status = "stopped"
+)
+
+// Config is how hard the orchestrator tries to start a component
+type Config struct {
+	Attempts       int           // Tries per component before giving up
+	InitialBackoff time.Duration // Wait after the first failed try, doubled after each one
+	MaxBackoff     time.Duration
+}
+
+// DefaultConfig returns the retry policy used when none is configured
+func DefaultConfig() Config {
+	return Config{Attempts: 5, InitialBackoff: time.Second, MaxBackoff: 15 * time.Second}
+}
+
+// Component is a subsystem the server starts
+type Component struct {
+	Name     string
+	Required bool                            // The server can't run without it; optional components degrade instead
+	Attempts int                             // Tries before giving up, when not the configured number
+	Start    func(ctx context.Context) error // Retried with backoff until it succeeds
+	Stop     func()                          // Shutdown hook, run in reverse start order (optional)
+	Check    func(ctx context.Context) error // Live health check for readiness (optional)
+}
+
+// ComponentStatus is a component's state as reported on readiness
+type ComponentStatus struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Status   Status `json:"status"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error,omitempty"`
+}
+
+type component struct {
+	Component
+	status   Status
+	attempts int
+	err      error
+}
+
+type hook struct {
+	name string
+	stop func()
+}
+
+// Orchestrator starts components and keeps their status and shutdown hooks
+type Orchestrator struct {
+	cfg   Config
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu           sync.RWMutex
+	components   []*component
+	hooks        []hook
+	shuttingDown bool
+}
+
+// New creates an orchestrator with a retry policy; unset fields use the defaults
+func New(cfg Config) *Orchestrator {
+	defaults := DefaultConfig()
+	if cfg.Attempts <= 0 {
+		cfg.Attempts = defaults.Attempts
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = defaults.InitialBackoff
+	}
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		cfg.MaxBackoff = max(defaults.MaxBackoff, cfg.InitialBackoff)
+	}
+	return &Orchestrator{cfg: cfg, sleep: sleep}
+}
+
+// Start brings a component up, retrying with exponential backoff. A required
+// component that never starts is a hard failure and its error is returned; an
+// optional one is marked degraded and Start returns nil so startup goes on.
+func (o *Orchestrator) Start(ctx context.Context, c Component) error {
+	log := logger.GetLogger()
+	state := &component{Component: c, status: StatusStarting}
+	o.mu.Lock()
+	o.components = append(o.components, state)
+	o.mu.Unlock()
+
+	attempts := o.cfg.Attempts
+	if c.Attempts > 0 {
+		attempts = c.Attempts
+	}
+	backoff := o.cfg.InitialBackoff
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		o.mu.Lock()
+		state.attempts = attempt
+		o.mu.Unlock()
+
+		if err = c.Start(ctx); err == nil {
+			break
+		}
+		if attempt == attempts {
+			break
+		}
+		log.Warn("Component failed to start, retrying",
+			"component", c.Name,
+			"attempt", attempt,
+			"retry_in", backoff,
+			"error", err)
+		if sleepErr := o.sleep(ctx, backoff); sleepErr != nil {
+			err = sleepErr
+			break
+		}
+		backoff = min(backoff*2, o.cfg.MaxBackoff)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	state.err = err
+	switch {
+	case err == nil:
+		state.status = StatusReady
+		if c.Stop != nil {
+			o.hooks = append(o.hooks, hook{name: c.Name, stop: c.Stop})
+		}
+		log.Info("Component started", "component", c.Name, "attempts", state.attempts)
+		return nil
+	case c.Required:
+		state.status = StatusFailed
+		log.Error("Required component failed to start", "component", c.Name, "attempts", state.attempts, "error", err)
+		return fmt.Errorf("%s: %w", c.Name, err)
+	default:
+		state.status = StatusDegraded
+		log.Warn("Optional component unavailable, running degraded", "component", c.Name, "attempts", state.attempts, "error", err)
+		return nil
+	}
+}
+
+// OnShutdown registers a shutdown hook for something started outside the
+// orchestrator, e.g. a background job. Hooks run in reverse registration order.
+func (o *Orchestrator) OnShutdown(name string, stop func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.hooks = append(o.hooks, hook{name: name, stop: stop})
+}
+
+// Shutdown runs the shutdown hooks, the latest registered first, so nothing
+// stops before what depends on it. It runs them once.
+func (o *Orchestrator) Shutdown() {
+	o.mu.Lock()
+	if o.shuttingDown {
+		o.mu.Unlock()
+		return
+	}
+	o.shuttingDown = true
+	hooks := o.hooks
+	o.hooks = nil
+	o.mu.Unlock()
+
+	log := logger.GetLogger()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		log.Info("Stopping component", "component", hooks[i].name)
+		hooks[i].stop()
+	}
+
+	o.mu.Lock()
+	for _, c := range o.components {
+		if c.status == StatusReady {
+			c.status = StatusStopped
+		}
+	}
+	o.mu.Unlock()
+}
+
+// Report lists the status of every component, running the live checks of
+// the ready ones, and whether the server can take traffic: every required
+// component is up and the server isn't shutting down
+func (o *Orchestrator) Report(ctx context.Context) ([]ComponentStatus, bool) {
+	o.mu.RLock()
+	components := append([]*component(nil), o.components...)
+	ready := !o.shuttingDown
+	statuses := make([]ComponentStatus, len(components))
+	for i, c := range components {
+		statuses[i] = ComponentStatus{Name: c.Name, Required: c.Required, Status: c.status, Attempts: c.attempts}
+		if c.err != nil {
+			statuses[i].Error = c.err.Error()
+		}
+	}
+	o.mu.RUnlock()
+
+	for i, c := range components {
+		if statuses[i].Status == StatusReady && c.Check != nil {
+			if err := c.Check(ctx); err != nil {
+				statuses[i].Status = StatusDegraded
+				if c.Required {
+					statuses[i].Status = StatusFailed
+				}
+				statuses[i].Error = err.Error()
+			}
+		}
+		if c.Required && statuses[i].Status != StatusReady {
+			ready = false
+		}
+	}
+	return statuses, ready
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,104 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOrchestrator(waits *[]time.Duration) *Orchestrator {
+	o := New(Config{Attempts: 4, InitialBackoff: time.Second, MaxBackoff: 3 * time.Second})
+	o.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return o
+}
+
+func TestStartRetriesWithBackoff(t *testing.T) {
+	var waits []time.Duration
+	o := testOrchestrator(&waits)
+
+	tries := 0
+	require.NoError(t, o.Start(context.Background(), Component{
+		Name:     "database",
+		Required: true,
+		Start: func(context.Context) error {
+			if tries++; tries < 4 {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+	}))
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, waits)
+
+	statuses, ready := o.Report(context.Background())
+	assert.True(t, ready)
+	assert.Equal(t, []ComponentStatus{{Name: "database", Required: true, Status: StatusReady, Attempts: 4}}, statuses)
+}
+
+func TestRequiredFailureIsHardOptionalIsDegraded(t *testing.T) {
+	var waits []time.Duration
+	o := testOrchestrator(&waits)
+	down := func(context.Context) error { return errors.New("down") }
+
+	require.NoError(t, o.Start(context.Background(), Component{Name: "minio", Start: down}))
+	statuses, ready := o.Report(context.Background())
+	assert.True(t, ready, "an optional component doesn't hold up traffic")
+	assert.Equal(t, StatusDegraded, statuses[0].Status)
+	assert.Equal(t, "down", statuses[0].Error)
+
+	err := o.Start(context.Background(), Component{Name: "redis", Required: true, Attempts: 1, Start: down})
+	assert.EqualError(t, err, "redis: down")
+	statuses, ready = o.Report(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, StatusFailed, statuses[1].Status)
+	assert.Equal(t, 1, statuses[1].Attempts)
+}
+
+func TestReportRunsLiveChecks(t *testing.T) {
+	var waits []time.Duration
+	o := testOrchestrator(&waits)
+	healthy := true
+	require.NoError(t, o.Start(context.Background(), Component{
+		Name:     "redis",
+		Required: true,
+		Start:    func(context.Context) error { return nil },
+		Check: func(context.Context) error {
+			if !healthy {
+				return errors.New("timeout")
+			}
+			return nil
+		},
+	}))
+
+	_, ready := o.Report(context.Background())
+	assert.True(t, ready)
+	healthy = false
+	statuses, ready := o.Report(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, StatusFailed, statuses[0].Status)
+}
+
+func TestShutdownRunsHooksInReverse(t *testing.T) {
+	var waits []time.Duration
+	o := testOrchestrator(&waits)
+	var stopped []string
+	up := func(context.Context) error { return nil }
+
+	require.NoError(t, o.Start(context.Background(), Component{Name: "database", Start: up, Stop: func() { stopped = append(stopped, "database") }}))
+	require.NoError(t, o.Start(context.Background(), Component{Name: "redis", Start: up, Stop: func() { stopped = append(stopped, "redis") }}))
+	o.OnShutdown("projector", func() { stopped = append(stopped, "projector") })
+
+	o.Shutdown()
+	o.Shutdown()
+	assert.Equal(t, []string{"projector", "redis", "database"}, stopped)
+
+	statuses, ready := o.Report(context.Background())
+	assert.False(t, ready, "a server shutting down takes no traffic")
+	assert.Equal(t, StatusStopped, statuses[0].Status)
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -33,6 +34,9 @@ type MinIOConfig struct {
 
 var minioClient *MinIOClient
 
+// ErrImageNotFound is returned when a card image object doesn't exist
+var ErrImageNotFound = errors.New("image not found")
+
 // Initialize sets up MinIO client
 func Initialize(cfg MinIOConfig) error {
 	log := logger.GetLogger()
@@ -47,11 +51,6 @@ func Initialize(cfg MinIOConfig) error {
 		return fmt.Errorf("failed to create MinIO client: %w", err)
 	}
 
-	minioClient = &MinIOClient{
-		client:     client,
-		bucketName: cfg.BucketName,
-	}
-
 	// Check if bucket exists, create if not
 	ctx := context.Background()
 	exists, err := client.BucketExists(ctx, cfg.BucketName)
@@ -87,10 +86,28 @@ func Initialize(cfg MinIOConfig) error {
 		log.Warn("Failed to set bucket policy", "error", err)
 	}
 
+	// Only a working client is handed out; callers fall back without one
+	minioClient = &MinIOClient{
+		client:     client,
+		bucketName: cfg.BucketName,
+	}
+
 	log.Info("MinIO client initialized", "endpoint", cfg.Endpoint, "bucket", cfg.BucketName)
 	return nil
 }
 
+// Ping checks that MinIO answers and the card bucket is there
+func (mc *MinIOClient) Ping(ctx context.Context) error {
+	exists, err := mc.client.BucketExists(ctx, mc.bucketName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s not found", mc.bucketName)
+	}
+	return nil
+}
+
 // GetClient returns the MinIO client instance
 func GetClient() *MinIOClient {
 	return minioClient
@@ -141,6 +158,25 @@ func (mc *MinIOClient) UploadCardImage(cardID int, file multipart.File, header *
 	return url, nil
 }
 
+// PutCardImage uploads card image data that did not come from a form upload,
+// e.g. from a bulk import, and returns its public URL
+func (mc *MinIOClient) PutCardImage(ctx context.Context, cardID int, extension string, data io.Reader, size int64, contentType string) (string, error) {
+	if extension == "" {
+		extension = ".jpg"
+	}
+	objectName := fmt.Sprintf("cards/%d%s", cardID, extension)
+	if _, err := mc.client.PutObject(ctx, mc.bucketName, objectName, data, size, minio.PutObjectOptions{
+		ContentType: contentType,
+		UserMetadata: map[string]string{
+			"card-id":     fmt.Sprintf("%d", cardID),
+			"uploaded-at": time.Now().Format(time.RFC3339),
+		},
+	}); err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	return mc.GetCardImageURL(cardID, extension), nil
+}
+
 // GetCardImageURL returns the public URL for a card image
 func (mc *MinIOClient) GetCardImageURL(cardID int, extension string) string {
 	if extension == "" {
@@ -264,3 +300,56 @@ func (mc *MinIOClient) GeneratePresignedDownloadURL(cardID int, extension string
 
 	return url.String(), nil
 }
+
+// CardImageInfo describes a stored card image object
+type CardImageInfo struct {
+	Size        int64
+	ContentType string
+}
+
+// StatCardImage returns the size and content type of a card image without downloading it
+func (mc *MinIOClient) StatCardImage(ctx context.Context, cardID int, extension string) (*CardImageInfo, error) {
+	if extension == "" {
+		extension = ".jpg"
+	}
+	if !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+
+	objectName := fmt.Sprintf("cards/%d%s", cardID, extension)
+
+	info, err := mc.client.StatObject(ctx, mc.bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to stat image: %w", err)
+	}
+
+	return &CardImageInfo{Size: info.Size, ContentType: info.ContentType}, nil
+}
+
+// EnsureBucket creates a private bucket if it does not exist yet
+func (mc *MinIOClient) EnsureBucket(ctx context.Context, bucket string) error {
+	exists, err := mc.client.BucketExists(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to check bucket existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if err := mc.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+		return fmt.Errorf("failed to create bucket: %w", err)
+	}
+	logger.Info("MinIO bucket created", "bucket", bucket)
+	return nil
+}
+
+// PutObject uploads data to any bucket, e.g. for exports that do not belong
+// with the card images
+func (mc *MinIOClient) PutObject(ctx context.Context, bucket, objectName string, data io.Reader, size int64, contentType string) error {
+	if _, err := mc.client.PutObject(ctx, bucket, objectName, data, size, minio.PutObjectOptions{ContentType: contentType}); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", objectName, err)
+	}
+	return nil
+}
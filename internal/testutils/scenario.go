@@ -0,0 +1,643 @@
+// Package testutils holds helpers shared by tests across packages.
+//
+// The scenario runner drives game flows declaratively against a real game
+// Manager, with every player on an in-memory connection and time under the
+// test's control:
+//
+//	testutils.RunScenario(t,
+//		testutils.CreateRoom("Alice"),
+//		testutils.Join("Bob"),
+//		testutils.Join("Cara"),
+//		testutils.Start("Alice"),
+//		testutils.GiveClue("a quiet storm"),
+//		testutils.ExpectBroadcast(game.MessageTypeClueSubmitted),
+//		testutils.SubmitCards(),
+//		testutils.ExpectPhase(models.RoundStatusVoting),
+//		testutils.Vote("Bob", testutils.StorytellerCard),
+//		testutils.Vote("Cara", testutils.StorytellerCard),
+//		testutils.ExpectBroadcast(game.MessageTypeRoundCompleted),
+//		testutils.AdvanceTime(5*time.Second),
+//		testutils.ExpectRound(2),
+//	)
+//
+// Players are referred to by name. Steps that act "as the storyteller" or "as
+// everyone else" look the roles up in the current round, since the manager
+// picks the storyteller.
+package testutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/models"
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+)
+
+// Step is one action or expectation in a scenario
+type Step struct {
+	Name string
+	Run  func(s *Scenario) error
+}
+
+// Scenario is a running game flow
+type Scenario struct {
+	t        testing.TB
+	Manager  *game.Manager
+	Clock    *ManualScheduler
+	RoomCode string
+
+	players map[string]uuid.UUID
+	conns   map[string]*MemoryConnection
+	order   []string // Names in the order they arrived
+}
+
+// NewScenario creates a scenario on an ephemeral manager whose delayed events
+// only run when the scenario advances time
+func NewScenario(t testing.TB) *Scenario {
+	clock := NewManualScheduler()
+	manager := game.NewEphemeralManager()
+	manager.SetScheduler(clock)
+
+	s := &Scenario{
+		t:        t,
+		Manager:  manager,
+		Clock:    clock,
+		RoomCode: "SCN" + strings.ToUpper(uuid.NewString()[:5]),
+		players:  make(map[string]uuid.UUID),
+		conns:    make(map[string]*MemoryConnection),
+	}
+	t.Cleanup(func() {
+		for name, playerID := range s.players {
+			game.UnregisterPlayerConnection(playerID, s.conns[name])
+		}
+	})
+	return s
+}
+
+// RunScenario runs steps in order on a new scenario, failing the test at the
+// first step that fails
+func RunScenario(t testing.TB, steps ...Step) *Scenario {
+	t.Helper()
+	s := NewScenario(t)
+	s.Run(steps...)
+	return s
+}
+
+// Run runs steps in order, failing the test at the first step that fails
+func (s *Scenario) Run(steps ...Step) {
+	s.t.Helper()
+	for i, step := range steps {
+		if err := step.Run(s); err != nil {
+			s.t.Fatalf("scenario step %d (%s): %v", i+1, step.Name, err)
+		}
+	}
+}
+
+// PlayerID returns the ID of a named player
+func (s *Scenario) PlayerID(name string) uuid.UUID {
+	return s.players[name]
+}
+
+// Connection returns the in-memory connection of a named player
+func (s *Scenario) Connection(name string) *MemoryConnection {
+	return s.conns[name]
+}
+
+// player looks up a named player, failing when the scenario never added them
+func (s *Scenario) player(name string) (uuid.UUID, error) {
+	playerID, ok := s.players[name]
+	if !ok {
+		return uuid.Nil, fmt.Errorf("unknown player %q", name)
+	}
+	return playerID, nil
+}
+
+// connect gives a new player an ID and an in-memory connection
+func (s *Scenario) connect(name string) uuid.UUID {
+	playerID := uuid.New()
+	conn := &MemoryConnection{}
+	s.players[name] = playerID
+	s.conns[name] = conn
+	s.order = append(s.order, name)
+	game.RegisterPlayerConnection(playerID, conn)
+	return playerID
+}
+
+// nameOf returns the scenario name of a player ID
+func (s *Scenario) nameOf(playerID uuid.UUID) string {
+	for name, id := range s.players {
+		if id == playerID {
+			return name
+		}
+	}
+	return playerID.String()
+}
+
+// inspect runs f on the game while holding its lock
+func (s *Scenario) inspect(f func(gs *game.GameState) error) error {
+	gs := s.Manager.GetGame(s.RoomCode)
+	if gs == nil {
+		return fmt.Errorf("room %s does not exist", s.RoomCode)
+	}
+	gs.Lock()
+	defer gs.Unlock()
+	return f(gs)
+}
+
+// round returns the current round's storyteller and the other seated humans,
+// in arrival order
+func (s *Scenario) round() (storyteller string, others []string, err error) {
+	err = s.inspect(func(gs *game.GameState) error {
+		if gs.CurrentRound == nil {
+			return fmt.Errorf("no round in progress")
+		}
+		storyteller = s.nameOf(gs.CurrentRound.StorytellerID)
+		for _, name := range s.order {
+			if name != storyteller {
+				if player, ok := gs.Players[s.players[name]]; ok && !player.WasReplaced {
+					others = append(others, name)
+				}
+			}
+		}
+		return nil
+	})
+	return storyteller, others, err
+}
+
+// hand returns a copy of a player's hand
+func (s *Scenario) hand(playerID uuid.UUID) ([]int, error) {
+	var hand []int
+	err := s.inspect(func(gs *game.GameState) error {
+		player, ok := gs.Players[playerID]
+		if !ok {
+			return fmt.Errorf("player is not in the room")
+		}
+		hand = append(hand, player.Hand...)
+		return nil
+	})
+	return hand, err
+}
+
+// CreateRoom creates the scenario's room as a sandbox game hosted by name
+func CreateRoom(host string) Step {
+	return CreateRoomWith(host, game.CreateGameOptions{})
+}
+
+// CreateRoomWith creates the scenario's room with creation options. The room
+// is always a sandbox, since the scenario has no database.
+func CreateRoomWith(host string, opts game.CreateGameOptions) Step {
+	return Step{Name: "create room as " + host, Run: func(s *Scenario) error {
+		opts.Sandbox = true
+		_, err := s.Manager.CreateGameWithOptions(s.RoomCode, s.connect(host), host, opts)
+		return err
+	}}
+}
+
+// Join seats a new player
+func Join(name string) Step {
+	return Step{Name: name + " joins", Run: func(s *Scenario) error {
+		_, err := s.Manager.JoinGame(s.RoomCode, s.connect(name), name)
+		return err
+	}}
+}
+
+// Settings changes the lobby settings as a player
+func Settings(name string, change func(settings *game.GameSettings)) Step {
+	return Step{Name: name + " changes the settings", Run: func(s *Scenario) error {
+		playerID, err := s.player(name)
+		if err != nil {
+			return err
+		}
+		var settings game.GameSettings
+		if err := s.inspect(func(gs *game.GameState) error {
+			settings = gs.Settings
+			return nil
+		}); err != nil {
+			return err
+		}
+		change(&settings)
+		_, err = s.Manager.UpdateGameSettings(s.RoomCode, playerID, settings)
+		return err
+	}}
+}
+
+// Start starts the game as a player
+func Start(name string) Step {
+	return Step{Name: name + " starts the game", Run: func(s *Scenario) error {
+		playerID, err := s.player(name)
+		if err != nil {
+			return err
+		}
+		return s.Manager.StartGame(s.RoomCode, playerID)
+	}}
+}
+
+// GiveClue has the storyteller submit a clue with the first card in their hand
+func GiveClue(clue string) Step {
+	return Step{Name: fmt.Sprintf("storyteller gives clue %q", clue), Run: func(s *Scenario) error {
+		storyteller, _, err := s.round()
+		if err != nil {
+			return err
+		}
+		playerID := s.players[storyteller]
+		hand, err := s.hand(playerID)
+		if err != nil {
+			return err
+		}
+		if len(hand) == 0 {
+			return fmt.Errorf("storyteller %s has no cards", storyteller)
+		}
+		return s.Manager.SubmitClue(s.RoomCode, playerID, clue, hand[0])
+	}}
+}
+
+// SubmitCard has a player submit the first card in their hand
+func SubmitCard(name string) Step {
+	return Step{Name: name + " submits a card", Run: func(s *Scenario) error {
+		return s.submitCard(name)
+	}}
+}
+
+// SubmitCards has every player but the storyteller submit a card
+func SubmitCards() Step {
+	return Step{Name: "everyone submits a card", Run: func(s *Scenario) error {
+		_, others, err := s.round()
+		if err != nil {
+			return err
+		}
+		for _, name := range others {
+			if err := s.submitCard(name); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		return nil
+	}}
+}
+
+func (s *Scenario) submitCard(name string) error {
+	playerID, err := s.player(name)
+	if err != nil {
+		return err
+	}
+	hand, err := s.hand(playerID)
+	if err != nil {
+		return err
+	}
+	if len(hand) == 0 {
+		return fmt.Errorf("%s has no cards", name)
+	}
+	return s.Manager.SubmitCard(s.RoomCode, playerID, hand[0])
+}
+
+// VoteTarget picks the card a player votes for from the revealed cards
+type VoteTarget func(s *Scenario, round *game.Round, voterID uuid.UUID) (int, error)
+
+// StorytellerCard votes for the storyteller's card
+func StorytellerCard(s *Scenario, round *game.Round, voterID uuid.UUID) (int, error) {
+	return round.StorytellerCard, nil
+}
+
+// CardOf votes for the card another player submitted
+func CardOf(name string) VoteTarget {
+	return func(s *Scenario, round *game.Round, voterID uuid.UUID) (int, error) {
+		submission, ok := round.Submissions[s.players[name]]
+		if !ok {
+			return 0, fmt.Errorf("%s submitted no card", name)
+		}
+		return submission.CardID, nil
+	}
+}
+
+// Vote has a player vote for a card
+func Vote(name string, target VoteTarget) Step {
+	return Step{Name: name + " votes", Run: func(s *Scenario) error {
+		return s.vote(name, target)
+	}}
+}
+
+// EveryoneVotes has every player but the storyteller vote for the same target
+func EveryoneVotes(target VoteTarget) Step {
+	return Step{Name: "everyone votes", Run: func(s *Scenario) error {
+		_, others, err := s.round()
+		if err != nil {
+			return err
+		}
+		for _, name := range others {
+			if err := s.vote(name, target); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		return nil
+	}}
+}
+
+func (s *Scenario) vote(name string, target VoteTarget) error {
+	playerID, err := s.player(name)
+	if err != nil {
+		return err
+	}
+	var cardID int
+	if err := s.inspect(func(gs *game.GameState) error {
+		if gs.CurrentRound == nil {
+			return fmt.Errorf("no round in progress")
+		}
+		cardID, err = target(s, gs.CurrentRound, playerID)
+		return err
+	}); err != nil {
+		return err
+	}
+	return s.Manager.SubmitVote(s.RoomCode, playerID, cardID)
+}
+
+// Do runs an arbitrary action against the manager, for flows the DSL doesn't cover
+func Do(name string, action func(s *Scenario) error) Step {
+	return Step{Name: name, Run: action}
+}
+
+// ExpectError runs a step and passes only if it fails with an error containing text
+func ExpectError(step Step, text string) Step {
+	return Step{Name: step.Name + " fails", Run: func(s *Scenario) error {
+		err := step.Run(s)
+		if err == nil {
+			return fmt.Errorf("expected an error containing %q, got none", text)
+		}
+		if !strings.Contains(err.Error(), text) {
+			return fmt.Errorf("expected an error containing %q, got %q", text, err)
+		}
+		return nil
+	}}
+}
+
+// AdvanceTime moves the scenario clock forward, running the delayed events that fall due
+func AdvanceTime(d time.Duration) Step {
+	return Step{Name: "advance time by " + d.String(), Run: func(s *Scenario) error {
+		s.Clock.Advance(d)
+		return nil
+	}}
+}
+
+// ExpectStatus checks the game status
+func ExpectStatus(status models.GameStatus) Step {
+	return Step{Name: "expect game " + string(status), Run: func(s *Scenario) error {
+		return s.inspect(func(gs *game.GameState) error {
+			if gs.Status != status {
+				return fmt.Errorf("game is %s", gs.Status)
+			}
+			return nil
+		})
+	}}
+}
+
+// ExpectPhase checks the status of the current round
+func ExpectPhase(status models.RoundStatus) Step {
+	return Step{Name: "expect " + string(status) + " phase", Run: func(s *Scenario) error {
+		return s.inspect(func(gs *game.GameState) error {
+			if gs.CurrentRound == nil {
+				return fmt.Errorf("no round in progress")
+			}
+			if gs.CurrentRound.Status != status {
+				return fmt.Errorf("round is in the %s phase", gs.CurrentRound.Status)
+			}
+			return nil
+		})
+	}}
+}
+
+// ExpectRound checks the current round number
+func ExpectRound(number int) Step {
+	return Step{Name: fmt.Sprintf("expect round %d", number), Run: func(s *Scenario) error {
+		return s.inspect(func(gs *game.GameState) error {
+			if gs.RoundNumber != number {
+				return fmt.Errorf("game is on round %d", gs.RoundNumber)
+			}
+			return nil
+		})
+	}}
+}
+
+// ExpectScores checks the scores of named players
+func ExpectScores(scores map[string]int) Step {
+	return Step{Name: "expect scores", Run: func(s *Scenario) error {
+		return s.inspect(func(gs *game.GameState) error {
+			var wrong []string
+			for name, want := range scores {
+				player, ok := gs.Players[s.players[name]]
+				if !ok {
+					return fmt.Errorf("%s is not in the room", name)
+				}
+				if player.Score != want {
+					wrong = append(wrong, fmt.Sprintf("%s has %d, want %d", name, player.Score, want))
+				}
+			}
+			if len(wrong) > 0 {
+				sort.Strings(wrong)
+				return fmt.Errorf("%s", strings.Join(wrong, "; "))
+			}
+			return nil
+		})
+	}}
+}
+
+// ExpectGame runs a custom check on the game state
+func ExpectGame(name string, check func(gs *game.GameState) error) Step {
+	return Step{Name: name, Run: func(s *Scenario) error {
+		return s.inspect(check)
+	}}
+}
+
+// ExpectMessage checks that a player received a message of a type since the
+// last message of theirs an expectation matched, and consumes it
+func ExpectMessage(name string, messageType game.MessageType) Step {
+	return Step{Name: fmt.Sprintf("expect %s to receive %s", name, messageType), Run: func(s *Scenario) error {
+		conn, ok := s.conns[name]
+		if !ok {
+			return fmt.Errorf("unknown player %q", name)
+		}
+		if _, ok := conn.next(messageType); !ok {
+			return fmt.Errorf("not received; got %v", conn.pendingTypes())
+		}
+		return nil
+	}}
+}
+
+// ExpectBroadcast checks that every connected human in the room received a
+// message of a type, consuming it like ExpectMessage
+func ExpectBroadcast(messageType game.MessageType) Step {
+	return Step{Name: fmt.Sprintf("expect broadcast %s", messageType), Run: func(s *Scenario) error {
+		var missing []string
+		for _, name := range s.order {
+			if _, ok := s.conns[name].next(messageType); !ok {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("not received by %s", strings.Join(missing, ", "))
+		}
+		return nil
+	}}
+}
+
+// ExpectNoMessage checks that a player has received no message of a type
+// since the last match
+func ExpectNoMessage(name string, messageType game.MessageType) Step {
+	return Step{Name: fmt.Sprintf("expect %s not to receive %s", name, messageType), Run: func(s *Scenario) error {
+		conn, ok := s.conns[name]
+		if !ok {
+			return fmt.Errorf("unknown player %q", name)
+		}
+		for _, pending := range conn.pendingTypes() {
+			if pending == messageType {
+				return fmt.Errorf("received %s", messageType)
+			}
+		}
+		return nil
+	}}
+}
+
+// MemoryConnection is an in-memory game.Connection that records every
+// message sent to it
+type MemoryConnection struct {
+	mu       sync.Mutex
+	messages []game.GameMessage
+	cursor   int // Messages before this were matched by an expectation
+}
+
+// Send records an encoded message
+func (c *MemoryConnection) Send(data []byte) error {
+	var message game.GameMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.messages = append(c.messages, message)
+	c.mu.Unlock()
+	return nil
+}
+
+// SendJSON records a message
+func (c *MemoryConnection) SendJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.Send(data)
+}
+
+// Transport reports an in-memory transport
+func (c *MemoryConnection) Transport() string {
+	return "memory"
+}
+
+// ProtocolVersion reports the v1 protocol
+func (c *MemoryConnection) ProtocolVersion() int {
+	return game.ProtocolV1
+}
+
+// Locale reports the default locale
+func (c *MemoryConnection) Locale() string {
+	return i18n.DefaultLocale
+}
+
+// Messages returns every message received so far
+func (c *MemoryConnection) Messages() []game.GameMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]game.GameMessage(nil), c.messages...)
+}
+
+// next finds the first unmatched message of a type and moves the cursor past it
+func (c *MemoryConnection) next(messageType game.MessageType) (game.GameMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := c.cursor; i < len(c.messages); i++ {
+		if c.messages[i].Type == messageType {
+			c.cursor = i + 1
+			return c.messages[i], true
+		}
+	}
+	return game.GameMessage{}, false
+}
+
+// pendingTypes lists the types of the messages not matched yet
+func (c *MemoryConnection) pendingTypes() []game.MessageType {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	types := make([]game.MessageType, 0, len(c.messages)-c.cursor)
+	for _, message := range c.messages[c.cursor:] {
+		types = append(types, message.Type)
+	}
+	return types
+}
+
+// ManualScheduler is a game.Scheduler whose events run only when the test
+// advances its clock
+type ManualScheduler struct {
+	mu      sync.Mutex
+	now     time.Duration // Time elapsed since the scheduler was created
+	pending []scheduledEvent
+	seq     int
+}
+
+type scheduledEvent struct {
+	at  time.Duration
+	seq int // Keeps events due at the same time in the order they were scheduled
+	f   func()
+}
+
+// NewManualScheduler creates a scheduler stopped at time zero
+func NewManualScheduler() *ManualScheduler {
+	return &ManualScheduler{}
+}
+
+// AfterFunc schedules f to run once the clock has advanced by d
+func (c *ManualScheduler) AfterFunc(d time.Duration, f func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	c.pending = append(c.pending, scheduledEvent{at: c.now + d, seq: c.seq, f: f})
+}
+
+// Advance moves the clock forward, running due events in time order. Events
+// scheduled by those events run too if they fall due within the window.
+func (c *ManualScheduler) Advance(d time.Duration) {
+	c.mu.Lock()
+	deadline := c.now + d
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		sort.Slice(c.pending, func(i, j int) bool {
+			if c.pending[i].at != c.pending[j].at {
+				return c.pending[i].at < c.pending[j].at
+			}
+			return c.pending[i].seq < c.pending[j].seq
+		})
+		if len(c.pending) == 0 || c.pending[0].at > deadline {
+			c.now = deadline
+			c.mu.Unlock()
+			return
+		}
+		event := c.pending[0]
+		c.pending = c.pending[1:]
+		c.now = event.at
+		c.mu.Unlock()
+
+		// Run without the lock, so the event may schedule more
+		event.f()
+	}
+}
+
+// Pending returns the number of events waiting to run
+func (c *ManualScheduler) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pending)
+}
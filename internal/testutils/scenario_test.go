@@ -0,0 +1,68 @@
+package testutils
+
+import (
+	"testing"
+	"time"
+
+	"dixitme/internal/models"
+	"dixitme/internal/services/game"
+)
+
+func TestScenarioPlaysARound(t *testing.T) {
+	RunScenario(t,
+		CreateRoom("Alice"),
+		Join("Bob"),
+		Join("Cara"),
+		ExpectBroadcast(game.MessageTypePlayerJoined),
+		Start("Alice"),
+		ExpectBroadcast(game.MessageTypeRoundStarted),
+		ExpectPhase(models.RoundStatusStorytelling),
+		GiveClue("a quiet storm"),
+		ExpectBroadcast(game.MessageTypeClueSubmitted),
+		SubmitCards(),
+		ExpectPhase(models.RoundStatusVoting),
+		ExpectBroadcast(game.MessageTypeVotingStarted),
+		EveryoneVotes(StorytellerCard),
+		ExpectBroadcast(game.MessageTypeRoundCompleted),
+		ExpectRound(1),
+		// The next round waits for the reveal delay
+		AdvanceTime(4*time.Second),
+		ExpectRound(1),
+		AdvanceTime(time.Second),
+		ExpectRound(2),
+		ExpectPhase(models.RoundStatusStorytelling),
+		ExpectBroadcast(game.MessageTypeRoundStarted),
+	)
+}
+
+func TestScenarioRejectsOutOfTurnActions(t *testing.T) {
+	RunScenario(t,
+		CreateRoom("Alice"),
+		Join("Bob"),
+		ExpectError(Start("Alice"), "at least 3 players"),
+		Join("Cara"),
+		Start("Alice"),
+		ExpectError(Start("Bob"), "already started"),
+		ExpectError(Vote("Bob", StorytellerCard), ""),
+		ExpectStatus(models.GameStatusInProgress),
+	)
+}
+
+func TestManualSchedulerRunsEventsInOrder(t *testing.T) {
+	clock := NewManualScheduler()
+	var ran []string
+	clock.AfterFunc(2*time.Second, func() { ran = append(ran, "b") })
+	clock.AfterFunc(time.Second, func() {
+		ran = append(ran, "a")
+		clock.AfterFunc(time.Second, func() { ran = append(ran, "a+1") })
+	})
+	clock.AfterFunc(5*time.Second, func() { ran = append(ran, "c") })
+
+	clock.Advance(2 * time.Second)
+	if got := len(ran); got != 3 || ran[0] != "a" || ran[1] != "b" || ran[2] != "a+1" {
+		t.Fatalf("ran %v", ran)
+	}
+	if clock.Pending() != 1 {
+		t.Fatalf("%d events pending, want 1", clock.Pending())
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"dixitme/internal/services/activity"
+	"dixitme/internal/services/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActivityHandlers handles activity feed requests
+type ActivityHandlers struct {
+	feed *activity.Feed
+}
+
+// NewActivityHandlers creates a new ActivityHandlers instance
+func NewActivityHandlers(feed *activity.Feed) *ActivityHandlers {
+	return &ActivityHandlers{feed: feed}
+}
+
+// GetMyActivity returns the authenticated user's activity feed
+// @Summary Get my activity
+// @Description Get a paginated feed of the authenticated user's recent events, newest first: games played, rating changes, achievements and friend requests
+// @Tags players
+// @Produce json
+// @Param types query string false "Comma-separated event types to include" example(game_played,rating_changed)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of events per page" default(20)
+// @Success 200 {object} ActivityFeedResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/activity [get]
+func (h *ActivityHandlers) GetMyActivity(c *gin.Context) {
+	userInfo, exists := auth.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var types []string
+	if raw := c.Query("types"); raw != "" {
+		for _, eventType := range strings.Split(raw, ",") {
+			eventType = strings.TrimSpace(eventType)
+			if !activity.IsType(eventType) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown activity type: " + eventType})
+				return
+			}
+			types = append(types, eventType)
+		}
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	events, total, err := h.feed.List(c.Request.Context(), userInfo.PlayerID(), types, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch activity"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ActivityFeedResponse{
+		Events: events,
+		Pagination: PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: total,
+			Pages: (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
@@ -1,13 +1,23 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
+	"dixitme/internal/cache"
 	"dixitme/internal/database"
+	"dixitme/internal/metrics"
 	"dixitme/internal/models"
 	"dixitme/internal/seeder"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/cardimages"
 	"dixitme/internal/services/game"
+	"dixitme/internal/services/readmodel"
+	"dixitme/internal/storage"
+	"dixitme/internal/transport/validation"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -31,6 +41,7 @@ func NewAdminHandlers(deps *HandlerDependencies) *AdminHandlers {
 // @Produce json
 // @Success 200 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
 // @Router /admin/seed [post]
 func SeedDatabase(c *gin.Context) {
 	if err := seeder.SeedDatabase(); err != nil {
@@ -40,6 +51,7 @@ func SeedDatabase(c *gin.Context) {
 		})
 		return
 	}
+	cache.Invalidate(c.Request.Context(), cache.ScopeCards, cache.ScopeTags)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -55,6 +67,7 @@ func SeedDatabase(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
 // @Router /admin/seed/tags [post]
 func SeedTags(c *gin.Context) {
 	// Use general seed database for now since specific seeders might not exist
@@ -65,6 +78,7 @@ func SeedTags(c *gin.Context) {
 		})
 		return
 	}
+	cache.Invalidate(c.Request.Context(), cache.ScopeCards, cache.ScopeTags)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -80,6 +94,7 @@ func SeedTags(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
 // @Router /admin/seed/cards [post]
 func SeedCards(c *gin.Context) {
 	// Use general seed database for now since specific seeders might not exist
@@ -90,6 +105,7 @@ func SeedCards(c *gin.Context) {
 		})
 		return
 	}
+	cache.Invalidate(c.Request.Context(), cache.ScopeCards, cache.ScopeTags)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -105,16 +121,17 @@ func SeedCards(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} DatabaseStatsResponse
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
 // @Router /admin/stats [get]
 func GetDatabaseStats(c *gin.Context) {
-	db := database.GetDB()
+	db := database.Reader(c.Request.Context())
 
 	stats := make(map[string]interface{})
 
 	// Count various entities
 	var userCount, playerCount, gameCount, cardCount, tagCount, chatCount int64
 	db.Model(&models.User{}).Count(&userCount)
-	db.Model(&models.Player{}).Count(&playerCount)
+	db.Model(&models.Player{}).Where("type <> ?", models.PlayerTypeSystem).Count(&playerCount)
 	db.Model(&models.Game{}).Count(&gameCount)
 	db.Model(&models.Card{}).Count(&cardCount)
 	db.Model(&models.Tag{}).Count(&tagCount)
@@ -154,6 +171,19 @@ func GetDatabaseStats(c *gin.Context) {
 	`).Scan(&cardTagCounts)
 	stats["popular_tags"] = cardTagCounts
 
+	// Detected clue languages
+	var clueLanguageCounts []struct {
+		Language string `json:"language"`
+		Count    int64  `json:"count"`
+	}
+	db.Model(&models.GameRound{}).
+		Select("clue_language as language, COUNT(*) as count").
+		Where("clue_language <> ''").
+		Group("clue_language").
+		Order("count DESC").
+		Scan(&clueLanguageCounts)
+	stats["clues_by_language"] = clueLanguageCounts
+
 	response := DatabaseStatsResponse{Stats: stats}
 	c.JSON(http.StatusOK, response)
 }
@@ -166,6 +196,7 @@ func GetDatabaseStats(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
 // @Router /admin/cleanup [post]
 func CleanupOldGames(c *gin.Context) {
 	db := database.GetDB()
@@ -196,6 +227,7 @@ func CleanupOldGames(c *gin.Context) {
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
 // @Router /admin/replace-player [post]
 func (h *AdminHandlers) ReplacePlayerWithBot(c *gin.Context) {
 	var req ReplacePlayerRequest
@@ -250,6 +282,7 @@ func (h *AdminHandlers) ReplacePlayerWithBot(c *gin.Context) {
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
 // @Router /admin/check-afk [post]
 func (h *AdminHandlers) CheckAFKPlayers(c *gin.Context) {
 	var req CheckAFKRequest
@@ -263,10 +296,8 @@ func (h *AdminHandlers) CheckAFKPlayers(c *gin.Context) {
 		return
 	}
 
+	// Without a timeout, the room's per-phase thresholds apply
 	afkTimeout := time.Duration(req.AFKTimeoutMinutes) * time.Minute
-	if afkTimeout == 0 {
-		afkTimeout = 3 * time.Minute // Default to 3 minutes
-	}
 
 	manager := game.GetManager()
 	gameState, err := manager.CheckAndReplaceAFKPlayers(req.RoomCode, afkTimeout)
@@ -286,3 +317,472 @@ func (h *AdminHandlers) CheckAFKPlayers(c *gin.Context) {
 		"game_state":       gameState,
 	})
 }
+
+// ResyncPlayer pushes a fresh game state to one player
+// @Summary Resync a player's game state
+// @Description Rebuild the player's view of the game and send it down their connection, or queue it until they reconnect. Use when a client shows a stale UI.
+// @Tags admin
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param player_id path string true "Player ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/games/{room_code}/resync/{player_id} [post]
+func ResyncPlayer(c *gin.Context) {
+	roomCode := c.Param("room_code")
+	playerID, err := uuid.Parse(c.Param("player_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID format"})
+		return
+	}
+
+	result, err := game.GetManager().ResyncPlayer(roomCode, playerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrResyncGameNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case errors.Is(err, game.ErrResyncPlayerNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not in game"})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"room_code": roomCode,
+		"player_id": playerID,
+		"result":    result,
+	})
+}
+
+// ListShadowBans lists shadow-banned players with their report counts
+// @Summary List shadow bans
+// @Description List shadow-banned players, most reported first, with how many reports they collected in total and since the ban
+// @Tags admin
+// @Produce json
+// @Success 200 {array} game.ShadowBanSummary
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/shadow-bans [get]
+func ListShadowBans(c *gin.Context) {
+	bans, err := game.GetManager().ListShadowBans(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, bans)
+}
+
+// ShadowBanPlayer shadow-bans a player
+// @Summary Shadow-ban player
+// @Description Hide a player's chat from everyone but themselves, without telling them. Their votes still count and reports keep accumulating. A softer alternative to kicking.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param player_id path string true "Player ID"
+// @Param ban body ShadowBanRequest false "Reason"
+// @Success 200 {object} models.ShadowBan
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/players/{player_id}/shadow-ban [put]
+func ShadowBanPlayer(c *gin.Context) {
+	playerID, err := uuid.Parse(c.Param("player_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID format"})
+		return
+	}
+
+	var req ShadowBanRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		validation.Respond(c, err)
+		return
+	}
+
+	var bannedBy *uuid.UUID
+	if userInfo, exists := auth.GetUserFromContext(c); exists {
+		moderatorID := userInfo.PlayerID()
+		bannedBy = &moderatorID
+	}
+
+	ban, err := game.GetManager().ShadowBan(c.Request.Context(), playerID, req.Reason, bannedBy)
+	if err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ban)
+}
+
+// WaiveForfeit upholds a player's appeal against a ranked forfeit
+// @Summary Waive ranked forfeit
+// @Description Stop a forfeit counting towards the player's ranked standing, lifting any restriction it caused. The rating penalty is not refunded.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param forfeit_id path string true "Forfeit ID"
+// @Param waiver body WaiveForfeitRequest false "Reason"
+// @Success 200 {object} models.GameForfeit
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/forfeits/{forfeit_id}/waive [post]
+func WaiveForfeit(c *gin.Context) {
+	forfeitID, err := uuid.Parse(c.Param("forfeit_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid forfeit ID format"})
+		return
+	}
+
+	var req WaiveForfeitRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		validation.Respond(c, err)
+		return
+	}
+
+	var waivedBy *uuid.UUID
+	if userInfo, exists := auth.GetUserFromContext(c); exists {
+		moderatorID := userInfo.PlayerID()
+		waivedBy = &moderatorID
+	}
+
+	forfeit, err := game.GetManager().WaiveForfeit(c.Request.Context(), forfeitID, req.Reason, waivedBy)
+	if err != nil {
+		if errors.Is(err, game.ErrForfeitNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		respondGameActionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, forfeit)
+}
+
+// LiftShadowBan lifts a player's shadow ban
+// @Summary Lift shadow ban
+// @Description Let a shadow-banned player's chat reach everyone again. Messages sent during the ban stay hidden.
+// @Tags admin
+// @Produce json
+// @Param player_id path string true "Player ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/players/{player_id}/shadow-ban [delete]
+func LiftShadowBan(c *gin.Context) {
+	playerID, err := uuid.Parse(c.Param("player_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID format"})
+		return
+	}
+
+	if err := game.GetManager().LiftShadowBan(c.Request.Context(), playerID); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "player_id": playerID})
+}
+
+// GetPlayerReports lists the reports filed against a player
+// @Summary Get player reports
+// @Description List the reports other players filed against a player, newest first
+// @Tags admin
+// @Produce json
+// @Param player_id path string true "Player ID"
+// @Param limit query int false "Maximum reports (max 100)" default(50)
+// @Success 200 {array} models.PlayerReport
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/players/{player_id}/reports [get]
+func GetPlayerReports(c *gin.Context) {
+	playerID, err := uuid.Parse(c.Param("player_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID format"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	reports, err := game.GetManager().PlayerReports(c.Request.Context(), playerID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reports)
+}
+
+// PurgeChatMessages runs the chat retention job immediately
+// @Summary Purge expired chat messages
+// @Description Run the chat retention policy now and report how many messages were purged
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} ChatPurgeResponse
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/chat/purge [post]
+func (h *AdminHandlers) PurgeChatMessages(c *gin.Context) {
+	result, err := h.deps.GameService.PurgeExpiredChatMessages(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to purge chat messages",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	policy := h.deps.GameService.GetChatRetentionPolicy()
+	c.JSON(http.StatusOK, ChatPurgeResponse{
+		Result:             result,
+		TotalPurged:        result.Total(),
+		LobbyRetentionDays: int(policy.LobbyRetention.Hours() / 24),
+		GameRetentionDays:  int(policy.GameRetention.Hours() / 24),
+	})
+}
+
+// SetRoomChatRetention sets or clears the chat retention override for a room
+// @Summary Set room chat retention
+// @Description Override how many days chat is kept for a room (null restores the deployment default)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param request body SetChatRetentionRequest true "Retention override"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/games/{room_code}/chat-retention [put]
+func SetRoomChatRetention(c *gin.Context) {
+	roomCode := c.Param("room_code")
+
+	var req SetChatRetentionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if req.Days != nil && *req.Days < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Retention must be at least 1 day"})
+		return
+	}
+
+	db := database.GetDB()
+	result := db.Model(&models.Game{}).
+		Where("room_code = ?", roomCode).
+		Update("chat_retention_days", req.Days)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update chat retention"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":             true,
+		"room_code":           roomCode,
+		"chat_retention_days": req.Days,
+	})
+}
+
+// GetMetrics returns the in-process metrics snapshot
+// @Summary Get server metrics
+// @Description Get the current values of all in-process counters, gauges and histograms. Histograms appear as _bucket{le="..."}, _sum and _count entries.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} MetricsResponse
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/metrics [get]
+func GetMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, MetricsResponse{Metrics: metrics.Snapshot()})
+}
+
+// GetEventStats returns the traffic of the game engine's event topics
+// @Summary Get event bus stats
+// @Description Get, per domain event topic, how many subscribers it has, how many events were published, how many handlers panicked and when it last fired.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} EventStatsResponse
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/events [get]
+func GetEventStats(c *gin.Context) {
+	c.JSON(http.StatusOK, EventStatsResponse{Topics: game.GetManager().Events().Stats()})
+}
+
+// RebuildReadModels recomputes the game listing and history read tables
+// @Summary Rebuild read models
+// @Description Recompute game summaries and player game stats from the games and game history tables. Backfills older games and repairs projections that were dropped.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} readmodel.RebuildReport
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/read-models/rebuild [post]
+func RebuildReadModels(c *gin.Context) {
+	report, err := readmodel.Rebuild(c.Request.Context(), database.GetDB())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to rebuild read models",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// CheckCardImages verifies that every active card has a usable image
+// @Summary Check card image integrity
+// @Description Verify that every active card's image exists in MinIO or on disk, is non-empty and has an image content type. Optionally deactivate broken cards.
+// @Tags admin
+// @Produce json
+// @Param deactivate query bool false "Deactivate cards with broken images" default(false)
+// @Success 200 {object} cardimages.Report
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/cards/image-check [post]
+func CheckCardImages(c *gin.Context) {
+	deactivate := c.Query("deactivate") == "true"
+
+	checker := cardimages.NewChecker(database.GetDB(), storage.GetClient())
+	report, err := checker.Run(c.Request.Context(), deactivate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to check card images",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// FindSimilarCards lists active cards whose image looks like a card's image
+// @Summary Find similar cards
+// @Description List active cards whose image is perceptually close to the given card's image, closest first
+// @Tags admin
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param max_distance query int false "Largest Hamming distance between image hashes (0-64)" default(10)
+// @Success 200 {object} SimilarCardsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/cards/{card_id}/similar [get]
+func FindSimilarCards(c *gin.Context) {
+	cardID, err := strconv.Atoi(c.Param("card_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid card ID"})
+		return
+	}
+	maxDistance, ok := similarityDistance(c)
+	if !ok {
+		return
+	}
+
+	db := database.GetDB()
+	var card models.Card
+	if err := db.First(&card, cardID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Card not found"})
+		return
+	}
+	if card.ImageHash == "" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Card image has not been hashed yet"})
+		return
+	}
+	hash, err := cardimages.ParseHash(card.ImageHash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Card has an invalid image hash"})
+		return
+	}
+
+	similar, err := cardimages.FindSimilar(c.Request.Context(), db, hash, cardID, maxDistance)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search similar cards"})
+		return
+	}
+
+	c.JSON(http.StatusOK, SimilarCardsResponse{ImageHash: card.ImageHash, Cards: similar})
+}
+
+// FindSimilarToImage lists active cards whose image looks like an uploaded image
+// @Summary Find cards similar to an image
+// @Description Check a candidate image against the active deck before creating a card from it
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param image formData file true "Candidate image (JPEG, PNG or GIF)"
+// @Param max_distance query int false "Largest Hamming distance between image hashes (0-64)" default(10)
+// @Success 200 {object} SimilarCardsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/cards/similar [post]
+func FindSimilarToImage(c *gin.Context) {
+	maxDistance, ok := similarityDistance(c)
+	if !ok {
+		return
+	}
+
+	file, _, err := c.Request.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No image file provided"})
+		return
+	}
+	defer file.Close()
+
+	hash, err := cardimages.PerceptualHash(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported or corrupt image", "details": err.Error()})
+		return
+	}
+
+	similar, err := cardimages.FindSimilar(c.Request.Context(), database.GetDB(), hash, 0, maxDistance)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search similar cards"})
+		return
+	}
+
+	c.JSON(http.StatusOK, SimilarCardsResponse{ImageHash: cardimages.FormatHash(hash), Cards: similar})
+}
+
+// BackfillCardHashes hashes the images of cards added before duplicate detection
+// @Summary Backfill card image hashes
+// @Description Compute perceptual hashes for active cards that don't have one, so they take part in duplicate detection
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/cards/hashes/backfill [post]
+func BackfillCardHashes(c *gin.Context) {
+	checker := cardimages.NewChecker(database.GetDB(), storage.GetClient())
+	hashed, err := checker.BackfillHashes(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to backfill card hashes",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"hashed": hashed})
+}
+
+// similarityDistance reads the max_distance query parameter, answering 400 when it is invalid
+func similarityDistance(c *gin.Context) (int, bool) {
+	maxDistance, err := strconv.Atoi(c.DefaultQuery("max_distance", strconv.Itoa(cardimages.SimilarDistance)))
+	if err != nil || maxDistance < 0 || maxDistance > 64 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_distance must be between 0 and 64"})
+		return 0, false
+	}
+	return maxDistance, true
+}
@@ -0,0 +1,50 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+	"dixitme/internal/testutils/testdb"
+	"dixitme/internal/transport/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRoomChatRetention(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := testdb.Open(t, &models.Game{})
+	require.NoError(t, db.Create(&models.Game{ID: uuid.New(), RoomCode: "KEEP"}).Error)
+	previous := database.GetDB()
+	database.SetDB(db)
+	t.Cleanup(func() { database.SetDB(previous) })
+
+	router := gin.New()
+	router.PUT("/admin/games/:room_code/chat-retention", handlers.SetRoomChatRetention)
+	retention := func() *int {
+		var stored models.Game
+		require.NoError(t, db.First(&stored, "room_code = ?", "KEEP").Error)
+		return stored.ChatRetentionDays
+	}
+
+	recorder := sendJSON(t, router, http.MethodPut, "/admin/games/KEEP/chat-retention", gin.H{"days": 7})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.NotNil(t, retention())
+	assert.Equal(t, 7, *retention())
+
+	recorder = sendJSON(t, router, http.MethodPut, "/admin/games/KEEP/chat-retention", gin.H{"days": 0})
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, 7, *retention())
+
+	recorder = sendJSON(t, router, http.MethodPut, "/admin/games/NOSUCH/chat-retention", gin.H{"days": 7})
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	// null restores the deployment default
+	recorder = sendJSON(t, router, http.MethodPut, "/admin/games/KEEP/chat-retention", gin.H{"days": nil})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.Nil(t, retention())
+}
@@ -0,0 +1,437 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"dixitme/internal/database"
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/mail"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Audited admin actions
+const (
+	AuditViewUser          = "view_user"
+	AuditDeactivateUser    = "deactivate_user"
+	AuditReactivateUser    = "reactivate_user"
+	AuditSendPasswordReset = "send_password_reset"
+	AuditSetUserRole       = "set_user_role"
+)
+
+// Admin user detail limits
+const (
+	adminUserSessions    = 20
+	adminUserRecentGames = 10
+)
+
+// UserAdminHandlers handles account administration
+type UserAdminHandlers struct {
+	deps     *HandlerDependencies
+	mailer   mail.Mailer
+	resetURL string
+}
+
+// NewUserAdminHandlers creates account administration handlers; reset emails
+// link to resetURL with the token in its query string
+func NewUserAdminHandlers(deps *HandlerDependencies, mailer mail.Mailer, resetURL string) *UserAdminHandlers {
+	return &UserAdminHandlers{deps: deps, mailer: mailer, resetURL: resetURL}
+}
+
+// ListUsers searches accounts
+// @Summary Search users
+// @Description Search accounts by email, username or display name, optionally filtered by status and role
+// @Tags admin
+// @Produce json
+// @Param q query string false "Matches email, username or display name"
+// @Param status query string false "active or inactive"
+// @Param role query string false "player, curator, admin, or none for users without a role"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} AdminUsersResponse
+// @Failure 400 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/users [get]
+func (h *UserAdminHandlers) ListUsers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	query := database.GetDB().WithContext(c.Request.Context()).Model(&models.User{})
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		pattern := "%" + strings.ToLower(q) + "%"
+		query = query.Where("LOWER(email) LIKE ? OR LOWER(username) LIKE ? OR LOWER(display_name) LIKE ?", pattern, pattern, pattern)
+	}
+	switch c.Query("status") {
+	case "":
+	case "active":
+		query = query.Where("is_active = ?", true)
+	case "inactive":
+		query = query.Where("is_active = ?", false)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be active or inactive"})
+		return
+	}
+	if role := c.Query("role"); role == "none" {
+		query = query.Where("role = '' OR role IS NULL")
+	} else if role != "" {
+		if _, err := auth.RoleScopes(role); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		query = query.Where("role = ?", role)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search users"})
+		return
+	}
+
+	users := make([]models.User, 0)
+	if err := query.Order("created_at DESC").Limit(limit).Offset((page - 1) * limit).Find(&users).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, AdminUsersResponse{
+		Users: users,
+		Pagination: PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: total,
+			Pages: (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
+
+// GetUser returns an account with its sessions and recent games
+// @Summary Get user
+// @Description Get an account with its latest sessions and games in one response. Viewing is audit-logged.
+// @Tags admin
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} AdminUserDetail
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/users/{user_id} [get]
+func (h *UserAdminHandlers) GetUser(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+	db := database.GetDB().WithContext(c.Request.Context())
+
+	detail := AdminUserDetail{Sessions: make([]models.Session, 0), RecentGames: make([]MyGame, 0)}
+	if err := db.First(&detail.User, "id = ?", userID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	err := db.Model(&models.Session{}).
+		Where("user_id = ? AND is_active = ? AND expires_at > ?", userID, true, time.Now()).
+		Count(&detail.ActiveSessions).Error
+	if err == nil {
+		err = db.Where("user_id = ?", userID).Order("created_at DESC").Limit(adminUserSessions).Find(&detail.Sessions).Error
+	}
+	if err == nil {
+		err = db.Table("game_players").
+			Joins("JOIN games ON games.id = game_players.game_id AND games.deleted_at IS NULL").
+			Where("game_players.player_id = ?", userID).
+			Select("games.id AS game_id, games.room_code, games.status, games.current_round, games.max_rounds, " +
+				"games.created_at, games.updated_at, game_players.score, game_players.position, game_players.is_active").
+			Order("games.updated_at DESC").
+			Limit(adminUserRecentGames).
+			Scan(&detail.RecentGames).Error
+	}
+	if err != nil {
+		logger.Error("Failed to load user detail", "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load user"})
+		return
+	}
+
+	recordAdminAction(c, AuditViewUser, userID, nil)
+	c.JSON(http.StatusOK, detail)
+}
+
+// DeactivateUser deactivates an account
+// @Summary Deactivate user
+// @Description Block an account from signing in and end all of its sessions
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param request body UserActionRequest false "Reason"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/users/{user_id}/deactivate [post]
+func (h *UserAdminHandlers) DeactivateUser(c *gin.Context) {
+	h.setUserActive(c, false)
+}
+
+// ReactivateUser reactivates an account
+// @Summary Reactivate user
+// @Description Let a deactivated account sign in again
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param request body UserActionRequest false "Reason"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/users/{user_id}/reactivate [post]
+func (h *UserAdminHandlers) ReactivateUser(c *gin.Context) {
+	h.setUserActive(c, true)
+}
+
+func (h *UserAdminHandlers) setUserActive(c *gin.Context, active bool) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+	var req UserActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		validation.Respond(c, err)
+		return
+	}
+	if !active && isSelf(c, userID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You can't deactivate your own account"})
+		return
+	}
+
+	user, err := h.deps.AuthService.SetUserActive(userID, active)
+	if err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	action := AuditReactivateUser
+	if !active {
+		action = AuditDeactivateUser
+	}
+	recordAdminAction(c, action, userID, gin.H{"reason": req.Reason})
+	c.JSON(http.StatusOK, user)
+}
+
+// SendPasswordReset emails a password reset link to a user
+// @Summary Send password reset
+// @Description Email a single-use password reset link, valid for an hour, to a password account
+// @Tags admin
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/users/{user_id}/password-reset [post]
+func (h *UserAdminHandlers) SendPasswordReset(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	var requestedBy *uuid.UUID
+	if userInfo, exists := auth.GetUserFromContext(c); exists {
+		adminID := userInfo.PlayerID()
+		requestedBy = &adminID
+	}
+
+	user, token, err := h.deps.AuthService.CreatePasswordReset(userID, requestedBy)
+	if err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	link := h.resetURL + "?token=" + url.QueryEscape(token)
+	if strings.Contains(h.resetURL, "?") {
+		link = h.resetURL + "&token=" + url.QueryEscape(token)
+	}
+	err = h.mailer.Send(c.Request.Context(), mail.Message{
+		To:      user.Email,
+		Subject: "Reset your DixitMe password",
+		Body: fmt.Sprintf("Hi %s,\n\nA password reset was requested for your account. "+
+			"Choose a new password within the next hour:\n\n%s\n\n"+
+			"If you didn't expect this email, you can ignore it.\n", user.DisplayName, link),
+	})
+	if err != nil {
+		logger.Error("Failed to send password reset email", "user_id", userID, "error", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to send password reset email"})
+		return
+	}
+
+	recordAdminAction(c, AuditSendPasswordReset, userID, nil)
+	c.JSON(http.StatusOK, gin.H{"sent": true, "email": user.Email})
+}
+
+// SetUserRole changes a user's role
+// @Summary Set user role
+// @Description Set the role that scopes the user's sessions: player, curator or admin. An empty role makes the user a player. The user's sessions are ended so the change applies at their next sign-in.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param request body SetUserRoleRequest true "Role"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/users/{user_id}/role [put]
+func (h *UserAdminHandlers) SetUserRole(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+	var req SetUserRoleRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	if isSelf(c, userID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You can't change your own role"})
+		return
+	}
+
+	user, err := h.deps.AuthService.SetUserRole(userID, req.Role)
+	if err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	recordAdminAction(c, AuditSetUserRole, userID, gin.H{"role": req.Role, "reason": req.Reason})
+	c.JSON(http.StatusOK, user)
+}
+
+// ListAuditLog lists recorded admin actions
+// @Summary List admin audit log
+// @Description List admin actions on accounts, newest first
+// @Tags admin
+// @Produce json
+// @Param target_id query string false "Only actions on this user"
+// @Param actor_id query string false "Only actions by this admin"
+// @Param action query string false "Only this action"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(50)
+// @Success 200 {object} AuditLogResponse
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/audit-log [get]
+func (h *UserAdminHandlers) ListAuditLog(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	query := database.GetDB().WithContext(c.Request.Context()).Model(&models.AdminAuditEntry{})
+	if targetID := c.Query("target_id"); targetID != "" {
+		query = query.Where("target_id = ?", targetID)
+	}
+	if actorID := c.Query("actor_id"); actorID != "" {
+		query = query.Where("actor_id = ?", actorID)
+	}
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load audit log"})
+		return
+	}
+
+	entries := make([]models.AdminAuditEntry, 0)
+	if err := query.Order("created_at DESC").Limit(limit).Offset((page - 1) * limit).Find(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, AuditLogResponse{
+		Entries: entries,
+		Pagination: PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: total,
+			Pages: (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
+
+// recordAdminAction writes an admin action on a user to the audit log. A
+// failed write is logged rather than failing an action that already happened.
+func recordAdminAction(c *gin.Context, action string, userID uuid.UUID, details interface{}) {
+	entry := models.AdminAuditEntry{
+		ID:         uuid.New(),
+		Action:     action,
+		TargetType: "user",
+		TargetID:   userID.String(),
+		CreatedAt:  time.Now(),
+	}
+	if userInfo, exists := auth.GetUserFromContext(c); exists {
+		actorID := userInfo.PlayerID()
+		entry.ActorID = &actorID
+	}
+	if details != nil {
+		if encoded, err := json.Marshal(details); err == nil {
+			entry.Details = string(encoded)
+		}
+	}
+
+	if err := database.GetDB().WithContext(c.Request.Context()).Create(&entry).Error; err != nil {
+		logger.Error("Failed to write admin audit log", "action", action, "target_id", entry.TargetID, "error", err)
+	}
+}
+
+// parseUserID reads the user_id path parameter, replying 400 when it is malformed
+func parseUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// isSelf reports whether the admin is acting on their own account
+func isSelf(c *gin.Context, userID uuid.UUID) bool {
+	userInfo, exists := auth.GetUserFromContext(c)
+	return exists && userInfo.UserID != nil && *userInfo.UserID == userID
+}
+
+// respondUserAdminError maps account administration errors to responses
+func respondUserAdminError(c *gin.Context, err error) {
+	if errors.Is(err, auth.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.HasPrefix(err.Error(), "failed to") {
+		logger.Error("User administration failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dixitme/internal/database"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/bot"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetBotDifficultyPolicy returns the policy for bots the server seats itself
+// @Summary Get bot difficulty policy
+// @Description Get the policy that picks the difficulty of AFK replacement bots and bots added with the auto level
+// @Tags admin
+// @Produce json
+// @Success 200 {object} bot.DifficultyPolicy
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/bots/difficulty-policy [get]
+func GetBotDifficultyPolicy(c *gin.Context) {
+	c.JSON(http.StatusOK, bot.CurrentPolicy())
+}
+
+// UpdateBotDifficultyPolicy sets the bot difficulty policy at runtime
+// @Summary Update bot difficulty policy
+// @Description Set the bot difficulty policy on every instance. Modes: heuristic (easy in rooms of three or fewer, medium otherwise), weighted (percentages for easy, medium and hard adding up to 100) and mirror (match the average rating of the room's humans).
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param policy body bot.DifficultyPolicy true "Difficulty policy"
+// @Success 200 {object} bot.DifficultyPolicy
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/bots/difficulty-policy [put]
+func UpdateBotDifficultyPolicy(c *gin.Context) {
+	var req bot.DifficultyPolicy
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var updatedBy *uuid.UUID
+	if userInfo, exists := auth.GetUserFromContext(c); exists {
+		adminID := userInfo.PlayerID()
+		updatedBy = &adminID
+	}
+	if _, err := bot.SavePolicy(c.Request.Context(), database.GetDB(), req, updatedBy); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bot.CurrentPolicy())
+}
+
+// ResetBotDifficultyPolicy drops the runtime bot difficulty policy
+// @Summary Reset bot difficulty policy
+// @Description Remove the runtime policy so every instance goes back to the heuristic
+// @Tags admin
+// @Produce json
+// @Success 200 {object} bot.DifficultyPolicy
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/bots/difficulty-policy [delete]
+func ResetBotDifficultyPolicy(c *gin.Context) {
+	if err := bot.ResetPolicy(c.Request.Context(), database.GetDB()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, bot.CurrentPolicy())
+}
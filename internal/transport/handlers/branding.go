@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dixitme/internal/database"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/branding"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetBranding returns the deployment's branding
+// @Summary Get branding
+// @Description Get the name, logo, color palette, default deck and footer links clients should present this deployment with
+// @Tags branding
+// @Produce json
+// @Success 200 {object} branding.Branding
+// @Router /branding [get]
+func GetBranding(c *gin.Context) {
+	c.JSON(http.StatusOK, branding.Current())
+}
+
+// UpdateBranding overrides the configured branding at runtime
+// @Summary Update branding
+// @Description Override the configured branding on every instance. Empty fields keep their configured value; footer_links replaces the configured links when present.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param branding body branding.Branding true "Branding"
+// @Success 200 {object} branding.Branding
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/branding [put]
+func UpdateBranding(c *gin.Context) {
+	var req branding.Branding
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	if err := branding.Validate(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var updatedBy *uuid.UUID
+	if userInfo, exists := auth.GetUserFromContext(c); exists {
+		adminID := userInfo.PlayerID()
+		updatedBy = &adminID
+	}
+	if _, err := branding.SaveOverride(c.Request.Context(), database.GetDB(), req, updatedBy); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, branding.Current())
+}
+
+// ResetBranding drops the runtime branding
+// @Summary Reset branding
+// @Description Remove the runtime override so every instance uses the configured branding again
+// @Tags admin
+// @Produce json
+// @Success 200 {object} branding.Branding
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/branding [delete]
+func ResetBranding(c *gin.Context) {
+	if err := branding.ResetOverride(c.Request.Context(), database.GetDB()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, branding.Current())
+}
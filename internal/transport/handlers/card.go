@@ -1,13 +1,20 @@
 package handlers
 
 import (
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"dixitme/internal/cache"
 	"dixitme/internal/database"
+	"dixitme/internal/logger"
 	"dixitme/internal/models"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/cardimages"
+	"dixitme/internal/services/taxonomy"
 	"dixitme/internal/storage"
+	"dixitme/internal/transport/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -46,15 +53,18 @@ func GetCards(c *gin.Context) {
 
 // UploadCardImage uploads an image for a card to MinIO storage
 // @Summary Upload card image
-// @Description Upload an image for a card to MinIO storage
+// @Description Upload an image for a card to MinIO storage. Images that are near-identical to another active card are rejected unless force is set; merely similar ones are returned as warnings.
 // @Tags cards
 // @Accept multipart/form-data
 // @Produce json
 // @Param card_id path int true "Card ID"
 // @Param image formData file true "Card image file"
+// @Param force query bool false "Upload even if the image duplicates another card" default(false)
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[manage_cards]
 // @Router /cards/{card_id}/image [post]
 func UploadCardImage(c *gin.Context) {
 	cardID, err := strconv.Atoi(c.Param("card_id"))
@@ -71,6 +81,33 @@ func UploadCardImage(c *gin.Context) {
 	}
 	defer file.Close()
 
+	db := database.GetDB()
+
+	// Compare the image with the active deck before storing it
+	var imageHash string
+	var similar []cardimages.SimilarCard
+	if hash, err := cardimages.PerceptualHash(file); err != nil {
+		logger.Warn("Could not hash card image, skipping duplicate check", "card_id", cardID, "error", err)
+	} else {
+		imageHash = cardimages.FormatHash(hash)
+		similar, err = cardimages.FindSimilar(c.Request.Context(), db, hash, cardID, cardimages.SimilarDistance)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check for duplicate images"})
+			return
+		}
+		if len(similar) > 0 && similar[0].Duplicate && c.Query("force") != "true" {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":         "Image is a near-duplicate of an existing card",
+				"similar_cards": similar,
+			})
+			return
+		}
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read image"})
+		return
+	}
+
 	// Upload to MinIO
 	minioClient := storage.GetClient()
 	if minioClient == nil {
@@ -85,7 +122,6 @@ func UploadCardImage(c *gin.Context) {
 	}
 
 	// Update card in database
-	db := database.GetDB()
 	var card models.Card
 	if err := db.First(&card, cardID).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Card not found"})
@@ -93,15 +129,19 @@ func UploadCardImage(c *gin.Context) {
 	}
 
 	card.ImageURL = imageURL
+	card.ImageHash = imageHash
 	if err := db.Save(&card).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update card"})
 		return
 	}
+	cache.Invalidate(c.Request.Context(), cache.ScopeCards)
 
 	c.JSON(http.StatusOK, gin.H{
-		"success":   true,
-		"image_url": imageURL,
-		"card_id":   cardID,
+		"success":       true,
+		"image_url":     imageURL,
+		"card_id":       cardID,
+		"image_hash":    imageHash,
+		"similar_cards": similar, // Close but not duplicate matches, for the curator to review
 	})
 }
 
@@ -115,10 +155,16 @@ func UploadCardImage(c *gin.Context) {
 // @Success 201 {object} models.Card
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[manage_cards]
 // @Router /cards [post]
 func CreateCard(c *gin.Context) {
 	var req CreateCardRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	attribution, err := req.CardAttribution.Normalize()
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -127,10 +173,12 @@ func CreateCard(c *gin.Context) {
 
 	// Create card
 	card := models.Card{
-		Title:       req.Title,
-		Description: req.Description,
-		Extension:   req.Extension,
-		IsActive:    true,
+		Title:           req.Title,
+		Description:     req.Description,
+		Extension:       req.Extension,
+		IsActive:        true,
+		Version:         1,
+		CardAttribution: attribution,
 	}
 
 	if err := db.Create(&card).Error; err != nil {
@@ -157,6 +205,13 @@ func CreateCard(c *gin.Context) {
 		db.Save(&card)
 	}
 
+	// Record the initial version for the card's edit history
+	editor, _ := auth.GetUserFromContext(c)
+	if err := recordCardVersion(db, &card, editor, "Card created", nil); err != nil {
+		logger.Warn("Failed to record initial card version", "card_id", card.ID, "error", err)
+	}
+	cache.Invalidate(c.Request.Context(), cache.ScopeCards)
+
 	c.JSON(http.StatusCreated, card)
 }
 
@@ -198,32 +253,99 @@ func GetCardWithTags(c *gin.Context) {
 	}
 
 	response := CardWithTagsResponse{
-		ID:          card.ID,
-		ImageURL:    card.ImageURL,
-		Title:       card.Title,
-		Description: card.Description,
-		Extension:   card.Extension,
-		IsActive:    card.IsActive,
-		Tags:        tags,
-		CreatedAt:   card.CreatedAt,
-		UpdatedAt:   card.UpdatedAt,
+		ID:              card.ID,
+		ImageURL:        card.ImageURL,
+		Title:           card.Title,
+		Description:     card.Description,
+		Extension:       card.Extension,
+		IsActive:        card.IsActive,
+		Tags:            tags,
+		CreatedAt:       card.CreatedAt,
+		UpdatedAt:       card.UpdatedAt,
+		CardAttribution: card.CardAttribution,
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// GetCardCredits lists the artists behind the playable cards
+// @Summary Get card credits
+// @Description Credit every artist whose art is in the playable deck, with the license and source of their cards
+// @Tags cards
+// @Produce json
+// @Success 200 {object} CardCreditsResponse
+// @Failure 500 {object} map[string]interface{}
+// @Router /cards/credits [get]
+func GetCardCredits(c *gin.Context) {
+	db := database.GetDB()
+
+	var credits []CardCredit
+	if err := db.Model(&models.Card{}).
+		Select("artist, source_url, license, COUNT(*) AS cards").
+		Where("is_active = ? AND artist <> ''", true).
+		Group("artist, source_url, license").
+		Order("artist, license, source_url").
+		Scan(&credits).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load card credits"})
+		return
+	}
+
+	var unattributed int64
+	if err := db.Model(&models.Card{}).
+		Where("is_active = ? AND (artist = '' OR artist IS NULL)", true).
+		Count(&unattributed).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load card credits"})
+		return
+	}
+
+	if credits == nil {
+		credits = []CardCredit{}
+	}
+	c.JSON(http.StatusOK, CardCreditsResponse{Credits: credits, Unattributed: unattributed})
+}
+
+// GetCardPacks lists the card packs a room's deck can be built from
+// @Summary List card packs
+// @Description List the card packs hosts can pick when creating a room, with their active card counts. Rooms that pick none play with the default packs.
+// @Tags cards
+// @Produce json
+// @Success 200 {object} CardPacksResponse
+// @Failure 500 {object} map[string]interface{}
+// @Router /cards/packs [get]
+func GetCardPacks(c *gin.Context) {
+	db := database.Reader(c.Request.Context())
+
+	var packs []CardPackSummary
+	if err := db.Model(&models.CardPack{}).
+		Select("card_packs.*, COUNT(cards.id) AS cards").
+		Joins("LEFT JOIN card_pack_cards ON card_pack_cards.card_pack_id = card_packs.id").
+		Joins("LEFT JOIN cards ON cards.id = card_pack_cards.card_id AND cards.is_active = ?", true).
+		Group("card_packs.id").
+		Order("card_packs.is_default DESC, card_packs.name").
+		Scan(&packs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load card packs"})
+		return
+	}
+
+	if packs == nil {
+		packs = []CardPackSummary{}
+	}
+	c.JSON(http.StatusOK, CardPacksResponse{Packs: packs})
+}
+
 // ListCards gets a list of cards with optional tag filtering
 // @Summary List cards with filtering
 // @Description Get a list of cards with optional tag filtering
 // @Tags cards
 // @Produce json
 // @Param tags query string false "Comma-separated tag IDs for filtering"
+// @Param expand query string false "Expand tag filters through the hierarchy: none, up, down, both" default(down)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
 // @Success 200 {object} CardsListResponse
 // @Router /cards [get]
 func ListCards(c *gin.Context) {
-	db := database.GetDB()
+	db := database.Reader(c.Request.Context())
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
@@ -233,11 +355,37 @@ func ListCards(c *gin.Context) {
 
 	query := db.Model(&models.Card{}).Where("is_active = ?", true)
 
-	// Filter by tags if provided
+	// Filter by tags if provided, expanding through the tag hierarchy
+	var tagWeights map[int]float64
 	if tagsParam != "" {
-		tagIDs := strings.Split(tagsParam, ",")
-		query = query.Joins("JOIN card_tag_relations ON cards.id = card_tag_relations.card_id").
-			Where("card_tag_relations.tag_id IN ?", tagIDs)
+		tagIDs := make([]int, 0)
+		for _, raw := range strings.Split(tagsParam, ",") {
+			if id, err := strconv.Atoi(strings.TrimSpace(raw)); err == nil {
+				tagIDs = append(tagIDs, id)
+			}
+		}
+
+		tagWeights = make(map[int]float64, len(tagIDs))
+		for _, id := range tagIDs {
+			tagWeights[id] = 1.0
+		}
+
+		direction := taxonomy.ParseDirection(c.DefaultQuery("expand", string(taxonomy.DirectionDown)), taxonomy.DirectionDown)
+		if direction != taxonomy.DirectionNone {
+			if tree, err := taxonomy.Load(db); err == nil {
+				tagWeights = tree.ExpandAll(tagIDs, direction, taxonomy.DefaultDecay, taxonomy.DefaultMaxDepth)
+			} else {
+				logger.Warn("Failed to load tag taxonomy, filtering by exact tags", "error", err)
+			}
+		}
+
+		expandedIDs := make([]int, 0, len(tagWeights))
+		for id := range tagWeights {
+			expandedIDs = append(expandedIDs, id)
+		}
+
+		query = query.Where("cards.id IN (?)",
+			db.Table("card_tag_relations").Select("card_id").Where("tag_id IN ?", expandedIDs))
 	}
 
 	var total int64
@@ -252,8 +400,12 @@ func ListCards(c *gin.Context) {
 	// Build response
 	cardResponses := make([]CardWithTagsResponse, 0, len(cards))
 	for _, card := range cards {
+		matchScore := 0.0
 		tags := make([]TagResponse, 0, len(card.Tags))
 		for _, cardTag := range card.Tags {
+			if weight := tagWeights[cardTag.Tag.ID]; weight > matchScore {
+				matchScore = weight
+			}
 			tags = append(tags, TagResponse{
 				ID:       cardTag.Tag.ID,
 				Name:     cardTag.Tag.Name,
@@ -265,15 +417,17 @@ func ListCards(c *gin.Context) {
 		}
 
 		cardResponses = append(cardResponses, CardWithTagsResponse{
-			ID:          card.ID,
-			ImageURL:    card.ImageURL,
-			Title:       card.Title,
-			Description: card.Description,
-			Extension:   card.Extension,
-			IsActive:    card.IsActive,
-			Tags:        tags,
-			CreatedAt:   card.CreatedAt,
-			UpdatedAt:   card.UpdatedAt,
+			ID:              card.ID,
+			ImageURL:        card.ImageURL,
+			Title:           card.Title,
+			Description:     card.Description,
+			Extension:       card.Extension,
+			IsActive:        card.IsActive,
+			Tags:            tags,
+			MatchScore:      matchScore,
+			CreatedAt:       card.CreatedAt,
+			UpdatedAt:       card.UpdatedAt,
+			CardAttribution: card.CardAttribution,
 		})
 	}
 
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+	"dixitme/internal/services/cardart"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResolveCards returns metadata and signed art URLs for a set of cards
+// @Summary Resolve cards
+// @Description Return metadata and signed thumb/full art URLs for up to 100 card IDs, so clients can fill in hands delivered as bare IDs
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param request body ResolveCardsRequest true "Card IDs"
+// @Success 200 {object} ResolveCardsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /cards/resolve [post]
+func ResolveCards(c *gin.Context) {
+	var req ResolveCardsRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	var cards []models.Card
+	if err := database.GetDB().Where("id IN ?", req.CardIDs).Find(&cards).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load cards"})
+		return
+	}
+
+	byID := make(map[int]models.Card, len(cards))
+	for _, card := range cards {
+		byID[card.ID] = card
+	}
+
+	now := time.Now()
+	response := ResolveCardsResponse{Cards: make([]ResolvedCard, 0, len(req.CardIDs))}
+	seen := make(map[int]bool, len(req.CardIDs))
+	for _, cardID := range req.CardIDs {
+		if seen[cardID] {
+			continue
+		}
+		seen[cardID] = true
+
+		card, exists := byID[cardID]
+		if !exists {
+			response.Missing = append(response.Missing, cardID)
+			continue
+		}
+		response.Cards = append(response.Cards, ResolvedCard{
+			ID:              card.ID,
+			Title:           card.Title,
+			Description:     card.Description,
+			ThumbURL:        artURL(card, cardart.VariantThumb, now),
+			FullURL:         artURL(card, cardart.VariantFull, now),
+			CardAttribution: card.CardAttribution,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCardArt redirects a signed art URL to the stored image
+// @Summary Get card art
+// @Description Redirect to a card's image. Requires the exp and sig of a signed URL from a game state payload or /cards/resolve.
+// @Tags cards
+// @Param card_id path int true "Card ID"
+// @Param variant path string true "Art variant: thumb or full"
+// @Param exp query int true "Expiry (unix seconds)"
+// @Param sig query string true "Signature"
+// @Success 302
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /cards/{card_id}/art/{variant} [get]
+func GetCardArt(c *gin.Context) {
+	cardID, err := strconv.Atoi(c.Param("card_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid card ID"})
+		return
+	}
+	variant := c.Param("variant")
+	if !cardart.IsVariant(variant) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown art variant"})
+		return
+	}
+
+	if err := cardart.Verify(c.Query("exp"), c.Query("sig"), time.Now()); err != nil {
+		status := http.StatusForbidden
+		if errors.Is(err, cardart.ErrNotConfigured) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	var card models.Card
+	if err := database.GetDB().First(&card, cardID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Card not found"})
+		return
+	}
+
+	target := card.ImageURL
+	if variant == cardart.VariantThumb && card.ThumbnailURL != "" {
+		target = card.ThumbnailURL
+	}
+
+	// Replacing an image is rare, so let clients cache the redirect briefly
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Redirect(http.StatusFound, target)
+}
+
+// artURL signs a card's art URL, falling back to the raw image URL when signing is off
+func artURL(card models.Card, variant string, now time.Time) string {
+	if url := cardart.URL(card.ID, variant, now); url != "" {
+		return url
+	}
+	if variant == cardart.VariantThumb && card.ThumbnailURL != "" {
+		return card.ThumbnailURL
+	}
+	return card.ImageURL
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"dixitme/internal/cache"
+	"dixitme/internal/database"
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Bulk tag assignment modes
+const (
+	BulkTagModeMerge   = "merge"   // Add new tags and update weights, keep the rest
+	BulkTagModeReplace = "replace" // Card ends up with exactly the given tags
+)
+
+// maxBulkTagCards bounds a single bulk request
+const maxBulkTagCards = 5000
+
+// BulkAssignCardTags assigns tags to many cards in one transaction
+// @Summary Bulk assign card tags
+// @Description Assign tags (by slug, with weights) to many cards at once. The request is validated up front and applied in a single transaction: any error rejects the whole batch. A tag without a weight keeps its current weight, or weighs 1.0 when new. Each changed card gets a new version.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body BulkCardTagsRequest true "Card ID to tag assignments"
+// @Success 200 {object} BulkCardTagsResponse
+// @Failure 400 {object} BulkCardTagsResponse
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/cards/tags/bulk [post]
+func BulkAssignCardTags(c *gin.Context) {
+	var req BulkCardTagsRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	if req.Mode == "" {
+		req.Mode = BulkTagModeMerge
+	}
+	if req.Mode != BulkTagModeMerge && req.Mode != BulkTagModeReplace {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be merge or replace"})
+		return
+	}
+	if len(req.Assignments) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No assignments provided"})
+		return
+	}
+	if len(req.Assignments) > maxBulkTagCards {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d cards per request", maxBulkTagCards)})
+		return
+	}
+
+	db := database.GetDB()
+	report := BulkCardTagsResponse{
+		DryRun:         req.DryRun,
+		Mode:           req.Mode,
+		CardsProcessed: len(req.Assignments),
+	}
+
+	cardIDs := make([]int, 0, len(req.Assignments))
+	slugSet := make(map[string]bool)
+	for cardID, assignments := range req.Assignments {
+		cardIDs = append(cardIDs, cardID)
+		for _, assignment := range assignments {
+			slugSet[assignment.Slug] = true
+		}
+	}
+	sort.Ints(cardIDs)
+
+	slugs := make([]string, 0, len(slugSet))
+	for slug := range slugSet {
+		slugs = append(slugs, slug)
+	}
+
+	var tags []models.Tag
+	if err := db.Where("slug IN ?", slugs).Find(&tags).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load tags"})
+		return
+	}
+	tagIDsBySlug := make(map[string]int, len(tags))
+	for _, tag := range tags {
+		tagIDsBySlug[tag.Slug] = tag.ID
+	}
+
+	var existingCardIDs []int
+	if err := db.Model(&models.Card{}).Where("id IN ?", cardIDs).Pluck("id", &existingCardIDs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load cards"})
+		return
+	}
+	cardExists := make(map[int]bool, len(existingCardIDs))
+	for _, id := range existingCardIDs {
+		cardExists[id] = true
+	}
+
+	// Resolve and validate every assignment before touching the database
+	desired := make(map[int]map[int]*float64, len(cardIDs))
+	for _, cardID := range cardIDs {
+		if !cardExists[cardID] {
+			report.Errors = append(report.Errors, BulkCardTagsError{CardID: cardID, Error: "card not found"})
+			continue
+		}
+
+		cardTags := make(map[int]*float64, len(req.Assignments[cardID]))
+		for _, assignment := range req.Assignments[cardID] {
+			tagID, exists := tagIDsBySlug[assignment.Slug]
+			if !exists {
+				report.Errors = append(report.Errors, BulkCardTagsError{CardID: cardID, Slug: assignment.Slug, Error: "tag not found"})
+				continue
+			}
+			if _, duplicate := cardTags[tagID]; duplicate {
+				report.Errors = append(report.Errors, BulkCardTagsError{CardID: cardID, Slug: assignment.Slug, Error: "duplicate tag for card"})
+				continue
+			}
+
+			if weight := assignment.Weight; weight != nil && (*weight <= 0 || *weight > 1) {
+				report.Errors = append(report.Errors, BulkCardTagsError{CardID: cardID, Slug: assignment.Slug, Error: "weight must be greater than 0 and at most 1"})
+				continue
+			}
+			cardTags[tagID] = assignment.Weight
+		}
+		desired[cardID] = cardTags
+	}
+
+	if len(report.Errors) > 0 {
+		c.JSON(http.StatusBadRequest, report)
+		return
+	}
+
+	editor, _ := auth.GetUserFromContext(c)
+	note := req.ChangeNote
+	if note == "" {
+		note = "Bulk tag assignment"
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var currentTags []models.CardTag
+		if err := tx.Where("card_id IN ?", cardIDs).Find(&currentTags).Error; err != nil {
+			return err
+		}
+		current := make(map[int]map[int]float64, len(cardIDs))
+		for _, cardTag := range currentTags {
+			if current[cardTag.CardID] == nil {
+				current[cardTag.CardID] = make(map[int]float64)
+			}
+			current[cardTag.CardID][cardTag.TagID] = cardTag.Weight
+		}
+
+		for _, cardID := range cardIDs {
+			result := mergeCardTags(current[cardID], desired[cardID], req.Mode)
+			added, updated, removed := diffCardTags(current[cardID], result)
+			if added+updated+removed == 0 {
+				report.CardsUnchanged++
+				continue
+			}
+
+			report.CardsUpdated++
+			report.TagsAdded += added
+			report.TagsUpdated += updated
+			report.TagsRemoved += removed
+
+			if req.DryRun {
+				continue
+			}
+
+			var card models.Card
+			if err := tx.First(&card, cardID).Error; err != nil {
+				return err
+			}
+			if err := ensureBaselineVersion(tx, &card); err != nil {
+				return err
+			}
+
+			snapshot := make([]models.CardTagSnapshot, 0, len(result))
+			for tagID, weight := range result {
+				snapshot = append(snapshot, models.CardTagSnapshot{TagID: tagID, Weight: weight})
+			}
+			sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].TagID < snapshot[j].TagID })
+
+			if err := applyCardVersion(tx, &card, snapshot, editor, note, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Bulk tag assignment failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign tags"})
+		return
+	}
+
+	if !req.DryRun && report.CardsUpdated > 0 {
+		cache.Invalidate(c.Request.Context(), cache.ScopeCards)
+		logger.Info("Bulk tag assignment applied",
+			"cards_updated", report.CardsUpdated,
+			"tags_added", report.TagsAdded,
+			"tags_updated", report.TagsUpdated,
+			"tags_removed", report.TagsRemoved)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// mergeCardTags returns a card's tag weights after applying the requested
+// tags. A requested tag without a weight keeps its current one, or weighs 1.0.
+func mergeCardTags(current map[int]float64, requested map[int]*float64, mode string) map[int]float64 {
+	result := make(map[int]float64, len(current)+len(requested))
+	if mode == BulkTagModeMerge {
+		for tagID, weight := range current {
+			result[tagID] = weight
+		}
+	}
+	for tagID, weight := range requested {
+		switch previous, exists := current[tagID]; {
+		case weight != nil:
+			result[tagID] = *weight
+		case exists:
+			result[tagID] = previous
+		default:
+			result[tagID] = 1.0
+		}
+	}
+	return result
+}
+
+// diffCardTags counts tag changes between two sets of tag weights
+func diffCardTags(before, after map[int]float64) (added, updated, removed int) {
+	for tagID, weight := range after {
+		previous, exists := before[tagID]
+		switch {
+		case !exists:
+			added++
+		case previous != weight:
+			updated++
+		}
+	}
+	for tagID := range before {
+		if _, exists := after[tagID]; !exists {
+			removed++
+		}
+	}
+	return added, updated, removed
+}
@@ -0,0 +1,100 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"dixitme/internal/transport/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkTags builds a bulk request for card 1
+func bulkTags(mode string, tags ...gin.H) gin.H {
+	return gin.H{"mode": mode, "assignments": gin.H{"1": tags}}
+}
+
+func TestBulkAssignCardTags(t *testing.T) {
+	cases := []struct {
+		name    string
+		body    gin.H
+		weights map[int]float64
+		report  handlers.BulkCardTagsResponse
+	}{
+		{
+			name:    "merge adds a new tag at full weight",
+			body:    bulkTags("merge", gin.H{"slug": "sea"}),
+			weights: map[int]float64{1: 0.4, 2: 0.7, 3: 1.0},
+			report:  handlers.BulkCardTagsResponse{CardsUpdated: 1, TagsAdded: 1},
+		},
+		{
+			name:    "merge keeps the weight of a tag named without one",
+			body:    bulkTags("merge", gin.H{"slug": "forest"}),
+			weights: map[int]float64{1: 0.4, 2: 0.7},
+			report:  handlers.BulkCardTagsResponse{CardsUnchanged: 1},
+		},
+		{
+			name:    "merge updates an explicit weight",
+			body:    bulkTags("merge", gin.H{"slug": "forest", "weight": 0.9}),
+			weights: map[int]float64{1: 0.9, 2: 0.7},
+			report:  handlers.BulkCardTagsResponse{CardsUpdated: 1, TagsUpdated: 1},
+		},
+		{
+			name:    "replace removes the tags not named",
+			body:    bulkTags("replace", gin.H{"slug": "night"}),
+			weights: map[int]float64{2: 0.7},
+			report:  handlers.BulkCardTagsResponse{CardsUpdated: 1, TagsRemoved: 1},
+		},
+		{
+			name:    "replace with new tags and weights",
+			body:    bulkTags("replace", gin.H{"slug": "sea", "weight": 0.3}, gin.H{"slug": "forest", "weight": 0.5}),
+			weights: map[int]float64{1: 0.5, 3: 0.3},
+			report:  handlers.BulkCardTagsResponse{CardsUpdated: 1, TagsAdded: 1, TagsUpdated: 1, TagsRemoved: 1},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router, db := cardHistoryRouter(t)
+			router.POST("/admin/cards/tags/bulk", handlers.BulkAssignCardTags)
+
+			recorder := sendJSON(t, router, http.MethodPost, "/admin/cards/tags/bulk", tc.body)
+			require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+			var report handlers.BulkCardTagsResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+
+			tc.report.Mode = tc.body["mode"].(string)
+			tc.report.CardsProcessed = 1
+			assert.Equal(t, tc.report, report)
+			assert.Equal(t, tc.weights, cardTagWeights(t, db))
+		})
+	}
+}
+
+func TestBulkAssignCardTagsRejectsBatch(t *testing.T) {
+	cases := []struct {
+		name string
+		body gin.H
+	}{
+		{"zero weight", bulkTags("merge", gin.H{"slug": "sea", "weight": 0})},
+		{"negative weight", bulkTags("merge", gin.H{"slug": "sea", "weight": -0.5})},
+		{"weight above one", bulkTags("merge", gin.H{"slug": "sea", "weight": 1.5})},
+		{"duplicate tag", bulkTags("replace", gin.H{"slug": "sea"}, gin.H{"slug": "sea", "weight": 0.2})},
+		{"unknown tag", bulkTags("merge", gin.H{"slug": "sea"}, gin.H{"slug": "desert"})},
+		{"unknown card", gin.H{"assignments": gin.H{"1": []gin.H{{"slug": "sea"}}, "99": []gin.H{{"slug": "sea"}}}}},
+		{"unknown mode", bulkTags("append", gin.H{"slug": "sea"})},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router, db := cardHistoryRouter(t)
+			router.POST("/admin/cards/tags/bulk", handlers.BulkAssignCardTags)
+
+			recorder := sendJSON(t, router, http.MethodPost, "/admin/cards/tags/bulk", tc.body)
+			assert.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+			assert.Equal(t, map[int]float64{1: 0.4, 2: 0.7}, cardTagWeights(t, db), "nothing is written")
+			assert.Equal(t, 1, cardVersion(t, db))
+		})
+	}
+}
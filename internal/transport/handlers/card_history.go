@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"dixitme/internal/cache"
+	"dixitme/internal/database"
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UpdateCard edits a card's metadata and records a new version
+// @Summary Update card metadata
+// @Description Update a card's title, description or tags; every edit creates a new version. tag_ids and tags both replace the card's tags: tags can set weights, and tags kept without one keep their current weight.
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param card body UpdateCardRequest true "Card changes"
+// @Success 200 {object} models.Card
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[manage_cards]
+// @Router /cards/{card_id} [put]
+func UpdateCard(c *gin.Context) {
+	cardID, err := strconv.Atoi(c.Param("card_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid card ID"})
+		return
+	}
+
+	var req UpdateCardRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	if req.Title == nil && req.Description == nil && req.TagIDs == nil && req.Tags == nil &&
+		req.Artist == nil && req.SourceURL == nil && req.License == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No changes provided"})
+		return
+	}
+
+	assignments, err := requestedCardTags(database.GetDB(), req)
+	if err != nil {
+		respondCardHistoryError(c, err, "Failed to update card")
+		return
+	}
+
+	editor, _ := auth.GetUserFromContext(c)
+
+	var card models.Card
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&card, cardID).Error; err != nil {
+			return err
+		}
+
+		if err := ensureBaselineVersion(tx, &card); err != nil {
+			return err
+		}
+
+		if req.Title != nil {
+			card.Title = *req.Title
+		}
+		if req.Description != nil {
+			card.Description = *req.Description
+		}
+		if req.Artist != nil {
+			card.Artist = *req.Artist
+		}
+		if req.SourceURL != nil {
+			card.SourceURL = *req.SourceURL
+		}
+		if req.License != nil {
+			card.License = *req.License
+		}
+		attribution, err := card.CardAttribution.Normalize()
+		if err != nil {
+			return fmt.Errorf("%w: %v", errInvalidAttribution, err)
+		}
+		card.CardAttribution = attribution
+
+		var tags []models.CardTagSnapshot
+		if assignments != nil {
+			var current []models.CardTag
+			if err := tx.Where("card_id = ?", card.ID).Find(&current).Error; err != nil {
+				return err
+			}
+			weights := make(map[int]float64, len(current))
+			for _, cardTag := range current {
+				weights[cardTag.TagID] = cardTag.Weight
+			}
+
+			tags = make([]models.CardTagSnapshot, 0, len(assignments))
+			for _, assignment := range assignments {
+				weight, retained := weights[assignment.TagID]
+				switch {
+				case assignment.Weight != nil:
+					weight = *assignment.Weight
+				case !retained:
+					weight = 1.0
+				}
+				tags = append(tags, models.CardTagSnapshot{TagID: assignment.TagID, Weight: weight})
+			}
+		}
+
+		return applyCardVersion(tx, &card, tags, editor, req.ChangeNote, nil)
+	})
+	if err != nil {
+		respondCardHistoryError(c, err, "Failed to update card")
+		return
+	}
+	cache.Invalidate(c.Request.Context(), cache.ScopeCards)
+
+	c.JSON(http.StatusOK, card)
+}
+
+// GetCardHistory lists every recorded version of a card
+// @Summary Get card history
+// @Description Get the edit history of a card, newest version first
+// @Tags cards
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Success 200 {object} CardHistoryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /cards/{card_id}/history [get]
+func GetCardHistory(c *gin.Context) {
+	cardID, err := strconv.Atoi(c.Param("card_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid card ID"})
+		return
+	}
+
+	db := database.GetDB()
+
+	var card models.Card
+	if err := db.First(&card, cardID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Card not found"})
+		return
+	}
+
+	var versions []models.CardVersion
+	if err := db.Where("card_id = ?", cardID).Order("version DESC").Find(&versions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load card history"})
+		return
+	}
+
+	response := CardHistoryResponse{
+		CardID:         card.ID,
+		CurrentVersion: card.Version,
+		Versions:       make([]CardVersionResponse, 0, len(versions)),
+	}
+	for _, version := range versions {
+		response.Versions = append(response.Versions, newCardVersionResponse(version))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RollbackCard restores a card's metadata to an earlier version
+// @Summary Roll back card metadata
+// @Description Restore a card's title, description and tags from an earlier version (recorded as a new version)
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param request body RollbackCardRequest true "Version to restore"
+// @Success 200 {object} models.Card
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[manage_cards]
+// @Router /cards/{card_id}/rollback [post]
+func RollbackCard(c *gin.Context) {
+	cardID, err := strconv.Atoi(c.Param("card_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid card ID"})
+		return
+	}
+
+	var req RollbackCardRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	editor, _ := auth.GetUserFromContext(c)
+
+	var card models.Card
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&card, cardID).Error; err != nil {
+			return err
+		}
+
+		if req.Version == card.Version {
+			return errCardVersionCurrent
+		}
+
+		var target models.CardVersion
+		if err := tx.Where("card_id = ? AND version = ?", cardID, req.Version).First(&target).Error; err != nil {
+			return err
+		}
+
+		tags, err := decodeCardTags(target.Tags)
+		if err != nil {
+			return err
+		}
+		if tags == nil {
+			tags = []models.CardTagSnapshot{}
+		}
+
+		card.Title = target.Title
+		card.Description = target.Description
+
+		note := req.ChangeNote
+		if note == "" {
+			note = fmt.Sprintf("Rolled back to version %d", target.Version)
+		}
+
+		return applyCardVersion(tx, &card, tags, editor, note, &target.Version)
+	})
+	if err != nil {
+		respondCardHistoryError(c, err, "Failed to roll back card")
+		return
+	}
+	cache.Invalidate(c.Request.Context(), cache.ScopeCards)
+
+	logger.Info("Card rolled back", "card_id", card.ID, "restored_version", req.Version, "new_version", card.Version)
+
+	c.JSON(http.StatusOK, card)
+}
+
+var errCardVersionCurrent = errors.New("card is already at this version")
+
+var errInvalidAttribution = errors.New("invalid attribution")
+
+var errInvalidCardTags = errors.New("invalid tags")
+
+// requestedCardTags validates the tags an update sets on a card, before
+// anything is written: each tag must exist and appear once, with a weight in
+// (0, 1] if it has one. It returns nil when the update leaves tags alone.
+func requestedCardTags(db *gorm.DB, req UpdateCardRequest) ([]CardTagAssignment, error) {
+	var assignments []CardTagAssignment
+	switch {
+	case req.TagIDs != nil && req.Tags != nil:
+		return nil, fmt.Errorf("%w: send tag_ids or tags, not both", errInvalidCardTags)
+	case req.Tags != nil:
+		assignments = *req.Tags
+	case req.TagIDs != nil:
+		assignments = make([]CardTagAssignment, 0, len(*req.TagIDs))
+		for _, tagID := range *req.TagIDs {
+			assignments = append(assignments, CardTagAssignment{TagID: tagID})
+		}
+	default:
+		return nil, nil
+	}
+
+	tagIDs := make([]int, 0, len(assignments))
+	seen := make(map[int]bool, len(assignments))
+	for _, assignment := range assignments {
+		if seen[assignment.TagID] {
+			return nil, fmt.Errorf("%w: tag %d is listed twice", errInvalidCardTags, assignment.TagID)
+		}
+		if assignment.Weight != nil && (*assignment.Weight <= 0 || *assignment.Weight > 1) {
+			return nil, fmt.Errorf("%w: weight of tag %d must be greater than 0 and at most 1", errInvalidCardTags, assignment.TagID)
+		}
+		seen[assignment.TagID] = true
+		tagIDs = append(tagIDs, assignment.TagID)
+	}
+
+	if len(tagIDs) > 0 {
+		var existing []int
+		if err := db.Model(&models.Tag{}).Where("id IN ?", tagIDs).Pluck("id", &existing).Error; err != nil {
+			return nil, err
+		}
+		for _, tagID := range existing {
+			delete(seen, tagID)
+		}
+		for _, tagID := range tagIDs {
+			if seen[tagID] {
+				return nil, fmt.Errorf("%w: tag %d not found", errInvalidCardTags, tagID)
+			}
+		}
+	}
+	return assignments, nil
+}
+
+// ensureBaselineVersion snapshots the current state of a card that predates versioning
+func ensureBaselineVersion(tx *gorm.DB, card *models.Card) error {
+	var count int64
+	if err := tx.Model(&models.CardVersion{}).Where("card_id = ?", card.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if card.Version < 1 {
+		card.Version = 1
+	}
+	return recordCardVersion(tx, card, nil, "Initial version", nil)
+}
+
+// applyCardVersion saves the card, replaces its tags when tags is non-nil,
+// bumps its version and records the resulting snapshot
+func applyCardVersion(tx *gorm.DB, card *models.Card, tags []models.CardTagSnapshot, editor *auth.UserInfo, note string, restoredFrom *int) error {
+	if tags != nil {
+		if err := tx.Where("card_id = ?", card.ID).Delete(&models.CardTag{}).Error; err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			if err := tx.Create(&models.CardTag{CardID: card.ID, TagID: tag.TagID, Weight: tag.Weight}).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	card.Version++
+	if err := tx.Model(card).Select("title", "description", "artist", "source_url", "license", "version").Updates(card).Error; err != nil {
+		return err
+	}
+
+	return recordCardVersion(tx, card, editor, note, restoredFrom)
+}
+
+// recordCardVersion stores a snapshot of the card's current metadata and tags
+func recordCardVersion(tx *gorm.DB, card *models.Card, editor *auth.UserInfo, note string, restoredFrom *int) error {
+	var cardTags []models.CardTag
+	if err := tx.Where("card_id = ?", card.ID).Order("tag_id").Find(&cardTags).Error; err != nil {
+		return err
+	}
+
+	tags := make([]models.CardTagSnapshot, 0, len(cardTags))
+	for _, cardTag := range cardTags {
+		tags = append(tags, models.CardTagSnapshot{TagID: cardTag.TagID, Weight: cardTag.Weight})
+	}
+	encoded, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+
+	version := models.CardVersion{
+		ID:           uuid.New(),
+		CardID:       card.ID,
+		Version:      card.Version,
+		Title:        card.Title,
+		Description:  card.Description,
+		Tags:         string(encoded),
+		EditorName:   "system",
+		ChangeNote:   note,
+		RestoredFrom: restoredFrom,
+	}
+	if editor != nil {
+		version.EditorID = editor.UserID
+		version.EditorName = editor.Name
+	}
+
+	return tx.Create(&version).Error
+}
+
+func decodeCardTags(encoded string) ([]models.CardTagSnapshot, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	var tags []models.CardTagSnapshot
+	if err := json.Unmarshal([]byte(encoded), &tags); err != nil {
+		return nil, fmt.Errorf("failed to decode card tags: %w", err)
+	}
+	return tags, nil
+}
+
+func newCardVersionResponse(version models.CardVersion) CardVersionResponse {
+	tags, err := decodeCardTags(version.Tags)
+	if err != nil {
+		logger.Warn("Invalid tag snapshot in card version", "card_id", version.CardID, "version", version.Version, "error", err)
+	}
+
+	return CardVersionResponse{
+		CardVersion: version,
+		Tags:        tags,
+	}
+}
+
+func respondCardHistoryError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Card or version not found"})
+	case errors.Is(err, errCardVersionCurrent), errors.Is(err, errInvalidAttribution), errors.Is(err, errInvalidCardTags):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		logger.Error(message, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
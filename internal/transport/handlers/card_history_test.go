@@ -0,0 +1,153 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+	"dixitme/internal/testutils/testdb"
+	"dixitme/internal/transport/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// cardHistoryRouter serves the card editing routes over a database holding
+// card 1, tagged with tag 1 at 0.4 and tag 2 at 0.7. Tag 3 is unused.
+func cardHistoryRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db := testdb.Open(t, &models.Tag{}, &models.Card{}, &models.CardTag{}, &models.CardVersion{})
+	for id, slug := range map[int]string{1: "forest", 2: "night", 3: "sea"} {
+		require.NoError(t, db.Create(&models.Tag{ID: id, Name: slug, Slug: slug, IsActive: true}).Error)
+	}
+	require.NoError(t, db.Create(&models.Card{ID: 1, Title: "Woods", ImageURL: "/cards/1.jpg", IsActive: true, Version: 1}).Error)
+	require.NoError(t, db.Create(&models.CardTag{CardID: 1, TagID: 1, Weight: 0.4}).Error)
+	require.NoError(t, db.Create(&models.CardTag{CardID: 1, TagID: 2, Weight: 0.7}).Error)
+
+	previous := database.GetDB()
+	database.SetDB(db)
+	t.Cleanup(func() { database.SetDB(previous) })
+
+	router := gin.New()
+	router.PUT("/cards/:card_id", handlers.UpdateCard)
+	router.POST("/cards/:card_id/rollback", handlers.RollbackCard)
+	router.GET("/cards/:card_id/history", handlers.GetCardHistory)
+	return router, db
+}
+
+func sendJSON(t *testing.T, router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// cardTagWeights reads card 1's tags
+func cardTagWeights(t *testing.T, db *gorm.DB) map[int]float64 {
+	t.Helper()
+	var cardTags []models.CardTag
+	require.NoError(t, db.Where("card_id = ?", 1).Find(&cardTags).Error)
+	weights := make(map[int]float64, len(cardTags))
+	for _, cardTag := range cardTags {
+		weights[cardTag.TagID] = cardTag.Weight
+	}
+	return weights
+}
+
+func cardVersion(t *testing.T, db *gorm.DB) int {
+	t.Helper()
+	var card models.Card
+	require.NoError(t, db.First(&card, 1).Error)
+	return card.Version
+}
+
+func TestUpdateCardTagIDsKeepRetainedWeights(t *testing.T) {
+	router, db := cardHistoryRouter(t)
+
+	recorder := sendJSON(t, router, http.MethodPut, "/cards/1", gin.H{"tag_ids": []int{1, 3}})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.Equal(t, map[int]float64{1: 0.4, 3: 1.0}, cardTagWeights(t, db))
+	assert.Equal(t, 2, cardVersion(t, db))
+}
+
+func TestUpdateCardTagsWithWeights(t *testing.T) {
+	router, db := cardHistoryRouter(t)
+
+	recorder := sendJSON(t, router, http.MethodPut, "/cards/1", gin.H{"tags": []gin.H{
+		{"tag_id": 1, "weight": 0.9},
+		{"tag_id": 2},
+		{"tag_id": 3, "weight": 0.2},
+	}})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.Equal(t, map[int]float64{1: 0.9, 2: 0.7, 3: 0.2}, cardTagWeights(t, db))
+}
+
+func TestUpdateCardRejectsInvalidTagsUpFront(t *testing.T) {
+	router, db := cardHistoryRouter(t)
+
+	cases := []struct {
+		name string
+		body gin.H
+	}{
+		{"unknown tag", gin.H{"tag_ids": []int{1, 99}}},
+		{"duplicate tag", gin.H{"tag_ids": []int{1, 1}}},
+		{"zero weight", gin.H{"tags": []gin.H{{"tag_id": 1, "weight": 0}}}},
+		{"weight above one", gin.H{"tags": []gin.H{{"tag_id": 1, "weight": 1.5}}}},
+		{"both forms", gin.H{"tag_ids": []int{1}, "tags": []gin.H{{"tag_id": 2}}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := sendJSON(t, router, http.MethodPut, "/cards/1", tc.body)
+			assert.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+		})
+	}
+
+	assert.Equal(t, map[int]float64{1: 0.4, 2: 0.7}, cardTagWeights(t, db))
+	assert.Equal(t, 1, cardVersion(t, db), "no version is recorded for a rejected edit")
+}
+
+func TestRollbackCardRestoresTagsAndRecordsVersion(t *testing.T) {
+	router, db := cardHistoryRouter(t)
+
+	recorder := sendJSON(t, router, http.MethodPut, "/cards/1", gin.H{"title": "Dark woods", "tag_ids": []int{3}})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	recorder = sendJSON(t, router, http.MethodPost, "/cards/1/rollback", gin.H{"version": 2})
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "version 2 is current")
+
+	recorder = sendJSON(t, router, http.MethodPost, "/cards/1/rollback", gin.H{"version": 1})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.Equal(t, map[int]float64{1: 0.4, 2: 0.7}, cardTagWeights(t, db))
+	assert.Equal(t, 3, cardVersion(t, db))
+
+	recorder = sendJSON(t, router, http.MethodPost, "/cards/1/rollback", gin.H{"version": 7})
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/cards/1/history", nil)
+	history := httptest.NewRecorder()
+	router.ServeHTTP(history, req)
+	require.Equal(t, http.StatusOK, history.Code, history.Body.String())
+
+	var response handlers.CardHistoryResponse
+	require.NoError(t, json.Unmarshal(history.Body.Bytes(), &response))
+	assert.Equal(t, 3, response.CurrentVersion)
+	require.Len(t, response.Versions, 3)
+	latest := response.Versions[0]
+	assert.Equal(t, "Woods", latest.Title)
+	require.NotNil(t, latest.RestoredFrom)
+	assert.Equal(t, 1, *latest.RestoredFrom)
+	assert.Equal(t, []models.CardTagSnapshot{{TagID: 1, Weight: 0.4}, {TagID: 2, Weight: 0.7}}, latest.Tags)
+	assert.Equal(t, "Dark woods", response.Versions[1].Title)
+}
@@ -6,6 +6,7 @@ import (
 
 	"dixitme/internal/database"
 	"dixitme/internal/models"
+	"dixitme/internal/transport/validation"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -31,11 +32,11 @@ func NewChatHandlers(deps *HandlerDependencies) *ChatHandlers {
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
 // @Router /chat/send [post]
 func SendChatMessage(c *gin.Context) {
 	var req SendChatMessageRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 
@@ -87,11 +88,11 @@ func SendChatMessage(c *gin.Context) {
 // @Success 200 {object} ChatHistoryResponse
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
 // @Router /chat/history [get]
 func GetChatHistory(c *gin.Context) {
 	var req GetChatHistoryRequest
-	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindQuery(c, &req) {
 		return
 	}
 
@@ -144,9 +145,10 @@ func GetChatHistory(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} ChatStatsResponse
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
 // @Router /chat/stats [get]
 func GetChatStats(c *gin.Context) {
-	db := database.GetDB()
+	db := database.Reader(c.Request.Context())
 
 	var totalMessages int64
 	db.Model(&models.ChatMessage{}).Count(&totalMessages)
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"dixitme/internal/database"
+	"dixitme/internal/logger"
+	"dixitme/internal/models"
+	"dixitme/internal/services/clues"
+	"dixitme/internal/services/game"
+	"dixitme/internal/services/taxonomy"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ClueHandlers handles clue inspiration requests
+type ClueHandlers struct {
+	deps *HandlerDependencies
+}
+
+// NewClueHandlers creates a new ClueHandlers instance
+func NewClueHandlers(deps *HandlerDependencies) *ClueHandlers {
+	return &ClueHandlers{deps: deps}
+}
+
+// GetClueSuggestions returns clue inspiration for a card
+// @Summary Get clue suggestions
+// @Description Get sample words, idioms and proverbs for a card in the caller's hand, derived from its tags. The room must have clue suggestions enabled, and while the storyteller picks a clue only they may ask. Guests prove their seat with their resume token.
+// @Tags clues
+// @Produce json
+// @Param card_id query int true "Card ID"
+// @Param room_code query string true "Room code"
+// @Param player_id query string false "Player ID (guests)"
+// @Param X-Resume-Token header string false "Resume token (guests)"
+// @Param limit query int false "Maximum number of suggestions" default(8)
+// @Success 200 {object} ClueSuggestionsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /clues/suggestions [get]
+func (h *ClueHandlers) GetClueSuggestions(c *gin.Context) {
+	cardID, err := strconv.Atoi(c.Query("card_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "card_id is required"})
+		return
+	}
+	roomCode := c.Query("room_code")
+	if roomCode == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "room_code is required"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "8"))
+	if limit < 1 || limit > 20 {
+		limit = 8
+	}
+
+	// Suggestions for a card say something about it, so the caller must prove
+	// the seat holding it rather than name a player ID from the game state
+	playerID, proven, err := h.deps.provenPlayerID(c, roomCode, "")
+	if err == nil && !proven {
+		err = errSeatProofRequired
+	}
+	if err != nil {
+		respondActingPlayerError(c, err)
+		return
+	}
+
+	gameState := h.deps.GameService.GetGame(roomCode)
+	if gameState == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		return
+	}
+	if err := clueSuggestionsAllowed(gameState, playerID, cardID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := database.GetDB()
+
+	var cardTags []models.CardTag
+	if err := db.Preload("Tag").Where("card_id = ?", cardID).Find(&cardTags).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load card tags"})
+		return
+	}
+
+	weighted := make([]clues.WeightedTag, 0, len(cardTags))
+	seen := make(map[int]bool, len(cardTags))
+	for _, cardTag := range cardTags {
+		seen[cardTag.TagID] = true
+		weighted = append(weighted, clues.WeightedTag{Tag: cardTag.Tag, Weight: cardTag.Weight})
+	}
+
+	// Broader tags make good abstract clues, so walk up the hierarchy too
+	if tree, err := taxonomy.Load(db); err == nil {
+		for _, cardTag := range cardTags {
+			for tagID, weight := range tree.Expand(cardTag.TagID, taxonomy.DirectionUp, taxonomy.DefaultDecay, taxonomy.DefaultMaxDepth) {
+				if seen[tagID] {
+					continue
+				}
+				if tag, exists := tree.Tag(tagID); exists {
+					seen[tagID] = true
+					weighted = append(weighted, clues.WeightedTag{Tag: tag, Weight: weight * cardTag.Weight})
+				}
+			}
+		}
+	} else {
+		logger.Warn("Failed to load tag taxonomy for clue suggestions", "error", err)
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	c.JSON(http.StatusOK, ClueSuggestionsResponse{
+		CardID:      cardID,
+		Suggestions: clues.Suggest(weighted, limit, rng),
+	})
+}
+
+// clueSuggestionsAllowed checks the room's beginner aid setting, that the card
+// is in the player's hand, and that nobody but the storyteller asks while the
+// clue is being chosen
+func clueSuggestionsAllowed(gameState *game.GameState, playerID uuid.UUID, cardID int) error {
+	gameState.RLock()
+	defer gameState.RUnlock()
+
+	if !gameState.Settings.ClueSuggestions {
+		return errors.New("Clue suggestions are disabled for this room")
+	}
+	player, exists := gameState.Players[playerID]
+	if !exists {
+		return errors.New("You are not playing in this room")
+	}
+	round := gameState.CurrentRound
+	if round != nil && round.Status == models.RoundStatusStorytelling && round.StorytellerID != playerID {
+		return errors.New("Only the storyteller can ask for clue suggestions now")
+	}
+	if !slices.Contains(player.Hand, cardID) {
+		return errors.New("Card is not in your hand")
+	}
+	return nil
+}
+
+// ListClueLanguages lists the languages a room can declare for its clues
+// @Summary List clue languages
+// @Description List the languages clue detection recognises. Rooms may declare one and warn about or reject clues in other languages.
+// @Tags clues
+// @Produce json
+// @Success 200 {object} ClueLanguagesResponse
+// @Router /clues/languages [get]
+func (h *ClueHandlers) ListClueLanguages(c *gin.Context) {
+	c.JSON(http.StatusOK, ClueLanguagesResponse{Languages: clues.Languages})
+}
@@ -0,0 +1,124 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/game"
+	"dixitme/internal/testutils/testdb"
+	"dixitme/internal/transport/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clueTable is a play table with clue suggestions served, in the storytelling
+// phase of a round told by the host. The host holds card 1 and Bob card 2.
+type clueTable struct {
+	*playTable
+	bobID uuid.UUID
+}
+
+func newClueTable(t *testing.T, suggestions bool) *clueTable {
+	t.Helper()
+	table := &clueTable{playTable: newPlayTable(t), bobID: uuid.New()}
+	clueHandlers := handlers.NewClueHandlers(handlers.NewHandlerDependencies(nil, table.manager, table.jwt))
+	table.router.GET("/api/v1/clues/suggestions", auth.GuestOrAuth(table.jwt), clueHandlers.GetClueSuggestions)
+
+	joined := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_id": table.bobID, "player_name": "Bob"}, nil)
+	require.Equal(t, http.StatusOK, joined.Code, joined.Body.String())
+
+	liveGame := table.manager.GetGame(table.roomCode)
+	liveGame.Lock()
+	liveGame.Settings.ClueSuggestions = suggestions
+	liveGame.Status = models.GameStatusInProgress
+	liveGame.CurrentRound = &game.Round{ID: uuid.New(), RoundNumber: 1, StorytellerID: table.hostID, Status: models.RoundStatusStorytelling}
+	liveGame.Players[table.hostID].Hand = []int{1}
+	liveGame.Players[table.bobID].Hand = []int{2}
+	liveGame.Unlock()
+
+	// Card 1 is tagged, so the suggestions have something to work from
+	db := testdb.Open(t, &models.User{}, &models.Tag{}, &models.Card{}, &models.CardTag{})
+	require.NoError(t, db.Create(&models.Tag{ID: 1, Name: "Forest", Slug: "forest", IsActive: true}).Error)
+	require.NoError(t, db.Create(&models.Card{ID: 1, Title: "Woods", ImageURL: "cards/1.jpg", IsActive: true}).Error)
+	require.NoError(t, db.Create(&models.CardTag{CardID: 1, TagID: 1, Weight: 1}).Error)
+	previous := database.GetDB()
+	database.SetDB(db)
+	t.Cleanup(func() { database.SetDB(previous) })
+	return table
+}
+
+func (table *clueTable) suggestions(t *testing.T, query string, headers map[string]string) (int, string) {
+	t.Helper()
+	recorder := table.do(t, http.MethodGet, "/api/v1/clues/suggestions?"+query, nil, headers)
+	return recorder.Code, recorder.Body.String()
+}
+
+func (table *clueTable) hostProof(t *testing.T) map[string]string {
+	return map[string]string{"X-Resume-Token": table.resumeToken(t, table.hostID)}
+}
+
+func TestClueSuggestionsForStorytellersCard(t *testing.T) {
+	table := newClueTable(t, true)
+
+	status, body := table.suggestions(t, fmt.Sprintf("card_id=1&room_code=%s", table.roomCode), table.hostProof(t))
+	require.Equal(t, http.StatusOK, status, body)
+	var response handlers.ClueSuggestionsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &response))
+	assert.Equal(t, 1, response.CardID)
+}
+
+func TestClueSuggestionsRefusals(t *testing.T) {
+	table := newClueTable(t, true)
+	bobProof := map[string]string{"X-Resume-Token": table.resumeToken(t, table.bobID)}
+	elsewhere, _, err := table.jwt.GenerateResumeToken(table.hostID, "NOSUCH")
+	require.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		query   string
+		headers map[string]string
+		status  int
+	}{
+		{"room code is required, so the room's setting can't be skipped", "card_id=1", table.hostProof(t), http.StatusBadRequest},
+		{"unknown room", "card_id=1&room_code=NOSUCH", map[string]string{"X-Resume-Token": elsewhere}, http.StatusNotFound},
+		{"naming the storyteller's ID proves nothing", fmt.Sprintf("card_id=1&room_code=%s&player_id=%s", table.roomCode, table.hostID), nil, http.StatusForbidden},
+		{"card not in the storyteller's hand", fmt.Sprintf("card_id=2&room_code=%s", table.roomCode), table.hostProof(t), http.StatusForbidden},
+		{"other players wait for the clue", fmt.Sprintf("card_id=2&room_code=%s", table.roomCode), bobProof, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := table.suggestions(t, tc.query, tc.headers)
+			assert.Equal(t, tc.status, status, body)
+		})
+	}
+}
+
+func TestClueSuggestionsFollowRoomSetting(t *testing.T) {
+	table := newClueTable(t, false)
+
+	status, body := table.suggestions(t, fmt.Sprintf("card_id=1&room_code=%s", table.roomCode), table.hostProof(t))
+	assert.Equal(t, http.StatusForbidden, status, body)
+}
+
+func TestClueSuggestionsForOwnCardOnceClueIsGiven(t *testing.T) {
+	table := newClueTable(t, true)
+	bobProof := map[string]string{"X-Resume-Token": table.resumeToken(t, table.bobID)}
+	liveGame := table.manager.GetGame(table.roomCode)
+	liveGame.Lock()
+	liveGame.CurrentRound.Status = models.RoundStatusSubmitting
+	liveGame.Unlock()
+
+	status, body := table.suggestions(t, fmt.Sprintf("card_id=2&room_code=%s", table.roomCode), bobProof)
+	assert.Equal(t, http.StatusOK, status, body)
+
+	status, body = table.suggestions(t, fmt.Sprintf("card_id=1&room_code=%s", table.roomCode), bobProof)
+	assert.Equal(t, http.StatusForbidden, status, body, "other players' cards stay private")
+}
@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"strconv"
+	"time"
 
 	"dixitme/internal/database"
+	"dixitme/internal/services/game"
+	"dixitme/internal/startup"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,10 +41,82 @@ func HealthCheck(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"status":    "healthy",
 		"timestamp": c.GetHeader("X-Request-ID"),
-	})
+		"instance":  game.GetManager().Instance(),
+	}
+	if configured, healthy, lag := database.ReplicaStatus(); configured {
+		response["replica"] = gin.H{"in_use": healthy, "lag_seconds": lag.Seconds()}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ReadinessCheck reports whether the server can take traffic
+// @Summary Readiness check
+// @Description Report the status of each subsystem started with the server: ready, degraded (an optional one is down and the server runs without it), failed or stopped. Ready components are checked live. Unavailable until the database and Redis are up, and while shutting down.
+// @Tags health
+// @Produce json
+// @Success 200 {object} ReadinessResponse
+// @Failure 503 {object} ReadinessResponse
+// @Router /health/ready [get]
+func ReadinessCheck(orchestrator *startup.Orchestrator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+		defer cancel()
+
+		components, ready := orchestrator.Report(ctx)
+		response := ReadinessResponse{Status: "ready", Components: components}
+		if !ready {
+			response.Status = "unavailable"
+			c.JSON(http.StatusServiceUnavailable, response)
+			return
+		}
+		for _, component := range components {
+			if component.Status != startup.StatusReady {
+				response.Status = "degraded"
+				break
+			}
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// readinessTimeout bounds the live checks of a readiness probe
+const readinessTimeout = 3 * time.Second
+
+// primaryUntilCookie holds when a client's reads may go back to the read replica
+const primaryUntilCookie = "dixitme_primary_until"
+
+// ReadYourWritesMiddleware keeps a client's reads on the primary database for a
+// while after it writes, so it never reads older data than it just wrote from
+// a lagging replica. Without a replica it does nothing.
+func ReadYourWritesMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		window := database.StickyWindow()
+		if window <= 0 {
+			c.Next()
+			return
+		}
+
+		now := time.Now()
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			if value, err := c.Cookie(primaryUntilCookie); err == nil {
+				if until, err := strconv.ParseInt(value, 10, 64); err == nil && now.UnixMilli() < until {
+					c.Request = c.Request.WithContext(database.WithPrimary(c.Request.Context()))
+				}
+			}
+		default:
+			// Set before the handler runs, since the response may be written by then
+			until := now.Add(window)
+			c.SetCookie(primaryUntilCookie, strconv.FormatInt(until.UnixMilli(), 10), int(window.Seconds())+1, "/", "", false, true)
+			c.Request = c.Request.WithContext(database.WithPrimary(c.Request.Context()))
+		}
+
+		c.Next()
+	}
 }
 
 // CORSMiddleware provides CORS support
@@ -0,0 +1,266 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/game"
+	"dixitme/internal/transport/handlers"
+	"dixitme/internal/transport/versioning"
+	"dixitme/internal/transport/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The REST handlers and the WebSocket handlers implement the same game
+// actions twice. Until they share one implementation, these contract tests
+// make sure both keep emitting the same JSON to clients.
+
+// recordingConnection is a game.Connection that keeps the raw JSON of every
+// message sent to it
+type recordingConnection struct {
+	mu       sync.Mutex
+	protocol int
+	messages []json.RawMessage
+}
+
+func (c *recordingConnection) Send(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, append(json.RawMessage(nil), data...))
+	return nil
+}
+
+func (c *recordingConnection) SendJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.Send(data)
+}
+
+func (c *recordingConnection) Transport() string    { return "contract" }
+func (c *recordingConnection) ProtocolVersion() int { return c.protocol }
+func (c *recordingConnection) Locale() string       { return i18n.DefaultLocale }
+
+// take returns the messages received so far and forgets them
+func (c *recordingConnection) take() []json.RawMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	messages := c.messages
+	c.messages = nil
+	return messages
+}
+
+// payloadOf finds the payload of the first message of a type
+func payloadOf(t *testing.T, messages []json.RawMessage, messageType game.MessageType) json.RawMessage {
+	t.Helper()
+	for _, raw := range messages {
+		var message struct {
+			Type    game.MessageType `json:"type"`
+			Payload json.RawMessage  `json:"payload"`
+		}
+		require.NoError(t, json.Unmarshal(raw, &message))
+		if message.Type == messageType {
+			return message.Payload
+		}
+	}
+	t.Fatalf("no %s message among %d", messageType, len(messages))
+	return nil
+}
+
+// jsonShape reduces a JSON document to its structure: object keys and the
+// kinds of their values, with arrays reduced to the shapes of their elements
+func jsonShape(t *testing.T, data []byte) interface{} {
+	t.Helper()
+	var value interface{}
+	require.NoError(t, json.Unmarshal(data, &value))
+	return shapeOf(value)
+}
+
+func shapeOf(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		shape := make(map[string]interface{}, len(v))
+		var byID []interface{}
+		for key, field := range v {
+			if _, err := uuid.Parse(key); err == nil {
+				// Maps keyed by player ID are shaped like arrays of their values
+				byID = append(byID, field)
+				continue
+			}
+			shape[key] = shapeOf(field)
+		}
+		if byID != nil {
+			shape["<id>"] = distinctShapes(byID)
+		}
+		return shape
+	case []interface{}:
+		return distinctShapes(v)
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
+
+// distinctShapes lists the distinct shapes of some values, in a stable order
+func distinctShapes(values []interface{}) []interface{} {
+	seen := make(map[string]interface{})
+	for _, value := range values {
+		shape := shapeOf(value)
+		encoded, _ := json.Marshal(shape)
+		seen[string(encoded)] = shape
+	}
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	shapes := make([]interface{}, len(keys))
+	for i, key := range keys {
+		shapes[i] = seen[key]
+	}
+	return shapes
+}
+
+// contractTable is a sandbox room reachable from both handler trees
+type contractTable struct {
+	router    *gin.Engine
+	roomCode  string
+	hostID    uuid.UUID
+	host      *recordingConnection
+	hostToken string // Resume token proving the host's seat to host controls
+	jwt       *auth.JWTService
+}
+
+func newContractTable(t *testing.T) *contractTable {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	manager := game.NewEphemeralManager()
+	game.SetManager(manager) // The WebSocket handlers use the global manager
+
+	jwtService := auth.NewJWTService("contract-secret")
+	gameHandlers := handlers.NewGameHandlers(handlers.NewHandlerDependencies(nil, manager, jwtService))
+	router := gin.New()
+	for _, version := range []int{versioning.V1, versioning.V2} {
+		group := router.Group("/api/v"+strconv.Itoa(version), versioning.Middleware(version))
+		group.GET("/games/:room_code/state", gameHandlers.GetLiveGameState)
+		group.POST("/games/add-bot", gameHandlers.AddBotToGame)
+	}
+
+	table := &contractTable{
+		router:   router,
+		roomCode: "CT" + strings.ToUpper(uuid.NewString()[:6]),
+		hostID:   uuid.New(),
+		host:     &recordingConnection{protocol: game.ProtocolV1},
+		jwt:      jwtService,
+	}
+	_, err := manager.CreateGameWithOptions(table.roomCode, table.hostID, "Alice", game.CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+	table.hostToken, _, err = jwtService.GenerateResumeToken(table.hostID, table.roomCode)
+	require.NoError(t, err)
+	game.RegisterPlayerConnection(table.hostID, table.host)
+	t.Cleanup(func() { game.UnregisterPlayerConnection(table.hostID, table.host) })
+	return table
+}
+
+// ws sends a client message through the WebSocket handlers
+func (table *contractTable) ws(t *testing.T, conn game.Connection, playerID uuid.UUID, messageType string, payload interface{}) error {
+	t.Helper()
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return websocket.HandleMessage(conn, playerID, websocket.ConnectionMessage{Type: messageType, Payload: data})
+}
+
+// rest sends a request through the REST handlers, as the host
+func (table *contractTable) rest(t *testing.T, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return table.restAs(t, method, path, body, table.hostToken)
+}
+
+// restAs sends a request through the REST handlers with a resume token
+func (table *contractTable) restAs(t *testing.T, method, path string, body interface{}, resumeToken string) *httptest.ResponseRecorder {
+	t.Helper()
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Resume-Token", resumeToken)
+	recorder := httptest.NewRecorder()
+	table.router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestGameStateContract(t *testing.T) {
+	for _, protocol := range []int{game.ProtocolV1, game.ProtocolV2} {
+		table := newContractTable(t)
+		playerID := uuid.New()
+		conn := &recordingConnection{protocol: protocol}
+		require.NoError(t, table.ws(t, conn, playerID, websocket.ClientMessageJoinGame,
+			websocket.JoinGamePayload{RoomCode: table.roomCode, PlayerName: "Bob"}))
+		wsPayload := payloadOf(t, conn.take(), game.MessageTypeGameState)
+
+		token, _, err := table.jwt.GenerateResumeToken(playerID, table.roomCode)
+		require.NoError(t, err)
+		path := "/api/v" + strconv.Itoa(protocol) + "/games/" + table.roomCode + "/state"
+		response := table.restAs(t, http.MethodGet, path, nil, token)
+		require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+		assert.Equal(t, jsonShape(t, wsPayload), jsonShape(t, response.Body.Bytes()),
+			"protocol v%d: the REST and WebSocket game states differ", protocol)
+	}
+}
+
+func TestAddBotContract(t *testing.T) {
+	table := newContractTable(t)
+
+	response := table.rest(t, http.MethodPost, "/api/v1/games/add-bot",
+		handlers.AddBotRequest{RoomCode: table.roomCode, BotLevel: "easy", HostID: table.hostID.String()})
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	viaREST := table.host.take()
+
+	require.NoError(t, table.ws(t, table.host, table.hostID, websocket.ClientMessageAddBot,
+		websocket.AddBotPayload{RoomCode: table.roomCode, BotLevel: "easy"}))
+	viaWS := table.host.take()
+
+	require.NotEmpty(t, viaREST)
+	require.Len(t, viaWS, len(viaREST), "both add a bot with the same broadcasts")
+	for i := range viaREST {
+		assert.Equal(t, jsonShape(t, viaREST[i]), jsonShape(t, viaWS[i]), "broadcast %d differs", i)
+	}
+}
+
+func TestAddBotLevelsContract(t *testing.T) {
+	for _, level := range []string{"", "easy", "medium", "hard", "auto", "expert"} {
+		table := newContractTable(t)
+
+		response := table.rest(t, http.MethodPost, "/api/v1/games/add-bot",
+			handlers.AddBotRequest{RoomCode: table.roomCode, BotLevel: level, HostID: table.hostID.String()})
+		wsErr := table.ws(t, table.host, table.hostID, websocket.ClientMessageAddBot,
+			websocket.AddBotPayload{RoomCode: table.roomCode, BotLevel: level})
+
+		assert.Equal(t, response.Code == http.StatusOK, wsErr == nil, "level %q: REST answered %d, WebSocket %v", level, response.Code, wsErr)
+	}
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"dixitme/internal/database"
+	"dixitme/internal/logger"
+	"dixitme/internal/services/customdeck"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const deckGuestMessage = "Create an account to build decks"
+
+// ListDecks returns the authenticated user's custom decks
+// @Summary List my decks
+// @Description List the decks the authenticated user has built, in name order
+// @Tags decks
+// @Produce json
+// @Success 200 {object} DecksResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot build decks"
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /decks [get]
+func ListDecks(c *gin.Context) {
+	userID, ok := registeredUserID(c, deckGuestMessage)
+	if !ok {
+		return
+	}
+
+	decks, err := customdeck.List(c.Request.Context(), database.GetDB(), userID)
+	if err != nil {
+		logger.Error("Failed to list decks", "error", err, "user_id", userID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch decks"})
+		return
+	}
+	c.JSON(http.StatusOK, DecksResponse{Decks: decks})
+}
+
+// GetDeck returns one of the authenticated user's custom decks
+// @Summary Get a deck
+// @Description Get one of the authenticated user's decks with its card IDs
+// @Tags decks
+// @Produce json
+// @Param deck_id path string true "Deck ID" format(uuid)
+// @Success 200 {object} customdeck.Deck
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot build decks"
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /decks/{deck_id} [get]
+func GetDeck(c *gin.Context) {
+	userID, ok := registeredUserID(c, deckGuestMessage)
+	if !ok {
+		return
+	}
+	deckID, ok := deckIDParam(c)
+	if !ok {
+		return
+	}
+
+	deck, err := customdeck.Get(c.Request.Context(), database.GetDB(), userID, deckID)
+	if err != nil {
+		respondDeckError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, deck)
+}
+
+// CreateDeck saves a custom deck for the authenticated user
+// @Summary Build a deck
+// @Description Save a named deck of existing, active cards. Rooms created with its ID (POST /games with deck_id) deal only these cards.
+// @Tags decks
+// @Accept json
+// @Produce json
+// @Param deck body DeckRequest true "Deck"
+// @Success 201 {object} customdeck.Deck
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot build decks"
+// @Failure 409 {object} map[string]interface{} "Name taken or too many decks"
+// @Security BearerAuth
+// @Router /decks [post]
+func CreateDeck(c *gin.Context) {
+	userID, ok := registeredUserID(c, deckGuestMessage)
+	if !ok {
+		return
+	}
+	var req DeckRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	deck, err := customdeck.Create(c.Request.Context(), database.GetDB(), userID, customdeck.Spec(req))
+	if err != nil {
+		respondDeckError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, deck)
+}
+
+// UpdateDeck replaces one of the authenticated user's custom decks
+// @Summary Update a deck
+// @Description Replace the name, description and cards of a deck. Rooms already created with it keep their cards.
+// @Tags decks
+// @Accept json
+// @Produce json
+// @Param deck_id path string true "Deck ID" format(uuid)
+// @Param deck body DeckRequest true "Deck"
+// @Success 200 {object} customdeck.Deck
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot build decks"
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{} "Name taken"
+// @Security BearerAuth
+// @Router /decks/{deck_id} [put]
+func UpdateDeck(c *gin.Context) {
+	userID, ok := registeredUserID(c, deckGuestMessage)
+	if !ok {
+		return
+	}
+	deckID, ok := deckIDParam(c)
+	if !ok {
+		return
+	}
+	var req DeckRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	deck, err := customdeck.Update(c.Request.Context(), database.GetDB(), userID, deckID, customdeck.Spec(req))
+	if err != nil {
+		respondDeckError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, deck)
+}
+
+// DeleteDeck removes one of the authenticated user's custom decks
+// @Summary Delete a deck
+// @Description Delete a deck. Rooms already created with it are not affected.
+// @Tags decks
+// @Param deck_id path string true "Deck ID" format(uuid)
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot build decks"
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /decks/{deck_id} [delete]
+func DeleteDeck(c *gin.Context) {
+	userID, ok := registeredUserID(c, deckGuestMessage)
+	if !ok {
+		return
+	}
+	deckID, ok := deckIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := customdeck.Delete(c.Request.Context(), database.GetDB(), userID, deckID); err != nil {
+		respondDeckError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func deckIDParam(c *gin.Context) (uuid.UUID, bool) {
+	deckID, err := uuid.Parse(c.Param("deck_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid deck ID"})
+		return uuid.Nil, false
+	}
+	return deckID, true
+}
+
+func respondDeckError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, customdeck.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, customdeck.ErrNameTaken), errors.Is(err, customdeck.ErrTooMany):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"dixitme/internal/database"
+	"dixitme/internal/logger"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/deckpack"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ListDeckPacks browses the deck pack marketplace
+// @Summary Browse deck packs
+// @Description List the published community deck packs with their author, card count, average rating and preview images. Signed-in users see which packs are in their library.
+// @Tags deck-packs
+// @Produce json
+// @Param search query string false "Match the pack name or author"
+// @Param sort query string false "popular (most installed), rating or newest" default(popular)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} DeckPacksResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /deck-packs [get]
+func ListDeckPacks(c *gin.Context) {
+	var req DeckPackQuery
+	if !validation.BindQuery(c, &req) {
+		return
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	packs, total, err := deckpack.Browse(c.Request.Context(), database.Reader(c.Request.Context()), deckPackViewer(c), deckpack.Query{
+		Search: req.Search,
+		Sort:   req.Sort,
+		Limit:  req.Limit,
+		Offset: (req.Page - 1) * req.Limit,
+	})
+	if err != nil {
+		logger.Error("Failed to list deck packs", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deck packs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, DeckPacksResponse{
+		Packs: packs,
+		Pagination: PaginationResponse{
+			Page:  req.Page,
+			Limit: req.Limit,
+			Total: total,
+			Pages: (total + int64(req.Limit) - 1) / int64(req.Limit),
+		},
+	})
+}
+
+// GetDeckPack returns a published deck pack
+// @Summary Get a deck pack
+// @Description Get a published deck pack's listing
+// @Tags deck-packs
+// @Produce json
+// @Param pack_id path string true "Pack ID" format(uuid)
+// @Success 200 {object} deckpack.Pack
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /deck-packs/{pack_id} [get]
+func GetDeckPack(c *gin.Context) {
+	packID, ok := deckPackIDParam(c)
+	if !ok {
+		return
+	}
+
+	pack, err := deckpack.Get(c.Request.Context(), database.Reader(c.Request.Context()), deckPackViewer(c), packID)
+	if err != nil {
+		respondDeckPackError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pack)
+}
+
+// GetMyDeckPacks returns the authenticated user's deck pack library
+// @Summary List my deck packs
+// @Description List the deck packs the authenticated user installed, most recent first. Packs taken out of the marketplace since stay in the library.
+// @Tags deck-packs
+// @Produce json
+// @Success 200 {object} DeckPackLibraryResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests have no library"
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/deck-packs [get]
+func GetMyDeckPacks(c *gin.Context) {
+	userID, ok := registeredUserID(c, "Create an account to install deck packs")
+	if !ok {
+		return
+	}
+
+	packs, err := deckpack.Library(c.Request.Context(), database.GetDB(), userID)
+	if err != nil {
+		logger.Error("Failed to load deck pack library", "error", err, "user_id", userID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deck packs"})
+		return
+	}
+	c.JSON(http.StatusOK, DeckPackLibraryResponse{Packs: packs})
+}
+
+// InstallDeckPack adds a deck pack to the authenticated user's library
+// @Summary Install a deck pack
+// @Description Add a published deck pack to the authenticated user's library. Installing it again changes nothing.
+// @Tags deck-packs
+// @Produce json
+// @Param pack_id path string true "Pack ID" format(uuid)
+// @Success 200 {object} deckpack.Pack
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests have no library"
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/deck-packs/{pack_id} [put]
+func InstallDeckPack(c *gin.Context) {
+	userID, ok := registeredUserID(c, "Create an account to install deck packs")
+	if !ok {
+		return
+	}
+	packID, ok := deckPackIDParam(c)
+	if !ok {
+		return
+	}
+
+	pack, err := deckpack.Install(c.Request.Context(), database.GetDB(), userID, packID)
+	if err != nil {
+		respondDeckPackError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pack)
+}
+
+// UninstallDeckPack removes a deck pack from the authenticated user's library
+// @Summary Uninstall a deck pack
+// @Description Remove a deck pack from the authenticated user's library
+// @Tags deck-packs
+// @Param pack_id path string true "Pack ID" format(uuid)
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests have no library"
+// @Failure 404 {object} map[string]interface{} "Not in the library"
+// @Security BearerAuth
+// @Router /me/deck-packs/{pack_id} [delete]
+func UninstallDeckPack(c *gin.Context) {
+	userID, ok := registeredUserID(c, "Create an account to install deck packs")
+	if !ok {
+		return
+	}
+	packID, ok := deckPackIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := deckpack.Uninstall(c.Request.Context(), database.GetDB(), userID, packID); err != nil {
+		respondDeckPackError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RateDeckPack records the authenticated user's rating of a deck pack
+// @Summary Rate a deck pack
+// @Description Rate a deck pack one to five stars. Only players who finished a game with the pack among its expansions can rate it; rating again replaces the earlier rating.
+// @Tags deck-packs
+// @Accept json
+// @Produce json
+// @Param pack_id path string true "Pack ID" format(uuid)
+// @Param rating body RateDeckPackRequest true "Stars"
+// @Success 200 {object} deckpack.Pack
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guest, or hasn't played with the pack"
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/deck-packs/{pack_id}/rating [put]
+func RateDeckPack(c *gin.Context) {
+	userID, ok := registeredUserID(c, "Create an account to rate deck packs")
+	if !ok {
+		return
+	}
+	packID, ok := deckPackIDParam(c)
+	if !ok {
+		return
+	}
+	var req RateDeckPackRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	pack, err := deckpack.Rate(c.Request.Context(), database.GetDB(), userID, packID, req.Stars)
+	if err != nil {
+		respondDeckPackError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pack)
+}
+
+// PublishDeckPack lists an imported deck in the marketplace
+// @Summary Publish a deck pack
+// @Description List an imported deck, named by its tag slug, in the deck pack marketplace, or update its listing. The deck needs active cards; a pack taken down before is listed again.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param pack body PublishDeckPackRequest true "Pack listing"
+// @Success 200 {object} deckpack.Pack
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/deck-packs [post]
+func PublishDeckPack(c *gin.Context) {
+	var req PublishDeckPackRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	var adminID uuid.UUID
+	if userInfo, exists := auth.GetUserFromContext(c); exists {
+		adminID = userInfo.PlayerID()
+	}
+	pack, err := deckpack.Publish(c.Request.Context(), database.GetDB(), adminID, deckpack.Spec(req))
+	if err != nil {
+		respondDeckPackError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pack)
+}
+
+// UnpublishDeckPack takes a deck pack out of the marketplace
+// @Summary Unpublish a deck pack
+// @Description Take a deck pack out of the marketplace. Players who installed it keep it in their library.
+// @Tags admin
+// @Param pack_id path string true "Pack ID" format(uuid)
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/deck-packs/{pack_id} [delete]
+func UnpublishDeckPack(c *gin.Context) {
+	packID, ok := deckPackIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := deckpack.Unpublish(c.Request.Context(), database.GetDB(), packID); err != nil {
+		respondDeckPackError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// deckPackViewer is the registered user browsing the marketplace, if any
+func deckPackViewer(c *gin.Context) uuid.UUID {
+	if userInfo, exists := auth.GetUserFromContext(c); exists && userInfo.UserID != nil {
+		return *userInfo.UserID
+	}
+	return uuid.Nil
+}
+
+func deckPackIDParam(c *gin.Context) (uuid.UUID, bool) {
+	packID, err := uuid.Parse(c.Param("pack_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pack ID"})
+		return uuid.Nil, false
+	}
+	return packID, true
+}
+
+func respondDeckPackError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, deckpack.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, deckpack.ErrNotPlayed):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Unwrap(err) != nil:
+		logger.Error("Deck pack request failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update deck packs"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/experiments"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListExperiments lists experimental mechanics and whether rooms can opt into them
+// @Summary List experiments
+// @Description List experimental game mechanics and whether they are enabled on this server. Rooms opt in through their settings.
+// @Tags experiments
+// @Produce json
+// @Success 200 {object} ExperimentsResponse
+// @Router /experiments [get]
+func ListExperiments(c *gin.Context) {
+	c.JSON(http.StatusOK, ExperimentsResponse{Experiments: experiments.List()})
+}
+
+// GetExperimentStats reports how many games were played with each experiment
+// @Summary Get experiment assignment stats
+// @Description Get experiment flag states and the number of games assigned to each experiment
+// @Tags admin
+// @Produce json
+// @Success 200 {object} ExperimentStatsResponse
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/experiments [get]
+func GetExperimentStats(c *gin.Context) {
+	var counts []struct {
+		Experiment string
+		Games      int64
+	}
+	if err := database.GetDB().Model(&models.GameExperiment{}).
+		Select("experiment, COUNT(DISTINCT game_id) as games").
+		Group("experiment").
+		Scan(&counts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load experiment assignments"})
+		return
+	}
+
+	games := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		games[count.Experiment] = count.Games
+	}
+
+	c.JSON(http.StatusOK, ExperimentStatsResponse{
+		Experiments: experiments.List(),
+		Games:       games,
+	})
+}
+
+// SetExperimentFlag flags an experiment on or off at runtime
+// @Summary Set experiment flag
+// @Description Override an experiment's configured flag on every instance. Rooms that already opted in keep the experiment.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param key path string true "Experiment key"
+// @Param flag body SetExperimentFlagRequest true "Flag state"
+// @Success 200 {object} models.FeatureFlag
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/experiments/{key} [put]
+func SetExperimentFlag(c *gin.Context) {
+	key := c.Param("key")
+	if !experiments.IsKnown(key) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Experiment not found"})
+		return
+	}
+
+	var req SetExperimentFlagRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	flag := models.FeatureFlag{Key: key, Enabled: *req.Enabled, UpdatedAt: time.Now()}
+	if userInfo, exists := auth.GetUserFromContext(c); exists {
+		adminID := userInfo.PlayerID()
+		flag.UpdatedBy = &adminID
+	}
+	if err := database.GetDB().Save(&flag).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save feature flag"})
+		return
+	}
+
+	// Other instances pick the change up through the feature_flags trigger
+	if err := experiments.LoadOverrides(c.Request.Context(), database.GetDB()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
+
+// ResetExperimentFlag drops an experiment's runtime flag
+// @Summary Reset experiment flag
+// @Description Remove the runtime override so the experiment follows the EXPERIMENTS setting again, on every instance
+// @Tags admin
+// @Produce json
+// @Param key path string true "Experiment key"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/experiments/{key} [delete]
+func ResetExperimentFlag(c *gin.Context) {
+	key := c.Param("key")
+	if !experiments.IsKnown(key) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Experiment not found"})
+		return
+	}
+
+	if err := database.GetDB().Delete(&models.FeatureFlag{}, "key = ?", key).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset feature flag"})
+		return
+	}
+	if err := experiments.LoadOverrides(c.Request.Context(), database.GetDB()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "enabled": experiments.IsEnabled(key)})
+}
@@ -1,14 +1,35 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"dixitme/internal/database"
+	"dixitme/internal/logger"
 	"dixitme/internal/models"
+	"dixitme/internal/redis"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/bot"
+	"dixitme/internal/services/cardimages"
 	"dixitme/internal/services/game"
+	"dixitme/internal/services/ladder"
+	"dixitme/internal/services/namepolicy"
+	"dixitme/internal/services/readmodel"
+	"dixitme/internal/services/roundimage"
+	"dixitme/internal/storage"
+	"dixitme/internal/transport/validation"
+	"dixitme/internal/transport/versioning"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // GameHandlers handles game-related HTTP requests
@@ -31,17 +52,19 @@ func NewGameHandlers(deps *HandlerDependencies) *GameHandlers {
 // @Success 200 {object} GetGameResponse
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
 // @Router /games/{room_code} [get]
 func (h *GameHandlers) GetGame(c *gin.Context) {
 	var req GetGameRequest
-	if err := c.ShouldBindUri(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindURI(c, &req) {
 		return
 	}
 
-	db := database.GetDB()
+	// The room may have been created a moment ago, before the replica caught up
 	var dbGame models.Game
-	if err := db.Preload("Players.Player").Preload("Rounds").First(&dbGame, "room_code = ?", req.RoomCode).Error; err != nil {
+	if err := database.ReadWithFallback(c.Request.Context(), func(db *gorm.DB) error {
+		return db.Preload("Players.Player").Preload("Rounds").First(&dbGame, "room_code = ?", req.RoomCode).Error
+	}); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
 		return
 	}
@@ -58,27 +81,367 @@ func (h *GameHandlers) GetGame(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// GetGames gets all games
+// GetGames lists games from the game summary read model
 // @Summary Get all games
-// @Description Get a list of all games with their current status
+// @Description Get a page of game summaries, newest first. Summaries are projected from game events and may lag the live game by a moment.
 // @Tags games
 // @Accept json
 // @Produce json
+// @Param pace query string false "Only games with this pace preset" Enums(blitz, standard, relaxed, custom)
+// @Param status query string false "Only games with this status" Enums(waiting, in_progress, completed, abandoned)
+// @Param visibility query string false "Public rooms, or private or all rooms for admins" Enums(public, private, all) default(public)
+// @Param has_space query bool false "Only rooms with a free seat"
+// @Param region query string false "Only rooms hosted in this region"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of games per page" default(20)
 // @Success 200 {object} GetGamesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
 // @Router /games [get]
 func (h *GameHandlers) GetGames(c *gin.Context) {
-	db := database.GetDB()
-	var games []models.Game
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	// Private rooms are only listed for admins; everyone else joins them by code
+	visibility := c.DefaultQuery("visibility", readmodel.VisibilityPublic)
+	switch visibility {
+	case readmodel.VisibilityPublic:
+	case readmodel.VisibilityPrivate, readmodel.VisibilityAll:
+		if userInfo, ok := auth.GetUserFromContext(c); !ok || !userInfo.HasScope(auth.ScopeAdmin) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only admins can list private rooms"})
+			return
+		}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "visibility must be public, private or all"})
+		return
+	}
+	hasSpace := false
+	if value := c.Query("has_space"); value != "" {
+		var err error
+		if hasSpace, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "has_space must be true or false"})
+			return
+		}
+	}
 
-	// Get all games with their players and rounds
-	if err := db.Preload("Players.Player").Preload("Rounds").Find(&games).Error; err != nil {
+	games, total, err := readmodel.ListGames(c.Request.Context(), database.Reader(c.Request.Context()), readmodel.GameFilter{
+		Pace:       c.Query("pace"),
+		Status:     models.GameStatus(c.Query("status")),
+		Visibility: visibility,
+		HasSpace:   hasSpace,
+		Region:     c.Query("region"),
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch games"})
 		return
 	}
 
-	response := GetGamesResponse{Games: games}
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, GetGamesResponse{Games: games, Total: total, Page: page, Limit: limit})
+}
+
+// ListsPrivateGames reports whether a game listing request asks for private
+// rooms, whose response depends on the caller being an admin
+func ListsPrivateGames(c *gin.Context) bool {
+	visibility := c.Query("visibility")
+	return visibility != "" && visibility != readmodel.VisibilityPublic
+}
+
+// myGameStatuses maps the my-games status filter to stored game statuses
+var myGameStatuses = map[string][]models.GameStatus{
+	"active":    {models.GameStatusInProgress},
+	"waiting":   {models.GameStatusWaiting},
+	"completed": {models.GameStatusCompleted, models.GameStatusAbandoned},
+}
+
+// GetMyGames lists the rooms the caller has a seat in
+// @Summary List my games
+// @Description Get a page of the rooms the authenticated player belongs to, most recently updated first. Rooms still running on this server carry is_live and the current round phase, so clients can offer to resume them.
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param status query string false "Only games in this state" Enums(active, waiting, completed)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of games per page" default(20)
+// @Success 200 {object} MyGamesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/games [get]
+func (h *GameHandlers) GetMyGames(c *gin.Context) {
+	userInfo, exists := auth.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var statuses []models.GameStatus
+	if status := c.Query("status"); status != "" {
+		var ok bool
+		if statuses, ok = myGameStatuses[status]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "status must be active, waiting or completed"})
+			return
+		}
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	query := database.Reader(c.Request.Context()).
+		Table("game_players").
+		Joins("JOIN games ON games.id = game_players.game_id AND games.deleted_at IS NULL").
+		Where("game_players.player_id = ?", userInfo.PlayerID())
+	if len(statuses) > 0 {
+		query = query.Where("games.status IN ?", statuses)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch games"})
+		return
+	}
+
+	games := make([]MyGame, 0)
+	err := query.Select("games.id AS game_id, games.room_code, games.status, games.current_round, games.max_rounds, games.max_players, " +
+		"games.created_at, games.updated_at, game_players.score, game_players.position, game_players.is_active").
+		Order("games.updated_at DESC").
+		Limit(limit).Offset((page - 1) * limit).
+		Scan(&games).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch games"})
+		return
+	}
+
+	for i := range games {
+		h.addLiveState(&games[i], userInfo.PlayerID())
+	}
+
+	c.JSON(http.StatusOK, MyGamesResponse{Games: games, Total: total, Page: page, Limit: limit})
+}
+
+// addLiveState overlays a room's live state when it is running on this
+// server. Scores and status are persisted lazily, so the live game wins.
+func (h *GameHandlers) addLiveState(entry *MyGame, playerID uuid.UUID) {
+	liveGame := h.deps.GameService.GetGame(entry.RoomCode)
+	if liveGame == nil || liveGame.ID != entry.GameID {
+		return
+	}
+
+	liveGame.Lock()
+	defer liveGame.Unlock()
+
+	entry.IsLive = true
+	entry.Status = liveGame.Status
+	entry.CurrentRound = liveGame.RoundNumber
+	entry.MaxRounds = liveGame.MaxRounds
+	entry.PlayerCount = len(liveGame.Players)
+	entry.MaxPlayers = liveGame.Settings.Rules.PlayerLimit()
+	if liveGame.CurrentRound != nil {
+		entry.Phase = liveGame.CurrentRound.Status
+	}
+	if player, exists := liveGame.Players[playerID]; exists {
+		entry.Score = player.Score
+		entry.IsActive = player.IsActive && !player.WasReplaced
+	}
+}
+
+// CreateGame creates a room over REST, optionally seating bots straight away
+// @Summary Create game
+// @Description Create a room without a WebSocket, e.g. for kiosks and integration tests. Optionally seats bots, and returns a join link plus a WebSocket URL carrying a resume token for the creator's seat.
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param game body CreateGameRequest true "Room options"
+// @Success 201 {object} CreateGameResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Guest player ID belongs to a registered account"
+// @Failure 404 {object} map[string]interface{} "Tournament or deck not found"
+// @Failure 409 {object} map[string]interface{} "Room code taken or bot limit reached"
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]interface{} "Server at capacity, retry after the Retry-After header"
+// @Security BearerAuth && Scopes[play]
+// @Router /games [post]
+func (h *GameHandlers) CreateGame(c *gin.Context) {
+	var req CreateGameRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	// The body is already consumed, so guests identify themselves in it
+	playerID := uuid.New()
+	playerName := strings.TrimSpace(req.PlayerName)
+	if userInfo, ok := auth.GetUserFromContext(c); ok {
+		playerID = userInfo.PlayerID()
+		if playerName == "" {
+			playerName = userInfo.Name
+		}
+	} else if req.PlayerID != "" {
+		parsed, err := uuid.Parse(req.PlayerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+			return
+		}
+		if err := auth.CheckGuestPlayerID(parsed); err != nil {
+			respondActingPlayerError(c, err)
+			return
+		}
+		playerID = parsed
+	}
+	playerName, err := namepolicy.Check(playerName)
+	if err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+
+	if req.BotLevel == "" {
+		req.BotLevel = "medium"
+	}
+	if req.BotLevel != "easy" && req.BotLevel != "medium" && req.BotLevel != "hard" && req.BotLevel != bot.LevelAuto {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bot level. Must be easy, medium, hard or auto"})
+		return
+	}
+
+	opts := game.CreateGameOptions{Sandbox: req.Sandbox, Pace: req.Pace, Private: req.Private, Password: req.Password, Rules: req.Rules, Packs: req.Packs}
+	if req.DeckID != "" {
+		deckID, err := uuid.Parse(req.DeckID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid deck ID"})
+			return
+		}
+		opts.DeckID = &deckID
+	}
+	if req.TournamentID != "" {
+		tournamentID, err := uuid.Parse(req.TournamentID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+			return
+		}
+		if _, err := ladder.ActiveTournament(c.Request.Context(), database.GetDB(), tournamentID); err != nil {
+			if errors.Is(err, ladder.ErrTournamentNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			logger.Error("Failed to load tournament", "error", err, "tournament_id", tournamentID)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load tournament"})
+			return
+		}
+		opts.TournamentID = &tournamentID
+	}
+
+	h.createRoom(c, req.RoomCode, playerID, playerName, req.Bots, req.BotLevel, opts)
+}
+
+// createRoom creates a room for playerID, seats bots and responds with the
+// join details. An empty room code is generated.
+func (h *GameHandlers) createRoom(c *gin.Context, roomCode string, playerID uuid.UUID, playerName string, bots int, botLevel string, opts game.CreateGameOptions) {
+	rules := game.DefaultGameRules()
+	switch {
+	case opts.Settings != nil:
+		rules = opts.Settings.Rules
+	case opts.Rules != nil:
+		rules = *opts.Rules
+	}
+	if limit := h.deps.GameService.MaxStartingBots(rules); bots < 0 || bots > limit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("bots must be between 0 and %d", limit)})
+		return
+	}
+
+	var err error
+	if strings.TrimSpace(roomCode) != "" {
+		if roomCode, err = game.NormalizeRoomCode(roomCode); err != nil {
+			gameErr, _ := game.AsGameError(err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": gameErr.Message, "code": gameErr.Code, "details": gameErr.Details})
+			return
+		}
+		_, err = h.deps.GameService.CreateGameWithOptions(roomCode, playerID, playerName, opts)
+	} else {
+		// Generated codes can still collide with a live room, so retry a few times
+		for attempt := 0; attempt < 5; attempt++ {
+			if roomCode, err = game.GenerateRoomCode(); err != nil {
+				break
+			}
+			if _, err = h.deps.GameService.CreateGameWithOptions(roomCode, playerID, playerName, opts); err == nil ||
+				!errors.Is(err, &game.GameError{Code: game.ErrCodeRoomCodeTaken}) {
+				break
+			}
+		}
+	}
+	if err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+
+	// All or nothing: a room missing the bots it was asked for is removed again
+	for i := 0; i < bots; i++ {
+		if _, err := h.deps.GameService.AddBot(roomCode, botLevel); err != nil {
+			if deleteErr := h.deps.GameService.DeleteGame(roomCode, playerID); deleteErr != nil {
+				logger.Error("Failed to remove room after bot setup failed", "error", deleteErr, "room_code", roomCode)
+			}
+			respondGameActionError(c, err)
+			return
+		}
+	}
+
+	token, expiresAt, err := h.deps.JWTService.GenerateResumeToken(playerID, roomCode)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue resume token"})
+		return
+	}
+
+	liveGame := h.deps.GameService.GetGame(roomCode)
+	if liveGame == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Game was removed before it could be returned"})
+		return
+	}
+	liveGame.Lock()
+	state := game.GameStateMessage(liveGame, playerID, versioning.FromContext(c)).Payload
+	liveGame.Unlock()
+
+	scheme, wsScheme := "http", "ws"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme, wsScheme = "https", "wss"
+	}
+
+	c.JSON(http.StatusCreated, CreateGameResponse{
+		RoomCode:             roomCode,
+		PlayerID:             playerID,
+		JoinURL:              fmt.Sprintf("%s://%s/?room=%s", scheme, c.Request.Host, roomCode),
+		WebSocketURL:         fmt.Sprintf("%s://%s/ws?resume_token=%s", wsScheme, c.Request.Host, url.QueryEscape(token)),
+		ResumeToken:          token,
+		ResumeTokenExpiresAt: expiresAt,
+		GameState:            state,
+	})
+}
+
+// GetPacePresets lists the pace presets rooms can be created with
+// @Summary Get pace presets
+// @Description Get the timing bundled by each pace preset (blitz, standard, relaxed)
+// @Tags games
+// @Produce json
+// @Success 200 {object} PacePresetsResponse
+// @Security BearerAuth && Scopes[play]
+// @Router /games/pace-presets [get]
+func (h *GameHandlers) GetPacePresets(c *gin.Context) {
+	c.JSON(http.StatusOK, PacePresetsResponse{
+		Presets: game.PacePresets(),
+		Default: game.PaceStandard,
+	})
 }
 
 // AddBotToGame adds a bot to an existing game
@@ -87,26 +450,33 @@ func (h *GameHandlers) GetGames(c *gin.Context) {
 // @Tags games
 // @Accept json
 // @Produce json
+// @Param X-Resume-Token header string false "Guest host's resume token for the room"
 // @Param bot body AddBotRequest true "Bot information"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]interface{} "Only the host can add bots (code not_host), and must prove who they are"
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{} "Bot limit reached (code bot_limit_reached)"
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
 // @Router /api/v1/games/add-bot [post]
 func (h *GameHandlers) AddBotToGame(c *gin.Context) {
 	var req AddBotRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	hostID, ok := h.hostPlayerID(c, req.RoomCode, req.HostID)
+	if !ok {
 		return
 	}
 
 	// Validate bot level
-	validLevels := map[string]bool{"easy": true, "medium": true, "hard": true}
+	validLevels := map[string]bool{"easy": true, "medium": true, "hard": true, bot.LevelAuto: true}
 	if req.BotLevel == "" {
 		req.BotLevel = "medium" // Default level
 	}
 	if !validLevels[req.BotLevel] {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bot level. Must be easy, medium, or hard"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bot level. Must be easy, medium, hard or auto"})
 		return
 	}
 
@@ -140,15 +510,14 @@ func (h *GameHandlers) AddBotToGame(c *gin.Context) {
 	}
 
 	// Check if game has space for more players
-	if len(liveGame.Players) >= 6 { // Max 6 players in Dixit
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Game is full (maximum 6 players)"})
+	if seats := liveGame.Settings.Rules.PlayerLimit(); len(liveGame.Players) >= seats {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Game is full (maximum %d players)", seats)})
 		return
 	}
 
 	// Add bot to game
-	_, err := h.deps.GameService.AddBot(req.RoomCode, req.BotLevel)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if _, err := h.deps.GameService.AddBotAsHost(req.RoomCode, hostID, req.BotLevel); err != nil {
+		respondGameActionError(c, err)
 		return
 	}
 
@@ -169,7 +538,7 @@ func (h *GameHandlers) AddBotToGame(c *gin.Context) {
 // @Failure 500 {object} map[string]interface{}
 // @Router /bots/stats [get]
 func (h *GameHandlers) GetBotStats(c *gin.Context) {
-	db := database.GetDB()
+	db := database.Reader(c.Request.Context())
 
 	var totalBots int64
 	db.Model(&models.Player{}).Where("type = ?", "bot").Count(&totalBots)
@@ -236,16 +605,18 @@ func (h *GameHandlers) GetBotStats(c *gin.Context) {
 // @Tags games
 // @Accept json
 // @Produce json
+// @Param X-Resume-Token header string false "Guest host's resume token for the room"
 // @Param player body RemovePlayerRequest true "Player removal information"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]interface{} "Only the host can remove players (code not_host), and must prove who they are"
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
 // @Router /api/v1/games/remove-player [delete]
 func (h *GameHandlers) RemovePlayerFromGame(c *gin.Context) {
 	var req RemovePlayerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 
@@ -255,6 +626,10 @@ func (h *GameHandlers) RemovePlayerFromGame(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID format"})
 		return
 	}
+	hostID, ok := h.hostPlayerID(c, req.RoomCode, req.HostID)
+	if !ok {
+		return
+	}
 
 	// Get game and remove player
 	liveGame := h.deps.GameService.GetGame(req.RoomCode)
@@ -294,9 +669,9 @@ func (h *GameHandlers) RemovePlayerFromGame(c *gin.Context) {
 
 	// Remove player using the game service
 	gameManager := h.deps.GameService.(*game.Manager)
-	_, err = gameManager.RemovePlayer(req.RoomCode, playerID)
+	_, err = gameManager.KickPlayer(req.RoomCode, hostID, playerID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondGameActionError(c, err)
 		return
 	}
 
@@ -315,29 +690,24 @@ func (h *GameHandlers) RemovePlayerFromGame(c *gin.Context) {
 // @Tags games
 // @Accept json
 // @Produce json
+// @Param X-Resume-Token header string false "Guest's resume token for the room"
 // @Param leave body LeaveGameRequest true "Leave game information"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Player ID not proven"
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
 // @Router /api/v1/games/leave [post]
 func (h *GameHandlers) LeaveGame(c *gin.Context) {
 	var req LeaveGameRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	// Get player ID from context (set by auth middleware)
-	playerIDInterface, exists := c.Get("player_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Player ID not found in context"})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 
-	playerID, ok := playerIDInterface.(uuid.UUID)
+	// Player IDs are in every game state, so anyone could name someone else's
+	playerID, ok := h.seatPlayerID(c, req.RoomCode, req.PlayerID, errActionProofRequired)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid player ID format"})
 		return
 	}
 
@@ -345,7 +715,7 @@ func (h *GameHandlers) LeaveGame(c *gin.Context) {
 	gameManager := h.deps.GameService.(*game.Manager)
 	updatedGame, err := gameManager.LeaveGame(req.RoomCode, playerID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondGameActionError(c, err)
 		return
 	}
 
@@ -358,15 +728,19 @@ func (h *GameHandlers) LeaveGame(c *gin.Context) {
 
 // DeleteGame allows a lobby manager to delete an entire game
 // @Summary Delete game
-// @Description Delete an entire game (only allowed by lobby manager/creator)
+// @Description Delete an entire waiting game. Only the host can delete it.
 // @Tags games
 // @Accept json
 // @Produce json
 // @Param room_code path string true "Room code"
+// @Param player_id query string false "Guest player ID" format(uuid)
+// @Param X-Resume-Token header string false "Guest host's resume token for the room"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]interface{} "Only the host can delete the game (code not_host), and must prove who they are"
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
 // @Router /api/v1/games/{room_code} [delete]
 func (h *GameHandlers) DeleteGame(c *gin.Context) {
 	roomCode := c.Param("room_code")
@@ -375,16 +749,8 @@ func (h *GameHandlers) DeleteGame(c *gin.Context) {
 		return
 	}
 
-	// Get player ID from context (set by auth middleware)
-	playerIDInterface, exists := c.Get("player_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Player ID not found in context"})
-		return
-	}
-
-	playerID, ok := playerIDInterface.(uuid.UUID)
+	playerID, ok := h.hostPlayerID(c, roomCode, "")
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid player ID format"})
 		return
 	}
 
@@ -392,7 +758,7 @@ func (h *GameHandlers) DeleteGame(c *gin.Context) {
 	gameManager := h.deps.GameService.(*game.Manager)
 	err := gameManager.DeleteGame(roomCode, playerID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondGameActionError(c, err)
 		return
 	}
 
@@ -401,3 +767,294 @@ func (h *GameHandlers) DeleteGame(c *gin.Context) {
 		"room_code": roomCode,
 	})
 }
+
+// GetHostReport returns the post-game analytics summary for the room's host
+// @Summary Get host report
+// @Description Get pace stats, chat activity, AFK incidents and bot interventions for a finished game. Only the room's host can view it.
+// @Tags games
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param player_id query string false "Guest player ID" format(uuid)
+// @Param X-Resume-Token header string false "Guest host's resume token for the room"
+// @Success 200 {object} game.HostReport
+// @Failure 403 {object} map[string]string "Only the host can view the report, and must prove who they are"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/host-report [get]
+func (h *GameHandlers) GetHostReport(c *gin.Context) {
+	roomCode := c.Param("room_code")
+	playerID, ok := h.hostPlayerID(c, roomCode, "")
+	if !ok {
+		return
+	}
+
+	report, err := h.deps.GameService.GetHostReport(c.Request.Context(), roomCode, playerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrNotHost):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, game.ErrReportNotReady):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, game.ErrReportNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load host report"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetScoreTimeline returns every player's score at the end of each round
+// @Summary Get score timeline
+// @Description Get cumulative scores per round for a live game, or for the last recorded game in the room, for score-over-time charts and stream overlays
+// @Tags games
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Success 200 {object} game.ScoreTimeline
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/score-timeline [get]
+func (h *GameHandlers) GetScoreTimeline(c *gin.Context) {
+	timeline, err := h.deps.GameService.GetScoreTimeline(c.Request.Context(), c.Param("room_code"))
+	if err != nil {
+		if errors.Is(err, game.ErrTimelineNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load score timeline"})
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
+
+// Rendering a round image is comparatively expensive, so cache misses are rate limited per client
+const (
+	roundImageRenderLimit  = 10
+	roundImageRenderWindow = time.Minute
+)
+
+// GetRoundImage returns a completed round as a shareable PNG
+// @Summary Get round image
+// @Description Render a completed round of the room's last recorded game as a PNG for sharing: the clue, the storyteller's card and the vote distribution. Images are cached; rendering uncached images is rate limited per client.
+// @Tags games
+// @Produce png
+// @Param room_code path string true "Room code"
+// @Param round_number path int true "Round number"
+// @Success 200 {file} binary
+// @Success 304 "Not modified"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string "Too many renders, retry after the Retry-After header"
+// @Failure 500 {object} map[string]string
+// @Router /games/{room_code}/rounds/{round_number}/image [get]
+func (h *GameHandlers) GetRoundImage(c *gin.Context) {
+	roundNumber, err := strconv.Atoi(c.Param("round_number"))
+	if err != nil || roundNumber < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid round number"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	summary, err := h.deps.GameService.GetRoundSummary(ctx, strings.ToUpper(c.Param("room_code")), roundNumber)
+	if err != nil {
+		if errors.Is(err, game.ErrRoundNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Round not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load round"})
+		return
+	}
+
+	etag := roundimage.ETag(summary)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=86400")
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	if data, cached := roundimage.Cached(ctx, summary); cached {
+		c.Header("X-Cache", "HIT")
+		c.Data(http.StatusOK, "image/png", data)
+		return
+	}
+
+	allowed, retryAfter, err := redis.Allow(ctx, "round-image:"+c.ClientIP(), roundImageRenderLimit, roundImageRenderWindow)
+	if err != nil {
+		logger.Warn("Round image rate limit unavailable", "error", err)
+	}
+	if !allowed {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many round images rendered, try again shortly"})
+		return
+	}
+
+	var art image.Image
+	if summary.StorytellerCard != 0 {
+		images := cardimages.NewChecker(database.GetDB(), storage.GetClient())
+		if art, err = roundimage.LoadArt(database.GetDB(), images, summary.StorytellerCard); err != nil {
+			logger.Warn("Failed to load card art for round image", "error", err, "card_id", summary.StorytellerCard)
+		}
+	}
+
+	data, err := roundimage.Render(summary, art)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render round image"})
+		return
+	}
+	roundimage.Store(ctx, summary, data)
+
+	c.Header("X-Cache", "MISS")
+	c.Data(http.StatusOK, "image/png", data)
+}
+
+// GetFairnessProof returns the room's shuffle commitment, and the seed behind it once the game is over
+// @Summary Get fairness proof
+// @Description Get the hash the deck order was committed to when the room was created. Once the game is over the seed is revealed as well, with the deck order it produces and whether it matches the commitment.
+// @Tags games
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Success 200 {object} game.FairnessProof
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/fairness [get]
+func (h *GameHandlers) GetFairnessProof(c *gin.Context) {
+	proof, err := h.deps.GameService.GetFairnessProof(c.Request.Context(), c.Param("room_code"))
+	if err != nil {
+		if errors.Is(err, game.ErrFairnessNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No shuffle record for this room"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load fairness proof"})
+		return
+	}
+
+	c.JSON(http.StatusOK, proof)
+}
+
+// GetBotDecisions returns why the bots of a finished game played as they did
+// @Summary Get bot decisions
+// @Description Get every recorded bot clue, card submission and vote of the room's last game, each with its top-3 scored candidates and a rationale. Available once the game is over, and only for games played while bot decision logging was enabled.
+// @Tags games
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Success 200 {object} game.BotDecisionLog
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "Game still running"
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/bot-decisions [get]
+func (h *GameHandlers) GetBotDecisions(c *gin.Context) {
+	decisions, err := h.deps.GameService.GetBotDecisions(c.Request.Context(), c.Param("room_code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrBotDecisionsNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case errors.Is(err, game.ErrGameNotOver):
+			c.JSON(http.StatusConflict, gin.H{"error": "Bot decisions are available once the game is over"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load bot decisions"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, decisions)
+}
+
+// GetReplay returns the move log of a finished game
+// @Summary Get game replay
+// @Description Get every move of the room's last game in the order it was played: round starts, clues, card submissions with their owners, the revealed table, votes, and the points and scores of each round. Available once the game is over, for games played since moves were recorded.
+// @Tags games
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Success 200 {object} game.GameReplay
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "Game still running"
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/replay [get]
+func (h *GameHandlers) GetReplay(c *gin.Context) {
+	replay, err := h.deps.GameService.GetReplay(c.Request.Context(), c.Param("room_code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrReplayNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case errors.Is(err, game.ErrGameNotOver):
+			c.JSON(http.StatusConflict, gin.H{"error": "Replays are available once the game is over"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load replay"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, replay)
+}
+
+// GetLiveGameState returns the in-memory state of a live game in the shape of the requested API version
+// @Summary Get live game state
+// @Description Get the live state of a game the caller is playing in. v1 returns the full state including every hand; v2 hides other players' hands and the deck, and returns the caller's hand separately.
+// @Tags games
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param player_id query string false "Guest player ID" format(uuid)
+// @Param X-Resume-Token header string false "Guest's resume token for the room"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 403 {object} map[string]string "The caller isn't seated, or is a guest who didn't prove their seat"
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/state [get]
+func (h *GameHandlers) GetLiveGameState(c *gin.Context) {
+	roomCode := c.Param("room_code")
+	// The state includes the caller's hand, so a guest must prove their seat
+	playerID, ok := h.seatPlayerID(c, roomCode, "", errSeatProofRequired)
+	if !ok {
+		return
+	}
+
+	liveGame := h.deps.GameService.GetGame(roomCode)
+	if liveGame == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game is not live"})
+		return
+	}
+
+	liveGame.Lock()
+	defer liveGame.Unlock()
+
+	if _, exists := liveGame.Players[playerID]; !exists {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Player not in game"})
+		return
+	}
+
+	message := game.GameStateMessage(liveGame, playerID, versioning.FromContext(c))
+	c.JSON(http.StatusOK, message.Payload)
+}
+
+// GetRoomHome tells which server instance hosts a room
+// @Summary Get room home
+// @Description Get the region and public URL of the server instance hosting a room, so clients connect to it directly rather than through another region. local is true when it is the instance that answered.
+// @Tags games
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Success 200 {object} game.RoomHome
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/home [get]
+func (h *GameHandlers) GetRoomHome(c *gin.Context) {
+	home, err := h.deps.GameService.FindRoomHome(c.Request.Context(), c.Param("room_code"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up the room's server"})
+		return
+	}
+	if home == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game is not live"})
+		return
+	}
+	c.JSON(http.StatusOK, home)
+}
@@ -0,0 +1,710 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/game"
+	"dixitme/internal/services/namepolicy"
+	"dixitme/internal/transport/validation"
+	"dixitme/internal/transport/versioning"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// The handlers in this file mirror the WebSocket client messages one to one and
+// call the same game service methods, so REST and WebSocket callers go through
+// identical validation. Voice chat stays WebSocket-only: signalling is relayed
+// to peer connections, which a REST client doesn't have.
+
+// resumeTokenHeader carries a guest's resume token for a room: sent back when
+// they join, and presented to prove they hold their seat
+const resumeTokenHeader = "X-Resume-Token"
+
+var (
+	// errGuestIDRequired is returned when a guest doesn't say which seat they act for
+	errGuestIDRequired = errors.New("player_id is required for guests")
+
+	// errInvalidResumeToken is returned for a resume token that is expired,
+	// forged, or for another room or player
+	errInvalidResumeToken = errors.New("invalid or expired resume token")
+
+	// errHostProofRequired refuses a host control to a guest who only named a player ID
+	errHostProofRequired = errors.New("sign in or send your resume token to use host controls")
+
+	// errSeatProofRequired refuses a request about a player's hand to a guest
+	// who only named a player ID
+	errSeatProofRequired = errors.New("sign in or send your resume token to see your cards")
+
+	// errActionProofRequired refuses a move to a guest who only named a player ID
+	errActionProofRequired = errors.New("sign in or send your resume token to play your seat")
+)
+
+// actingPlayerID resolves who is acting: the signed-in user, or the guest
+// player ID from the request body. Callers bind the body first, since binding
+// consumes it. Guests can't claim a registered account's player ID.
+func actingPlayerID(c *gin.Context, guestID string) (uuid.UUID, error) {
+	if userInfo, ok := auth.GetUserFromContext(c); ok {
+		return userInfo.PlayerID(), nil
+	}
+	if guestID == "" {
+		guestID = c.Query("player_id")
+	}
+	if guestID == "" {
+		return uuid.Nil, errGuestIDRequired
+	}
+	playerID, err := uuid.Parse(guestID)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid player ID")
+	}
+	if err := auth.CheckGuestPlayerID(playerID); err != nil {
+		return uuid.Nil, err
+	}
+	return playerID, nil
+}
+
+// provenPlayerID resolves the acting player like actingPlayerID, and reports
+// whether they proved who they are: signed-in players with their session JWT,
+// guests with a resume token for the room in the X-Resume-Token header. Player
+// IDs are shown to everyone at the table, so a bare guest ID is only a claim.
+func (deps *HandlerDependencies) provenPlayerID(c *gin.Context, roomCode, guestID string) (uuid.UUID, bool, error) {
+	if userInfo, ok := auth.GetUserFromContext(c); ok {
+		return userInfo.PlayerID(), true, nil
+	}
+
+	token := c.GetHeader(resumeTokenHeader)
+	if token == "" {
+		playerID, err := actingPlayerID(c, guestID)
+		return playerID, false, err
+	}
+	if deps.JWTService == nil {
+		return uuid.Nil, false, errInvalidResumeToken
+	}
+	claims, err := deps.JWTService.ValidateResumeToken(token)
+	if err != nil || !strings.EqualFold(claims.RoomCode, roomCode) {
+		return uuid.Nil, false, errInvalidResumeToken
+	}
+	if guestID != "" && !strings.EqualFold(guestID, claims.PlayerID.String()) {
+		return uuid.Nil, false, errInvalidResumeToken
+	}
+	return claims.PlayerID, true, nil
+}
+
+// hostPlayerID resolves the player using a host control. The host's ID is in
+// every game state, so a bare guest ID is not enough: the caller must prove it.
+func (h *GameHandlers) hostPlayerID(c *gin.Context, roomCode, guestID string) (uuid.UUID, bool) {
+	return h.seatPlayerID(c, roomCode, guestID, errHostProofRequired)
+}
+
+// seatPlayerID resolves a player acting for their seat, who must prove who
+// they are. A guest who only names a player ID is refused with unproven.
+func (h *GameHandlers) seatPlayerID(c *gin.Context, roomCode, guestID string, unproven error) (uuid.UUID, bool) {
+	playerID, proven, err := h.deps.provenPlayerID(c, roomCode, guestID)
+	if err == nil && !proven {
+		err = unproven
+	}
+	if err != nil {
+		respondActingPlayerError(c, err)
+		return uuid.Nil, false
+	}
+	return playerID, true
+}
+
+// respondActingPlayerError answers a request whose acting player couldn't be resolved
+func respondActingPlayerError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, auth.ErrRegisteredPlayerID), errors.Is(err, errInvalidResumeToken), errors.Is(err, errHostProofRequired),
+		errors.Is(err, errSeatProofRequired), errors.Is(err, errActionProofRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Unwrap(err) != nil:
+		logger.Error("Failed to resolve acting player", "error", err, "path", c.FullPath())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check player ID"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// respondGameActionError maps a game service error to a response. Rule
+// violations and the service's own messages are shown to the client; errors
+// wrapping a storage failure are logged and answered with a generic 500.
+func respondGameActionError(c *gin.Context, err error) {
+	var nameErr *namepolicy.PolicyError
+	if errors.As(err, &nameErr) {
+		respondNameError(c, err, "player_name")
+		return
+	}
+	if retryAfter, ok := game.RetryAfterSeconds(err); ok {
+		gameErr, _ := game.AsGameError(err)
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": gameErr.Message, "code": gameErr.Code, "details": gameErr.Details})
+		return
+	}
+	if gameErr, ok := game.AsGameError(err); ok {
+		status := http.StatusConflict
+		switch gameErr.Code {
+		case game.ErrCodePasswordRequired, game.ErrCodeWrongPassword, game.ErrCodeNotHost:
+			status = http.StatusForbidden
+		case game.ErrCodeClueTooLong, game.ErrCodeClueTooManyWords, game.ErrCodeClueProfanity, game.ErrCodeClueNamesCard:
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": gameErr.Message, "code": gameErr.Code, "details": gameErr.Details})
+		return
+	}
+	if errors.Unwrap(err) != nil {
+		// Wrapped errors carry storage details; log them instead of showing them
+		logger.Error("Game action failed", "error", err, "path", c.FullPath())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "The game could not be updated"})
+		return
+	}
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+// respondGameState replies with the room as the acting player sees it
+func (h *GameHandlers) respondGameState(c *gin.Context, roomCode string, playerID uuid.UUID) {
+	liveGame := h.deps.GameService.GetGame(roomCode)
+	if liveGame == nil {
+		// The action ended the game and it has already been unloaded
+		c.JSON(http.StatusOK, gin.H{"room_code": roomCode})
+		return
+	}
+
+	liveGame.Lock()
+	defer liveGame.Unlock()
+	c.JSON(http.StatusOK, game.GameStateMessage(liveGame, playerID, versioning.FromContext(c)).Payload)
+}
+
+// bindActionBody binds the request body of a game action, responding with
+// 400 when it is invalid
+func bindActionBody(c *gin.Context, req interface{}) bool {
+	err := c.ShouldBindJSON(req)
+	if errors.Is(err, io.EOF) {
+		// Signed-in players may send no body at all for actions without fields
+		err = binding.Validator.ValidateStruct(req)
+	}
+	if err != nil {
+		validation.Respond(c, err)
+		return false
+	}
+	return true
+}
+
+// bindHostAction binds the request body of a host control and resolves the
+// host, who must prove who they are
+func (h *GameHandlers) bindHostAction(c *gin.Context, req interface{}, guestID func() string) (uuid.UUID, bool) {
+	if !bindActionBody(c, req) {
+		return uuid.Nil, false
+	}
+	return h.hostPlayerID(c, c.Param("room_code"), guestID())
+}
+
+// bindGameAction binds the request body and resolves the player making a
+// move, who must prove who they are like over the WebSocket, where the
+// connection does. Responds with an error when either fails.
+func (h *GameHandlers) bindGameAction(c *gin.Context, req interface{}, guestID func() string) (uuid.UUID, bool) {
+	if !bindActionBody(c, req) {
+		return uuid.Nil, false
+	}
+	return h.seatPlayerID(c, c.Param("room_code"), guestID(), errActionProofRequired)
+}
+
+// JoinGame seats the caller in a waiting room
+// @Summary Join game
+// @Description Join a room over REST, the equivalent of the join_game WebSocket message
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest's resume token for the room, needed to take over their seat from another device"
+// @Param join body JoinGameRequest true "Player name"
+// @Success 200 {object} game.GameStateV2Payload
+// @Header 200 {string} X-Resume-Token "Guest's resume token for the seat"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]interface{} "Room password missing or wrong, or player ID not proven"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{} "Seat already taken (code seat_taken)"
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/join [post]
+func (h *GameHandlers) JoinGame(c *gin.Context) {
+	var req JoinGameRequest
+	if !bindActionBody(c, &req) {
+		return
+	}
+	roomCode := c.Param("room_code")
+	playerID, proven, err := h.deps.provenPlayerID(c, roomCode, req.PlayerID)
+	if err != nil {
+		respondActingPlayerError(c, err)
+		return
+	}
+
+	playerName := req.PlayerName
+	if userInfo, ok := auth.GetUserFromContext(c); ok && strings.TrimSpace(playerName) == "" {
+		playerName = userInfo.Name
+	}
+	playerName, err = namepolicy.Check(playerName)
+	if err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+
+	// A finished game's code may lead on to its rematch lobby
+	joined, err := h.deps.GameService.JoinGameWithOptions(roomCode, playerID, playerName, game.JoinGameOptions{
+		Password: req.Password,
+		Verified: proven,
+	})
+	if err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+
+	// Guests have no session to prove who they are, so they get a resume token
+	if _, signedIn := auth.GetUserFromContext(c); !signedIn && h.deps.JWTService != nil {
+		if token, _, err := h.deps.JWTService.GenerateResumeToken(playerID, joined.RoomCode); err == nil {
+			c.Header(resumeTokenHeader, token)
+		} else {
+			logger.Error("Failed to issue resume token", "error", err, "room_code", joined.RoomCode, "player_id", playerID)
+		}
+	}
+	h.respondGameState(c, joined.RoomCode, playerID)
+}
+
+// StartGame starts a waiting room
+// @Summary Start game
+// @Description Start the game, the equivalent of the start_game WebSocket message
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest host's resume token for the room"
+// @Param player body GameActionRequest false "Guest player ID"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]interface{} "Not the host, or the host's identity not proven"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/start [post]
+func (h *GameHandlers) StartGame(c *gin.Context) {
+	var req GameActionRequest
+	playerID, ok := h.bindHostAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+
+	roomCode := c.Param("room_code")
+	if err := h.deps.GameService.StartGame(roomCode, playerID); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
+
+// UpdateGameSettings changes the lobby settings of a waiting room
+// @Summary Update game settings
+// @Description Change lobby settings, the equivalent of the update_settings WebSocket message. Only the host can change them.
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest host's resume token for the room"
+// @Param settings body UpdateGameSettingsRequest true "New settings"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]interface{} "Not the host, or the host's identity not proven"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/settings [put]
+func (h *GameHandlers) UpdateGameSettings(c *gin.Context) {
+	var req UpdateGameSettingsRequest
+	playerID, ok := h.bindHostAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+
+	roomCode := c.Param("room_code")
+	if _, err := h.deps.GameService.UpdateGameSettings(roomCode, playerID, req.Settings); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
+
+// SetChatModeration changes the room's chat controls
+// @Summary Moderate chat
+// @Description Turn slow mode, emotes or a chat pause on or off, the equivalent of the moderate_chat WebSocket message. Only the host can moderate, at any point of the game.
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest host's resume token for the room"
+// @Param moderation body ChatModerationRequest true "Chat controls"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]interface{} "Not the host, or the host's identity not proven"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/chat-moderation [put]
+func (h *GameHandlers) SetChatModeration(c *gin.Context) {
+	var req ChatModerationRequest
+	playerID, ok := h.bindHostAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+
+	roomCode := c.Param("room_code")
+	if _, err := h.deps.GameService.SetChatModeration(roomCode, playerID, req.Moderation); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
+
+// ReportPlayer reports another player in the room to moderators
+// @Summary Report player
+// @Description Report another player in the room for moderators to review, the equivalent of the report_player WebSocket message. Repeat reports of the same player in one game count once.
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest's resume token for the room"
+// @Param report body ReportPlayerRequest true "Reported player and reason"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Player ID not proven"
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/report [post]
+func (h *GameHandlers) ReportPlayer(c *gin.Context) {
+	var req ReportPlayerRequest
+	playerID, ok := h.bindGameAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+	reportedID, err := uuid.Parse(req.ReportedID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reported player ID format"})
+		return
+	}
+
+	if err := h.deps.GameService.ReportPlayer(c.Param("room_code"), playerID, reportedID, req.Reason); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// SubmitClue submits the storyteller's clue and card
+// @Summary Submit clue
+// @Description Submit the storyteller's clue, the equivalent of the submit_clue WebSocket message
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest's resume token for the room"
+// @Param clue body SubmitClueRequest true "Clue and card"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Player ID not proven"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/clue [post]
+func (h *GameHandlers) SubmitClue(c *gin.Context) {
+	var req SubmitClueRequest
+	playerID, ok := h.bindGameAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+
+	roomCode := c.Param("room_code")
+	if err := h.deps.GameService.SubmitClue(roomCode, playerID, req.Clue, req.CardID); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
+
+// Mulligan exchanges the storyteller's hand
+// @Summary Mulligan
+// @Description Exchange the storyteller's hand once per game, the equivalent of the mulligan WebSocket message
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest's resume token for the room"
+// @Param player body GameActionRequest false "Guest player ID"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Player ID not proven"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/mulligan [post]
+func (h *GameHandlers) Mulligan(c *gin.Context) {
+	var req GameActionRequest
+	playerID, ok := h.bindGameAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+
+	roomCode := c.Param("room_code")
+	if err := h.deps.GameService.Mulligan(roomCode, playerID); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
+
+// StartKickVote opens a vote on handing a disruptive player's seat to a bot
+// @Summary Start kick vote
+// @Description Open a vote on kicking a player out of a running game, the equivalent of the start_kick_vote WebSocket message. The player starting it votes yes; the vote passes with a majority of the other humans at the table within a minute, and a bot takes the kicked player's seat.
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest's resume token for the room"
+// @Param vote body StartKickVoteRequest true "Player to kick"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Player ID not proven"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{} "A vote is already open, or too few players to hold one"
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/kick-votes [post]
+func (h *GameHandlers) StartKickVote(c *gin.Context) {
+	var req StartKickVoteRequest
+	playerID, ok := h.bindGameAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+	targetID, err := uuid.Parse(req.TargetID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target player ID format"})
+		return
+	}
+
+	roomCode := c.Param("room_code")
+	if err := h.deps.GameService.StartKickVote(roomCode, playerID, targetID); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
+
+// CastKickVote votes on the open kick vote
+// @Summary Cast kick vote
+// @Description Vote for or against kicking the target of the open kick vote, the equivalent of the cast_kick_vote WebSocket message
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest's resume token for the room"
+// @Param vote body CastKickVoteRequest true "Ballot"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Player ID not proven"
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/kick-votes/ballots [post]
+func (h *GameHandlers) CastKickVote(c *gin.Context) {
+	var req CastKickVoteRequest
+	playerID, ok := h.bindGameAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+
+	roomCode := c.Param("room_code")
+	if err := h.deps.GameService.CastKickVote(roomCode, playerID, *req.Kick); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
+
+// Rematch opens a lobby for a rematch of a finished game
+// @Summary Rematch
+// @Description Open a new lobby for the table of a finished game, the equivalent of the rematch WebSocket message. Everyone who saw the game out and the bots still seated come along, with the same settings; scores and deck start over. The lobby has its own room code, and joining through the finished game's code leads to it. Asking again returns the same lobby.
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code of the finished game"
+// @Param X-Resume-Token header string false "Guest's resume token for the room"
+// @Param player body GameActionRequest false "Guest player ID"
+// @Success 200 {object} game.GameStateV2Payload "The rematch lobby"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Player ID not proven"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/rematch [post]
+func (h *GameHandlers) Rematch(c *gin.Context) {
+	var req GameActionRequest
+	playerID, ok := h.bindGameAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+
+	rematch, err := h.deps.GameService.Rematch(c.Param("room_code"), playerID)
+	if err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, rematch.RoomCode, playerID)
+}
+
+// RequestBotTakeover asks the host for a bot's seat in a running game
+// @Summary Request bot takeover
+// @Description Ask the host to hand a bot's seat to the caller mid-game, the equivalent of the request_bot_takeover WebSocket message. Players watching and players whose own seat went to a bot may ask; the host approves or declines.
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param takeover body RequestBotTakeoverRequest true "Bot seat to take over"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/takeover-requests [post]
+func (h *GameHandlers) RequestBotTakeover(c *gin.Context) {
+	var req RequestBotTakeoverRequest
+	if !bindActionBody(c, &req) {
+		return
+	}
+	// Spectators ask too and have no seat to prove; the host decides
+	playerID, err := actingPlayerID(c, req.PlayerID)
+	if err != nil {
+		respondActingPlayerError(c, err)
+		return
+	}
+	botID, err := uuid.Parse(req.BotID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bot ID format"})
+		return
+	}
+
+	playerName := req.PlayerName
+	if userInfo, ok := auth.GetUserFromContext(c); ok && strings.TrimSpace(playerName) == "" {
+		playerName = userInfo.Name
+	}
+
+	roomCode := c.Param("room_code")
+	if err := h.deps.GameService.RequestBotTakeover(roomCode, playerID, playerName, botID); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
+
+// ResolveBotTakeover answers a request for a bot's seat
+// @Summary Resolve bot takeover
+// @Description Approve or decline the request for a bot's seat, the equivalent of the resolve_bot_takeover WebSocket message. Host only. An approved requester inherits the bot's hand, score and seat, and the bot retires.
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest host's resume token for the room"
+// @Param bot_id path string true "Bot ID"
+// @Param answer body ResolveBotTakeoverRequest true "Host's answer"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]interface{} "Not the host, or the host's identity not proven"
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/takeover-requests/{bot_id} [post]
+func (h *GameHandlers) ResolveBotTakeover(c *gin.Context) {
+	var req ResolveBotTakeoverRequest
+	playerID, ok := h.bindHostAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+	botID, err := uuid.Parse(c.Param("bot_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bot ID format"})
+		return
+	}
+
+	roomCode := c.Param("room_code")
+	if _, err := h.deps.GameService.ResolveBotTakeover(roomCode, playerID, botID, *req.Approve); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
+
+// SubmitCard submits a card matching the storyteller's clue
+// @Summary Submit card
+// @Description Submit a card for the current clue, the equivalent of the submit_card WebSocket message
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest's resume token for the room"
+// @Param card body SubmitCardRequest true "Card"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Player ID not proven"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/cards [post]
+func (h *GameHandlers) SubmitCard(c *gin.Context) {
+	var req SubmitCardRequest
+	playerID, ok := h.bindGameAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+
+	roomCode := c.Param("room_code")
+	if err := h.deps.GameService.SubmitCard(roomCode, playerID, req.CardID); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
+
+// SubmitVote votes for the card the caller thinks is the storyteller's
+// @Summary Submit vote
+// @Description Vote for a card, the equivalent of the submit_vote WebSocket message
+// @Tags gameplay
+// @Accept json
+// @Produce json
+// @Param room_code path string true "Room code"
+// @Param X-Resume-Token header string false "Guest's resume token for the room"
+// @Param vote body SubmitVoteRequest true "Vote"
+// @Success 200 {object} game.GameStateV2Payload
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Player ID not proven"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[play]
+// @Router /games/{room_code}/votes [post]
+func (h *GameHandlers) SubmitVote(c *gin.Context) {
+	var req SubmitVoteRequest
+	playerID, ok := h.bindGameAction(c, &req, func() string { return req.PlayerID })
+	if !ok {
+		return
+	}
+
+	roomCode := c.Param("room_code")
+	if err := h.deps.GameService.SubmitVoteWithOptions(roomCode, playerID, req.CardID, game.VoteOptions{
+		Weight:     req.Weight,
+		DoubleDown: req.DoubleDown,
+	}); err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+	h.respondGameState(c, roomCode, playerID)
+}
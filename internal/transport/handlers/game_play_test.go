@@ -0,0 +1,506 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/game"
+	"dixitme/internal/testutils/testdb"
+	"dixitme/internal/transport/handlers"
+	"dixitme/internal/transport/versioning"
+	"dixitme/internal/transport/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playTable is a sandbox room served by the REST game action handlers behind
+// the same auth middleware as the real router
+type playTable struct {
+	router   *gin.Engine
+	manager  *game.Manager
+	jwt      *auth.JWTService
+	roomCode string
+	hostID   uuid.UUID
+	host     *recordingConnection
+}
+
+func newPlayTable(t *testing.T) *playTable {
+	return newPlayTableWith(t, nil)
+}
+
+// newPlayTableWith serves the table through a game service wrapping the
+// manager, e.g. one that fails, or the manager itself when wrap is nil
+func newPlayTableWith(t *testing.T, wrap func(*game.Manager) game.FullGameService) *playTable {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	manager := game.NewEphemeralManager()
+	game.SetManager(manager)
+	jwtService := auth.NewJWTService("test-secret")
+
+	var service game.FullGameService = manager
+	if wrap != nil {
+		service = wrap(manager)
+	}
+	gameHandlers := handlers.NewGameHandlers(handlers.NewHandlerDependencies(nil, service, jwtService))
+	router := gin.New()
+	games := router.Group("/api/v1/games", versioning.Middleware(versioning.V1), auth.GuestOrAuth(jwtService))
+	games.POST("/:room_code/join", gameHandlers.JoinGame)
+	games.POST("/:room_code/start", gameHandlers.StartGame)
+	games.PUT("/:room_code/settings", gameHandlers.UpdateGameSettings)
+	games.POST("/:room_code/clue", gameHandlers.SubmitClue)
+	games.POST("/:room_code/cards", gameHandlers.SubmitCard)
+	games.POST("/:room_code/votes", gameHandlers.SubmitVote)
+	games.POST("/add-bot", gameHandlers.AddBotToGame)
+	games.DELETE("/remove-player", gameHandlers.RemovePlayerFromGame)
+	games.DELETE("/:room_code", gameHandlers.DeleteGame)
+	games.GET("/:room_code/host-report", gameHandlers.GetHostReport)
+	games.GET("/:room_code/state", gameHandlers.GetLiveGameState)
+	games.POST("/leave", gameHandlers.LeaveGame)
+
+	table := &playTable{
+		router:   router,
+		manager:  manager,
+		jwt:      jwtService,
+		roomCode: "PT" + strings.ToUpper(uuid.NewString()[:6]),
+		hostID:   uuid.New(),
+		host:     &recordingConnection{protocol: game.ProtocolV1},
+	}
+	_, err := manager.CreateGameWithOptions(table.roomCode, table.hostID, "Alice", game.CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+	table.connect(t, table.hostID, table.host)
+	return table
+}
+
+// connect registers a player's live connection for the rest of the test
+func (table *playTable) connect(t *testing.T, playerID uuid.UUID, conn *recordingConnection) {
+	game.RegisterPlayerConnection(playerID, conn)
+	t.Cleanup(func() { game.UnregisterPlayerConnection(playerID, conn) })
+}
+
+// resumeToken signs a resume token for a seat in the table's room
+func (table *playTable) resumeToken(t *testing.T, playerID uuid.UUID) string {
+	token, _, err := table.jwt.GenerateResumeToken(playerID, table.roomCode)
+	require.NoError(t, err)
+	return token
+}
+
+// userToken signs a session JWT for a registered user
+func (table *playTable) userToken(t *testing.T, userID uuid.UUID) string {
+	token, err := table.jwt.GenerateToken(
+		&models.Session{ID: uuid.New(), AuthType: models.AuthTypePassword},
+		&models.User{ID: userID, DisplayName: "Registered"}, "")
+	require.NoError(t, err)
+	return token
+}
+
+// do sends a request with optional headers, e.g. Authorization or X-Resume-Token
+func (table *playTable) do(t *testing.T, method, path string, body interface{}, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	recorder := httptest.NewRecorder()
+	table.router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (table *playTable) path(action string) string {
+	return "/api/v1/games/" + table.roomCode + action
+}
+
+// errorCode reads the code of an error response
+func errorCode(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body struct {
+		Code string `json:"code"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	return body.Code
+}
+
+// withUsers backs the auth checks with a database holding the given users
+func withUsers(t *testing.T, userIDs ...uuid.UUID) {
+	t.Helper()
+	db := testdb.Open(t, &models.User{})
+	for i, userID := range userIDs {
+		suffix := uuid.NewString()[:8]
+		require.NoError(t, db.Create(&models.User{
+			ID:          userID,
+			Email:       suffix + "@example.com",
+			Username:    "user" + suffix,
+			DisplayName: "User " + string(rune('A'+i)),
+			AuthType:    models.AuthTypePassword,
+		}).Error)
+	}
+	previous := database.GetDB()
+	database.SetDB(db)
+	t.Cleanup(func() { database.SetDB(previous) })
+}
+
+func TestJoinGameRefusesSeatTakeoverByBarePlayerID(t *testing.T) {
+	table := newPlayTable(t)
+	bobID := uuid.New()
+	bob := &recordingConnection{protocol: game.ProtocolV1}
+	table.connect(t, bobID, bob)
+
+	joined := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_id": bobID, "player_name": "Bob"}, nil)
+	require.Equal(t, http.StatusOK, joined.Code, joined.Body.String())
+	assert.NotEmpty(t, joined.Header().Get("X-Resume-Token"), "guests get a resume token for their seat")
+
+	// Bob's player ID is in every game state, so anyone at the table knows it
+	bob.take()
+	takeover := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_id": bobID, "player_name": "Mallory"}, nil)
+	assert.Equal(t, http.StatusConflict, takeover.Code)
+	assert.Equal(t, game.ErrCodeSeatTaken, errorCode(t, takeover))
+	assert.Empty(t, bob.take(), "the seated player is left alone")
+
+	// A resume token for another seat proves nothing about Bob's
+	forged := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_id": bobID, "player_name": "Mallory"},
+		map[string]string{"X-Resume-Token": table.resumeToken(t, uuid.New())})
+	assert.Equal(t, http.StatusForbidden, forged.Code)
+}
+
+func TestJoinGameWithResumeTokenTakesOverSeatFromSecondDevice(t *testing.T) {
+	table := newPlayTable(t)
+	bobID := uuid.New()
+	firstDevice := &recordingConnection{protocol: game.ProtocolV1}
+	table.connect(t, bobID, firstDevice)
+
+	joined := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_id": bobID, "player_name": "Bob"}, nil)
+	require.Equal(t, http.StatusOK, joined.Code, joined.Body.String())
+	token := joined.Header().Get("X-Resume-Token")
+	require.NotEmpty(t, token)
+
+	// The second device connects and proves the seat with the token
+	secondDevice := &recordingConnection{protocol: game.ProtocolV1}
+	table.connect(t, bobID, secondDevice)
+	firstDevice.take()
+	rejoined := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_name": "Bob"},
+		map[string]string{"X-Resume-Token": token})
+	require.Equal(t, http.StatusOK, rejoined.Code, rejoined.Body.String())
+
+	payloadOf(t, firstDevice.take(), game.MessageTypeSessionReplaced)
+	payloadOf(t, secondDevice.take(), game.MessageTypeGameState)
+	liveGame := table.manager.GetGame(table.roomCode)
+	liveGame.Lock()
+	assert.Len(t, liveGame.Players, 2)
+	assert.Equal(t, game.Connection(secondDevice), liveGame.Players[bobID].Connection)
+	liveGame.Unlock()
+}
+
+func TestJoinGameRefusesGuestClaimingRegisteredUserID(t *testing.T) {
+	table := newPlayTable(t)
+	userID := uuid.New()
+	withUsers(t, userID)
+
+	claimed := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_id": userID, "player_name": "Mallory"}, nil)
+	assert.Equal(t, http.StatusForbidden, claimed.Code)
+
+	// Signed in, the same user joins, then takes the seat over from a second device
+	bearer := map[string]string{"Authorization": "Bearer " + table.userToken(t, userID)}
+	joined := table.do(t, http.MethodPost, table.path("/join"), gin.H{}, bearer)
+	require.Equal(t, http.StatusOK, joined.Code, joined.Body.String())
+	assert.Empty(t, joined.Header().Get("X-Resume-Token"), "signed-in players prove themselves with their session")
+
+	secondDevice := &recordingConnection{protocol: game.ProtocolV1}
+	table.connect(t, userID, secondDevice)
+	rejoined := table.do(t, http.MethodPost, table.path("/join"), gin.H{}, bearer)
+	require.Equal(t, http.StatusOK, rejoined.Code, rejoined.Body.String())
+	payloadOf(t, secondDevice.take(), game.MessageTypeGameState)
+}
+
+// provingConnection is a connection that says which room its client proved its player ID for
+type provingConnection struct {
+	recordingConnection
+	provenRoom string
+}
+
+func (c *provingConnection) ProvesSeat(roomCode string) bool {
+	return c.provenRoom == roomCode
+}
+
+func TestWebSocketJoinNeedsProofToTakeOverSeat(t *testing.T) {
+	table := newPlayTable(t)
+	join := websocket.JoinGamePayload{RoomCode: table.roomCode, PlayerName: "Alice"}
+	data, err := json.Marshal(join)
+	require.NoError(t, err)
+	message := websocket.ConnectionMessage{Type: websocket.ClientMessageJoinGame, Payload: data}
+
+	// A client naming the host's ID is refused
+	claim := &recordingConnection{protocol: game.ProtocolV1}
+	err = websocket.HandleMessage(claim, table.hostID, message)
+	assert.ErrorIs(t, err, &game.GameError{Code: game.ErrCodeSeatTaken})
+
+	// The host's own second tab, opened with their resume token, takes over
+	secondTab := &provingConnection{recordingConnection: recordingConnection{protocol: game.ProtocolV1}, provenRoom: table.roomCode}
+	game.RegisterPlayerConnection(table.hostID, secondTab)
+	t.Cleanup(func() { game.UnregisterPlayerConnection(table.hostID, secondTab) })
+	require.NoError(t, websocket.HandleMessage(secondTab, table.hostID, message))
+	payloadOf(t, secondTab.take(), game.MessageTypeGameState)
+
+	liveGame := table.manager.GetGame(table.roomCode)
+	liveGame.Lock()
+	defer liveGame.Unlock()
+	assert.Equal(t, game.Connection(secondTab), liveGame.Players[table.hostID].Connection)
+}
+
+func TestHostControlsNeedProofOfTheHostsIdentity(t *testing.T) {
+	table := newPlayTable(t)
+	hostID := table.hostID.String()
+	guestID := uuid.New()
+	joined := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_id": guestID, "player_name": "Bob"}, nil)
+	require.Equal(t, http.StatusOK, joined.Code, joined.Body.String())
+
+	// The host ID is broadcast to the room, so naming it proves nothing
+	claims := []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+	}{
+		{"add bot", http.MethodPost, "/api/v1/games/add-bot", gin.H{"room_code": table.roomCode, "host_id": hostID}},
+		{"remove player", http.MethodDelete, "/api/v1/games/remove-player", gin.H{"room_code": table.roomCode, "player_id": guestID, "host_id": hostID}},
+		{"start", http.MethodPost, table.path("/start"), gin.H{"player_id": hostID}},
+		{"settings", http.MethodPut, table.path("/settings"), gin.H{"player_id": hostID, "settings": game.DefaultGameSettings()}},
+		{"delete", http.MethodDelete, table.path("?player_id=" + hostID), nil},
+		{"host report", http.MethodGet, table.path("/host-report?player_id=" + hostID), nil},
+	}
+	for _, claim := range claims {
+		response := table.do(t, claim.method, claim.path, claim.body, nil)
+		assert.Equal(t, http.StatusForbidden, response.Code, "%s: %s", claim.name, response.Body.String())
+	}
+
+	// Another player proving their own seat is still not the host
+	guestToken := map[string]string{"X-Resume-Token": joined.Header().Get("X-Resume-Token")}
+	kick := table.do(t, http.MethodDelete, "/api/v1/games/remove-player",
+		gin.H{"room_code": table.roomCode, "player_id": hostID}, guestToken)
+	assert.Equal(t, http.StatusForbidden, kick.Code)
+	assert.Equal(t, game.ErrCodeNotHost, errorCode(t, kick))
+	settings := table.do(t, http.MethodPut, table.path("/settings"), gin.H{"settings": game.DefaultGameSettings()}, guestToken)
+	assert.Equal(t, http.StatusForbidden, settings.Code)
+	assert.Equal(t, game.ErrCodeNotHost, errorCode(t, settings))
+
+	// The host proves the seat with their resume token
+	hostToken := map[string]string{"X-Resume-Token": table.resumeToken(t, table.hostID)}
+	report := table.do(t, http.MethodGet, table.path("/host-report"), nil, hostToken)
+	assert.Equal(t, http.StatusConflict, report.Code, "the host gets past the check to a report still being written")
+	added := table.do(t, http.MethodPost, "/api/v1/games/add-bot", gin.H{"room_code": table.roomCode, "bot_level": "easy"}, hostToken)
+	require.Equal(t, http.StatusOK, added.Code, added.Body.String())
+	removed := table.do(t, http.MethodDelete, "/api/v1/games/remove-player",
+		gin.H{"room_code": table.roomCode, "player_id": guestID}, hostToken)
+	require.Equal(t, http.StatusOK, removed.Code, removed.Body.String())
+	deleted := table.do(t, http.MethodDelete, table.path(""), nil, hostToken)
+	require.Equal(t, http.StatusOK, deleted.Code, deleted.Body.String())
+	assert.Nil(t, table.manager.GetGame(table.roomCode))
+}
+
+// startRound seats Bob and Cara next to the host and starts the game, with
+// the host telling the first story. It returns the guests' IDs and every
+// guest's proof of their seat.
+func (table *playTable) startRound(t *testing.T) (bobID, caraID uuid.UUID, proof map[uuid.UUID]map[string]string) {
+	t.Helper()
+	bobID, caraID = uuid.New(), uuid.New()
+	proof = map[uuid.UUID]map[string]string{
+		table.hostID: {"X-Resume-Token": table.resumeToken(t, table.hostID)},
+	}
+	for playerID, name := range map[uuid.UUID]string{bobID: "Bob", caraID: "Cara"} {
+		joined := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_id": playerID, "player_name": name}, nil)
+		require.Equal(t, http.StatusOK, joined.Code, joined.Body.String())
+		proof[playerID] = map[string]string{"X-Resume-Token": joined.Header().Get("X-Resume-Token")}
+	}
+	started := table.do(t, http.MethodPost, table.path("/start"), nil, proof[table.hostID])
+	require.Equal(t, http.StatusOK, started.Code, started.Body.String())
+
+	liveGame := table.manager.GetGame(table.roomCode)
+	liveGame.Lock()
+	liveGame.CurrentRound.StorytellerID = table.hostID
+	liveGame.Unlock()
+	return bobID, caraID, proof
+}
+
+// firstCard returns the first card in a player's hand
+func (table *playTable) firstCard(t *testing.T, playerID uuid.UUID) int {
+	t.Helper()
+	liveGame := table.manager.GetGame(table.roomCode)
+	liveGame.Lock()
+	defer liveGame.Unlock()
+	require.NotEmpty(t, liveGame.Players[playerID].Hand)
+	return liveGame.Players[playerID].Hand[0]
+}
+
+func (table *playTable) roundStatus() models.RoundStatus {
+	liveGame := table.manager.GetGame(table.roomCode)
+	liveGame.Lock()
+	defer liveGame.Unlock()
+	return liveGame.CurrentRound.Status
+}
+
+func TestGameActionsNeedProofOfTheSeat(t *testing.T) {
+	table := newPlayTable(t)
+	bobID, _, proof := table.startRound(t)
+	clue := gin.H{"player_id": table.hostID, "clue": "a quiet storm", "card_id": table.firstCard(t, table.hostID)}
+
+	// Everyone at the table sees the storyteller's ID
+	bare := table.do(t, http.MethodPost, table.path("/clue"), clue, nil)
+	assert.Equal(t, http.StatusForbidden, bare.Code, bare.Body.String())
+
+	// A guest's own resume token doesn't let them act for another player
+	impersonated := table.do(t, http.MethodPost, table.path("/clue"), clue, proof[bobID])
+	assert.Equal(t, http.StatusForbidden, impersonated.Code, impersonated.Body.String())
+	assert.Equal(t, models.RoundStatusStorytelling, table.roundStatus())
+
+	given := table.do(t, http.MethodPost, table.path("/clue"), clue, proof[table.hostID])
+	require.Equal(t, http.StatusOK, given.Code, given.Body.String())
+	assert.Equal(t, models.RoundStatusSubmitting, table.roundStatus())
+}
+
+func TestLeaveGameNeedsProofOfTheSeat(t *testing.T) {
+	table := newPlayTable(t)
+	joined := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_id": uuid.New(), "player_name": "Bob"}, nil)
+	require.Equal(t, http.StatusOK, joined.Code, joined.Body.String())
+
+	// Naming the host would otherwise hand the room to someone else
+	leave := gin.H{"room_code": table.roomCode, "player_id": table.hostID}
+	bare := table.do(t, http.MethodPost, "/api/v1/games/leave", leave, nil)
+	assert.Equal(t, http.StatusForbidden, bare.Code, bare.Body.String())
+	assert.Equal(t, 2, seated(table.manager, table.roomCode))
+
+	left := table.do(t, http.MethodPost, "/api/v1/games/leave", leave,
+		map[string]string{"X-Resume-Token": table.resumeToken(t, table.hostID)})
+	require.Equal(t, http.StatusOK, left.Code, left.Body.String())
+	assert.Equal(t, 1, seated(table.manager, table.roomCode))
+}
+
+func TestLiveGameStateNeedsProofOfTheSeat(t *testing.T) {
+	table := newPlayTable(t)
+	bobID, _, proof := table.startRound(t)
+
+	// The state carries the caller's hand, so naming Bob isn't enough to see it
+	bare := table.do(t, http.MethodGet, table.path("/state?player_id="+bobID.String()), nil, nil)
+	assert.Equal(t, http.StatusForbidden, bare.Code, bare.Body.String())
+
+	proven := table.do(t, http.MethodGet, table.path("/state"), nil, proof[bobID])
+	assert.Equal(t, http.StatusOK, proven.Code, proven.Body.String())
+}
+
+func TestSignedInPlayerActsForTheirOwnSeat(t *testing.T) {
+	table := newPlayTable(t)
+	userID := uuid.New()
+	withUsers(t, userID)
+	signedIn := map[string]string{"Authorization": "Bearer " + table.userToken(t, userID)}
+
+	joined := table.do(t, http.MethodPost, table.path("/join"), gin.H{"player_name": "Dana"}, signedIn)
+	require.Equal(t, http.StatusOK, joined.Code, joined.Body.String())
+	bobID, _, proof := table.startRound(t)
+
+	// A player ID in the body is ignored for signed-in players
+	clue := gin.H{"player_id": table.hostID, "clue": "a quiet storm", "card_id": table.firstCard(t, table.hostID)}
+	refused := table.do(t, http.MethodPost, table.path("/clue"), clue, signedIn)
+	assert.Equal(t, http.StatusBadRequest, refused.Code, "Dana is not the storyteller")
+
+	given := table.do(t, http.MethodPost, table.path("/clue"), clue, proof[table.hostID])
+	require.Equal(t, http.StatusOK, given.Code, given.Body.String())
+	submitted := table.do(t, http.MethodPost, table.path("/cards"), gin.H{"player_id": bobID, "card_id": table.firstCard(t, userID)}, signedIn)
+	require.Equal(t, http.StatusOK, submitted.Code, submitted.Body.String())
+
+	liveGame := table.manager.GetGame(table.roomCode)
+	liveGame.Lock()
+	defer liveGame.Unlock()
+	assert.Contains(t, liveGame.CurrentRound.Submissions, userID)
+	assert.NotContains(t, liveGame.CurrentRound.Submissions, bobID)
+}
+
+// brokenStorage is a game service whose card submissions fail in storage
+type brokenStorage struct {
+	*game.Manager
+}
+
+func (brokenStorage) SubmitCard(roomCode string, playerID uuid.UUID, cardID int) error {
+	return fmt.Errorf("failed to persist card submission: %w", errors.New("dial tcp 10.0.0.5:5432: connection refused"))
+}
+
+func TestGameActionErrorResponses(t *testing.T) {
+	table := newPlayTableWith(t, func(manager *game.Manager) game.FullGameService { return brokenStorage{manager} })
+	registeredID := uuid.New()
+	withUsers(t, registeredID)
+	bobID, caraID, proof := table.startRound(t)
+	elsewhere, _, err := table.jwt.GenerateResumeToken(bobID, "NOSUCH")
+	require.NoError(t, err)
+
+	clue := func(text string) gin.H {
+		return gin.H{"clue": text, "card_id": table.firstCard(t, table.hostID)}
+	}
+	cases := []struct {
+		name    string
+		method  string
+		path    string
+		body    gin.H
+		headers map[string]string
+		status  int
+		code    string
+	}{
+		{"no player ID", http.MethodPost, table.path("/clue"), clue("storm"), nil, http.StatusBadRequest, ""},
+		{"malformed player ID", http.MethodPost, table.path("/clue"), gin.H{"player_id": "nope", "clue": "storm", "card_id": 1}, nil, http.StatusBadRequest, ""},
+		{"guest claiming a registered player's ID", http.MethodPost, table.path("/clue"), gin.H{"player_id": registeredID, "clue": "storm", "card_id": 1}, nil, http.StatusForbidden, ""},
+		{"resume token for another room", http.MethodPost, table.path("/clue"), clue("storm"), map[string]string{"X-Resume-Token": elsewhere}, http.StatusForbidden, ""},
+		{"unknown room", http.MethodPost, "/api/v1/games/NOSUCH/clue", clue("storm"), map[string]string{"X-Resume-Token": elsewhere}, http.StatusNotFound, ""},
+		{"rule broken", http.MethodPost, table.path("/clue"), clue(strings.Repeat("storm ", 50)), proof[table.hostID], http.StatusBadRequest, game.ErrCodeClueTooLong},
+		{"out of turn", http.MethodPost, table.path("/clue"), clue("storm"), proof[caraID], http.StatusBadRequest, ""},
+		{"settings once started", http.MethodPut, table.path("/settings"), gin.H{"settings": game.DefaultGameSettings()}, proof[table.hostID], http.StatusBadRequest, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := table.do(t, tc.method, tc.path, tc.body, tc.headers)
+			assert.Equal(t, tc.status, recorder.Code, recorder.Body.String())
+			if tc.code != "" {
+				assert.Equal(t, tc.code, errorCode(t, recorder))
+			}
+		})
+	}
+
+	t.Run("storage failure", func(t *testing.T) {
+		given := table.do(t, http.MethodPost, table.path("/clue"), clue("storm"), proof[table.hostID])
+		require.Equal(t, http.StatusOK, given.Code, given.Body.String())
+
+		recorder := table.do(t, http.MethodPost, table.path("/cards"), gin.H{"card_id": table.firstCard(t, bobID)}, proof[bobID])
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.NotContains(t, recorder.Body.String(), "10.0.0.5", "storage details stay in the logs")
+	})
+
+	t.Run("game rule conflict", func(t *testing.T) {
+		// Bob and Cara's cards are on the table; Bob votes for his own
+		liveGame := table.manager.GetGame(table.roomCode)
+		liveGame.Lock()
+		bobCard := liveGame.Players[bobID].Hand[0]
+		liveGame.Unlock()
+		for _, playerID := range []uuid.UUID{bobID, caraID} {
+			require.NoError(t, table.manager.SubmitCard(table.roomCode, playerID, table.firstCard(t, playerID)))
+		}
+		require.Equal(t, models.RoundStatusVoting, table.roundStatus())
+
+		recorder := table.do(t, http.MethodPost, table.path("/votes"), gin.H{"card_id": bobCard}, proof[bobID])
+		assert.Equal(t, http.StatusConflict, recorder.Code, recorder.Body.String())
+		assert.Equal(t, game.ErrCodeSelfVote, errorCode(t, recorder))
+	})
+}
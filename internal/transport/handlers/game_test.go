@@ -0,0 +1,237 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/game"
+	"dixitme/internal/testutils/testdb"
+	"dixitme/internal/transport/handlers"
+	"dixitme/internal/transport/versioning"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingBots is a game service whose AddBot fails after working ok times
+type failingBots struct {
+	*game.Manager
+	ok int
+}
+
+func (s *failingBots) AddBot(roomCode, botLevel string) (*game.GameState, error) {
+	if s.ok == 0 {
+		return nil, errors.New("no bot available")
+	}
+	s.ok--
+	return s.Manager.AddBot(roomCode, botLevel)
+}
+
+// creationTable serves room creation from a game service
+func creationTable(t *testing.T, service game.FullGameService) *playTable {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	jwtService := auth.NewJWTService("test-secret")
+	gameHandlers := handlers.NewGameHandlers(handlers.NewHandlerDependencies(nil, service, jwtService))
+
+	router := gin.New()
+	games := router.Group("/api/v1/games", versioning.Middleware(versioning.V1), auth.GuestOrAuth(jwtService))
+	games.POST("", gameHandlers.CreateGame)
+	return &playTable{router: router, jwt: jwtService}
+}
+
+func createRoom(t *testing.T, table *playTable, roomCode string, bots int, rules *game.GameRules) int {
+	t.Helper()
+	body := gin.H{"room_code": roomCode, "player_id": uuid.New(), "player_name": "Alice", "bots": bots, "bot_level": "easy", "sandbox": true}
+	if rules != nil {
+		body["rules"] = rules
+	}
+	recorder := table.do(t, http.MethodPost, "/api/v1/games", body, nil)
+	return recorder.Code
+}
+
+func seated(manager *game.Manager, roomCode string) int {
+	liveGame := manager.GetGame(roomCode)
+	if liveGame == nil {
+		return 0
+	}
+	liveGame.Lock()
+	defer liveGame.Unlock()
+	return len(liveGame.Players)
+}
+
+func TestCreateGameBotCapFollowsSeatsAndBotLimits(t *testing.T) {
+	manager := game.NewEphemeralManager()
+	table := creationTable(t, manager)
+
+	// Every seat but the creator's, at the standard six seats
+	assert.Equal(t, http.StatusCreated, createRoom(t, table, "BOTSA", 5, nil))
+	assert.Equal(t, 6, seated(manager, "BOTSA"))
+	assert.Equal(t, http.StatusBadRequest, createRoom(t, table, "BOTSB", 6, nil))
+	assert.Nil(t, manager.GetGame("BOTSB"))
+
+	// Smaller tables seat fewer
+	rules := game.DefaultGameRules()
+	rules.MaxPlayers = 4
+	assert.Equal(t, http.StatusBadRequest, createRoom(t, table, "BOTSC", 4, &rules))
+	assert.Equal(t, http.StatusCreated, createRoom(t, table, "BOTSD", 3, &rules))
+
+	// The deployment's bot limits apply too, above and below six seats
+	manager.SetBotLimits(game.BotLimits{MaxBots: 7, MinHumans: 1})
+	rules.MaxPlayers = 8
+	assert.Equal(t, http.StatusCreated, createRoom(t, table, "BOTSF", 7, &rules))
+	assert.Equal(t, 8, seated(manager, "BOTSF"))
+
+	manager.SetBotLimits(game.BotLimits{MaxBots: 2, MinHumans: 1})
+	assert.Equal(t, http.StatusBadRequest, createRoom(t, table, "BOTSG", 3, nil))
+	assert.Equal(t, http.StatusCreated, createRoom(t, table, "BOTSH", 2, nil))
+}
+
+func TestCreateGameRemovesRoomWhenBotsCannotBeSeated(t *testing.T) {
+	manager := game.NewEphemeralManager()
+	table := creationTable(t, &failingBots{Manager: manager, ok: 2})
+
+	assert.Equal(t, http.StatusBadRequest, createRoom(t, table, "HALF", 3, nil))
+	assert.Nil(t, manager.GetGame("HALF"), "a room missing its bots is removed again")
+
+	// The room code is free again
+	table = creationTable(t, manager)
+	assert.Equal(t, http.StatusCreated, createRoom(t, table, "HALF", 3, nil))
+	assert.Equal(t, 4, seated(manager, "HALF"))
+}
+
+// myGamesTable serves the caller's game list over a database where Dana has
+// a seat in rooms of every state, most recently updated first: LIVE (running
+// on this server), WAIT, PLAY, DONE, QUIT and the deleted GONE. Bob only sits
+// in OTHER.
+type myGamesTable struct {
+	*playTable
+	danaID uuid.UUID
+	rooms  map[string]uuid.UUID
+}
+
+func newMyGamesTable(t *testing.T) *myGamesTable {
+	t.Helper()
+	table := &myGamesTable{playTable: newPlayTable(t), danaID: uuid.New(), rooms: make(map[string]uuid.UUID)}
+	gameHandlers := handlers.NewGameHandlers(handlers.NewHandlerDependencies(nil, table.manager, table.jwt))
+	table.router.GET("/api/v1/me/games", auth.GuestOrAuth(table.jwt), gameHandlers.GetMyGames)
+
+	db := testdb.Open(t, &models.Game{}, &models.Player{}, &models.GamePlayer{})
+	bobID := uuid.New()
+	require.NoError(t, db.Create(&models.Player{ID: table.danaID, Name: "Dana"}).Error)
+	require.NoError(t, db.Create(&models.Player{ID: bobID, Name: "Bob"}).Error)
+
+	seats := []struct {
+		roomCode string
+		status   models.GameStatus
+		playerID uuid.UUID
+		score    int
+	}{
+		{"LIVE", models.GameStatusWaiting, table.danaID, 0},
+		{"WAIT", models.GameStatusWaiting, table.danaID, 0},
+		{"PLAY", models.GameStatusInProgress, table.danaID, 4},
+		{"DONE", models.GameStatusCompleted, table.danaID, 30},
+		{"QUIT", models.GameStatusAbandoned, table.danaID, 7},
+		{"GONE", models.GameStatusCompleted, table.danaID, 12},
+		{"OTHER", models.GameStatusInProgress, bobID, 3},
+	}
+	now := time.Now()
+	for i, seat := range seats {
+		gameID := uuid.New()
+		table.rooms[seat.roomCode] = gameID
+		updated := now.Add(-time.Duration(i) * time.Hour)
+		require.NoError(t, db.Create(&models.Game{ID: gameID, RoomCode: seat.roomCode, Status: seat.status, CreatedAt: updated, UpdatedAt: updated}).Error)
+		require.NoError(t, db.Create(&models.GamePlayer{ID: uuid.New(), GameID: gameID, PlayerID: seat.playerID, Score: seat.score, IsActive: true}).Error)
+	}
+	require.NoError(t, db.Delete(&models.Game{}, "room_code = ?", "GONE").Error)
+
+	previous := database.GetDB()
+	database.SetDB(db)
+	t.Cleanup(func() { database.SetDB(previous) })
+
+	// LIVE is running here, with a score the database hasn't caught up with
+	liveGame, err := table.manager.CreateGameWithOptions("LIVE", table.danaID, "Dana", game.CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+	liveGame.Lock()
+	liveGame.ID = table.rooms["LIVE"]
+	liveGame.Players[table.danaID].Score = 5
+	liveGame.Unlock()
+	return table
+}
+
+func (table *myGamesTable) list(t *testing.T, query string) handlers.MyGamesResponse {
+	t.Helper()
+	recorder := table.do(t, http.MethodGet, "/api/v1/me/games"+query, nil,
+		map[string]string{"Authorization": "Bearer " + table.userToken(t, table.danaID)})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var response handlers.MyGamesResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return response
+}
+
+func roomCodes(games []handlers.MyGame) []string {
+	codes := make([]string, len(games))
+	for i, entry := range games {
+		codes[i] = entry.RoomCode
+	}
+	return codes
+}
+
+func TestMyGamesListsOwnSeatsNewestFirst(t *testing.T) {
+	table := newMyGamesTable(t)
+
+	response := table.list(t, "")
+	assert.Equal(t, int64(5), response.Total)
+	assert.Equal(t, []string{"LIVE", "WAIT", "PLAY", "DONE", "QUIT"}, roomCodes(response.Games))
+
+	live, waiting := response.Games[0], response.Games[1]
+	assert.True(t, live.IsLive)
+	assert.Equal(t, 5, live.Score, "the running game wins over the stored score")
+	assert.Equal(t, 1, live.PlayerCount)
+	assert.False(t, waiting.IsLive)
+	assert.Equal(t, 30, response.Games[3].Score)
+}
+
+func TestMyGamesFiltersAndPages(t *testing.T) {
+	table := newMyGamesTable(t)
+
+	assert.Equal(t, []string{"DONE", "QUIT"}, roomCodes(table.list(t, "?status=completed").Games))
+	assert.Equal(t, []string{"PLAY"}, roomCodes(table.list(t, "?status=active").Games))
+	assert.Equal(t, []string{"LIVE", "WAIT"}, roomCodes(table.list(t, "?status=waiting").Games))
+
+	page := table.list(t, "?limit=2&page=2")
+	assert.Equal(t, int64(5), page.Total)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, []string{"PLAY", "DONE"}, roomCodes(page.Games))
+
+	recorder := table.do(t, http.MethodGet, "/api/v1/me/games?status=lost", nil,
+		map[string]string{"Authorization": "Bearer " + table.userToken(t, table.danaID)})
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestMyGamesNeedsSignIn(t *testing.T) {
+	table := newMyGamesTable(t)
+
+	recorder := table.do(t, http.MethodGet, "/api/v1/me/games", nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestMyGamesIgnoresReusedRoomCode(t *testing.T) {
+	table := newMyGamesTable(t)
+
+	// A new room took WAIT's code after the stored game ended
+	_, err := table.manager.CreateGameWithOptions("WAIT", uuid.New(), "Eve", game.CreateGameOptions{Sandbox: true})
+	require.NoError(t, err)
+
+	waiting := table.list(t, "?status=waiting").Games
+	require.Len(t, waiting, 2)
+	assert.False(t, waiting[1].IsLive)
+}
@@ -1,14 +1,23 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"dixitme/internal/database"
 	"dixitme/internal/models"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/game"
+	"dixitme/internal/services/namepolicy"
+	"dixitme/internal/services/notify"
+	"dixitme/internal/services/presence"
+	"dixitme/internal/services/readmodel"
+	"dixitme/internal/transport/validation"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // PlayerHandlers handles player-related HTTP requests
@@ -34,14 +43,19 @@ func NewPlayerHandlers(deps *HandlerDependencies) *PlayerHandlers {
 // @Router /players [post]
 func CreatePlayer(c *gin.Context) {
 	var req CreatePlayerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	name, err := namepolicy.Check(req.Name)
+	if err != nil {
+		respondNameError(c, err, "name")
 		return
 	}
 
 	player := &models.Player{
 		ID:   uuid.New(),
-		Name: req.Name,
+		Name: name,
 	}
 
 	db := database.GetDB()
@@ -53,6 +67,314 @@ func CreatePlayer(c *gin.Context) {
 	c.JSON(http.StatusCreated, CreatePlayerResponse{Player: player})
 }
 
+// RenamePlayer changes the caller's own player name
+// @Summary Rename player
+// @Description Change the caller's player name. Seats in rooms that are already open keep the old name until the player rejoins.
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param id path string true "Player ID" format(uuid)
+// @Param player body RenamePlayerRequest true "New name"
+// @Success 200 {object} CreatePlayerResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /players/{id}/name [put]
+func RenamePlayer(c *gin.Context) {
+	playerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+		return
+	}
+
+	var req RenamePlayerRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	callerID, err := actingPlayerID(c, req.PlayerID)
+	if err != nil {
+		respondActingPlayerError(c, err)
+		return
+	}
+	if callerID != playerID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only rename yourself"})
+		return
+	}
+
+	name, err := namepolicy.Check(req.Name)
+	if err != nil {
+		respondNameError(c, err, "name")
+		return
+	}
+
+	db := database.GetDB()
+	var player models.Player
+	if err := db.First(&player, "id = ?", playerID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+		return
+	}
+	if player.Type != models.PlayerTypeHuman {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only human players can be renamed"})
+		return
+	}
+
+	if err := db.Model(&player).Update("name", name).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rename player"})
+		return
+	}
+
+	c.JSON(http.StatusOK, CreatePlayerResponse{Player: &player})
+}
+
+// GetPlayerTokens lists the colors and avatars players are shown with in rooms
+// @Summary List player tokens
+// @Description List the color and avatar tokens assigned to players in rooms, in assignment order
+// @Tags players
+// @Produce json
+// @Success 200 {array} game.PlayerToken
+// @Router /player-tokens [get]
+func GetPlayerTokens(c *gin.Context) {
+	c.JSON(http.StatusOK, game.PlayerTokens)
+}
+
+// SetPreferredToken saves the token a registered account asks for in rooms
+// @Summary Set preferred player token
+// @Description Save the player token to ask for when joining rooms. It is used whenever no one in the room holds it yet. An empty token clears the preference.
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param token body SetPreferredTokenRequest true "Preferred token"
+// @Success 200 {object} PreferredTokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/preferred-token [put]
+func SetPreferredToken(c *gin.Context) {
+	userInfo, exists := auth.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	if userInfo.UserID == nil || *userInfo.UserID == uuid.Nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Create an account to save a preferred token"})
+		return
+	}
+
+	var req SetPreferredTokenRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	if req.Token != "" && !game.IsPlayerToken(req.Token) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown player token"})
+		return
+	}
+
+	db := database.GetDB()
+	if err := db.Model(&models.User{}).Where("id = ?", *userInfo.UserID).Update("preferred_token", req.Token).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save preferred token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, PreferredTokenResponse{Token: req.Token})
+}
+
+// GetNotificationPreferences returns the account's time zone and quiet hours
+// @Summary Get notification preferences
+// @Description Get the time zone and quiet hours notifications respect. Only urgent account mail is sent during quiet hours; the rest waits until they end.
+// @Tags players
+// @Produce json
+// @Success 200 {object} notify.Preferences
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/notification-preferences [get]
+func GetNotificationPreferences(c *gin.Context) {
+	userInfo, exists := auth.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	if userInfo.UserID == nil || *userInfo.UserID == uuid.Nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Create an account to receive notifications"})
+		return
+	}
+
+	var user models.User
+	if err := database.GetDB().First(&user, "id = ?", *userInfo.UserID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load notification preferences"})
+		return
+	}
+	c.JSON(http.StatusOK, notify.PreferencesOf(&user))
+}
+
+// SetNotificationPreferences saves the account's time zone and quiet hours
+// @Summary Set notification preferences
+// @Description Save the IANA time zone (e.g. Europe/Paris) and quiet hours (HH:MM, in that time zone) notifications respect. Quiet hours may span midnight; leave both empty for none.
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param preferences body notify.Preferences true "Notification preferences"
+// @Success 200 {object} notify.Preferences
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/notification-preferences [put]
+func SetNotificationPreferences(c *gin.Context) {
+	userInfo, exists := auth.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	if userInfo.UserID == nil || *userInfo.UserID == uuid.Nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Create an account to receive notifications"})
+		return
+	}
+
+	var req notify.Preferences
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := database.GetDB().Model(&models.User{}).Where("id = ?", *userInfo.UserID).Updates(map[string]interface{}{
+		"time_zone":         req.TimeZone,
+		"quiet_hours_start": req.QuietHoursStart,
+		"quiet_hours_end":   req.QuietHoursEnd,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save notification preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, req)
+}
+
+// PresenceRequest chooses how the account appears to others
+type PresenceRequest struct {
+	Mode string `json:"mode" binding:"required,oneof=online do_not_disturb invisible"`
+}
+
+// PresenceResponse is the account's presence mode
+type PresenceResponse struct {
+	Mode presence.Mode `json:"mode"`
+}
+
+// GetPresence returns the account's presence mode
+// @Summary Get presence mode
+// @Description Get how the account appears to others: online, do_not_disturb (visible, but non-urgent notifications are dropped) or invisible (appears offline).
+// @Tags players
+// @Produce json
+// @Success 200 {object} PresenceResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/presence [get]
+func GetPresence(c *gin.Context) {
+	userID, ok := registeredUserID(c, "Create an account to set a presence mode")
+	if !ok {
+		return
+	}
+
+	var user models.User
+	if err := database.GetDB().First(&user, "id = ?", userID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load presence"})
+		return
+	}
+	c.JSON(http.StatusOK, PresenceResponse{Mode: presence.Of(&user)})
+}
+
+// SetPresence saves the account's presence mode
+// @Summary Set presence mode
+// @Description Choose online, do_not_disturb or invisible. Do not disturb drops non-urgent notifications until it is turned off; invisible hides the account from others' online lists.
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param presence body PresenceRequest true "Presence mode"
+// @Success 200 {object} PresenceResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/presence [put]
+func SetPresence(c *gin.Context) {
+	userID, ok := registeredUserID(c, "Create an account to set a presence mode")
+	if !ok {
+		return
+	}
+
+	var req PresenceRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	mode := presence.Mode(req.Mode)
+
+	if err := database.GetDB().Model(&models.User{}).Where("id = ?", userID).Update("presence", string(mode)).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save presence"})
+		return
+	}
+	c.JSON(http.StatusOK, PresenceResponse{Mode: mode})
+}
+
+// registeredUserID is the signed-in account, responding 401 or 403 (with
+// guestMessage) when there is none
+func registeredUserID(c *gin.Context, guestMessage string) (uuid.UUID, bool) {
+	userInfo, exists := auth.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return uuid.Nil, false
+	}
+	if userInfo.UserID == nil || *userInfo.UserID == uuid.Nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": guestMessage})
+		return uuid.Nil, false
+	}
+	return *userInfo.UserID, true
+}
+
+// GetMyRankedStanding returns how recent ranked forfeits limit the player's ranked play
+// @Summary Get my ranked standing
+// @Description Ranked forfeits count against a player for 30 days. Two bring a warning, three a 30 minute cooldown, four a day-long ranked ban and six a week-long one, counted from the latest forfeit. Forfeits waived on appeal don't count.
+// @Tags players
+// @Produce json
+// @Success 200 {object} game.RankedStanding
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/ranked-standing [get]
+func GetMyRankedStanding(c *gin.Context) {
+	userInfo, exists := auth.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	standing, err := game.GetManager().GetRankedStanding(c.Request.Context(), userInfo.PlayerID())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load ranked standing"})
+		return
+	}
+	c.JSON(http.StatusOK, standing)
+}
+
+// respondNameError replies to a name rejected by the name policy, naming the offending field
+func respondNameError(c *gin.Context, err error, field string) {
+	var nameErr *namepolicy.PolicyError
+	if errors.As(err, &nameErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": nameErr.Message, "code": nameErr.Code, "field": field})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
 // GetPlayer gets a player by ID
 // @Summary Get player by ID
 // @Description Get player information by player ID
@@ -102,11 +424,14 @@ func GetPlayerStats(c *gin.Context) {
 		return
 	}
 
-	db := database.GetDB()
+	ctx := c.Request.Context()
+	db := database.Reader(ctx)
 
-	// Check if player exists
+	// Check if player exists; guests may have been created a moment ago
 	var player models.Player
-	if err := db.First(&player, "id = ?", playerID).Error; err != nil {
+	if err := database.ReadWithFallback(ctx, func(db *gorm.DB) error {
+		return db.First(&player, "id = ?", playerID).Error
+	}); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
 		return
 	}
@@ -139,6 +464,10 @@ func GetPlayerStats(c *gin.Context) {
 	var gamesAsStoryteller int64
 	db.Model(&models.GameRound{}).Where("storyteller_id = ?", playerID).Count(&gamesAsStoryteller)
 
+	// Players without a ranked result yet sit at the starting rating
+	rating := models.PlayerRating{Rating: models.DefaultRating}
+	db.Where("player_id = ?", playerID).Limit(1).Find(&rating)
+
 	stats := PlayerStatsResponse{
 		PlayerID:           playerID.String(),
 		TotalGames:         totalGames,
@@ -148,6 +477,9 @@ func GetPlayerStats(c *gin.Context) {
 		TotalScore:         int64(totalScore),
 		FavoriteRole:       "storyteller", // Simplified
 		GamesAsStoryteller: gamesAsStoryteller,
+		Rating:             rating.Rating,
+		RankedGames:        rating.RankedGames,
+		RankedForfeits:     rating.Forfeits,
 	}
 
 	c.JSON(http.StatusOK, stats)
@@ -155,7 +487,7 @@ func GetPlayerStats(c *gin.Context) {
 
 // GetGameHistory gets the game history for a specific player
 // @Summary Get player's game history
-// @Description Get a list of games played by a specific player
+// @Description Get a page of the games a player finished, most recent first, from the player game stats read model
 // @Tags players
 // @Accept json
 // @Produce json
@@ -186,31 +518,19 @@ func GetGameHistory(c *gin.Context) {
 	}
 	offset := (page - 1) * limit
 
-	db := database.GetDB()
+	ctx := c.Request.Context()
+	db := database.Reader(ctx)
 
-	// Check if player exists
+	// Check if player exists; guests may have been created a moment ago
 	var player models.Player
-	if err := db.First(&player, "id = ?", playerID).Error; err != nil {
+	if err := database.ReadWithFallback(ctx, func(db *gorm.DB) error {
+		return db.First(&player, "id = ?", playerID).Error
+	}); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
 		return
 	}
 
-	// Get total count
-	var total int64
-	db.Model(&models.GameHistory{}).
-		Joins("JOIN game_players gp ON gp.game_id = game_histories.game_id").
-		Where("gp.player_id = ?", playerID).
-		Count(&total)
-
-	// Get game history with pagination
-	var gameHistories []models.GameHistory
-	err = db.Preload("Game").Preload("Winner").
-		Joins("JOIN game_players gp ON gp.game_id = game_histories.game_id").
-		Where("gp.player_id = ?", playerID).
-		Order("game_histories.created_at DESC").
-		Limit(limit).Offset(offset).
-		Find(&gameHistories).Error
-
+	gameHistories, total, err := readmodel.PlayerHistory(ctx, db, playerID, limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch game history"})
 		return
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"dixitme/internal/database"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/game"
+	"dixitme/internal/services/namepolicy"
+	"dixitme/internal/services/roomtemplate"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ListRoomTemplates returns the authenticated user's room templates
+// @Summary List my room templates
+// @Description List the room setups the authenticated user has saved, in name order
+// @Tags games
+// @Produce json
+// @Success 200 {object} RoomTemplatesResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot save templates"
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/room-templates [get]
+func (h *GameHandlers) ListRoomTemplates(c *gin.Context) {
+	userID, ok := templateOwner(c)
+	if !ok {
+		return
+	}
+
+	templates, err := roomtemplate.List(c.Request.Context(), database.GetDB(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch room templates"})
+		return
+	}
+	c.JSON(http.StatusOK, RoomTemplatesResponse{Templates: templates})
+}
+
+// GetRoomTemplate returns one of the authenticated user's room templates
+// @Summary Get a room template
+// @Description Get one of the authenticated user's saved room setups
+// @Tags games
+// @Produce json
+// @Param template_id path string true "Template ID" format(uuid)
+// @Success 200 {object} roomtemplate.Template
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot save templates"
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/room-templates/{template_id} [get]
+func (h *GameHandlers) GetRoomTemplate(c *gin.Context) {
+	userID, ok := templateOwner(c)
+	if !ok {
+		return
+	}
+	templateID, ok := templateIDParam(c)
+	if !ok {
+		return
+	}
+
+	template, err := roomtemplate.Get(c.Request.Context(), database.GetDB(), userID, templateID)
+	if err != nil {
+		respondRoomTemplateError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// CreateRoomTemplate saves a room setup for the authenticated user
+// @Summary Save a room template
+// @Description Save a named combination of lobby settings and bot fill to create rooms from later. Omitted settings take their defaults.
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param template body RoomTemplateRequest true "Template"
+// @Success 201 {object} roomtemplate.Template
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot save templates"
+// @Failure 409 {object} map[string]interface{} "Name taken or too many templates"
+// @Security BearerAuth
+// @Router /me/room-templates [post]
+func (h *GameHandlers) CreateRoomTemplate(c *gin.Context) {
+	userID, ok := templateOwner(c)
+	if !ok {
+		return
+	}
+	req, ok := bindRoomTemplate(c)
+	if !ok {
+		return
+	}
+
+	template, err := roomtemplate.Create(c.Request.Context(), database.GetDB(), userID, req)
+	if err != nil {
+		respondRoomTemplateError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateRoomTemplate replaces one of the authenticated user's room templates
+// @Summary Update a room template
+// @Description Replace the name, settings and bot fill of a saved room setup. Omitted settings take their defaults.
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param template_id path string true "Template ID" format(uuid)
+// @Param template body RoomTemplateRequest true "Template"
+// @Success 200 {object} roomtemplate.Template
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot save templates"
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{} "Name taken"
+// @Security BearerAuth
+// @Router /me/room-templates/{template_id} [put]
+func (h *GameHandlers) UpdateRoomTemplate(c *gin.Context) {
+	userID, ok := templateOwner(c)
+	if !ok {
+		return
+	}
+	templateID, ok := templateIDParam(c)
+	if !ok {
+		return
+	}
+	req, ok := bindRoomTemplate(c)
+	if !ok {
+		return
+	}
+
+	template, err := roomtemplate.Update(c.Request.Context(), database.GetDB(), userID, templateID, req)
+	if err != nil {
+		respondRoomTemplateError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteRoomTemplate removes one of the authenticated user's room templates
+// @Summary Delete a room template
+// @Description Delete a saved room setup. Rooms already created from it are not affected.
+// @Tags games
+// @Param template_id path string true "Template ID" format(uuid)
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot save templates"
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /me/room-templates/{template_id} [delete]
+func (h *GameHandlers) DeleteRoomTemplate(c *gin.Context) {
+	userID, ok := templateOwner(c)
+	if !ok {
+		return
+	}
+	templateID, ok := templateIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := roomtemplate.Delete(c.Request.Context(), database.GetDB(), userID, templateID); err != nil {
+		respondRoomTemplateError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateGameFromTemplate creates a room with a saved template's settings and bots
+// @Summary Create game from template
+// @Description Create a room with the settings of one of the authenticated user's templates, seating its bots straight away. Responds like POST /games.
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param template_id path string true "Template ID" format(uuid)
+// @Param game body CreateGameFromTemplateRequest false "Room code and display name"
+// @Success 201 {object} CreateGameResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Guests cannot save templates"
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{} "Room code taken or bot limit reached"
+// @Failure 503 {object} map[string]interface{} "Server at capacity, retry after the Retry-After header"
+// @Security BearerAuth && Scopes[play]
+// @Router /games/from-template/{template_id} [post]
+func (h *GameHandlers) CreateGameFromTemplate(c *gin.Context) {
+	userID, ok := templateOwner(c)
+	if !ok {
+		return
+	}
+	templateID, ok := templateIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req CreateGameFromTemplateRequest
+	if c.Request.ContentLength > 0 {
+		if !validation.BindJSON(c, &req) {
+			return
+		}
+	}
+
+	template, err := roomtemplate.Get(c.Request.Context(), database.GetDB(), userID, templateID)
+	if err != nil {
+		respondRoomTemplateError(c, err)
+		return
+	}
+
+	userInfo, _ := auth.GetUserFromContext(c)
+	playerName := req.PlayerName
+	if playerName == "" {
+		playerName = userInfo.Name
+	}
+	playerName, err = namepolicy.Check(playerName)
+	if err != nil {
+		respondGameActionError(c, err)
+		return
+	}
+
+	opts := game.CreateGameOptions{Sandbox: template.Sandbox, Settings: &template.Settings}
+	h.createRoom(c, req.RoomCode, userInfo.PlayerID(), playerName, template.Bots, template.BotLevel, opts)
+}
+
+// templateOwner returns the registered user templates belong to, responding
+// with an error for guests
+func templateOwner(c *gin.Context) (uuid.UUID, bool) {
+	userInfo, exists := auth.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return uuid.Nil, false
+	}
+	if userInfo.UserID == nil || *userInfo.UserID == uuid.Nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Create an account to save room templates"})
+		return uuid.Nil, false
+	}
+	return *userInfo.UserID, true
+}
+
+func templateIDParam(c *gin.Context) (uuid.UUID, bool) {
+	templateID, err := uuid.Parse(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return uuid.Nil, false
+	}
+	return templateID, true
+}
+
+// bindRoomTemplate reads a template request, with omitted settings at their defaults
+func bindRoomTemplate(c *gin.Context) (roomtemplate.Spec, bool) {
+	req := RoomTemplateRequest{Settings: game.DefaultGameSettings()}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.Respond(c, err)
+		return roomtemplate.Spec{}, false
+	}
+	return roomtemplate.Spec(req), true
+}
+
+func respondRoomTemplateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, roomtemplate.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, roomtemplate.ErrNameTaken), errors.Is(err, roomtemplate.ErrTooMany):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
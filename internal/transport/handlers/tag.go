@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
+	"dixitme/internal/cache"
 	"dixitme/internal/database"
+	"dixitme/internal/logger"
 	"dixitme/internal/models"
+	"dixitme/internal/services/taxonomy"
+	"dixitme/internal/transport/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -30,11 +35,11 @@ func NewTagHandlers(deps *HandlerDependencies) *TagHandlers {
 // @Success 201 {object} models.Tag
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[manage_cards]
 // @Router /tags [post]
 func CreateTag(c *gin.Context) {
 	var req CreateTagRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 
@@ -66,6 +71,7 @@ func CreateTag(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create tag"})
 		return
 	}
+	cache.Invalidate(c.Request.Context(), cache.ScopeTags)
 
 	c.JSON(http.StatusCreated, tag)
 }
@@ -98,3 +104,84 @@ func ListTags(c *gin.Context) {
 	response := ListTagsResponse{Tags: tags}
 	c.JSON(http.StatusOK, response)
 }
+
+// GetTagTree returns the tag hierarchy
+// @Summary Get tag tree
+// @Description Get all active tags arranged as a parent/child hierarchy
+// @Tags admin
+// @Produce json
+// @Success 200 {object} TagTreeResponse
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/tags/tree [get]
+func GetTagTree(c *gin.Context) {
+	tree, err := taxonomy.Load(database.GetDB())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load tag tree"})
+		return
+	}
+
+	c.JSON(http.StatusOK, TagTreeResponse{Tree: tree.Tree()})
+}
+
+// SetTagParent moves a tag under a new parent in the hierarchy
+// @Summary Set tag parent
+// @Description Place a tag under a broader parent tag, or make it a root tag with a null parent
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param tag_id path int true "Tag ID"
+// @Param request body SetTagParentRequest true "New parent"
+// @Success 200 {object} models.Tag
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/tags/{tag_id}/parent [put]
+func SetTagParent(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("tag_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	var req SetTagParentRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	db := database.GetDB()
+
+	var tag models.Tag
+	if err := db.First(&tag, tagID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
+		return
+	}
+
+	if req.ParentID != nil {
+		// Validate against fresh data so concurrent edits can't introduce a cycle
+		taxonomy.Invalidate()
+		tree, err := taxonomy.Load(db)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load tag tree"})
+			return
+		}
+		if err := tree.ValidateParent(tagID, *req.ParentID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := db.Model(&tag).Update("parent_id", req.ParentID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update tag parent"})
+		return
+	}
+	tag.ParentID = req.ParentID
+	taxonomy.Invalidate()
+	// Card filters expand through the hierarchy, so cached card lists are stale too
+	cache.Invalidate(c.Request.Context(), cache.ScopeTags, cache.ScopeCards)
+
+	logger.Info("Tag parent updated", "tag_id", tagID, "parent_id", req.ParentID)
+
+	c.JSON(http.StatusOK, tag)
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+	"dixitme/internal/services/ladder"
+	"dixitme/internal/transport/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TournamentHandlers handles tournament and ladder delivery admin requests
+type TournamentHandlers struct {
+	dispatcher *ladder.Dispatcher
+}
+
+// NewTournamentHandlers creates a new TournamentHandlers instance
+func NewTournamentHandlers(dispatcher *ladder.Dispatcher) *TournamentHandlers {
+	return &TournamentHandlers{dispatcher: dispatcher}
+}
+
+// CreateTournament registers a tournament on an external platform
+// @Summary Create tournament
+// @Description Register a Challonge or Toornament tournament. Games created with its ID report their result to the platform once they complete.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param tournament body CreateTournamentRequest true "Tournament"
+// @Success 201 {object} models.Tournament
+// @Failure 400 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/tournaments [post]
+func (h *TournamentHandlers) CreateTournament(c *gin.Context) {
+	var req CreateTournamentRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	tournament := &models.Tournament{
+		Name:        req.Name,
+		Platform:    req.Platform,
+		ExternalID:  req.ExternalID,
+		APIKey:      req.APIKey,
+		AccessToken: req.AccessToken,
+	}
+	if err := ladder.CreateTournament(c.Request.Context(), database.GetDB(), tournament); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tournament)
+}
+
+// ListTournaments lists every tournament
+// @Summary List tournaments
+// @Description List registered tournaments, newest first. Credentials are never returned.
+// @Tags admin
+// @Produce json
+// @Success 200 {array} models.Tournament
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/tournaments [get]
+func (h *TournamentHandlers) ListTournaments(c *gin.Context) {
+	tournaments, err := ladder.ListTournaments(c.Request.Context(), database.Reader(c.Request.Context()))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tournaments)
+}
+
+// SetParticipants maps players to their participant IDs on the platform
+// @Summary Set tournament participants
+// @Description Map players to their participant IDs on the tournament platform. Results of games with an unmapped player fail until the mapping is added and the delivery retried.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param tournament_id path string true "Tournament ID"
+// @Param participants body SetTournamentParticipantsRequest true "Participant mapping"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/tournaments/{tournament_id}/participants [put]
+func (h *TournamentHandlers) SetParticipants(c *gin.Context) {
+	tournamentID, err := uuid.Parse(c.Param("tournament_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID format"})
+		return
+	}
+
+	var req SetTournamentParticipantsRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	externalIDs := make(map[uuid.UUID]string, len(req.Participants))
+	for rawID, externalID := range req.Participants {
+		playerID, err := uuid.Parse(rawID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID format: " + rawID})
+			return
+		}
+		externalIDs[playerID] = externalID
+	}
+
+	if err := ladder.SetParticipants(c.Request.Context(), database.GetDB(), tournamentID, externalIDs); err != nil {
+		if errors.Is(err, ladder.ErrTournamentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "updated": len(externalIDs)})
+}
+
+// ListDeliveries lists a tournament's result deliveries
+// @Summary List ladder deliveries
+// @Description List the results pushed, or still to be pushed, to a tournament's platform, newest first
+// @Tags admin
+// @Produce json
+// @Param tournament_id path string true "Tournament ID"
+// @Param status query string false "Only deliveries with this status" Enums(pending, delivered, failed)
+// @Param limit query int false "Maximum number of deliveries" default(50)
+// @Success 200 {array} models.LadderDelivery
+// @Failure 400 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/tournaments/{tournament_id}/deliveries [get]
+func (h *TournamentHandlers) ListDeliveries(c *gin.Context) {
+	tournamentID, err := uuid.Parse(c.Param("tournament_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID format"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	deliveries, err := ladder.ListDeliveries(c.Request.Context(), database.GetDB(), tournamentID, c.Query("status"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// RetryDelivery retries a failed or pending delivery straight away
+// @Summary Retry ladder delivery
+// @Description Reset a delivery's attempts and push it to the platform again, e.g. after fixing a participant mapping
+// @Tags admin
+// @Produce json
+// @Param delivery_id path string true "Delivery ID"
+// @Success 200 {object} models.LadderDelivery
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth && Scopes[admin]
+// @Router /admin/ladder-deliveries/{delivery_id}/retry [post]
+func (h *TournamentHandlers) RetryDelivery(c *gin.Context) {
+	deliveryID, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID format"})
+		return
+	}
+
+	delivery, err := h.dispatcher.Retry(c.Request.Context(), deliveryID)
+	if err != nil {
+		if errors.Is(err, ladder.ErrDeliveryNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
@@ -1,8 +1,22 @@
 package handlers
 
 import (
-	"dixitme/internal/models"
 	"time"
+
+	"dixitme/internal/events"
+	"dixitme/internal/models"
+	"dixitme/internal/services/activity"
+	"dixitme/internal/services/cardimages"
+	"dixitme/internal/services/clues"
+	"dixitme/internal/services/customdeck"
+	"dixitme/internal/services/deckpack"
+	"dixitme/internal/services/experiments"
+	"dixitme/internal/services/game"
+	"dixitme/internal/services/roomtemplate"
+	"dixitme/internal/services/taxonomy"
+	"dixitme/internal/startup"
+
+	"github.com/google/uuid"
 )
 
 // Player related types
@@ -10,6 +24,20 @@ type CreatePlayerRequest struct {
 	Name string `json:"name" binding:"required"`
 }
 
+type RenamePlayerRequest struct {
+	Name     string `json:"name" binding:"required"`
+	PlayerID string `json:"player_id"` // Guest player ID, ignored when authenticated
+}
+
+// SetPreferredTokenRequest picks the player token to ask for in rooms ("" clears it)
+type SetPreferredTokenRequest struct {
+	Token string `json:"token"`
+}
+
+type PreferredTokenResponse struct {
+	Token string `json:"token"`
+}
+
 type CreatePlayerResponse struct {
 	Player *models.Player `json:"player"`
 }
@@ -25,21 +53,255 @@ type GetGameResponse struct {
 }
 
 type GetGamesResponse struct {
-	Games []models.Game `json:"games"`
+	Games []models.GameSummary `json:"games"`
+	Total int64                `json:"total"`
+	Page  int                  `json:"page"`
+	Limit int                  `json:"limit"`
+}
+
+// MyGame is a room the caller has a seat in. Live fields come from the
+// running game and are empty once it is unloaded.
+type MyGame struct {
+	GameID       uuid.UUID          `json:"game_id"`
+	RoomCode     string             `json:"room_code"`
+	Status       models.GameStatus  `json:"status"`
+	Score        int                `json:"score"`
+	Position     int                `json:"position"`
+	IsActive     bool               `json:"is_active"` // False once the seat was left or handed to a bot
+	CurrentRound int                `json:"current_round"`
+	MaxRounds    int                `json:"max_rounds"`
+	IsLive       bool               `json:"is_live"`
+	Phase        models.RoundStatus `json:"phase,omitempty"`
+	PlayerCount  int                `json:"player_count,omitempty"`
+	MaxPlayers   int                `json:"max_players"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+type MyGamesResponse struct {
+	Games []MyGame `json:"games"`
+	Total int64    `json:"total"`
+	Page  int      `json:"page"`
+	Limit int      `json:"limit"`
+}
+
+type CreateGameRequest struct {
+	RoomCode   string `json:"room_code"`   // Generated when empty
+	PlayerName string `json:"player_name"` // Creator's display name, defaults to the account name
+	PlayerID   string `json:"player_id"`   // Guest player ID, ignored when authenticated
+	Bots       int    `json:"bots"`        // Bots to seat straight away
+	BotLevel   string `json:"bot_level"`   // easy, medium, hard or auto
+	Pace       string `json:"pace"`        // blitz, standard or relaxed
+	Sandbox    bool   `json:"sandbox"`     // Practice room that is never persisted
+	Private    bool   `json:"private"`     // Keep the room out of the lobby browser
+	Password   string `json:"password"`    // Password new players need to join; makes the room private
+
+	Rules  *game.GameRules `json:"rules"`   // Seats, target score, hand size, round timer, expansions and the large-game variant
+	Packs  []string        `json:"packs"`   // Card pack slugs the deck is built from, the default packs when empty
+	DeckID string          `json:"deck_id"` // Custom deck to play with instead of card packs
+
+	TournamentID string `json:"tournament_id"` // Report the result to this tournament's ladder
+}
+
+type CreateGameFromTemplateRequest struct {
+	RoomCode   string `json:"room_code"`   // Generated when empty
+	PlayerName string `json:"player_name"` // Creator's display name, defaults to the account name
+}
+
+type RoomTemplateRequest struct {
+	Name     string            `json:"name" binding:"required"`
+	Settings game.GameSettings `json:"settings"`  // Lobby settings, as for PUT /games/{room_code}/settings
+	Bots     int               `json:"bots"`      // Bots seated when a room is created
+	BotLevel string            `json:"bot_level"` // easy, medium, hard or auto
+	Sandbox  bool              `json:"sandbox"`   // Practice rooms that are never persisted
+}
+
+type RoomTemplatesResponse struct {
+	Templates []roomtemplate.Template `json:"templates"`
+}
+
+type DeckRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	CardIDs     []int  `json:"card_ids" binding:"required"` // Existing, active cards; duplicates are dropped
+}
+
+type DecksResponse struct {
+	Decks []customdeck.Deck `json:"decks"`
+}
+
+type CreateGameResponse struct {
+	RoomCode             string      `json:"room_code"`
+	PlayerID             uuid.UUID   `json:"player_id"`
+	JoinURL              string      `json:"join_url"`      // Link other players open to join the room
+	WebSocketURL         string      `json:"websocket_url"` // Connect here to take the creator's seat
+	ResumeToken          string      `json:"resume_token"`
+	ResumeTokenExpiresAt time.Time   `json:"resume_token_expires_at"`
+	GameState            interface{} `json:"game_state"` // In the shape of the requested API version
+}
+
+type PacePresetsResponse struct {
+	Presets map[string]game.PaceOptions `json:"presets"`
+	Default string                      `json:"default"`
 }
 
 type AddBotRequest struct {
 	RoomCode string `json:"room_code" binding:"required"`
-	BotLevel string `json:"bot_level"` // easy, medium, hard
+	BotLevel string `json:"bot_level"` // easy, medium, hard or auto
+	HostID   string `json:"host_id"`   // Guest host's player ID, ignored when authenticated
 }
 
 type RemovePlayerRequest struct {
 	RoomCode string `json:"room_code" binding:"required"`
-	PlayerID string `json:"player_id" binding:"required"`
+	PlayerID string `json:"player_id" binding:"required"` // Player to remove
+	HostID   string `json:"host_id"`                      // Guest host's player ID, ignored when authenticated
 }
 
 type LeaveGameRequest struct {
 	RoomCode string `json:"room_code" binding:"required"`
+	PlayerID string `json:"player_id"` // Guest player ID, ignored when authenticated
+}
+
+type GameActionRequest struct {
+	PlayerID string `json:"player_id"` // Guest player ID, ignored when authenticated
+}
+
+type JoinGameRequest struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"` // Defaults to the account name
+	Password   string `json:"password"`    // Needed for password-protected rooms
+}
+
+type UpdateGameSettingsRequest struct {
+	PlayerID string            `json:"player_id"`
+	Settings game.GameSettings `json:"settings" binding:"required"`
+}
+
+type ChatModerationRequest struct {
+	PlayerID   string              `json:"player_id"`
+	Moderation game.ChatModeration `json:"moderation"`
+}
+
+type ShadowBanRequest struct {
+	Reason string `json:"reason"`
+}
+
+type WaiveForfeitRequest struct {
+	Reason string `json:"reason"`
+}
+
+// User administration types
+
+type AdminUsersResponse struct {
+	Users      []models.User      `json:"users"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+// AdminUserDetail is everything an admin needs to review an account
+type AdminUserDetail struct {
+	User           models.User      `json:"user"`
+	ActiveSessions int64            `json:"active_sessions"`
+	Sessions       []models.Session `json:"sessions"`     // Latest sessions, active or not
+	RecentGames    []MyGame         `json:"recent_games"` // Latest games, most recently updated first
+}
+
+type UserActionRequest struct {
+	Reason string `json:"reason"`
+}
+
+type SetUserRoleRequest struct {
+	Role   string `json:"role"` // player, curator or admin; empty makes the user a player
+	Reason string `json:"reason"`
+}
+
+type AuditLogResponse struct {
+	Entries    []models.AdminAuditEntry `json:"entries"`
+	Pagination PaginationResponse       `json:"pagination"`
+}
+
+type CreateTournamentRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Platform    string `json:"platform" binding:"required"`    // challonge or toornament
+	ExternalID  string `json:"external_id" binding:"required"` // Tournament ID or URL slug on the platform
+	APIKey      string `json:"api_key" binding:"required"`
+	AccessToken string `json:"access_token"` // OAuth token with the organizer:result scope; Toornament only
+}
+
+type SetTournamentParticipantsRequest struct {
+	// Player ID to participant ID on the platform. An empty participant ID removes the mapping.
+	Participants map[string]string `json:"participants" binding:"required"`
+}
+
+type ReportPlayerRequest struct {
+	PlayerID   string `json:"player_id"`
+	ReportedID string `json:"reported_id" binding:"required"`
+	Reason     string `json:"reason"`
+}
+
+type SubmitClueRequest struct {
+	PlayerID string `json:"player_id"`
+	Clue     string `json:"clue" binding:"required"`
+	CardID   int    `json:"card_id" binding:"required"`
+}
+
+type SubmitCardRequest struct {
+	PlayerID string `json:"player_id"`
+	CardID   int    `json:"card_id" binding:"required"`
+}
+
+type SubmitVoteRequest struct {
+	PlayerID   string `json:"player_id"`
+	CardID     int    `json:"card_id" binding:"required"`
+	Weight     int    `json:"weight"`      // weighted_votes experiment
+	DoubleDown bool   `json:"double_down"` // double_down experiment
+}
+
+type StartKickVoteRequest struct {
+	PlayerID string `json:"player_id"`
+	TargetID string `json:"target_id" binding:"required,uuid"`
+}
+
+type CastKickVoteRequest struct {
+	PlayerID string `json:"player_id"`
+	Kick     *bool  `json:"kick" binding:"required"` // true to vote the target out
+}
+
+type RequestBotTakeoverRequest struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"` // Defaults to the account name; returning players keep theirs
+	BotID      string `json:"bot_id" binding:"required,uuid"`
+}
+
+type ResolveBotTakeoverRequest struct {
+	PlayerID string `json:"player_id"`
+	Approve  *bool  `json:"approve" binding:"required"`
+}
+
+type DeckPackQuery struct {
+	Search string `form:"search" binding:"max=100"`
+	Sort   string `form:"sort" binding:"omitempty,oneof=popular rating newest"`
+	Page   int    `form:"page" binding:"omitempty,min=1"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type DeckPacksResponse struct {
+	Packs      []deckpack.Pack    `json:"packs"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type DeckPackLibraryResponse struct {
+	Packs []deckpack.Pack `json:"packs"`
+}
+
+type PublishDeckPackRequest struct {
+	Slug        string `json:"slug" binding:"required"` // Tag of the imported deck
+	Name        string `json:"name" binding:"required"`
+	Author      string `json:"author" binding:"required"`
+	Description string `json:"description"`
+}
+
+type RateDeckPackRequest struct {
+	Stars int `json:"stars" binding:"required,min=1,max=5"`
 }
 
 type DeleteGameRequest struct {
@@ -57,6 +319,7 @@ type CreateCardRequest struct {
 	Description string `json:"description"`
 	Extension   string `json:"extension"`
 	TagIDs      []int  `json:"tag_ids"`
+	models.CardAttribution
 }
 
 type CreateCardResponse struct {
@@ -80,8 +343,10 @@ type CardWithTagsResponse struct {
 	Extension   string        `json:"extension"`
 	IsActive    bool          `json:"is_active"`
 	Tags        []TagResponse `json:"tags"`
+	MatchScore  float64       `json:"match_score,omitempty"` // Best hierarchy weight of a matched tag when filtering
 	CreatedAt   time.Time     `json:"created_at"`
 	UpdatedAt   time.Time     `json:"updated_at"`
+	models.CardAttribution
 }
 
 type PaginationResponse struct {
@@ -91,11 +356,120 @@ type PaginationResponse struct {
 	Pages int64 `json:"pages"`
 }
 
+type ActivityFeedResponse struct {
+	Events     []activity.Entry   `json:"events"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
 type CardsListResponse struct {
 	Cards      []CardWithTagsResponse `json:"cards"`
 	Pagination PaginationResponse     `json:"pagination"`
 }
 
+type ResolveCardsRequest struct {
+	CardIDs []int `json:"card_ids" binding:"required,min=1,max=100"`
+}
+
+// ResolvedCard is a card's metadata with signed art URLs
+type ResolvedCard struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ThumbURL    string `json:"thumb_url"`
+	FullURL     string `json:"full_url"`
+	models.CardAttribution
+}
+
+type ResolveCardsResponse struct {
+	Cards   []ResolvedCard `json:"cards"`
+	Missing []int          `json:"missing,omitempty"` // Requested IDs with no card
+}
+
+type UpdateCardRequest struct {
+	Title       *string              `json:"title"`
+	Description *string              `json:"description"`
+	TagIDs      *[]int               `json:"tag_ids"` // Replaces all tags when present; retained tags keep their weights
+	Tags        *[]CardTagAssignment `json:"tags"`    // Like tag_ids, with weights
+	Artist      *string              `json:"artist"`
+	SourceURL   *string              `json:"source_url"`
+	License     *string              `json:"license"`
+	ChangeNote  string               `json:"change_note"`
+}
+
+// CardTagAssignment is a tag set on a card. Without a weight a retained tag
+// keeps its weight and a new one gets 1.0.
+type CardTagAssignment struct {
+	TagID  int      `json:"tag_id"`
+	Weight *float64 `json:"weight"` // 0 < weight <= 1
+}
+
+// CardCredit is an artist's contribution to the playable cards under one license and source
+type CardCredit struct {
+	models.CardAttribution
+	Cards int64 `json:"cards"`
+}
+
+type CardCreditsResponse struct {
+	Credits      []CardCredit `json:"credits"`
+	Unattributed int64        `json:"unattributed"` // Playable cards without an artist on record
+}
+
+// CardPackSummary is a card pack hosts can build a room's deck from
+type CardPackSummary struct {
+	models.CardPack
+	Cards int64 `json:"cards"` // Active cards in the pack
+}
+
+type CardPacksResponse struct {
+	Packs []CardPackSummary `json:"packs"`
+}
+
+type RollbackCardRequest struct {
+	Version    int    `json:"version" binding:"required,min=1"`
+	ChangeNote string `json:"change_note"`
+}
+
+type BulkTagAssignment struct {
+	Slug   string   `json:"slug"`
+	Weight *float64 `json:"weight"` // 0 < weight <= 1; when omitted the card's current weight, or 1.0 for a new tag
+}
+
+type BulkCardTagsRequest struct {
+	Assignments map[int][]BulkTagAssignment `json:"assignments" binding:"required"` // Card ID -> tags
+	Mode        string                      `json:"mode"`                           // merge (default) or replace
+	ChangeNote  string                      `json:"change_note"`
+	DryRun      bool                        `json:"dry_run"` // Validate and report without writing
+}
+
+type BulkCardTagsError struct {
+	CardID int    `json:"card_id,omitempty"`
+	Slug   string `json:"slug,omitempty"`
+	Error  string `json:"error"`
+}
+
+type BulkCardTagsResponse struct {
+	DryRun         bool                `json:"dry_run"`
+	Mode           string              `json:"mode"`
+	CardsProcessed int                 `json:"cards_processed"`
+	CardsUpdated   int                 `json:"cards_updated"`
+	CardsUnchanged int                 `json:"cards_unchanged"`
+	TagsAdded      int                 `json:"tags_added"`
+	TagsUpdated    int                 `json:"tags_updated"` // Weight changed
+	TagsRemoved    int                 `json:"tags_removed"` // Replace mode only
+	Errors         []BulkCardTagsError `json:"errors,omitempty"`
+}
+
+type CardVersionResponse struct {
+	models.CardVersion
+	Tags []models.CardTagSnapshot `json:"tags"`
+}
+
+type CardHistoryResponse struct {
+	CardID         int                   `json:"card_id"`
+	CurrentVersion int                   `json:"current_version"`
+	Versions       []CardVersionResponse `json:"versions"`
+}
+
 type ListCardsResponse struct {
 	Cards []models.Card `json:"cards"`
 	Total int64         `json:"total"`
@@ -103,6 +477,16 @@ type ListCardsResponse struct {
 	Limit int           `json:"limit"`
 }
 
+// Clue related types
+type ClueSuggestionsResponse struct {
+	CardID      int                `json:"card_id"`
+	Suggestions []clues.Suggestion `json:"suggestions"`
+}
+
+type ClueLanguagesResponse struct {
+	Languages []clues.Language `json:"languages"`
+}
+
 // Tag related types
 type CreateTagRequest struct {
 	Name        string  `json:"name" binding:"required"`
@@ -121,6 +505,14 @@ type ListTagsResponse struct {
 	Tags []models.Tag `json:"tags"`
 }
 
+type SetTagParentRequest struct {
+	ParentID *int `json:"parent_id"` // null makes the tag a root
+}
+
+type TagTreeResponse struct {
+	Tree []*taxonomy.Node `json:"tree"`
+}
+
 type TagsListResponse struct {
 	Tags       []models.Tag       `json:"tags"`
 	Pagination PaginationResponse `json:"pagination"`
@@ -170,11 +562,36 @@ type ReplacePlayerRequest struct {
 	Reason   string `json:"reason"`
 }
 
+type SetChatRetentionRequest struct {
+	Days *int `json:"days"` // null clears the override
+}
+
+type ChatPurgeResponse struct {
+	Result             *game.ChatPurgeResult `json:"result"`
+	TotalPurged        int64                 `json:"total_purged"`
+	LobbyRetentionDays int                   `json:"lobby_retention_days"`
+	GameRetentionDays  int                   `json:"game_retention_days"`
+}
+
+type MetricsResponse struct {
+	Metrics map[string]int64 `json:"metrics"`
+}
+
+// EventStatsResponse lists the event bus topics, by name
+type EventStatsResponse struct {
+	Topics []events.TopicStats `json:"topics"`
+}
+
 type CheckAFKRequest struct {
 	RoomCode          string `json:"room_code" binding:"required"`
 	AFKTimeoutMinutes int    `json:"afk_timeout_minutes"`
 }
 
+type SimilarCardsResponse struct {
+	ImageHash string                   `json:"image_hash"`
+	Cards     []cardimages.SimilarCard `json:"cards"`
+}
+
 // Player stats types
 type PlayerStatsResponse struct {
 	PlayerID           string  `json:"player_id"`
@@ -185,11 +602,33 @@ type PlayerStatsResponse struct {
 	TotalScore         int64   `json:"total_score"`
 	FavoriteRole       string  `json:"favorite_role"`
 	GamesAsStoryteller int64   `json:"games_as_storyteller"`
+	Rating             int     `json:"rating"`
+	RankedGames        int     `json:"ranked_games"`
+	RankedForfeits     int     `json:"ranked_forfeits"`
 }
 
 type GameHistoryResponse struct {
-	Games []models.GameHistory `json:"games"`
-	Total int64                `json:"total"`
-	Page  int                  `json:"page"`
-	Limit int                  `json:"limit"`
+	Games []models.PlayerGameStat `json:"games"`
+	Total int64                   `json:"total"`
+	Page  int                     `json:"page"`
+	Limit int                     `json:"limit"`
+}
+
+type ExperimentsResponse struct {
+	Experiments []experiments.Experiment `json:"experiments"`
+}
+
+type ExperimentStatsResponse struct {
+	Experiments []experiments.Experiment `json:"experiments"`
+	Games       map[string]int64         `json:"games"` // Experiment key -> games played with it
+}
+
+type SetExperimentFlagRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// ReadinessResponse reports whether the server can take traffic and why
+type ReadinessResponse struct {
+	Status     string                    `json:"status"` // ready, degraded or unavailable
+	Components []startup.ComponentStatus `json:"components"`
 }
@@ -0,0 +1,181 @@
+package longpoll
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+)
+
+// maxBufferedEvents bounds the per-client event backlog; slower clients see a gap
+const maxBufferedEvents = 256
+
+// Event is a server message tagged with a monotonically increasing sequence number
+type Event struct {
+	Seq     int64           `json:"seq"`
+	Message json.RawMessage `json:"message"`
+}
+
+// Connection buffers messages for a client that fetches them by polling.
+// It implements game.Connection.
+type Connection struct {
+	playerID      uuid.UUID
+	protocol      int
+	locale        string
+	authenticated bool // Started with a session JWT, which every request must then carry
+
+	mu       sync.Mutex
+	events   []Event
+	lastSeq  int64
+	notify   chan struct{} // Closed and replaced whenever an event arrives
+	lastSeen time.Time
+	closed   bool
+}
+
+func newConnection(playerID uuid.UUID, protocol int, locale string, authenticated bool) *Connection {
+	return &Connection{
+		playerID:      playerID,
+		protocol:      protocol,
+		locale:        locale,
+		authenticated: authenticated,
+		notify:        make(chan struct{}),
+		lastSeen:      time.Now(),
+	}
+}
+
+// Send queues an encoded message for the next poll
+func (c *Connection) Send(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return fmt.Errorf("long-poll session closed")
+	}
+
+	c.lastSeq++
+	message := make(json.RawMessage, len(data))
+	copy(message, data)
+	c.events = append(c.events, Event{Seq: c.lastSeq, Message: message})
+	if len(c.events) > maxBufferedEvents {
+		c.events = c.events[len(c.events)-maxBufferedEvents:]
+	}
+
+	close(c.notify)
+	c.notify = make(chan struct{})
+	return nil
+}
+
+// SendJSON encodes and queues a message for the next poll
+func (c *Connection) SendJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	return c.Send(data)
+}
+
+// Transport reports the long-polling transport
+func (c *Connection) Transport() string {
+	return game.TransportLongPolling
+}
+
+// ProtocolVersion reports the protocol chosen when the session started
+func (c *Connection) ProtocolVersion() int {
+	return c.protocol
+}
+
+// ProvesSeat reports whether the client proved its player ID with a session
+// JWT, rather than just naming it. Long-poll sessions have no resume tokens.
+func (c *Connection) ProvesSeat(roomCode string) bool {
+	return c.authenticated
+}
+
+// Locale reports the language the session started with
+func (c *Connection) Locale() string {
+	return c.locale
+}
+
+// Poll returns events after cursor, waiting up to timeout for new ones.
+// missed is true when events after cursor were dropped from the buffer.
+func (c *Connection) Poll(ctx context.Context, cursor int64, timeout time.Duration) (events []Event, next int64, missed bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		c.mu.Lock()
+		c.lastSeen = time.Now()
+		events, missed = c.eventsAfter(cursor)
+		next, notify, closed := c.lastSeq, c.notify, c.closed
+		c.mu.Unlock()
+
+		if len(events) > 0 || missed || closed {
+			if len(events) > 0 {
+				next = events[len(events)-1].Seq
+			}
+			return events, next, missed
+		}
+
+		select {
+		case <-notify:
+		case <-timer.C:
+			return []Event{}, next, false
+		case <-ctx.Done():
+			return []Event{}, next, false
+		}
+	}
+}
+
+// eventsAfter returns buffered events with Seq > cursor (mu must be held)
+func (c *Connection) eventsAfter(cursor int64) ([]Event, bool) {
+	if cursor > c.lastSeq {
+		// Client is ahead of us (e.g. server restarted); start over
+		cursor = 0
+	}
+	missed := len(c.events) > 0 && c.events[0].Seq > cursor+1
+
+	for i, event := range c.events {
+		if event.Seq > cursor {
+			result := make([]Event, len(c.events)-i)
+			copy(result, c.events[i:])
+			return result, missed
+		}
+	}
+	return nil, missed
+}
+
+// Cursor returns the sequence number of the latest queued event
+func (c *Connection) Cursor() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastSeq
+}
+
+// touch records client activity
+func (c *Connection) touch() {
+	c.mu.Lock()
+	c.lastSeen = time.Now()
+	c.mu.Unlock()
+}
+
+// idleSince reports how long the client has gone without polling or posting
+func (c *Connection) idleSince() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Since(c.lastSeen)
+}
+
+// close stops the connection from accepting messages and wakes any waiting poll
+func (c *Connection) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.notify)
+		c.notify = make(chan struct{})
+	}
+}
@@ -0,0 +1,343 @@
+// Package longpoll provides an HTTP long-polling fallback transport for clients
+// that can't hold a WebSocket open (constrained webviews, automated tests).
+//
+// Clients POST the same message envelopes they would send over the WebSocket
+// and GET queued server messages since a cursor. Both transports implement
+// game.Connection and share the WebSocket package's message routing.
+package longpoll
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"dixitme/internal/logger"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/game"
+	"dixitme/internal/transport/versioning"
+	"dixitme/internal/transport/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPollTimeout = 25 * time.Second
+	maxPollTimeout     = 30 * time.Second
+	sessionIdleTimeout = 60 * time.Second
+	sweepInterval      = 30 * time.Second
+)
+
+// Handlers serves the long-polling endpoints
+type Handlers struct {
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*Connection
+	stop     chan struct{}
+	done     chan struct{} // Closed once the sweeper has returned
+}
+
+// NewHandlers creates the long-polling handlers and starts the idle session
+// sweeper. Call Stop to end it.
+func NewHandlers() *Handlers {
+	h := &Handlers{
+		sessions: make(map[uuid.UUID]*Connection),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go h.sweepIdleSessions()
+	return h
+}
+
+// Stop stops the idle session sweeper and waits for it to return
+func (h *Handlers) Stop() {
+	close(h.stop)
+	<-h.done
+}
+
+// PollResponse is returned by the events endpoint
+type PollResponse struct {
+	Events []Event `json:"events"`
+	Cursor int64   `json:"cursor"`           // Pass back as ?cursor= on the next poll
+	Missed bool    `json:"missed,omitempty"` // Events were dropped; resync game state
+}
+
+// Events returns queued messages after a cursor, waiting for new ones if none are queued
+// @Summary Poll for game events
+// @Description Long-poll for server messages queued after the given cursor. Guests must pass player_id.
+// @Tags realtime
+// @Produce json
+// @Param player_id query string false "Guest player ID" format(uuid)
+// @Param protocol query int false "Message protocol version for a new session (defaults to the API version)"
+// @Param cursor query int false "Last seen event sequence number" default(0)
+// @Param timeout query int false "Seconds to wait for new events (max 30)" default(25)
+// @Success 200 {object} PollResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{} "Session was started with a token this request lacks"
+// @Failure 403 {object} map[string]interface{} "Guest player ID belongs to a registered account"
+// @Security BearerAuth && Scopes[play]
+// @Router /poll/events [get]
+func (h *Handlers) Events(c *gin.Context) {
+	playerID, ok := playerIDFromRequest(c)
+	if !ok {
+		return
+	}
+
+	protocol, err := versioning.NegotiateProtocol(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cursor, _ := strconv.ParseInt(c.DefaultQuery("cursor", "0"), 10, 64)
+
+	timeout := defaultPollTimeout
+	if seconds, err := strconv.Atoi(c.Query("timeout")); err == nil && seconds >= 0 {
+		timeout = min(time.Duration(seconds)*time.Second, maxPollTimeout)
+	}
+
+	conn, err := h.session(c, playerID, protocol)
+	if err != nil {
+		respondSessionError(c, err)
+		return
+	}
+	events, next, missed := conn.Poll(c.Request.Context(), cursor, timeout)
+	if events == nil {
+		events = []Event{}
+	}
+
+	c.JSON(http.StatusOK, PollResponse{
+		Events: events,
+		Cursor: next,
+		Missed: missed,
+	})
+}
+
+// Actions accepts a client message and routes it like a WebSocket message
+// @Summary Send a game action
+// @Description Send a client message envelope (same format as the WebSocket). Replies are delivered through /poll/events.
+// @Tags realtime
+// @Accept json
+// @Produce json
+// @Param player_id query string false "Guest player ID" format(uuid)
+// @Param protocol query int false "Message protocol version for a new session (defaults to the API version)"
+// @Param message body websocket.ConnectionMessage true "Client message"
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{} "Session was started with a token this request lacks"
+// @Failure 403 {object} map[string]interface{} "Guest player ID belongs to a registered account"
+// @Security BearerAuth && Scopes[play]
+// @Router /poll/actions [post]
+func (h *Handlers) Actions(c *gin.Context) {
+	playerID, ok := playerIDFromRequest(c)
+	if !ok {
+		return
+	}
+
+	protocol, err := versioning.NegotiateProtocol(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var msg websocket.ConnectionMessage
+	if err := c.ShouldBindJSON(&msg); err != nil || msg.Type == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message format"})
+		return
+	}
+
+	conn, err := h.session(c, playerID, protocol)
+	if err != nil {
+		respondSessionError(c, err)
+		return
+	}
+	websocket.UpdatePlayerActivity(playerID)
+
+	if err := websocket.HandleMessage(conn, playerID, msg); err != nil {
+		logger.Error("Error handling long-poll message", "error", err, "player_id", playerID, "message_type", msg.Type)
+		if websocket.RespondAtCapacity(c, err) {
+			return
+		}
+		if gameErr, ok := game.AsGameError(err); ok {
+			c.JSON(http.StatusConflict, gin.H{"error": gameErr.Message, "code": gameErr.Code, "details": gameErr.Details})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"accepted": true,
+		"cursor":   conn.Cursor(),
+	})
+}
+
+// Close ends a long-poll session, like closing a WebSocket
+// @Summary Close long-poll session
+// @Description End the caller's long-poll session and mark them disconnected
+// @Tags realtime
+// @Produce json
+// @Param player_id query string false "Guest player ID" format(uuid)
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{} "Session was started with a token this request lacks"
+// @Failure 403 {object} map[string]interface{} "Guest player ID belongs to a registered account"
+// @Security BearerAuth && Scopes[play]
+// @Router /poll/session [delete]
+func (h *Handlers) Close(c *gin.Context) {
+	playerID, ok := playerIDFromRequest(c)
+	if !ok {
+		return
+	}
+
+	_, authenticated := auth.GetUserFromContext(c)
+
+	h.mu.Lock()
+	conn, exists := h.sessions[playerID]
+	if exists && conn.authenticated && !authenticated {
+		h.mu.Unlock()
+		respondSessionError(c, errSessionAuthenticated)
+		return
+	}
+	delete(h.sessions, playerID)
+	h.mu.Unlock()
+
+	if exists {
+		h.disconnect(playerID, conn)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// errSessionAuthenticated refuses a request without a token to a session
+// started with one, since player IDs alone are public
+var errSessionAuthenticated = errors.New("this session needs the token it was started with")
+
+// respondSessionError answers a request whose session couldn't be opened
+func respondSessionError(c *gin.Context, err error) {
+	if websocket.RespondAtCapacity(c, err) || websocket.RespondConnectionInUse(c, err) {
+		return
+	}
+	c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+}
+
+// session returns the player's long-poll connection, creating and registering it if needed.
+// The protocol and locale only apply to new sessions; an existing session keeps the ones it started with.
+// New sessions fail with a capacity error when the server is full.
+func (h *Handlers) session(c *gin.Context, playerID uuid.UUID, protocol int) (*Connection, error) {
+	_, authenticated := auth.GetUserFromContext(c)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if conn, exists := h.sessions[playerID]; exists {
+		if conn.authenticated && !authenticated {
+			return nil, errSessionAuthenticated
+		}
+		conn.touch()
+		// Back to polling after a WebSocket that took over has closed
+		if game.GetPlayerConnection(playerID) == nil {
+			game.RegisterPlayerConnection(playerID, conn)
+		}
+		return conn, nil
+	}
+	if err := game.GetManager().CheckConnectionCapacity(playerID); err != nil {
+		logger.Warn("Refused long-poll session, server at capacity", "player_id", playerID)
+		return nil, err
+	}
+
+	userInfo, _ := auth.GetUserFromContext(c)
+	locale := websocket.NegotiateLocale(c.Request, userInfo)
+	conn := newConnection(playerID, protocol, locale, authenticated)
+	// Long-poll sessions have no resume tokens, so only signed-in players may
+	// move a live session here
+	previous, err := game.ClaimPlayerConnection(playerID, conn, authenticated)
+	if err != nil {
+		logger.Warn("Refused long-poll session, player already connected", "player_id", playerID)
+		return nil, err
+	}
+	h.sessions[playerID] = conn
+	game.GetManager().TakeOverConnection(playerID, previous, conn)
+	versioning.RecordProtocol(game.TransportLongPolling, protocol)
+
+	conn.SendJSON(game.GameMessage{
+		Type: "connection_established",
+		Payload: map[string]interface{}{
+			"player_id": playerID,
+			"transport": conn.Transport(),
+			"protocol":  protocol,
+			"locale":    locale,
+		},
+	})
+
+	logger.Info("Long-poll session started", "player_id", playerID)
+	return conn, nil
+}
+
+// sweepIdleSessions disconnects clients that stopped polling, until Stop is called
+func (h *Handlers) sweepIdleSessions() {
+	defer close(h.done)
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.expireIdleSessions(sessionIdleTimeout)
+		case <-h.stop:
+			return
+		}
+	}
+}
+
+// expireIdleSessions disconnects sessions idle for longer than timeout
+func (h *Handlers) expireIdleSessions(timeout time.Duration) {
+	h.mu.Lock()
+	expired := make(map[uuid.UUID]*Connection)
+	for playerID, conn := range h.sessions {
+		if conn.idleSince() > timeout {
+			expired[playerID] = conn
+			delete(h.sessions, playerID)
+		}
+	}
+	h.mu.Unlock()
+
+	for playerID, conn := range expired {
+		logger.Info("Long-poll session expired", "player_id", playerID)
+		h.disconnect(playerID, conn)
+	}
+}
+
+// disconnect closes a session and, unless the player has since connected another
+// way (e.g. upgraded to a WebSocket), marks them disconnected from their games
+func (h *Handlers) disconnect(playerID uuid.UUID, conn *Connection) {
+	conn.close()
+
+	if !game.UnregisterPlayerConnection(playerID, conn) {
+		return
+	}
+	websocket.HandleDisconnect(playerID)
+}
+
+// playerIDFromRequest resolves the caller's player ID, writing an error response if it can't
+func playerIDFromRequest(c *gin.Context) (uuid.UUID, bool) {
+	if userInfo, exists := auth.GetUserFromContext(c); exists {
+		return userInfo.PlayerID(), true
+	}
+
+	playerID, err := uuid.Parse(c.Query("player_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "player_id is required for guests"})
+		return uuid.Nil, false
+	}
+	if err := auth.CheckGuestPlayerID(playerID); err != nil {
+		if errors.Is(err, auth.ErrRegisteredPlayerID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else {
+			logger.Error("Failed to check guest player ID", "error", err, "player_id", playerID)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check player ID"})
+		}
+		return uuid.Nil, false
+	}
+	return playerID, true
+}
@@ -0,0 +1,192 @@
+package longpoll
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"dixitme/internal/database"
+	"dixitme/internal/models"
+	"dixitme/internal/services/auth"
+	"dixitme/internal/services/game"
+	"dixitme/internal/testutils/testdb"
+	"dixitme/internal/transport/versioning"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHandlers returns handlers without the idle sweeper, backed by an
+// ephemeral game manager. Signed-in sessions look their locale up in the
+// database, so there is one.
+func newTestHandlers(t *testing.T) *Handlers {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	game.SetManager(game.NewEphemeralManager())
+
+	previous := database.GetDB()
+	database.SetDB(testdb.Open(t, &models.Session{}))
+	t.Cleanup(func() { database.SetDB(previous) })
+
+	return &Handlers{sessions: make(map[uuid.UUID]*Connection)}
+}
+
+// requestContext builds a poll request, signed in as userInfo when it is set
+func requestContext(userInfo *auth.UserInfo) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/poll/events", nil)
+	if userInfo != nil {
+		c.Set(auth.AuthContextKey, userInfo)
+	}
+	return c
+}
+
+func TestSessionStartedWithTokenRefusesBarePlayerID(t *testing.T) {
+	h := newTestHandlers(t)
+	sessionID := uuid.New()
+	signedIn := &auth.UserInfo{SessionID: sessionID}
+
+	conn, err := h.session(requestContext(signedIn), sessionID, versioning.V1)
+	require.NoError(t, err)
+	t.Cleanup(func() { h.disconnect(sessionID, conn) })
+	assert.True(t, conn.ProvesSeat("ANY"))
+
+	// The player ID is public; without the token it reaches nobody's session
+	_, err = h.session(requestContext(nil), sessionID, versioning.V1)
+	assert.ErrorIs(t, err, errSessionAuthenticated)
+
+	again, err := h.session(requestContext(signedIn), sessionID, versioning.V1)
+	require.NoError(t, err)
+	assert.Same(t, conn, again)
+}
+
+func TestGuestSessionDoesNotProveSeat(t *testing.T) {
+	h := newTestHandlers(t)
+	guestID := uuid.New()
+
+	conn, err := h.session(requestContext(nil), guestID, versioning.V1)
+	require.NoError(t, err)
+	t.Cleanup(func() { h.disconnect(guestID, conn) })
+	assert.False(t, conn.ProvesSeat("ANY"))
+}
+
+func TestGuestCannotTakeOverLiveConnection(t *testing.T) {
+	h := newTestHandlers(t)
+	playerID := uuid.New()
+	live := newConnection(playerID, game.ProtocolV1, "en", false)
+	game.RegisterPlayerConnection(playerID, live)
+	t.Cleanup(func() { game.UnregisterPlayerConnection(playerID, live) })
+
+	// Naming a connected player's public ID doesn't move their session here
+	_, err := h.session(requestContext(nil), playerID, versioning.V1)
+	assert.ErrorIs(t, err, &game.GameError{Code: game.ErrCodeConnectionInUse})
+	assert.Same(t, live, game.GetPlayerConnection(playerID))
+
+	conn, err := h.session(requestContext(&auth.UserInfo{SessionID: playerID}), playerID, versioning.V1)
+	require.NoError(t, err)
+	t.Cleanup(func() { h.disconnect(playerID, conn) })
+	assert.Same(t, conn, game.GetPlayerConnection(playerID))
+}
+
+// queued returns a connection holding n events
+func queued(t *testing.T, n int) *Connection {
+	t.Helper()
+	conn := newConnection(uuid.New(), game.ProtocolV1, "en", false)
+	for i := 1; i <= n; i++ {
+		require.NoError(t, conn.Send([]byte(fmt.Sprintf(`{"n":%d}`, i))))
+	}
+	return conn
+}
+
+func TestPollReturnsEventsAfterCursor(t *testing.T) {
+	conn := queued(t, 3)
+	ctx := context.Background()
+
+	events, next, missed := conn.Poll(ctx, 0, time.Second)
+	require.Len(t, events, 3)
+	assert.Equal(t, int64(3), next)
+	assert.False(t, missed)
+
+	events, next, _ = conn.Poll(ctx, 2, time.Second)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(3), events[0].Seq)
+	assert.JSONEq(t, `{"n":3}`, string(events[0].Message))
+	assert.Equal(t, int64(3), next)
+
+	// Caught up: the poll waits, then returns nothing with the same cursor
+	events, next, missed = conn.Poll(ctx, 3, 10*time.Millisecond)
+	assert.Empty(t, events)
+	assert.Equal(t, int64(3), next)
+	assert.False(t, missed)
+
+	// A cursor from before a server restart starts over
+	events, _, _ = conn.Poll(ctx, 99, time.Second)
+	assert.Len(t, events, 3)
+}
+
+func TestPollWakesOnNewEvent(t *testing.T) {
+	conn := queued(t, 0)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		conn.Send([]byte(`{"n":1}`))
+	}()
+
+	events, next, _ := conn.Poll(context.Background(), 0, 5*time.Second)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(1), next)
+}
+
+func TestPollReportsMissedEventsOnOverflow(t *testing.T) {
+	conn := queued(t, maxBufferedEvents+10)
+
+	events, next, missed := conn.Poll(context.Background(), 0, time.Second)
+	assert.True(t, missed, "the oldest events were dropped")
+	require.Len(t, events, maxBufferedEvents)
+	assert.Equal(t, int64(11), events[0].Seq)
+	assert.Equal(t, int64(maxBufferedEvents+10), next)
+
+	// A client that saw up to the oldest kept event missed nothing
+	_, _, missed = conn.Poll(context.Background(), 10, time.Second)
+	assert.False(t, missed)
+}
+
+func TestIdleSessionsExpire(t *testing.T) {
+	h := newTestHandlers(t)
+	idleID, activeID := uuid.New(), uuid.New()
+
+	idle, err := h.session(requestContext(nil), idleID, versioning.V1)
+	require.NoError(t, err)
+	active, err := h.session(requestContext(nil), activeID, versioning.V1)
+	require.NoError(t, err)
+	t.Cleanup(func() { h.disconnect(activeID, active) })
+
+	idle.mu.Lock()
+	idle.lastSeen = time.Now().Add(-2 * sessionIdleTimeout)
+	idle.mu.Unlock()
+
+	h.expireIdleSessions(sessionIdleTimeout)
+	assert.NotContains(t, h.sessions, idleID)
+	assert.Contains(t, h.sessions, activeID)
+	assert.Error(t, idle.Send([]byte(`{}`)), "an expired session is closed")
+	assert.NoError(t, active.Send([]byte(`{}`)))
+}
+
+func TestStopEndsSweeper(t *testing.T) {
+	h := NewHandlers()
+	stopped := make(chan struct{})
+	go func() {
+		h.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("sweeper did not stop")
+	}
+}
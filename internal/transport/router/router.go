@@ -1,9 +1,15 @@
 package router
 
 import (
+	"time"
+
+	"dixitme/internal/cache"
 	"dixitme/internal/logger"
 	"dixitme/internal/services/auth"
+	"dixitme/internal/startup"
 	"dixitme/internal/transport/handlers"
+	"dixitme/internal/transport/longpoll"
+	"dixitme/internal/transport/versioning"
 	websocketHandler "dixitme/internal/transport/websocket"
 
 	"github.com/gin-gonic/gin"
@@ -13,14 +19,22 @@ import (
 
 // RouterDependencies holds all the dependencies needed to set up routes
 type RouterDependencies struct {
-	AuthHandlers   *auth.AuthHandlers
-	JWTService     *auth.JWTService
-	GameHandlers   *handlers.GameHandlers
-	PlayerHandlers *handlers.PlayerHandlers
-	CardHandlers   *handlers.CardHandlers
-	TagHandlers    *handlers.TagHandlers
-	AdminHandlers  *handlers.AdminHandlers
-	ChatHandlers   *handlers.ChatHandlers
+	AuthHandlers      *auth.AuthHandlers
+	JWTService        *auth.JWTService
+	GameHandlers      *handlers.GameHandlers
+	PlayerHandlers    *handlers.PlayerHandlers
+	CardHandlers      *handlers.CardHandlers
+	TagHandlers       *handlers.TagHandlers
+	AdminHandlers     *handlers.AdminHandlers
+	UserAdminHandlers *handlers.UserAdminHandlers
+	ChatHandlers      *handlers.ChatHandlers
+	ClueHandlers      *handlers.ClueHandlers
+	PollHandlers      *longpoll.Handlers
+
+	TournamentHandlers *handlers.TournamentHandlers
+	ActivityHandlers   *handlers.ActivityHandlers
+
+	Startup *startup.Orchestrator // Component status for readiness
 }
 
 // SetupRouter creates and configures the Gin router with all routes
@@ -37,9 +51,12 @@ func SetupRouter(deps *RouterDependencies) *gin.Engine {
 	// Add CORS middleware
 	r.Use(handlers.CORSMiddleware())
 
+	// Keep reads on the primary database right after a client writes
+	r.Use(handlers.ReadYourWritesMiddleware())
+
 	// Setup all routes
 	setupSwaggerRoutes(r)
-	setupHealthRoutes(r)
+	setupHealthRoutes(r, deps)
 	setupAPIRoutes(r, deps)
 	setupWebSocketRoutes(r, deps.JWTService)
 	setupStaticRoutes(r)
@@ -53,23 +70,36 @@ func setupSwaggerRoutes(r *gin.Engine) {
 }
 
 // setupHealthRoutes configures health check endpoints
-func setupHealthRoutes(r *gin.Engine) {
+func setupHealthRoutes(r *gin.Engine, deps *RouterDependencies) {
 	r.GET("/health", handlers.HealthCheck)
+	r.GET("/health/ready", handlers.ReadinessCheck(deps.Startup))
 }
 
-// setupAPIRoutes configures all API routes under /api/v1
+// setupAPIRoutes mounts the API under every supported version. Versions share
+// handlers; handlers whose payload changed between versions check versioning.FromContext.
 func setupAPIRoutes(r *gin.Engine, deps *RouterDependencies) {
-	api := r.Group("/api/v1")
-	{
-		setupAuthRoutes(api, deps)
-		setupPlayerRoutes(api, deps)
-		setupGameRoutes(api, deps)
-		setupCardRoutes(api, deps)
-		setupTagRoutes(api, deps)
-		setupBotRoutes(api, deps)
-		setupAdminRoutes(api, deps)
-		setupChatRoutes(api, deps)
-	}
+	setupVersionedAPIRoutes(r.Group("/api/v1", versioning.Middleware(versioning.V1)), deps)
+	setupVersionedAPIRoutes(r.Group("/api/v2", versioning.Middleware(versioning.V2)), deps)
+}
+
+// setupVersionedAPIRoutes configures the API routes of one version
+func setupVersionedAPIRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	setupAuthRoutes(api, deps)
+	setupPlayerRoutes(api, deps)
+	setupGameRoutes(api, deps)
+	setupCardRoutes(api, deps)
+	setupTagRoutes(api, deps)
+	setupDeckPackRoutes(api, deps)
+	setupDeckRoutes(api, deps)
+	setupClueRoutes(api, deps)
+	setupBotRoutes(api, deps)
+	setupAdminRoutes(api, deps)
+	setupChatRoutes(api, deps)
+	setupPollRoutes(api, deps)
+
+	api.GET("/experiments", handlers.ListExperiments)   // Public
+	api.GET("/branding", handlers.GetBranding)          // Public
+	api.GET("/player-tokens", handlers.GetPlayerTokens) // Public
 }
 
 // setupAuthRoutes configures authentication routes
@@ -81,13 +111,16 @@ func setupAuthRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 		authGroup.POST("/login", deps.AuthHandlers.Login)
 		authGroup.POST("/google", deps.AuthHandlers.GoogleLogin)
 		authGroup.POST("/guest", deps.AuthHandlers.GuestLogin)
+		authGroup.POST("/upgrade", deps.AuthHandlers.UpgradeGuest)
 		authGroup.POST("/refresh", deps.AuthHandlers.RefreshToken)
+		authGroup.POST("/password-reset", deps.AuthHandlers.ResetPassword)
 		authGroup.GET("/status", deps.AuthHandlers.GetAuthStatus)
 
 		// Protected auth routes
 		authGroup.POST("/logout", auth.RequireAuth(deps.JWTService), deps.AuthHandlers.Logout)
 		authGroup.GET("/me", auth.RequireAuth(deps.JWTService), deps.AuthHandlers.GetCurrentUser)
 		authGroup.GET("/validate", auth.RequireAuth(deps.JWTService), deps.AuthHandlers.ValidateToken)
+		authGroup.POST("/tokens", auth.RequireAuth(deps.JWTService), deps.AuthHandlers.IssueIntegrationToken)
 	}
 }
 
@@ -99,6 +132,33 @@ func setupPlayerRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 	{
 		playerGroup.POST("", handlers.CreatePlayer)
 		playerGroup.GET("/:id", handlers.GetPlayer)
+		playerGroup.PUT("/:id/name", handlers.RenamePlayer)
+	}
+
+	// The authenticated user's own feeds
+	meGroup := api.Group("/me")
+	meGroup.Use(auth.RequireAuth(deps.JWTService))
+	{
+		meGroup.GET("/activity", deps.ActivityHandlers.GetMyActivity)
+		meGroup.GET("/games", deps.GameHandlers.GetMyGames)
+
+		meGroup.PUT("/preferred-token", handlers.SetPreferredToken)
+		meGroup.GET("/ranked-standing", handlers.GetMyRankedStanding)
+		meGroup.GET("/notification-preferences", handlers.GetNotificationPreferences)
+		meGroup.PUT("/notification-preferences", handlers.SetNotificationPreferences)
+		meGroup.GET("/presence", handlers.GetPresence)
+		meGroup.PUT("/presence", handlers.SetPresence)
+
+		meGroup.GET("/room-templates", deps.GameHandlers.ListRoomTemplates)
+		meGroup.POST("/room-templates", deps.GameHandlers.CreateRoomTemplate)
+		meGroup.GET("/room-templates/:template_id", deps.GameHandlers.GetRoomTemplate)
+		meGroup.PUT("/room-templates/:template_id", deps.GameHandlers.UpdateRoomTemplate)
+		meGroup.DELETE("/room-templates/:template_id", deps.GameHandlers.DeleteRoomTemplate)
+
+		meGroup.GET("/deck-packs", handlers.GetMyDeckPacks)
+		meGroup.PUT("/deck-packs/:pack_id", handlers.InstallDeckPack)
+		meGroup.DELETE("/deck-packs/:pack_id", handlers.UninstallDeckPack)
+		meGroup.PUT("/deck-packs/:pack_id/rating", handlers.RateDeckPack)
 	}
 
 	// Player stats routes (separate to avoid route conflicts)
@@ -112,11 +172,41 @@ func setupPlayerRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 
 // setupGameRoutes configures game management routes
 func setupGameRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	// Round images are public so link previews can fetch them
+	api.GET("/games/:room_code/rounds/:round_number/image", deps.GameHandlers.GetRoundImage)
+
 	gameGroup := api.Group("/games")
-	gameGroup.Use(auth.GuestOrAuth(deps.JWTService))
+	gameGroup.Use(auth.GuestOrAuth(deps.JWTService), auth.RequireScope(auth.ScopePlay))
 	{
-		gameGroup.GET("", deps.GameHandlers.GetGames)
+		gameGroup.GET("", cache.Unless(handlers.ListsPrivateGames, cache.Middleware(cache.ScopeGames, time.Minute)), deps.GameHandlers.GetGames)
+		gameGroup.POST("", deps.GameHandlers.CreateGame)
+		gameGroup.GET("/pace-presets", deps.GameHandlers.GetPacePresets)
+		gameGroup.POST("/from-template/:template_id", deps.GameHandlers.CreateGameFromTemplate)
 		gameGroup.GET("/:room_code", deps.GameHandlers.GetGame)
+		gameGroup.GET("/:room_code/state", deps.GameHandlers.GetLiveGameState)
+		gameGroup.GET("/:room_code/home", deps.GameHandlers.GetRoomHome)
+		gameGroup.GET("/:room_code/host-report", deps.GameHandlers.GetHostReport)
+		gameGroup.GET("/:room_code/score-timeline", deps.GameHandlers.GetScoreTimeline)
+		gameGroup.GET("/:room_code/fairness", deps.GameHandlers.GetFairnessProof)
+		gameGroup.GET("/:room_code/bot-decisions", deps.GameHandlers.GetBotDecisions)
+		gameGroup.GET("/:room_code/replay", deps.GameHandlers.GetReplay)
+
+		// REST equivalents of the WebSocket game actions
+		gameGroup.POST("/:room_code/join", deps.GameHandlers.JoinGame)
+		gameGroup.POST("/:room_code/start", deps.GameHandlers.StartGame)
+		gameGroup.PUT("/:room_code/settings", deps.GameHandlers.UpdateGameSettings)
+		gameGroup.PUT("/:room_code/chat-moderation", deps.GameHandlers.SetChatModeration)
+		gameGroup.POST("/:room_code/report", deps.GameHandlers.ReportPlayer)
+		gameGroup.POST("/:room_code/clue", deps.GameHandlers.SubmitClue)
+		gameGroup.POST("/:room_code/mulligan", deps.GameHandlers.Mulligan)
+		gameGroup.POST("/:room_code/cards", deps.GameHandlers.SubmitCard)
+		gameGroup.POST("/:room_code/votes", deps.GameHandlers.SubmitVote)
+		gameGroup.POST("/:room_code/kick-votes", deps.GameHandlers.StartKickVote)
+		gameGroup.POST("/:room_code/kick-votes/ballots", deps.GameHandlers.CastKickVote)
+		gameGroup.POST("/:room_code/takeover-requests", deps.GameHandlers.RequestBotTakeover)
+		gameGroup.POST("/:room_code/takeover-requests/:bot_id", deps.GameHandlers.ResolveBotTakeover)
+		gameGroup.POST("/:room_code/rematch", deps.GameHandlers.Rematch)
+
 		gameGroup.POST("/add-bot", deps.GameHandlers.AddBotToGame)
 		gameGroup.DELETE("/remove-player", deps.GameHandlers.RemovePlayerFromGame)
 		gameGroup.DELETE("/:room_code", deps.GameHandlers.DeleteGame)
@@ -129,13 +219,22 @@ func setupCardRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 	cardsGroup := api.Group("/cards")
 	{
 		// Public card routes
-		cardsGroup.GET("", handlers.ListCards)
-		cardsGroup.GET("/legacy", handlers.GetCards)
-		cardsGroup.GET("/:card_id", handlers.GetCardWithTags)
+		cardsGroup.GET("", cache.Middleware(cache.ScopeCards, 0), handlers.ListCards)
+		cardsGroup.GET("/legacy", cache.Middleware(cache.ScopeCards, 0), handlers.GetCards)
+		cardsGroup.GET("/credits", cache.Middleware(cache.ScopeCards, 0), handlers.GetCardCredits)
+		cardsGroup.GET("/packs", cache.Middleware(cache.ScopeCards, 0), handlers.GetCardPacks)
+		cardsGroup.GET("/:card_id", cache.Middleware(cache.ScopeCards, 0), handlers.GetCardWithTags)
+		cardsGroup.GET("/:card_id/history", handlers.GetCardHistory)
+		cardsGroup.GET("/:card_id/art/:variant", handlers.GetCardArt) // Authorized by the URL signature
+		cardsGroup.POST("/resolve", handlers.ResolveCards)
 
-		// Protected card routes (auth required)
-		cardsGroup.POST("", auth.RequireAuth(deps.JWTService), handlers.CreateCard)
-		cardsGroup.POST("/:card_id/image", auth.RequireAuth(deps.JWTService), handlers.UploadCardImage)
+		// Protected card routes (auth and manage_cards scope required)
+		requireAuth := auth.RequireAuth(deps.JWTService)
+		manageCards := auth.RequireScope(auth.ScopeManageCards)
+		cardsGroup.POST("", requireAuth, manageCards, handlers.CreateCard)
+		cardsGroup.POST("/:card_id/image", requireAuth, manageCards, handlers.UploadCardImage)
+		cardsGroup.PUT("/:card_id", requireAuth, manageCards, handlers.UpdateCard)
+		cardsGroup.POST("/:card_id/rollback", requireAuth, manageCards, handlers.RollbackCard)
 	}
 }
 
@@ -143,8 +242,41 @@ func setupCardRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 func setupTagRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 	tagsGroup := api.Group("/tags")
 	{
-		tagsGroup.GET("", handlers.ListTags)                                      // Public
-		tagsGroup.POST("", auth.RequireAuth(deps.JWTService), handlers.CreateTag) // Auth required
+		tagsGroup.GET("", cache.Middleware(cache.ScopeTags, 0), handlers.ListTags) // Public
+		tagsGroup.POST("", auth.RequireAuth(deps.JWTService), auth.RequireScope(auth.ScopeManageCards), handlers.CreateTag)
+	}
+}
+
+// setupDeckPackRoutes configures the deck pack marketplace routes
+func setupDeckPackRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	deckPackGroup := api.Group("/deck-packs")
+	deckPackGroup.Use(auth.OptionalAuth(deps.JWTService)) // Signed-in users see their installs
+	{
+		deckPackGroup.GET("", handlers.ListDeckPacks)
+		deckPackGroup.GET("/:pack_id", handlers.GetDeckPack)
+	}
+}
+
+// setupDeckRoutes configures custom deck routes
+func setupDeckRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	deckGroup := api.Group("/decks")
+	deckGroup.Use(auth.RequireAuth(deps.JWTService))
+	{
+		deckGroup.GET("", handlers.ListDecks)
+		deckGroup.POST("", handlers.CreateDeck)
+		deckGroup.GET("/:deck_id", handlers.GetDeck)
+		deckGroup.PUT("/:deck_id", handlers.UpdateDeck)
+		deckGroup.DELETE("/:deck_id", handlers.DeleteDeck)
+	}
+}
+
+// setupClueRoutes configures clue inspiration routes
+func setupClueRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	cluesGroup := api.Group("/clues")
+	cluesGroup.Use(auth.GuestOrAuth(deps.JWTService))
+	{
+		cluesGroup.GET("/suggestions", deps.ClueHandlers.GetClueSuggestions)
+		cluesGroup.GET("/languages", deps.ClueHandlers.ListClueLanguages)
 	}
 }
 
@@ -152,35 +284,88 @@ func setupTagRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 func setupBotRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 	botGroup := api.Group("/bots")
 	{
-		botGroup.GET("/stats", deps.GameHandlers.GetBotStats) // Public
+		// Bot stats follow live games, so they only get a short TTL
+		botGroup.GET("/stats", cache.Middleware(cache.ScopeBots, 30*time.Second), deps.GameHandlers.GetBotStats) // Public
 	}
 }
 
-// setupAdminRoutes configures admin routes (all require authentication)
+// setupAdminRoutes configures admin routes (all require a signed-in account with the admin role)
 func setupAdminRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 	adminGroup := api.Group("/admin")
-	adminGroup.Use(auth.RequireAuth(deps.JWTService)) // All admin routes require auth
+	adminGroup.Use(auth.RequireAdmin(deps.JWTService))
 	{
 		adminGroup.POST("/seed", handlers.SeedDatabase)
 		adminGroup.POST("/seed/tags", handlers.SeedTags)
 		adminGroup.POST("/seed/cards", handlers.SeedCards)
 		adminGroup.GET("/stats", handlers.GetDatabaseStats)
 		adminGroup.POST("/cleanup", handlers.CleanupOldGames)
+		adminGroup.GET("/metrics", handlers.GetMetrics)
+		adminGroup.GET("/events", handlers.GetEventStats)
+		adminGroup.POST("/read-models/rebuild", handlers.RebuildReadModels)
+		adminGroup.POST("/chat/purge", deps.AdminHandlers.PurgeChatMessages)
+		adminGroup.PUT("/games/:room_code/chat-retention", handlers.SetRoomChatRetention)
+		adminGroup.POST("/games/:room_code/resync/:player_id", handlers.ResyncPlayer)
+		adminGroup.GET("/shadow-bans", handlers.ListShadowBans)
+		adminGroup.PUT("/players/:player_id/shadow-ban", handlers.ShadowBanPlayer)
+		adminGroup.DELETE("/players/:player_id/shadow-ban", handlers.LiftShadowBan)
+		adminGroup.GET("/players/:player_id/reports", handlers.GetPlayerReports)
+		adminGroup.POST("/forfeits/:forfeit_id/waive", handlers.WaiveForfeit)
+		adminGroup.GET("/users", deps.UserAdminHandlers.ListUsers)
+		adminGroup.GET("/users/:user_id", deps.UserAdminHandlers.GetUser)
+		adminGroup.POST("/users/:user_id/deactivate", deps.UserAdminHandlers.DeactivateUser)
+		adminGroup.POST("/users/:user_id/reactivate", deps.UserAdminHandlers.ReactivateUser)
+		adminGroup.POST("/users/:user_id/password-reset", deps.UserAdminHandlers.SendPasswordReset)
+		adminGroup.PUT("/users/:user_id/role", deps.UserAdminHandlers.SetUserRole)
+		adminGroup.GET("/audit-log", deps.UserAdminHandlers.ListAuditLog)
+		adminGroup.GET("/tags/tree", handlers.GetTagTree)
+		adminGroup.PUT("/tags/:tag_id/parent", handlers.SetTagParent)
+		adminGroup.POST("/cards/tags/bulk", handlers.BulkAssignCardTags)
+		adminGroup.POST("/cards/image-check", handlers.CheckCardImages)
+		adminGroup.POST("/cards/hashes/backfill", handlers.BackfillCardHashes)
+		adminGroup.POST("/cards/similar", handlers.FindSimilarToImage)
+		adminGroup.GET("/cards/:card_id/similar", handlers.FindSimilarCards)
+		adminGroup.GET("/experiments", handlers.GetExperimentStats)
+		adminGroup.PUT("/experiments/:key", handlers.SetExperimentFlag)
+		adminGroup.DELETE("/experiments/:key", handlers.ResetExperimentFlag)
+		adminGroup.PUT("/branding", handlers.UpdateBranding)
+		adminGroup.DELETE("/branding", handlers.ResetBranding)
+		adminGroup.GET("/bots/difficulty-policy", handlers.GetBotDifficultyPolicy)
+		adminGroup.PUT("/bots/difficulty-policy", handlers.UpdateBotDifficultyPolicy)
+		adminGroup.DELETE("/bots/difficulty-policy", handlers.ResetBotDifficultyPolicy)
+		adminGroup.POST("/deck-packs", handlers.PublishDeckPack)
+		adminGroup.DELETE("/deck-packs/:pack_id", handlers.UnpublishDeckPack)
+		adminGroup.POST("/tournaments", deps.TournamentHandlers.CreateTournament)
+		adminGroup.GET("/tournaments", deps.TournamentHandlers.ListTournaments)
+		adminGroup.PUT("/tournaments/:tournament_id/participants", deps.TournamentHandlers.SetParticipants)
+		adminGroup.GET("/tournaments/:tournament_id/deliveries", deps.TournamentHandlers.ListDeliveries)
+		adminGroup.POST("/ladder-deliveries/:delivery_id/retry", deps.TournamentHandlers.RetryDelivery)
 	}
 }
 
 // setupChatRoutes configures chat routes
 func setupChatRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 	chatGroup := api.Group("/chat")
-	chatGroup.Use(auth.GuestOrAuth(deps.JWTService))
+	chatGroup.Use(auth.GuestOrAuth(deps.JWTService), auth.RequireScope(auth.ScopePlay))
 	{
 		chatGroup.POST("/send", handlers.SendChatMessage)
 		chatGroup.GET("/history", handlers.GetChatHistory)
-		chatGroup.GET("/stats", handlers.GetChatStats)
+		chatGroup.GET("/stats", cache.Middleware(cache.ScopeChat, 30*time.Second), handlers.GetChatStats)
+	}
+}
+
+// setupPollRoutes configures the long-polling fallback transport
+func setupPollRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	pollGroup := api.Group("/poll")
+	pollGroup.Use(auth.GuestOrAuth(deps.JWTService), auth.RequireScope(auth.ScopePlay))
+	{
+		pollGroup.GET("/events", deps.PollHandlers.Events)
+		pollGroup.POST("/actions", deps.PollHandlers.Actions)
+		pollGroup.DELETE("/session", deps.PollHandlers.Close)
 	}
 }
 
-// setupWebSocketRoutes configures WebSocket endpoints
+// setupWebSocketRoutes configures WebSocket endpoints. Clients pick a message
+// protocol with ?protocol= (v1 when omitted).
 func setupWebSocketRoutes(r *gin.Engine, jwtService *auth.JWTService) {
 	r.GET("/ws", websocketHandler.HandleWebSocketWithAuth(jwtService))
 }
@@ -0,0 +1,202 @@
+// Package validation turns request binding failures into structured,
+// localized errors. Handlers used to reply with the binder's raw error text,
+// which exposed Go struct and type names; Bind* reply instead with a stable
+// code and one entry per offending field, named as the client sent it.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"dixitme/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// CodeInvalidRequest is the error code of every response written by this package
+const CodeInvalidRequest = "invalid_request"
+
+// Messages, in English. They double as the i18n catalog keys, which clients
+// get alongside the localized text to render their own copy.
+const (
+	msgInvalidRequest = "The request is invalid"
+	msgMissingBody    = "The request body is missing"
+	msgMalformedBody  = "The request body is not valid JSON"
+	msgRequired       = "{1} is required"
+	msgWrongType      = "{1} has the wrong type"
+	msgInvalidField   = "{1} is invalid"
+	msgEmail          = "{1} must be an email address"
+	msgUUID           = "{1} must be a valid ID"
+	msgOneOf          = "{1} must be one of: {2}"
+	msgMinLength      = "{1} must be at least {2} characters"
+	msgMaxLength      = "{1} must be at most {2} characters"
+	msgMinItems       = "{1} needs at least {2} items"
+	msgMaxItems       = "{1} allows at most {2} items"
+	msgMinValue       = "{1} must be at least {2}"
+	msgMaxValue       = "{1} must be at most {2}"
+)
+
+// FieldError describes one rejected field. Key is the untranslated message,
+// with Field and Param as its arguments.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+// Error is a rejected request: a summary message and its fields' errors
+type Error struct {
+	Message string       `json:"message"`
+	Key     string       `json:"key"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func init() {
+	// Report fields by the name the client used rather than the Go field name
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// fieldName is the JSON, query or path name of a struct field
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Translate describes a binding error in a locale
+func Translate(err error, locale string) *Error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			fields = append(fields, translateField(fieldErr, locale))
+		}
+		// A single problem is the most useful summary a client can show
+		summary := Error{Message: i18n.T(locale, msgInvalidRequest), Key: msgInvalidRequest, Fields: fields}
+		if len(fields) == 1 {
+			summary.Message, summary.Key = fields[0].Message, fields[0].Key
+		}
+		return &summary
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := FieldError{Field: typeErr.Field, Rule: "type", Param: typeErr.Type.String(), Key: msgWrongType}
+		field.Message = i18n.T(locale, msgWrongType, field.Field)
+		return &Error{Message: field.Message, Key: field.Key, Fields: []FieldError{field}}
+	}
+
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.Is(err, io.EOF):
+		return newError(locale, msgMissingBody)
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return newError(locale, msgMalformedBody)
+	}
+	return newError(locale, msgInvalidRequest)
+}
+
+func newError(locale, key string) *Error {
+	return &Error{Message: i18n.T(locale, key), Key: key}
+}
+
+// translateField describes one failed validation rule
+func translateField(fieldErr validator.FieldError, locale string) FieldError {
+	field := FieldError{Field: fieldErr.Field(), Rule: fieldErr.Tag(), Param: fieldErr.Param()}
+	field.Key = messageFor(fieldErr)
+	if field.Rule == "oneof" {
+		field.Message = i18n.T(locale, field.Key, field.Field, strings.ReplaceAll(field.Param, " ", ", "))
+	} else {
+		field.Message = i18n.T(locale, field.Key, field.Field, field.Param)
+	}
+	return field
+}
+
+// messageFor picks the message of a rule, by the kind of value it checks
+func messageFor(fieldErr validator.FieldError) string {
+	kind := fieldErr.Kind()
+	sized := func(text, items, value string) string {
+		switch kind {
+		case reflect.String:
+			return text
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return items
+		default:
+			return value
+		}
+	}
+
+	switch fieldErr.Tag() {
+	case "required":
+		return msgRequired
+	case "email":
+		return msgEmail
+	case "uuid", "uuid4":
+		return msgUUID
+	case "oneof":
+		return msgOneOf
+	case "min", "gte":
+		return sized(msgMinLength, msgMinItems, msgMinValue)
+	case "max", "lte":
+		return sized(msgMaxLength, msgMaxItems, msgMaxValue)
+	}
+	return msgInvalidField
+}
+
+// Respond replies 400 to a request that failed to bind, in the client's language
+func Respond(c *gin.Context, err error) {
+	locale, _ := i18n.FromRequest(c.Request)
+	if locale == "" {
+		locale = i18n.DefaultLocale
+	}
+	validationErr := Translate(err, locale)
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":   validationErr.Message,
+		"code":    CodeInvalidRequest,
+		"details": validationErr,
+	})
+}
+
+// BindJSON binds the request body, responding 400 when it doesn't validate
+func BindJSON(c *gin.Context, req interface{}) bool {
+	return bind(c, c.ShouldBindJSON(req))
+}
+
+// BindQuery binds the query string, responding 400 when it doesn't validate
+func BindQuery(c *gin.Context, req interface{}) bool {
+	return bind(c, c.ShouldBindQuery(req))
+}
+
+// BindURI binds the path parameters, responding 400 when they don't validate
+func BindURI(c *gin.Context, req interface{}) bool {
+	return bind(c, c.ShouldBindUri(req))
+}
+
+func bind(c *gin.Context, err error) bool {
+	if err != nil {
+		Respond(c, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type signupRequest struct {
+	Email    string   `json:"email" binding:"required,email"`
+	Password string   `json:"password" binding:"required,min=8"`
+	Level    string   `json:"level" binding:"omitempty,oneof=easy medium hard"`
+	Tags     []string `json:"tags" binding:"omitempty,max=2"`
+}
+
+type response struct {
+	Error   string `json:"error"`
+	Code    string `json:"code"`
+	Details Error  `json:"details"`
+}
+
+func bindBody(t *testing.T, body, acceptLanguage string) (bool, *httptest.ResponseRecorder) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if acceptLanguage != "" {
+		c.Request.Header.Set("Accept-Language", acceptLanguage)
+	}
+	var req signupRequest
+	return BindJSON(c, &req), recorder
+}
+
+func decode(t *testing.T, recorder *httptest.ResponseRecorder) response {
+	t.Helper()
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	var resp response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, CodeInvalidRequest, resp.Code)
+	return resp
+}
+
+func TestBindJSONAcceptsValidBody(t *testing.T) {
+	ok, recorder := bindBody(t, `{"email":"ana@example.com","password":"long enough"}`, "")
+	assert.True(t, ok)
+	assert.Empty(t, recorder.Body.String())
+}
+
+func TestBindJSONReportsFieldsByTheirJSONName(t *testing.T) {
+	ok, recorder := bindBody(t, `{"email":"nope","password":"short","level":"expert","tags":["a","b","c"]}`, "")
+	require.False(t, ok)
+
+	resp := decode(t, recorder)
+	assert.Equal(t, "The request is invalid", resp.Error)
+	require.Len(t, resp.Details.Fields, 4)
+	assert.Equal(t, FieldError{Field: "email", Rule: "email", Key: msgEmail, Message: "email must be an email address"}, resp.Details.Fields[0])
+	assert.Equal(t, "password must be at least 8 characters", resp.Details.Fields[1].Message)
+	assert.Equal(t, "level must be one of: easy, medium, hard", resp.Details.Fields[2].Message)
+	assert.Equal(t, "tags allows at most 2 items", resp.Details.Fields[3].Message)
+	assert.NotContains(t, recorder.Body.String(), "signupRequest", "Go type names stay out of responses")
+}
+
+func TestBindJSONLocalizesMessages(t *testing.T) {
+	_, recorder := bindBody(t, `{"password":"long enough"}`, "fr-FR,fr;q=0.9")
+
+	resp := decode(t, recorder)
+	assert.Equal(t, "email est obligatoire", resp.Error, "a single problem is the summary")
+	assert.Equal(t, msgRequired, resp.Details.Key)
+	require.Len(t, resp.Details.Fields, 1)
+	assert.Equal(t, "required", resp.Details.Fields[0].Rule)
+}
+
+func TestBindJSONDescribesMalformedBodies(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		key   string
+		field string
+	}{
+		{name: "empty", body: "", key: msgMissingBody},
+		{name: "syntax", body: `{"email":`, key: msgMalformedBody},
+		{name: "wrong type", body: `{"email":42}`, key: msgWrongType, field: "email"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, recorder := bindBody(t, tt.body, "")
+			resp := decode(t, recorder)
+			assert.Equal(t, tt.key, resp.Details.Key)
+			if tt.field != "" {
+				require.Len(t, resp.Details.Fields, 1)
+				assert.Equal(t, tt.field, resp.Details.Fields[0].Field)
+			}
+		})
+	}
+}
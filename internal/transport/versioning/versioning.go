@@ -0,0 +1,130 @@
+// Package versioning negotiates API and realtime protocol versions.
+//
+// REST versions are path based (/api/v1, /api/v2) and share handlers unless a
+// version needs a different payload. Realtime clients pick a protocol with the
+// ?protocol= query parameter. Deprecated versions keep working but advertise
+// Deprecation, Sunset and Link headers, and every request is counted per version
+// so usage can be tracked before a version is retired.
+package versioning
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"dixitme/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Supported versions
+const (
+	V1     = 1
+	V2     = 2
+	Latest = V2
+)
+
+const contextKey = "api_version"
+
+// Deprecation describes the retirement plan for a version
+type Deprecation struct {
+	Deprecated bool
+	Sunset     time.Time // When the version stops working (zero if not scheduled)
+	Link       string    // Migration guide
+}
+
+// Config holds the deprecation policy per version
+type Config struct {
+	V1 Deprecation
+}
+
+var config Config
+
+// Configure sets the deprecation policy
+func Configure(cfg Config) {
+	config = cfg
+}
+
+// IsSupported reports whether a version can be requested
+func IsSupported(version int) bool {
+	return version >= V1 && version <= Latest
+}
+
+func deprecationFor(version int) Deprecation {
+	if version == V1 {
+		return config.V1
+	}
+	return Deprecation{}
+}
+
+// Headers returns the version and deprecation headers for a response
+func Headers(version int) http.Header {
+	header := http.Header{}
+	header.Set("API-Version", strconv.Itoa(version))
+
+	deprecation := deprecationFor(version)
+	if !deprecation.Deprecated {
+		return header
+	}
+
+	header.Set("Deprecation", "true")
+	if !deprecation.Sunset.IsZero() {
+		header.Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if deprecation.Link != "" {
+		header.Set("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", deprecation.Link))
+	}
+	return header
+}
+
+// Middleware tags requests to a versioned route group with its version
+func Middleware(version int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(contextKey, version)
+		for key, values := range Headers(version) {
+			c.Header(key, values[0])
+		}
+
+		metrics.GetCounter(fmt.Sprintf("api_v%d_requests_total", version)).Inc()
+		if deprecationFor(version).Deprecated {
+			metrics.GetCounter("api_deprecated_requests_total").Inc()
+		}
+
+		c.Next()
+	}
+}
+
+// FromContext returns the version of the route group serving the request
+func FromContext(c *gin.Context) int {
+	if version, ok := c.Get(contextKey); ok {
+		if v, ok := version.(int); ok {
+			return v
+		}
+	}
+	return V1
+}
+
+// NegotiateProtocol reads the realtime protocol requested with ?protocol=.
+// Without one it follows the route group's version, which is v1 for /ws so
+// clients that predate versioning keep their message shapes.
+func NegotiateProtocol(c *gin.Context) (int, error) {
+	requested := c.Query("protocol")
+	if requested == "" {
+		return FromContext(c), nil
+	}
+
+	version, err := strconv.Atoi(requested)
+	if err != nil || !IsSupported(version) {
+		return 0, fmt.Errorf("unsupported protocol version: %s", requested)
+	}
+	return version, nil
+}
+
+// RecordProtocol counts a realtime session on a protocol version
+func RecordProtocol(transport string, version int) {
+	metrics.GetCounter(fmt.Sprintf("%s_protocol_v%d_sessions_total", transport, version)).Inc()
+	if deprecationFor(version).Deprecated {
+		metrics.GetCounter("deprecated_protocol_sessions_total").Inc()
+	}
+}
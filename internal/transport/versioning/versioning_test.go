@@ -0,0 +1,61 @@
+package versioning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadersForCurrentVersion(t *testing.T) {
+	Configure(Config{V1: Deprecation{Deprecated: true}})
+	defer Configure(Config{})
+
+	header := Headers(V2)
+	assert.Equal(t, "2", header.Get("API-Version"))
+	assert.Empty(t, header.Get("Deprecation"))
+	assert.Empty(t, header.Get("Sunset"))
+}
+
+func TestHeadersForDeprecatedVersion(t *testing.T) {
+	sunset := time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC)
+	Configure(Config{V1: Deprecation{Deprecated: true, Sunset: sunset, Link: "https://example.com/migrate"}})
+	defer Configure(Config{})
+
+	header := Headers(V1)
+	assert.Equal(t, "1", header.Get("API-Version"))
+	assert.Equal(t, "true", header.Get("Deprecation"))
+	assert.Equal(t, "Wed, 30 Jun 2027 00:00:00 GMT", header.Get("Sunset"))
+	assert.Equal(t, `<https://example.com/migrate>; rel="deprecation"`, header.Get("Link"))
+}
+
+func TestNegotiateProtocol(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		version int
+		wantErr bool
+	}{
+		"":             {version: V1},
+		"?protocol=1":  {version: V1},
+		"?protocol=2":  {version: V2},
+		"?protocol=3":  {wantErr: true},
+		"?protocol=v2": {wantErr: true},
+	}
+
+	for query, want := range cases {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/ws"+query, nil)
+
+		version, err := NegotiateProtocol(c)
+		if want.wantErr {
+			assert.Error(t, err, query)
+			continue
+		}
+		assert.NoError(t, err, query)
+		assert.Equal(t, want.version, version, query)
+	}
+}
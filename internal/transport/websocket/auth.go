@@ -1,6 +1,10 @@
 package websocket
 
 import (
+	"errors"
+	"net/http"
+
+	"dixitme/internal/logger"
 	"dixitme/internal/services/auth"
 
 	"github.com/gin-gonic/gin"
@@ -29,6 +33,16 @@ func extractTokenFromWebSocket(c *gin.Context) string {
 	return ""
 }
 
+// extractResumeClaims validates the resume token a reconnecting client presents.
+// It returns nil claims when no token was given.
+func extractResumeClaims(c *gin.Context, jwtService *auth.JWTService) (*auth.ResumeClaims, error) {
+	token := c.Query("resume_token")
+	if token == "" {
+		return nil, nil
+	}
+	return jwtService.ValidateResumeToken(token)
+}
+
 // extractPlayerInfo extracts player information from authentication context
 func extractPlayerInfo(c *gin.Context, jwtService *auth.JWTService) (uuid.UUID, *auth.UserInfo, error) {
 	var playerID uuid.UUID
@@ -39,18 +53,22 @@ func extractPlayerInfo(c *gin.Context, jwtService *auth.JWTService) (uuid.UUID,
 	if token != "" {
 		if info, err := jwtService.ExtractUserInfo(token); err == nil {
 			userInfo = info
-			playerID = info.SessionID // Use session ID as player ID for consistency
+			playerID = info.PlayerID()
 			return playerID, userInfo, nil
 		}
 	}
 
-	// If no valid auth, fall back to legacy behavior
+	// If no valid auth, fall back to legacy behavior. A registered user's
+	// player ID is public, so only their JWT may claim it.
 	playerIDStr := c.Query("player_id")
 	if playerIDStr != "" {
 		parsedID, err := uuid.Parse(playerIDStr)
 		if err != nil {
 			return uuid.Nil, nil, err
 		}
+		if err := auth.CheckGuestPlayerID(parsedID); err != nil {
+			return uuid.Nil, nil, err
+		}
 		playerID = parsedID
 	} else {
 		playerID = uuid.New()
@@ -58,3 +76,16 @@ func extractPlayerInfo(c *gin.Context, jwtService *auth.JWTService) (uuid.UUID,
 
 	return playerID, nil, nil
 }
+
+// respondGuestIDError refuses a connection whose guest player ID was rejected
+func respondGuestIDError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, auth.ErrRegisteredPlayerID):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Unwrap(err) != nil:
+		logger.Error("Failed to check guest player ID", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check player ID"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+	}
+}
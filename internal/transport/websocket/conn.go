@@ -0,0 +1,81 @@
+package websocket
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"dixitme/internal/services/game"
+
+	"github.com/gorilla/websocket"
+)
+
+// closeSessionReplaced is the application close code sent to a connection the
+// player replaced by connecting again elsewhere
+const closeSessionReplaced = 4000
+
+// wsConnection adapts a gorilla WebSocket to game.Connection.
+// gorilla connections allow only one concurrent writer, so writes are serialized.
+type wsConnection struct {
+	conn          *websocket.Conn
+	protocol      int
+	locale        string
+	admin         bool   // Authenticated with the admin scope
+	authenticated bool   // Opened with a session JWT
+	resumeRoom    string // Room of the resume token it was opened with
+	mu            sync.Mutex
+}
+
+func newConnection(conn *websocket.Conn, protocol int, locale string) *wsConnection {
+	return &wsConnection{conn: conn, protocol: protocol, locale: locale}
+}
+
+// Send writes an encoded message as a text frame
+func (c *wsConnection) Send(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// SendJSON writes a message as a JSON text frame
+func (c *wsConnection) SendJSON(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteJSON(v)
+}
+
+// Transport reports the WebSocket transport
+func (c *wsConnection) Transport() string {
+	return game.TransportWebSocket
+}
+
+// ProtocolVersion reports the protocol negotiated during the upgrade
+func (c *wsConnection) ProtocolVersion() int {
+	return c.protocol
+}
+
+// Close ends a connection that another connection took over, telling the
+// client why before dropping it
+func (c *wsConnection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	message := websocket.FormatCloseMessage(closeSessionReplaced, "session replaced")
+	_ = c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(time.Second))
+	return c.conn.Close()
+}
+
+// IsAdmin reports whether the connection was opened with an admin token
+func (c *wsConnection) IsAdmin() bool {
+	return c.admin
+}
+
+// ProvesSeat reports whether the client proved its player ID for a room, with
+// a session JWT or a resume token for that room, rather than just naming it
+func (c *wsConnection) ProvesSeat(roomCode string) bool {
+	return c.authenticated || (c.resumeRoom != "" && strings.EqualFold(c.resumeRoom, roomCode))
+}
+
+// Locale reports the language negotiated during the upgrade
+func (c *wsConnection) Locale() string {
+	return c.locale
+}
@@ -2,10 +2,13 @@ package websocket
 
 import (
 	"net/http"
+	"strconv"
 
+	"dixitme/internal/i18n"
 	"dixitme/internal/logger"
 	"dixitme/internal/services/auth"
 	"dixitme/internal/services/game"
+	"dixitme/internal/transport/versioning"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -33,35 +36,86 @@ func HandleWebSocket(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
 			return
 		}
+		if err := auth.CheckGuestPlayerID(playerID); err != nil {
+			respondGuestIDError(c, err)
+			return
+		}
 	} else {
 		playerID = uuid.New()
 	}
 
-	handleWebSocketConnection(c, playerID, nil)
+	handleWebSocketConnection(c, playerID, nil, versioning.V1, NegotiateLocale(c.Request, nil), "")
 }
 
 // HandleWebSocketWithAuth handles WebSocket connections with authentication support
 func HandleWebSocketWithAuth(jwtService *auth.JWTService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		resume, err := extractResumeClaims(c, jwtService)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired resume token"})
+			return
+		}
+
 		playerID, userInfo, err := extractPlayerInfo(c, jwtService)
+
+		// The resume token decides the identity, so an expired JWT can't
+		// turn a reconnecting player into a new one
+		resumeRoom := ""
+		if resume != nil {
+			if userInfo == nil || userInfo.PlayerID() != resume.PlayerID {
+				playerID, userInfo, err = resume.PlayerID, nil, nil
+			}
+			resumeRoom = resume.RoomCode
+		}
+
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+			respondGuestIDError(c, err)
+			return
+		}
+		if userInfo != nil && !userInfo.HasScope(auth.ScopePlay) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Token is missing the play scope"})
+			return
+		}
+
+		protocol, err := versioning.NegotiateProtocol(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		handleWebSocketConnection(c, playerID, userInfo)
+		handleWebSocketConnection(c, playerID, userInfo, protocol, NegotiateLocale(c.Request, userInfo), resumeRoom)
 	}
 }
 
-// handleWebSocketConnection handles the actual WebSocket connection logic
-func handleWebSocketConnection(c *gin.Context, playerID uuid.UUID, userInfo *auth.UserInfo) {
+// handleWebSocketConnection handles the actual WebSocket connection logic.
+// When resumeRoom is set, the player is reattached to their seat in that room.
+func handleWebSocketConnection(c *gin.Context, playerID uuid.UUID, userInfo *auth.UserInfo, protocol int, locale, resumeRoom string) {
+	if err := game.GetManager().CheckConnectionCapacity(playerID); err != nil {
+		logger.Warn("Refused WebSocket connection, server at capacity", "player_id", playerID)
+		RespondAtCapacity(c, err)
+		return
+	}
+
+	// Only a client that proved the player ID may move a live session here
+	proven := userInfo != nil || resumeRoom != ""
+	if err := game.CheckConnectionTakeover(playerID, proven); err != nil {
+		logger.Warn("Refused WebSocket connection, player already connected", "player_id", playerID)
+		RespondConnectionInUse(c, err)
+		return
+	}
+
 	// Upgrade HTTP connection to WebSocket
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, versioning.Headers(protocol))
 	if err != nil {
 		logger.Error("Failed to upgrade to WebSocket", "error", err)
 		return
 	}
 	defer conn.Close()
+	client := newConnection(conn, protocol, locale)
+	client.admin = userInfo != nil && userInfo.HasScope(auth.ScopeAdmin)
+	client.authenticated = userInfo != nil
+	client.resumeRoom = resumeRoom
+	versioning.RecordProtocol(game.TransportWebSocket, protocol)
 
 	var playerName string
 	var authType string
@@ -76,8 +130,15 @@ func handleWebSocketConnection(c *gin.Context, playerID uuid.UUID, userInfo *aut
 		logger.Info("Guest WebSocket connection established", "player_id", playerID)
 	}
 
-	// Register this connection in the game package registry
-	game.RegisterPlayerConnection(playerID, conn)
+	// Register this connection in the game package registry; a second tab
+	// that proved the player ID takes the connection over from the first
+	previous, err := game.ClaimPlayerConnection(playerID, client, proven)
+	if err != nil {
+		// Another connection for the player got in since the check above
+		SendErrorFor(client, err)
+		return
+	}
+	game.GetManager().TakeOverConnection(playerID, previous, client)
 
 	// Send initial connection confirmation
 	welcomeMsg := game.GameMessage{
@@ -87,13 +148,33 @@ func handleWebSocketConnection(c *gin.Context, playerID uuid.UUID, userInfo *aut
 			"player_name":   playerName,
 			"auth_type":     authType,
 			"authenticated": userInfo != nil,
+			"protocol":      protocol,
+			"locale":        locale,
 		},
 	}
-	if err := conn.WriteJSON(welcomeMsg); err != nil {
+	if err := client.SendJSON(welcomeMsg); err != nil {
 		logger.Error("Failed to send welcome message", "error", err, "player_id", playerID)
 		return
 	}
 
+	if resumeRoom != "" {
+		if err := game.GetManager().ResumeSeat(resumeRoom, playerID, client); err != nil {
+			logger.Warn("Failed to resume seat", "error", err, "player_id", playerID, "room_code", resumeRoom)
+			SendError(client, err.Error())
+		}
+	} else if userInfo != nil {
+		// Same signed-in player back after a drop: pick up the seats they
+		// still hold. A guest's bare player ID is public, so guests come back
+		// with their resume token instead.
+		if rooms := game.GetManager().ReconnectPlayer(playerID, client); len(rooms) > 0 {
+			logger.Info("Restored seats on reconnect", "player_id", playerID, "rooms", rooms)
+		}
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	measureLatency(conn, playerID, done)
+
 	// Handle incoming messages
 	for {
 		var msg ConnectionMessage
@@ -105,21 +186,22 @@ func handleWebSocketConnection(c *gin.Context, playerID uuid.UUID, userInfo *aut
 		}
 
 		// Update player activity on every message
-		updatePlayerActivity(playerID)
+		UpdatePlayerActivity(playerID)
 
-		if err := handleMessage(conn, playerID, msg); err != nil {
+		if err := HandleMessage(client, playerID, msg); err != nil {
 			logger.Error("Error handling WebSocket message", "error", err, "player_id", playerID, "message_type", msg.Type)
-			sendError(conn, err.Error())
+			SendErrorFor(client, err)
 		}
 	}
 
-	// Clean up on disconnect
-	game.UnregisterPlayerConnection(playerID)
-	handleDisconnect(playerID)
+	// Clean up on disconnect, unless another connection took over
+	if game.UnregisterPlayerConnection(playerID, client) {
+		HandleDisconnect(playerID)
+	}
 }
 
-// updatePlayerActivity updates the player's last activity in all their games
-func updatePlayerActivity(playerID uuid.UUID) {
+// UpdatePlayerActivity updates the player's last activity in all their games
+func UpdatePlayerActivity(playerID uuid.UUID) {
 	manager := game.GetManager()
 
 	// Find all games the player is in and update their activity
@@ -132,19 +214,22 @@ func updatePlayerActivity(playerID uuid.UUID) {
 	}
 }
 
-// handleDisconnect cleans up when a player disconnects
-func handleDisconnect(playerID uuid.UUID) {
+// HandleDisconnect cleans up when a player disconnects
+func HandleDisconnect(playerID uuid.UUID) {
 	log := logger.GetLogger()
 	manager := game.GetManager()
 
 	log.Info("Player disconnected", "player_id", playerID)
 
 	// Mark player as disconnected in all their games
+	var voiceRooms []string
 	for _, gameState := range manager.GetAllGames() {
 		gameState.Lock()
 		if player, exists := gameState.Players[playerID]; exists && !player.IsBot {
-			player.IsConnected = false
-			player.Connection = nil
+			if player.VoiceJoined {
+				voiceRooms = append(voiceRooms, gameState.RoomCode)
+			}
+			player.MarkDisconnected(gameState, game.DisconnectClosed)
 			player.UpdateActivity() // Update activity timestamp on disconnect
 
 			log.Info("Marked player as disconnected in game",
@@ -157,13 +242,60 @@ func handleDisconnect(playerID uuid.UUID) {
 		}
 		gameState.Unlock()
 	}
+
+	// A dropped connection can't carry audio signaling, so hang up voice for peers
+	for _, roomCode := range voiceRooms {
+		if err := manager.LeaveVoice(roomCode, playerID); err != nil {
+			log.Warn("Failed to leave voice on disconnect", "error", err, "room_code", roomCode)
+		}
+	}
 }
 
-// sendError sends an error message to the WebSocket client
-func sendError(conn *websocket.Conn, message string) error {
+// SendError sends an error message to the client
+func SendError(conn game.Connection, message string) error {
 	errorMsg := game.GameMessage{
 		Type:    game.MessageTypeError,
-		Payload: game.ErrorPayload{Message: message},
+		Payload: game.ErrorPayload{Message: i18n.T(conn.Locale(), message)},
+	}
+	return conn.SendJSON(errorMsg)
+}
+
+// RespondAtCapacity answers an HTTP request refused for capacity with 503 and
+// a Retry-After header. It reports whether err was a capacity error.
+func RespondAtCapacity(c *gin.Context, err error) bool {
+	retryAfter, ok := game.RetryAfterSeconds(err)
+	if !ok {
+		return false
 	}
-	return conn.WriteJSON(errorMsg)
+	gameErr, _ := game.AsGameError(err)
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.JSON(http.StatusServiceUnavailable, gin.H{"error": gameErr.Message, "code": gameErr.Code, "details": gameErr.Details})
+	return true
+}
+
+// RespondConnectionInUse answers with 409 if err refuses a connection for a
+// player who is still connected, reporting whether it did
+func RespondConnectionInUse(c *gin.Context, err error) bool {
+	gameErr, ok := game.AsGameError(err)
+	if !ok || gameErr.Code != game.ErrCodeConnectionInUse {
+		return false
+	}
+	c.JSON(http.StatusConflict, gin.H{"error": gameErr.Message, "code": gameErr.Code})
+	return true
+}
+
+// SendErrorFor sends an error to the client, keeping the code and details of structured game errors
+func SendErrorFor(conn game.Connection, err error) error {
+	gameErr, ok := game.AsGameError(err)
+	if !ok {
+		return SendError(conn, err.Error())
+	}
+	return conn.SendJSON(game.GameMessage{
+		Type: game.MessageTypeError,
+		Payload: game.ErrorPayload{
+			Message: i18n.T(conn.Locale(), gameErr.Message),
+			Code:    gameErr.Code,
+			Details: gameErr.Details,
+		},
+	})
 }
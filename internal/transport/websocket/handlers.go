@@ -4,15 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
 	"dixitme/internal/models"
+	"dixitme/internal/services/bot"
 	"dixitme/internal/services/game"
 
 	"github.com/google/uuid"
-	"github.com/gorilla/websocket"
 )
 
-// handleMessage routes incoming WebSocket messages to appropriate handlers
-func handleMessage(conn *websocket.Conn, playerID uuid.UUID, msg ConnectionMessage) error {
+// HandleMessage routes incoming client messages to appropriate handlers.
+// It is shared by every transport that carries ConnectionMessage envelopes.
+func HandleMessage(conn game.Connection, playerID uuid.UUID, msg ConnectionMessage) error {
 	manager := game.GetManager()
 
 	switch msg.Type {
@@ -26,6 +29,8 @@ func handleMessage(conn *websocket.Conn, playerID uuid.UUID, msg ConnectionMessa
 		return handleStartGame(msg, manager, playerID)
 	case ClientMessageSubmitClue:
 		return handleSubmitClue(msg, manager, playerID)
+	case ClientMessageMulligan:
+		return handleMulligan(msg, manager, playerID)
 	case ClientMessageSubmitCard:
 		return handleSubmitCard(msg, manager, playerID)
 	case ClientMessageSubmitVote:
@@ -35,20 +40,54 @@ func handleMessage(conn *websocket.Conn, playerID uuid.UUID, msg ConnectionMessa
 	case ClientMessageSendChat:
 		return handleSendChat(msg, manager, playerID)
 	case ClientMessageGetChatHistory:
-		return handleGetChatHistory(conn, msg, manager)
+		return handleGetChatHistory(conn, msg, manager, playerID)
+	case ClientMessageModerateChat:
+		return handleModerateChat(msg, manager, playerID)
+	case ClientMessageReportPlayer:
+		return handleReportPlayer(msg, manager, playerID)
+	case ClientMessageUpdateSettings:
+		return handleUpdateSettings(msg, manager, playerID)
+	case ClientMessageVoiceJoin:
+		return handleVoiceJoin(msg, manager, playerID)
+	case ClientMessageVoiceLeave:
+		return handleVoiceLeave(msg, manager, playerID)
+	case ClientMessageVoiceSignal:
+		return handleVoiceSignal(msg, manager, playerID)
+	case ClientMessageVoiceState:
+		return handleVoiceState(msg, manager, playerID)
+	case ClientMessageDebugStream:
+		return handleDebugStream(conn, msg, manager, playerID)
+	case ClientMessageStartKickVote:
+		return handleStartKickVote(msg, manager, playerID)
+	case ClientMessageCastKickVote:
+		return handleCastKickVote(msg, manager, playerID)
+	case ClientMessageRequestTakeover:
+		return handleRequestBotTakeover(msg, manager, playerID)
+	case ClientMessageResolveTakeover:
+		return handleResolveBotTakeover(msg, manager, playerID)
+	case ClientMessageRematch:
+		return handleRematch(msg, manager, playerID)
 	default:
-		return sendError(conn, "Unknown message type: "+msg.Type)
+		return SendError(conn, "Unknown message type: "+msg.Type)
 	}
 }
 
 // handleCreateGame handles game creation requests
-func handleCreateGame(conn *websocket.Conn, playerID uuid.UUID, msg ConnectionMessage, manager *game.Manager) error {
+func handleCreateGame(conn game.Connection, playerID uuid.UUID, msg ConnectionMessage, manager *game.Manager) error {
 	var payload CreateGamePayload
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		return err
 	}
 
-	gameState, err := manager.CreateGame(payload.RoomCode, playerID, payload.PlayerName)
+	gameState, err := manager.CreateGameWithOptions(payload.RoomCode, playerID, payload.PlayerName, game.CreateGameOptions{
+		Sandbox:  payload.Sandbox,
+		Pace:     payload.Pace,
+		Private:  payload.Private,
+		Password: payload.Password,
+		Rules:    payload.Rules,
+		Packs:    payload.Packs,
+		DeckID:   payload.DeckID,
+	})
 	if err != nil {
 		return err
 	}
@@ -59,20 +98,26 @@ func handleCreateGame(conn *websocket.Conn, playerID uuid.UUID, msg ConnectionMe
 	}
 
 	// Send game state
-	return conn.WriteJSON(game.GameMessage{
-		Type:    game.MessageTypeGameState,
-		Payload: game.GameStatePayload{GameState: gameState},
-	})
+	return conn.SendJSON(game.GameStateMessage(gameState, playerID, conn.ProtocolVersion()))
 }
 
 // handleJoinGame handles game join requests
-func handleJoinGame(conn *websocket.Conn, playerID uuid.UUID, msg ConnectionMessage, manager *game.Manager) error {
+func handleJoinGame(conn game.Connection, playerID uuid.UUID, msg ConnectionMessage, manager *game.Manager) error {
 	var payload JoinGamePayload
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		return err
 	}
 
-	gameState, err := manager.JoinGame(payload.RoomCode, playerID, payload.PlayerName)
+	// Player IDs are public; the connection knows whether the client proved its own
+	verified := false
+	if prover, ok := conn.(interface{ ProvesSeat(roomCode string) bool }); ok {
+		verified = prover.ProvesSeat(payload.RoomCode)
+	}
+
+	gameState, err := manager.JoinGameWithOptions(payload.RoomCode, playerID, payload.PlayerName, game.JoinGameOptions{
+		Password: payload.Password,
+		Verified: verified,
+	})
 	if err != nil {
 		return err
 	}
@@ -82,11 +127,8 @@ func handleJoinGame(conn *websocket.Conn, playerID uuid.UUID, msg ConnectionMess
 		player.Connection = conn
 	}
 
-	// Send game state
-	return conn.WriteJSON(game.GameMessage{
-		Type:    game.MessageTypeGameState,
-		Payload: game.GameStatePayload{GameState: gameState},
-	})
+	// The manager already sent the recent chat to the player's connection
+	return conn.SendJSON(game.GameStateMessage(gameState, playerID, conn.ProtocolVersion()))
 }
 
 // handleAddBot handles add bot requests
@@ -97,15 +139,26 @@ func handleAddBot(msg ConnectionMessage, manager *game.Manager, playerID uuid.UU
 	}
 
 	// Validate bot level
-	validLevels := map[string]bool{"easy": true, "medium": true, "hard": true}
+	validLevels := map[string]bool{"easy": true, "medium": true, "hard": true, bot.LevelAuto: true}
 	if payload.BotLevel == "" {
 		payload.BotLevel = "medium" // Default level
 	}
 	if !validLevels[payload.BotLevel] {
-		return fmt.Errorf("invalid bot level. Must be easy, medium, or hard")
+		return fmt.Errorf("invalid bot level. Must be easy, medium, hard or auto")
 	}
 
-	_, err := manager.AddBot(payload.RoomCode, payload.BotLevel)
+	_, err := manager.AddBotAsHost(payload.RoomCode, playerID, payload.BotLevel)
+	return err
+}
+
+// handleUpdateSettings handles lobby settings changes
+func handleUpdateSettings(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload UpdateSettingsPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	_, err := manager.UpdateGameSettings(payload.RoomCode, playerID, payload.Settings)
 	return err
 }
 
@@ -119,6 +172,28 @@ func handleStartGame(msg ConnectionMessage, manager *game.Manager, playerID uuid
 	return manager.StartGame(payload.RoomCode, playerID)
 }
 
+// handleRematch handles a player asking for a rematch of a finished game.
+// The new lobby reaches the table through the rematch broadcast.
+func handleRematch(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload RematchPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	_, err := manager.Rematch(payload.RoomCode, playerID)
+	return err
+}
+
+// handleMulligan handles a storyteller exchanging their hand
+func handleMulligan(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload MulliganPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	return manager.Mulligan(payload.RoomCode, playerID)
+}
+
 // handleSubmitClue handles clue submission requests
 func handleSubmitClue(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
 	var payload SubmitCluePayload
@@ -146,7 +221,10 @@ func handleSubmitVote(msg ConnectionMessage, manager *game.Manager, playerID uui
 		return err
 	}
 
-	return manager.SubmitVote(payload.RoomCode, playerID, payload.CardID)
+	return manager.SubmitVoteWithOptions(payload.RoomCode, playerID, payload.CardID, game.VoteOptions{
+		Weight:     payload.Weight,
+		DoubleDown: payload.DoubleDown,
+	})
 }
 
 // handleLeaveGame handles leave game requests
@@ -169,8 +247,70 @@ func handleSendChat(msg ConnectionMessage, manager *game.Manager, playerID uuid.
 	return manager.SendChatMessage(payload.RoomCode, playerID, payload.Message, payload.MessageType)
 }
 
+// handleModerateChat handles the host changing the room's chat controls
+func handleModerateChat(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload ModerateChatPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	_, err := manager.SetChatModeration(payload.RoomCode, playerID, payload.Moderation)
+	return err
+}
+
+// handleReportPlayer handles a player reporting another player in their room
+func handleReportPlayer(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload ReportPlayerPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	return manager.ReportPlayer(payload.RoomCode, playerID, payload.ReportedID, payload.Reason)
+}
+
+// handleStartKickVote handles a player opening a vote on kicking another
+func handleStartKickVote(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload StartKickVotePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	return manager.StartKickVote(payload.RoomCode, playerID, payload.TargetID)
+}
+
+// handleCastKickVote handles a player's ballot on the open kick vote
+func handleCastKickVote(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload CastKickVotePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	return manager.CastKickVote(payload.RoomCode, playerID, payload.Kick)
+}
+
+// handleRequestBotTakeover handles a player asking the host for a bot's seat
+func handleRequestBotTakeover(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload RequestBotTakeoverPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	return manager.RequestBotTakeover(payload.RoomCode, playerID, payload.PlayerName, payload.BotID)
+}
+
+// handleResolveBotTakeover handles the host answering a request for a bot's seat
+func handleResolveBotTakeover(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload ResolveBotTakeoverPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	_, err := manager.ResolveBotTakeover(payload.RoomCode, playerID, payload.BotID, payload.Approve)
+	return err
+}
+
 // handleGetChatHistory handles chat history requests
-func handleGetChatHistory(conn *websocket.Conn, msg ConnectionMessage, manager *game.Manager) error {
+func handleGetChatHistory(conn game.Connection, msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
 	var payload GetChatHistoryPayload
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		return err
@@ -181,13 +321,13 @@ func handleGetChatHistory(conn *websocket.Conn, msg ConnectionMessage, manager *
 		limit = 50
 	}
 
-	messages, err := manager.GetChatHistory(payload.RoomCode, payload.Phase, limit)
+	messages, err := manager.GetChatHistory(payload.RoomCode, playerID, payload.Phase, limit, conn.Locale())
 	if err != nil {
 		return err
 	}
 
 	// Send chat history back to requesting client
-	return conn.WriteJSON(game.GameMessage{
+	return conn.SendJSON(game.GameMessage{
 		Type: game.MessageTypeChatHistory,
 		Payload: game.ChatHistoryPayload{
 			Messages: messages,
@@ -196,6 +336,61 @@ func handleGetChatHistory(conn *websocket.Conn, msg ConnectionMessage, manager *
 	})
 }
 
+// handleDebugStream starts or stops the room's debug stream for the player.
+// Admin WebSocket sessions may watch any room.
+func handleDebugStream(conn game.Connection, msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload DebugStreamPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	admin := false
+	if adminConn, ok := conn.(interface{ IsAdmin() bool }); ok {
+		admin = adminConn.IsAdmin()
+	}
+	return manager.WatchDebugStream(payload.RoomCode, playerID, payload.Watch, admin)
+}
+
+// handleVoiceJoin handles requests to join the room's voice channel
+func handleVoiceJoin(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload VoiceRoomPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	return manager.JoinVoice(payload.RoomCode, playerID)
+}
+
+// handleVoiceLeave handles requests to leave the room's voice channel
+func handleVoiceLeave(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload VoiceRoomPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	return manager.LeaveVoice(payload.RoomCode, playerID)
+}
+
+// handleVoiceSignal relays WebRTC offers, answers and ICE candidates to a peer
+func handleVoiceSignal(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload VoiceSignalPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	return manager.RelayVoiceSignal(payload.RoomCode, playerID, payload.To, payload.VoiceSignal)
+}
+
+// handleVoiceState handles speaking indicator and mute updates
+func handleVoiceState(msg ConnectionMessage, manager *game.Manager, playerID uuid.UUID) error {
+	var payload VoiceStatePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	return manager.SetVoiceState(payload.RoomCode, playerID, payload.Speaking, payload.Muted)
+}
+
 // handlePlayerLeaveGame handles the logic when a player leaves a game
 func handlePlayerLeaveGame(playerID uuid.UUID, roomCode string) error {
 	manager := game.GetManager()
@@ -205,6 +400,11 @@ func handlePlayerLeaveGame(playerID uuid.UUID, roomCode string) error {
 		return nil // Game doesn't exist, nothing to do
 	}
 
+	// Hang up voice before taking the game lock (LeaveVoice locks the game itself)
+	if err := manager.LeaveVoice(roomCode, playerID); err != nil {
+		logger.Warn("Failed to leave voice", "error", err, "player_id", playerID, "room_code", roomCode)
+	}
+
 	gameState.Lock()
 	defer gameState.Unlock()
 
@@ -220,7 +420,7 @@ func handlePlayerLeaveGame(playerID uuid.UUID, roomCode string) error {
 		})
 
 		// Send system message
-		manager.SendSystemMessage(roomCode, fmt.Sprintf("%s left the game", player.Name))
+		manager.SendSystemMessage(roomCode, i18n.Msg("{1} left the game", player.Name))
 
 		// If game hasn't started, remove player completely
 		if gameState.Status == models.GameStatusWaiting {
@@ -2,7 +2,8 @@
 // Components are organized into separate files for better maintainability:
 //
 //   - connection.go: WebSocket connection management and upgrade logic
-//   - handlers.go: Message routing and game action handlers
+//   - conn.go: game.Connection adapter for WebSocket connections
+//   - handlers.go: Message routing and game action handlers (shared with other transports)
 //   - auth.go: Authentication and token extraction
 //   - types.go: Message type definitions and payload structures
 //
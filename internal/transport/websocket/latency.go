@@ -0,0 +1,47 @@
+package websocket
+
+import (
+	"strconv"
+	"time"
+
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// pingInterval is how often connections are pinged to measure their round-trip time
+const pingInterval = 15 * time.Second
+
+// measureLatency pings the connection until done is closed. Each ping
+// carries the time it was sent, which the pong echoes back, so the round
+// trip is measured without keeping state per ping.
+func measureLatency(conn *websocket.Conn, playerID uuid.UUID, done <-chan struct{}) {
+	conn.SetPongHandler(func(data string) error {
+		sentAt, err := strconv.ParseInt(data, 10, 64)
+		if err != nil {
+			return nil // Not one of our pings
+		}
+		if rtt := time.Since(time.Unix(0, sentAt)); rtt >= 0 {
+			game.GetManager().RecordLatency(playerID, rtt)
+		}
+		return nil
+	})
+
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// WriteControl is safe alongside the serialized data writes
+				payload := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+				if err := conn.WriteControl(websocket.PingMessage, payload, time.Now().Add(time.Second)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+}
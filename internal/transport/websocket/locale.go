@@ -0,0 +1,36 @@
+package websocket
+
+import (
+	"net/http"
+
+	"dixitme/internal/i18n"
+	"dixitme/internal/logger"
+	"dixitme/internal/services/auth"
+)
+
+// NegotiateLocale picks the language of a new connection. An explicit
+// ?locale= wins and is saved on the caller's session; otherwise the session's
+// saved language is used, then the Accept-Language header.
+func NegotiateLocale(r *http.Request, userInfo *auth.UserInfo) string {
+	locale, explicit := i18n.FromRequest(r)
+	if userInfo == nil {
+		if locale == "" {
+			return i18n.DefaultLocale
+		}
+		return locale
+	}
+
+	if explicit {
+		if err := auth.SetSessionLocale(userInfo.SessionID, locale); err != nil {
+			logger.Warn("Failed to save session locale", "error", err, "session_id", userInfo.SessionID)
+		}
+		return locale
+	}
+	if saved := i18n.Normalize(auth.SessionLocale(userInfo.SessionID)); saved != "" {
+		return saved
+	}
+	if locale == "" {
+		return i18n.DefaultLocale
+	}
+	return locale
+}
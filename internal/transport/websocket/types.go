@@ -2,6 +2,10 @@ package websocket
 
 import (
 	"encoding/json"
+
+	"dixitme/internal/services/game"
+
+	"github.com/google/uuid"
 )
 
 // ConnectionMessage represents incoming WebSocket messages
@@ -12,27 +16,50 @@ type ConnectionMessage struct {
 
 // Message types from client
 const (
-	ClientMessageJoinGame       = "join_game"
-	ClientMessageCreateGame     = "create_game"
-	ClientMessageAddBot         = "add_bot"
-	ClientMessageStartGame      = "start_game"
-	ClientMessageSubmitClue     = "submit_clue"
-	ClientMessageSubmitCard     = "submit_card"
-	ClientMessageSubmitVote     = "submit_vote"
-	ClientMessageLeaveGame      = "leave_game"
-	ClientMessageSendChat       = "send_chat"
-	ClientMessageGetChatHistory = "get_chat_history"
+	ClientMessageJoinGame        = "join_game"
+	ClientMessageCreateGame      = "create_game"
+	ClientMessageAddBot          = "add_bot"
+	ClientMessageStartGame       = "start_game"
+	ClientMessageSubmitClue      = "submit_clue"
+	ClientMessageSubmitCard      = "submit_card"
+	ClientMessageSubmitVote      = "submit_vote"
+	ClientMessageLeaveGame       = "leave_game"
+	ClientMessageSendChat        = "send_chat"
+	ClientMessageGetChatHistory  = "get_chat_history"
+	ClientMessageModerateChat    = "moderate_chat"
+	ClientMessageReportPlayer    = "report_player"
+	ClientMessageUpdateSettings  = "update_settings"
+	ClientMessageVoiceJoin       = "voice_join"
+	ClientMessageVoiceLeave      = "voice_leave"
+	ClientMessageVoiceSignal     = "voice_signal"
+	ClientMessageVoiceState      = "voice_state"
+	ClientMessageMulligan        = "mulligan"
+	ClientMessageDebugStream     = "debug_stream"
+	ClientMessageStartKickVote   = "start_kick_vote"
+	ClientMessageCastKickVote    = "cast_kick_vote"
+	ClientMessageRequestTakeover = "request_bot_takeover"
+	ClientMessageResolveTakeover = "resolve_bot_takeover"
+	ClientMessageRematch         = "rematch"
 )
 
 // Payload structures for client messages
 type JoinGamePayload struct {
 	RoomCode   string `json:"room_code"`
 	PlayerName string `json:"player_name"`
+	Password   string `json:"password,omitempty"` // Needed for password-protected rooms
 }
 
 type CreateGamePayload struct {
 	RoomCode   string `json:"room_code"`
 	PlayerName string `json:"player_name"`
+	Sandbox    bool   `json:"sandbox,omitempty"`  // Practice room that is never persisted
+	Pace       string `json:"pace,omitempty"`     // blitz, standard or relaxed (standard when omitted)
+	Private    bool   `json:"private,omitempty"`  // Keep the room out of the lobby browser
+	Password   string `json:"password,omitempty"` // Password new players need to join; makes the room private
+
+	Rules  *game.GameRules `json:"rules,omitempty"`   // Table rules, the standard ones when omitted
+	Packs  []string        `json:"packs,omitempty"`   // Card pack slugs the deck is built from, the default packs when omitted
+	DeckID *uuid.UUID      `json:"deck_id,omitempty"` // Custom deck to play with instead of card packs
 }
 
 type AddBotPayload struct {
@@ -44,6 +71,25 @@ type StartGamePayload struct {
 	RoomCode string `json:"room_code"`
 }
 
+type MulliganPayload struct {
+	RoomCode string `json:"room_code"`
+}
+
+type RematchPayload struct {
+	RoomCode string `json:"room_code"` // The finished game
+}
+
+type ModerateChatPayload struct {
+	RoomCode   string              `json:"room_code"`
+	Moderation game.ChatModeration `json:"moderation"`
+}
+
+type ReportPlayerPayload struct {
+	RoomCode   string    `json:"room_code"`
+	ReportedID uuid.UUID `json:"reported_id"`
+	Reason     string    `json:"reason"`
+}
+
 type SubmitCluePayload struct {
 	RoomCode string `json:"room_code"`
 	Clue     string `json:"clue"`
@@ -56,8 +102,10 @@ type SubmitCardPayload struct {
 }
 
 type SubmitVotePayload struct {
-	RoomCode string `json:"room_code"`
-	CardID   int    `json:"card_id"`
+	RoomCode   string `json:"room_code"`
+	CardID     int    `json:"card_id"`
+	Weight     int    `json:"weight,omitempty"`      // weighted_votes experiment
+	DoubleDown bool   `json:"double_down,omitempty"` // double_down experiment
 }
 
 type LeaveGamePayload struct {
@@ -75,3 +123,51 @@ type GetChatHistoryPayload struct {
 	Phase    string `json:"phase,omitempty"` // lobby, voting, all
 	Limit    int    `json:"limit,omitempty"` // default 50
 }
+
+type DebugStreamPayload struct {
+	RoomCode string `json:"room_code"`
+	Watch    bool   `json:"watch"` // false stops the stream
+}
+
+type StartKickVotePayload struct {
+	RoomCode string    `json:"room_code"`
+	TargetID uuid.UUID `json:"target_id"`
+}
+
+type CastKickVotePayload struct {
+	RoomCode string `json:"room_code"`
+	Kick     bool   `json:"kick"` // true to vote the target out
+}
+
+type RequestBotTakeoverPayload struct {
+	RoomCode   string    `json:"room_code"`
+	BotID      uuid.UUID `json:"bot_id"`
+	PlayerName string    `json:"player_name"` // Returning players keep their name
+}
+
+type ResolveBotTakeoverPayload struct {
+	RoomCode string    `json:"room_code"`
+	BotID    uuid.UUID `json:"bot_id"`
+	Approve  bool      `json:"approve"`
+}
+
+type UpdateSettingsPayload struct {
+	RoomCode string            `json:"room_code"`
+	Settings game.GameSettings `json:"settings"`
+}
+
+type VoiceRoomPayload struct {
+	RoomCode string `json:"room_code"`
+}
+
+type VoiceSignalPayload struct {
+	RoomCode string    `json:"room_code"`
+	To       uuid.UUID `json:"to"`
+	game.VoiceSignal
+}
+
+type VoiceStatePayload struct {
+	RoomCode string `json:"room_code"`
+	Speaking bool   `json:"speaking"`
+	Muted    bool   `json:"muted"`
+}
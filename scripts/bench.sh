@@ -0,0 +1,25 @@
+#!/bin/bash
+
+# Compare game engine benchmarks against a baseline git ref (default: main).
+# Exits non-zero when a benchmark regressed by more than THRESHOLD percent.
+#
+#   ./scripts/bench.sh            # compare against main
+#   BASE=v1.2.0 THRESHOLD=15 ./scripts/bench.sh
+
+set -euo pipefail
+
+BASE=${BASE:-main}
+THRESHOLD=${THRESHOLD:-10}
+COUNT=${COUNT:-5}
+PACKAGES="./internal/services/game ./internal/services/bot"
+OUT=$(mktemp -d)
+trap 'git worktree remove --force "$OUT/base" >/dev/null 2>&1 || true; rm -rf "$OUT"' EXIT
+
+echo "📊 Running benchmarks on the working tree..."
+go test -run xxx -bench . -benchmem -count "$COUNT" $PACKAGES > "$OUT/new.txt"
+
+echo "📊 Running benchmarks on $BASE..."
+git worktree add --quiet --detach "$OUT/base" "$BASE"
+(cd "$OUT/base" && go test -run xxx -bench . -benchmem -count "$COUNT" $PACKAGES) > "$OUT/old.txt"
+
+go run ./scripts/benchcmp -threshold "$THRESHOLD" "$OUT/old.txt" "$OUT/new.txt"
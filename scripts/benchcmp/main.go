@@ -0,0 +1,163 @@
+// Command benchcmp compares two `go test -bench` outputs and fails when a
+// benchmark got slower than the allowed threshold.
+//
+//	go test -run xxx -bench . -benchmem ./internal/services/... > new.txt
+//	go run ./scripts/benchcmp -threshold 10 old.txt new.txt
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// result holds the averaged measurements of one benchmark
+type result struct {
+	nsPerOp     float64
+	allocsPerOp float64
+	runs        int
+}
+
+func main() {
+	threshold := flag.Float64("threshold", 10, "maximum allowed ns/op regression in percent")
+	allocs := flag.Bool("allocs", false, "also fail when allocs/op regress beyond the threshold")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: benchcmp [-threshold pct] [-allocs] old.txt new.txt")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	before, err := parseFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "benchcmp:", err)
+		os.Exit(2)
+	}
+	after, err := parseFile(flag.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "benchcmp:", err)
+		os.Exit(2)
+	}
+
+	names := make([]string, 0, len(after))
+	for name := range after {
+		if _, ok := before[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		fmt.Fprintln(os.Stderr, "benchcmp: no benchmarks in common")
+		os.Exit(2)
+	}
+
+	regressions := 0
+	fmt.Printf("%-50s %14s %14s %9s %11s\n", "benchmark", "old ns/op", "new ns/op", "delta", "allocs")
+	for _, name := range names {
+		old, cur := before[name], after[name]
+		delta := change(old.nsPerOp, cur.nsPerOp)
+		allocDelta := change(old.allocsPerOp, cur.allocsPerOp)
+
+		mark := ""
+		if delta > *threshold || (*allocs && allocDelta > *threshold) {
+			mark = "  REGRESSION"
+			regressions++
+		}
+		fmt.Printf("%-50s %14.1f %14.1f %+8.1f%% %+10.1f%%%s\n",
+			name, old.nsPerOp, cur.nsPerOp, delta, allocDelta, mark)
+	}
+
+	if regressions > 0 {
+		fmt.Printf("\n%d benchmark(s) regressed by more than %.1f%%\n", regressions, *threshold)
+		os.Exit(1)
+	}
+}
+
+// change returns the relative change from old to cur in percent
+func change(old, cur float64) float64 {
+	if old == 0 {
+		if cur == 0 {
+			return 0
+		}
+		return 100
+	}
+	return (cur - old) / old * 100
+}
+
+// parseFile reads benchmark lines from a `go test -bench` output, averaging
+// repeated runs (-count) of the same benchmark
+func parseFile(path string) (map[string]result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pkg := ""
+	results := make(map[string]result)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "pkg: ") {
+			pkg = strings.TrimPrefix(line, "pkg: ")
+			continue
+		}
+		name, ns, allocs, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+		if pkg != "" {
+			name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+		}
+
+		r := results[name]
+		r.nsPerOp = (r.nsPerOp*float64(r.runs) + ns) / float64(r.runs+1)
+		r.allocsPerOp = (r.allocsPerOp*float64(r.runs) + allocs) / float64(r.runs+1)
+		r.runs++
+		results[name] = r
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return results, nil
+}
+
+// parseLine extracts the name, ns/op and allocs/op of a benchmark result line
+// such as "BenchmarkDealCards-8  2000000  543.2 ns/op  574 B/op  1 allocs/op"
+func parseLine(line string) (string, float64, float64, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+		return "", 0, 0, false
+	}
+
+	name := fields[0]
+	// Drop the GOMAXPROCS suffix so runs from different machines line up
+	if i := strings.LastIndex(name, "-"); i > 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			name = name[:i]
+		}
+	}
+
+	var ns, allocs float64
+	found := false
+	for i := 2; i+1 < len(fields); i += 2 {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			continue
+		}
+		switch fields[i+1] {
+		case "ns/op":
+			ns, found = value, true
+		case "allocs/op":
+			allocs = value
+		}
+	}
+	return name, ns, allocs, found
+}